@@ -1,8 +1,12 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"log"
 	"log/slog"
+	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
@@ -13,7 +17,11 @@ import (
 	"pos-api/internal/handlers"
 	"pos-api/internal/listeners"
 	appLogger "pos-api/internal/logger"
+	"pos-api/internal/models"
 	"pos-api/internal/pkg/events"
+	"pos-api/internal/pkg/scale"
+	"pos-api/internal/pkg/scheduler"
+	"pos-api/internal/pkg/storage"
 	"pos-api/internal/repositories"
 	"pos-api/internal/routes"
 	"pos-api/internal/services"
@@ -59,7 +67,9 @@ func main() {
 	database.RunMigrations(database.DB)
 
 	// 3. Inisiasi Fiber App
-	app := fiber.New()
+	// Room for the largest attachment plus the multipart overhead
+	bodyLimit := max(4, cfg.AttachmentMaxSizeMB+1) * 1024 * 1024
+	app := fiber.New(fiber.Config{BodyLimit: bodyLimit})
 	app.Use(logger.New())
 
 	// CORS Middleware
@@ -77,13 +87,29 @@ func main() {
 
 	// --- PRODUCT Module ---
 	productRepo := repositories.NewProductRepository(database.DB)
-	productService := services.NewProductService(productRepo)
+	barcodeAllocator := services.NewBarcodeAllocator(productRepo, cfg.BarcodePrefixMin, cfg.BarcodePrefixMax)
+	scalePatterns, err := scale.ParsePatterns(cfg.ScaleBarcodePatterns)
+	if err != nil {
+		log.Fatalf("Invalid SCALE_BARCODE_PATTERNS: %v", err)
+	}
+	for _, p := range scalePatterns {
+		for prefix := cfg.BarcodePrefixMin; prefix <= cfg.BarcodePrefixMax; prefix++ {
+			internal := fmt.Sprintf("%02d", prefix)
+			if strings.HasPrefix(internal, p.Prefix()) || strings.HasPrefix(p.Prefix(), internal) {
+				slog.Warn("Scale barcode pattern overlaps the internal barcode prefix range", "pattern", p.Mask)
+				break
+			}
+		}
+	}
+	barcodeScanner := services.NewBarcodeScanner(productRepo, scale.NewParser(scalePatterns))
+	productService := services.NewProductService(productRepo, barcodeAllocator, barcodeScanner)
 	productHandler := handlers.NewProductHandler(productService)
 
 	// --- INITIALIZE EVENT BUS ---
 	eventBus := events.NewMemoryEventBus()
-	eventBus.Subscribe(events.EventTransactionCreated, listeners.HandleCashFlowOnTransaction)
+	// Inventory first: it sets the cost of goods sold the ledger posts
 	eventBus.Subscribe(events.EventTransactionCreated, listeners.HandleInventoryOnTransaction)
+	eventBus.Subscribe(events.EventTransactionCreated, listeners.HandleCashFlowOnTransaction)
 
 	eventBus.Subscribe(events.EventTransactionReturned, listeners.HandleCashFlowOnTransactionReverted)
 	eventBus.Subscribe(events.EventTransactionReturned, listeners.HandleInventoryOnTransactionReverted)
@@ -92,10 +118,26 @@ func main() {
 	eventBus.Subscribe(events.EventTransactionCancelled, listeners.HandleInventoryOnTransactionReverted)
 
 	eventBus.Subscribe(events.EventInventoryAdjusted, listeners.HandleCashFlowOnInventoryAdjusted)
+	eventBus.Subscribe(events.EventInventoryAdjusted, listeners.HandlePurchaseOrderOnInventoryAdjusted)
+
+	eventBus.Subscribe(events.EventBudgetThresholdCrossed, listeners.HandleBudgetAlert)
+
+	// --- LOCATION Module ---
+	locationRepo := repositories.NewLocationRepository(database.DB)
+	locationService := services.NewLocationService(locationRepo)
+	locationHandler := handlers.NewLocationHandler(locationService)
+
+	// --- STORE SETTINGS & CURRENCY Module ---
+	storeSettingRepo := repositories.NewStoreSettingRepository(database.DB)
+	currencyRepo := repositories.NewCurrencyRepository(database.DB)
+	storeSettingService := services.NewStoreSettingService(storeSettingRepo, currencyRepo)
+	storeSettingHandler := handlers.NewStoreSettingHandler(storeSettingService)
+	currencyService := services.NewCurrencyService(currencyRepo, storeSettingRepo)
+	currencyHandler := handlers.NewCurrencyHandler(currencyService)
 
 	// --- TRANSACTION Module ---
 	transactionRepo := repositories.NewTransactionRepository(database.DB, eventBus)
-	transactionService := services.NewTransactionService(transactionRepo, productRepo)
+	transactionService := services.NewTransactionService(transactionRepo, productRepo, locationRepo, barcodeScanner, currencyService)
 	transactionHandler := handlers.NewTransactionHandler(transactionService)
 
 	// --- CATEGORY Module ---
@@ -103,25 +145,53 @@ func main() {
 	categoryService := services.NewCategoryService(categoryRepo)
 	categoryHandler := handlers.NewCategoryHandler(categoryService)
 
+	// --- ATTACHMENT Storage ---
+	var files storage.Storage
+	switch cfg.StorageDriver {
+	case "local":
+		files, err = storage.NewLocalStorage(cfg.StorageDir)
+	case "s3":
+		files, err = storage.NewS3Storage(storage.S3Config{
+			Endpoint:  cfg.S3Endpoint,
+			Region:    cfg.S3Region,
+			Bucket:    cfg.S3Bucket,
+			AccessKey: cfg.S3AccessKey,
+			SecretKey: cfg.S3SecretKey,
+			PathStyle: cfg.S3PathStyle,
+		})
+	default:
+		err = fmt.Errorf("unknown driver %q, use local or s3", cfg.StorageDriver)
+	}
+	if err != nil {
+		log.Fatalf("Invalid attachment storage: %v", err)
+	}
+	attachmentRepo := repositories.NewAttachmentRepository(database.DB)
+	attachmentService := services.NewAttachmentService(attachmentRepo, files, int64(cfg.AttachmentMaxSizeMB)*1024*1024)
+
 	// --- CASH FLOW Module ---
 	cashFlowRepo := repositories.NewCashFlowRepository(database.DB)
-	cashFlowService := services.NewCashFlowService(cashFlowRepo)
+	cashFlowCategoryRepo := repositories.NewCashFlowCategoryRepository(database.DB)
+	cashFlowService := services.NewCashFlowService(cashFlowRepo, cashFlowCategoryRepo, files)
 	cashFlowHandler := handlers.NewCashFlowHandler(cashFlowService)
+	cashFlowAttachmentHandler := handlers.NewAttachmentHandler(attachmentService, models.AttachmentOwnerCashFlow)
+	recurringExpenseRepo := repositories.NewRecurringExpenseRepository(database.DB)
+	recurringExpenseService := services.NewRecurringExpenseService(recurringExpenseRepo, cashFlowCategoryRepo)
+	recurringExpenseHandler := handlers.NewRecurringExpenseHandler(recurringExpenseService)
+	budgetRepo := repositories.NewBudgetRepository(database.DB)
+	budgetService := services.NewBudgetService(budgetRepo, cashFlowCategoryRepo, cashFlowRepo, eventBus, float64(cfg.BudgetAlertPercent))
+	budgetHandler := handlers.NewBudgetHandler(budgetService)
 
 	// --- DASHBOARD Module ---
 	dashboardRepo := repositories.NewDashboardRepository(database.DB)
-	dashboardService := services.NewDashboardService(dashboardRepo, cashFlowRepo)
+	dashboardService := services.NewDashboardService(dashboardRepo, cashFlowRepo, budgetService)
 	dashboardHandler := handlers.NewDashboardHandler(dashboardService)
 
 	// --- REPORT Module ---
 	reportRepo := repositories.NewReportRepository(database.DB)
 	reportService := services.NewReportService(reportRepo)
 	reportHandler := handlers.NewReportHandler(reportService)
-
-	// --- STORE SETTINGS Module ---
-	storeSettingRepo := repositories.NewStoreSettingRepository(database.DB)
-	storeSettingService := services.NewStoreSettingService(storeSettingRepo)
-	storeSettingHandler := handlers.NewStoreSettingHandler(storeSettingService)
+	forecastService := services.NewForecastService(reportRepo)
+	forecastHandler := handlers.NewForecastHandler(forecastService)
 
 	// --- EXPORT Module ---
 	exportHandler := handlers.NewExportHandler(productService, transactionService)
@@ -131,14 +201,72 @@ func main() {
 
 	// --- INVENTORY LOG Module ---
 	inventoryLogRepo := repositories.NewInventoryLogRepository(database.DB, eventBus)
-	inventoryLogService := services.NewInventoryLogService(inventoryLogRepo, productRepo)
+	inventoryLogService := services.NewInventoryLogService(inventoryLogRepo, productRepo, locationRepo)
 	inventoryLogHandler := handlers.NewInventoryLogHandler(inventoryLogService)
+	inventoryLogAttachmentHandler := handlers.NewAttachmentHandler(attachmentService, models.AttachmentOwnerInventoryLog)
 
 	// --- PAYMENT METHOD Module ---
 	paymentMethodRepo := repositories.NewPaymentMethodRepository(database.DB)
 	paymentMethodService := services.NewPaymentMethodService(paymentMethodRepo)
 	paymentMethodHandler := handlers.NewPaymentMethodHandler(paymentMethodService)
 
+	// --- SUPPLIER & PURCHASE ORDER Module ---
+	supplierRepo := repositories.NewSupplierRepository(database.DB)
+	supplierService := services.NewSupplierService(supplierRepo)
+	supplierHandler := handlers.NewSupplierHandler(supplierService)
+
+	purchaseOrderRepo := repositories.NewPurchaseOrderRepository(database.DB)
+	purchaseOrderService := services.NewPurchaseOrderService(purchaseOrderRepo, supplierRepo, productRepo, inventoryLogService)
+	purchaseOrderHandler := handlers.NewPurchaseOrderHandler(purchaseOrderService)
+
+	supplierPayableRepo := repositories.NewSupplierPayableRepository(database.DB)
+	supplierPayableService := services.NewSupplierPayableService(supplierPayableRepo)
+	supplierPayableHandler := handlers.NewSupplierPayableHandler(supplierPayableService)
+
+	// --- STOCK OPNAME Module ---
+	stockOpnameRepo := repositories.NewStockOpnameRepository(database.DB, eventBus)
+	stockOpnameService := services.NewStockOpnameService(stockOpnameRepo, barcodeScanner)
+	stockOpnameHandler := handlers.NewStockOpnameHandler(stockOpnameService)
+
+	// --- STOCK TRANSFER Module ---
+	stockTransferRepo := repositories.NewStockTransferRepository(database.DB)
+	stockTransferService := services.NewStockTransferService(stockTransferRepo, locationRepo, productRepo)
+	stockTransferHandler := handlers.NewStockTransferHandler(stockTransferService)
+
+	// --- PRODUCT LOT Module ---
+	productLotRepo := repositories.NewProductLotRepository(database.DB, eventBus)
+	productLotService := services.NewProductLotService(productLotRepo)
+	productLotHandler := handlers.NewProductLotHandler(productLotService)
+
+	// --- PRODUCT SERIAL Module ---
+	productSerialRepo := repositories.NewProductSerialRepository(database.DB)
+	productSerialService := services.NewProductSerialService(productSerialRepo, productRepo, locationRepo)
+	productSerialHandler := handlers.NewProductSerialHandler(productSerialService)
+
+	// --- STOCK RESERVATION Module ---
+	stockReservationRepo := repositories.NewStockReservationRepository(database.DB)
+	stockReservationService := services.NewStockReservationService(stockReservationRepo, locationRepo, productRepo)
+	stockReservationHandler := handlers.NewStockReservationHandler(stockReservationService)
+
+	// --- INVENTORY INTEGRITY Module ---
+	inventoryIntegrityRepo := repositories.NewInventoryIntegrityRepository(database.DB)
+	inventoryIntegrityService := services.NewInventoryIntegrityService(inventoryIntegrityRepo)
+	inventoryIntegrityHandler := handlers.NewInventoryIntegrityHandler(inventoryIntegrityService)
+
+	// --- ACCOUNTING Module ---
+	accountingRepo := repositories.NewAccountingRepository(database.DB)
+	accountingService := services.NewAccountingService(accountingRepo)
+	accountingHandler := handlers.NewAccountingHandler(accountingService)
+	accountingPeriodRepo := repositories.NewAccountingPeriodRepository(database.DB)
+	accountingPeriodService := services.NewAccountingPeriodService(accountingPeriodRepo)
+	accountingPeriodHandler := handlers.NewAccountingPeriodHandler(accountingPeriodService)
+	reconciliationRepo := repositories.NewReconciliationRepository(database.DB)
+	reconciliationService := services.NewReconciliationService(reconciliationRepo, paymentMethodRepo)
+	reconciliationHandler := handlers.NewReconciliationHandler(reconciliationService)
+	journalExportRepo := repositories.NewJournalExportRepository(database.DB)
+	journalExportService := services.NewJournalExportService(journalExportRepo)
+	journalExportHandler := handlers.NewJournalExportHandler(journalExportService)
+
 	// 5. Definisi Route
 	// Health Check
 	app.Get("/", func(c *fiber.Ctx) error {
@@ -172,9 +300,45 @@ func main() {
 		inventoryLogHandler,
 		cashFlowHandler,
 		paymentMethodHandler,
+		supplierHandler,
+		purchaseOrderHandler,
+		supplierPayableHandler,
+		stockOpnameHandler,
+		locationHandler,
+		stockTransferHandler,
+		productLotHandler,
+		productSerialHandler,
+		forecastHandler,
+		stockReservationHandler,
+		inventoryIntegrityHandler,
+		accountingHandler,
+		recurringExpenseHandler,
+		cashFlowAttachmentHandler,
+		inventoryLogAttachmentHandler,
+		accountingPeriodHandler,
+		reconciliationHandler,
+		budgetHandler,
+		journalExportHandler,
+		currencyHandler,
 	)
 
-	// 6. Jalankan Server
+	// 6. Background jobs
+	if cfg.RecurringExpenseIntervalMinutes > 0 {
+		interval := time.Duration(cfg.RecurringExpenseIntervalMinutes) * time.Minute
+		scheduler.Every(context.Background(), "recurring-expenses", interval, func(ctx context.Context) error {
+			_, err := recurringExpenseService.RunDue(ctx, time.Now())
+			return err
+		})
+	}
+	if cfg.BudgetAlertIntervalMinutes > 0 {
+		interval := time.Duration(cfg.BudgetAlertIntervalMinutes) * time.Minute
+		scheduler.Every(context.Background(), "budget-alerts", interval, func(ctx context.Context) error {
+			_, err := budgetService.CheckAlerts(ctx, time.Now())
+			return err
+		})
+	}
+
+	// 7. Jalankan Server
 	slog.Info("Starting server on port " + cfg.AppPort)
 	log.Fatal(app.Listen(":" + cfg.AppPort))
 }
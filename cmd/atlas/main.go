@@ -19,9 +19,45 @@ func main() {
 		&models.Transaction{},
 		&models.TransactionDetail{},
 		&models.InventoryLog{},
+		&models.CashFlowCategory{},
 		&models.CashFlow{},
+		&models.RecurringExpense{},
+		&models.Attachment{},
+		&models.AccountingPeriod{},
+		&models.AccountingPeriodLog{},
+		&models.BankStatement{},
+		&models.BankStatementLine{},
+		&models.Budget{},
+		&models.JournalExportTemplate{},
+		&models.JournalExport{},
+		&models.JournalExportEntry{},
+		&models.Currency{},
+		&models.ExchangeRate{},
+		&models.TransactionPayment{},
 		&models.PaymentMethod{},
 		&models.StoreSetting{},
+		&models.Supplier{},
+		&models.PurchaseOrder{},
+		&models.PurchaseOrderItem{},
+		&models.SupplierPayable{},
+		&models.SupplierPayment{},
+		&models.StockOpname{},
+		&models.StockOpnameItem{},
+		&models.StockOpnameCount{},
+		&models.Location{},
+		&models.ProductStock{},
+		&models.Register{},
+		&models.StockTransfer{},
+		&models.StockTransferItem{},
+		&models.ProductLot{},
+		&models.InventoryLogLot{},
+		&models.ProductSerial{},
+		&models.StockReservation{},
+		&models.StockReservationItem{},
+		&models.CostLayer{},
+		&models.Account{},
+		&models.JournalEntry{},
+		&models.JournalLine{},
 	)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "failed to load schema: %v\n", err)
@@ -0,0 +1,76 @@
+// Command barcodes backfills valid internal EAN-13 barcodes for legacy products.
+//
+// Products whose barcode is empty, or numeric but not a valid EAN-8/UPC-A/EAN-13
+// (such as the "8<unix micro>" codes produced by the old update_barcodes.py script),
+// get a new code from the same allocator used by the API.
+//
+// Usage:
+//
+//	go run ./cmd/barcodes            # apply changes
+//	go run ./cmd/barcodes -dry-run   # only list affected products
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+
+	"pos-api/internal/config"
+	"pos-api/internal/models"
+	"pos-api/internal/pkg/gtin"
+	"pos-api/internal/repositories"
+	"pos-api/internal/services"
+	"pos-api/pkg/database"
+)
+
+func main() {
+	dryRun := flag.Bool("dry-run", false, "List products that would get a new barcode without updating them")
+	flag.Parse()
+
+	cfg := config.LoadConfig()
+	database.ConnectDB(cfg)
+	db := database.DB
+
+	ctx := context.Background()
+	allocator := services.NewBarcodeAllocator(repositories.NewProductRepository(db), cfg.BarcodePrefixMin, cfg.BarcodePrefixMax)
+
+	var products []models.Product
+	if err := db.Unscoped().Order("id ASC").Find(&products).Error; err != nil {
+		log.Fatalf("Failed to load products: %v", err)
+	}
+
+	updated := 0
+	for _, p := range products {
+		if !needsBackfill(p.Barcode) {
+			continue
+		}
+
+		if *dryRun {
+			log.Printf("[dry-run] Product %d (%s): barcode %q would be replaced", p.ID, p.Name, p.Barcode)
+			updated++
+			continue
+		}
+
+		code, err := allocator.Allocate(ctx)
+		if err != nil {
+			log.Fatalf("Failed to allocate barcode for product %d: %v", p.ID, err)
+		}
+
+		if err := db.Unscoped().Model(&models.Product{}).Where("id = ?", p.ID).Update("barcode", code).Error; err != nil {
+			log.Fatalf("Failed to update product %d: %v", p.ID, err)
+		}
+		log.Printf("Product %d (%s): %q -> %s", p.ID, p.Name, p.Barcode, code)
+		updated++
+	}
+
+	log.Printf("Barcode backfill completed: %d of %d products affected.", updated, len(products))
+}
+
+// needsBackfill reports whether a stored barcode should be replaced. Non-numeric
+// codes are left alone since they were entered deliberately.
+func needsBackfill(barcode string) bool {
+	if barcode == "" {
+		return true
+	}
+	return gtin.IsNumeric(barcode) && !gtin.Valid(barcode)
+}
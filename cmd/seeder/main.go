@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"math/rand"
@@ -8,6 +9,8 @@ import (
 
 	"pos-api/internal/config"
 	"pos-api/internal/models"
+	"pos-api/internal/repositories"
+	"pos-api/internal/services"
 	"pos-api/pkg/database"
 
 	"golang.org/x/crypto/bcrypt"
@@ -28,10 +31,11 @@ func main() {
 	seedUsers(db)
 	seedPaymentMethods(db)
 	categories := seedCategories(db)
-	products := seedProducts(db, categories)
-	seedInventory(db, products)
+	location := seedLocations(db)
+	products := seedProducts(db, cfg, categories)
+	seedInventory(db, products, location)
 	seedCashFlow(db)
-	seedTransactions(db, products)
+	seedTransactions(db, products, location)
 
 	log.Println("✅ Database Seeding Completed Successfully!")
 }
@@ -133,7 +137,7 @@ func seedPaymentMethods(db *gorm.DB) {
 	}
 }
 
-func seedProducts(db *gorm.DB, categories []models.Category) []models.Product {
+func seedProducts(db *gorm.DB, cfg *config.Config, categories []models.Category) []models.Product {
 	// Helper to find category ID by name
 	getCatID := func(name string) uint {
 		for _, c := range categories {
@@ -144,39 +148,38 @@ func seedProducts(db *gorm.DB, categories []models.Category) []models.Product {
 		return categories[0].ID // Fallback
 	}
 
-	getBarcode := func(idx int) string {
-		return fmt.Sprintf("8%d%04d", time.Now().UnixMicro(), idx)
-	}
+	// Barcodes come from the same internal EAN-13 allocator the API uses
+	allocator := services.NewBarcodeAllocator(repositories.NewProductRepository(db), cfg.BarcodePrefixMin, cfg.BarcodePrefixMax)
 
 	products := []models.Product{
 		// Makanan & Minuman
-		{Name: "Nasi Goreng Spesial", SKU: "FOOD-001", Barcode: getBarcode(1), Price: 25000, Cost: 15000, Stock: 100, CategoryID: getCatID("Makanan")},
-		{Name: "Ayam Bakar Madu", SKU: "FOOD-002", Barcode: getBarcode(2), Price: 30000, Cost: 18000, Stock: 80, CategoryID: getCatID("Makanan")},
-		{Name: "Sate Ayam Madura", SKU: "FOOD-003", Barcode: getBarcode(3), Price: 22000, Cost: 12000, Stock: 50, CategoryID: getCatID("Makanan")},
-		{Name: "Mie Goreng Jawa", SKU: "FOOD-004", Barcode: getBarcode(4), Price: 20000, Cost: 10000, Stock: 0, CategoryID: getCatID("Makanan")}, // Out of stock
-		{Name: "Es Teh Manis", SKU: "DRINK-001", Barcode: getBarcode(5), Price: 5000, Cost: 2000, Stock: 200, CategoryID: getCatID("Minuman")},
-		{Name: "Kopi Susu Gula Aren", SKU: "DRINK-002", Barcode: getBarcode(6), Price: 18000, Cost: 8000, Stock: 150, CategoryID: getCatID("Minuman")},
-		{Name: "Jus Jeruk Segar", SKU: "DRINK-003", Barcode: getBarcode(7), Price: 15000, Cost: 7000, Stock: 5, CategoryID: getCatID("Minuman")}, // Low stock
-		{Name: "Air Mineral 600ml", SKU: "DRINK-004", Barcode: getBarcode(8), Price: 5000, Cost: 2500, Stock: 300, CategoryID: getCatID("Minuman")},
+		{Name: "Nasi Goreng Spesial", SKU: "FOOD-001", Price: 25000, Cost: 15000, Stock: 100, CategoryID: getCatID("Makanan")},
+		{Name: "Ayam Bakar Madu", SKU: "FOOD-002", Price: 30000, Cost: 18000, Stock: 80, CategoryID: getCatID("Makanan")},
+		{Name: "Sate Ayam Madura", SKU: "FOOD-003", Price: 22000, Cost: 12000, Stock: 50, CategoryID: getCatID("Makanan")},
+		{Name: "Mie Goreng Jawa", SKU: "FOOD-004", Price: 20000, Cost: 10000, Stock: 0, CategoryID: getCatID("Makanan")}, // Out of stock
+		{Name: "Es Teh Manis", SKU: "DRINK-001", Price: 5000, Cost: 2000, Stock: 200, CategoryID: getCatID("Minuman")},
+		{Name: "Kopi Susu Gula Aren", SKU: "DRINK-002", Price: 18000, Cost: 8000, Stock: 150, CategoryID: getCatID("Minuman")},
+		{Name: "Jus Jeruk Segar", SKU: "DRINK-003", Price: 15000, Cost: 7000, Stock: 5, CategoryID: getCatID("Minuman")}, // Low stock
+		{Name: "Air Mineral 600ml", SKU: "DRINK-004", Price: 5000, Cost: 2500, Stock: 300, CategoryID: getCatID("Minuman")},
 
 		// Snack
-		{Name: "Keripik Singkong", SKU: "SNACK-001", Barcode: getBarcode(9), Price: 10000, Cost: 5000, Stock: 50, CategoryID: getCatID("Snack")},
-		{Name: "Chitato Lite", SKU: "SNACK-002", Barcode: getBarcode(10), Price: 12000, Cost: 9000, Stock: 40, CategoryID: getCatID("Snack")},
-		{Name: "Oreo Original", SKU: "SNACK-003", Barcode: getBarcode(11), Price: 8000, Cost: 5000, Stock: 2, CategoryID: getCatID("Snack")}, // Low stock
-		{Name: "Silverqueen Chunky", SKU: "SNACK-004", Barcode: getBarcode(12), Price: 25000, Cost: 18000, Stock: 60, CategoryID: getCatID("Snack")},
+		{Name: "Keripik Singkong", SKU: "SNACK-001", Price: 10000, Cost: 5000, Stock: 50, CategoryID: getCatID("Snack")},
+		{Name: "Chitato Lite", SKU: "SNACK-002", Price: 12000, Cost: 9000, Stock: 40, CategoryID: getCatID("Snack")},
+		{Name: "Oreo Original", SKU: "SNACK-003", Price: 8000, Cost: 5000, Stock: 2, CategoryID: getCatID("Snack")}, // Low stock
+		{Name: "Silverqueen Chunky", SKU: "SNACK-004", Price: 25000, Cost: 18000, Stock: 60, CategoryID: getCatID("Snack")},
 
 		// Sembako
-		{Name: "Beras 5kg", SKU: "SEMBAKO-001", Barcode: getBarcode(13), Price: 70000, Cost: 60000, Stock: 20, CategoryID: getCatID("Sembako")},
-		{Name: "Minyak Goreng 2L", SKU: "SEMBAKO-002", Barcode: getBarcode(14), Price: 35000, Cost: 30000, Stock: 30, CategoryID: getCatID("Sembako")},
-		{Name: "Gula Pasir 1kg", SKU: "SEMBAKO-003", Barcode: getBarcode(15), Price: 16000, Cost: 13000, Stock: 0, CategoryID: getCatID("Sembako")}, // Out of stock
-		{Name: "Telur Ayam 1kg", SKU: "SEMBAKO-004", Barcode: getBarcode(16), Price: 28000, Cost: 24000, Stock: 15, CategoryID: getCatID("Sembako")},
+		{Name: "Beras 5kg", SKU: "SEMBAKO-001", Price: 70000, Cost: 60000, Stock: 20, CategoryID: getCatID("Sembako")},
+		{Name: "Minyak Goreng 2L", SKU: "SEMBAKO-002", Price: 35000, Cost: 30000, Stock: 30, CategoryID: getCatID("Sembako")},
+		{Name: "Gula Pasir 1kg", SKU: "SEMBAKO-003", Price: 16000, Cost: 13000, Stock: 0, CategoryID: getCatID("Sembako")}, // Out of stock
+		{Name: "Telur Ayam 1kg", SKU: "SEMBAKO-004", Price: 28000, Cost: 24000, Stock: 15, CategoryID: getCatID("Sembako")},
 
 		// Elektronik & Aksesoris HP
-		{Name: "Kabel Data Type-C", SKU: "ELEC-001", Barcode: getBarcode(17), Price: 25000, Cost: 10000, Stock: 50, CategoryID: getCatID("Aksesoris HP")},
-		{Name: "Charger Samsung 25W", SKU: "ELEC-002", Barcode: getBarcode(18), Price: 150000, Cost: 100000, Stock: 10, CategoryID: getCatID("Aksesoris HP")},
-		{Name: "Powerbank 10000mAh", SKU: "ELEC-003", Barcode: getBarcode(19), Price: 200000, Cost: 150000, Stock: 5, CategoryID: getCatID("Aksesoris HP")}, // Low stock
-		{Name: "Earphone Bluetooth", SKU: "ELEC-004", Barcode: getBarcode(20), Price: 120000, Cost: 80000, Stock: 25, CategoryID: getCatID("Elektronik")},
-		{Name: "Mouse Wireless", SKU: "ELEC-005", Barcode: getBarcode(21), Price: 85000, Cost: 50000, Stock: 30, CategoryID: getCatID("Elektronik")},
+		{Name: "Kabel Data Type-C", SKU: "ELEC-001", Price: 25000, Cost: 10000, Stock: 50, CategoryID: getCatID("Aksesoris HP")},
+		{Name: "Charger Samsung 25W", SKU: "ELEC-002", Price: 150000, Cost: 100000, Stock: 10, CategoryID: getCatID("Aksesoris HP")},
+		{Name: "Powerbank 10000mAh", SKU: "ELEC-003", Price: 200000, Cost: 150000, Stock: 5, CategoryID: getCatID("Aksesoris HP")}, // Low stock
+		{Name: "Earphone Bluetooth", SKU: "ELEC-004", Price: 120000, Cost: 80000, Stock: 25, CategoryID: getCatID("Elektronik")},
+		{Name: "Mouse Wireless", SKU: "ELEC-005", Price: 85000, Cost: 50000, Stock: 30, CategoryID: getCatID("Elektronik")},
 
 		// Alat Tulis
 		{Name: "Pulpen Pilot", SKU: "ATK-001", Price: 3000, Cost: 1500, Stock: 200, CategoryID: getCatID("Alat Tulis")},
@@ -223,6 +226,10 @@ func seedProducts(db *gorm.DB, categories []models.Category) []models.Product {
 		{Name: "Wajan Teflon 24cm", SKU: "HOME-005", Price: 85000, Cost: 60000, Stock: 12, CategoryID: getCatID("Dapur")},
 		{Name: "Sabun Cuci Piring", SKU: "HOME-006", Price: 15000, Cost: 10000, Stock: 40, CategoryID: getCatID("Perabotan")},
 		{Name: "Minyak Kayu Putih 60ml", SKU: "HEALTH-005", Price: 18000, Cost: 12000, Stock: 25, CategoryID: getCatID("Kesehatan")},
+
+		// Produk timbangan (harga & stok per kg, PLU dicetak di label timbangan)
+		{Name: "Daging Sapi Has Dalam", SKU: "FRESH-001", PLU: "101", SoldByWeight: true, Price: 150000, Cost: 120000, Stock: 12.5, CategoryID: getCatID("Makanan")},
+		{Name: "Apel Fuji", SKU: "FRESH-002", PLU: "102", SoldByWeight: true, Price: 45000, Cost: 32000, Stock: 20.75, CategoryID: getCatID("Makanan")},
 	}
 
 	var seededProducts []models.Product
@@ -234,6 +241,12 @@ func seedProducts(db *gorm.DB, categories []models.Category) []models.Product {
 			continue
 		}
 
+		barcode, err := allocator.Allocate(context.Background())
+		if err != nil {
+			log.Fatalf("Failed to allocate barcode for %s: %v", p.Name, err)
+		}
+		p.Barcode = barcode
+
 		if err := db.Create(&p).Error; err != nil {
 			log.Fatalf("Failed to seed product %s: %v", p.Name, err)
 		}
@@ -243,7 +256,24 @@ func seedProducts(db *gorm.DB, categories []models.Category) []models.Product {
 	return seededProducts
 }
 
-func seedInventory(db *gorm.DB, products []models.Product) {
+// seedLocations returns the default location (created by the migrations),
+// and adds it when the schema was created without them.
+func seedLocations(db *gorm.DB) models.Location {
+	var location models.Location
+	if err := db.Where("is_default = ?", true).First(&location).Error; err == nil {
+		log.Printf("Default location %s already exists.", location.Code)
+		return location
+	}
+
+	location = models.Location{Code: "MAIN", Name: "Toko Utama", Type: "store", IsDefault: true, IsActive: true}
+	if err := db.Create(&location).Error; err != nil {
+		log.Fatalf("Failed to seed default location: %v", err)
+	}
+	log.Println("Default location seeded.")
+	return location
+}
+
+func seedInventory(db *gorm.DB, products []models.Product, location models.Location) {
 	// Assuming first user is admin/system
 	var user models.User
 	db.First(&user)
@@ -257,13 +287,21 @@ func seedInventory(db *gorm.DB, products []models.Product) {
 			continue
 		}
 
+		// Initial stock is kept at the default location
+		level := models.ProductStock{ProductID: p.ID, LocationID: location.ID, Quantity: p.Stock}
+		if err := db.Where("product_id = ? AND location_id = ?", p.ID, location.ID).FirstOrCreate(&level).Error; err != nil {
+			log.Printf("Failed to seed stock level for %s: %v", p.Name, err)
+			continue
+		}
+
 		logEntry := models.InventoryLog{
 			ProductID:   p.ID,
+			LocationID:  location.ID,
 			Type:        "in",
 			Source:      "purchase",
 			Quantity:    p.Stock, // Initial stock from product definition
 			CostPrice:   p.Cost,
-			TotalCost:   float64(p.Stock) * p.Cost,
+			TotalCost:   p.Stock * p.Cost,
 			StockBefore: 0,
 			StockAfter:  p.Stock,
 			Notes:       "Initial Stock Seed",
@@ -273,8 +311,14 @@ func seedInventory(db *gorm.DB, products []models.Product) {
 
 		if err := db.Create(&logEntry).Error; err != nil {
 			log.Printf("Failed to seed inventory for %s: %v", p.Name, err)
-		} else {
-			log.Printf("Inventory log seeded for %s.", p.Name)
+			continue
+		}
+		log.Printf("Inventory log seeded for %s.", p.Name)
+
+		// The initial stock is the first cost layer for FIFO costing
+		layer := models.CostLayer{ProductID: p.ID, InventoryLogID: &logEntry.ID, UnitCost: p.Cost, Quantity: p.Stock, Remaining: p.Stock}
+		if err := db.Create(&layer).Error; err != nil {
+			log.Printf("Failed to seed cost layer for %s: %v", p.Name, err)
 		}
 	}
 	log.Println("Inventory logs check completed.")
@@ -299,16 +343,18 @@ func seedCashFlow(db *gorm.DB) {
 		{Type: "expense", Source: "gaji_karyawan", Amount: 3000000, Notes: "Gaji Kasir Utama", Date: time.Now().Add(-3 * 24 * time.Hour)},
 	}
 
+	// Through the repository so each entry is posted to the ledger
+	cashFlowRepo := repositories.NewCashFlowRepository(db)
 	for _, f := range flows {
 		f.UserID = user.ID
-		if err := db.Create(&f).Error; err != nil {
+		if err := cashFlowRepo.Create(context.Background(), &f); err != nil {
 			log.Printf("Failed to seed cash flow: %v", err)
 		}
 	}
 	log.Println("Cash Flow seeded.")
 }
 
-func seedTransactions(db *gorm.DB, products []models.Product) {
+func seedTransactions(db *gorm.DB, products []models.Product, location models.Location) {
 	var count int64
 	db.Model(&models.Transaction{}).Count(&count)
 	if count > 0 {
@@ -334,8 +380,8 @@ func seedTransactions(db *gorm.DB, products []models.Product) {
 
 		for j := 0; j < numItems; j++ {
 			prod := products[r.Intn(len(products))]
-			qty := r.Intn(3) + 1
-			subTotal := float64(qty) * prod.Price
+			qty := float64(r.Intn(3) + 1)
+			subTotal := qty * prod.Price
 
 			details = append(details, models.TransactionDetail{
 				ProductID:   prod.ID,
@@ -358,6 +404,7 @@ func seedTransactions(db *gorm.DB, products []models.Product) {
 			Cash:               totalAmount, // Assume exact payment for simplicity
 			Change:             0,
 			PaymentMethod:      paymentMethod,
+			LocationID:         location.ID,
 			TransactionDetails: details,
 			CreatedAt:          txDate,
 		}
@@ -0,0 +1 @@
+DROP SEQUENCE IF EXISTS product_barcode_seq;
@@ -0,0 +1,2 @@
+-- Sequence backing the internal EAN-13 barcode allocator (in-store prefix range)
+CREATE SEQUENCE IF NOT EXISTS product_barcode_seq START WITH 1 INCREMENT BY 1;
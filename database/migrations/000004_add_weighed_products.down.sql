@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS products_plu_key;
+ALTER TABLE products DROP COLUMN IF EXISTS sold_by_weight;
+ALTER TABLE products DROP COLUMN IF EXISTS plu;
+
+ALTER TABLE inventory_logs ALTER COLUMN stock_after TYPE bigint USING ROUND(stock_after);
+ALTER TABLE inventory_logs ALTER COLUMN stock_before TYPE bigint USING ROUND(stock_before);
+ALTER TABLE inventory_logs ALTER COLUMN quantity TYPE bigint USING ROUND(quantity);
+ALTER TABLE transaction_details ALTER COLUMN quantity TYPE bigint USING ROUND(quantity);
+ALTER TABLE products ALTER COLUMN stock TYPE bigint USING ROUND(stock);
@@ -0,0 +1,11 @@
+-- Decimal quantities for products sold by weight (kg, 3 decimals = grams)
+ALTER TABLE products ALTER COLUMN stock TYPE numeric(14,3);
+ALTER TABLE transaction_details ALTER COLUMN quantity TYPE numeric(14,3);
+ALTER TABLE inventory_logs ALTER COLUMN quantity TYPE numeric(14,3);
+ALTER TABLE inventory_logs ALTER COLUMN stock_before TYPE numeric(14,3);
+ALTER TABLE inventory_logs ALTER COLUMN stock_after TYPE numeric(14,3);
+
+-- Weighing scale PLU and sold-by-weight flag
+ALTER TABLE products ADD COLUMN IF NOT EXISTS plu text;
+ALTER TABLE products ADD COLUMN IF NOT EXISTS sold_by_weight boolean NOT NULL DEFAULT false;
+CREATE UNIQUE INDEX IF NOT EXISTS products_plu_key ON products (plu) WHERE plu != '';
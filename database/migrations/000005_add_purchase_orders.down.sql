@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_cash_flows_purchase_order_id;
+ALTER TABLE cash_flows DROP COLUMN IF EXISTS purchase_order_id;
+DROP INDEX IF EXISTS idx_inventory_logs_purchase_order_id;
+ALTER TABLE inventory_logs DROP COLUMN IF EXISTS purchase_order_id;
+
+DROP TABLE IF EXISTS purchase_order_items;
+DROP TABLE IF EXISTS purchase_orders;
+DROP TABLE IF EXISTS suppliers;
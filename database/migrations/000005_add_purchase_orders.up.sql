@@ -0,0 +1,53 @@
+-- Suppliers
+CREATE TABLE IF NOT EXISTS suppliers (
+    id bigserial PRIMARY KEY,
+    name text NOT NULL,
+    contact_name text,
+    phone text,
+    email text,
+    address text,
+    notes text,
+    is_active boolean NOT NULL DEFAULT true,
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+    deleted_at timestamp with time zone,
+    CONSTRAINT uni_suppliers_name UNIQUE (name)
+);
+CREATE INDEX IF NOT EXISTS idx_suppliers_deleted_at ON suppliers (deleted_at);
+
+-- Purchase orders and their lines
+CREATE TABLE IF NOT EXISTS purchase_orders (
+    id bigserial PRIMARY KEY,
+    po_number text NOT NULL,
+    supplier_id bigint NOT NULL REFERENCES suppliers (id),
+    status varchar(20) NOT NULL DEFAULT 'draft',
+    expected_date timestamp with time zone,
+    total_amount numeric NOT NULL DEFAULT 0,
+    notes text,
+    user_id bigint NOT NULL REFERENCES users (id),
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+    deleted_at timestamp with time zone,
+    CONSTRAINT uni_purchase_orders_po_number UNIQUE (po_number)
+);
+CREATE INDEX IF NOT EXISTS idx_purchase_orders_supplier_id ON purchase_orders (supplier_id);
+CREATE INDEX IF NOT EXISTS idx_purchase_orders_user_id ON purchase_orders (user_id);
+CREATE INDEX IF NOT EXISTS idx_purchase_orders_deleted_at ON purchase_orders (deleted_at);
+
+CREATE TABLE IF NOT EXISTS purchase_order_items (
+    id bigserial PRIMARY KEY,
+    purchase_order_id bigint NOT NULL REFERENCES purchase_orders (id),
+    product_id bigint NOT NULL REFERENCES products (id),
+    quantity numeric(14,3) NOT NULL,
+    received_quantity numeric(14,3) NOT NULL DEFAULT 0,
+    unit_cost numeric NOT NULL,
+    sub_total numeric NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_purchase_order_items_purchase_order_id ON purchase_order_items (purchase_order_id);
+CREATE INDEX IF NOT EXISTS idx_purchase_order_items_product_id ON purchase_order_items (product_id);
+
+-- Goods receipts reference the purchase order on the inventory log and the restock expense
+ALTER TABLE inventory_logs ADD COLUMN IF NOT EXISTS purchase_order_id bigint;
+CREATE INDEX IF NOT EXISTS idx_inventory_logs_purchase_order_id ON inventory_logs (purchase_order_id);
+ALTER TABLE cash_flows ADD COLUMN IF NOT EXISTS purchase_order_id bigint;
+CREATE INDEX IF NOT EXISTS idx_cash_flows_purchase_order_id ON cash_flows (purchase_order_id);
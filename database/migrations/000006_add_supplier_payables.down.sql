@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS supplier_payments;
+DROP TABLE IF EXISTS supplier_payables;
+
+ALTER TABLE purchase_orders DROP COLUMN IF EXISTS payment_term_days;
+ALTER TABLE suppliers DROP COLUMN IF EXISTS payment_term_days;
@@ -0,0 +1,44 @@
+-- Credit terms: 0 means goods are paid on receipt
+ALTER TABLE suppliers ADD COLUMN IF NOT EXISTS payment_term_days bigint NOT NULL DEFAULT 0;
+ALTER TABLE purchase_orders ADD COLUMN IF NOT EXISTS payment_term_days bigint NOT NULL DEFAULT 0;
+
+-- Amounts owed to suppliers for goods received on credit
+CREATE TABLE IF NOT EXISTS supplier_payables (
+    id bigserial PRIMARY KEY,
+    supplier_id bigint NOT NULL REFERENCES suppliers (id),
+    purchase_order_id bigint REFERENCES purchase_orders (id),
+    inventory_log_id bigint REFERENCES inventory_logs (id),
+    amount numeric NOT NULL,
+    paid_amount numeric NOT NULL DEFAULT 0,
+    due_date timestamp with time zone NOT NULL,
+    status varchar(20) NOT NULL DEFAULT 'open',
+    notes text,
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+    deleted_at timestamp with time zone
+);
+CREATE INDEX IF NOT EXISTS idx_supplier_payables_supplier_id ON supplier_payables (supplier_id);
+CREATE INDEX IF NOT EXISTS idx_supplier_payables_purchase_order_id ON supplier_payables (purchase_order_id);
+CREATE INDEX IF NOT EXISTS idx_supplier_payables_inventory_log_id ON supplier_payables (inventory_log_id);
+CREATE INDEX IF NOT EXISTS idx_supplier_payables_due_date ON supplier_payables (due_date);
+CREATE INDEX IF NOT EXISTS idx_supplier_payables_status ON supplier_payables (status);
+CREATE INDEX IF NOT EXISTS idx_supplier_payables_deleted_at ON supplier_payables (deleted_at);
+
+-- Payments against payables; each one creates a cash flow expense
+CREATE TABLE IF NOT EXISTS supplier_payments (
+    id bigserial PRIMARY KEY,
+    payable_id bigint NOT NULL REFERENCES supplier_payables (id),
+    supplier_id bigint NOT NULL REFERENCES suppliers (id),
+    amount numeric NOT NULL,
+    date timestamp with time zone NOT NULL,
+    reference text,
+    notes text,
+    cash_flow_id bigint NOT NULL REFERENCES cash_flows (id),
+    user_id bigint NOT NULL REFERENCES users (id),
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone
+);
+CREATE INDEX IF NOT EXISTS idx_supplier_payments_payable_id ON supplier_payments (payable_id);
+CREATE INDEX IF NOT EXISTS idx_supplier_payments_supplier_id ON supplier_payments (supplier_id);
+CREATE INDEX IF NOT EXISTS idx_supplier_payments_cash_flow_id ON supplier_payments (cash_flow_id);
+CREATE INDEX IF NOT EXISTS idx_supplier_payments_user_id ON supplier_payments (user_id);
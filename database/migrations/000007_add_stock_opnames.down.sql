@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_inventory_logs_stock_opname_id;
+ALTER TABLE inventory_logs DROP COLUMN IF EXISTS stock_opname_id;
+
+DROP TABLE IF EXISTS stock_opname_counts;
+DROP TABLE IF EXISTS stock_opname_items;
+DROP TABLE IF EXISTS stock_opnames;
@@ -0,0 +1,50 @@
+-- Physical count sessions
+CREATE TABLE IF NOT EXISTS stock_opnames (
+    id bigserial PRIMARY KEY,
+    code text NOT NULL,
+    category_id bigint REFERENCES categories (id),
+    status varchar(20) NOT NULL DEFAULT 'open',
+    notes text,
+    user_id bigint NOT NULL REFERENCES users (id),
+    approved_by bigint REFERENCES users (id),
+    approved_at timestamp with time zone,
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+    deleted_at timestamp with time zone,
+    CONSTRAINT uni_stock_opnames_code UNIQUE (code)
+);
+CREATE INDEX IF NOT EXISTS idx_stock_opnames_category_id ON stock_opnames (category_id);
+CREATE INDEX IF NOT EXISTS idx_stock_opnames_status ON stock_opnames (status);
+CREATE INDEX IF NOT EXISTS idx_stock_opnames_user_id ON stock_opnames (user_id);
+CREATE INDEX IF NOT EXISTS idx_stock_opnames_deleted_at ON stock_opnames (deleted_at);
+
+-- Expected stock frozen per product when the session starts
+CREATE TABLE IF NOT EXISTS stock_opname_items (
+    id bigserial PRIMARY KEY,
+    stock_opname_id bigint NOT NULL REFERENCES stock_opnames (id),
+    product_id bigint NOT NULL REFERENCES products (id),
+    expected_stock numeric(14,3) NOT NULL,
+    cost_price numeric NOT NULL,
+    counted_quantity numeric(14,3)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_stock_opname_product ON stock_opname_items (stock_opname_id, product_id);
+
+-- Individual counts submitted by staff, summed per item
+CREATE TABLE IF NOT EXISTS stock_opname_counts (
+    id bigserial PRIMARY KEY,
+    stock_opname_id bigint NOT NULL REFERENCES stock_opnames (id),
+    stock_opname_item_id bigint NOT NULL REFERENCES stock_opname_items (id),
+    product_id bigint NOT NULL REFERENCES products (id),
+    quantity numeric(14,3) NOT NULL,
+    barcode text,
+    user_id bigint NOT NULL REFERENCES users (id),
+    created_at timestamp with time zone
+);
+CREATE INDEX IF NOT EXISTS idx_stock_opname_counts_stock_opname_id ON stock_opname_counts (stock_opname_id);
+CREATE INDEX IF NOT EXISTS idx_stock_opname_counts_stock_opname_item_id ON stock_opname_counts (stock_opname_item_id);
+CREATE INDEX IF NOT EXISTS idx_stock_opname_counts_product_id ON stock_opname_counts (product_id);
+CREATE INDEX IF NOT EXISTS idx_stock_opname_counts_user_id ON stock_opname_counts (user_id);
+
+-- Approved variances are posted as inventory adjustments referencing the session
+ALTER TABLE inventory_logs ADD COLUMN IF NOT EXISTS stock_opname_id bigint;
+CREATE INDEX IF NOT EXISTS idx_inventory_logs_stock_opname_id ON inventory_logs (stock_opname_id);
@@ -0,0 +1,21 @@
+DROP INDEX IF EXISTS idx_purchase_orders_location_id;
+ALTER TABLE purchase_orders DROP COLUMN IF EXISTS location_id;
+
+DROP INDEX IF EXISTS idx_stock_opnames_location_id;
+ALTER TABLE stock_opnames DROP COLUMN IF EXISTS location_id;
+
+DROP INDEX IF EXISTS idx_transactions_register_id;
+ALTER TABLE transactions DROP COLUMN IF EXISTS register_id;
+DROP INDEX IF EXISTS idx_transactions_location_id;
+ALTER TABLE transactions DROP COLUMN IF EXISTS location_id;
+
+DROP INDEX IF EXISTS idx_inventory_logs_stock_transfer_id;
+ALTER TABLE inventory_logs DROP COLUMN IF EXISTS stock_transfer_id;
+DROP INDEX IF EXISTS idx_inventory_logs_location_id;
+ALTER TABLE inventory_logs DROP COLUMN IF EXISTS location_id;
+
+DROP TABLE IF EXISTS stock_transfer_items;
+DROP TABLE IF EXISTS stock_transfers;
+DROP TABLE IF EXISTS registers;
+DROP TABLE IF EXISTS product_stocks;
+DROP TABLE IF EXISTS locations;
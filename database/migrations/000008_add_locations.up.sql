@@ -0,0 +1,106 @@
+-- Places stock is kept (storeroom, shop floors)
+CREATE TABLE IF NOT EXISTS locations (
+    id bigserial PRIMARY KEY,
+    code text NOT NULL,
+    name text NOT NULL,
+    type varchar(20) NOT NULL DEFAULT 'store',
+    is_default boolean NOT NULL DEFAULT false,
+    is_active boolean DEFAULT true,
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+    deleted_at timestamp with time zone,
+    CONSTRAINT uni_locations_code UNIQUE (code),
+    CONSTRAINT uni_locations_name UNIQUE (name)
+);
+CREATE INDEX IF NOT EXISTS idx_locations_deleted_at ON locations (deleted_at);
+
+-- Existing stock, sales and logs all belong to the single store we had so far
+INSERT INTO locations (code, name, type, is_default, is_active, created_at, updated_at)
+SELECT 'MAIN', 'Toko Utama', 'store', true, true, now(), now()
+WHERE NOT EXISTS (SELECT 1 FROM locations WHERE is_default);
+
+-- Stock level per product per location; products.stock stays the total
+CREATE TABLE IF NOT EXISTS product_stocks (
+    id bigserial PRIMARY KEY,
+    product_id bigint NOT NULL REFERENCES products (id),
+    location_id bigint NOT NULL REFERENCES locations (id),
+    quantity numeric(14,3) NOT NULL DEFAULT 0,
+    updated_at timestamp with time zone
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_product_location ON product_stocks (product_id, location_id);
+CREATE INDEX IF NOT EXISTS idx_product_stocks_location_id ON product_stocks (location_id);
+
+INSERT INTO product_stocks (product_id, location_id, quantity, updated_at)
+SELECT p.id, l.id, p.stock, now()
+FROM products p
+CROSS JOIN (SELECT id FROM locations WHERE is_default LIMIT 1) l
+ON CONFLICT (product_id, location_id) DO NOTHING;
+
+-- POS checkout counters, each assigned to the location its sales deduct from
+CREATE TABLE IF NOT EXISTS registers (
+    id bigserial PRIMARY KEY,
+    code text NOT NULL,
+    name text,
+    location_id bigint NOT NULL REFERENCES locations (id),
+    is_active boolean DEFAULT true,
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+    deleted_at timestamp with time zone,
+    CONSTRAINT uni_registers_code UNIQUE (code)
+);
+CREATE INDEX IF NOT EXISTS idx_registers_location_id ON registers (location_id);
+CREATE INDEX IF NOT EXISTS idx_registers_deleted_at ON registers (deleted_at);
+
+-- Transfers between locations, posted in one DB transaction
+CREATE TABLE IF NOT EXISTS stock_transfers (
+    id bigserial PRIMARY KEY,
+    code text NOT NULL,
+    from_location_id bigint NOT NULL REFERENCES locations (id),
+    to_location_id bigint NOT NULL REFERENCES locations (id),
+    notes text,
+    user_id bigint NOT NULL REFERENCES users (id),
+    created_at timestamp with time zone,
+    deleted_at timestamp with time zone,
+    CONSTRAINT uni_stock_transfers_code UNIQUE (code),
+    CONSTRAINT chk_stock_transfers_locations CHECK (from_location_id <> to_location_id)
+);
+CREATE INDEX IF NOT EXISTS idx_stock_transfers_from_location_id ON stock_transfers (from_location_id);
+CREATE INDEX IF NOT EXISTS idx_stock_transfers_to_location_id ON stock_transfers (to_location_id);
+CREATE INDEX IF NOT EXISTS idx_stock_transfers_user_id ON stock_transfers (user_id);
+CREATE INDEX IF NOT EXISTS idx_stock_transfers_deleted_at ON stock_transfers (deleted_at);
+
+CREATE TABLE IF NOT EXISTS stock_transfer_items (
+    id bigserial PRIMARY KEY,
+    stock_transfer_id bigint NOT NULL REFERENCES stock_transfers (id),
+    product_id bigint NOT NULL REFERENCES products (id),
+    quantity numeric(14,3) NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_stock_transfer_items_stock_transfer_id ON stock_transfer_items (stock_transfer_id);
+CREATE INDEX IF NOT EXISTS idx_stock_transfer_items_product_id ON stock_transfer_items (product_id);
+
+-- Every inventory log records the location it moved stock at
+ALTER TABLE inventory_logs ADD COLUMN IF NOT EXISTS location_id bigint REFERENCES locations (id);
+UPDATE inventory_logs SET location_id = (SELECT id FROM locations WHERE is_default LIMIT 1) WHERE location_id IS NULL;
+ALTER TABLE inventory_logs ALTER COLUMN location_id SET NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_inventory_logs_location_id ON inventory_logs (location_id);
+
+ALTER TABLE inventory_logs ADD COLUMN IF NOT EXISTS stock_transfer_id bigint REFERENCES stock_transfers (id);
+CREATE INDEX IF NOT EXISTS idx_inventory_logs_stock_transfer_id ON inventory_logs (stock_transfer_id);
+
+-- Sales deduct from their register's location
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS location_id bigint REFERENCES locations (id);
+UPDATE transactions SET location_id = (SELECT id FROM locations WHERE is_default LIMIT 1) WHERE location_id IS NULL;
+ALTER TABLE transactions ALTER COLUMN location_id SET NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_transactions_location_id ON transactions (location_id);
+
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS register_id bigint REFERENCES registers (id);
+CREATE INDEX IF NOT EXISTS idx_transactions_register_id ON transactions (register_id);
+
+-- Counts and goods receipts happen at a location
+ALTER TABLE stock_opnames ADD COLUMN IF NOT EXISTS location_id bigint REFERENCES locations (id);
+UPDATE stock_opnames SET location_id = (SELECT id FROM locations WHERE is_default LIMIT 1) WHERE location_id IS NULL;
+ALTER TABLE stock_opnames ALTER COLUMN location_id SET NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_stock_opnames_location_id ON stock_opnames (location_id);
+
+ALTER TABLE purchase_orders ADD COLUMN IF NOT EXISTS location_id bigint REFERENCES locations (id);
+CREATE INDEX IF NOT EXISTS idx_purchase_orders_location_id ON purchase_orders (location_id);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_inventory_logs_transaction_id;
+ALTER TABLE inventory_logs DROP COLUMN IF EXISTS transaction_id;
+
+DROP TABLE IF EXISTS inventory_log_lots;
+DROP TABLE IF EXISTS product_lots;
@@ -0,0 +1,30 @@
+-- Batches of a product received at a location, consumed first-expiry-first-out
+CREATE TABLE IF NOT EXISTS product_lots (
+    id bigserial PRIMARY KEY,
+    product_id bigint NOT NULL REFERENCES products (id),
+    location_id bigint NOT NULL REFERENCES locations (id),
+    lot_number text NOT NULL,
+    expiry_date date,
+    quantity numeric(14,3) NOT NULL DEFAULT 0,
+    received_quantity numeric(14,3) NOT NULL DEFAULT 0,
+    cost_price numeric NOT NULL DEFAULT 0,
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_product_lot_number ON product_lots (product_id, location_id, lot_number);
+CREATE INDEX IF NOT EXISTS idx_product_lots_location_id ON product_lots (location_id);
+CREATE INDEX IF NOT EXISTS idx_product_lots_expiry_date ON product_lots (expiry_date);
+
+-- How much of each lot an inventory log moved
+CREATE TABLE IF NOT EXISTS inventory_log_lots (
+    id bigserial PRIMARY KEY,
+    inventory_log_id bigint NOT NULL REFERENCES inventory_logs (id) ON DELETE CASCADE,
+    product_lot_id bigint NOT NULL REFERENCES product_lots (id),
+    quantity numeric(14,3) NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_inventory_log_lots_inventory_log_id ON inventory_log_lots (inventory_log_id);
+CREATE INDEX IF NOT EXISTS idx_inventory_log_lots_product_lot_id ON inventory_log_lots (product_lot_id);
+
+-- Sale and return logs point at their transaction so returns put back the same lots
+ALTER TABLE inventory_logs ADD COLUMN IF NOT EXISTS transaction_id bigint REFERENCES transactions (id);
+CREATE INDEX IF NOT EXISTS idx_inventory_logs_transaction_id ON inventory_logs (transaction_id);
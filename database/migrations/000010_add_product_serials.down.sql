@@ -0,0 +1,10 @@
+ALTER TABLE transactions DROP COLUMN IF EXISTS customer_phone;
+ALTER TABLE transactions DROP COLUMN IF EXISTS customer_name;
+
+ALTER TABLE stock_transfer_items DROP COLUMN IF EXISTS serial_numbers;
+ALTER TABLE inventory_logs DROP COLUMN IF EXISTS serial_numbers;
+ALTER TABLE transaction_details DROP COLUMN IF EXISTS serial_numbers;
+
+DROP TABLE IF EXISTS product_serials;
+
+ALTER TABLE products DROP COLUMN IF EXISTS serialized;
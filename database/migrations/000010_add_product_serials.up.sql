@@ -0,0 +1,33 @@
+-- Products whose units each carry a serial number (electronics, phone accessories)
+ALTER TABLE products ADD COLUMN IF NOT EXISTS serialized boolean NOT NULL DEFAULT false;
+
+-- One row per unit of a serialized product, following it from receipt to sale
+CREATE TABLE IF NOT EXISTS product_serials (
+    id bigserial PRIMARY KEY,
+    product_id bigint NOT NULL REFERENCES products (id),
+    serial_number text NOT NULL,
+    location_id bigint NOT NULL REFERENCES locations (id),
+    status varchar(20) NOT NULL DEFAULT 'in_stock',
+    received_at timestamp with time zone,
+    received_log_id bigint REFERENCES inventory_logs (id),
+    purchase_order_id bigint REFERENCES purchase_orders (id),
+    transaction_id bigint REFERENCES transactions (id),
+    sold_at timestamp with time zone,
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_product_serial_number ON product_serials (product_id, serial_number);
+CREATE INDEX IF NOT EXISTS idx_product_serials_serial_number ON product_serials (serial_number);
+CREATE INDEX IF NOT EXISTS idx_product_serials_location_id ON product_serials (location_id);
+CREATE INDEX IF NOT EXISTS idx_product_serials_received_log_id ON product_serials (received_log_id);
+CREATE INDEX IF NOT EXISTS idx_product_serials_purchase_order_id ON product_serials (purchase_order_id);
+CREATE INDEX IF NOT EXISTS idx_product_serials_transaction_id ON product_serials (transaction_id);
+
+-- Serial numbers moved by each sale line, inventory entry and transfer line
+ALTER TABLE transaction_details ADD COLUMN IF NOT EXISTS serial_numbers jsonb;
+ALTER TABLE inventory_logs ADD COLUMN IF NOT EXISTS serial_numbers jsonb;
+ALTER TABLE stock_transfer_items ADD COLUMN IF NOT EXISTS serial_numbers jsonb;
+
+-- Optional customer details for warranty claims
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS customer_name text;
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS customer_phone text;
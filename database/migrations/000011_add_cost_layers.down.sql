@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS cost_layers;
+
+ALTER TABLE store_settings DROP COLUMN IF EXISTS costing_method;
@@ -0,0 +1,23 @@
+-- How the cost of goods sold and the stock value are computed
+ALTER TABLE store_settings ADD COLUMN IF NOT EXISTS costing_method varchar(10) NOT NULL DEFAULT 'average';
+
+-- Stock per product at the unit cost it came in at, drained oldest first
+CREATE TABLE IF NOT EXISTS cost_layers (
+    id bigserial PRIMARY KEY,
+    product_id bigint NOT NULL REFERENCES products (id),
+    inventory_log_id bigint REFERENCES inventory_logs (id),
+    unit_cost numeric NOT NULL,
+    quantity numeric(14,3) NOT NULL,
+    remaining numeric(14,3) NOT NULL DEFAULT 0,
+    created_at timestamp with time zone
+);
+CREATE INDEX IF NOT EXISTS idx_cost_layers_product_id ON cost_layers (product_id);
+CREATE INDEX IF NOT EXISTS idx_cost_layers_inventory_log_id ON cost_layers (inventory_log_id);
+-- Open layers are what sales drain
+CREATE INDEX IF NOT EXISTS idx_cost_layers_open ON cost_layers (product_id, id) WHERE remaining > 0;
+
+-- Stock on hand becomes an opening layer at the current product cost
+INSERT INTO cost_layers (product_id, unit_cost, quantity, remaining, created_at)
+SELECT id, COALESCE(cost, 0), stock, stock, now()
+FROM products
+WHERE stock > 0;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_products_supplier_id;
+ALTER TABLE products DROP COLUMN IF EXISTS supplier_id;
+ALTER TABLE products DROP COLUMN IF EXISTS lead_time_days;
+ALTER TABLE products DROP COLUMN IF EXISTS max_stock;
+ALTER TABLE products DROP COLUMN IF EXISTS min_stock;
@@ -0,0 +1,6 @@
+-- Per-product reorder levels; min_stock defaults to the old fixed low-stock threshold
+ALTER TABLE products ADD COLUMN IF NOT EXISTS min_stock numeric(14,3) NOT NULL DEFAULT 10;
+ALTER TABLE products ADD COLUMN IF NOT EXISTS max_stock numeric(14,3) NOT NULL DEFAULT 0;
+ALTER TABLE products ADD COLUMN IF NOT EXISTS lead_time_days bigint NOT NULL DEFAULT 0;
+ALTER TABLE products ADD COLUMN IF NOT EXISTS supplier_id bigint REFERENCES suppliers (id);
+CREATE INDEX IF NOT EXISTS idx_products_supplier_id ON products (supplier_id);
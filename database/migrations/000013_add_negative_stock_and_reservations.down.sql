@@ -0,0 +1,15 @@
+DROP INDEX IF EXISTS idx_transactions_reservation_id;
+ALTER TABLE transactions DROP COLUMN IF EXISTS reservation_id;
+
+DROP TABLE IF EXISTS stock_reservation_items;
+DROP TABLE IF EXISTS stock_reservations;
+
+DROP INDEX IF EXISTS idx_inventory_logs_needs_review;
+ALTER TABLE inventory_logs DROP COLUMN IF EXISTS review_notes;
+ALTER TABLE inventory_logs DROP COLUMN IF EXISTS reviewed_by;
+ALTER TABLE inventory_logs DROP COLUMN IF EXISTS reviewed_at;
+ALTER TABLE inventory_logs DROP COLUMN IF EXISTS needs_review;
+ALTER TABLE inventory_logs DROP COLUMN IF EXISTS shortfall;
+
+ALTER TABLE products DROP COLUMN IF EXISTS negative_stock_policy;
+ALTER TABLE store_settings DROP COLUMN IF EXISTS negative_stock_policy;
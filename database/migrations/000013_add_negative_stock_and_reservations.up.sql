@@ -0,0 +1,46 @@
+-- How sales beyond the stock on record are handled: block, warn or allow.
+-- An empty product policy follows the store's.
+ALTER TABLE store_settings ADD COLUMN IF NOT EXISTS negative_stock_policy varchar(10) NOT NULL DEFAULT 'block';
+ALTER TABLE products ADD COLUMN IF NOT EXISTS negative_stock_policy varchar(10) NOT NULL DEFAULT '';
+
+-- Units sold beyond the stock on record, and the review of those sold under "allow"
+ALTER TABLE inventory_logs ADD COLUMN IF NOT EXISTS shortfall numeric(14,3) NOT NULL DEFAULT 0;
+ALTER TABLE inventory_logs ADD COLUMN IF NOT EXISTS needs_review boolean NOT NULL DEFAULT false;
+ALTER TABLE inventory_logs ADD COLUMN IF NOT EXISTS reviewed_at timestamp with time zone;
+ALTER TABLE inventory_logs ADD COLUMN IF NOT EXISTS reviewed_by bigint;
+ALTER TABLE inventory_logs ADD COLUMN IF NOT EXISTS review_notes text;
+CREATE INDEX IF NOT EXISTS idx_inventory_logs_needs_review ON inventory_logs (created_at) WHERE needs_review;
+
+-- Stock held at a location for held orders from any channel
+CREATE TABLE IF NOT EXISTS stock_reservations (
+    id bigserial PRIMARY KEY,
+    code text NOT NULL,
+    location_id bigint NOT NULL REFERENCES locations (id),
+    channel varchar(30),
+    reference text,
+    notes text,
+    status varchar(20) NOT NULL DEFAULT 'active',
+    expires_at timestamp with time zone NOT NULL,
+    transaction_id bigint REFERENCES transactions (id),
+    user_id bigint NOT NULL REFERENCES users (id),
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone
+);
+CREATE UNIQUE INDEX IF NOT EXISTS uni_stock_reservations_code ON stock_reservations (code);
+CREATE INDEX IF NOT EXISTS idx_stock_reservations_location_id ON stock_reservations (location_id);
+CREATE INDEX IF NOT EXISTS idx_stock_reservations_expires_at ON stock_reservations (expires_at);
+CREATE INDEX IF NOT EXISTS idx_stock_reservations_transaction_id ON stock_reservations (transaction_id);
+CREATE INDEX IF NOT EXISTS idx_stock_reservations_user_id ON stock_reservations (user_id);
+
+CREATE TABLE IF NOT EXISTS stock_reservation_items (
+    id bigserial PRIMARY KEY,
+    reservation_id bigint NOT NULL REFERENCES stock_reservations (id) ON DELETE CASCADE,
+    product_id bigint NOT NULL REFERENCES products (id),
+    quantity numeric(14,3) NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_stock_reservation_items_reservation_id ON stock_reservation_items (reservation_id);
+CREATE INDEX IF NOT EXISTS idx_stock_reservation_items_product_id ON stock_reservation_items (product_id);
+
+-- The reservation a sale fulfilled
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS reservation_id bigint REFERENCES stock_reservations (id);
+CREATE INDEX IF NOT EXISTS idx_transactions_reservation_id ON transactions (reservation_id);
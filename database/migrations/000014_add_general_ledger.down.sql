@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_cash_flows_journal_entry_id;
+ALTER TABLE cash_flows DROP COLUMN IF EXISTS journal_entry_id;
+
+DROP TABLE IF EXISTS journal_lines;
+DROP TABLE IF EXISTS journal_entries;
+DROP TABLE IF EXISTS accounts;
@@ -0,0 +1,128 @@
+-- Chart of accounts; non-cash payment methods get a bank account of their own on first use
+CREATE TABLE IF NOT EXISTS accounts (
+    id bigserial PRIMARY KEY,
+    code text NOT NULL,
+    name text NOT NULL,
+    type varchar(20) NOT NULL,
+    payment_method_id bigint REFERENCES payment_methods (id),
+    is_system boolean DEFAULT false,
+    is_active boolean DEFAULT true,
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+    CONSTRAINT uni_accounts_code UNIQUE (code)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_accounts_payment_method_id ON accounts (payment_method_id);
+
+INSERT INTO accounts (code, name, type, is_system, is_active, created_at, updated_at) VALUES
+    ('1100', 'Kas', 'asset', true, true, now(), now()),
+    ('1200', 'Bank', 'asset', true, true, now(), now()),
+    ('1300', 'Persediaan Barang', 'asset', true, true, now(), now()),
+    ('2100', 'Utang Usaha', 'liability', true, true, now(), now()),
+    ('3100', 'Modal Pemilik', 'equity', true, true, now(), now()),
+    ('4100', 'Penjualan', 'revenue', true, true, now(), now()),
+    ('4110', 'Diskon Penjualan', 'revenue', true, true, now(), now()),
+    ('4200', 'Pendapatan Lain-lain', 'revenue', true, true, now(), now()),
+    ('5100', 'Harga Pokok Penjualan', 'expense', true, true, now(), now()),
+    ('5200', 'Selisih Persediaan', 'expense', true, true, now(), now()),
+    ('6100', 'Beban Operasional', 'expense', true, true, now(), now())
+ON CONFLICT (code) DO NOTHING;
+
+-- Balanced postings; never changed, corrected by a reversal instead
+CREATE TABLE IF NOT EXISTS journal_entries (
+    id bigserial PRIMARY KEY,
+    date timestamp with time zone NOT NULL,
+    source varchar(30) NOT NULL,
+    description text,
+    transaction_id bigint REFERENCES transactions (id),
+    purchase_order_id bigint REFERENCES purchase_orders (id),
+    inventory_log_id bigint REFERENCES inventory_logs (id),
+    reversal_of_id bigint REFERENCES journal_entries (id),
+    user_id bigint NOT NULL REFERENCES users (id),
+    created_at timestamp with time zone
+);
+CREATE INDEX IF NOT EXISTS idx_journal_entries_date ON journal_entries (date);
+CREATE INDEX IF NOT EXISTS idx_journal_entries_source ON journal_entries (source);
+CREATE INDEX IF NOT EXISTS idx_journal_entries_transaction_id ON journal_entries (transaction_id);
+CREATE INDEX IF NOT EXISTS idx_journal_entries_purchase_order_id ON journal_entries (purchase_order_id);
+CREATE INDEX IF NOT EXISTS idx_journal_entries_inventory_log_id ON journal_entries (inventory_log_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_journal_entries_reversal_of_id ON journal_entries (reversal_of_id);
+CREATE INDEX IF NOT EXISTS idx_journal_entries_user_id ON journal_entries (user_id);
+
+CREATE TABLE IF NOT EXISTS journal_lines (
+    id bigserial PRIMARY KEY,
+    journal_entry_id bigint NOT NULL REFERENCES journal_entries (id),
+    account_id bigint NOT NULL REFERENCES accounts (id),
+    debit numeric(14,2) NOT NULL DEFAULT 0,
+    credit numeric(14,2) NOT NULL DEFAULT 0,
+    memo text
+);
+CREATE INDEX IF NOT EXISTS idx_journal_lines_journal_entry_id ON journal_lines (journal_entry_id);
+CREATE INDEX IF NOT EXISTS idx_journal_lines_account_id ON journal_lines (account_id);
+
+-- The cash book entry's posting
+ALTER TABLE cash_flows ADD COLUMN IF NOT EXISTS journal_entry_id bigint REFERENCES journal_entries (id);
+CREATE INDEX IF NOT EXISTS idx_cash_flows_journal_entry_id ON cash_flows (journal_entry_id);
+
+-- Post the history: goods received on credit, then every cash book entry
+-- against cash (sales without their cost, which was never booked)
+DO $$
+DECLARE
+    rec record;
+    entry_id bigint;
+    cash_id bigint;
+    counter_id bigint;
+    entry_source text;
+    entry_transaction_id bigint;
+BEGIN
+    SELECT id INTO cash_id FROM accounts WHERE code = '1100';
+
+    FOR rec IN SELECT * FROM supplier_payables WHERE deleted_at IS NULL AND amount <> 0 ORDER BY id LOOP
+        INSERT INTO journal_entries (date, source, description, purchase_order_id, inventory_log_id, user_id, created_at)
+        VALUES (rec.created_at, 'purchase', rec.notes, rec.purchase_order_id, rec.inventory_log_id,
+                COALESCE((SELECT user_id FROM inventory_logs WHERE id = rec.inventory_log_id), (SELECT min(id) FROM users)), now())
+        RETURNING id INTO entry_id;
+        INSERT INTO journal_lines (journal_entry_id, account_id, debit, credit) VALUES
+            (entry_id, (SELECT id FROM accounts WHERE code = '1300'), round(rec.amount, 2), 0),
+            (entry_id, (SELECT id FROM accounts WHERE code = '2100'), 0, round(rec.amount, 2));
+    END LOOP;
+
+    FOR rec IN SELECT * FROM cash_flows WHERE deleted_at IS NULL AND journal_entry_id IS NULL AND amount <> 0 ORDER BY id LOOP
+        entry_source := 'cash_flow';
+        entry_transaction_id := NULL;
+        IF rec.type = 'income' AND rec.source IN ('modal_awal', 'modal_tambahan') THEN
+            counter_id := (SELECT id FROM accounts WHERE code = '3100');
+        ELSIF rec.type = 'income' AND rec.source = 'sales' THEN
+            counter_id := (SELECT id FROM accounts WHERE code = '4100');
+            SELECT id INTO entry_transaction_id FROM transactions WHERE 'Transaction ' || transaction_code = rec.notes;
+            IF entry_transaction_id IS NOT NULL THEN
+                entry_source := 'sale';
+            END IF;
+        ELSIF rec.type = 'income' THEN
+            counter_id := (SELECT id FROM accounts WHERE code = '4200');
+        ELSIF EXISTS (SELECT 1 FROM supplier_payments WHERE cash_flow_id = rec.id) THEN
+            counter_id := (SELECT id FROM accounts WHERE code = '2100');
+            entry_source := 'supplier_payment';
+        ELSIF rec.source = 'penambahan_stok' THEN
+            counter_id := (SELECT id FROM accounts WHERE code = '1300');
+            IF rec.purchase_order_id IS NOT NULL OR rec.notes LIKE 'Restock %' THEN
+                entry_source := 'purchase';
+            END IF;
+        ELSE
+            counter_id := (SELECT id FROM accounts WHERE code = '6100');
+        END IF;
+
+        INSERT INTO journal_entries (date, source, description, transaction_id, purchase_order_id, user_id, created_at)
+        VALUES (rec.date, entry_source, rec.notes, entry_transaction_id, rec.purchase_order_id, rec.user_id, now())
+        RETURNING id INTO entry_id;
+        IF rec.type = 'income' THEN
+            INSERT INTO journal_lines (journal_entry_id, account_id, debit, credit) VALUES
+                (entry_id, cash_id, round(rec.amount, 2), 0),
+                (entry_id, counter_id, 0, round(rec.amount, 2));
+        ELSE
+            INSERT INTO journal_lines (journal_entry_id, account_id, debit, credit) VALUES
+                (entry_id, counter_id, round(rec.amount, 2), 0),
+                (entry_id, cash_id, 0, round(rec.amount, 2));
+        END IF;
+        UPDATE cash_flows SET journal_entry_id = entry_id WHERE id = rec.id;
+    END LOOP;
+END $$;
@@ -0,0 +1,4 @@
+ALTER TABLE cash_flows DROP CONSTRAINT IF EXISTS fk_cash_flows_category;
+DROP INDEX IF EXISTS idx_cash_flows_source;
+
+DROP TABLE IF EXISTS cash_flow_categories;
@@ -0,0 +1,56 @@
+-- Cash flow categories; entries reference them by code as their source
+CREATE TABLE IF NOT EXISTS cash_flow_categories (
+    id bigserial PRIMARY KEY,
+    code text NOT NULL,
+    name text NOT NULL,
+    type varchar(20) NOT NULL,
+    account_code text NOT NULL,
+    is_system boolean DEFAULT false,
+    is_active boolean DEFAULT true,
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+    CONSTRAINT uni_cash_flow_categories_code UNIQUE (code)
+);
+
+INSERT INTO cash_flow_categories (code, name, type, account_code, is_system, is_active, created_at, updated_at) VALUES
+    ('sales', 'Penjualan', 'income', '4100', true, true, now(), now()),
+    ('penambahan_stok', 'Penambahan Stok', 'expense', '1300', true, true, now(), now()),
+    ('modal_awal', 'Modal Awal', 'capital', '3100', false, true, now(), now()),
+    ('modal_tambahan', 'Modal Tambahan', 'capital', '3100', false, true, now(), now()),
+    ('prive', 'Prive (Pengambilan Pemilik)', 'capital', '3100', false, true, now(), now()),
+    ('pendapatan_lain', 'Pendapatan Lain-lain', 'income', '4200', false, true, now(), now()),
+    ('sewa', 'Sewa', 'expense', '6100', false, true, now(), now()),
+    ('listrik', 'Listrik', 'expense', '6100', false, true, now(), now()),
+    ('gaji_karyawan', 'Gaji Karyawan', 'expense', '6100', false, true, now(), now()),
+    ('perlengkapan', 'Perlengkapan', 'expense', '6100', false, true, now(), now()),
+    ('beban_lain', 'Beban Lain-lain', 'expense', '6100', false, true, now(), now())
+ON CONFLICT (code) DO NOTHING;
+
+-- Map the free-text sources: normalize them, fold the usual English names
+-- into the defaults, and open a category for every other source, typed by
+-- how it was mostly used
+UPDATE cash_flows SET source = lower(regexp_replace(trim(source), '\s+', '_', 'g'));
+UPDATE cash_flows SET source = CASE WHEN type = 'income' THEN 'pendapatan_lain' ELSE 'beban_lain' END WHERE source = '';
+UPDATE cash_flows SET source = CASE source
+        WHEN 'rent' THEN 'sewa'
+        WHEN 'electricity' THEN 'listrik'
+        WHEN 'salary' THEN 'gaji_karyawan'
+        WHEN 'supplies' THEN 'perlengkapan'
+        WHEN 'other_income' THEN 'pendapatan_lain'
+        WHEN 'other_expense' THEN 'beban_lain'
+    END
+WHERE source IN ('rent', 'electricity', 'salary', 'supplies', 'other_income', 'other_expense');
+
+INSERT INTO cash_flow_categories (code, name, type, account_code, is_system, is_active, created_at, updated_at)
+SELECT source,
+       initcap(replace(source, '_', ' ')),
+       CASE WHEN count(*) FILTER (WHERE type = 'income') > count(*) FILTER (WHERE type = 'expense') THEN 'income' ELSE 'expense' END,
+       CASE WHEN count(*) FILTER (WHERE type = 'income') > count(*) FILTER (WHERE type = 'expense') THEN '4200' ELSE '6100' END,
+       false, true, now(), now()
+FROM cash_flows
+WHERE source NOT IN (SELECT code FROM cash_flow_categories)
+GROUP BY source;
+
+CREATE INDEX IF NOT EXISTS idx_cash_flows_source ON cash_flows (source);
+ALTER TABLE cash_flows ADD CONSTRAINT fk_cash_flows_category
+    FOREIGN KEY (source) REFERENCES cash_flow_categories (code) ON UPDATE CASCADE;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_cash_flows_recurring_expense_date;
+DROP INDEX IF EXISTS idx_cash_flows_recurring_expense_id;
+ALTER TABLE cash_flows DROP COLUMN IF EXISTS recurring_expense_id;
+
+DROP TABLE IF EXISTS recurring_expenses;
@@ -0,0 +1,29 @@
+-- Templates the scheduler books as cash flow expenses when they fall due
+CREATE TABLE IF NOT EXISTS recurring_expenses (
+    id bigserial PRIMARY KEY,
+    name text NOT NULL,
+    source text NOT NULL REFERENCES cash_flow_categories (code) ON UPDATE CASCADE,
+    amount numeric(14,2) NOT NULL,
+    frequency varchar(20) NOT NULL,
+    day_of_week bigint NOT NULL DEFAULT 0,
+    day_of_month bigint NOT NULL DEFAULT 0,
+    month bigint NOT NULL DEFAULT 0,
+    start_date date NOT NULL,
+    end_date date,
+    notes text,
+    is_active boolean DEFAULT true,
+    booked_through date,
+    user_id bigint NOT NULL REFERENCES users (id),
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+    deleted_at timestamp with time zone
+);
+CREATE INDEX IF NOT EXISTS idx_recurring_expenses_source ON recurring_expenses (source);
+CREATE INDEX IF NOT EXISTS idx_recurring_expenses_user_id ON recurring_expenses (user_id);
+CREATE INDEX IF NOT EXISTS idx_recurring_expenses_deleted_at ON recurring_expenses (deleted_at);
+
+-- The template an expense was booked from; one entry per template and date
+ALTER TABLE cash_flows ADD COLUMN IF NOT EXISTS recurring_expense_id bigint REFERENCES recurring_expenses (id);
+CREATE INDEX IF NOT EXISTS idx_cash_flows_recurring_expense_id ON cash_flows (recurring_expense_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_cash_flows_recurring_expense_date ON cash_flows (recurring_expense_id, date)
+    WHERE recurring_expense_id IS NOT NULL;
@@ -0,0 +1,18 @@
+-- Files kept as evidence for cash book entries and stock movements; the
+-- files themselves are in the blob storage
+CREATE TABLE IF NOT EXISTS attachments (
+    id bigserial PRIMARY KEY,
+    cash_flow_id bigint REFERENCES cash_flows (id),
+    inventory_log_id bigint REFERENCES inventory_logs (id),
+    file_name text NOT NULL,
+    content_type text NOT NULL,
+    size bigint NOT NULL,
+    storage_key text NOT NULL,
+    user_id bigint NOT NULL REFERENCES users (id),
+    created_at timestamp with time zone,
+    CONSTRAINT uni_attachments_storage_key UNIQUE (storage_key),
+    CONSTRAINT chk_attachments_owner CHECK ((cash_flow_id IS NULL) <> (inventory_log_id IS NULL))
+);
+CREATE INDEX IF NOT EXISTS idx_attachments_cash_flow_id ON attachments (cash_flow_id);
+CREATE INDEX IF NOT EXISTS idx_attachments_inventory_log_id ON attachments (inventory_log_id);
+CREATE INDEX IF NOT EXISTS idx_attachments_user_id ON attachments (user_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS accounting_period_logs;
+DROP TABLE IF EXISTS accounting_periods;
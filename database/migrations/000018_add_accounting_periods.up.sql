@@ -0,0 +1,23 @@
+-- Months of the books; nothing dated in a closed month can be posted, changed or cancelled
+CREATE TABLE IF NOT EXISTS accounting_periods (
+    id bigserial PRIMARY KEY,
+    period date NOT NULL,
+    is_closed boolean NOT NULL DEFAULT false,
+    closed_at timestamp with time zone,
+    closed_by bigint REFERENCES users (id),
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+    CONSTRAINT uni_accounting_periods_period UNIQUE (period)
+);
+
+-- Who closed or reopened each period, when and why
+CREATE TABLE IF NOT EXISTS accounting_period_logs (
+    id bigserial PRIMARY KEY,
+    accounting_period_id bigint NOT NULL REFERENCES accounting_periods (id),
+    action varchar(20) NOT NULL,
+    reason text,
+    user_id bigint NOT NULL REFERENCES users (id),
+    created_at timestamp with time zone
+);
+CREATE INDEX IF NOT EXISTS idx_accounting_period_logs_accounting_period_id ON accounting_period_logs (accounting_period_id);
+CREATE INDEX IF NOT EXISTS idx_accounting_period_logs_user_id ON accounting_period_logs (user_id);
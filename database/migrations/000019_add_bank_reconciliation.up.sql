@@ -0,0 +1,52 @@
+-- Ledger account and system category the merchant fees of settlements are booked to
+INSERT INTO accounts (code, name, type, is_system, is_active, created_at, updated_at) VALUES
+    ('6200', 'Biaya Merchant', 'expense', true, true, now(), now())
+ON CONFLICT (code) DO NOTHING;
+
+INSERT INTO cash_flow_categories (code, name, type, account_code, is_system, is_active, created_at, updated_at) VALUES
+    ('biaya_merchant', 'Biaya Merchant', 'expense', '6200', true, true, now(), now())
+ON CONFLICT (code) DO NOTHING;
+
+-- Settlement statements imported for non-cash payment methods, with the column mapping they were read with
+CREATE TABLE IF NOT EXISTS bank_statements (
+    id bigserial PRIMARY KEY,
+    payment_method_id bigint NOT NULL REFERENCES payment_methods (id),
+    file_name text NOT NULL,
+    mapping jsonb NOT NULL,
+    start_date date NOT NULL,
+    end_date date NOT NULL,
+    line_count bigint NOT NULL DEFAULT 0,
+    total_amount numeric(14,2) NOT NULL DEFAULT 0,
+    total_fee numeric(14,2) NOT NULL DEFAULT 0,
+    total_net numeric(14,2) NOT NULL DEFAULT 0,
+    user_id bigint NOT NULL REFERENCES users (id),
+    created_at timestamp with time zone
+);
+CREATE INDEX IF NOT EXISTS idx_bank_statements_payment_method_id ON bank_statements (payment_method_id);
+CREATE INDEX IF NOT EXISTS idx_bank_statements_user_id ON bank_statements (user_id);
+
+-- Settlements on the statements; each sale is settled by at most one line
+CREATE TABLE IF NOT EXISTS bank_statement_lines (
+    id bigserial PRIMARY KEY,
+    bank_statement_id bigint NOT NULL REFERENCES bank_statements (id),
+    line_no bigint NOT NULL,
+    date date NOT NULL,
+    reference text,
+    description text,
+    amount numeric(14,2) NOT NULL,
+    fee numeric(14,2) NOT NULL DEFAULT 0,
+    net_amount numeric(14,2) NOT NULL,
+    status varchar(20) NOT NULL DEFAULT 'unmatched',
+    transaction_id bigint REFERENCES transactions (id),
+    matched_at timestamp with time zone,
+    fee_cash_flow_id bigint REFERENCES cash_flows (id),
+    fingerprint text NOT NULL,
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+    CONSTRAINT uni_bank_statement_lines_fingerprint UNIQUE (fingerprint)
+);
+CREATE INDEX IF NOT EXISTS idx_bank_statement_lines_bank_statement_id ON bank_statement_lines (bank_statement_id);
+CREATE INDEX IF NOT EXISTS idx_bank_statement_lines_date ON bank_statement_lines (date);
+CREATE INDEX IF NOT EXISTS idx_bank_statement_lines_status ON bank_statement_lines (status);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_bank_statement_lines_transaction_id ON bank_statement_lines (transaction_id);
+CREATE INDEX IF NOT EXISTS idx_bank_statement_lines_fee_cash_flow_id ON bank_statement_lines (fee_cash_flow_id);
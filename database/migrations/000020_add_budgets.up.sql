@@ -0,0 +1,16 @@
+-- Monthly spending caps per expense category, with the share of the cap that raises an alert
+CREATE TABLE IF NOT EXISTS budgets (
+    id bigserial PRIMARY KEY,
+    source text NOT NULL REFERENCES cash_flow_categories (code) ON UPDATE CASCADE,
+    month date NOT NULL,
+    amount numeric(14,2) NOT NULL,
+    alert_percent numeric(5,2) NOT NULL,
+    notes text,
+    alerted_at timestamp with time zone,
+    user_id bigint NOT NULL REFERENCES users (id),
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_budgets_source_month ON budgets (source, month);
+CREATE INDEX IF NOT EXISTS idx_budgets_month ON budgets (month);
+CREATE INDEX IF NOT EXISTS idx_budgets_user_id ON budgets (user_id);
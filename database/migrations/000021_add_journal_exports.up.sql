@@ -0,0 +1,34 @@
+-- Saved column layouts journal exports are written with, e.g. one per accounting software
+CREATE TABLE IF NOT EXISTS journal_export_templates (
+    id bigserial PRIMARY KEY,
+    name text NOT NULL,
+    layout jsonb NOT NULL,
+    user_id bigint NOT NULL REFERENCES users (id),
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+    CONSTRAINT uni_journal_export_templates_name UNIQUE (name)
+);
+CREATE INDEX IF NOT EXISTS idx_journal_export_templates_user_id ON journal_export_templates (user_id);
+
+-- Batches of journal entries exported for the bookkeeper, with the layout they were written with
+CREATE TABLE IF NOT EXISTS journal_exports (
+    id bigserial PRIMARY KEY,
+    scopes jsonb NOT NULL,
+    template_name text,
+    layout jsonb NOT NULL,
+    start_date date NOT NULL,
+    end_date date NOT NULL,
+    entry_count bigint NOT NULL DEFAULT 0,
+    line_count bigint NOT NULL DEFAULT 0,
+    total_debit numeric(14,2) NOT NULL DEFAULT 0,
+    user_id bigint NOT NULL REFERENCES users (id),
+    created_at timestamp with time zone
+);
+CREATE INDEX IF NOT EXISTS idx_journal_exports_user_id ON journal_exports (user_id);
+
+-- Journal entries already exported; an entry belongs to one batch at most
+CREATE TABLE IF NOT EXISTS journal_export_entries (
+    journal_entry_id bigint PRIMARY KEY REFERENCES journal_entries (id),
+    journal_export_id bigint NOT NULL REFERENCES journal_exports (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_journal_export_entries_journal_export_id ON journal_export_entries (journal_export_id);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS transaction_payments;
+DROP TABLE IF EXISTS exchange_rates;
+DROP TABLE IF EXISTS currencies;
+ALTER TABLE store_settings DROP COLUMN IF EXISTS base_currency;
@@ -0,0 +1,39 @@
+-- Currency every amount is kept and reported in
+ALTER TABLE store_settings ADD COLUMN IF NOT EXISTS base_currency varchar(3) NOT NULL DEFAULT 'IDR';
+
+-- Foreign currencies accepted as payment, with their current rate against the base currency
+CREATE TABLE IF NOT EXISTS currencies (
+    id bigserial PRIMARY KEY,
+    code varchar(3) NOT NULL,
+    name text NOT NULL,
+    symbol text,
+    rate numeric(18,6) NOT NULL,
+    rate_updated_at timestamp with time zone,
+    is_active boolean DEFAULT true,
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+    CONSTRAINT uni_currencies_code UNIQUE (code)
+);
+
+-- Every rate a currency was set to, entered by hand or imported
+CREATE TABLE IF NOT EXISTS exchange_rates (
+    id bigserial PRIMARY KEY,
+    currency_id bigint NOT NULL REFERENCES currencies (id),
+    rate numeric(18,6) NOT NULL,
+    source varchar(10) NOT NULL,
+    user_id bigint NOT NULL REFERENCES users (id),
+    created_at timestamp with time zone
+);
+CREATE INDEX IF NOT EXISTS idx_exchange_rates_currency_id ON exchange_rates (currency_id);
+CREATE INDEX IF NOT EXISTS idx_exchange_rates_user_id ON exchange_rates (user_id);
+
+-- What the customer paid per currency, with the rate it was converted at
+CREATE TABLE IF NOT EXISTS transaction_payments (
+    id bigserial PRIMARY KEY,
+    transaction_id bigint NOT NULL REFERENCES transactions (id),
+    currency varchar(3) NOT NULL,
+    amount numeric(14,2) NOT NULL,
+    rate numeric(18,6) NOT NULL,
+    base_amount numeric(14,2) NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_transaction_payments_transaction_id ON transaction_payments (transaction_id);
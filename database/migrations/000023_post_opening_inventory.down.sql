@@ -0,0 +1,8 @@
+DELETE FROM journal_export_entries WHERE journal_entry_id IN (
+    SELECT id FROM journal_entries WHERE source = 'inventory' AND description = 'Saldo awal persediaan' AND inventory_log_id IS NULL
+);
+DELETE FROM journal_lines WHERE journal_entry_id IN (
+    SELECT id FROM journal_entries WHERE source = 'inventory' AND description = 'Saldo awal persediaan' AND inventory_log_id IS NULL
+);
+DELETE FROM journal_entries WHERE source = 'inventory' AND description = 'Saldo awal persediaan' AND inventory_log_id IS NULL;
+DELETE FROM accounts a WHERE code = '3200' AND NOT EXISTS (SELECT 1 FROM journal_lines WHERE account_id = a.id);
@@ -0,0 +1,53 @@
+-- Equity the value of stock on hand is booked against when it is first recorded
+INSERT INTO accounts (code, name, type, is_system, is_active, created_at, updated_at) VALUES
+    ('3200', 'Ekuitas Saldo Awal', 'equity', true, true, now(), now())
+ON CONFLICT (code) DO NOTHING;
+
+-- Opening stock and the cost of sales made before the ledger were never
+-- posted, so bring the inventory account to the value of the stock on hand,
+-- costed like the stock value report
+DO $$
+DECLARE
+    stock_value numeric;
+    booked numeric;
+    difference numeric;
+    entry_id bigint;
+    inventory_id bigint;
+    opening_id bigint;
+BEGIN
+    SELECT id INTO inventory_id FROM accounts WHERE code = '1300';
+    SELECT id INTO opening_id FROM accounts WHERE code = '3200';
+
+    IF (SELECT costing_method FROM store_settings ORDER BY id LIMIT 1) = 'fifo' THEN
+        SELECT COALESCE(SUM(COALESCE(layers.value, 0) + GREATEST(products.stock - COALESCE(layers.remaining, 0), 0) * products.cost), 0)
+        INTO stock_value
+        FROM products
+        LEFT JOIN (
+            SELECT product_id, SUM(remaining * unit_cost) AS value, SUM(remaining) AS remaining
+            FROM cost_layers WHERE remaining > 0 GROUP BY product_id
+        ) layers ON layers.product_id = products.id
+        WHERE products.deleted_at IS NULL AND products.stock > 0;
+    ELSE
+        SELECT COALESCE(SUM(stock * cost), 0) INTO stock_value
+        FROM products WHERE deleted_at IS NULL AND stock > 0;
+    END IF;
+
+    SELECT COALESCE(SUM(debit - credit), 0) INTO booked FROM journal_lines WHERE account_id = inventory_id;
+    difference := round(stock_value - booked, 2);
+    IF difference = 0 OR NOT EXISTS (SELECT 1 FROM users) THEN
+        RETURN;
+    END IF;
+
+    INSERT INTO journal_entries (date, source, description, user_id, created_at)
+    VALUES (now(), 'inventory', 'Saldo awal persediaan', (SELECT min(id) FROM users), now())
+    RETURNING id INTO entry_id;
+    IF difference > 0 THEN
+        INSERT INTO journal_lines (journal_entry_id, account_id, debit, credit, memo) VALUES
+            (entry_id, inventory_id, difference, 0, 'opening'),
+            (entry_id, opening_id, 0, difference, 'opening');
+    ELSE
+        INSERT INTO journal_lines (journal_entry_id, account_id, debit, credit, memo) VALUES
+            (entry_id, opening_id, -difference, 0, 'opening'),
+            (entry_id, inventory_id, 0, -difference, 'opening');
+    END IF;
+END $$;
@@ -0,0 +1 @@
+ALTER TABLE stock_opname_counts DROP COLUMN IF EXISTS missing_serials;
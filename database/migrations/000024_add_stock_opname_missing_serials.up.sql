@@ -0,0 +1 @@
+ALTER TABLE stock_opname_counts ADD COLUMN IF NOT EXISTS missing_serials JSONB;
@@ -7,6 +7,8 @@ import (
 	"strconv"
 	"strings"
 
+	"pos-api/internal/pkg/scale"
+
 	"github.com/joho/godotenv"
 )
 
@@ -16,6 +18,37 @@ type Config struct {
 	JWTSecret   string
 	CORSOrigins string
 	Environment string
+
+	// In-store EAN-13 prefix range used for internally allocated barcodes (GS1 restricted circulation,
+	// 20-27; 28 and 29 are left to weighing scale labels)
+	BarcodePrefixMin int
+	BarcodePrefixMax int
+
+	// GS1 variable-measure patterns for weighing scale labels, e.g. "28PPPPPWWWWWC:3,29PPPPRRRRRRC:0"
+	ScaleBarcodePatterns string
+
+	// How often the scheduler books due recurring expenses, in minutes; 0 turns it off
+	RecurringExpenseIntervalMinutes int
+
+	// Budget alerts: the default share of a budget, in percent, whose
+	// spending raises an alert, and how often spending is checked, in
+	// minutes; 0 turns the check off
+	BudgetAlertPercent         int
+	BudgetAlertIntervalMinutes int
+
+	// Blob storage for attachments: "local" keeps them under StorageDir, "s3"
+	// in an S3-compatible bucket
+	StorageDriver string
+	StorageDir    string
+	S3Endpoint    string
+	S3Region      string
+	S3Bucket      string
+	S3AccessKey   string
+	S3SecretKey   string
+	S3PathStyle   bool
+
+	// Largest attachment accepted, in megabytes
+	AttachmentMaxSizeMB int
 }
 
 func LoadConfig() *Config {
@@ -42,6 +75,27 @@ func LoadConfig() *Config {
 		JWTSecret:   getEnv("JWT_SECRET", "verysecretkey"), // Default for dev, warn in prod
 		CORSOrigins: getEnv("CORS_ORIGINS", "http://localhost:5173,http://localhost:5174"),
 		Environment: getEnv("APP_ENV", "development"),
+
+		BarcodePrefixMin: getEnvInt("BARCODE_PREFIX_MIN", 20),
+		BarcodePrefixMax: getEnvInt("BARCODE_PREFIX_MAX", 27),
+
+		ScaleBarcodePatterns: getEnv("SCALE_BARCODE_PATTERNS", scale.DefaultPatterns),
+
+		RecurringExpenseIntervalMinutes: getEnvInt("RECURRING_EXPENSE_INTERVAL_MINUTES", 60),
+
+		BudgetAlertPercent:         getEnvInt("BUDGET_ALERT_PERCENT", 80),
+		BudgetAlertIntervalMinutes: getEnvInt("BUDGET_ALERT_INTERVAL_MINUTES", 15),
+
+		StorageDriver: getEnv("STORAGE_DRIVER", "local"),
+		StorageDir:    getEnv("STORAGE_DIR", "./storage"),
+		S3Endpoint:    getEnv("S3_ENDPOINT", ""),
+		S3Region:      getEnv("S3_REGION", "us-east-1"),
+		S3Bucket:      getEnv("S3_BUCKET", ""),
+		S3AccessKey:   getEnv("S3_ACCESS_KEY", ""),
+		S3SecretKey:   getEnv("S3_SECRET_KEY", ""),
+		S3PathStyle:   getEnv("S3_PATH_STYLE", "false") == "true",
+
+		AttachmentMaxSizeMB: getEnvInt("ATTACHMENT_MAX_SIZE_MB", 5),
 	}
 }
 
@@ -52,6 +106,16 @@ func getEnv(key, fallback string) string {
 	return fallback
 }
 
+func getEnvInt(key string, fallback int) int {
+	if value, ok := os.LookupEnv(key); ok {
+		if n, err := strconv.Atoi(value); err == nil {
+			return n
+		}
+		log.Printf("Invalid integer for %s, using default %d", key, fallback)
+	}
+	return fallback
+}
+
 func (c *Config) GetCORSOrigins() []string {
 	return strings.Split(c.CORSOrigins, ",")
 }
@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"pos-api/internal/services"
+	"strconv"
+	"time"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AccountingHandler struct {
+	service services.AccountingService
+}
+
+func NewAccountingHandler(s services.AccountingService) *AccountingHandler {
+	return &AccountingHandler{service: s}
+}
+
+// GetAccounts handles GET /accounting/accounts
+func (h *AccountingHandler) GetAccounts(c *fiber.Ctx) error {
+	accounts, err := h.service.GetAccounts(c.UserContext())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Accounts retrieved",
+		"data":    accounts,
+	})
+}
+
+// GetJournal handles GET /accounting/journal?source=&account_id=&start_date=&end_date=
+func (h *AccountingHandler) GetJournal(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	pageSize, _ := strconv.Atoi(c.Query("pageSize", "20"))
+	accountID, _ := strconv.ParseUint(c.Query("account_id", "0"), 10, 64)
+
+	var startDate, endDate *time.Time
+	if sd := c.Query("start_date"); sd != "" {
+		if t, err := time.Parse("2006-01-02", sd); err == nil {
+			startDate = &t
+		}
+	}
+	if ed := c.Query("end_date"); ed != "" {
+		if t, err := time.Parse("2006-01-02", ed); err == nil {
+			t = t.Add(23*time.Hour + 59*time.Minute + 59*time.Second)
+			endDate = &t
+		}
+	}
+
+	entries, total, err := h.service.GetJournal(c.UserContext(), page, pageSize, c.Query("source"), uint(accountID), startDate, endDate)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"message":     "Journal entries retrieved",
+		"data":        entries,
+		"total_items": total,
+		"page":        page,
+		"page_size":   pageSize,
+	})
+}
+
+// GetJournalEntry handles GET /accounting/journal/:id
+func (h *AccountingHandler) GetJournalEntry(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	entry, err := h.service.GetJournalEntry(c.UserContext(), uint(id))
+	if err != nil {
+		if customErrors.Is(err, customErrors.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Journal entry not found"})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Journal entry retrieved",
+		"data":    entry,
+	})
+}
+
+// GetTrialBalance handles GET /accounting/trial-balance?date=YYYY-MM-DD (default today)
+func (h *AccountingHandler) GetTrialBalance(c *fiber.Ctx) error {
+	asOf := time.Now()
+	if d := c.Query("date"); d != "" {
+		t, err := time.Parse("2006-01-02", d)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid date format, use YYYY-MM-DD"})
+		}
+		asOf = t
+	}
+
+	balance, err := h.service.GetTrialBalance(c.UserContext(), asOf)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Trial balance retrieved",
+		"data":    balance,
+	})
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"pos-api/internal/services"
+	"time"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AccountingPeriodHandler struct {
+	service services.AccountingPeriodService
+}
+
+func NewAccountingPeriodHandler(s services.AccountingPeriodService) *AccountingPeriodHandler {
+	return &AccountingPeriodHandler{service: s}
+}
+
+type periodActionRequest struct {
+	Reason string `json:"reason"`
+}
+
+// periodErrorStatus maps period service errors to HTTP status codes.
+func periodErrorStatus(err error) int {
+	if customErrors.Is(err, customErrors.ErrConflict) {
+		return fiber.StatusConflict
+	}
+	return fiber.StatusBadRequest
+}
+
+// ListPeriods handles GET /accounting/periods
+func (h *AccountingPeriodHandler) ListPeriods(c *fiber.Ctx) error {
+	periods, err := h.service.GetAll(c.UserContext())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Accounting periods retrieved",
+		"data":    periods,
+	})
+}
+
+// GetPeriodLogs handles GET /accounting/periods/logs?month=YYYY-MM
+func (h *AccountingPeriodHandler) GetPeriodLogs(c *fiber.Ctx) error {
+	logs, err := h.service.GetLogs(c.UserContext(), c.Query("month"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Accounting period logs retrieved",
+		"data":    logs,
+	})
+}
+
+// ClosePeriod handles POST /accounting/periods/:month/close
+func (h *AccountingPeriodHandler) ClosePeriod(c *fiber.Ctx) error {
+	var req periodActionRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid input"})
+		}
+	}
+
+	userIDFloat, ok := c.Locals("userID").(float64)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "User ID not found in token"})
+	}
+
+	period, err := h.service.Close(c.UserContext(), c.Params("month"), time.Now(), uint(userIDFloat), req.Reason)
+	if err != nil {
+		return c.Status(periodErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Accounting period closed",
+		"data":    period,
+	})
+}
+
+// ReopenPeriod handles POST /accounting/periods/:month/reopen
+func (h *AccountingPeriodHandler) ReopenPeriod(c *fiber.Ctx) error {
+	var req periodActionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid input"})
+	}
+
+	userIDFloat, ok := c.Locals("userID").(float64)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "User ID not found in token"})
+	}
+
+	period, err := h.service.Reopen(c.UserContext(), c.Params("month"), uint(userIDFloat), req.Reason)
+	if err != nil {
+		return c.Status(periodErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Accounting period reopened",
+		"data":    period,
+	})
+}
@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"mime"
+	"pos-api/internal/services"
+	"strconv"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// AttachmentHandler serves the attachments of one kind of record, the record
+// being the route's :id.
+type AttachmentHandler struct {
+	service services.AttachmentService
+	owner   string // One of the models.AttachmentOwner* kinds
+}
+
+func NewAttachmentHandler(s services.AttachmentService, owner string) *AttachmentHandler {
+	return &AttachmentHandler{service: s, owner: owner}
+}
+
+// attachmentErrorStatus maps service errors to HTTP status codes.
+func attachmentErrorStatus(err error) int {
+	if customErrors.Is(err, customErrors.ErrNotFound) {
+		return fiber.StatusNotFound
+	}
+	return fiber.StatusBadRequest
+}
+
+// attachmentIDs parses the record's :id and the :attachmentId.
+func attachmentIDs(c *fiber.Ctx) (ownerID, id uint, ok bool) {
+	o, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	a, err := strconv.ParseUint(c.Params("attachmentId", "0"), 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return uint(o), uint(a), true
+}
+
+// UploadAttachment handles POST /{records}/:id/attachments (multipart field "file")
+func (h *AttachmentHandler) UploadAttachment(c *fiber.Ctx) error {
+	ownerID, _, ok := attachmentIDs(c)
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	userIDFloat, ok := c.Locals("userID").(float64)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "User ID not found in token"})
+	}
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "A file is required in the 'file' field"})
+	}
+	file, err := header.Open()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Could not read the uploaded file"})
+	}
+	defer file.Close()
+
+	attachment, err := h.service.Upload(c.UserContext(), h.owner, ownerID, services.UploadedFile{
+		Name:    header.Filename,
+		Size:    header.Size,
+		Content: file,
+	}, uint(userIDFloat))
+	if err != nil {
+		return c.Status(attachmentErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Attachment uploaded",
+		"data":    attachment,
+	})
+}
+
+// ListAttachments handles GET /{records}/:id/attachments
+func (h *AttachmentHandler) ListAttachments(c *fiber.Ctx) error {
+	ownerID, _, ok := attachmentIDs(c)
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	attachments, err := h.service.GetByOwner(c.UserContext(), h.owner, ownerID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Attachments retrieved",
+		"data":    attachments,
+	})
+}
+
+// DownloadAttachment handles GET /{records}/:id/attachments/:attachmentId
+func (h *AttachmentHandler) DownloadAttachment(c *fiber.Ctx) error {
+	ownerID, id, ok := attachmentIDs(c)
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	attachment, file, err := h.service.Open(c.UserContext(), h.owner, ownerID, id)
+	if err != nil {
+		return c.Status(attachmentErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	c.Set(fiber.HeaderContentType, attachment.ContentType)
+	c.Set(fiber.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}))
+	c.Set(fiber.HeaderXContentTypeOptions, "nosniff")
+	c.Set(fiber.HeaderCacheControl, "private, no-store")
+	// The response closes file once it is sent
+	return c.SendStream(file, int(attachment.Size))
+}
+
+// DeleteAttachment handles DELETE /{records}/:id/attachments/:attachmentId
+func (h *AttachmentHandler) DeleteAttachment(c *fiber.Ctx) error {
+	ownerID, id, ok := attachmentIDs(c)
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	if err := h.service.Delete(c.UserContext(), h.owner, ownerID, id); err != nil {
+		return c.Status(attachmentErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{"message": "Attachment deleted"})
+}
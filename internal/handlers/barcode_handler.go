@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"fmt"
 	"image/png"
+	"pos-api/internal/pkg/gtin"
+	"pos-api/internal/pkg/labels"
 	"pos-api/internal/services"
 	"strconv"
 
@@ -30,7 +32,7 @@ func NewBarcodeHandler(ps services.ProductService) *BarcodeHandler {
 // @Produce      image/png
 // @Security     ApiKeyAuth
 // @Param        id path int true "Product ID"
-// @Param        type query string false "Barcode type: code128 or ean13" default(code128)
+// @Param        type query string false "Barcode type: code128 (SKU) or ean13 (product barcode)" default(code128)
 // @Param        width query int false "Width in pixels" default(300)
 // @Param        height query int false "Height in pixels" default(100)
 // @Success      200 {file} file "PNG barcode image"
@@ -60,8 +62,11 @@ func (h *BarcodeHandler) GenerateBarcode(c *fiber.Ctx) error {
 
 	switch barcodeType {
 	case "ean13":
-		// EAN-13 requires exactly 12 or 13 digits
-		bc, err = ean.Encode(sku)
+		// EAN-13 encodes the product barcode, which must be a valid 13-digit EAN
+		if !gtin.ValidEAN13(product.Barcode) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Product barcode is not a valid EAN-13"})
+		}
+		bc, err = ean.Encode(product.Barcode)
 	default:
 		// Code128 supports any ASCII string
 		bc, err = code128.Encode(sku)
@@ -146,3 +151,129 @@ func (h *BarcodeHandler) GenerateBatchBarcodes(c *fiber.Ctx) error {
 		"count": len(results),
 	})
 }
+
+// LabelSheetItem is one product entry in a label sheet request
+type LabelSheetItem struct {
+	ProductID uint `json:"product_id"`
+	Copies    int  `json:"copies"` // Defaults to 1
+}
+
+// LabelSheetRequest is the request body for generating printable label sheets
+type LabelSheetRequest struct {
+	Items  []LabelSheetItem `json:"items"`
+	Layout string           `json:"layout"` // e.g. "a4_3x10", "thermal_33x15"
+	Type   string           `json:"type"`   // "code128", "ean13" or "qr"
+	Format string           `json:"format"` // "pdf" or "png"
+	DPI    int              `json:"dpi"`    // Optional, 72-600, defaults to the layout's DPI
+}
+
+// ListLabelLayouts returns the predefined label media layouts
+// @Summary      List Label Layouts
+// @Description  List the predefined label sheet and roll layouts supported by the label generator
+// @Tags         Barcode
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Success      200 {object} map[string]interface{} "Available layouts"
+// @Router       /barcode/labels/layouts [get]
+func (h *BarcodeHandler) ListLabelLayouts(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{
+		"data": labels.Layouts(),
+	})
+}
+
+// GenerateLabelSheet renders printable price labels for multiple products
+// @Summary      Generate Label Sheet
+// @Description  Render price labels (name, price and barcode) laid out for common label paper as a single PDF or PNG
+// @Tags         Barcode
+// @Accept       json
+// @Produce      application/pdf
+// @Produce      image/png
+// @Security     ApiKeyAuth
+// @Param        body body LabelSheetRequest true "Products, copies, layout and barcode type"
+// @Success      200 {file} file "PDF or PNG label sheet"
+// @Failure      400 {object} map[string]string "Invalid request"
+// @Failure      404 {object} map[string]string "Product not found"
+// @Router       /barcode/labels [post]
+func (h *BarcodeHandler) GenerateLabelSheet(c *fiber.Ctx) error {
+	var req LabelSheetRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	if len(req.Items) == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "No products provided"})
+	}
+
+	if req.Layout == "" {
+		req.Layout = "a4_3x10"
+	}
+	layout, ok := labels.GetLayout(req.Layout)
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("Unknown label layout '%s'", req.Layout)})
+	}
+
+	if req.DPI != 0 && (req.DPI < labels.MinDPI || req.DPI > labels.MaxDPI) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("DPI must be between %d and %d", labels.MinDPI, labels.MaxDPI)})
+	}
+
+	if req.Type == "" {
+		req.Type = labels.SymbologyCode128
+	}
+	if req.Format == "" {
+		req.Format = "pdf"
+	}
+
+	var items []labels.Label
+	for _, item := range req.Items {
+		product, err := h.productService.GetProduct(c.UserContext(), item.ProductID)
+		if err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": fmt.Sprintf("Product %d not found", item.ProductID)})
+		}
+
+		// EAN-13 needs the numeric barcode; other symbologies encode the SKU like GenerateBarcode.
+		code := product.SKU
+		if req.Type == labels.SymbologyEAN13 {
+			code = product.Barcode
+		}
+		if code == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("Product %s has no code to encode", product.Name)})
+		}
+
+		copies := item.Copies
+		if copies <= 0 {
+			copies = 1
+		}
+		if len(items)+copies > labels.MaxLabels {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("Too many labels (max %d)", labels.MaxLabels)})
+		}
+		for i := 0; i < copies; i++ {
+			items = append(items, labels.Label{Name: product.Name, Price: product.Price, Code: code})
+		}
+	}
+
+	opts := labels.Options{Layout: layout, Symbology: req.Type, DPI: req.DPI}
+
+	var buf bytes.Buffer
+	var contentType string
+	var err error
+	switch req.Format {
+	case "png":
+		contentType = "image/png"
+		err = labels.WritePNG(&buf, opts, items)
+	case "pdf":
+		contentType = "application/pdf"
+		err = labels.WritePDF(&buf, opts, items)
+	default:
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Format must be 'pdf' or 'png'"})
+	}
+
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": fmt.Sprintf("Failed to render labels: %s", err.Error()),
+		})
+	}
+
+	c.Set("Content-Type", contentType)
+	c.Set("Content-Disposition", fmt.Sprintf("inline; filename=labels_%s.%s", layout.Name, req.Format))
+	return c.Send(buf.Bytes())
+}
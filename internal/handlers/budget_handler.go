@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"pos-api/internal/services"
+	"strconv"
+	"time"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type BudgetHandler struct {
+	service services.BudgetService
+}
+
+func NewBudgetHandler(s services.BudgetService) *BudgetHandler {
+	return &BudgetHandler{service: s}
+}
+
+// budgetErrorStatus maps service errors to HTTP status codes.
+func budgetErrorStatus(err error) int {
+	switch {
+	case customErrors.Is(err, customErrors.ErrNotFound):
+		return fiber.StatusNotFound
+	case customErrors.Is(err, customErrors.ErrConflict):
+		return fiber.StatusConflict
+	default:
+		return fiber.StatusBadRequest
+	}
+}
+
+// CreateBudget handles POST /cash-flow/budgets
+func (h *BudgetHandler) CreateBudget(c *fiber.Ctx) error {
+	var req services.BudgetRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid input"})
+	}
+
+	userIDFloat, ok := c.Locals("userID").(float64)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "User ID not found in token"})
+	}
+
+	budget, err := h.service.Create(c.UserContext(), req, uint(userIDFloat))
+	if err != nil {
+		return c.Status(budgetErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Budget created",
+		"data":    budget,
+	})
+}
+
+// UpdateBudget handles PUT /cash-flow/budgets/:id
+func (h *BudgetHandler) UpdateBudget(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	var req services.BudgetRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid input"})
+	}
+
+	budget, err := h.service.Update(c.UserContext(), uint(id), req)
+	if err != nil {
+		return c.Status(budgetErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Budget updated",
+		"data":    budget,
+	})
+}
+
+// DeleteBudget handles DELETE /cash-flow/budgets/:id
+func (h *BudgetHandler) DeleteBudget(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	if err := h.service.Delete(c.UserContext(), uint(id)); err != nil {
+		return c.Status(budgetErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{"message": "Budget deleted"})
+}
+
+// GetBudget handles GET /cash-flow/budgets/:id
+func (h *BudgetHandler) GetBudget(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	budget, err := h.service.GetByID(c.UserContext(), uint(id))
+	if err != nil {
+		return c.Status(budgetErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Budget retrieved",
+		"data":    budget,
+	})
+}
+
+// ListBudgets handles GET /cash-flow/budgets?month=2026-03
+func (h *BudgetHandler) ListBudgets(c *fiber.Ctx) error {
+	budgets, err := h.service.GetByMonth(c.UserContext(), c.Query("month"), time.Now())
+	if err != nil {
+		return c.Status(budgetErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Budgets retrieved",
+		"data":    budgets,
+	})
+}
+
+// GetReport handles GET /cash-flow/budgets/report?month=2026-03
+func (h *BudgetHandler) GetReport(c *fiber.Ctx) error {
+	report, err := h.service.GetReport(c.UserContext(), c.Query("month"), time.Now())
+	if err != nil {
+		return c.Status(budgetErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Budget report retrieved",
+		"data":    report,
+	})
+}
@@ -5,6 +5,8 @@ import (
 	"strconv"
 	"time"
 
+	customErrors "pos-api/internal/pkg/errors"
+
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -31,7 +33,7 @@ func (h *CashFlowHandler) CreateCashFlow(c *fiber.Ctx) error {
 
 	cf, err := h.service.Create(c.UserContext(), req, userID)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		return c.Status(cashFlowErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
@@ -54,7 +56,7 @@ func (h *CashFlowHandler) UpdateCashFlow(c *fiber.Ctx) error {
 
 	cf, err := h.service.Update(c.UserContext(), uint(id), req)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		return c.Status(cashFlowErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
@@ -71,12 +73,26 @@ func (h *CashFlowHandler) DeleteCashFlow(c *fiber.Ctx) error {
 	}
 
 	if err := h.service.Delete(c.UserContext(), uint(id)); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		return c.Status(cashFlowErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Cash flow entry deleted"})
 }
 
+// ForceDeleteCashFlow handles DELETE /cash-flow/:id/force
+func (h *CashFlowHandler) ForceDeleteCashFlow(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	if err := h.service.ForceDelete(c.UserContext(), uint(id)); err != nil {
+		return c.Status(cashFlowErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Cash flow entry permanently deleted"})
+}
+
 // GetCashFlow handles GET /cash-flow/:id
 func (h *CashFlowHandler) GetCashFlow(c *fiber.Ctx) error {
 	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
@@ -167,3 +183,102 @@ func (h *CashFlowHandler) GetSummary(c *fiber.Ctx) error {
 		"data":    summary,
 	})
 }
+
+// cashFlowErrorStatus maps cash flow and category service errors to HTTP
+// status codes.
+func cashFlowErrorStatus(err error) int {
+	switch {
+	case customErrors.Is(err, customErrors.ErrNotFound):
+		return fiber.StatusNotFound
+	case customErrors.Is(err, customErrors.ErrConflict), customErrors.Is(err, customErrors.ErrPeriodClosed):
+		return fiber.StatusConflict
+	default:
+		return fiber.StatusBadRequest
+	}
+}
+
+// ListCategories handles GET /cash-flow/categories?type=&active=
+func (h *CashFlowHandler) ListCategories(c *fiber.Ctx) error {
+	categories, err := h.service.GetCategories(c.UserContext(), c.Query("type"), c.QueryBool("active", false))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Cash flow categories retrieved",
+		"data":    categories,
+	})
+}
+
+// GetCategory handles GET /cash-flow/categories/:id
+func (h *CashFlowHandler) GetCategory(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	category, err := h.service.GetCategoryByID(c.UserContext(), uint(id))
+	if err != nil {
+		return c.Status(cashFlowErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Cash flow category retrieved",
+		"data":    category,
+	})
+}
+
+// CreateCategory handles POST /cash-flow/categories
+func (h *CashFlowHandler) CreateCategory(c *fiber.Ctx) error {
+	var req services.CashFlowCategoryRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid input"})
+	}
+
+	category, err := h.service.CreateCategory(c.UserContext(), req)
+	if err != nil {
+		return c.Status(cashFlowErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Cash flow category created",
+		"data":    category,
+	})
+}
+
+// UpdateCategory handles PUT /cash-flow/categories/:id
+func (h *CashFlowHandler) UpdateCategory(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	var req services.CashFlowCategoryRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid input"})
+	}
+
+	category, err := h.service.UpdateCategory(c.UserContext(), uint(id), req)
+	if err != nil {
+		return c.Status(cashFlowErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Cash flow category updated",
+		"data":    category,
+	})
+}
+
+// DeleteCategory handles DELETE /cash-flow/categories/:id
+func (h *CashFlowHandler) DeleteCategory(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	if err := h.service.DeleteCategory(c.UserContext(), uint(id)); err != nil {
+		return c.Status(cashFlowErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{"message": "Cash flow category deleted"})
+}
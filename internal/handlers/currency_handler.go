@@ -0,0 +1,208 @@
+package handlers
+
+import (
+	"pos-api/internal/services"
+	"strconv"
+	"time"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type CurrencyHandler struct {
+	service services.CurrencyService
+}
+
+func NewCurrencyHandler(s services.CurrencyService) *CurrencyHandler {
+	return &CurrencyHandler{service: s}
+}
+
+// currencyErrorStatus maps service errors to HTTP status codes.
+func currencyErrorStatus(err error) int {
+	switch {
+	case customErrors.Is(err, customErrors.ErrNotFound):
+		return fiber.StatusNotFound
+	case customErrors.Is(err, customErrors.ErrConflict):
+		return fiber.StatusConflict
+	default:
+		return fiber.StatusBadRequest
+	}
+}
+
+// Create handles POST /currencies
+func (h *CurrencyHandler) Create(c *fiber.Ctx) error {
+	var req services.CurrencyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid input"})
+	}
+
+	userIDFloat, ok := c.Locals("userID").(float64)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "User ID not found in token"})
+	}
+
+	currency, err := h.service.Create(c.UserContext(), req, uint(userIDFloat))
+	if err != nil {
+		return c.Status(currencyErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Currency created",
+		"data":    currency,
+	})
+}
+
+// Update handles PUT /currencies/:id
+func (h *CurrencyHandler) Update(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	var req services.UpdateCurrencyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid input"})
+	}
+
+	currency, err := h.service.Update(c.UserContext(), uint(id), req)
+	if err != nil {
+		return c.Status(currencyErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Currency updated",
+		"data":    currency,
+	})
+}
+
+// Get handles GET /currencies/:id
+func (h *CurrencyHandler) Get(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	currency, err := h.service.GetByID(c.UserContext(), uint(id))
+	if err != nil {
+		return c.Status(currencyErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Currency retrieved",
+		"data":    currency,
+	})
+}
+
+// List handles GET /currencies?active=true
+func (h *CurrencyHandler) List(c *fiber.Ctx) error {
+	currencies, err := h.service.GetAll(c.UserContext(), c.QueryBool("active"))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Currencies retrieved",
+		"data":    currencies,
+	})
+}
+
+// SetRate handles POST /currencies/:id/rate
+func (h *CurrencyHandler) SetRate(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	var req struct {
+		Rate float64 `json:"rate"`
+	}
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid input"})
+	}
+
+	userIDFloat, ok := c.Locals("userID").(float64)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "User ID not found in token"})
+	}
+
+	currency, err := h.service.SetRate(c.UserContext(), uint(id), req.Rate, uint(userIDFloat))
+	if err != nil {
+		return c.Status(currencyErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Exchange rate updated",
+		"data":    currency,
+	})
+}
+
+// ImportRates handles POST /currencies/rates/import (multipart fields "file",
+// optional "delimiter" and "decimal_comma")
+func (h *CurrencyHandler) ImportRates(c *fiber.Ctx) error {
+	userIDFloat, ok := c.Locals("userID").(float64)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "User ID not found in token"})
+	}
+
+	req := services.RateImport{Delimiter: c.FormValue("delimiter")}
+	if raw := c.FormValue("decimal_comma"); raw != "" {
+		decimalComma, err := strconv.ParseBool(raw)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "decimal_comma must be true or false"})
+		}
+		req.DecimalComma = decimalComma
+	}
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "A CSV file is required in the 'file' field"})
+	}
+	file, err := header.Open()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Could not read the uploaded file"})
+	}
+	defer file.Close()
+	req.Content = file
+
+	rates, err := h.service.ImportRates(c.UserContext(), req, uint(userIDFloat))
+	if err != nil {
+		return c.Status(currencyErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Exchange rates imported",
+		"data":    rates,
+	})
+}
+
+// RateHistory handles GET /currencies/:id/rates
+func (h *CurrencyHandler) RateHistory(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	rates, err := h.service.GetRateHistory(c.UserContext(), uint(id))
+	if err != nil {
+		return c.Status(currencyErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Exchange rate history retrieved",
+		"data":    rates,
+	})
+}
+
+// Receipts handles GET /currencies/receipts?start_date=&end_date=
+func (h *CurrencyHandler) Receipts(c *fiber.Ctx) error {
+	report, err := h.service.GetReceipts(c.UserContext(), c.Query("start_date"), c.Query("end_date"), time.Now())
+	if err != nil {
+		return c.Status(currencyErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Currency receipts retrieved",
+		"data":    report,
+	})
+}
@@ -64,7 +64,7 @@ func (h *ExportHandler) ExportProductsCSV(c *fiber.Ctx) error {
 			p.Description,
 			fmt.Sprintf("%.2f", p.Price),
 			fmt.Sprintf("%.2f", p.Cost),
-			strconv.Itoa(p.Stock),
+			strconv.FormatFloat(p.Stock, 'f', -1, 64),
 			p.Category.Name,
 			p.CreatedAt.Format("2006-01-02 15:04:05"),
 		})
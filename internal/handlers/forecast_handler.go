@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"strconv"
+
+	"pos-api/internal/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ForecastHandler handles demand forecasting HTTP requests
+type ForecastHandler struct {
+	service services.ForecastService
+}
+
+// NewForecastHandler creates a new forecast handler
+func NewForecastHandler(s services.ForecastService) *ForecastHandler {
+	return &ForecastHandler{service: s}
+}
+
+// GetForecast handles GET /reports/forecast
+// @Summary      Get Sales Forecast
+// @Description  Project sales for the next days per product, category and for the whole store with weekly seasonality, and estimate each product's stock-out date from its current stock. Requires Admin or Manager role.
+// @Tags         Reports
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        days query int false "Days to forecast (default: 14, max: 90)" default(14)
+// @Param        history_days query int false "Days of sales history to fit on (default: 56, 14-365)" default(56)
+// @Success      200 {object} utils.SuccessResponse{data=services.SalesForecast} "Forecast retrieved successfully"
+// @Failure      400 {object} utils.ErrorResponse "Invalid period"
+// @Failure      401 {object} utils.ErrorResponse "Authentication required"
+// @Failure      403 {object} utils.ErrorResponse "Insufficient permissions"
+// @Router       /reports/forecast [get]
+func (h *ForecastHandler) GetForecast(c *fiber.Ctx) error {
+	days, err := strconv.Atoi(c.Query("days", "14"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Parameter days tidak valid"})
+	}
+	historyDays, err := strconv.Atoi(c.Query("history_days", "56"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Parameter history_days tidak valid"})
+	}
+
+	result, err := h.service.GetForecast(c.UserContext(), days, historyDays)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Prakiraan penjualan berhasil dimuat",
+		"data":    result,
+	})
+}
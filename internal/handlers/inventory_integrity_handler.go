@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"pos-api/internal/services"
+	"strconv"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type InventoryIntegrityHandler struct {
+	service services.InventoryIntegrityService
+}
+
+func NewInventoryIntegrityHandler(s services.InventoryIntegrityService) *InventoryIntegrityHandler {
+	return &InventoryIntegrityHandler{service: s}
+}
+
+// Check handles GET /inventory/integrity?product_id=
+func (h *InventoryIntegrityHandler) Check(c *fiber.Ctx) error {
+	productID, _ := strconv.ParseUint(c.Query("product_id", "0"), 10, 64)
+
+	report, err := h.service.Check(c.UserContext(), uint(productID), false, 0)
+	if err != nil {
+		return integrityError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Inventory integrity checked",
+		"data":    report,
+	})
+}
+
+// Fix handles POST /inventory/integrity/fix?product_id=
+func (h *InventoryIntegrityHandler) Fix(c *fiber.Ctx) error {
+	productID, _ := strconv.ParseUint(c.Query("product_id", "0"), 10, 64)
+
+	userIDFloat, ok := c.Locals("userID").(float64)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "User ID not found in token"})
+	}
+
+	report, err := h.service.Check(c.UserContext(), uint(productID), true, uint(userIDFloat))
+	if err != nil {
+		return integrityError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Inventory integrity corrected",
+		"data":    report,
+	})
+}
+
+func integrityError(c *fiber.Ctx, err error) error {
+	if customErrors.Is(err, customErrors.ErrNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Product not found"})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+}
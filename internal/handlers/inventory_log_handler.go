@@ -5,6 +5,8 @@ import (
 	"strconv"
 	"time"
 
+	customErrors "pos-api/internal/pkg/errors"
+
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -33,7 +35,7 @@ func (h *InventoryLogHandler) AdjustStock(c *fiber.Ctx) error {
 
 	log, err := h.service.AdjustStock(c.UserContext(), req, userID)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		return c.Status(periodClosedStatus(err)).JSON(fiber.Map{"error": err.Error()})
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
@@ -130,3 +132,60 @@ func (h *InventoryLogHandler) GetInventoryStats(c *fiber.Ctx) error {
 		"data":    stats,
 	})
 }
+
+// GetShortfalls handles GET /inventory/shortfalls?status=open|all
+func (h *InventoryLogHandler) GetShortfalls(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	pageSize, _ := strconv.Atoi(c.Query("pageSize", "20"))
+
+	logs, total, err := h.service.GetShortfalls(c.UserContext(), page, pageSize, c.Query("status"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"message":     "Stock shortfalls retrieved",
+		"data":        logs,
+		"total_items": total,
+		"page":        page,
+		"page_size":   pageSize,
+	})
+}
+
+// ReviewShortfall handles POST /inventory/shortfalls/:id/review
+func (h *InventoryLogHandler) ReviewShortfall(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	var req struct {
+		Notes string `json:"notes"`
+	}
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid input"})
+		}
+	}
+
+	userIDFloat, ok := c.Locals("userID").(float64)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "User ID not found in token"})
+	}
+
+	log, err := h.service.ReviewShortfall(c.UserContext(), uint(id), uint(userIDFloat), req.Notes)
+	if err != nil {
+		status := fiber.StatusBadRequest
+		if customErrors.Is(err, customErrors.ErrNotFound) {
+			status = fiber.StatusNotFound
+		} else if customErrors.Is(err, customErrors.ErrConflict) {
+			status = fiber.StatusConflict
+		}
+		return c.Status(status).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Shortfall reviewed",
+		"data":    log,
+	})
+}
@@ -0,0 +1,214 @@
+package handlers
+
+import (
+	"fmt"
+	"pos-api/internal/services"
+	"strconv"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type JournalExportHandler struct {
+	service services.JournalExportService
+}
+
+func NewJournalExportHandler(s services.JournalExportService) *JournalExportHandler {
+	return &JournalExportHandler{service: s}
+}
+
+// journalExportErrorStatus maps service errors to HTTP status codes.
+func journalExportErrorStatus(err error) int {
+	switch {
+	case customErrors.Is(err, customErrors.ErrNotFound):
+		return fiber.StatusNotFound
+	case customErrors.Is(err, customErrors.ErrConflict):
+		return fiber.StatusConflict
+	default:
+		return fiber.StatusBadRequest
+	}
+}
+
+// CreateTemplate handles POST /accounting/export-templates
+func (h *JournalExportHandler) CreateTemplate(c *fiber.Ctx) error {
+	var req services.JournalExportTemplateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid input"})
+	}
+
+	userIDFloat, ok := c.Locals("userID").(float64)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "User ID not found in token"})
+	}
+
+	template, err := h.service.CreateTemplate(c.UserContext(), req, uint(userIDFloat))
+	if err != nil {
+		return c.Status(journalExportErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Export template created",
+		"data":    template,
+	})
+}
+
+// UpdateTemplate handles PUT /accounting/export-templates/:id
+func (h *JournalExportHandler) UpdateTemplate(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	var req services.JournalExportTemplateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid input"})
+	}
+
+	template, err := h.service.UpdateTemplate(c.UserContext(), uint(id), req)
+	if err != nil {
+		return c.Status(journalExportErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Export template updated",
+		"data":    template,
+	})
+}
+
+// DeleteTemplate handles DELETE /accounting/export-templates/:id
+func (h *JournalExportHandler) DeleteTemplate(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	if err := h.service.DeleteTemplate(c.UserContext(), uint(id)); err != nil {
+		return c.Status(journalExportErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{"message": "Export template deleted"})
+}
+
+// GetTemplate handles GET /accounting/export-templates/:id
+func (h *JournalExportHandler) GetTemplate(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	template, err := h.service.GetTemplate(c.UserContext(), uint(id))
+	if err != nil {
+		return c.Status(journalExportErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Export template retrieved",
+		"data":    template,
+	})
+}
+
+// ListTemplates handles GET /accounting/export-templates
+func (h *JournalExportHandler) ListTemplates(c *fiber.Ctx) error {
+	templates, err := h.service.GetTemplates(c.UserContext())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Export templates retrieved",
+		"data":    templates,
+		"default": services.DefaultExportLayout(),
+	})
+}
+
+// CreateExport handles POST /accounting/exports
+func (h *JournalExportHandler) CreateExport(c *fiber.Ctx) error {
+	var req services.JournalExportRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid input"})
+	}
+
+	userIDFloat, ok := c.Locals("userID").(float64)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "User ID not found in token"})
+	}
+
+	export, err := h.service.Create(c.UserContext(), req, uint(userIDFloat))
+	if err != nil {
+		return c.Status(journalExportErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Journal export created",
+		"data":    export,
+	})
+}
+
+// ListExports handles GET /accounting/exports
+func (h *JournalExportHandler) ListExports(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	pageSize, _ := strconv.Atoi(c.Query("pageSize", "20"))
+
+	exports, total, err := h.service.GetExports(c.UserContext(), page, pageSize)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"message":     "Journal exports retrieved",
+		"data":        exports,
+		"total_items": total,
+		"page":        page,
+		"page_size":   pageSize,
+	})
+}
+
+// GetExport handles GET /accounting/exports/:id
+func (h *JournalExportHandler) GetExport(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	export, err := h.service.GetExport(c.UserContext(), uint(id))
+	if err != nil {
+		return c.Status(journalExportErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Journal export retrieved",
+		"data":    export,
+	})
+}
+
+// DownloadExport handles GET /accounting/exports/:id/download
+func (h *JournalExportHandler) DownloadExport(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	file, err := h.service.Download(c.UserContext(), uint(id))
+	if err != nil {
+		return c.Status(journalExportErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	c.Set("Content-Type", "text/csv; charset=utf-8")
+	c.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", file.FileName))
+	return c.Send(file.Content)
+}
+
+// DeleteExport handles DELETE /accounting/exports/:id
+func (h *JournalExportHandler) DeleteExport(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	if err := h.service.Delete(c.UserContext(), uint(id)); err != nil {
+		return c.Status(journalExportErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{"message": "Journal export deleted, its entries will be exported again"})
+}
@@ -0,0 +1,245 @@
+package handlers
+
+import (
+	"pos-api/internal/services"
+	"strconv"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type LocationHandler struct {
+	service services.LocationService
+}
+
+func NewLocationHandler(s services.LocationService) *LocationHandler {
+	return &LocationHandler{service: s}
+}
+
+// locationErrorStatus maps service errors to HTTP status codes.
+func locationErrorStatus(err error) int {
+	switch {
+	case customErrors.Is(err, customErrors.ErrNotFound):
+		return fiber.StatusNotFound
+	case customErrors.Is(err, customErrors.ErrConflict):
+		return fiber.StatusConflict
+	default:
+		return fiber.StatusBadRequest
+	}
+}
+
+// CreateLocation handles POST /locations
+func (h *LocationHandler) CreateLocation(c *fiber.Ctx) error {
+	var req services.LocationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid input"})
+	}
+
+	location, err := h.service.Create(c.UserContext(), req)
+	if err != nil {
+		return c.Status(locationErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Location created",
+		"data":    location,
+	})
+}
+
+// UpdateLocation handles PUT /locations/:id
+func (h *LocationHandler) UpdateLocation(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	var req services.LocationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid input"})
+	}
+
+	location, err := h.service.Update(c.UserContext(), uint(id), req)
+	if err != nil {
+		return c.Status(locationErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Location updated",
+		"data":    location,
+	})
+}
+
+// DeleteLocation handles DELETE /locations/:id
+func (h *LocationHandler) DeleteLocation(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	if err := h.service.Delete(c.UserContext(), uint(id)); err != nil {
+		return c.Status(locationErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Location deleted"})
+}
+
+// GetLocation handles GET /locations/:id
+func (h *LocationHandler) GetLocation(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	location, err := h.service.GetByID(c.UserContext(), uint(id))
+	if err != nil {
+		return c.Status(locationErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Location retrieved",
+		"data":    location,
+	})
+}
+
+// ListLocations handles GET /locations
+func (h *LocationHandler) ListLocations(c *fiber.Ctx) error {
+	onlyActive := c.QueryBool("active", false)
+
+	locations, err := h.service.GetAll(c.UserContext(), onlyActive)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Locations retrieved",
+		"data":    locations,
+	})
+}
+
+// SetDefaultLocation handles POST /locations/:id/default
+func (h *LocationHandler) SetDefaultLocation(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	location, err := h.service.SetDefault(c.UserContext(), uint(id))
+	if err != nil {
+		return c.Status(locationErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Default location updated",
+		"data":    location,
+	})
+}
+
+// GetStockLevels handles GET /locations/stock-levels
+func (h *LocationHandler) GetStockLevels(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	pageSize, _ := strconv.Atoi(c.Query("pageSize", "20"))
+	locationID, _ := strconv.ParseUint(c.Query("location_id", "0"), 10, 64)
+	productID, _ := strconv.ParseUint(c.Query("product_id", "0"), 10, 64)
+
+	levels, total, err := h.service.GetStockLevels(c.UserContext(), page, pageSize, uint(locationID), uint(productID))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"message":     "Stock levels retrieved",
+		"data":        levels,
+		"total_items": total,
+		"page":        page,
+		"page_size":   pageSize,
+	})
+}
+
+// CreateRegister handles POST /registers
+func (h *LocationHandler) CreateRegister(c *fiber.Ctx) error {
+	var req services.RegisterRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid input"})
+	}
+
+	register, err := h.service.CreateRegister(c.UserContext(), req)
+	if err != nil {
+		return c.Status(locationErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Register created",
+		"data":    register,
+	})
+}
+
+// UpdateRegister handles PUT /registers/:id
+func (h *LocationHandler) UpdateRegister(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	var req services.RegisterRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid input"})
+	}
+
+	register, err := h.service.UpdateRegister(c.UserContext(), uint(id), req)
+	if err != nil {
+		return c.Status(locationErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Register updated",
+		"data":    register,
+	})
+}
+
+// DeleteRegister handles DELETE /registers/:id
+func (h *LocationHandler) DeleteRegister(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	if err := h.service.DeleteRegister(c.UserContext(), uint(id)); err != nil {
+		return c.Status(locationErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Register deleted"})
+}
+
+// GetRegister handles GET /registers/:id
+func (h *LocationHandler) GetRegister(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	register, err := h.service.GetRegisterByID(c.UserContext(), uint(id))
+	if err != nil {
+		return c.Status(locationErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Register retrieved",
+		"data":    register,
+	})
+}
+
+// ListRegisters handles GET /registers
+func (h *LocationHandler) ListRegisters(c *fiber.Ctx) error {
+	locationID, _ := strconv.ParseUint(c.Query("location_id", "0"), 10, 64)
+
+	registers, err := h.service.GetRegisters(c.UserContext(), uint(locationID))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Registers retrieved",
+		"data":    registers,
+	})
+}
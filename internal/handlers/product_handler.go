@@ -41,7 +41,12 @@ func (h *ProductHandler) CreateProduct(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Input JSON tidak valid"})
 	}
 
-	product, err := h.service.CreateProduct(c.UserContext(), req)
+	userIDFloat, ok := c.Locals("userID").(float64)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "User ID tidak ditemukan di token"})
+	}
+
+	product, err := h.service.CreateProduct(c.UserContext(), req, uint(userIDFloat))
 	if err != nil {
 		if customErrors.Is(err, customErrors.ErrConflict) {
 			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "SKU atau nama produk sudah ada (duplikat)."}) // 409
@@ -124,23 +129,49 @@ func (h *ProductHandler) ListProducts(c *fiber.Ctx) error {
 	return utils.JSONPaged(c, "Daftar produk berhasil dimuat", products, page, pageSize, count)
 }
 
+// ScanBarcode handles GET /products/scan/{code}
+// @Summary      Scan Barcode
+// @Description  Resolve a scanned barcode at the register. Recognizes product barcodes and weighing scale labels (PLU with embedded weight or price), returning the quantity and line total to add to the cart.
+// @Tags         Products
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        code path string true "Scanned barcode"
+// @Success      200 {object} utils.SuccessResponse{data=services.ScanResult} "Product found"
+// @Failure      400 {object} utils.ErrorResponse "Scale label cannot be applied to the product"
+// @Failure      401 {object} utils.ErrorResponse "Authentication required"
+// @Failure      404 {object} utils.ErrorResponse "No product for this barcode"
+// @Router       /products/scan/{code} [get]
+func (h *ProductHandler) ScanBarcode(c *fiber.Ctx) error {
+	result, err := h.service.ScanBarcode(c.UserContext(), c.Params("code"))
+	if err != nil {
+		if customErrors.Is(err, customErrors.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Produk dengan barcode ini tidak ditemukan."}) // 404
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return utils.JSONSuccess(c, fiber.StatusOK, "Produk ditemukan", result)
+}
+
 // GetLowStockProducts handles GET /products/low-stock
 // @Summary      Get Low Stock Products
-// @Description  Retrieve products with stock at or below the threshold. Useful for inventory alerts. Requires Admin or Manager role.
+// @Description  Retrieve products below their own minimum stock, or at or below the threshold when one is given. Useful for inventory alerts. Requires Admin or Manager role.
 // @Tags         Products
 // @Accept       json
 // @Produce      json
 // @Security     ApiKeyAuth
-// @Param        threshold query int false "Stock threshold (default: 10)" default(10)
+// @Param        threshold query int false "Stock threshold (default: each product's min_stock)"
 // @Success      200 {object} utils.SuccessResponse{data=[]models.Product} "Low stock products"
 // @Failure      401 {object} utils.ErrorResponse "Authentication required"
 // @Failure      403 {object} utils.ErrorResponse "Insufficient permissions"
 // @Failure      500 {object} utils.ErrorResponse "Internal server error"
 // @Router       /products/low-stock [get]
 func (h *ProductHandler) GetLowStockProducts(c *fiber.Ctx) error {
-	threshold, err := strconv.Atoi(c.Query("threshold", "10"))
-	if err != nil || threshold <= 0 {
-		threshold = 10
+	// threshold 0 memakai min_stock masing-masing produk
+	threshold, err := strconv.Atoi(c.Query("threshold", "0"))
+	if err != nil || threshold < 0 {
+		threshold = 0
 	}
 
 	products, err := h.service.GetLowStockProducts(c.UserContext(), threshold)
@@ -158,9 +189,36 @@ func (h *ProductHandler) GetLowStockProducts(c *fiber.Ctx) error {
 	})
 }
 
+// GetReorderSuggestions handles GET /products/reorder-suggestions
+// @Summary      Get Reorder Suggestions
+// @Description  Suggest order quantities for products whose stock plus open purchase orders fell below their reorder point, based on sales velocity over the last days, grouped by supplier. Requires Admin or Manager role.
+// @Tags         Products
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        days query int false "Sales period in days (default: 30, max: 365)"
+// @Success      200 {object} utils.SuccessResponse{data=services.ReorderSuggestions} "Reorder suggestions"
+// @Failure      400 {object} utils.ErrorResponse "Invalid period"
+// @Failure      401 {object} utils.ErrorResponse "Authentication required"
+// @Failure      403 {object} utils.ErrorResponse "Insufficient permissions"
+// @Router       /products/reorder-suggestions [get]
+func (h *ProductHandler) GetReorderSuggestions(c *fiber.Ctx) error {
+	days, err := strconv.Atoi(c.Query("days", "30"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Parameter days tidak valid"})
+	}
+
+	suggestions, err := h.service.GetReorderSuggestions(c.UserContext(), days)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return utils.JSONSuccess(c, fiber.StatusOK, "Saran pemesanan ulang berhasil dimuat", suggestions)
+}
+
 // UpdateProduct handles PUT /products/{id}
 // @Summary      Update Product
-// @Description  Update an existing product by its ID. Requires Admin or Manager role.
+// @Description  Update an existing product by its ID. Stock cannot change here; send the current stock and use inventory adjustments to change it. Requires Admin or Manager role.
 // @Tags         Products
 // @Accept       json
 // @Produce      json
@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"pos-api/internal/services"
+	"strconv"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ProductLotHandler struct {
+	service services.ProductLotService
+}
+
+func NewProductLotHandler(s services.ProductLotService) *ProductLotHandler {
+	return &ProductLotHandler{service: s}
+}
+
+// ListLots handles GET /lots
+func (h *ProductLotHandler) ListLots(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	pageSize, _ := strconv.Atoi(c.Query("pageSize", "20"))
+	productID, _ := strconv.ParseUint(c.Query("product_id", "0"), 10, 64)
+	locationID, _ := strconv.ParseUint(c.Query("location_id", "0"), 10, 64)
+	includeEmpty := c.QueryBool("include_empty", false)
+
+	lots, total, err := h.service.GetAll(c.UserContext(), page, pageSize, uint(productID), uint(locationID), includeEmpty)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"message":     "Lots retrieved",
+		"data":        lots,
+		"total_items": total,
+		"page":        page,
+		"page_size":   pageSize,
+	})
+}
+
+// GetLot handles GET /lots/:id
+func (h *ProductLotHandler) GetLot(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	lot, err := h.service.GetByID(c.UserContext(), uint(id))
+	if err != nil {
+		if customErrors.Is(err, customErrors.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Lot retrieved",
+		"data":    lot,
+	})
+}
+
+// GetExpiring handles GET /lots/expiring?days=30&location_id=
+func (h *ProductLotHandler) GetExpiring(c *fiber.Ctx) error {
+	days, err := strconv.Atoi(c.Query("days", "30"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid days"})
+	}
+	locationID, _ := strconv.ParseUint(c.Query("location_id", "0"), 10, 64)
+
+	report, err := h.service.GetExpiring(c.UserContext(), days, uint(locationID))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Expiring lots retrieved",
+		"data":    report,
+	})
+}
+
+// WriteOffLot handles POST /lots/:id/write-off
+func (h *ProductLotHandler) WriteOffLot(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	var req services.WriteOffRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid input"})
+		}
+	}
+
+	userIDFloat, ok := c.Locals("userID").(float64)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "User ID not found in token"})
+	}
+
+	log, err := h.service.WriteOff(c.UserContext(), uint(id), req, uint(userIDFloat))
+	if err != nil {
+		status := fiber.StatusBadRequest
+		switch {
+		case customErrors.Is(err, customErrors.ErrNotFound):
+			status = fiber.StatusNotFound
+		case customErrors.Is(err, customErrors.ErrConflict):
+			status = fiber.StatusConflict
+		}
+		return c.Status(status).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Lot written off",
+		"data":    log,
+	})
+}
+
+// WriteOffExpired handles POST /lots/write-off-expired?location_id=
+func (h *ProductLotHandler) WriteOffExpired(c *fiber.Ctx) error {
+	locationID, _ := strconv.ParseUint(c.Query("location_id", "0"), 10, 64)
+
+	var req services.WriteOffRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid input"})
+		}
+	}
+
+	userIDFloat, ok := c.Locals("userID").(float64)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "User ID not found in token"})
+	}
+
+	logs, err := h.service.WriteOffExpired(c.UserContext(), uint(locationID), req, uint(userIDFloat))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error(), "data": logs})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Expired lots written off",
+		"data":    logs,
+	})
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"pos-api/internal/services"
+	"strconv"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ProductSerialHandler struct {
+	service services.ProductSerialService
+}
+
+func NewProductSerialHandler(s services.ProductSerialService) *ProductSerialHandler {
+	return &ProductSerialHandler{service: s}
+}
+
+// ListSerials handles GET /serials?product_id=&location_id=&status=&search=
+func (h *ProductSerialHandler) ListSerials(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	pageSize, _ := strconv.Atoi(c.Query("pageSize", "20"))
+	productID, _ := strconv.ParseUint(c.Query("product_id", "0"), 10, 64)
+	locationID, _ := strconv.ParseUint(c.Query("location_id", "0"), 10, 64)
+
+	serials, total, err := h.service.GetAll(c.UserContext(), page, pageSize, uint(productID), uint(locationID), c.Query("status"), c.Query("search"))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"message":     "Serial numbers retrieved",
+		"data":        serials,
+		"total_items": total,
+		"page":        page,
+		"page_size":   pageSize,
+	})
+}
+
+// LookupSerial handles GET /serials/lookup/:serial
+func (h *ProductSerialHandler) LookupSerial(c *fiber.Ctx) error {
+	units, err := h.service.Lookup(c.UserContext(), c.Params("serial"))
+	if err != nil {
+		if customErrors.Is(err, customErrors.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Serial number not found"})
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Serial number found",
+		"data":    units,
+	})
+}
+
+// RegisterSerials handles POST /serials
+func (h *ProductSerialHandler) RegisterSerials(c *fiber.Ctx) error {
+	var req services.RegisterSerialsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid input"})
+	}
+
+	units, err := h.service.Register(c.UserContext(), req)
+	if err != nil {
+		status := fiber.StatusBadRequest
+		if customErrors.Is(err, customErrors.ErrConflict) {
+			status = fiber.StatusConflict
+		}
+		return c.Status(status).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Serial numbers registered",
+		"data":    units,
+	})
+}
@@ -0,0 +1,193 @@
+package handlers
+
+import (
+	"pos-api/internal/services"
+	"strconv"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PurchaseOrderHandler struct {
+	service services.PurchaseOrderService
+}
+
+func NewPurchaseOrderHandler(s services.PurchaseOrderService) *PurchaseOrderHandler {
+	return &PurchaseOrderHandler{service: s}
+}
+
+// purchaseOrderError writes err with 404 for unknown orders and 400 otherwise.
+func purchaseOrderError(c *fiber.Ctx, err error) error {
+	status := fiber.StatusBadRequest
+	switch {
+	case customErrors.Is(err, customErrors.ErrNotFound):
+		status = fiber.StatusNotFound
+	case customErrors.Is(err, customErrors.ErrConflict):
+		status = fiber.StatusConflict
+	}
+	return c.Status(status).JSON(fiber.Map{"error": err.Error()})
+}
+
+// CreatePurchaseOrder handles POST /purchase-orders
+func (h *PurchaseOrderHandler) CreatePurchaseOrder(c *fiber.Ctx) error {
+	var req services.PurchaseOrderRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid input"})
+	}
+
+	userIDFloat, ok := c.Locals("userID").(float64)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "User ID not found in token"})
+	}
+
+	po, err := h.service.Create(c.UserContext(), req, uint(userIDFloat))
+	if err != nil {
+		return purchaseOrderError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Purchase order created",
+		"data":    po,
+	})
+}
+
+// UpdatePurchaseOrder handles PUT /purchase-orders/:id
+func (h *PurchaseOrderHandler) UpdatePurchaseOrder(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	var req services.PurchaseOrderRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid input"})
+	}
+
+	po, err := h.service.Update(c.UserContext(), uint(id), req)
+	if err != nil {
+		return purchaseOrderError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Purchase order updated",
+		"data":    po,
+	})
+}
+
+// DeletePurchaseOrder handles DELETE /purchase-orders/:id
+func (h *PurchaseOrderHandler) DeletePurchaseOrder(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	if err := h.service.Delete(c.UserContext(), uint(id)); err != nil {
+		return purchaseOrderError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Purchase order deleted"})
+}
+
+// GetPurchaseOrder handles GET /purchase-orders/:id
+func (h *PurchaseOrderHandler) GetPurchaseOrder(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	po, err := h.service.GetByID(c.UserContext(), uint(id))
+	if err != nil {
+		return purchaseOrderError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Purchase order retrieved",
+		"data":    po,
+	})
+}
+
+// ListPurchaseOrders handles GET /purchase-orders
+func (h *PurchaseOrderHandler) ListPurchaseOrders(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	pageSize, _ := strconv.Atoi(c.Query("pageSize", "20"))
+	status := c.Query("status", "")
+	supplierID, _ := strconv.ParseUint(c.Query("supplier_id", "0"), 10, 64)
+
+	orders, total, err := h.service.GetAll(c.UserContext(), page, pageSize, status, uint(supplierID))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"message":     "Purchase orders retrieved",
+		"data":        orders,
+		"total_items": total,
+		"page":        page,
+		"page_size":   pageSize,
+	})
+}
+
+// SendPurchaseOrder handles POST /purchase-orders/:id/send
+func (h *PurchaseOrderHandler) SendPurchaseOrder(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	po, err := h.service.Send(c.UserContext(), uint(id))
+	if err != nil {
+		return purchaseOrderError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Purchase order sent",
+		"data":    po,
+	})
+}
+
+// CancelPurchaseOrder handles POST /purchase-orders/:id/cancel
+func (h *PurchaseOrderHandler) CancelPurchaseOrder(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	po, err := h.service.Cancel(c.UserContext(), uint(id))
+	if err != nil {
+		return purchaseOrderError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Purchase order cancelled",
+		"data":    po,
+	})
+}
+
+// ReceivePurchaseOrder handles POST /purchase-orders/:id/receive
+func (h *PurchaseOrderHandler) ReceivePurchaseOrder(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	var req services.ReceivePurchaseOrderRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid input"})
+	}
+
+	userIDFloat, ok := c.Locals("userID").(float64)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "User ID not found in token"})
+	}
+
+	po, err := h.service.Receive(c.UserContext(), uint(id), req, uint(userIDFloat))
+	if err != nil {
+		return purchaseOrderError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Goods received",
+		"data":    po,
+	})
+}
@@ -0,0 +1,211 @@
+package handlers
+
+import (
+	"encoding/json"
+	"pos-api/internal/models"
+	"pos-api/internal/services"
+	"strconv"
+	"time"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ReconciliationHandler struct {
+	service services.ReconciliationService
+}
+
+func NewReconciliationHandler(s services.ReconciliationService) *ReconciliationHandler {
+	return &ReconciliationHandler{service: s}
+}
+
+type matchLineRequest struct {
+	TransactionID uint `json:"transaction_id"`
+}
+
+// reconciliationErrorStatus maps service errors to HTTP status codes.
+func reconciliationErrorStatus(err error) int {
+	switch {
+	case customErrors.Is(err, customErrors.ErrNotFound):
+		return fiber.StatusNotFound
+	case customErrors.Is(err, customErrors.ErrConflict), customErrors.Is(err, customErrors.ErrPeriodClosed):
+		return fiber.StatusConflict
+	default:
+		return fiber.StatusBadRequest
+	}
+}
+
+// paymentMethodQuery parses the optional ?payment_method_id= filter.
+func paymentMethodQuery(c *fiber.Ctx) (*uint, bool) {
+	raw := c.Query("payment_method_id")
+	if raw == "" {
+		return nil, true
+	}
+	id, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil {
+		return nil, false
+	}
+	pmID := uint(id)
+	return &pmID, true
+}
+
+// ImportStatement handles POST /reconciliation/statements (multipart fields
+// "file", "payment_method_id", optional "mapping" as JSON and "settlement_days")
+func (h *ReconciliationHandler) ImportStatement(c *fiber.Ctx) error {
+	userIDFloat, ok := c.Locals("userID").(float64)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "User ID not found in token"})
+	}
+
+	paymentMethodID, err := strconv.ParseUint(c.FormValue("payment_method_id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "A valid payment_method_id is required"})
+	}
+	req := services.StatementImport{PaymentMethodID: uint(paymentMethodID)}
+	if raw := c.FormValue("mapping"); raw != "" {
+		var mapping models.StatementMapping
+		if err := json.Unmarshal([]byte(raw), &mapping); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "mapping must be a JSON object: " + err.Error()})
+		}
+		req.Mapping = &mapping
+	}
+	if raw := c.FormValue("settlement_days"); raw != "" {
+		if req.SettlementDays, err = strconv.Atoi(raw); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "settlement_days must be a number"})
+		}
+	}
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "A CSV file is required in the 'file' field"})
+	}
+	file, err := header.Open()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Could not read the uploaded file"})
+	}
+	defer file.Close()
+	req.FileName, req.Content = header.Filename, file
+
+	result, err := h.service.Import(c.UserContext(), req, uint(userIDFloat))
+	if err != nil {
+		return c.Status(reconciliationErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Statement imported",
+		"data":    result,
+	})
+}
+
+// ListStatements handles GET /reconciliation/statements?payment_method_id=
+func (h *ReconciliationHandler) ListStatements(c *fiber.Ctx) error {
+	paymentMethodID, ok := paymentMethodQuery(c)
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid payment_method_id"})
+	}
+
+	statements, err := h.service.GetStatements(c.UserContext(), paymentMethodID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Statements retrieved",
+		"data":    statements,
+	})
+}
+
+// GetStatement handles GET /reconciliation/statements/:id
+func (h *ReconciliationHandler) GetStatement(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	statement, err := h.service.GetStatement(c.UserContext(), uint(id))
+	if err != nil {
+		return c.Status(reconciliationErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Statement retrieved",
+		"data":    statement,
+	})
+}
+
+// GetUnmatched handles GET /reconciliation/unmatched?payment_method_id=&start_date=&end_date=
+func (h *ReconciliationHandler) GetUnmatched(c *fiber.Ctx) error {
+	paymentMethodID, ok := paymentMethodQuery(c)
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid payment_method_id"})
+	}
+
+	items, err := h.service.GetUnmatched(c.UserContext(), paymentMethodID, c.Query("start_date"), c.Query("end_date"), time.Now())
+	if err != nil {
+		return c.Status(reconciliationErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Unmatched items retrieved",
+		"data":    items,
+	})
+}
+
+// MatchLine handles POST /reconciliation/lines/:id/match
+func (h *ReconciliationHandler) MatchLine(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+	var req matchLineRequest
+	if err := c.BodyParser(&req); err != nil || req.TransactionID == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "transaction_id is required"})
+	}
+
+	line, err := h.service.Match(c.UserContext(), uint(id), req.TransactionID)
+	if err != nil {
+		return c.Status(reconciliationErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Statement line matched",
+		"data":    line,
+	})
+}
+
+// UnmatchLine handles POST /reconciliation/lines/:id/unmatch
+func (h *ReconciliationHandler) UnmatchLine(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	line, err := h.service.Unmatch(c.UserContext(), uint(id))
+	if err != nil {
+		return c.Status(reconciliationErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Statement line unmatched",
+		"data":    line,
+	})
+}
+
+// IgnoreLine handles POST /reconciliation/lines/:id/ignore
+func (h *ReconciliationHandler) IgnoreLine(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	line, err := h.service.Ignore(c.UserContext(), uint(id))
+	if err != nil {
+		return c.Status(reconciliationErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Statement line ignored",
+		"data":    line,
+	})
+}
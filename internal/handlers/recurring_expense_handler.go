@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"pos-api/internal/services"
+	"strconv"
+	"time"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type RecurringExpenseHandler struct {
+	service services.RecurringExpenseService
+}
+
+func NewRecurringExpenseHandler(s services.RecurringExpenseService) *RecurringExpenseHandler {
+	return &RecurringExpenseHandler{service: s}
+}
+
+// recurringExpenseErrorStatus maps service errors to HTTP status codes.
+func recurringExpenseErrorStatus(err error) int {
+	if customErrors.Is(err, customErrors.ErrNotFound) {
+		return fiber.StatusNotFound
+	}
+	return fiber.StatusBadRequest
+}
+
+// CreateRecurringExpense handles POST /cash-flow/recurring
+func (h *RecurringExpenseHandler) CreateRecurringExpense(c *fiber.Ctx) error {
+	var req services.RecurringExpenseRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid input"})
+	}
+
+	userIDFloat, ok := c.Locals("userID").(float64)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "User ID not found in token"})
+	}
+
+	expense, err := h.service.Create(c.UserContext(), req, uint(userIDFloat))
+	if err != nil {
+		return c.Status(recurringExpenseErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Recurring expense created",
+		"data":    expense,
+	})
+}
+
+// UpdateRecurringExpense handles PUT /cash-flow/recurring/:id
+func (h *RecurringExpenseHandler) UpdateRecurringExpense(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	var req services.RecurringExpenseRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid input"})
+	}
+
+	expense, err := h.service.Update(c.UserContext(), uint(id), req)
+	if err != nil {
+		return c.Status(recurringExpenseErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Recurring expense updated",
+		"data":    expense,
+	})
+}
+
+// DeleteRecurringExpense handles DELETE /cash-flow/recurring/:id
+func (h *RecurringExpenseHandler) DeleteRecurringExpense(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	if err := h.service.Delete(c.UserContext(), uint(id)); err != nil {
+		return c.Status(recurringExpenseErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{"message": "Recurring expense deleted"})
+}
+
+// GetRecurringExpense handles GET /cash-flow/recurring/:id
+func (h *RecurringExpenseHandler) GetRecurringExpense(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	expense, err := h.service.GetByID(c.UserContext(), uint(id))
+	if err != nil {
+		return c.Status(recurringExpenseErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Recurring expense retrieved",
+		"data":    expense,
+	})
+}
+
+// ListRecurringExpenses handles GET /cash-flow/recurring?active=true
+func (h *RecurringExpenseHandler) ListRecurringExpenses(c *fiber.Ctx) error {
+	expenses, err := h.service.GetAll(c.UserContext(), c.QueryBool("active", false))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Recurring expenses retrieved",
+		"data":    expenses,
+	})
+}
+
+// GetUpcoming handles GET /cash-flow/recurring/upcoming?days=30
+func (h *RecurringExpenseHandler) GetUpcoming(c *fiber.Ctx) error {
+	days, _ := strconv.Atoi(c.Query("days", "30"))
+	if days > 366 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "days must be at most 366"})
+	}
+
+	upcoming, err := h.service.GetUpcoming(c.UserContext(), time.Now(), days)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Upcoming recurring expenses retrieved",
+		"data":    upcoming,
+	})
+}
+
+// RunDue handles POST /cash-flow/recurring/run, booking what is due now
+// instead of waiting for the scheduler
+func (h *RecurringExpenseHandler) RunDue(c *fiber.Ctx) error {
+	booked, err := h.service.RunDue(c.UserContext(), time.Now())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error(), "data": booked})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Due recurring expenses booked",
+		"data":    booked,
+	})
+}
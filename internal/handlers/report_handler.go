@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"strconv"
+	"time"
 
 	"pos-api/internal/services"
 
@@ -115,3 +116,83 @@ func (h *ReportHandler) GetStockValue(c *fiber.Ctx) error {
 		"data":    stockValue,
 	})
 }
+
+// GetProfitAndLoss handles GET /reports/profit-loss
+// @Summary      Get Profit & Loss Statement
+// @Description  Get the profit & loss statement of a month or date range, optionally compared with the previous period or the same period a year earlier. Requires Admin or Manager role.
+// @Tags         Reports
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        month query string false "Month (YYYY-MM), instead of start_date and end_date" example(2026-02)
+// @Param        start_date query string false "Start date (YYYY-MM-DD)" example(2026-02-01)
+// @Param        end_date query string false "End date (YYYY-MM-DD)" example(2026-02-28)
+// @Param        compare query string false "Comparison period: previous or year"
+// @Success      200 {object} utils.SuccessResponse{data=services.ProfitAndLossReport} "Profit & loss statement retrieved successfully"
+// @Failure      400 {object} utils.ErrorResponse "Invalid date format or range"
+// @Failure      401 {object} utils.ErrorResponse "Authentication required"
+// @Failure      403 {object} utils.ErrorResponse "Insufficient permissions"
+// @Router       /reports/profit-loss [get]
+func (h *ReportHandler) GetProfitAndLoss(c *fiber.Ctx) error {
+	startDate := c.Query("start_date")
+	endDate := c.Query("end_date")
+
+	if month := c.Query("month"); month != "" {
+		start, err := time.Parse("2006-01", month)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Format bulan tidak valid (gunakan YYYY-MM)",
+			})
+		}
+		startDate = start.Format("2006-01-02")
+		endDate = start.AddDate(0, 1, -1).Format("2006-01-02")
+	}
+
+	if startDate == "" || endDate == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Parameter month atau start_date dan end_date harus diisi",
+		})
+	}
+
+	report, err := h.service.GetProfitAndLoss(c.UserContext(), startDate, endDate, c.Query("compare"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Laporan laba rugi berhasil dimuat",
+		"data":    report,
+	})
+}
+
+// GetBalanceSheet handles GET /reports/balance-sheet
+// @Summary      Get Balance Sheet
+// @Description  Get the balance sheet at the end of a day (default today), optionally compared with another date. Requires Admin or Manager role.
+// @Tags         Reports
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        date query string false "Date (YYYY-MM-DD), default today" example(2026-02-28)
+// @Param        compare_date query string false "Date to compare with (YYYY-MM-DD)" example(2026-01-31)
+// @Success      200 {object} utils.SuccessResponse{data=services.BalanceSheetReport} "Balance sheet retrieved successfully"
+// @Failure      400 {object} utils.ErrorResponse "Invalid date format"
+// @Failure      401 {object} utils.ErrorResponse "Authentication required"
+// @Failure      403 {object} utils.ErrorResponse "Insufficient permissions"
+// @Router       /reports/balance-sheet [get]
+func (h *ReportHandler) GetBalanceSheet(c *fiber.Ctx) error {
+	date := c.Query("date", time.Now().Format("2006-01-02"))
+
+	report, err := h.service.GetBalanceSheet(c.UserContext(), date, c.Query("compare_date"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Neraca berhasil dimuat",
+		"data":    report,
+	})
+}
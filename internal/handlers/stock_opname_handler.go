@@ -0,0 +1,196 @@
+package handlers
+
+import (
+	"pos-api/internal/services"
+	"strconv"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type StockOpnameHandler struct {
+	service services.StockOpnameService
+}
+
+func NewStockOpnameHandler(s services.StockOpnameService) *StockOpnameHandler {
+	return &StockOpnameHandler{service: s}
+}
+
+// stockOpnameError writes err with 404 for unknown sessions, 409 when the session is closed and 400 otherwise.
+func stockOpnameError(c *fiber.Ctx, err error) error {
+	status := fiber.StatusBadRequest
+	switch {
+	case customErrors.Is(err, customErrors.ErrNotFound):
+		status = fiber.StatusNotFound
+	case customErrors.Is(err, customErrors.ErrConflict):
+		status = fiber.StatusConflict
+	}
+	return c.Status(status).JSON(fiber.Map{"error": err.Error()})
+}
+
+// CreateStockOpname handles POST /stock-opnames
+func (h *StockOpnameHandler) CreateStockOpname(c *fiber.Ctx) error {
+	var req services.CreateStockOpnameRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid input"})
+	}
+
+	userIDFloat, ok := c.Locals("userID").(float64)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "User ID not found in token"})
+	}
+
+	opname, err := h.service.Create(c.UserContext(), req, uint(userIDFloat))
+	if err != nil {
+		return stockOpnameError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Stock opname started",
+		"data":    opname,
+	})
+}
+
+// ListStockOpnames handles GET /stock-opnames
+func (h *StockOpnameHandler) ListStockOpnames(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	pageSize, _ := strconv.Atoi(c.Query("pageSize", "20"))
+	status := c.Query("status", "")
+
+	sessions, total, err := h.service.GetAll(c.UserContext(), page, pageSize, status)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"message":     "Stock opnames retrieved",
+		"data":        sessions,
+		"total_items": total,
+		"page":        page,
+		"page_size":   pageSize,
+	})
+}
+
+// GetStockOpname handles GET /stock-opnames/:id
+func (h *StockOpnameHandler) GetStockOpname(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	opname, err := h.service.GetByID(c.UserContext(), uint(id))
+	if err != nil {
+		return stockOpnameError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Stock opname retrieved",
+		"data":    opname,
+	})
+}
+
+// SubmitCount handles POST /stock-opnames/:id/counts
+func (h *StockOpnameHandler) SubmitCount(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	var req services.StockCountRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid input"})
+	}
+
+	userIDFloat, ok := c.Locals("userID").(float64)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "User ID not found in token"})
+	}
+
+	count, err := h.service.SubmitCount(c.UserContext(), uint(id), req, uint(userIDFloat))
+	if err != nil {
+		return stockOpnameError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Count recorded",
+		"data":    count,
+	})
+}
+
+// DeleteCount handles DELETE /stock-opnames/:id/counts/:countId
+func (h *StockOpnameHandler) DeleteCount(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+	countID, err := strconv.ParseUint(c.Params("countId"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid count ID"})
+	}
+
+	if err := h.service.DeleteCount(c.UserContext(), uint(id), uint(countID)); err != nil {
+		return stockOpnameError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Count deleted"})
+}
+
+// GetVariances handles GET /stock-opnames/:id/variances
+func (h *StockOpnameHandler) GetVariances(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	report, err := h.service.GetVariances(c.UserContext(), uint(id), c.QueryBool("only_differences", false))
+	if err != nil {
+		return stockOpnameError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Stock opname variances retrieved",
+		"data":    report,
+	})
+}
+
+// ApproveStockOpname handles POST /stock-opnames/:id/approve
+func (h *StockOpnameHandler) ApproveStockOpname(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	userIDFloat, ok := c.Locals("userID").(float64)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "User ID not found in token"})
+	}
+
+	opname, err := h.service.Approve(c.UserContext(), uint(id), uint(userIDFloat))
+	if err != nil {
+		return stockOpnameError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Stock opname approved",
+		"data":    opname,
+	})
+}
+
+// CancelStockOpname handles POST /stock-opnames/:id/cancel
+func (h *StockOpnameHandler) CancelStockOpname(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	opname, err := h.service.Cancel(c.UserContext(), uint(id))
+	if err != nil {
+		return stockOpnameError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Stock opname cancelled",
+		"data":    opname,
+	})
+}
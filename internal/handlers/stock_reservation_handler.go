@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"pos-api/internal/services"
+	"strconv"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type StockReservationHandler struct {
+	service services.StockReservationService
+}
+
+func NewStockReservationHandler(s services.StockReservationService) *StockReservationHandler {
+	return &StockReservationHandler{service: s}
+}
+
+// CreateReservation handles POST /reservations
+func (h *StockReservationHandler) CreateReservation(c *fiber.Ctx) error {
+	var req services.StockReservationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid input"})
+	}
+
+	userIDFloat, ok := c.Locals("userID").(float64)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "User ID not found in token"})
+	}
+
+	reservation, err := h.service.Create(c.UserContext(), req, uint(userIDFloat))
+	if err != nil {
+		status := fiber.StatusBadRequest
+		if customErrors.Is(err, customErrors.ErrInsufficientStock) {
+			status = fiber.StatusConflict
+		}
+		return c.Status(status).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Stock reserved",
+		"data":    reservation,
+	})
+}
+
+// GetReservation handles GET /reservations/:id
+func (h *StockReservationHandler) GetReservation(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	reservation, err := h.service.GetByID(c.UserContext(), uint(id))
+	if err != nil {
+		if customErrors.Is(err, customErrors.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Reservation retrieved",
+		"data":    reservation,
+	})
+}
+
+// ListReservations handles GET /reservations?location_id=&status=
+func (h *StockReservationHandler) ListReservations(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	pageSize, _ := strconv.Atoi(c.Query("pageSize", "20"))
+	locationID, _ := strconv.ParseUint(c.Query("location_id", "0"), 10, 64)
+
+	reservations, total, err := h.service.GetAll(c.UserContext(), page, pageSize, uint(locationID), c.Query("status"))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"message":     "Reservations retrieved",
+		"data":        reservations,
+		"total_items": total,
+		"page":        page,
+		"page_size":   pageSize,
+	})
+}
+
+// ReleaseReservation handles POST /reservations/:id/release
+func (h *StockReservationHandler) ReleaseReservation(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	reservation, err := h.service.Release(c.UserContext(), uint(id))
+	if err != nil {
+		status := fiber.StatusBadRequest
+		if customErrors.Is(err, customErrors.ErrNotFound) {
+			status = fiber.StatusNotFound
+		} else if customErrors.Is(err, customErrors.ErrConflict) {
+			status = fiber.StatusConflict
+		}
+		return c.Status(status).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Reservation released",
+		"data":    reservation,
+	})
+}
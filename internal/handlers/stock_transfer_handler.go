@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"pos-api/internal/services"
+	"strconv"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type StockTransferHandler struct {
+	service services.StockTransferService
+}
+
+func NewStockTransferHandler(s services.StockTransferService) *StockTransferHandler {
+	return &StockTransferHandler{service: s}
+}
+
+// CreateStockTransfer handles POST /stock-transfers
+func (h *StockTransferHandler) CreateStockTransfer(c *fiber.Ctx) error {
+	var req services.StockTransferRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid input"})
+	}
+
+	userIDFloat, ok := c.Locals("userID").(float64)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "User ID not found in token"})
+	}
+
+	transfer, err := h.service.Create(c.UserContext(), req, uint(userIDFloat))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Stock transferred",
+		"data":    transfer,
+	})
+}
+
+// GetStockTransfer handles GET /stock-transfers/:id
+func (h *StockTransferHandler) GetStockTransfer(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	transfer, err := h.service.GetByID(c.UserContext(), uint(id))
+	if err != nil {
+		if customErrors.Is(err, customErrors.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Stock transfer retrieved",
+		"data":    transfer,
+	})
+}
+
+// ListStockTransfers handles GET /stock-transfers
+func (h *StockTransferHandler) ListStockTransfers(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	pageSize, _ := strconv.Atoi(c.Query("pageSize", "20"))
+	locationID, _ := strconv.ParseUint(c.Query("location_id", "0"), 10, 64)
+
+	transfers, total, err := h.service.GetAll(c.UserContext(), page, pageSize, uint(locationID))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"message":     "Stock transfers retrieved",
+		"data":        transfers,
+		"total_items": total,
+		"page":        page,
+		"page_size":   pageSize,
+	})
+}
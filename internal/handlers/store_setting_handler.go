@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"pos-api/internal/models"
 	"pos-api/internal/services"
 
@@ -40,6 +41,10 @@ func (h *StoreSettingHandler) UpdateStoreSettings(c *fiber.Ctx) error {
 	}
 
 	settings, err := h.service.UpdateSettings(c.UserContext(), &req)
+	if errors.Is(err, services.ErrInvalidCostingMethod) || errors.Is(err, services.ErrInvalidNegativeStockPolicy) ||
+		errors.Is(err, services.ErrInvalidBaseCurrency) || errors.Is(err, services.ErrBaseCurrencyInUse) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Gagal menyimpan pengaturan toko",
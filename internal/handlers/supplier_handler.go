@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"pos-api/internal/services"
+	"strconv"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type SupplierHandler struct {
+	service services.SupplierService
+}
+
+func NewSupplierHandler(s services.SupplierService) *SupplierHandler {
+	return &SupplierHandler{service: s}
+}
+
+// supplierErrorStatus maps service errors to HTTP status codes.
+func supplierErrorStatus(err error) int {
+	switch {
+	case customErrors.Is(err, customErrors.ErrNotFound):
+		return fiber.StatusNotFound
+	case customErrors.Is(err, customErrors.ErrConflict):
+		return fiber.StatusConflict
+	default:
+		return fiber.StatusBadRequest
+	}
+}
+
+// CreateSupplier handles POST /suppliers
+func (h *SupplierHandler) CreateSupplier(c *fiber.Ctx) error {
+	var req services.SupplierRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid input"})
+	}
+
+	supplier, err := h.service.Create(c.UserContext(), req)
+	if err != nil {
+		return c.Status(supplierErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Supplier created",
+		"data":    supplier,
+	})
+}
+
+// UpdateSupplier handles PUT /suppliers/:id
+func (h *SupplierHandler) UpdateSupplier(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	var req services.SupplierRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid input"})
+	}
+
+	supplier, err := h.service.Update(c.UserContext(), uint(id), req)
+	if err != nil {
+		return c.Status(supplierErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Supplier updated",
+		"data":    supplier,
+	})
+}
+
+// DeleteSupplier handles DELETE /suppliers/:id
+func (h *SupplierHandler) DeleteSupplier(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	if err := h.service.Delete(c.UserContext(), uint(id)); err != nil {
+		return c.Status(supplierErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Supplier deleted"})
+}
+
+// GetSupplier handles GET /suppliers/:id
+func (h *SupplierHandler) GetSupplier(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	supplier, err := h.service.GetByID(c.UserContext(), uint(id))
+	if err != nil {
+		return c.Status(supplierErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Supplier retrieved",
+		"data":    supplier,
+	})
+}
+
+// ListSuppliers handles GET /suppliers
+func (h *SupplierHandler) ListSuppliers(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	pageSize, _ := strconv.Atoi(c.Query("pageSize", "20"))
+	search := c.Query("search", "")
+	onlyActive := c.QueryBool("active", false)
+
+	suppliers, total, err := h.service.GetAll(c.UserContext(), page, pageSize, search, onlyActive)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"message":     "Suppliers retrieved",
+		"data":        suppliers,
+		"total_items": total,
+		"page":        page,
+		"page_size":   pageSize,
+	})
+}
@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"pos-api/internal/services"
+	"strconv"
+	"time"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type SupplierPayableHandler struct {
+	service services.SupplierPayableService
+}
+
+func NewSupplierPayableHandler(s services.SupplierPayableService) *SupplierPayableHandler {
+	return &SupplierPayableHandler{service: s}
+}
+
+// ListPayables handles GET /payables
+func (h *SupplierPayableHandler) ListPayables(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	pageSize, _ := strconv.Atoi(c.Query("pageSize", "20"))
+	status := c.Query("status", "")
+	supplierID, _ := strconv.ParseUint(c.Query("supplier_id", "0"), 10, 64)
+
+	payables, total, err := h.service.GetAll(c.UserContext(), page, pageSize, uint(supplierID), status)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"message":     "Payables retrieved",
+		"data":        payables,
+		"total_items": total,
+		"page":        page,
+		"page_size":   pageSize,
+	})
+}
+
+// GetPayable handles GET /payables/:id
+func (h *SupplierPayableHandler) GetPayable(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	payable, err := h.service.GetByID(c.UserContext(), uint(id))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Payable retrieved",
+		"data":    payable,
+	})
+}
+
+// RecordPayment handles POST /payables/:id/payments
+func (h *SupplierPayableHandler) RecordPayment(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	var req services.SupplierPaymentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid input"})
+	}
+
+	userIDFloat, ok := c.Locals("userID").(float64)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "User ID not found in token"})
+	}
+
+	payable, err := h.service.RecordPayment(c.UserContext(), uint(id), req, uint(userIDFloat))
+	if err != nil {
+		status := periodClosedStatus(err)
+		if customErrors.Is(err, customErrors.ErrNotFound) {
+			status = fiber.StatusNotFound
+		}
+		return c.Status(status).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Supplier payment recorded",
+		"data":    payable,
+	})
+}
+
+// GetAging handles GET /payables/aging
+func (h *SupplierPayableHandler) GetAging(c *fiber.Ctx) error {
+	asOf := time.Now()
+	if d := c.Query("as_of"); d != "" {
+		t, err := time.Parse("2006-01-02", d)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid as_of date, use YYYY-MM-DD"})
+		}
+		asOf = t
+	}
+	supplierID, _ := strconv.ParseUint(c.Query("supplier_id", "0"), 10, 64)
+
+	report, err := h.service.GetAging(c.UserContext(), asOf, uint(supplierID))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Payables aging retrieved",
+		"data":    report,
+	})
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"pos-api/internal/services"
 
+	customErrors "pos-api/internal/pkg/errors"
+
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -156,6 +158,15 @@ func (h *TransactionHandler) CreateTransaction(c *fiber.Ctx) error {
 	})
 }
 
+// periodClosedStatus answers changes refused by a closed accounting period
+// with 409, other failures with 400.
+func periodClosedStatus(err error) int {
+	if customErrors.Is(err, customErrors.ErrPeriodClosed) {
+		return fiber.StatusConflict
+	}
+	return fiber.StatusBadRequest
+}
+
 // CancelTransaction handles POST /transactions/:id/cancel
 func (h *TransactionHandler) CancelTransaction(c *fiber.Ctx) error {
 	id, err := c.ParamsInt("id")
@@ -164,7 +175,7 @@ func (h *TransactionHandler) CancelTransaction(c *fiber.Ctx) error {
 	}
 
 	if err := h.service.CancelTransaction(c.UserContext(), uint(id)); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		return c.Status(periodClosedStatus(err)).JSON(fiber.Map{"error": err.Error()})
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Transaksi berhasil dibatalkan"})
@@ -178,7 +189,7 @@ func (h *TransactionHandler) ReturnTransaction(c *fiber.Ctx) error {
 	}
 
 	if err := h.service.ReturnTransaction(c.UserContext(), uint(id)); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		return c.Status(periodClosedStatus(err)).JSON(fiber.Map{"error": err.Error()})
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Transaksi berhasil diretur"})
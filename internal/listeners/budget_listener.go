@@ -0,0 +1,34 @@
+package listeners
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"pos-api/internal/pkg/events"
+)
+
+// HandleBudgetAlert listens for EventBudgetThresholdCrossed and logs the
+// alert, naming the category, its budget and what it has spent.
+func HandleBudgetAlert(ctx context.Context, p interface{}) error {
+	payload, ok := p.(events.BudgetThresholdPayload)
+	if !ok {
+		return errors.New("invalid payload type for HandleBudgetAlert")
+	}
+
+	budget := payload.Budget
+	name := budget.Source
+	if budget.Category != nil {
+		name = budget.Category.Name
+	}
+	slog.WarnContext(ctx, "Budget alert threshold crossed",
+		"budget_id", budget.ID,
+		"category", name,
+		"month", budget.Month.Format("2006-01"),
+		"budget", budget.Amount,
+		"actual", payload.Actual,
+		"percent_used", payload.PercentUsed,
+		"alert_percent", budget.AlertPercent,
+	)
+	return nil
+}
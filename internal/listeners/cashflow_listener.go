@@ -4,14 +4,21 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"time"
 
 	"pos-api/internal/models"
 	"pos-api/internal/pkg/events"
+	"pos-api/internal/repositories"
+
+	"gorm.io/gorm"
 )
 
-// HandleCashFlowOnTransaction listens for a TransactionCreatedEvent
-// and writes an automatic CashFlow 'income' entry mapped to the active gorm TX.
+// HandleCashFlowOnTransaction listens for a TransactionCreatedEvent and posts
+// the sale to the ledger: the payment against sales and discounts, and the
+// cost of goods sold out of inventory. The cash book gets the matching
+// 'income' entry. It must run after HandleInventoryOnTransaction, which sets
+// the cost each item was sold at.
 func HandleCashFlowOnTransaction(ctx context.Context, p interface{}) error {
 	payload, ok := p.(events.TransactionCreatedPayload)
 	if !ok {
@@ -21,9 +28,14 @@ func HandleCashFlowOnTransaction(ctx context.Context, p interface{}) error {
 	tx := payload.TX
 	transaction := payload.Transaction
 
+	entry, err := saleEntry(tx, transaction, payload.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to build journal entry for transaction %s: %w", transaction.TransactionCode, err)
+	}
+
 	cashFlow := models.CashFlow{
 		Type:      "income",
-		Source:    "sales",
+		Source:    models.CashFlowSourceSales,
 		Amount:    transaction.GrandTotal,
 		Date:      transaction.CreatedAt,
 		Notes:     "Transaction " + transaction.TransactionCode,
@@ -32,15 +44,55 @@ func HandleCashFlowOnTransaction(ctx context.Context, p interface{}) error {
 		UpdatedAt: time.Now(),
 	}
 
-	if err := tx.Create(&cashFlow).Error; err != nil {
+	if err := repositories.RecordCashFlow(tx, &cashFlow, entry); err != nil {
 		return fmt.Errorf("failed to create automatic cash flow on transaction %s: %w", transaction.TransactionCode, err)
 	}
 
 	return nil
 }
 
-// HandleCashFlowOnInventoryAdjusted listens for EventInventoryAdjusted
-// and inserts an 'expense' record if the type is 'in' and source is 'purchase' or similar, denoting restock.
+// saleEntry builds the journal entry of a sale from its totals and the cost
+// of its items.
+func saleEntry(tx *gorm.DB, transaction *models.Transaction, userID uint) (*models.JournalEntry, error) {
+	payment, err := repositories.PaymentAccount(tx, transaction.PaymentMethod)
+	if err != nil {
+		return nil, err
+	}
+	accounts := make(map[string]*models.Account)
+	for _, code := range []string{models.AccountCodeSales, models.AccountCodeSalesDiscount, models.AccountCodeCOGS, models.AccountCodeInventory} {
+		if accounts[code], err = repositories.SystemAccount(tx, code); err != nil {
+			return nil, err
+		}
+	}
+
+	var cost float64
+	for _, detail := range transaction.TransactionDetails {
+		cost += detail.CostAtSale * detail.Quantity
+	}
+	paid, discount := roundMoney(transaction.GrandTotal), roundMoney(transaction.Discount)
+
+	return &models.JournalEntry{
+		Date:          transaction.CreatedAt,
+		Source:        models.JournalSale,
+		Description:   "Sale " + transaction.TransactionCode,
+		TransactionID: &transaction.ID,
+		UserID:        userID,
+		Lines: []models.JournalLine{
+			repositories.Debit(payment, paid, transaction.PaymentMethod),
+			repositories.Debit(accounts[models.AccountCodeSalesDiscount], discount, ""),
+			repositories.Credit(accounts[models.AccountCodeSales], paid+discount, ""),
+			repositories.Debit(accounts[models.AccountCodeCOGS], cost, ""),
+			repositories.Credit(accounts[models.AccountCodeInventory], cost, ""),
+		},
+	}, nil
+}
+
+// HandleCashFlowOnInventoryAdjusted listens for EventInventoryAdjusted and
+// posts the stock's value to the ledger. Stock coming in with a cost is a
+// restock paid in cash, booked with an 'expense' in the cash book; receipts
+// against a purchase order with payment terms are owed to the supplier
+// instead and create a SupplierPayable. Stock leaving or counted off (or
+// found) moves between inventory and the shrinkage account.
 func HandleCashFlowOnInventoryAdjusted(ctx context.Context, p interface{}) error {
 	payload, ok := p.(events.InventoryAdjustedPayload)
 	if !ok {
@@ -48,37 +100,137 @@ func HandleCashFlowOnInventoryAdjusted(ctx context.Context, p interface{}) error
 	}
 
 	log := payload.InventoryLog
+	if log.TotalCost <= 0 {
+		return nil
+	}
 
-	// Only care if it's an "in" event that costs money
-	if log.Type == "in" && log.TotalCost > 0 {
-		var product models.Product
-		productName := fmt.Sprintf("ID %d", log.ProductID)
-		if err := payload.TX.First(&product, log.ProductID).Error; err == nil && product.Name != "" {
-			productName = product.Name
-		}
+	inventory, err := repositories.SystemAccount(payload.TX, models.AccountCodeInventory)
+	if err != nil {
+		return fmt.Errorf("failed to get inventory account: %w", err)
+	}
 
-		cashFlow := models.CashFlow{
-			Type:      "expense",
-			Source:    "penambahan_stok", // Native category for Stock Replenishment
-			Amount:    log.TotalCost,
-			Date:      time.Now(),
-			Notes:     fmt.Sprintf("Restock %s, Qty: %d", productName, log.Quantity),
-			UserID:    payload.UserID,
-			CreatedAt: time.Now(),
-			UpdatedAt: time.Now(),
-		}
+	if log.Type != "in" {
+		return postInventoryChange(payload, inventory)
+	}
+
+	var product models.Product
+	productName := fmt.Sprintf("ID %d", log.ProductID)
+	if err := payload.TX.First(&product, log.ProductID).Error; err == nil && product.Name != "" {
+		productName = product.Name
+	}
+
+	notes := fmt.Sprintf("Restock %s, Qty: %g", productName, log.Quantity)
+	if log.PurchaseOrderID != nil {
+		var po models.PurchaseOrder
+		if err := payload.TX.First(&po, *log.PurchaseOrderID).Error; err == nil {
+			notes += ", PO: " + po.PONumber
 
-		if err := payload.TX.Create(&cashFlow).Error; err != nil {
-			return fmt.Errorf("failed to create cash flow for restock: %w", err)
+			// Received on credit: owe the supplier now, the expense is booked when it is paid
+			if po.PaymentTermDays > 0 {
+				return createSupplierPayable(payload, &po, inventory, notes)
+			}
 		}
 	}
 
+	cashFlow := models.CashFlow{
+		Type:            "expense",
+		Source:          models.CashFlowSourceStockPurchase,
+		Amount:          log.TotalCost,
+		Date:            time.Now(),
+		Notes:           notes,
+		PurchaseOrderID: log.PurchaseOrderID,
+		UserID:          payload.UserID,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+
+	cash, err := repositories.SystemAccount(payload.TX, models.AccountCodeCash)
+	if err != nil {
+		return fmt.Errorf("failed to get cash account: %w", err)
+	}
+	entry := repositories.CashFlowEntry(&cashFlow, cash, inventory, models.JournalPurchase)
+	entry.InventoryLogID = &log.ID
+
+	if err := repositories.RecordCashFlow(payload.TX, &cashFlow, entry); err != nil {
+		return fmt.Errorf("failed to create cash flow for restock: %w", err)
+	}
+
+	return nil
+}
+
+// postInventoryChange books stock written off, lost or found at its cost
+// against the shrinkage account.
+func postInventoryChange(payload events.InventoryAdjustedPayload, inventory *models.Account) error {
+	log := payload.InventoryLog
+	shrinkage, err := repositories.SystemAccount(payload.TX, models.AccountCodeShrinkage)
+	if err != nil {
+		return fmt.Errorf("failed to get shrinkage account: %w", err)
+	}
+
+	// Stock found at a count adds to inventory, everything else takes from it
+	value := -log.TotalCost
+	if log.Type == "adjustment" && log.Quantity > 0 {
+		value = log.TotalCost
+	}
+
+	entry := &models.JournalEntry{
+		Source:         models.JournalInventory,
+		Description:    fmt.Sprintf("Stock %s (%s), Qty: %g", log.Type, log.Source, log.Quantity),
+		InventoryLogID: &log.ID,
+		UserID:         payload.UserID,
+		Lines: []models.JournalLine{
+			repositories.Debit(inventory, value, log.Source),
+			repositories.Credit(shrinkage, value, log.Source),
+		},
+	}
+	if err := repositories.PostJournal(payload.TX, entry); err != nil {
+		return fmt.Errorf("failed to post inventory change: %w", err)
+	}
+	return nil
+}
+
+// createSupplierPayable books a goods receipt on credit as a payable due after
+// the PO's payment term, and posts it from inventory to accounts payable.
+func createSupplierPayable(payload events.InventoryAdjustedPayload, po *models.PurchaseOrder, inventory *models.Account, notes string) error {
+	log := payload.InventoryLog
+	payables, err := repositories.SystemAccount(payload.TX, models.AccountCodePayables)
+	if err != nil {
+		return fmt.Errorf("failed to get payables account: %w", err)
+	}
+	entry := &models.JournalEntry{
+		Source:          models.JournalPurchase,
+		Description:     notes,
+		PurchaseOrderID: &po.ID,
+		InventoryLogID:  &log.ID,
+		UserID:          payload.UserID,
+		Lines: []models.JournalLine{
+			repositories.Debit(inventory, log.TotalCost, ""),
+			repositories.Credit(payables, log.TotalCost, ""),
+		},
+	}
+	if err := repositories.PostJournal(payload.TX, entry); err != nil {
+		return fmt.Errorf("failed to post receipt on credit for %s: %w", po.PONumber, err)
+	}
+
+	payable := models.SupplierPayable{
+		SupplierID:      po.SupplierID,
+		PurchaseOrderID: &po.ID,
+		InventoryLogID:  &log.ID,
+		Amount:          roundMoney(log.TotalCost),
+		DueDate:         time.Now().AddDate(0, 0, po.PaymentTermDays),
+		Status:          models.PayableOpen,
+		Notes:           notes,
+	}
+
+	if err := payload.TX.Create(&payable).Error; err != nil {
+		return fmt.Errorf("failed to create supplier payable for %s: %w", po.PONumber, err)
+	}
 	return nil
 }
 
 // HandleCashFlowOnTransactionReverted listens for TransactionReturned or Cancelled events
-// and deletes the original income cash flow entry that was created when the transaction was made.
-// This keeps income and expenses properly synchronized instead of creating offsetting entries.
+// and posts the contra entry of the sale's journal entry, so the ledger keeps
+// both. The cash book gets the refund as a negative income against the sale.
 func HandleCashFlowOnTransactionReverted(ctx context.Context, p interface{}) error {
 	payload, ok := p.(events.TransactionCreatedPayload)
 	if !ok {
@@ -88,14 +240,52 @@ func HandleCashFlowOnTransactionReverted(ctx context.Context, p interface{}) err
 	tx := payload.TX
 	transaction := payload.Transaction
 
-	// Delete the original income cash flow entry matching this transaction
-	// The Notes field was set to "Transaction <code>" when the income entry was created
-	result := tx.Where("source = ? AND notes = ?", "sales", "Transaction "+transaction.TransactionCode).
-		Delete(&models.CashFlow{})
+	reversal := &models.JournalEntry{
+		Date:          time.Now(),
+		Description:   "Refund/Cancel for " + transaction.TransactionCode,
+		TransactionID: &transaction.ID,
+		UserID:        payload.UserID,
+	}
 
-	if result.Error != nil {
-		return fmt.Errorf("failed to delete cash flow for reverted transaction %s: %w", transaction.TransactionCode, result.Error)
+	var sale models.JournalEntry
+	err := tx.Select("id").Where("transaction_id = ? AND source = ?", transaction.ID, models.JournalSale).
+		Order("id DESC").First(&sale).Error
+	switch {
+	case err == nil:
+		err = repositories.BuildReversal(tx, sale.ID, reversal)
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		// Sales made before the ledger have no entry to reverse; post the
+		// contra of the entry the sale would have had
+		var entry *models.JournalEntry
+		if entry, err = saleEntry(tx, transaction, payload.UserID); err == nil {
+			reversal.Source = models.JournalReversal
+			for _, line := range entry.Lines {
+				reversal.Lines = append(reversal.Lines, models.JournalLine{AccountID: line.AccountID, Debit: line.Credit, Credit: line.Debit, Memo: line.Memo})
+			}
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to reverse journal entry for transaction %s: %w", transaction.TransactionCode, err)
+	}
+
+	cashFlow := models.CashFlow{
+		Type:      "income",
+		Source:    models.CashFlowSourceSales,
+		Amount:    -transaction.GrandTotal,
+		Date:      reversal.Date,
+		Notes:     "Refund/Cancel for " + transaction.TransactionCode,
+		UserID:    payload.UserID,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	if err := repositories.RecordCashFlow(tx, &cashFlow, reversal); err != nil {
+		return fmt.Errorf("failed to create refund cash flow for transaction %s: %w", transaction.TransactionCode, err)
 	}
 
 	return nil
 }
+
+// roundMoney rounds an amount to cents.
+func roundMoney(v float64) float64 {
+	return math.Round(v*100) / 100
+}
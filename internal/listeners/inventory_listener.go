@@ -4,9 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 
 	"pos-api/internal/models"
 	"pos-api/internal/pkg/events"
+	"pos-api/internal/repositories"
 
 	"gorm.io/gorm"
 )
@@ -22,46 +24,82 @@ func HandleInventoryOnTransaction(ctx context.Context, p interface{}) error {
 	tx := payload.TX
 	transaction := payload.Transaction
 
-	// Decrease stock and log it for each item
-	for _, detail := range transaction.TransactionDetails {
-		// 1. Get current stock
+	locationID, err := saleLocation(tx, transaction)
+	if err != nil {
+		return err
+	}
+
+	// A sale of a held order releases the stock its reservation held
+	if transaction.ReservationID != nil {
+		if err := repositories.FulfilReservation(tx, *transaction.ReservationID, locationID, transaction); err != nil {
+			return fmt.Errorf("reservation unavailable: %w", err)
+		}
+	}
+
+	// Lock every product sold before touching any, so sales of the same
+	// products in a different order wait instead of deadlocking
+	if err := repositories.LockStock(tx, stockKeys(transaction, locationID)...); err != nil {
+		return fmt.Errorf("failed to lock stock: %w", err)
+	}
+
+	// Decrease stock at the register's location, first-expiry-first-out
+	// across the product's lots, and log it for each item
+	for i := range transaction.TransactionDetails {
+		detail := &transaction.TransactionDetails[i]
 		var product models.Product
-		if err := tx.First(&product, detail.ProductID).Error; err != nil {
+		if err := tx.Select("id", "name", "serialized", "negative_stock_policy").First(&product, detail.ProductID).Error; err != nil {
 			return fmt.Errorf("product not found %d: %w", detail.ProductID, err)
 		}
 
-		if product.Stock < detail.Quantity {
-			return fmt.Errorf("insufficient stock for product %s. Have: %d, Need: %d", product.Name, product.Stock, detail.Quantity)
+		// Selling beyond the stock on record follows the negative stock policy
+		stockBefore, stockAfter, shortfall, policy, err := repositories.TakeSaleStock(tx, &product, locationID, detail.Quantity, transaction.ReservationID)
+		if err != nil {
+			return fmt.Errorf("insufficient stock for product %s: %w", product.Name, err)
+		}
+		if shortfall > 0 && policy == models.NegativeStockWarn {
+			transaction.StockWarnings = append(transaction.StockWarnings,
+				fmt.Sprintf("stok %s kurang %g di lokasi ini, periksa dan sesuaikan stoknya", product.Name, shortfall))
 		}
 
-		stockBefore := product.Stock
-		stockAfter := stockBefore - detail.Quantity
-
-		// 2. Atomic Stock Update (Optimistic attempt)
-		result := tx.Model(&models.Product{}).
-			Where("id = ? AND stock >= ?", detail.ProductID, detail.Quantity).
-			UpdateColumn("stock", gorm.Expr("stock - ?", detail.Quantity))
+		lots, err := repositories.ConsumeLots(tx, detail.ProductID, locationID, detail.Quantity, stockBefore)
+		if err != nil {
+			return fmt.Errorf("failed to consume lots for product %s: %w", product.Name, err)
+		}
 
-		if result.Error != nil {
-			return fmt.Errorf("failed to deduct stock for product %d: %w", detail.ProductID, result.Error)
+		// Serialized units must be in stock at the sale's location
+		if err := repositories.TakeSerials(tx, detail.ProductID, locationID, detail.SerialNumbers, models.SerialSold, &transaction.ID); err != nil {
+			return fmt.Errorf("serial number unavailable for product %s: %w", product.Name, err)
 		}
 
-		if result.RowsAffected == 0 {
-			return errors.New("concurrent modification or insufficient stock during update")
+		// The cost of goods sold comes from the costing method, replacing the
+		// product cost the sale was recorded with
+		cost, err := repositories.ConsumeCost(tx, detail.ProductID, detail.Quantity)
+		if err != nil {
+			return fmt.Errorf("failed to cost product %s: %w", product.Name, err)
+		}
+		if err := tx.Model(detail).Update("cost_at_sale", cost).Error; err != nil {
+			return fmt.Errorf("failed to update cost of product %s: %w", product.Name, err)
 		}
+		detail.CostAtSale = cost
 
-		// 3. Insert Inventory Log
+		// Insert Inventory Log
 		log := models.InventoryLog{
 			ProductID:   detail.ProductID,
+			LocationID:  locationID,
 			Type:        "out",
 			Source:      "sale",           // Maps to sales source
 			Quantity:    -detail.Quantity, // 'out' is a negative change conceptually, though stored absolute or delta depending on standard. Wait, check service usage.
 			CostPrice:   detail.CostAtSale,
-			TotalCost:   detail.CostAtSale * float64(detail.Quantity),
+			TotalCost:   detail.CostAtSale * detail.Quantity,
 			StockBefore: stockBefore,
 			StockAfter:  stockAfter,
 			Notes:       "Sale " + transaction.TransactionCode,
 			UserID:      payload.UserID,
+
+			TransactionID: &transaction.ID,
+			SerialNumbers: detail.SerialNumbers,
+			Shortfall:     shortfall,
+			NeedsReview:   shortfall > 0 && policy == models.NegativeStockAllow,
 		}
 		// Adjust Quantity depending on convention. Service sets it to absolute value.
 		log.Quantity = detail.Quantity
@@ -69,6 +107,9 @@ func HandleInventoryOnTransaction(ctx context.Context, p interface{}) error {
 		if err := tx.Create(&log).Error; err != nil {
 			return fmt.Errorf("failed to create inventory log for product %d: %w", detail.ProductID, err)
 		}
+		if err := repositories.LinkLots(tx, &log, lots); err != nil {
+			return fmt.Errorf("failed to link lots for product %d: %w", detail.ProductID, err)
+		}
 	}
 
 	return nil
@@ -85,25 +126,48 @@ func HandleInventoryOnTransactionReverted(ctx context.Context, p interface{}) er
 	tx := payload.TX
 	transaction := payload.Transaction
 
-	for _, detail := range transaction.TransactionDetails {
-		var product models.Product
-		if err := tx.First(&product, detail.ProductID).Error; err != nil {
-			return fmt.Errorf("product not found %d: %w", detail.ProductID, err)
-		}
+	locationID, err := saleLocation(tx, transaction)
+	if err != nil {
+		return err
+	}
 
-		stockBefore := product.Stock
-		stockAfter := stockBefore + detail.Quantity
+	// Lots consumed by the sale, per product in detail order
+	var saleLogs []models.InventoryLog
+	if err := tx.Preload("Lots").
+		Where("transaction_id = ? AND source = ?", transaction.ID, "sale").
+		Order("id ASC").Find(&saleLogs).Error; err != nil {
+		return fmt.Errorf("failed to load sale inventory logs: %w", err)
+	}
+	consumed := make(map[uint][][]models.InventoryLogLot)
+	for _, l := range saleLogs {
+		consumed[l.ProductID] = append(consumed[l.ProductID], l.Lots)
+	}
+
+	if err := repositories.LockStock(tx, stockKeys(transaction, locationID)...); err != nil {
+		return fmt.Errorf("failed to lock stock: %w", err)
+	}
 
-		result := tx.Model(&models.Product{}).
-			Where("id = ?", detail.ProductID).
-			UpdateColumn("stock", gorm.Expr("stock + ?", detail.Quantity))
+	// Stock goes back to the location, lots and serial numbers it was sold from
+	for _, detail := range transaction.TransactionDetails {
+		stockBefore, stockAfter, err := repositories.ApplyLocationStock(tx, detail.ProductID, locationID, detail.Quantity)
+		if err != nil {
+			return fmt.Errorf("failed to restore stock for product %d: %w", detail.ProductID, err)
+		}
 
-		if result.Error != nil {
-			return fmt.Errorf("failed to restore stock for product %d: %w", detail.ProductID, result.Error)
+		var lots []models.InventoryLogLot
+		if queue := consumed[detail.ProductID]; len(queue) > 0 {
+			consumed[detail.ProductID] = queue[1:]
+			if lots, err = repositories.RestoreLots(tx, queue[0]); err != nil {
+				return fmt.Errorf("failed to restore lots for product %d: %w", detail.ProductID, err)
+			}
+		}
+		if err := repositories.RestockSerials(tx, detail.ProductID, locationID, transaction.ID, detail.SerialNumbers); err != nil {
+			return fmt.Errorf("failed to restock serial numbers for product %d: %w", detail.ProductID, err)
 		}
 
 		log := models.InventoryLog{
 			ProductID:   detail.ProductID,
+			LocationID:  locationID,
 			Type:        "in",
 			Source:      "opname", // Mapping to opname or a custom source. We use opname because it's a manual adjustment equivalent, or we can use "return" if supported. Let's use "return" since we're just recording it. Wait, the frontend ENUM might not support "return".
 			Quantity:    detail.Quantity,
@@ -113,6 +177,9 @@ func HandleInventoryOnTransactionReverted(ctx context.Context, p interface{}) er
 			StockAfter:  stockAfter,
 			Notes:       "Refund/Cancel for " + transaction.TransactionCode,
 			UserID:      payload.UserID,
+
+			TransactionID: &transaction.ID,
+			SerialNumbers: detail.SerialNumbers,
 		}
 		// Based on SOURCE_OPTIONS in frontend, valid sources natively are: purchase, sale, opname.
 		// "return" or "cancel" are not strictly enforced by DB enum usually. We'll use "opname" to be safe,
@@ -122,7 +189,38 @@ func HandleInventoryOnTransactionReverted(ctx context.Context, p interface{}) er
 		if err := tx.Create(&log).Error; err != nil {
 			return fmt.Errorf("failed to create inventory log on return for product %d: %w", detail.ProductID, err)
 		}
+		if err := repositories.LinkLots(tx, &log, lots); err != nil {
+			return fmt.Errorf("failed to link lots on return for product %d: %w", detail.ProductID, err)
+		}
+
+		// Returned units go back into stock at the cost they were sold at
+		if _, err := repositories.ReceiveCost(tx, detail.ProductID, &log.ID, detail.Quantity, detail.CostAtSale); err != nil {
+			return fmt.Errorf("failed to restore cost for product %d: %w", detail.ProductID, err)
+		}
 	}
 
 	return nil
 }
+
+// saleLocation returns the location a transaction's stock moves at; sales
+// recorded before locations existed fall back to the default location.
+func saleLocation(tx *gorm.DB, transaction *models.Transaction) (uint, error) {
+	if transaction.LocationID != 0 {
+		return transaction.LocationID, nil
+	}
+	return repositories.DefaultLocationID(tx)
+}
+
+// stockKeys lists the stock a transaction's items move at its location.
+func stockKeys(transaction *models.Transaction, locationID uint) []repositories.StockKey {
+	keys := make([]repositories.StockKey, 0, len(transaction.TransactionDetails))
+	for _, detail := range transaction.TransactionDetails {
+		keys = append(keys, repositories.StockKey{ProductID: detail.ProductID, LocationID: locationID})
+	}
+	return keys
+}
+
+// roundStock trims float noise to the 3 decimals stored by the numeric(14,3) stock columns.
+func roundStock(v float64) float64 {
+	return math.Round(v*1000) / 1000
+}
@@ -0,0 +1,75 @@
+package listeners
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"pos-api/internal/models"
+	"pos-api/internal/pkg/events"
+
+	"gorm.io/gorm/clause"
+)
+
+// HandlePurchaseOrderOnInventoryAdjusted listens for EventInventoryAdjusted and,
+// for goods receipts tagged with a purchase order, books the received quantity
+// on the matching PO line and moves the PO to 'partially_received' or 'received'.
+func HandlePurchaseOrderOnInventoryAdjusted(ctx context.Context, p interface{}) error {
+	payload, ok := p.(events.InventoryAdjustedPayload)
+	if !ok {
+		return errors.New("invalid payload type for HandlePurchaseOrderOnInventoryAdjusted")
+	}
+
+	log := payload.InventoryLog
+	if log.PurchaseOrderID == nil || log.Type != "in" {
+		return nil
+	}
+
+	tx := payload.TX
+	poID := *log.PurchaseOrderID
+
+	// Lock the order and check it is still open, so a cancelled or fully
+	// received order cannot take more goods
+	var po models.PurchaseOrder
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&po, poID).Error; err != nil {
+		return fmt.Errorf("purchase order %d not found: %w", poID, err)
+	}
+	if po.Status != models.PurchaseOrderSent && po.Status != models.PurchaseOrderPartiallyReceived {
+		return fmt.Errorf("purchase order %s is '%s', only sent orders can be received", po.PONumber, po.Status)
+	}
+
+	// Lock the line so concurrent receipts of the same product cannot over-receive
+	var item models.PurchaseOrderItem
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("purchase_order_id = ? AND product_id = ?", poID, log.ProductID).
+		First(&item).Error; err != nil {
+		return fmt.Errorf("product %d is not on purchase order %d: %w", log.ProductID, poID, err)
+	}
+
+	received := roundStock(item.ReceivedQuantity + log.Quantity)
+	if received > item.Quantity {
+		return fmt.Errorf("received quantity for product %d exceeds the ordered %g", log.ProductID, item.Quantity)
+	}
+
+	if err := tx.Model(&item).UpdateColumn("received_quantity", received).Error; err != nil {
+		return fmt.Errorf("failed to update received quantity on purchase order %d: %w", poID, err)
+	}
+
+	var outstanding int64
+	if err := tx.Model(&models.PurchaseOrderItem{}).
+		Where("purchase_order_id = ? AND received_quantity < quantity", poID).
+		Count(&outstanding).Error; err != nil {
+		return fmt.Errorf("failed to check purchase order %d: %w", poID, err)
+	}
+
+	status := models.PurchaseOrderReceived
+	if outstanding > 0 {
+		status = models.PurchaseOrderPartiallyReceived
+	}
+
+	if err := tx.Model(&models.PurchaseOrder{}).Where("id = ?", poID).Update("status", status).Error; err != nil {
+		return fmt.Errorf("failed to update purchase order %d status: %w", poID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package models
+
+import "time"
+
+// Account types
+const (
+	AccountAsset     = "asset"
+	AccountLiability = "liability"
+	AccountEquity    = "equity"
+	AccountRevenue   = "revenue"
+	AccountExpense   = "expense"
+)
+
+// Codes of the system accounts the listeners post to
+const (
+	AccountCodeCash          = "1100" // Kas: cash payment methods and manual cash entries
+	AccountCodeBank          = "1200" // Bank: non-cash payment methods without an account of their own
+	AccountCodeInventory     = "1300" // Persediaan barang, at cost
+	AccountCodePayables      = "2100" // Utang usaha to suppliers
+	AccountCodeCapital       = "3100" // Modal pemilik
+	AccountCodeOpening       = "3200" // Ekuitas saldo awal: stock on hand when it was first recorded
+	AccountCodeSales         = "4100" // Penjualan, before discounts
+	AccountCodeSalesDiscount = "4110" // Diskon penjualan, contra revenue
+	AccountCodeOtherIncome   = "4200" // Pendapatan lain-lain
+	AccountCodeCOGS          = "5100" // Harga pokok penjualan
+	AccountCodeShrinkage     = "5200" // Selisih persediaan: damage, expiry and count variances
+	AccountCodeOperating     = "6100" // Beban operasional
+	AccountCodeMerchantFees  = "6200" // Biaya merchant (MDR) deducted from non-cash settlements
+)
+
+// Account is an entry in the chart of accounts. Each non-cash payment method
+// gets its own bank account (PaymentMethodID set) the first time it is used.
+type Account struct {
+	ID              uint           `json:"id" gorm:"primaryKey"`
+	Code            string         `json:"code" gorm:"unique;not null"` // e.g. 1100, 1200.3 for payment method 3
+	Name            string         `json:"name" gorm:"not null"`
+	Type            string         `json:"type" gorm:"type:varchar(20);not null"` // "asset", "liability", "equity", "revenue", "expense"
+	PaymentMethodID *uint          `json:"payment_method_id,omitempty" gorm:"uniqueIndex"`
+	PaymentMethod   *PaymentMethod `json:"payment_method,omitempty" gorm:"foreignKey:PaymentMethodID"`
+	IsSystem        bool           `json:"is_system" gorm:"default:false"` // Posted to automatically, cannot be removed
+	IsActive        bool           `json:"is_active" gorm:"default:true"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+}
+
+// DebitNormal reports whether the account's balance grows with debits.
+func (a Account) DebitNormal() bool {
+	return a.Type == AccountAsset || a.Type == AccountExpense
+}
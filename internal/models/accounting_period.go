@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// Accounting period log actions
+const (
+	PeriodActionClose  = "close"
+	PeriodActionReopen = "reopen"
+)
+
+// AccountingPeriod is a month of the books. Once it is closed nothing dated in
+// it can be posted, changed, deleted or cancelled; corrections are posted in
+// an open period instead.
+type AccountingPeriod struct {
+	ID        uint       `json:"id" gorm:"primaryKey"`
+	Period    time.Time  `json:"period" gorm:"type:date;not null;unique"` // First day of the month
+	IsClosed  bool       `json:"is_closed" gorm:"not null;default:false"`
+	ClosedAt  *time.Time `json:"closed_at,omitempty"`
+	ClosedBy  *uint      `json:"closed_by,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// AccountingPeriodLog records who closed or reopened a period, when and why.
+type AccountingPeriodLog struct {
+	ID                 uint              `json:"id" gorm:"primaryKey"`
+	AccountingPeriodID uint              `json:"accounting_period_id" gorm:"not null;index"`
+	AccountingPeriod   *AccountingPeriod `json:"accounting_period,omitempty" gorm:"foreignKey:AccountingPeriodID"`
+	Action             string            `json:"action" gorm:"type:varchar(20);not null"` // "close" or "reopen"
+	Reason             string            `json:"reason"`
+	UserID             uint              `json:"user_id" gorm:"not null;index"`
+	User               User              `json:"user" gorm:"foreignKey:UserID"`
+	CreatedAt          time.Time         `json:"created_at"`
+}
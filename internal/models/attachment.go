@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// Records a file can be attached to
+const (
+	AttachmentOwnerCashFlow     = "cash_flow"
+	AttachmentOwnerInventoryLog = "inventory_log"
+)
+
+// Attachment is a file kept as evidence for a cash book entry or a stock
+// movement, e.g. the receipt photo behind an expense. The file itself is in
+// the blob storage under StorageKey.
+type Attachment struct {
+	ID uint `json:"id" gorm:"primaryKey"`
+	// Exactly one of CashFlowID and InventoryLogID is set
+	CashFlowID     *uint     `json:"cash_flow_id,omitempty" gorm:"index"`
+	InventoryLogID *uint     `json:"inventory_log_id,omitempty" gorm:"index"`
+	FileName       string    `json:"file_name" gorm:"not null"`    // As uploaded
+	ContentType    string    `json:"content_type" gorm:"not null"` // Detected from the content, e.g. "image/jpeg"
+	Size           int64     `json:"size" gorm:"not null"`         // In bytes
+	StorageKey     string    `json:"-" gorm:"not null;unique"`
+	UserID         uint      `json:"user_id" gorm:"not null;index"`
+	User           User      `json:"user" gorm:"foreignKey:UserID"`
+	CreatedAt      time.Time `json:"created_at"`
+}
@@ -0,0 +1,79 @@
+package models
+
+import "time"
+
+// Bank statement line statuses
+const (
+	StatementLineUnmatched = "unmatched" // No sale found for it yet
+	StatementLineMatched   = "matched"   // Settles the sale in TransactionID
+	StatementLineIgnored   = "ignored"   // Not a sale settlement, e.g. a transfer or chargeback
+)
+
+// StatementMapping tells which CSV columns, by header, hold what in a bank or
+// e-wallet settlement statement, and how its dates and amounts are written.
+// Of Amount, Fee and NetAmount at least Amount or NetAmount is set; a missing
+// one is worked out from the other two.
+type StatementMapping struct {
+	Date        string `json:"date"`                  // Settlement date column
+	Amount      string `json:"amount,omitempty"`      // Gross amount column, what the customer paid
+	Fee         string `json:"fee,omitempty"`         // Merchant fee (MDR) column
+	NetAmount   string `json:"net_amount,omitempty"`  // Amount credited to the account column
+	Reference   string `json:"reference,omitempty"`   // e.g. the RRN or order ID
+	Description string `json:"description,omitempty"` // Free text shown alongside the line
+	// DateFormat uses YYYY, MM, DD, HH, mm and ss, e.g. "DD/MM/YYYY HH:mm";
+	// defaults to "YYYY-MM-DD"
+	DateFormat   string `json:"date_format,omitempty"`
+	Delimiter    string `json:"delimiter,omitempty"`     // One character, defaults to ","
+	DecimalComma bool   `json:"decimal_comma,omitempty"` // Amounts written as 1.234,56
+}
+
+// BankStatement is a settlement statement imported for a non-cash payment
+// method. Its lines are matched to the sales they settle, and the fees
+// deducted from them are booked as cash flow expenses.
+type BankStatement struct {
+	ID              uint           `json:"id" gorm:"primaryKey"`
+	PaymentMethodID uint           `json:"payment_method_id" gorm:"not null;index"`
+	PaymentMethod   *PaymentMethod `json:"payment_method,omitempty" gorm:"foreignKey:PaymentMethodID"`
+	FileName        string         `json:"file_name" gorm:"not null"`
+	// Mapping is the column mapping the file was read with; the next import
+	// for the payment method reuses it when none is given
+	Mapping     StatementMapping    `json:"mapping" gorm:"type:jsonb;serializer:json;not null"`
+	StartDate   time.Time           `json:"start_date" gorm:"type:date;not null"` // Earliest line date
+	EndDate     time.Time           `json:"end_date" gorm:"type:date;not null"`   // Latest line date
+	LineCount   int                 `json:"line_count" gorm:"not null;default:0"`
+	TotalAmount float64             `json:"total_amount" gorm:"type:numeric(14,2);not null;default:0"` // Gross
+	TotalFee    float64             `json:"total_fee" gorm:"type:numeric(14,2);not null;default:0"`
+	TotalNet    float64             `json:"total_net" gorm:"type:numeric(14,2);not null;default:0"`
+	UserID      uint                `json:"user_id" gorm:"not null;index"`
+	User        User                `json:"user" gorm:"foreignKey:UserID"`
+	Lines       []BankStatementLine `json:"lines,omitempty" gorm:"foreignKey:BankStatementID"`
+	CreatedAt   time.Time           `json:"created_at"`
+}
+
+// BankStatementLine is one settlement on a statement.
+type BankStatementLine struct {
+	ID              uint           `json:"id" gorm:"primaryKey"`
+	BankStatementID uint           `json:"bank_statement_id" gorm:"not null;index"`
+	BankStatement   *BankStatement `json:"bank_statement,omitempty" gorm:"foreignKey:BankStatementID"`
+	LineNo          int            `json:"line_no" gorm:"not null"` // Line number in the file, the header being 1
+	Date            time.Time      `json:"date" gorm:"type:date;not null;index"`
+	Reference       string         `json:"reference,omitempty"`
+	Description     string         `json:"description,omitempty"`
+	Amount          float64        `json:"amount" gorm:"type:numeric(14,2);not null"` // Gross
+	Fee             float64        `json:"fee" gorm:"type:numeric(14,2);not null;default:0"`
+	NetAmount       float64        `json:"net_amount" gorm:"type:numeric(14,2);not null"`
+	Status          string         `json:"status" gorm:"type:varchar(20);not null;default:'unmatched';index"`
+	// TransactionID is the sale the line settles; a sale is settled by at
+	// most one line
+	TransactionID *uint        `json:"transaction_id,omitempty" gorm:"uniqueIndex"`
+	Transaction   *Transaction `json:"transaction,omitempty" gorm:"foreignKey:TransactionID"`
+	MatchedAt     *time.Time   `json:"matched_at,omitempty"`
+	// FeeCashFlowID is the expense the line's fee is booked in, together with
+	// the other fees of the statement on the same date
+	FeeCashFlowID *uint `json:"fee_cash_flow_id,omitempty" gorm:"index"`
+	// Fingerprint identifies the line across imports, so a statement imported
+	// twice, or overlapping an earlier one, adds no line twice
+	Fingerprint string    `json:"-" gorm:"not null;unique"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// Budget caps what a cash flow expense category may spend in a month.
+type Budget struct {
+	ID       uint              `json:"id" gorm:"primaryKey"`
+	Source   string            `json:"source" gorm:"not null;uniqueIndex:idx_budgets_source_month"` // Code of the expense category
+	Category *CashFlowCategory `json:"category,omitempty" gorm:"foreignKey:Source;references:Code"`
+	Month    time.Time         `json:"month" gorm:"type:date;not null;uniqueIndex:idx_budgets_source_month;index"` // First day of the month
+	Amount   float64           `json:"amount" gorm:"type:numeric(14,2);not null"`
+	// AlertPercent is the share of the budget, in percent, whose spending
+	// raises an alert
+	AlertPercent float64 `json:"alert_percent" gorm:"type:numeric(5,2);not null"`
+	Notes        string  `json:"notes"`
+	// AlertedAt is when spending last crossed AlertPercent; it is cleared when
+	// spending falls back below it or the budget changes, so each crossing
+	// alerts once
+	AlertedAt *time.Time `json:"alerted_at,omitempty"`
+	UserID    uint       `json:"user_id" gorm:"not null;index"`
+	User      User       `json:"user" gorm:"foreignKey:UserID"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
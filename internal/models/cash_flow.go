@@ -6,17 +6,29 @@ import (
 	"gorm.io/gorm"
 )
 
-// CashFlow tracks income and expenses beyond sales
+// CashFlow is the cash book: the money side of a journal entry, listed as
+// income or expense. A reversed sale shows as a negative income.
 type CashFlow struct {
-	ID        uint           `json:"id" gorm:"primaryKey"`
-	Type      string         `json:"type" gorm:"not null"`   // "income" or "expense"
-	Source    string         `json:"source" gorm:"not null"` // e.g., "sales", "rent", "electricity", "supplies", "salary", "other_income", "other_expense"
-	Amount    float64        `json:"amount" gorm:"type:numeric;not null"`
-	Date      time.Time      `json:"date" gorm:"not null;index"`
-	Notes     string         `json:"notes"`
-	UserID    uint           `json:"user_id" gorm:"not null;index"`
-	User      User           `json:"user" gorm:"foreignKey:UserID"`
-	CreatedAt time.Time      `json:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at"`
-	DeletedAt gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index"`
+	ID   uint   `json:"id" gorm:"primaryKey"`
+	Type string `json:"type" gorm:"not null"` // "income" or "expense"
+	// Source is the code of the entry's category, e.g., "sales", "rent", "salary"
+	Source   string            `json:"source" gorm:"not null;index"`
+	Category *CashFlowCategory `json:"category,omitempty" gorm:"foreignKey:Source;references:Code"`
+	Amount   float64           `json:"amount" gorm:"type:numeric;not null"`
+	Date     time.Time         `json:"date" gorm:"not null;index"`
+	Notes    string            `json:"notes"`
+	// PurchaseOrderID is set for stock purchase expenses created by a goods receipt
+	PurchaseOrderID *uint `json:"purchase_order_id,omitempty" gorm:"index"`
+	// RecurringExpenseID is set for expenses the scheduler booked from a
+	// recurring expense; a template is booked at most once per date
+	RecurringExpenseID *uint `json:"recurring_expense_id,omitempty" gorm:"index"`
+	// JournalEntryID is the ledger posting behind the entry; editing or
+	// deleting the entry reverses it
+	JournalEntryID *uint          `json:"journal_entry_id,omitempty" gorm:"index"`
+	JournalEntry   *JournalEntry  `json:"journal_entry,omitempty" gorm:"foreignKey:JournalEntryID"`
+	UserID         uint           `json:"user_id" gorm:"not null;index"`
+	User           User           `json:"user" gorm:"foreignKey:UserID"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index"`
 }
@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+// Cash flow category types. Capital is the owner's money: paid in as income,
+// drawn out as expense.
+const (
+	CashFlowCategoryIncome  = "income"
+	CashFlowCategoryExpense = "expense"
+	CashFlowCategoryCapital = "capital"
+)
+
+// Codes of the system categories the listeners post to
+const (
+	CashFlowSourceSales         = "sales"           // Sales, with their refunds as negative income
+	CashFlowSourceStockPurchase = "penambahan_stok" // Stock paid for in cash and supplier payments
+	CashFlowSourceMerchantFees  = "biaya_merchant"  // Fees deducted from imported settlement statements
+)
+
+// CashFlowCategory is what a cash flow entry is for. Entries reference it by
+// code as their source.
+type CashFlowCategory struct {
+	ID   uint   `json:"id" gorm:"primaryKey"`
+	Code string `json:"code" gorm:"not null;unique"` // e.g., "rent", "electricity", "salary", "modal_awal"
+	Name string `json:"name" gorm:"not null"`
+	Type string `json:"type" gorm:"not null"` // "income", "expense" or "capital"
+	// AccountCode is the ledger account the other side of the category's
+	// entries is posted to
+	AccountCode string `json:"account_code" gorm:"not null"`
+	// IsSystem marks the categories the system posts to; they cannot be used
+	// for entries made by hand, changed or deleted
+	IsSystem  bool      `json:"is_system" gorm:"default:false"`
+	IsActive  bool      `json:"is_active" gorm:"default:true"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// EntryType is the cash flow type an entry of the category has; capital
+// entries say which way the money went themselves.
+func (c *CashFlowCategory) EntryType(requested string) string {
+	if c.Type == CashFlowCategoryCapital {
+		return requested
+	}
+	return c.Type
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// CostLayer is a batch of a product's stock at the unit cost it came in at.
+// Stock-ins open a layer and stock decreases drain the oldest layers first,
+// which gives the FIFO cost of what left stock. Layers are kept per product,
+// not per location: moving stock between locations does not change its cost.
+type CostLayer struct {
+	ID        uint     `json:"id" gorm:"primaryKey"`
+	ProductID uint     `json:"product_id" gorm:"not null;index"`
+	Product   *Product `json:"product,omitempty" gorm:"foreignKey:ProductID"`
+	// InventoryLogID is the stock-in that opened the layer; nil for opening balances
+	InventoryLogID *uint     `json:"inventory_log_id,omitempty" gorm:"index"`
+	UnitCost       float64   `json:"unit_cost" gorm:"type:numeric;not null"`
+	Quantity       float64   `json:"quantity" gorm:"type:numeric(14,3);not null"`            // Received into the layer
+	Remaining      float64   `json:"remaining" gorm:"type:numeric(14,3);not null;default:0"` // Still in stock
+	CreatedAt      time.Time `json:"created_at"`
+}
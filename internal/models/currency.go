@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+// Where an exchange rate came from
+const (
+	ExchangeRateManual = "manual" // Entered by hand
+	ExchangeRateImport = "import" // Read from an uploaded rate file
+)
+
+// Currency is a foreign currency the store accepts as payment. Amounts are
+// never kept in it: a payment is converted to the base currency at the rate
+// current when it is taken.
+type Currency struct {
+	ID     uint   `json:"id" gorm:"primaryKey"`
+	Code   string `json:"code" gorm:"type:varchar(3);unique;not null"` // ISO 4217, e.g. USD
+	Name   string `json:"name" gorm:"not null"`
+	Symbol string `json:"symbol"`
+	// Rate is how much of the base currency one unit of this currency buys
+	Rate          float64    `json:"rate" gorm:"type:numeric(18,6);not null"`
+	RateUpdatedAt *time.Time `json:"rate_updated_at,omitempty"`
+	IsActive      bool       `json:"is_active" gorm:"default:true"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// ExchangeRate is a rate a currency was set to, kept as its rate history.
+type ExchangeRate struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	CurrencyID uint      `json:"currency_id" gorm:"not null;index"`
+	Currency   *Currency `json:"currency,omitempty" gorm:"foreignKey:CurrencyID"`
+	Rate       float64   `json:"rate" gorm:"type:numeric(18,6);not null"`
+	Source     string    `json:"source" gorm:"type:varchar(10);not null"` // "manual" or "import"
+	UserID     uint      `json:"user_id" gorm:"not null;index"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// TransactionPayment is what a customer handed over in one currency. Amount
+// is in Currency, BaseAmount is Amount converted at Rate, the currency's rate
+// when the sale was made.
+type TransactionPayment struct {
+	ID            uint    `json:"id" gorm:"primaryKey"`
+	TransactionID uint    `json:"transaction_id" gorm:"not null;index"`
+	Currency      string  `json:"currency" gorm:"type:varchar(3);not null"`
+	Amount        float64 `json:"amount" gorm:"type:numeric(14,2);not null"`
+	Rate          float64 `json:"rate" gorm:"type:numeric(18,6);not null"` // 1 for the base currency
+	BaseAmount    float64 `json:"base_amount" gorm:"type:numeric(14,2);not null"`
+}
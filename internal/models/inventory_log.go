@@ -6,19 +6,47 @@ import (
 	"gorm.io/gorm"
 )
 
+// InventoryAuditSource is the source of the adjustments posted by the
+// inventory integrity check to book stock changes that were never logged. An
+// audit entry settles the location's chain: its StockBefore is what the logs add up to and its
+// StockAfter the stock on record.
+const InventoryAuditSource = "audit"
+
 // InventoryLog tracks all stock movements (In, Out, Adjustment)
 type InventoryLog struct {
-	ID          uint           `json:"id" gorm:"primaryKey"`
-	ProductID   uint           `json:"product_id" gorm:"not null;index"`
-	Product     Product        `json:"product" gorm:"foreignKey:ProductID"`
-	Type        string         `json:"type" gorm:"not null"` // "in", "out", "adjustment"
-	Source      string         `json:"source"`               // "purchase", "return", "damage", "expired", "opname", "sale", "audit"
-	Quantity    int            `json:"quantity" gorm:"not null"`
-	CostPrice   float64        `json:"cost_price" gorm:"type:numeric"` // Cost per unit at time of entry
-	TotalCost   float64        `json:"total_cost" gorm:"type:numeric"` // quantity * cost_price
-	StockBefore int            `json:"stock_before" gorm:"not null"`   // Stock level before this entry
-	StockAfter  int            `json:"stock_after" gorm:"not null"`    // Stock level after this entry
-	Notes       string         `json:"notes"`
+	ID        uint    `json:"id" gorm:"primaryKey"`
+	ProductID uint    `json:"product_id" gorm:"not null;index"`
+	Product   Product `json:"product" gorm:"foreignKey:ProductID"`
+	// LocationID is where the stock moved; StockBefore/StockAfter are that location's levels
+	LocationID  uint      `json:"location_id" gorm:"not null;index"`
+	Location    *Location `json:"location,omitempty" gorm:"foreignKey:LocationID"`
+	Type        string    `json:"type" gorm:"not null"` // "in", "out", "adjustment"
+	Source      string    `json:"source"`               // "purchase", "return", "damage", "expired", "opname", "sale", "opening", "audit"
+	Quantity    float64   `json:"quantity" gorm:"type:numeric(14,3);not null"`
+	CostPrice   float64   `json:"cost_price" gorm:"type:numeric"`                  // Cost per unit at time of entry
+	TotalCost   float64   `json:"total_cost" gorm:"type:numeric"`                  // quantity * cost_price
+	StockBefore float64   `json:"stock_before" gorm:"type:numeric(14,3);not null"` // Stock level before this entry
+	StockAfter  float64   `json:"stock_after" gorm:"type:numeric(14,3);not null"`  // Stock level after this entry
+	Notes       string    `json:"notes"`
+	// PurchaseOrderID links goods receipts to the purchase order they were received against
+	PurchaseOrderID *uint `json:"purchase_order_id,omitempty" gorm:"index"`
+	// StockOpnameID links count adjustments to the approved stock opname session
+	StockOpnameID *uint `json:"stock_opname_id,omitempty" gorm:"index"`
+	// StockTransferID links both legs of a transfer between locations
+	StockTransferID *uint `json:"stock_transfer_id,omitempty" gorm:"index"`
+	// TransactionID links sale and return entries to their POS transaction
+	TransactionID *uint `json:"transaction_id,omitempty" gorm:"index"`
+	// Lots lists the lots this entry received into or consumed from
+	Lots []InventoryLogLot `json:"lots,omitempty" gorm:"foreignKey:InventoryLogID"`
+	// SerialNumbers lists the units of a serialized product this entry moved
+	SerialNumbers []string `json:"serial_numbers,omitempty" gorm:"type:jsonb;serializer:json"`
+	// Shortfall is how many of the units sold were beyond the location's stock on record
+	Shortfall float64 `json:"shortfall" gorm:"type:numeric(14,3);not null;default:0"`
+	// NeedsReview flags a shortfall sold under the "allow" policy until a manager reviews it
+	NeedsReview bool           `json:"needs_review" gorm:"not null;default:false"`
+	ReviewedAt  *time.Time     `json:"reviewed_at,omitempty"`
+	ReviewedBy  *uint          `json:"reviewed_by,omitempty"`
+	ReviewNotes string         `json:"review_notes,omitempty"`
 	UserID      uint           `json:"user_id" gorm:"not null;index"`
 	User        User           `json:"user" gorm:"foreignKey:UserID"`
 	CreatedAt   time.Time      `json:"created_at"`
@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+// Journal entry sources
+const (
+	JournalSale            = "sale"             // Sale with its cost of goods sold
+	JournalPurchase        = "purchase"         // Stock received, paid in cash or owed to the supplier
+	JournalInventory       = "inventory"        // Opening stock, or stock written off, found or lost at a count
+	JournalSupplierPayment = "supplier_payment" // Payment of a supplier payable
+	JournalCashFlow        = "cash_flow"        // Income or expense entered by hand
+	JournalBankFee         = "bank_fee"         // Merchant fees deducted from an imported settlement statement
+	JournalReversal        = "reversal"         // Contra entry of a cancelled or returned sale, or a corrected entry
+)
+
+// JournalEntry is a balanced posting to the general ledger. Entries are never
+// changed or deleted; a wrong entry is undone by a reversal with its lines
+// swapped.
+type JournalEntry struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	Date        time.Time `json:"date" gorm:"not null;index"`
+	Source      string    `json:"source" gorm:"type:varchar(30);not null;index"`
+	Description string    `json:"description"`
+	// References to the document the entry was posted for
+	TransactionID   *uint `json:"transaction_id,omitempty" gorm:"index"`
+	PurchaseOrderID *uint `json:"purchase_order_id,omitempty" gorm:"index"`
+	InventoryLogID  *uint `json:"inventory_log_id,omitempty" gorm:"index"`
+	// ReversalOfID is the entry this one reverses; an entry is reversed at most once
+	ReversalOfID *uint         `json:"reversal_of_id,omitempty" gorm:"uniqueIndex"`
+	UserID       uint          `json:"user_id" gorm:"not null;index"`
+	User         *User         `json:"user,omitempty" gorm:"foreignKey:UserID"`
+	Lines        []JournalLine `json:"lines" gorm:"foreignKey:JournalEntryID"`
+	CreatedAt    time.Time     `json:"created_at"`
+}
+
+// JournalLine debits or credits one account; exactly one side is non-zero.
+type JournalLine struct {
+	ID             uint     `json:"id" gorm:"primaryKey"`
+	JournalEntryID uint     `json:"journal_entry_id" gorm:"not null;index"`
+	AccountID      uint     `json:"account_id" gorm:"not null;index"`
+	Account        *Account `json:"account,omitempty" gorm:"foreignKey:AccountID"`
+	Debit          float64  `json:"debit" gorm:"type:numeric(14,2);not null;default:0"`
+	Credit         float64  `json:"credit" gorm:"type:numeric(14,2);not null;default:0"`
+	Memo           string   `json:"memo,omitempty"`
+}
@@ -0,0 +1,87 @@
+package models
+
+import "time"
+
+// Journal export scopes, each covering the entries of some journal sources
+// and the reversals of those entries
+const (
+	JournalScopeSales     = "sales"     // Sales with their cost of goods sold
+	JournalScopeExpenses  = "expenses"  // Cash book entries, merchant fees and supplier payments
+	JournalScopeInventory = "inventory" // Stock received, written off or counted
+)
+
+// Fields a journal export column can hold, one row per journal line
+const (
+	ExportFieldDate        = "date"         // The entry's date, in the layout's DateFormat
+	ExportFieldEntryNo     = "entry_no"     // The entry's ID, shared by its lines
+	ExportFieldReference   = "reference"    // Transaction code or PO number of the document posted
+	ExportFieldSource      = "source"       // Journal source, e.g. "sale"
+	ExportFieldDescription = "description"  // The entry's description
+	ExportFieldAccountCode = "account_code" // Account code, translated by the layout's AccountCodes
+	ExportFieldAccountName = "account_name"
+	ExportFieldDebit       = "debit"
+	ExportFieldCredit      = "credit"
+	ExportFieldAmount      = "amount" // Debit minus credit
+	ExportFieldMemo        = "memo"   // The line's memo
+)
+
+// ExportColumn is one column of a journal export file: a field of the
+// journal line, or the fixed Value when Field is empty.
+type ExportColumn struct {
+	Header string `json:"header"`
+	Field  string `json:"field,omitempty"`
+	Value  string `json:"value,omitempty"`
+}
+
+// ExportLayout describes the CSV file a journal export is written as, so it
+// can be imported as is into the bookkeeper's accounting software.
+type ExportLayout struct {
+	Columns []ExportColumn `json:"columns"`
+	// AccountCodes maps our account codes to the bookkeeper's; unmapped
+	// codes are written as they are
+	AccountCodes map[string]string `json:"account_codes,omitempty"`
+	// DateFormat uses YYYY, MM and DD, e.g. "DD/MM/YYYY"; defaults to
+	// "YYYY-MM-DD"
+	DateFormat   string `json:"date_format,omitempty"`
+	Delimiter    string `json:"delimiter,omitempty"`     // One character, defaults to ","
+	DecimalComma bool   `json:"decimal_comma,omitempty"` // Amounts written as 1234,56
+	OmitHeader   bool   `json:"omit_header,omitempty"`   // Leave out the header row
+}
+
+// JournalExportTemplate is a saved export layout, e.g. one per accounting
+// software.
+type JournalExportTemplate struct {
+	ID        uint         `json:"id" gorm:"primaryKey"`
+	Name      string       `json:"name" gorm:"unique;not null"`
+	Layout    ExportLayout `json:"layout" gorm:"type:jsonb;serializer:json;not null"`
+	UserID    uint         `json:"user_id" gorm:"not null;index"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+}
+
+// JournalExport is a batch of journal entries exported for the bookkeeper.
+// An entry is exported in one batch only, so later exports of the same
+// period hold just the entries posted since; deleting a batch releases its
+// entries to be exported again.
+type JournalExport struct {
+	ID     uint     `json:"id" gorm:"primaryKey"`
+	Scopes []string `json:"scopes" gorm:"type:jsonb;serializer:json;not null"`
+	// TemplateName and Layout are the template the batch was made with, kept
+	// so that the file downloads the same after the template changes
+	TemplateName string       `json:"template_name"`
+	Layout       ExportLayout `json:"layout" gorm:"type:jsonb;serializer:json;not null"`
+	StartDate    time.Time    `json:"start_date" gorm:"type:date;not null"`
+	EndDate      time.Time    `json:"end_date" gorm:"type:date;not null"`
+	EntryCount   int          `json:"entry_count" gorm:"not null;default:0"`
+	LineCount    int          `json:"line_count" gorm:"not null;default:0"`
+	TotalDebit   float64      `json:"total_debit" gorm:"type:numeric(14,2);not null;default:0"`
+	UserID       uint         `json:"user_id" gorm:"not null;index"`
+	User         *User        `json:"user,omitempty" gorm:"foreignKey:UserID"`
+	CreatedAt    time.Time    `json:"created_at"`
+}
+
+// JournalExportEntry marks a journal entry as exported in a batch.
+type JournalExportEntry struct {
+	JournalEntryID  uint `json:"journal_entry_id" gorm:"primaryKey;autoIncrement:false"`
+	JournalExportID uint `json:"journal_export_id" gorm:"not null;index"`
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Location is a place stock is kept, e.g. the back storeroom or a shop floor
+type Location struct {
+	ID        uint           `json:"id" gorm:"primaryKey"`
+	Code      string         `json:"code" gorm:"unique;not null"` // e.g. "MAIN", "GUDANG"
+	Name      string         `json:"name" gorm:"unique;not null"`
+	Type      string         `json:"type" gorm:"type:varchar(20);not null;default:'store'"` // "store" or "warehouse"
+	IsDefault bool           `json:"is_default" gorm:"not null;default:false"`              // Used when no location is given; exactly one
+	IsActive  bool           `json:"is_active" gorm:"default:true"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index"`
+}
+
+// ProductStock is a product's stock level at one location. Product.Stock is
+// kept as the total over all locations.
+type ProductStock struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	ProductID  uint      `json:"product_id" gorm:"not null;uniqueIndex:idx_product_location"`
+	Product    *Product  `json:"product,omitempty" gorm:"foreignKey:ProductID"`
+	LocationID uint      `json:"location_id" gorm:"not null;uniqueIndex:idx_product_location;index"`
+	Location   *Location `json:"location,omitempty" gorm:"foreignKey:LocationID"`
+	Quantity   float64   `json:"quantity" gorm:"type:numeric(14,3);not null;default:0"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// Register is a POS checkout counter; its sales deduct stock from its location
+type Register struct {
+	ID         uint           `json:"id" gorm:"primaryKey"`
+	Code       string         `json:"code" gorm:"unique;not null"` // e.g. "KASIR-1"
+	Name       string         `json:"name"`
+	LocationID uint           `json:"location_id" gorm:"not null;index"`
+	Location   *Location      `json:"location,omitempty" gorm:"foreignKey:LocationID"`
+	IsActive   bool           `json:"is_active" gorm:"default:true"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+	DeletedAt  gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index"`
+}
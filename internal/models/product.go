@@ -7,17 +7,27 @@ import (
 )
 
 type Product struct {
-	ID          uint           `json:"id" gorm:"primaryKey"`
-	Name        string         `json:"name" gorm:"not null"`
-	SKU         string         `json:"sku" gorm:"unique"` // Stock Keeping Unit (kode unik)
-	Barcode     string         `json:"barcode"`           // Unique index handled by migration (partial index where != '')
-	Description string         `json:"description"`
-	Price       float64        `json:"price" gorm:"type:numeric;not null"` // Harga Jual
-	Cost        float64        `json:"cost" gorm:"type:numeric"`           // Harga Modal (penting untuk menghitung profit)
-	Stock       int            `json:"stock" gorm:"not null"`
-	CategoryID  uint           `json:"category_id"`
-	Category    Category       `json:"category" gorm:"foreignKey:CategoryID"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
-	DeletedAt   gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index"`
+	ID           uint      `json:"id" gorm:"primaryKey"`
+	Name         string    `json:"name" gorm:"not null"`
+	SKU          string    `json:"sku" gorm:"unique"`                            // Stock Keeping Unit (kode unik)
+	Barcode      string    `json:"barcode"`                                      // Unique index handled by migration (partial index where != '')
+	PLU          string    `json:"plu"`                                          // Kode PLU timbangan, unique index handled by migration (partial index where != '')
+	SoldByWeight bool      `json:"sold_by_weight" gorm:"not null;default:false"` // Dijual per kg, Price adalah harga per kg
+	Serialized   bool      `json:"serialized" gorm:"not null;default:false"`     // Setiap unit punya nomor seri (stok masuk & penjualan wajib menyertakan nomor seri)
+	Description  string    `json:"description"`
+	Price        float64   `json:"price" gorm:"type:numeric;not null"`       // Harga Jual
+	Cost         float64   `json:"cost" gorm:"type:numeric"`                 // Harga Modal (penting untuk menghitung profit)
+	Stock        float64   `json:"stock" gorm:"type:numeric(14,3);not null"` // Desimal untuk produk timbangan
+	CategoryID   uint      `json:"category_id"`
+	Category     Category  `json:"category" gorm:"foreignKey:CategoryID"`
+	MinStock     float64   `json:"min_stock" gorm:"type:numeric(14,3);not null;default:10"` // Stok di bawah ini dianggap stok rendah
+	MaxStock     float64   `json:"max_stock" gorm:"type:numeric(14,3);not null;default:0"`  // Target stok setelah restock, 0 berarti tidak diatur
+	LeadTimeDays int       `json:"lead_time_days" gorm:"not null;default:0"`                // Perkiraan hari dari pemesanan sampai barang datang
+	SupplierID   *uint     `json:"supplier_id,omitempty" gorm:"index"`                      // Supplier utama untuk saran pemesanan ulang
+	Supplier     *Supplier `json:"supplier,omitempty" gorm:"foreignKey:SupplierID"`
+	// NegativeStockPolicy menimpa pengaturan toko untuk produk ini ("block", "warn", "allow"), kosong = ikut toko
+	NegativeStockPolicy string         `json:"negative_stock_policy" gorm:"type:varchar(10);not null;default:''"`
+	CreatedAt           time.Time      `json:"created_at"`
+	UpdatedAt           time.Time      `json:"updated_at"`
+	DeletedAt           gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index"`
 }
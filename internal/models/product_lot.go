@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// ProductLot is a batch of a product received at one location, with its own lot
+// number and expiry date. Stock decreases consume lots first-expiry-first-out;
+// stock received without a lot is untracked and sits behind the lots.
+type ProductLot struct {
+	ID               uint       `json:"id" gorm:"primaryKey"`
+	ProductID        uint       `json:"product_id" gorm:"not null;uniqueIndex:idx_product_lot_number"`
+	Product          *Product   `json:"product,omitempty" gorm:"foreignKey:ProductID"`
+	LocationID       uint       `json:"location_id" gorm:"not null;uniqueIndex:idx_product_lot_number;index"`
+	Location         *Location  `json:"location,omitempty" gorm:"foreignKey:LocationID"`
+	LotNumber        string     `json:"lot_number" gorm:"not null;uniqueIndex:idx_product_lot_number"`
+	ExpiryDate       *time.Time `json:"expiry_date" gorm:"type:date;index"`                             // nil when the lot does not expire
+	Quantity         float64    `json:"quantity" gorm:"type:numeric(14,3);not null;default:0"`          // Remaining at the location
+	ReceivedQuantity float64    `json:"received_quantity" gorm:"type:numeric(14,3);not null;default:0"` // Total ever received into the lot
+	CostPrice        float64    `json:"cost_price" gorm:"type:numeric;not null;default:0"`              // Unit cost when first received
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+// IsExpired reports whether the lot is past its expiry date on the given day.
+// A lot can still be sold on its expiry date.
+func (l *ProductLot) IsExpired(on time.Time) bool {
+	return l.ExpiryDate != nil && l.ExpiryDate.Format("2006-01-02") < on.Format("2006-01-02")
+}
+
+// InventoryLogLot records how much of a lot an inventory log moved.
+type InventoryLogLot struct {
+	ID             uint        `json:"id" gorm:"primaryKey"`
+	InventoryLogID uint        `json:"inventory_log_id" gorm:"not null;index"`
+	ProductLotID   uint        `json:"product_lot_id" gorm:"not null;index"`
+	ProductLot     *ProductLot `json:"product_lot,omitempty" gorm:"foreignKey:ProductLotID"`
+	Quantity       float64     `json:"quantity" gorm:"type:numeric(14,3);not null"`
+}
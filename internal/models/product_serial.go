@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+// Product serial statuses
+const (
+	SerialInStock = "in_stock"
+	SerialSold    = "sold"
+	SerialRemoved = "removed" // Taken out of stock by an adjustment (damage, loss, ...)
+)
+
+// ProductSerial is one unit of a serialized product. It tracks where the unit
+// is, how it came into stock and, once sold, the sale it left with, so a
+// warranty claim can be traced from the serial number alone.
+type ProductSerial struct {
+	ID           uint      `json:"id" gorm:"primaryKey"`
+	ProductID    uint      `json:"product_id" gorm:"not null;uniqueIndex:idx_product_serial_number"`
+	Product      *Product  `json:"product,omitempty" gorm:"foreignKey:ProductID"`
+	SerialNumber string    `json:"serial_number" gorm:"not null;uniqueIndex:idx_product_serial_number;index"`
+	LocationID   uint      `json:"location_id" gorm:"not null;index"` // Where the unit is, or was when it left stock
+	Location     *Location `json:"location,omitempty" gorm:"foreignKey:LocationID"`
+	Status       string    `json:"status" gorm:"type:varchar(20);not null;default:'in_stock'"` // "in_stock", "sold", "removed"
+	ReceivedAt   time.Time `json:"received_at"`
+	// ReceivedLogID is the stock-in that brought the unit in; nil for units
+	// registered against stock already on hand
+	ReceivedLogID   *uint          `json:"received_log_id,omitempty" gorm:"index"`
+	PurchaseOrderID *uint          `json:"purchase_order_id,omitempty" gorm:"index"`
+	PurchaseOrder   *PurchaseOrder `json:"purchase_order,omitempty" gorm:"foreignKey:PurchaseOrderID"`
+	// TransactionID and SoldAt are set while the unit is sold and cleared when it is returned
+	TransactionID *uint        `json:"transaction_id,omitempty" gorm:"index"`
+	Transaction   *Transaction `json:"transaction,omitempty" gorm:"foreignKey:TransactionID"`
+	SoldAt        *time.Time   `json:"sold_at,omitempty"`
+	CreatedAt     time.Time    `json:"created_at"`
+	UpdatedAt     time.Time    `json:"updated_at"`
+}
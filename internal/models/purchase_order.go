@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Purchase order statuses
+const (
+	PurchaseOrderDraft             = "draft"
+	PurchaseOrderSent              = "sent"
+	PurchaseOrderPartiallyReceived = "partially_received"
+	PurchaseOrderReceived          = "received"
+	PurchaseOrderCancelled         = "cancelled"
+)
+
+// PurchaseOrder records what we ordered from a supplier and how much of it has arrived
+type PurchaseOrder struct {
+	ID         uint     `json:"id" gorm:"primaryKey"`
+	PONumber   string   `json:"po_number" gorm:"unique;not null"` // e.g. PO-1697430000000000000
+	SupplierID uint     `json:"supplier_id" gorm:"not null;index"`
+	Supplier   Supplier `json:"supplier" gorm:"foreignKey:SupplierID"`
+	// LocationID is where goods are received, nil for the default location
+	LocationID   *uint      `json:"location_id,omitempty" gorm:"index"`
+	Location     *Location  `json:"location,omitempty" gorm:"foreignKey:LocationID"`
+	Status       string     `json:"status" gorm:"type:varchar(20);not null;default:'draft'"` // "draft", "sent", "partially_received", "received", "cancelled"
+	ExpectedDate *time.Time `json:"expected_date"`
+	TotalAmount  float64    `json:"total_amount" gorm:"type:numeric;not null;default:0"` // Expected cost of all lines
+	// PaymentTermDays > 0 books receipts as a supplier payable due after that many days
+	PaymentTermDays int                 `json:"payment_term_days" gorm:"not null;default:0"`
+	Notes           string              `json:"notes"`
+	UserID          uint                `json:"user_id" gorm:"not null;index"`
+	User            User                `json:"user" gorm:"foreignKey:UserID"`
+	Items           []PurchaseOrderItem `json:"items" gorm:"foreignKey:PurchaseOrderID"`
+	CreatedAt       time.Time           `json:"created_at"`
+	UpdatedAt       time.Time           `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt      `json:"deleted_at,omitempty" gorm:"index"`
+}
+
+// PurchaseOrderItem is one product line of a purchase order
+type PurchaseOrderItem struct {
+	ID               uint    `json:"id" gorm:"primaryKey"`
+	PurchaseOrderID  uint    `json:"purchase_order_id" gorm:"not null;index"`
+	ProductID        uint    `json:"product_id" gorm:"not null;index"`
+	Product          Product `json:"product" gorm:"foreignKey:ProductID"`
+	Quantity         float64 `json:"quantity" gorm:"type:numeric(14,3);not null"`
+	ReceivedQuantity float64 `json:"received_quantity" gorm:"type:numeric(14,3);not null;default:0"`
+	UnitCost         float64 `json:"unit_cost" gorm:"type:numeric;not null"` // Expected cost per unit
+	SubTotal         float64 `json:"subtotal" gorm:"type:numeric;not null"`  // Quantity * UnitCost
+}
@@ -0,0 +1,93 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// How often a recurring expense falls due
+const (
+	RecurrenceWeekly  = "weekly"
+	RecurrenceMonthly = "monthly"
+	RecurrenceYearly  = "yearly"
+)
+
+// RecurringExpense is a template the scheduler books as a cash flow expense
+// each time it falls due, e.g. rent on the 1st of every month.
+type RecurringExpense struct {
+	ID        uint              `json:"id" gorm:"primaryKey"`
+	Name      string            `json:"name" gorm:"not null"`
+	Source    string            `json:"source" gorm:"not null;index"` // Code of the expense category
+	Category  *CashFlowCategory `json:"category,omitempty" gorm:"foreignKey:Source;references:Code"`
+	Amount    float64           `json:"amount" gorm:"type:numeric(14,2);not null"`
+	Frequency string            `json:"frequency" gorm:"not null"` // "weekly", "monthly" or "yearly"
+	// DayOfWeek is the weekday weekly expenses fall on, 0 = Sunday
+	DayOfWeek int `json:"day_of_week" gorm:"not null;default:0"`
+	// DayOfMonth is the day monthly and yearly expenses fall on; in shorter
+	// months they fall on the last day
+	DayOfMonth int        `json:"day_of_month" gorm:"not null;default:0"`
+	Month      int        `json:"month" gorm:"not null;default:0"` // Month yearly expenses fall in, 1-12
+	StartDate  time.Time  `json:"start_date" gorm:"type:date;not null"`
+	EndDate    *time.Time `json:"end_date,omitempty" gorm:"type:date"`
+	Notes      string     `json:"notes"`
+	IsActive   bool       `json:"is_active" gorm:"default:true"`
+	// BookedThrough is the last day the scheduler has booked the expense for;
+	// occurrences up to it are never booked again
+	BookedThrough *time.Time     `json:"booked_through,omitempty" gorm:"type:date"`
+	UserID        uint           `json:"user_id" gorm:"not null;index"` // Who set it up; the entries are booked as them
+	User          User           `json:"user" gorm:"foreignKey:UserID"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index"`
+}
+
+// Occurrences returns the dates between from and to, both included, the
+// expense falls due on within its start and end dates. Dates are midnight UTC,
+// like the cash flow dates they are booked on.
+func (r *RecurringExpense) Occurrences(from, to time.Time) []time.Time {
+	from, to = dateOnly(from), dateOnly(to)
+	if start := dateOnly(r.StartDate); from.Before(start) {
+		from = start
+	}
+	if r.EndDate != nil {
+		if end := dateOnly(*r.EndDate); to.After(end) {
+			to = end
+		}
+	}
+
+	var dates []time.Time
+	switch r.Frequency {
+	case RecurrenceWeekly:
+		offset := (r.DayOfWeek - int(from.Weekday()) + 7) % 7
+		for d := from.AddDate(0, 0, offset); !d.After(to); d = d.AddDate(0, 0, 7) {
+			dates = append(dates, d)
+		}
+	case RecurrenceMonthly:
+		for m := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, time.UTC); !m.After(to); m = m.AddDate(0, 1, 0) {
+			if d := dayInMonth(m.Year(), m.Month(), r.DayOfMonth); !d.Before(from) && !d.After(to) {
+				dates = append(dates, d)
+			}
+		}
+	case RecurrenceYearly:
+		for y := from.Year(); y <= to.Year(); y++ {
+			if d := dayInMonth(y, time.Month(r.Month), r.DayOfMonth); !d.Before(from) && !d.After(to) {
+				dates = append(dates, d)
+			}
+		}
+	}
+	return dates
+}
+
+// dayInMonth returns day of the month, or its last day when it is shorter.
+func dayInMonth(year int, month time.Month, day int) time.Time {
+	last := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	if day > last {
+		day = last
+	}
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Stock opname session statuses
+const (
+	StockOpnameOpen      = "open"
+	StockOpnameApproved  = "approved"
+	StockOpnameCancelled = "cancelled"
+)
+
+// StockOpname is a physical count session. Expected stock is frozen per product
+// when the session starts; approval posts the variances as inventory adjustments.
+type StockOpname struct {
+	ID         uint              `json:"id" gorm:"primaryKey"`
+	Code       string            `json:"code" gorm:"unique;not null"`       // e.g. SO-1697430000000000000
+	LocationID uint              `json:"location_id" gorm:"not null;index"` // Where the count takes place
+	Location   *Location         `json:"location,omitempty" gorm:"foreignKey:LocationID"`
+	CategoryID *uint             `json:"category_id,omitempty" gorm:"index"` // nil counts all products
+	Category   *Category         `json:"category,omitempty" gorm:"foreignKey:CategoryID"`
+	Status     string            `json:"status" gorm:"type:varchar(20);not null;default:'open';index"` // "open", "approved", "cancelled"
+	Notes      string            `json:"notes"`
+	UserID     uint              `json:"user_id" gorm:"not null;index"` // Who started the session
+	User       User              `json:"user" gorm:"foreignKey:UserID"`
+	ApprovedBy *uint             `json:"approved_by,omitempty"`
+	ApprovedAt *time.Time        `json:"approved_at,omitempty"`
+	Items      []StockOpnameItem `json:"items,omitempty" gorm:"foreignKey:StockOpnameID"`
+	CreatedAt  time.Time         `json:"created_at"`
+	UpdatedAt  time.Time         `json:"updated_at"`
+	DeletedAt  gorm.DeletedAt    `json:"deleted_at,omitempty" gorm:"index"`
+}
+
+// StockOpnameItem is the frozen snapshot of one product and the total counted so far
+type StockOpnameItem struct {
+	ID            uint    `json:"id" gorm:"primaryKey"`
+	StockOpnameID uint    `json:"stock_opname_id" gorm:"not null;uniqueIndex:idx_stock_opname_product"`
+	ProductID     uint    `json:"product_id" gorm:"not null;uniqueIndex:idx_stock_opname_product"`
+	Product       Product `json:"product" gorm:"foreignKey:ProductID"`
+	ExpectedStock float64 `json:"expected_stock" gorm:"type:numeric(14,3);not null"` // Stock when the session started
+	CostPrice     float64 `json:"cost_price" gorm:"type:numeric;not null"`           // Cost when the session started
+	// CountedQuantity is the sum of all submitted counts, nil while the product has not been counted
+	CountedQuantity *float64           `json:"counted_quantity" gorm:"type:numeric(14,3)"`
+	Counts          []StockOpnameCount `json:"counts,omitempty" gorm:"foreignKey:StockOpnameItemID"`
+}
+
+// Variance returns counted minus expected stock, or 0 when the product has not been counted
+func (i StockOpnameItem) Variance() float64 {
+	if i.CountedQuantity == nil {
+		return 0
+	}
+	return math.Round((*i.CountedQuantity-i.ExpectedStock)*1000) / 1000
+}
+
+// StockOpnameCount is one count submitted by a staff member. Counts for the
+// same product are added up, so several people can count different shelves.
+type StockOpnameCount struct {
+	ID                uint      `json:"id" gorm:"primaryKey"`
+	StockOpnameID     uint      `json:"stock_opname_id" gorm:"not null;index"`
+	StockOpnameItemID uint      `json:"stock_opname_item_id" gorm:"not null;index"`
+	ProductID         uint      `json:"product_id" gorm:"not null;index"`
+	Quantity          float64   `json:"quantity" gorm:"type:numeric(14,3);not null"`
+	Barcode           string    `json:"barcode,omitempty"`                                           // Set when the count was scanned
+	MissingSerials    []string  `json:"missing_serials,omitempty" gorm:"type:jsonb;serializer:json"` // Serialized products: units not found, taken out of stock on approval
+	UserID            uint      `json:"user_id" gorm:"not null;index"`
+	User              User      `json:"user" gorm:"foreignKey:UserID"`
+	CreatedAt         time.Time `json:"created_at"`
+}
@@ -0,0 +1,46 @@
+package models
+
+import "time"
+
+// Stock reservation statuses. An active reservation stops holding stock once
+// it expires, without its status changing.
+const (
+	ReservationActive    = "active"
+	ReservationReleased  = "released"
+	ReservationFulfilled = "fulfilled"
+)
+
+// StockReservation holds stock at a location for a held order until it is
+// sold, released or expires, so no other channel can sell the same units
+type StockReservation struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	Code       string    `json:"code" gorm:"unique;not null"` // e.g. RSV-1697430000000000000
+	LocationID uint      `json:"location_id" gorm:"not null;index"`
+	Location   *Location `json:"location,omitempty" gorm:"foreignKey:LocationID"`
+	Channel    string    `json:"channel" gorm:"type:varchar(30)"` // e.g. "pos", "online", "phone"
+	Reference  string    `json:"reference"`                       // Held order or external order number
+	Notes      string    `json:"notes"`
+	Status     string    `json:"status" gorm:"type:varchar(20);not null;default:'active'"` // "active", "released", "fulfilled"
+	ExpiresAt  time.Time `json:"expires_at" gorm:"not null;index"`
+	// TransactionID is the sale that fulfilled the reservation
+	TransactionID *uint                  `json:"transaction_id,omitempty" gorm:"index"`
+	UserID        uint                   `json:"user_id" gorm:"not null;index"`
+	User          User                   `json:"user" gorm:"foreignKey:UserID"`
+	Items         []StockReservationItem `json:"items" gorm:"foreignKey:ReservationID"`
+	CreatedAt     time.Time              `json:"created_at"`
+	UpdatedAt     time.Time              `json:"updated_at"`
+}
+
+// IsHolding reports whether the reservation still holds stock at now.
+func (r StockReservation) IsHolding(now time.Time) bool {
+	return r.Status == ReservationActive && now.Before(r.ExpiresAt)
+}
+
+// StockReservationItem is the quantity of one product a reservation holds
+type StockReservationItem struct {
+	ID            uint    `json:"id" gorm:"primaryKey"`
+	ReservationID uint    `json:"reservation_id" gorm:"not null;index"`
+	ProductID     uint    `json:"product_id" gorm:"not null;index"`
+	Product       Product `json:"product" gorm:"foreignKey:ProductID"`
+	Quantity      float64 `json:"quantity" gorm:"type:numeric(14,3);not null"`
+}
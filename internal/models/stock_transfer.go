@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// StockTransfer moves stock from one location to another. It is posted in a
+// single DB transaction when created.
+type StockTransfer struct {
+	ID             uint                `json:"id" gorm:"primaryKey"`
+	Code           string              `json:"code" gorm:"unique;not null"` // e.g. TRF-1697430000000000000
+	FromLocationID uint                `json:"from_location_id" gorm:"not null;index"`
+	FromLocation   *Location           `json:"from_location,omitempty" gorm:"foreignKey:FromLocationID"`
+	ToLocationID   uint                `json:"to_location_id" gorm:"not null;index"`
+	ToLocation     *Location           `json:"to_location,omitempty" gorm:"foreignKey:ToLocationID"`
+	Notes          string              `json:"notes"`
+	UserID         uint                `json:"user_id" gorm:"not null;index"`
+	User           User                `json:"user" gorm:"foreignKey:UserID"`
+	Items          []StockTransferItem `json:"items" gorm:"foreignKey:StockTransferID"`
+	CreatedAt      time.Time           `json:"created_at"`
+	DeletedAt      gorm.DeletedAt      `json:"deleted_at,omitempty" gorm:"index"`
+}
+
+// StockTransferItem is one product line of a transfer
+type StockTransferItem struct {
+	ID              uint    `json:"id" gorm:"primaryKey"`
+	StockTransferID uint    `json:"stock_transfer_id" gorm:"not null;index"`
+	ProductID       uint    `json:"product_id" gorm:"not null;index"`
+	Product         Product `json:"product" gorm:"foreignKey:ProductID"`
+	Quantity        float64 `json:"quantity" gorm:"type:numeric(14,3);not null"`
+	// SerialNumbers lists the units moved, for serialized products
+	SerialNumbers []string `json:"serial_numbers,omitempty" gorm:"type:jsonb;serializer:json"`
+}
@@ -2,12 +2,33 @@ package models
 
 import "time"
 
+// Costing methods for valuing stock and the cost of goods sold
+const (
+	CostingAverage = "average" // Weighted moving average, recomputed on every stock-in
+	CostingFIFO    = "fifo"    // Oldest cost layers are consumed first
+)
+
+// Negative stock policies: what a sale does when it needs more units than the
+// stock on record, e.g. goods on the shelf that were never received in
+const (
+	NegativeStockBlock = "block" // Refuse the sale
+	NegativeStockWarn  = "warn"  // Sell below zero and warn the cashier
+	NegativeStockAllow = "allow" // Sell below zero and flag the shortfall for review
+)
+
 type StoreSetting struct {
-	ID         uint      `json:"id" gorm:"primaryKey"`
-	StoreName  string    `json:"store_name" gorm:"not null;default:'My Store'"`
-	Address    string    `json:"address"`
-	Phone      string    `json:"phone"`
-	FooterText string    `json:"footer_text" gorm:"default:'Thank you for your purchase!'"`
-	CreatedAt  time.Time `json:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at"`
+	ID         uint   `json:"id" gorm:"primaryKey"`
+	StoreName  string `json:"store_name" gorm:"not null;default:'My Store'"`
+	Address    string `json:"address"`
+	Phone      string `json:"phone"`
+	FooterText string `json:"footer_text" gorm:"default:'Thank you for your purchase!'"`
+	// CostingMethod decides the cost of goods sold and the stock value: "average" or "fifo"
+	CostingMethod string `json:"costing_method" gorm:"type:varchar(10);not null;default:'average'"`
+	// NegativeStockPolicy applies to products without their own: "block", "warn" or "allow"
+	NegativeStockPolicy string `json:"negative_stock_policy" gorm:"type:varchar(10);not null;default:'block'"`
+	// BaseCurrency is the ISO 4217 code every amount is kept and reported in;
+	// exchange rates are quoted against it
+	BaseCurrency string    `json:"base_currency" gorm:"type:varchar(3);not null;default:'IDR'"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
 }
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Supplier payable statuses
+const (
+	PayableOpen    = "open"
+	PayablePartial = "partial"
+	PayablePaid    = "paid"
+)
+
+// SupplierPayable is an amount owed to a supplier for goods received on credit
+type SupplierPayable struct {
+	ID              uint              `json:"id" gorm:"primaryKey"`
+	SupplierID      uint              `json:"supplier_id" gorm:"not null;index"`
+	Supplier        Supplier          `json:"supplier" gorm:"foreignKey:SupplierID"`
+	PurchaseOrderID *uint             `json:"purchase_order_id,omitempty" gorm:"index"`
+	PurchaseOrder   *PurchaseOrder    `json:"purchase_order,omitempty" gorm:"foreignKey:PurchaseOrderID"`
+	InventoryLogID  *uint             `json:"inventory_log_id,omitempty" gorm:"index"` // The goods receipt that created the payable
+	Amount          float64           `json:"amount" gorm:"type:numeric;not null"`
+	PaidAmount      float64           `json:"paid_amount" gorm:"type:numeric;not null;default:0"`
+	DueDate         time.Time         `json:"due_date" gorm:"not null;index"`
+	Status          string            `json:"status" gorm:"type:varchar(20);not null;default:'open';index"` // "open", "partial", "paid"
+	Notes           string            `json:"notes"`
+	Payments        []SupplierPayment `json:"payments,omitempty" gorm:"foreignKey:PayableID"`
+	CreatedAt       time.Time         `json:"created_at"`
+	UpdatedAt       time.Time         `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt    `json:"deleted_at,omitempty" gorm:"index"`
+}
+
+// Outstanding returns the amount still owed
+func (p SupplierPayable) Outstanding() float64 {
+	return p.Amount - p.PaidAmount
+}
+
+// SupplierPayment records money paid against a payable. The matching
+// CashFlow expense is created at payment time.
+type SupplierPayment struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	PayableID  uint      `json:"payable_id" gorm:"not null;index"`
+	SupplierID uint      `json:"supplier_id" gorm:"not null;index"`
+	Amount     float64   `json:"amount" gorm:"type:numeric;not null"`
+	Date       time.Time `json:"date" gorm:"not null"`
+	Reference  string    `json:"reference"` // e.g. bank transfer number
+	Notes      string    `json:"notes"`
+	CashFlowID uint      `json:"cash_flow_id" gorm:"not null;index"`
+	UserID     uint      `json:"user_id" gorm:"not null;index"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
@@ -7,16 +7,23 @@ import (
 )
 
 type Transaction struct {
-	ID                 uint                `json:"id" gorm:"primaryKey"`
-	TransactionCode    string              `json:"transaction_code" gorm:"unique;not null"`   // Contoh: INV-20231016-0001
-	TotalAmount        float64             `json:"total_amount" gorm:"type:numeric;not null"` // Total sebelum diskon/pajak
-	Discount           float64             `json:"discount" gorm:"type:numeric"`
-	GrandTotal         float64             `json:"grand_total" gorm:"type:numeric;not null"`                    // Total akhir yang harus dibayar
-	Cash               float64             `json:"cash" gorm:"type:numeric;not null"`                           // Uang tunai yang dibayarkan pelanggan
-	Change             float64             `json:"change" gorm:"type:numeric;not null"`                         // Uang kembalian
-	PaymentMethod      string              `json:"payment_method"`                                              // e.g., "Cash", "QRIS"
-	Status             string              `json:"status" gorm:"type:varchar(20);not null;default:'completed'"` // "completed", "returned", "cancelled"
-	TransactionDetails []TransactionDetail `json:"transaction_details" gorm:"foreignKey:TransactionID"`         // Relasi ke detail
-	CreatedAt          time.Time           `json:"created_at"`
-	DeletedAt          gorm.DeletedAt      `json:"deleted_at,omitempty" gorm:"index"`
+	ID                 uint                 `json:"id" gorm:"primaryKey"`
+	TransactionCode    string               `json:"transaction_code" gorm:"unique;not null"`   // Contoh: INV-20231016-0001
+	TotalAmount        float64              `json:"total_amount" gorm:"type:numeric;not null"` // Total sebelum diskon/pajak
+	Discount           float64              `json:"discount" gorm:"type:numeric"`
+	GrandTotal         float64              `json:"grand_total" gorm:"type:numeric;not null"`                    // Total akhir yang harus dibayar
+	Cash               float64              `json:"cash" gorm:"type:numeric;not null"`                           // Uang yang dibayarkan pelanggan, dalam mata uang dasar toko
+	Change             float64              `json:"change" gorm:"type:numeric;not null"`                         // Uang kembalian, selalu dalam mata uang dasar toko
+	PaymentMethod      string               `json:"payment_method"`                                              // e.g., "Cash", "QRIS"
+	Status             string               `json:"status" gorm:"type:varchar(20);not null;default:'completed'"` // "completed", "returned", "cancelled"
+	LocationID         uint                 `json:"location_id" gorm:"not null;index"`                           // Lokasi stok yang dikurangi (lokasi kasir)
+	RegisterID         *uint                `json:"register_id,omitempty" gorm:"index"`                          // Kasir (register) tempat transaksi dibuat
+	CustomerName       string               `json:"customer_name,omitempty"`                                     // Opsional, untuk klaim garansi
+	CustomerPhone      string               `json:"customer_phone,omitempty"`                                    // Opsional, untuk klaim garansi
+	ReservationID      *uint                `json:"reservation_id,omitempty" gorm:"index"`                       // Reservasi stok (pesanan yang ditahan) yang dipenuhi transaksi ini
+	StockWarnings      []string             `json:"stock_warnings,omitempty" gorm:"-"`                           // Peringatan stok minus untuk kasir (kebijakan "warn"), tidak disimpan
+	TransactionDetails []TransactionDetail  `json:"transaction_details" gorm:"foreignKey:TransactionID"`         // Relasi ke detail
+	Payments           []TransactionPayment `json:"payments,omitempty" gorm:"foreignKey:TransactionID"`          // Pembayaran per mata uang; Cash adalah jumlah BaseAmount-nya
+	CreatedAt          time.Time            `json:"created_at"`
+	DeletedAt          gorm.DeletedAt       `json:"deleted_at,omitempty" gorm:"index"`
 }
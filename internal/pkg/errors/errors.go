@@ -4,11 +4,14 @@ import "errors"
 
 // Definisi Custom Errors untuk lapisan Service
 var (
-	ErrNotFound             = errors.New("not found")                        // 404
-	ErrConflict             = errors.New("conflict")                         // 409 (Data duplikat, dll.)
-	ErrInsufficientStock    = errors.New("insufficient stock")               // 400
-	ErrPaymantRequired      = errors.New("payment required")                 // 402 (Uang kurang)
-	ErrForeignKeyConstraint = errors.New("foreign key constraint violation") // 400/409
+	ErrNotFound             = errors.New("not found")                           // 404
+	ErrConflict             = errors.New("conflict")                            // 409 (Data duplikat, dll.)
+	ErrInsufficientStock    = errors.New("insufficient stock")                  // 400
+	ErrPaymantRequired      = errors.New("payment required")                    // 402 (Uang kurang)
+	ErrForeignKeyConstraint = errors.New("foreign key constraint violation")    // 400/409
+	ErrOverpayment          = errors.New("payment exceeds outstanding balance") // 400 (Bayar melebihi sisa hutang)
+	ErrUnbalancedJournal    = errors.New("journal entry is not balanced")       // 500 (Debit dan kredit jurnal tidak sama)
+	ErrPeriodClosed         = errors.New("accounting period is closed")         // 409 (Periode akuntansi sudah ditutup)
 )
 
 // Gunakan fungsi ini di Service Layer
@@ -18,6 +18,11 @@ const (
 
 	// EventInventoryAdjusted is emitted right before an inventory adjustment (in/out) is committed.
 	EventInventoryAdjusted = "inventory.adjusted"
+
+	// EventBudgetThresholdCrossed is emitted when a category's spending in a
+	// month reaches its budget's alert percentage. It is not part of any
+	// database transaction.
+	EventBudgetThresholdCrossed = "budget.threshold_crossed"
 )
 
 // TransactionCreatedPayload is the data passed when a transaction is completed.
@@ -39,3 +44,11 @@ type InventoryAdjustedPayload struct {
 	InventoryLog *models.InventoryLog
 	UserID       uint
 }
+
+// BudgetThresholdPayload is the data passed when spending crosses a budget's
+// alert percentage.
+type BudgetThresholdPayload struct {
+	Budget      *models.Budget
+	Actual      float64 // Spent in the month so far
+	PercentUsed float64 // Actual as a percentage of the budget
+}
@@ -0,0 +1,150 @@
+// Package forecast projects daily sales series forward. It fits Holt's
+// damped-trend exponential smoothing on top of multiplicative day-of-week
+// seasonality, which is enough for the weekly rhythm of a shop without
+// needing an external ML service.
+package forecast
+
+import (
+	"math"
+	"time"
+)
+
+// Smoothing parameters. Alpha weighs recent days for the level, Beta for the
+// trend, and Phi damps the trend so long projections flatten out instead of
+// running away.
+const (
+	Alpha = 0.3
+	Beta  = 0.1
+	Phi   = 0.9
+)
+
+// MinSeasonalDays is the history needed before weekday patterns are trusted;
+// shorter series are treated as having no weekly seasonality.
+const MinSeasonalDays = 14
+
+// Point is one day of a projected series.
+type Point struct {
+	Date  time.Time `json:"date"`
+	Value float64   `json:"value"`
+}
+
+// Model is a fitted series that can be projected forward.
+type Model struct {
+	Level    float64
+	Trend    float64
+	Seasonal [7]float64 // Multiplier per time.Weekday, averaging 1
+	LastDate time.Time  // Day of the last observation
+}
+
+// Fit fits a model to one value per day, the first being start. Missing days
+// must be passed as zeros.
+func Fit(start time.Time, values []float64) Model {
+	start = Day(start)
+	m := Model{LastDate: start.AddDate(0, 0, len(values)-1)}
+	for i := range m.Seasonal {
+		m.Seasonal[i] = 1
+	}
+	if len(values) == 0 {
+		m.LastDate = start.AddDate(0, 0, -1)
+		return m
+	}
+
+	if len(values) >= MinSeasonalDays {
+		m.Seasonal = seasonalIndices(start, values)
+	}
+
+	// Start from the deseasonalized mean of the first week
+	n := len(values)
+	if n > 7 {
+		n = 7
+	}
+	for i := 0; i < n; i++ {
+		m.Level += values[i] / m.Seasonal[start.AddDate(0, 0, i).Weekday()]
+	}
+	m.Level /= float64(n)
+
+	for i, v := range values {
+		y := v / m.Seasonal[start.AddDate(0, 0, i).Weekday()]
+		level := Alpha*y + (1-Alpha)*(m.Level+Phi*m.Trend)
+		m.Trend = Beta*(level-m.Level) + (1-Beta)*Phi*m.Trend
+		m.Level = level
+	}
+	return m
+}
+
+// Project returns the expected value for each of the days after the last
+// observation. Values never go below zero.
+func (m Model) Project(days int) []Point {
+	points := make([]Point, 0, days)
+	var damped, factor float64 = 0, 1
+	for h := 1; h <= days; h++ {
+		factor *= Phi
+		damped += factor
+		date := m.LastDate.AddDate(0, 0, h)
+		value := (m.Level + damped*m.Trend) * m.Seasonal[date.Weekday()]
+		points = append(points, Point{Date: date, Value: math.Max(value, 0)})
+	}
+	return points
+}
+
+// StockOut returns the first projected day on which cumulative demand uses up
+// stock, or nil when stock outlasts the projection.
+func StockOut(stock float64, points []Point) *time.Time {
+	if stock <= 0 && len(points) > 0 {
+		date := points[0].Date
+		return &date
+	}
+	var cumulative float64
+	for _, p := range points {
+		cumulative += p.Value
+		// Allow for float drift so a flat 3/day uses up 9 units on day three
+		if cumulative >= stock-1e-6 {
+			date := p.Date
+			return &date
+		}
+	}
+	return nil
+}
+
+// Day truncates t to midnight in its own location.
+func Day(t time.Time) time.Time {
+	y, mo, d := t.Date()
+	return time.Date(y, mo, d, 0, 0, 0, 0, t.Location())
+}
+
+// seasonalIndices returns the average value of each weekday relative to the
+// overall average, normalized to average 1. Weekdays with no sales at all keep
+// a small floor so they can still be deseasonalized.
+func seasonalIndices(start time.Time, values []float64) [7]float64 {
+	var sums [7]float64
+	var counts [7]int
+	var total float64
+	for i, v := range values {
+		wd := start.AddDate(0, 0, i).Weekday()
+		sums[wd] += v
+		counts[wd]++
+		total += v
+	}
+
+	var indices [7]float64
+	mean := total / float64(len(values))
+	if mean <= 0 {
+		for i := range indices {
+			indices[i] = 1
+		}
+		return indices
+	}
+
+	var sum float64
+	for i := range indices {
+		indices[i] = 1
+		if counts[i] > 0 {
+			indices[i] = math.Max(sums[i]/float64(counts[i])/mean, 0.05)
+		}
+		sum += indices[i]
+	}
+	for i := range indices {
+		indices[i] *= 7 / sum
+	}
+	return indices
+}
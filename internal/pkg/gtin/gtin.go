@@ -0,0 +1,86 @@
+// Package gtin implements check digit calculation and validation for
+// GS1 trade item numbers (EAN-8, UPC-A, EAN-13).
+package gtin
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// CheckDigit calculates the GS1 mod-10 check digit for body, which is the
+// code without its final check digit (e.g. 12 digits for EAN-13).
+func CheckDigit(body string) (int, error) {
+	if body == "" {
+		return 0, errors.New("empty code")
+	}
+
+	sum := 0
+	// Weights alternate 3,1,3,... starting from the rightmost digit of the body.
+	for i := len(body) - 1; i >= 0; i-- {
+		c := body[i]
+		if c < '0' || c > '9' {
+			return 0, fmt.Errorf("invalid character %q in code", c)
+		}
+		d := int(c - '0')
+		if (len(body)-1-i)%2 == 0 {
+			d *= 3
+		}
+		sum += d
+	}
+
+	return (10 - sum%10) % 10, nil
+}
+
+// IsNumeric reports whether s consists only of ASCII digits.
+func IsNumeric(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// IsGTINLength reports whether n is the length of an EAN-8, UPC-A or EAN-13 code.
+func IsGTINLength(n int) bool {
+	return n == 8 || n == 12 || n == 13
+}
+
+// Valid reports whether code is a numeric EAN-8, UPC-A or EAN-13 with a correct check digit.
+func Valid(code string) bool {
+	if !IsGTINLength(len(code)) || !IsNumeric(code) {
+		return false
+	}
+
+	cd, err := CheckDigit(code[:len(code)-1])
+	if err != nil {
+		return false
+	}
+	return int(code[len(code)-1]-'0') == cd
+}
+
+// ValidEAN13 reports whether code is a valid 13-digit EAN.
+func ValidEAN13(code string) bool {
+	return len(code) == 13 && Valid(code)
+}
+
+// BuildEAN13 composes an EAN-13 from a 2-digit prefix and a 10-digit item reference.
+func BuildEAN13(prefix int, itemRef int64) (string, error) {
+	if prefix < 0 || prefix > 99 {
+		return "", fmt.Errorf("prefix %d out of range", prefix)
+	}
+	if itemRef < 0 || itemRef > 9999999999 {
+		return "", fmt.Errorf("item reference %d out of range", itemRef)
+	}
+
+	body := fmt.Sprintf("%02d%010d", prefix, itemRef)
+	cd, err := CheckDigit(body)
+	if err != nil {
+		return "", err
+	}
+	return body + strconv.Itoa(cd), nil
+}
@@ -0,0 +1,81 @@
+package labels
+
+import "sort"
+
+// Layout describes the physical geometry of a label sheet or roll.
+// All measurements are in millimetres.
+type Layout struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	PageWidth   float64 `json:"page_width_mm"`
+	PageHeight  float64 `json:"page_height_mm"`
+	Columns     int     `json:"columns"`
+	Rows        int     `json:"rows"`
+	LabelWidth  float64 `json:"label_width_mm"`
+	LabelHeight float64 `json:"label_height_mm"`
+	MarginTop   float64 `json:"margin_top_mm"`
+	MarginLeft  float64 `json:"margin_left_mm"`
+	GapX        float64 `json:"gap_x_mm"` // Horizontal gap between columns
+	GapY        float64 `json:"gap_y_mm"` // Vertical gap between rows
+	DPI         int     `json:"dpi"`      // Default render resolution for this media
+}
+
+// PerPage returns how many labels fit on one page of the layout.
+func (l Layout) PerPage() int {
+	return l.Columns * l.Rows
+}
+
+// Predefined layouts for common label media.
+var layouts = map[string]Layout{
+	"a4_3x10": {
+		Name:        "a4_3x10",
+		Description: "A4 sheet, 3 x 10 labels (70 x 29.7 mm)",
+		PageWidth:   210, PageHeight: 297,
+		Columns: 3, Rows: 10,
+		LabelWidth: 70, LabelHeight: 29.7,
+		DPI: 300,
+	},
+	"a4_5x13": {
+		Name:        "a4_5x13",
+		Description: "A4 sheet, 5 x 13 labels (38.1 x 21.2 mm)",
+		PageWidth:   210, PageHeight: 297,
+		Columns: 5, Rows: 13,
+		LabelWidth: 38.1, LabelHeight: 21.2,
+		MarginTop: 10.7, MarginLeft: 4.75,
+		GapX: 2.5,
+		DPI:  300,
+	},
+	"thermal_33x15": {
+		Name:        "thermal_33x15",
+		Description: "Thermal roll, 1 label across (33 x 15 mm)",
+		PageWidth:   33, PageHeight: 15,
+		Columns: 1, Rows: 1,
+		LabelWidth: 33, LabelHeight: 15,
+		DPI: 203,
+	},
+	"thermal_33x15_3up": {
+		Name:        "thermal_33x15_3up",
+		Description: "Thermal roll, 3 labels across (33 x 15 mm, 105 mm liner)",
+		PageWidth:   105, PageHeight: 15,
+		Columns: 3, Rows: 1,
+		LabelWidth: 33, LabelHeight: 15,
+		MarginLeft: 1, GapX: 2,
+		DPI: 203,
+	},
+}
+
+// GetLayout returns the predefined layout with the given name.
+func GetLayout(name string) (Layout, bool) {
+	l, ok := layouts[name]
+	return l, ok
+}
+
+// Layouts returns all predefined layouts sorted by name.
+func Layouts() []Layout {
+	result := make([]Layout, 0, len(layouts))
+	for _, l := range layouts {
+		result = append(result, l)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
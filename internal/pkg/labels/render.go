@@ -0,0 +1,295 @@
+package labels
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"strconv"
+	"strings"
+
+	"github.com/boombuler/barcode"
+	"github.com/boombuler/barcode/code128"
+	"github.com/boombuler/barcode/ean"
+	"github.com/boombuler/barcode/qr"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+// Supported barcode symbologies.
+const (
+	SymbologyCode128 = "code128"
+	SymbologyEAN13   = "ean13"
+	SymbologyQR      = "qr"
+)
+
+// MaxLabels caps the number of labels rendered in a single request.
+const MaxLabels = 2000
+
+// MinDPI and MaxDPI bound the render resolution; a page image is allocated at
+// this resolution, so an unbounded value could exhaust memory.
+const (
+	MinDPI = 72
+	MaxDPI = 600
+)
+
+// Label is a single printable label.
+type Label struct {
+	Name  string
+	Price float64
+	Code  string // Content encoded into the barcode
+}
+
+// Options controls how a label sheet is rendered.
+type Options struct {
+	Layout    Layout
+	Symbology string
+	DPI       int // Overrides Layout.DPI when > 0
+}
+
+var (
+	regularFont *opentype.Font
+	boldFont    *opentype.Font
+)
+
+func init() {
+	var err error
+	if regularFont, err = opentype.Parse(goregular.TTF); err != nil {
+		panic(err)
+	}
+	if boldFont, err = opentype.Parse(gobold.TTF); err != nil {
+		panic(err)
+	}
+}
+
+// Encode creates an unscaled barcode for content using the given symbology.
+func Encode(symbology, content string) (barcode.Barcode, error) {
+	switch symbology {
+	case SymbologyEAN13:
+		// EAN-13 requires exactly 12 or 13 digits
+		return ean.Encode(content)
+	case SymbologyQR:
+		return qr.Encode(content, qr.M, qr.Auto)
+	case SymbologyCode128, "":
+		return code128.Encode(content)
+	default:
+		return nil, fmt.Errorf("unsupported barcode type '%s'", symbology)
+	}
+}
+
+// renderer draws labels onto page images for one set of Options.
+type renderer struct {
+	opts      Options
+	dpi       int
+	pageW     int
+	pageH     int
+	nameFace  font.Face
+	priceFace font.Face
+	codeFace  font.Face
+}
+
+func newRenderer(opts Options) (*renderer, error) {
+	l := opts.Layout
+	if l.Columns <= 0 || l.Rows <= 0 || l.LabelWidth <= 0 || l.LabelHeight <= 0 {
+		return nil, errors.New("invalid label layout")
+	}
+
+	dpi := opts.DPI
+	if dpi <= 0 {
+		dpi = l.DPI
+	}
+	if dpi <= 0 {
+		dpi = 300
+	}
+	if dpi < MinDPI || dpi > MaxDPI {
+		return nil, fmt.Errorf("dpi must be between %d and %d", MinDPI, MaxDPI)
+	}
+
+	r := &renderer{
+		opts:  opts,
+		dpi:   dpi,
+		pageW: mmToPx(l.PageWidth, dpi),
+		pageH: mmToPx(l.PageHeight, dpi),
+	}
+
+	// Font sizes scale with label height so small thermal labels stay legible.
+	lineH := float64(mmToPx(l.LabelHeight, dpi)) * 0.16
+	var err error
+	if r.nameFace, err = newFace(regularFont, lineH, dpi); err != nil {
+		return nil, err
+	}
+	if r.priceFace, err = newFace(boldFont, lineH, dpi); err != nil {
+		return nil, err
+	}
+	if r.codeFace, err = newFace(regularFont, lineH*0.8, dpi); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func newFace(f *opentype.Font, pixelHeight float64, dpi int) (font.Face, error) {
+	// pixels = points * dpi / 72
+	size := pixelHeight * 72 / float64(dpi)
+	return opentype.NewFace(f, &opentype.FaceOptions{Size: size, DPI: float64(dpi), Hinting: font.HintingFull})
+}
+
+func mmToPx(mm float64, dpi int) int {
+	return int(mm / 25.4 * float64(dpi))
+}
+
+// pageCount returns the number of pages needed for n labels.
+func (r *renderer) pageCount(n int) int {
+	per := r.opts.Layout.PerPage()
+	return (n + per - 1) / per
+}
+
+// renderPage draws up to one page worth of labels.
+func (r *renderer) renderPage(items []Label) (*image.Gray, error) {
+	page := image.NewGray(image.Rect(0, 0, r.pageW, r.pageH))
+	draw.Draw(page, page.Bounds(), image.White, image.Point{}, draw.Src)
+
+	l := r.opts.Layout
+	for i, item := range items {
+		col := i % l.Columns
+		row := i / l.Columns
+		x := l.MarginLeft + float64(col)*(l.LabelWidth+l.GapX)
+		y := l.MarginTop + float64(row)*(l.LabelHeight+l.GapY)
+		rect := image.Rect(
+			mmToPx(x, r.dpi), mmToPx(y, r.dpi),
+			mmToPx(x+l.LabelWidth, r.dpi), mmToPx(y+l.LabelHeight, r.dpi),
+		)
+		if err := r.drawLabel(page, rect, item); err != nil {
+			return nil, fmt.Errorf("label '%s': %w", item.Name, err)
+		}
+	}
+
+	return page, nil
+}
+
+// drawLabel lays out name, barcode and price inside rect.
+func (r *renderer) drawLabel(dst *image.Gray, rect image.Rectangle, item Label) error {
+	pad := mmToPx(1.5, r.dpi)
+	inner := rect.Inset(pad)
+	if inner.Empty() {
+		return errors.New("label too small")
+	}
+
+	bc, err := Encode(r.opts.Symbology, item.Code)
+	if err != nil {
+		return fmt.Errorf("failed to encode barcode: %w", err)
+	}
+
+	nameH := lineHeight(r.nameFace)
+	priceH := lineHeight(r.priceFace)
+	price := FormatRupiah(item.Price)
+
+	if r.opts.Symbology == SymbologyQR {
+		// QR on the left as a square, text stacked on the right.
+		side := inner.Dy()
+		if side > inner.Dx()/2 {
+			side = inner.Dx() / 2
+		}
+		qrRect := image.Rect(inner.Min.X, inner.Min.Y, inner.Min.X+side, inner.Min.Y+side)
+		if err := drawBarcode(dst, qrRect, bc); err != nil {
+			return err
+		}
+
+		textX := qrRect.Max.X + pad
+		textW := inner.Max.X - textX
+		drawText(dst, r.nameFace, textX, inner.Min.Y+nameH, textW, item.Name)
+		drawText(dst, r.codeFace, textX, inner.Min.Y+2*nameH, textW, item.Code)
+		drawText(dst, r.priceFace, textX, inner.Max.Y, textW, price)
+		return nil
+	}
+
+	drawText(dst, r.nameFace, inner.Min.X, inner.Min.Y+nameH, inner.Dx(), item.Name)
+
+	barRect := image.Rect(inner.Min.X, inner.Min.Y+nameH+pad/2, inner.Max.X, inner.Max.Y-priceH-pad/2)
+	if err := drawBarcode(dst, barRect, bc); err != nil {
+		return err
+	}
+
+	// Bottom row: human-readable code on the left, price on the right.
+	priceW := font.MeasureString(r.priceFace, price).Ceil()
+	drawText(dst, r.priceFace, inner.Max.X-priceW, inner.Max.Y, priceW, price)
+	drawText(dst, r.codeFace, inner.Min.X, inner.Max.Y, inner.Dx()-priceW-pad, item.Code)
+	return nil
+}
+
+func lineHeight(face font.Face) int {
+	return face.Metrics().Height.Ceil()
+}
+
+// drawBarcode scales bc to fit rect and draws it centred.
+func drawBarcode(dst *image.Gray, rect image.Rectangle, bc barcode.Barcode) error {
+	if rect.Dx() < bc.Bounds().Dx() || rect.Dy() < bc.Bounds().Dy() {
+		return errors.New("barcode content is too long for this label size")
+	}
+
+	scaled, err := barcode.Scale(bc, rect.Dx(), rect.Dy())
+	if err != nil {
+		return fmt.Errorf("failed to scale barcode: %w", err)
+	}
+
+	draw.Draw(dst, rect, scaled, scaled.Bounds().Min, draw.Src)
+	return nil
+}
+
+// drawText draws s with its baseline at y, truncating it to maxW pixels.
+func drawText(dst *image.Gray, face font.Face, x, y, maxW int, s string) {
+	if maxW <= 0 || s == "" {
+		return
+	}
+
+	s = truncate(face, s, maxW)
+	d := font.Drawer{
+		Dst:  dst,
+		Src:  image.NewUniform(color.Black),
+		Face: face,
+		Dot:  fixed.P(x, y-face.Metrics().Descent.Ceil()),
+	}
+	d.DrawString(s)
+}
+
+func truncate(face font.Face, s string, maxW int) string {
+	if font.MeasureString(face, s).Ceil() <= maxW {
+		return s
+	}
+
+	runes := []rune(s)
+	for len(runes) > 0 {
+		runes = runes[:len(runes)-1]
+		candidate := strings.TrimSpace(string(runes)) + "…"
+		if font.MeasureString(face, candidate).Ceil() <= maxW {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// FormatRupiah formats an amount as "Rp 25.000".
+func FormatRupiah(amount float64) string {
+	neg := amount < 0
+	if neg {
+		amount = -amount
+	}
+
+	digits := strconv.FormatInt(int64(amount+0.5), 10)
+	var b strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte('.')
+		}
+		b.WriteRune(d)
+	}
+
+	if neg {
+		return "-Rp " + b.String()
+	}
+	return "Rp " + b.String()
+}
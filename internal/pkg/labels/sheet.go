@@ -0,0 +1,186 @@
+package labels
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/png"
+	"io"
+)
+
+// maxPNGHeight limits the height of a stacked PNG sheet; larger jobs should use PDF.
+const maxPNGHeight = 20000
+
+func validate(items []Label) error {
+	if len(items) == 0 {
+		return errors.New("no labels to render")
+	}
+	if len(items) > MaxLabels {
+		return fmt.Errorf("too many labels (max %d)", MaxLabels)
+	}
+	return nil
+}
+
+// WritePNG renders all labels and writes them as a single PNG, with pages
+// stacked vertically (a continuous strip for thermal rolls).
+func WritePNG(w io.Writer, opts Options, items []Label) error {
+	if err := validate(items); err != nil {
+		return err
+	}
+
+	r, err := newRenderer(opts)
+	if err != nil {
+		return err
+	}
+
+	pages := r.pageCount(len(items))
+	if r.pageH*pages > maxPNGHeight {
+		return errors.New("label sheet too large for PNG, use PDF format instead")
+	}
+
+	sheet := image.NewGray(image.Rect(0, 0, r.pageW, r.pageH*pages))
+	per := opts.Layout.PerPage()
+	for p := 0; p < pages; p++ {
+		page, err := r.renderPage(items[p*per : min((p+1)*per, len(items))])
+		if err != nil {
+			return err
+		}
+		draw.Draw(sheet, page.Bounds().Add(image.Pt(0, p*r.pageH)), page, image.Point{}, draw.Src)
+	}
+
+	return png.Encode(w, sheet)
+}
+
+// WritePDF renders all labels and writes them as a PDF document with one
+// page per sheet. Each page is embedded as a lossless grayscale image.
+func WritePDF(w io.Writer, opts Options, items []Label) error {
+	if err := validate(items); err != nil {
+		return err
+	}
+
+	r, err := newRenderer(opts)
+	if err != nil {
+		return err
+	}
+
+	pdf := newPDFWriter(w)
+	pageW := opts.Layout.PageWidth * 72 / 25.4
+	pageH := opts.Layout.PageHeight * 72 / 25.4
+
+	per := opts.Layout.PerPage()
+	for p := 0; p < r.pageCount(len(items)); p++ {
+		page, err := r.renderPage(items[p*per : min((p+1)*per, len(items))])
+		if err != nil {
+			return err
+		}
+		if err := pdf.addImagePage(page, pageW, pageH); err != nil {
+			return err
+		}
+	}
+
+	return pdf.close()
+}
+
+// pdfWriter is a minimal PDF 1.4 writer that places one full-page image per page.
+// Object 1 is the catalog and object 2 the page tree; both are written last.
+type pdfWriter struct {
+	w       io.Writer
+	offset  int
+	objects map[int]int // object number -> byte offset
+	nextObj int
+	pages   []int
+	err     error
+}
+
+func newPDFWriter(w io.Writer) *pdfWriter {
+	p := &pdfWriter{w: w, objects: map[int]int{}, nextObj: 3}
+	p.printf("%%PDF-1.4\n%%\xE2\xE3\xCF\xD3\n")
+	return p
+}
+
+func (p *pdfWriter) printf(format string, args ...interface{}) {
+	if p.err != nil {
+		return
+	}
+	n, err := fmt.Fprintf(p.w, format, args...)
+	p.offset += n
+	p.err = err
+}
+
+func (p *pdfWriter) write(b []byte) {
+	if p.err != nil {
+		return
+	}
+	n, err := p.w.Write(b)
+	p.offset += n
+	p.err = err
+}
+
+func (p *pdfWriter) beginObj(num int) {
+	p.objects[num] = p.offset
+	p.printf("%d 0 obj\n", num)
+}
+
+func (p *pdfWriter) alloc() int {
+	n := p.nextObj
+	p.nextObj++
+	return n
+}
+
+func (p *pdfWriter) addImagePage(img *image.Gray, widthPt, heightPt float64) error {
+	var data bytes.Buffer
+	zw := zlib.NewWriter(&data)
+	b := img.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		off := img.PixOffset(b.Min.X, y)
+		if _, err := zw.Write(img.Pix[off : off+b.Dx()]); err != nil {
+			return err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+
+	imgObj, contentObj, pageObj := p.alloc(), p.alloc(), p.alloc()
+
+	p.beginObj(imgObj)
+	p.printf("<< /Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceGray /BitsPerComponent 8 /Filter /FlateDecode /Length %d >>\nstream\n",
+		b.Dx(), b.Dy(), data.Len())
+	p.write(data.Bytes())
+	p.printf("\nendstream\nendobj\n")
+
+	content := fmt.Sprintf("q %.2f 0 0 %.2f 0 0 cm /Im0 Do Q", widthPt, heightPt)
+	p.beginObj(contentObj)
+	p.printf("<< /Length %d >>\nstream\n%s\nendstream\nendobj\n", len(content), content)
+
+	p.beginObj(pageObj)
+	p.printf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /XObject << /Im0 %d 0 R >> >> /Contents %d 0 R >>\nendobj\n",
+		widthPt, heightPt, imgObj, contentObj)
+
+	p.pages = append(p.pages, pageObj)
+	return p.err
+}
+
+func (p *pdfWriter) close() error {
+	p.beginObj(1)
+	p.printf("<< /Type /Catalog /Pages 2 0 R >>\nendobj\n")
+
+	p.beginObj(2)
+	p.printf("<< /Type /Pages /Count %d /Kids [", len(p.pages))
+	for _, num := range p.pages {
+		p.printf(" %d 0 R", num)
+	}
+	p.printf(" ] >>\nendobj\n")
+
+	xref := p.offset
+	p.printf("xref\n0 %d\n0000000000 65535 f \n", p.nextObj)
+	for num := 1; num < p.nextObj; num++ {
+		p.printf("%010d 00000 n \n", p.objects[num])
+	}
+	p.printf("trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", p.nextObj, xref)
+
+	return p.err
+}
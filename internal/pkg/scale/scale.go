@@ -0,0 +1,208 @@
+// Package scale parses GS1 variable-measure (weighing scale) barcodes, where
+// an in-store EAN-13 embeds a product PLU together with a weight or a price.
+package scale
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"pos-api/internal/pkg/gtin"
+)
+
+// Measure types embedded in a scale barcode.
+const (
+	MeasureWeight = "weight"
+	MeasurePrice  = "price"
+)
+
+// Mask characters used in pattern definitions.
+const (
+	maskPLU        = 'P'
+	maskWeight     = 'W'
+	maskPrice      = 'R'
+	maskIgnore     = 'X' // e.g. the optional price check digit some scales print
+	maskCheckDigit = 'C'
+)
+
+// DefaultPatterns covers the common layouts: prefix 28 with a 5-digit PLU and
+// weight in grams, prefix 29 with a 4-digit PLU and a 6-digit rupiah price.
+const DefaultPatterns = "28PPPPPWWWWWC:3,29PPPPRRRRRRC:0"
+
+// Pattern describes one variable-measure barcode layout.
+//
+// Mask is a 13 character template: digits are a literal prefix, 'P' marks the
+// PLU, 'W' the weight, 'R' the price, 'X' an ignored digit and the final 'C'
+// the EAN-13 check digit. Decimals is the number of implied decimal places of
+// the embedded value (3 turns grams into kilograms).
+type Pattern struct {
+	Mask     string
+	Decimals int
+
+	prefix   string
+	measure  string
+	pluStart int
+	pluEnd   int
+	valStart int
+	valEnd   int
+}
+
+// Result is a decoded scale barcode.
+type Result struct {
+	PLU     string  `json:"plu"`     // PLU without leading zeros
+	Measure string  `json:"measure"` // MeasureWeight or MeasurePrice
+	Value   float64 `json:"value"`   // Weight in kg or price, depending on Measure
+}
+
+// Parser matches barcodes against a set of patterns.
+type Parser struct {
+	patterns []Pattern
+}
+
+// NewPattern validates mask and prepares it for matching.
+func NewPattern(mask string, decimals int) (Pattern, error) {
+	p := Pattern{Mask: mask, Decimals: decimals}
+	if len(mask) != 13 {
+		return p, fmt.Errorf("scale pattern '%s': mask must be 13 characters", mask)
+	}
+	if mask[12] != maskCheckDigit {
+		return p, fmt.Errorf("scale pattern '%s': last character must be the check digit 'C'", mask)
+	}
+	if decimals < 0 || decimals > 4 {
+		return p, fmt.Errorf("scale pattern '%s': decimals must be between 0 and 4", mask)
+	}
+
+	p.pluStart, p.valStart = -1, -1
+	for i := 0; i < 12; i++ {
+		c := mask[i]
+		switch {
+		case c >= '0' && c <= '9':
+			if i != len(p.prefix) {
+				return p, fmt.Errorf("scale pattern '%s': prefix digits must come first", mask)
+			}
+			p.prefix += string(c)
+		case c == maskPLU:
+			if err := extendRange(&p.pluStart, &p.pluEnd, i); err != nil {
+				return p, fmt.Errorf("scale pattern '%s': PLU %w", mask, err)
+			}
+		case c == maskWeight || c == maskPrice:
+			measure := MeasureWeight
+			if c == maskPrice {
+				measure = MeasurePrice
+			}
+			if p.measure != "" && p.measure != measure {
+				return p, fmt.Errorf("scale pattern '%s': cannot mix weight and price", mask)
+			}
+			p.measure = measure
+			if err := extendRange(&p.valStart, &p.valEnd, i); err != nil {
+				return p, fmt.Errorf("scale pattern '%s': value %w", mask, err)
+			}
+		case c == maskIgnore:
+		default:
+			return p, fmt.Errorf("scale pattern '%s': invalid character %q", mask, c)
+		}
+	}
+
+	if p.prefix == "" {
+		return p, fmt.Errorf("scale pattern '%s': missing prefix", mask)
+	}
+	if p.pluStart < 0 {
+		return p, fmt.Errorf("scale pattern '%s': missing PLU", mask)
+	}
+	if p.valStart < 0 {
+		return p, fmt.Errorf("scale pattern '%s': missing weight or price", mask)
+	}
+	return p, nil
+}
+
+// extendRange grows the contiguous [start, end) range to include position i.
+func extendRange(start, end *int, i int) error {
+	if *start < 0 {
+		*start, *end = i, i+1
+		return nil
+	}
+	if *end != i {
+		return errors.New("digits must be contiguous")
+	}
+	*end = i + 1
+	return nil
+}
+
+// Prefix returns the literal prefix matched by the pattern.
+func (p Pattern) Prefix() string {
+	return p.prefix
+}
+
+// ParsePatterns parses a comma separated list of "MASK:DECIMALS" entries,
+// e.g. "28PPPPPWWWWWC:3,29PPPPRRRRRRC:0". Decimals default to 0 when omitted.
+func ParsePatterns(spec string) ([]Pattern, error) {
+	var patterns []Pattern
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		mask, dec, hasDec := strings.Cut(entry, ":")
+		decimals := 0
+		if hasDec {
+			n, err := strconv.Atoi(dec)
+			if err != nil {
+				return nil, fmt.Errorf("scale pattern '%s': invalid decimals", entry)
+			}
+			decimals = n
+		}
+
+		p, err := NewPattern(strings.ToUpper(mask), decimals)
+		if err != nil {
+			return nil, err
+		}
+		patterns = append(patterns, p)
+	}
+	return patterns, nil
+}
+
+// NewParser creates a Parser for the given patterns. The first matching
+// pattern wins, so more specific prefixes should be listed first.
+func NewParser(patterns []Pattern) *Parser {
+	return &Parser{patterns: patterns}
+}
+
+// Patterns returns the configured patterns.
+func (s *Parser) Patterns() []Pattern {
+	return s.patterns
+}
+
+// Parse decodes code against the configured patterns. It returns false when
+// code is not a valid EAN-13 or no pattern matches.
+func (s *Parser) Parse(code string) (*Result, bool) {
+	if s == nil || !gtin.ValidEAN13(code) {
+		return nil, false
+	}
+
+	for _, p := range s.patterns {
+		if !strings.HasPrefix(code, p.prefix) {
+			continue
+		}
+
+		plu := strings.TrimLeft(code[p.pluStart:p.pluEnd], "0")
+		if plu == "" {
+			continue
+		}
+
+		raw, err := strconv.ParseInt(code[p.valStart:p.valEnd], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		return &Result{
+			PLU:     plu,
+			Measure: p.measure,
+			Value:   float64(raw) / math.Pow10(p.Decimals),
+		}, true
+	}
+
+	return nil, false
+}
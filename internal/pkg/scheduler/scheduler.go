@@ -0,0 +1,42 @@
+// Package scheduler runs background jobs inside the API process.
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// Job is one run of a background job.
+type Job func(ctx context.Context) error
+
+// Every starts job in the background: once right away, then every interval
+// until ctx is done. Runs never overlap. A failed or panicking run is logged
+// and the job tries again on the next tick, so jobs must be safe to repeat.
+func Every(ctx context.Context, name string, interval time.Duration, job Job) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			if err := runOnce(ctx, job); err != nil {
+				slog.Error("Scheduled job failed", "job", name, "error", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func runOnce(ctx context.Context, job Job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return job(ctx)
+}
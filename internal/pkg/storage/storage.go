@@ -0,0 +1,23 @@
+// Package storage keeps uploaded files, such as receipt photos, in a blob
+// store: the local filesystem or an S3-compatible bucket.
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ErrNotFound is returned when no file is stored under the key.
+var ErrNotFound = errors.New("file not found")
+
+// Storage stores files under slash-separated keys, e.g. "cash_flow/12/ab34.jpg".
+type Storage interface {
+	// Put stores size bytes read from r under key, replacing any file there.
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Open returns the file stored under key; the caller closes it.
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the file stored under key. Deleting a missing file is
+	// not an error.
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,128 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"pos-api/internal/models"
+	"time"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AccountingPeriodRepository interface {
+	// Close closes the month starting at period and logs it.
+	Close(ctx context.Context, period time.Time, userID uint, reason string) (*models.AccountingPeriod, error)
+	// Reopen reopens a closed month and logs it.
+	Reopen(ctx context.Context, period time.Time, userID uint, reason string) (*models.AccountingPeriod, error)
+	GetAll(ctx context.Context) ([]models.AccountingPeriod, error)
+	// GetLogs lists the closes and reopens, newest first; of one month when
+	// period is set.
+	GetLogs(ctx context.Context, period *time.Time) ([]models.AccountingPeriodLog, error)
+}
+
+type accountingPeriodRepository struct {
+	DB *gorm.DB
+}
+
+func NewAccountingPeriodRepository(db *gorm.DB) AccountingPeriodRepository {
+	return &accountingPeriodRepository{DB: db}
+}
+
+func (r *accountingPeriodRepository) Close(ctx context.Context, period time.Time, userID uint, reason string) (*models.AccountingPeriod, error) {
+	return r.setClosed(ctx, period, true, userID, reason)
+}
+
+func (r *accountingPeriodRepository) Reopen(ctx context.Context, period time.Time, userID uint, reason string) (*models.AccountingPeriod, error) {
+	return r.setClosed(ctx, period, false, userID, reason)
+}
+
+func (r *accountingPeriodRepository) setClosed(ctx context.Context, period time.Time, closed bool, userID uint, reason string) (*models.AccountingPeriod, error) {
+	var stored models.AccountingPeriod
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		day := period.Format("2006-01-02")
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&models.AccountingPeriod{Period: period}).Error; err != nil {
+			return err
+		}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("period = ?", day).First(&stored).Error; err != nil {
+			return err
+		}
+		if stored.IsClosed == closed {
+			state := "open"
+			if closed {
+				state = "closed"
+			}
+			return fmt.Errorf("%w: %s is already %s", customErrors.ErrConflict, period.Format("January 2006"), state)
+		}
+
+		action := models.PeriodActionReopen
+		stored.IsClosed = closed
+		stored.ClosedAt, stored.ClosedBy = nil, nil
+		if closed {
+			action = models.PeriodActionClose
+			now := time.Now()
+			stored.ClosedAt, stored.ClosedBy = &now, &userID
+		}
+		if err := tx.Save(&stored).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.AccountingPeriodLog{
+			AccountingPeriodID: stored.ID,
+			Action:             action,
+			Reason:             reason,
+			UserID:             userID,
+		}).Error
+	})
+	return &stored, err
+}
+
+func (r *accountingPeriodRepository) GetAll(ctx context.Context) ([]models.AccountingPeriod, error) {
+	var periods []models.AccountingPeriod
+	err := r.DB.WithContext(ctx).Order("period DESC").Find(&periods).Error
+	return periods, err
+}
+
+func (r *accountingPeriodRepository) GetLogs(ctx context.Context, period *time.Time) ([]models.AccountingPeriodLog, error) {
+	var logs []models.AccountingPeriodLog
+	query := r.DB.WithContext(ctx).Preload("AccountingPeriod").Preload("User")
+	if period != nil {
+		query = query.Joins("JOIN accounting_periods ON accounting_periods.id = accounting_period_logs.accounting_period_id").
+			Where("accounting_periods.period = ?", period.Format("2006-01-02"))
+	}
+	err := query.Order("accounting_period_logs.id DESC").Find(&logs).Error
+	return logs, err
+}
+
+// PeriodClosed reports whether date falls in a closed accounting period. The
+// month is taken in date's own time zone.
+func PeriodClosed(tx *gorm.DB, date time.Time) (bool, error) {
+	var count int64
+	err := tx.Model(&models.AccountingPeriod{}).
+		Where("period = ? AND is_closed = ?", PeriodStart(date).Format("2006-01-02"), true).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// EnsurePeriodOpen refuses, with ErrPeriodClosed, to touch anything dated in
+// a closed accounting period.
+func EnsurePeriodOpen(tx *gorm.DB, date time.Time) error {
+	closed, err := PeriodClosed(tx, date)
+	if err != nil {
+		return err
+	}
+	if closed {
+		return fmt.Errorf("%w: %s is closed; post a correction in the current period instead",
+			customErrors.ErrPeriodClosed, date.Format("January 2006"))
+	}
+	return nil
+}
+
+// PeriodStart returns the first day of date's month, at midnight UTC like the
+// periods are stored.
+func PeriodStart(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
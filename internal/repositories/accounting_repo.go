@@ -0,0 +1,91 @@
+package repositories
+
+import (
+	"context"
+	"pos-api/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AccountingRepository reads the chart of accounts and the general ledger.
+// Entries are posted by the listeners and the cash book, never through here.
+type AccountingRepository interface {
+	GetAccounts(ctx context.Context) ([]models.Account, error)
+	GetEntries(ctx context.Context, limit, offset int, source string, accountID uint, startDate, endDate *time.Time) ([]models.JournalEntry, int64, error)
+	GetEntryByID(ctx context.Context, id uint) (*models.JournalEntry, error)
+	// GetAccountTotals sums the debits and credits posted to each account on
+	// or before asOf (every entry when nil).
+	GetAccountTotals(ctx context.Context, asOf *time.Time) ([]AccountTotal, error)
+}
+
+// AccountTotal is what has been posted to an account.
+type AccountTotal struct {
+	AccountID uint    `json:"account_id"`
+	Debit     float64 `json:"debit"`
+	Credit    float64 `json:"credit"`
+}
+
+type accountingRepository struct {
+	DB *gorm.DB
+}
+
+func NewAccountingRepository(db *gorm.DB) AccountingRepository {
+	return &accountingRepository{DB: db}
+}
+
+func (r *accountingRepository) GetAccounts(ctx context.Context) ([]models.Account, error) {
+	var accounts []models.Account
+	err := r.DB.WithContext(ctx).Order("code ASC").Find(&accounts).Error
+	return accounts, err
+}
+
+func (r *accountingRepository) GetEntries(ctx context.Context, limit, offset int, source string, accountID uint, startDate, endDate *time.Time) ([]models.JournalEntry, int64, error) {
+	var entries []models.JournalEntry
+	var total int64
+
+	query := r.DB.WithContext(ctx).Model(&models.JournalEntry{})
+
+	if source != "" {
+		query = query.Where("source = ?", source)
+	}
+	if accountID != 0 {
+		query = query.Where("id IN (?)", r.DB.Model(&models.JournalLine{}).Select("journal_entry_id").Where("account_id = ?", accountID))
+	}
+	if startDate != nil {
+		query = query.Where("date >= ?", *startDate)
+	}
+	if endDate != nil {
+		query = query.Where("date <= ?", *endDate)
+	}
+
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("date DESC, id DESC").
+		Limit(limit).Offset(offset).
+		Preload("Lines.Account").Preload("User").
+		Find(&entries).Error
+
+	return entries, total, err
+}
+
+func (r *accountingRepository) GetEntryByID(ctx context.Context, id uint) (*models.JournalEntry, error) {
+	var entry models.JournalEntry
+	err := r.DB.WithContext(ctx).Preload("Lines.Account").Preload("User").First(&entry, id).Error
+	return &entry, err
+}
+
+func (r *accountingRepository) GetAccountTotals(ctx context.Context, asOf *time.Time) ([]AccountTotal, error) {
+	var totals []AccountTotal
+	query := r.DB.WithContext(ctx).Table("journal_lines").
+		Joins("JOIN journal_entries ON journal_entries.id = journal_lines.journal_entry_id")
+	if asOf != nil {
+		query = query.Where("journal_entries.date <= ?", *asOf)
+	}
+	err := query.Select("journal_lines.account_id, COALESCE(SUM(journal_lines.debit), 0) AS debit, COALESCE(SUM(journal_lines.credit), 0) AS credit").
+		Group("journal_lines.account_id").
+		Scan(&totals).Error
+	return totals, err
+}
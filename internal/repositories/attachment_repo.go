@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"pos-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type AttachmentRepository interface {
+	Create(ctx context.Context, attachment *models.Attachment) error
+	Delete(ctx context.Context, id uint) error
+	GetByID(ctx context.Context, id uint) (*models.Attachment, error)
+	// GetByOwner lists the files attached to a record, owner being one of
+	// the models.AttachmentOwner* kinds.
+	GetByOwner(ctx context.Context, owner string, ownerID uint) ([]models.Attachment, error)
+	// OwnerExists reports whether the record is on record and not deleted.
+	OwnerExists(ctx context.Context, owner string, ownerID uint) (bool, error)
+}
+
+type attachmentRepository struct {
+	DB *gorm.DB
+}
+
+func NewAttachmentRepository(db *gorm.DB) AttachmentRepository {
+	return &attachmentRepository{DB: db}
+}
+
+// attachmentOwners maps each owner kind to its model and the attachment column
+// referencing it.
+var attachmentOwners = map[string]struct {
+	model  interface{}
+	column string
+}{
+	models.AttachmentOwnerCashFlow:     {&models.CashFlow{}, "cash_flow_id"},
+	models.AttachmentOwnerInventoryLog: {&models.InventoryLog{}, "inventory_log_id"},
+}
+
+func (r *attachmentRepository) Create(ctx context.Context, attachment *models.Attachment) error {
+	return r.DB.WithContext(ctx).Omit("User").Create(attachment).Error
+}
+
+func (r *attachmentRepository) Delete(ctx context.Context, id uint) error {
+	return r.DB.WithContext(ctx).Delete(&models.Attachment{}, id).Error
+}
+
+func (r *attachmentRepository) GetByID(ctx context.Context, id uint) (*models.Attachment, error) {
+	var attachment models.Attachment
+	err := r.DB.WithContext(ctx).Preload("User").First(&attachment, id).Error
+	return &attachment, err
+}
+
+func (r *attachmentRepository) GetByOwner(ctx context.Context, owner string, ownerID uint) ([]models.Attachment, error) {
+	o, ok := attachmentOwners[owner]
+	if !ok {
+		return nil, fmt.Errorf("unknown attachment owner %q", owner)
+	}
+	var attachments []models.Attachment
+	err := r.DB.WithContext(ctx).Preload("User").
+		Where(o.column+" = ?", ownerID).
+		Order("id ASC").
+		Find(&attachments).Error
+	return attachments, err
+}
+
+func (r *attachmentRepository) OwnerExists(ctx context.Context, owner string, ownerID uint) (bool, error) {
+	o, ok := attachmentOwners[owner]
+	if !ok {
+		return false, fmt.Errorf("unknown attachment owner %q", owner)
+	}
+	var count int64
+	err := r.DB.WithContext(ctx).Model(o.model).Where("id = ?", ownerID).Count(&count).Error
+	return count > 0, err
+}
@@ -0,0 +1,77 @@
+package repositories
+
+import (
+	"context"
+	"pos-api/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type BudgetRepository interface {
+	Create(ctx context.Context, budget *models.Budget) error
+	Update(ctx context.Context, budget *models.Budget) error
+	Delete(ctx context.Context, id uint) error
+	GetByID(ctx context.Context, id uint) (*models.Budget, error)
+	// GetBySourceMonth returns the budget of a category for the month
+	// starting at month.
+	GetBySourceMonth(ctx context.Context, source string, month time.Time) (*models.Budget, error)
+	// GetByMonth lists the budgets of the month starting at month.
+	GetByMonth(ctx context.Context, month time.Time) ([]models.Budget, error)
+	// SetAlerted sets the budget's AlertedAt to at, or clears it when at is
+	// nil. It reports false when AlertedAt was already set, or already clear,
+	// so that of concurrent checks only one raises the alert.
+	SetAlerted(ctx context.Context, id uint, at *time.Time) (bool, error)
+}
+
+type budgetRepository struct {
+	DB *gorm.DB
+}
+
+func NewBudgetRepository(db *gorm.DB) BudgetRepository {
+	return &budgetRepository{DB: db}
+}
+
+func (r *budgetRepository) Create(ctx context.Context, budget *models.Budget) error {
+	return r.DB.WithContext(ctx).Omit("Category", "User").Create(budget).Error
+}
+
+func (r *budgetRepository) Update(ctx context.Context, budget *models.Budget) error {
+	return r.DB.WithContext(ctx).Omit("Category", "User").Save(budget).Error
+}
+
+func (r *budgetRepository) Delete(ctx context.Context, id uint) error {
+	return r.DB.WithContext(ctx).Delete(&models.Budget{}, id).Error
+}
+
+func (r *budgetRepository) GetByID(ctx context.Context, id uint) (*models.Budget, error) {
+	var budget models.Budget
+	err := r.DB.WithContext(ctx).Preload("Category").Preload("User").First(&budget, id).Error
+	return &budget, err
+}
+
+func (r *budgetRepository) GetBySourceMonth(ctx context.Context, source string, month time.Time) (*models.Budget, error) {
+	var budget models.Budget
+	err := r.DB.WithContext(ctx).Where("source = ? AND month = ?", source, month.Format("2006-01-02")).First(&budget).Error
+	return &budget, err
+}
+
+func (r *budgetRepository) GetByMonth(ctx context.Context, month time.Time) ([]models.Budget, error) {
+	var budgets []models.Budget
+	err := r.DB.WithContext(ctx).Preload("Category").
+		Where("month = ?", month.Format("2006-01-02")).
+		Order("source ASC").
+		Find(&budgets).Error
+	return budgets, err
+}
+
+func (r *budgetRepository) SetAlerted(ctx context.Context, id uint, at *time.Time) (bool, error) {
+	query := r.DB.WithContext(ctx).Model(&models.Budget{}).Where("id = ?", id)
+	if at != nil {
+		query = query.Where("alerted_at IS NULL")
+	} else {
+		query = query.Where("alerted_at IS NOT NULL")
+	}
+	result := query.Update("alerted_at", at)
+	return result.RowsAffected > 0, result.Error
+}
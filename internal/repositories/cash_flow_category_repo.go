@@ -0,0 +1,87 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"pos-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type CashFlowCategoryRepository interface {
+	Create(ctx context.Context, category *models.CashFlowCategory) error
+	Update(ctx context.Context, category *models.CashFlowCategory) error
+	Delete(ctx context.Context, id uint) error
+	GetByID(ctx context.Context, id uint) (*models.CashFlowCategory, error)
+	GetByCode(ctx context.Context, code string) (*models.CashFlowCategory, error)
+	// GetAll lists the categories, of one type when cfType is set.
+	GetAll(ctx context.Context, cfType string, onlyActive bool) ([]models.CashFlowCategory, error)
+	// IsUsed reports whether any cash flow entry, deleted ones included, is
+	// in the category.
+	IsUsed(ctx context.Context, code string) (bool, error)
+	// AccountExists reports whether the chart of accounts has an active
+	// account with code.
+	AccountExists(ctx context.Context, code string) (bool, error)
+}
+
+type cashFlowCategoryRepository struct {
+	DB *gorm.DB
+}
+
+func NewCashFlowCategoryRepository(db *gorm.DB) CashFlowCategoryRepository {
+	return &cashFlowCategoryRepository{DB: db}
+}
+
+func (r *cashFlowCategoryRepository) Create(ctx context.Context, category *models.CashFlowCategory) error {
+	return r.DB.WithContext(ctx).Create(category).Error
+}
+
+func (r *cashFlowCategoryRepository) Update(ctx context.Context, category *models.CashFlowCategory) error {
+	return r.DB.WithContext(ctx).Save(category).Error
+}
+
+func (r *cashFlowCategoryRepository) Delete(ctx context.Context, id uint) error {
+	return r.DB.WithContext(ctx).Delete(&models.CashFlowCategory{}, id).Error
+}
+
+func (r *cashFlowCategoryRepository) GetByID(ctx context.Context, id uint) (*models.CashFlowCategory, error) {
+	var category models.CashFlowCategory
+	err := r.DB.WithContext(ctx).First(&category, id).Error
+	return &category, err
+}
+
+func (r *cashFlowCategoryRepository) GetByCode(ctx context.Context, code string) (*models.CashFlowCategory, error) {
+	var category models.CashFlowCategory
+	err := r.DB.WithContext(ctx).Where("code = ?", code).First(&category).Error
+	return &category, err
+}
+
+func (r *cashFlowCategoryRepository) GetAll(ctx context.Context, cfType string, onlyActive bool) ([]models.CashFlowCategory, error) {
+	var categories []models.CashFlowCategory
+	query := r.DB.WithContext(ctx)
+	if cfType != "" {
+		query = query.Where("type = ?", cfType)
+	}
+	if onlyActive {
+		query = query.Where("is_active = ?", true)
+	}
+	err := query.Order("type ASC, is_system DESC, name ASC").Find(&categories).Error
+	return categories, err
+}
+
+func (r *cashFlowCategoryRepository) IsUsed(ctx context.Context, code string) (bool, error) {
+	var cf models.CashFlow
+	err := r.DB.WithContext(ctx).Unscoped().Select("id").Where("source = ?", code).First(&cf).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (r *cashFlowCategoryRepository) AccountExists(ctx context.Context, code string) (bool, error) {
+	var count int64
+	err := r.DB.WithContext(ctx).Model(&models.Account{}).
+		Where("code = ? AND is_active = ?", code, true).
+		Count(&count).Error
+	return count > 0, err
+}
@@ -2,16 +2,31 @@ package repositories
 
 import (
 	"context"
+	"fmt"
 	"pos-api/internal/models"
 	"time"
 
+	customErrors "pos-api/internal/pkg/errors"
+
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type CashFlowRepository interface {
+	// Create posts the entry's journal entry and records it in the cash book.
 	Create(ctx context.Context, cf *models.CashFlow) error
+	// Update reverses the entry's journal entry and posts the corrected one.
+	// Only entries made by hand can be changed; the others change through
+	// their sale, receipt or payment. Neither the old nor the new date may
+	// be in a closed period.
 	Update(ctx context.Context, cf *models.CashFlow) error
+	// Delete reverses the entry's journal entry and removes it from the cash
+	// book; like Update, only for entries made by hand in an open period.
 	Delete(ctx context.Context, id uint) error
+	// ForceDelete removes an entry made by hand for good, deleted or not,
+	// reversing its journal entry when still in the cash book. It returns
+	// the removed attachments, whose files are left to the caller.
+	ForceDelete(ctx context.Context, id uint) ([]models.Attachment, error)
 	GetByID(ctx context.Context, id uint) (*models.CashFlow, error)
 	GetAll(ctx context.Context, limit, offset int, cfType, source string, startDate, endDate *time.Time) ([]models.CashFlow, int64, error)
 	GetSummary(ctx context.Context, startDate, endDate time.Time) (totalCapital, totalIncome, totalExpense float64, err error)
@@ -19,11 +34,20 @@ type CashFlowRepository interface {
 }
 
 type CashFlowSourceData struct {
-	Source      string  `json:"source"`
-	Type        string  `json:"type"`
-	TotalAmount float64 `json:"total_amount"`
+	Source       string  `json:"source"`
+	Name         string  `json:"name"` // The category's name
+	Type         string  `json:"type"`
+	CategoryType string  `json:"category_type"` // "income", "expense" or "capital"
+	TotalAmount  float64 `json:"total_amount"`
 }
 
+// joinCashFlowCategory joins each cash book entry with its category.
+const joinCashFlowCategory = "JOIN cash_flow_categories ON cash_flow_categories.code = cash_flows.source"
+
+// cashFlowSourceColumns selects CashFlowSourceData over joinCashFlowCategory.
+const cashFlowSourceColumns = "cash_flows.source, cash_flow_categories.name, cash_flows.type, " +
+	"cash_flow_categories.type AS category_type, SUM(cash_flows.amount) AS total_amount"
+
 type cashFlowRepository struct {
 	DB *gorm.DB
 }
@@ -33,20 +57,136 @@ func NewCashFlowRepository(db *gorm.DB) CashFlowRepository {
 }
 
 func (r *cashFlowRepository) Create(ctx context.Context, cf *models.CashFlow) error {
-	return r.DB.WithContext(ctx).Create(cf).Error
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		entry, err := manualCashFlowEntry(tx, cf)
+		if err != nil {
+			return err
+		}
+		return RecordCashFlow(tx, cf, entry)
+	})
 }
 
 func (r *cashFlowRepository) Update(ctx context.Context, cf *models.CashFlow) error {
-	return r.DB.WithContext(ctx).Save(cf).Error
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		stored, err := lockManualCashFlow(tx, cf.ID)
+		if err != nil {
+			return err
+		}
+		if err := EnsurePeriodOpen(tx, stored.Date); err != nil {
+			return err
+		}
+		if err := reverseCashFlow(tx, stored, "Correction of cash flow entry"); err != nil {
+			return err
+		}
+
+		entry, err := manualCashFlowEntry(tx, cf)
+		if err != nil {
+			return err
+		}
+		if err := PostJournal(tx, entry); err != nil {
+			return err
+		}
+		cf.JournalEntryID = nil
+		if entry.ID != 0 {
+			cf.JournalEntryID = &entry.ID
+		}
+		cf.JournalEntry = nil
+		return tx.Omit("JournalEntry", "Category", "User").Save(cf).Error
+	})
 }
 
 func (r *cashFlowRepository) Delete(ctx context.Context, id uint) error {
-	return r.DB.WithContext(ctx).Delete(&models.CashFlow{}, id).Error
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		stored, err := lockManualCashFlow(tx, id)
+		if err != nil {
+			return err
+		}
+		if err := EnsurePeriodOpen(tx, stored.Date); err != nil {
+			return err
+		}
+		if err := reverseCashFlow(tx, stored, "Deletion of cash flow entry"); err != nil {
+			return err
+		}
+		return tx.Delete(&models.CashFlow{}, id).Error
+	})
+}
+
+func (r *cashFlowRepository) ForceDelete(ctx context.Context, id uint) ([]models.Attachment, error) {
+	var attachments []models.Attachment
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		stored, err := lockManualCashFlow(tx.Unscoped(), id)
+		if err != nil {
+			return err
+		}
+		if err := EnsurePeriodOpen(tx, stored.Date); err != nil {
+			return err
+		}
+		if !stored.DeletedAt.Valid {
+			if err := reverseCashFlow(tx, stored, "Deletion of cash flow entry"); err != nil {
+				return err
+			}
+		}
+		if err := tx.Clauses(clause.Returning{}).Where("cash_flow_id = ?", id).Delete(&attachments).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Delete(&models.CashFlow{}, id).Error
+	})
+	return attachments, err
+}
+
+// lockManualCashFlow locks a cash book entry made by hand inside tx.
+func lockManualCashFlow(tx *gorm.DB, id uint) (*models.CashFlow, error) {
+	var cf models.CashFlow
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&cf, id).Error; err != nil {
+		return nil, err
+	}
+	if cf.JournalEntryID == nil {
+		return &cf, nil
+	}
+	var entry models.JournalEntry
+	if err := tx.Select("id", "source").First(&entry, *cf.JournalEntryID).Error; err != nil {
+		return nil, err
+	}
+	if entry.Source != models.JournalCashFlow {
+		return nil, fmt.Errorf("%w: cash flow entry %d was posted by a %s and can only change through it",
+			customErrors.ErrConflict, id, entry.Source)
+	}
+	return &cf, nil
+}
+
+// reverseCashFlow posts the contra entry of the cash book entry's journal entry.
+func reverseCashFlow(tx *gorm.DB, cf *models.CashFlow, description string) error {
+	if cf.JournalEntryID == nil {
+		return nil
+	}
+	return ReverseJournal(tx, *cf.JournalEntryID, &models.JournalEntry{
+		Date:        time.Now(),
+		Description: fmt.Sprintf("%s %d: %s", description, cf.ID, cf.Notes),
+		UserID:      cf.UserID,
+	})
+}
+
+// manualCashFlowEntry builds the journal entry of a cash book entry made by
+// hand, booked between cash and the account of the entry's category.
+func manualCashFlowEntry(tx *gorm.DB, cf *models.CashFlow) (*models.JournalEntry, error) {
+	var category models.CashFlowCategory
+	if err := tx.Where("code = ?", cf.Source).First(&category).Error; err != nil {
+		return nil, fmt.Errorf("cash flow category %q: %w", cf.Source, err)
+	}
+	cash, err := SystemAccount(tx, models.AccountCodeCash)
+	if err != nil {
+		return nil, err
+	}
+	counter, err := SystemAccount(tx, category.AccountCode)
+	if err != nil {
+		return nil, err
+	}
+	return CashFlowEntry(cf, cash, counter, models.JournalCashFlow), nil
 }
 
 func (r *cashFlowRepository) GetByID(ctx context.Context, id uint) (*models.CashFlow, error) {
 	var cf models.CashFlow
-	err := r.DB.WithContext(ctx).Preload("User").First(&cf, id).Error
+	err := r.DB.WithContext(ctx).Preload("User").Preload("Category").Preload("JournalEntry.Lines.Account").First(&cf, id).Error
 	return &cf, err
 }
 
@@ -75,35 +215,40 @@ func (r *cashFlowRepository) GetAll(ctx context.Context, limit, offset int, cfTy
 
 	err := query.Order("created_at DESC").
 		Limit(limit).Offset(offset).
-		Preload("User").
+		Preload("User").Preload("Category").
 		Find(&flows).Error
 
 	return flows, total, err
 }
 
 func (r *cashFlowRepository) GetSummary(ctx context.Context, startDate, endDate time.Time) (totalCapital, totalIncome, totalExpense float64, err error) {
-	// Get total modal (capital) — cumulative, NOT filtered by date range
+	// Get total modal (capital) — cumulative, NOT filtered by date range; drawings reduce it
 	err = r.DB.WithContext(ctx).Model(&models.CashFlow{}).
-		Where("type = ? AND source IN ?", "income", []string{"modal_awal", "modal_tambahan"}).
-		Select("COALESCE(SUM(amount), 0)").
+		Joins(joinCashFlowCategory).
+		Where("cash_flow_categories.type = ?", models.CashFlowCategoryCapital).
+		Select("COALESCE(SUM(CASE WHEN cash_flows.type = 'income' THEN cash_flows.amount ELSE -cash_flows.amount END), 0)").
 		Scan(&totalCapital).Error
 	if err != nil {
 		return 0, 0, 0, err
 	}
 
-	// Get total income
+	// Get total income, capital paid in left out
 	err = r.DB.WithContext(ctx).Model(&models.CashFlow{}).
-		Where("type = ? AND date >= ? AND date <= ?", "income", startDate, endDate).
-		Select("COALESCE(SUM(amount), 0)").
+		Joins(joinCashFlowCategory).
+		Where("cash_flows.type = ? AND cash_flow_categories.type <> ?", "income", models.CashFlowCategoryCapital).
+		Where("cash_flows.date >= ? AND cash_flows.date <= ?", startDate, endDate).
+		Select("COALESCE(SUM(cash_flows.amount), 0)").
 		Scan(&totalIncome).Error
 	if err != nil {
 		return 0, 0, 0, err
 	}
 
-	// Get total expense
+	// Get total expense, owner drawings left out
 	err = r.DB.WithContext(ctx).Model(&models.CashFlow{}).
-		Where("type = ? AND date >= ? AND date <= ?", "expense", startDate, endDate).
-		Select("COALESCE(SUM(amount), 0)").
+		Joins(joinCashFlowCategory).
+		Where("cash_flows.type = ? AND cash_flow_categories.type <> ?", "expense", models.CashFlowCategoryCapital).
+		Where("cash_flows.date >= ? AND cash_flows.date <= ?", startDate, endDate).
+		Select("COALESCE(SUM(cash_flows.amount), 0)").
 		Scan(&totalExpense).Error
 	if err != nil {
 		return 0, 0, 0, err
@@ -115,9 +260,10 @@ func (r *cashFlowRepository) GetSummary(ctx context.Context, startDate, endDate
 func (r *cashFlowRepository) GetSourceBreakdown(ctx context.Context, startDate, endDate time.Time) ([]CashFlowSourceData, error) {
 	var results []CashFlowSourceData
 	err := r.DB.WithContext(ctx).Model(&models.CashFlow{}).
-		Where("date >= ? AND date <= ?", startDate, endDate).
-		Select("source, type, SUM(amount) as total_amount").
-		Group("source, type").
+		Joins(joinCashFlowCategory).
+		Where("cash_flows.date >= ? AND cash_flows.date <= ?", startDate, endDate).
+		Select(cashFlowSourceColumns).
+		Group("cash_flows.source, cash_flows.type, cash_flow_categories.name, cash_flow_categories.type").
 		Scan(&results).Error
 	return results, err
 }
@@ -0,0 +1,105 @@
+package repositories
+
+import (
+	"math"
+	"pos-api/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CostingMethod returns the store's costing method, weighted average unless
+// FIFO was chosen in the store settings.
+func CostingMethod(tx *gorm.DB) (string, error) {
+	var setting models.StoreSetting
+	if err := tx.Select("id", "costing_method").Limit(1).Find(&setting).Error; err != nil {
+		return "", err
+	}
+	if setting.CostingMethod == models.CostingFIFO {
+		return models.CostingFIFO, nil
+	}
+	return models.CostingAverage, nil
+}
+
+// ReceiveCost records quantity units of a product coming into stock at
+// unitCost inside tx. It opens a cost layer for FIFO and folds the units into
+// the weighted moving average kept in products.cost. Call it after the stock
+// level was raised; it returns the new average cost. Units that cover stock
+// sold below zero were already costed, so the layer only keeps the rest.
+func ReceiveCost(tx *gorm.DB, productID uint, logID *uint, quantity, unitCost float64) (float64, error) {
+	var product models.Product
+	if err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id", "stock", "cost").First(&product, productID).Error; err != nil {
+		return 0, err
+	}
+
+	// products.stock already includes the units being received
+	before := roundLot(product.Stock - quantity)
+	average := unitCost
+	if before > 0 {
+		average = roundAmount((before*product.Cost + quantity*unitCost) / (before + quantity))
+	}
+	if err := tx.Unscoped().Model(&models.Product{}).Where("id = ?", productID).
+		UpdateColumn("cost", average).Error; err != nil {
+		return 0, err
+	}
+
+	layer := models.CostLayer{
+		ProductID:      productID,
+		InventoryLogID: logID,
+		UnitCost:       unitCost,
+		Quantity:       quantity,
+		Remaining:      math.Min(quantity, math.Max(0, roundLot(product.Stock))),
+	}
+	if err := tx.Omit("Product").Create(&layer).Error; err != nil {
+		return 0, err
+	}
+	return average, nil
+}
+
+// ConsumeCost takes quantity units of a product out of its cost layers, oldest
+// first, inside tx and returns the unit cost of the units under the store's
+// costing method: the weighted cost of the FIFO layers drained, or the current
+// moving average. Layers are drained under either method so switching methods
+// keeps the FIFO layers in step with stock. Units no layer accounts for are
+// costed at the average.
+func ConsumeCost(tx *gorm.DB, productID uint, quantity float64) (float64, error) {
+	method, err := CostingMethod(tx)
+	if err != nil {
+		return 0, err
+	}
+
+	var product models.Product
+	if err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id", "cost").First(&product, productID).Error; err != nil {
+		return 0, err
+	}
+
+	var layers []models.CostLayer
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("product_id = ? AND remaining > 0", productID).
+		Order("id ASC").
+		Find(&layers).Error; err != nil {
+		return 0, err
+	}
+
+	remaining := roundLot(quantity)
+	var total float64
+	for i := range layers {
+		if remaining <= 0 {
+			break
+		}
+		qty := math.Min(remaining, layers[i].Remaining)
+		if err := tx.Model(&layers[i]).Update("remaining", roundLot(layers[i].Remaining-qty)).Error; err != nil {
+			return 0, err
+		}
+		total += qty * layers[i].UnitCost
+		remaining = roundLot(remaining - qty)
+	}
+	total += remaining * product.Cost
+
+	if method == models.CostingFIFO && quantity > 0 {
+		return roundAmount(total / quantity), nil
+	}
+	return product.Cost, nil
+}
@@ -0,0 +1,137 @@
+package repositories
+
+import (
+	"context"
+	"pos-api/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type CurrencyRepository interface {
+	// Create saves the currency with its rate as the first of its history.
+	Create(ctx context.Context, currency *models.Currency, userID uint) error
+	// Update saves the currency's name, symbol and whether it is accepted;
+	// its rate changes through SetRates only.
+	Update(ctx context.Context, currency *models.Currency) error
+	GetByID(ctx context.Context, id uint) (*models.Currency, error)
+	GetByCode(ctx context.Context, code string) (*models.Currency, error)
+	GetAll(ctx context.Context, activeOnly bool) ([]models.Currency, error)
+	Count(ctx context.Context) (int64, error)
+	// SetRates sets each currency to its rate as of at, adding the rates to
+	// the history, all or none of them.
+	SetRates(ctx context.Context, rates []models.ExchangeRate, at time.Time) error
+	// GetRateHistory lists the last limit rates of the currency, newest first.
+	GetRateHistory(ctx context.Context, currencyID uint, limit int) ([]models.ExchangeRate, error)
+	// GetReceipts sums the payments of the completed sales made between from
+	// and to per currency.
+	GetReceipts(ctx context.Context, from, to time.Time) ([]CurrencyReceipt, error)
+}
+
+// CurrencyReceipt is what was taken in one currency.
+type CurrencyReceipt struct {
+	Currency     string  `json:"currency"`
+	Payments     int64   `json:"payments"`
+	Amount       float64 `json:"amount"`
+	BaseAmount   float64 `json:"base_amount"`
+	AverageRate  float64 `json:"average_rate"`
+	Transactions int64   `json:"transactions"`
+}
+
+type currencyRepository struct {
+	DB *gorm.DB
+}
+
+func NewCurrencyRepository(db *gorm.DB) CurrencyRepository {
+	return &currencyRepository{DB: db}
+}
+
+func (r *currencyRepository) Create(ctx context.Context, currency *models.Currency, userID uint) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(currency).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.ExchangeRate{
+			CurrencyID: currency.ID,
+			Rate:       currency.Rate,
+			Source:     models.ExchangeRateManual,
+			UserID:     userID,
+		}).Error
+	})
+}
+
+func (r *currencyRepository) Update(ctx context.Context, currency *models.Currency) error {
+	return r.DB.WithContext(ctx).Model(currency).
+		Select("Name", "Symbol", "IsActive").
+		Updates(currency).Error
+}
+
+func (r *currencyRepository) GetByID(ctx context.Context, id uint) (*models.Currency, error) {
+	var currency models.Currency
+	err := r.DB.WithContext(ctx).First(&currency, id).Error
+	return &currency, err
+}
+
+func (r *currencyRepository) GetByCode(ctx context.Context, code string) (*models.Currency, error) {
+	var currency models.Currency
+	err := r.DB.WithContext(ctx).Where("code = ?", code).First(&currency).Error
+	return &currency, err
+}
+
+func (r *currencyRepository) GetAll(ctx context.Context, activeOnly bool) ([]models.Currency, error) {
+	var currencies []models.Currency
+	query := r.DB.WithContext(ctx).Order("code ASC")
+	if activeOnly {
+		query = query.Where("is_active = ?", true)
+	}
+	err := query.Find(&currencies).Error
+	return currencies, err
+}
+
+func (r *currencyRepository) Count(ctx context.Context) (int64, error) {
+	var count int64
+	err := r.DB.WithContext(ctx).Model(&models.Currency{}).Count(&count).Error
+	return count, err
+}
+
+func (r *currencyRepository) SetRates(ctx context.Context, rates []models.ExchangeRate, at time.Time) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i := range rates {
+			result := tx.Model(&models.Currency{}).Where("id = ?", rates[i].CurrencyID).
+				Updates(map[string]interface{}{"rate": rates[i].Rate, "rate_updated_at": at})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return gorm.ErrRecordNotFound
+			}
+			rates[i].CreatedAt = at
+		}
+		return tx.Omit("Currency").Create(&rates).Error
+	})
+}
+
+func (r *currencyRepository) GetRateHistory(ctx context.Context, currencyID uint, limit int) ([]models.ExchangeRate, error) {
+	var rates []models.ExchangeRate
+	err := r.DB.WithContext(ctx).Where("currency_id = ?", currencyID).
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Find(&rates).Error
+	return rates, err
+}
+
+func (r *currencyRepository) GetReceipts(ctx context.Context, from, to time.Time) ([]CurrencyReceipt, error) {
+	var receipts []CurrencyReceipt
+	err := r.DB.WithContext(ctx).Table("transaction_payments").
+		Joins("JOIN transactions ON transactions.id = transaction_payments.transaction_id").
+		Where("transactions.status = ? AND transactions.deleted_at IS NULL", "completed").
+		Where("transactions.created_at >= ? AND transactions.created_at <= ?", from, to).
+		Select(`transaction_payments.currency, COUNT(*) AS payments,
+			COALESCE(SUM(transaction_payments.amount), 0) AS amount,
+			COALESCE(SUM(transaction_payments.base_amount), 0) AS base_amount,
+			COUNT(DISTINCT transaction_payments.transaction_id) AS transactions`).
+		Group("transaction_payments.currency").
+		Order("transaction_payments.currency ASC").
+		Scan(&receipts).Error
+	return receipts, err
+}
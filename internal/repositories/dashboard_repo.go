@@ -12,7 +12,7 @@ import (
 type DashboardStats struct {
 	TodaySales        float64 `json:"today_sales"`
 	TodayTransactions int64   `json:"today_transactions"`
-	TodayItemsSold    int64   `json:"today_items_sold"`
+	TodayItemsSold    float64 `json:"today_items_sold"`
 	TodayProfit       float64 `json:"today_profit"`
 	LowStockCount     int64   `json:"low_stock_count"`
 }
@@ -21,16 +21,17 @@ type DashboardStats struct {
 type TopProduct struct {
 	ProductID   uint    `json:"product_id"`
 	ProductName string  `json:"product_name"`
-	Quantity    int64   `json:"quantity"`
+	Quantity    float64 `json:"quantity"`
 	Revenue     float64 `json:"revenue"`
 }
 
 // LowStockProduct represents a product with low stock for the stock alert table
 type LowStockProduct struct {
-	ID    uint   `json:"id"`
-	Name  string `json:"name"`
-	SKU   string `json:"sku"`
-	Stock int    `json:"stock"`
+	ID       uint    `json:"id"`
+	Name     string  `json:"name"`
+	SKU      string  `json:"sku"`
+	Stock    float64 `json:"stock"`
+	MinStock float64 `json:"min_stock"`
 }
 
 // PaymentMethodData represents payment method breakdown for charts
@@ -44,8 +45,8 @@ type PaymentMethodData struct {
 type DashboardRepository interface {
 	GetDashboardStats(ctx context.Context, startDate, endDate time.Time) (*DashboardStats, error)
 	GetTopProducts(ctx context.Context, startDate, endDate time.Time, limit int) ([]TopProduct, error)
-	GetLowStockCount(ctx context.Context, threshold int) (int64, error)
-	GetLowStockProducts(ctx context.Context, threshold int, limit int) ([]LowStockProduct, error)
+	GetLowStockCount(ctx context.Context) (int64, error)
+	GetLowStockProducts(ctx context.Context, limit int) ([]LowStockProduct, error)
 	GetRevenueTrend(ctx context.Context, startDate, endDate time.Time) ([]RevenueData, error)
 	GetHourlyRevenueTrend(ctx context.Context, startDate, endDate time.Time) ([]RevenueData, error)
 	GetRecentTransactions(ctx context.Context, limit int) ([]TransactionSummary, error)
@@ -90,7 +91,7 @@ func (r *dashboardRepository) GetDashboardStats(ctx context.Context, startDate,
 	}
 
 	// Get items sold count
-	var itemsSold int64
+	var itemsSold float64
 	err = r.db.WithContext(ctx).Table("transaction_details").
 		Joins("JOIN transactions ON transactions.id = transaction_details.transaction_id").
 		Where("transactions.created_at >= ? AND transactions.created_at < ? AND transactions.status = 'completed'", startDate, endDate).
@@ -123,21 +124,21 @@ func (r *dashboardRepository) GetTopProducts(ctx context.Context, startDate, end
 	return topProducts, nil
 }
 
-// GetLowStockCount returns the count of products below the threshold
-func (r *dashboardRepository) GetLowStockCount(ctx context.Context, threshold int) (int64, error) {
+// GetLowStockCount returns the count of products below their minimum stock
+func (r *dashboardRepository) GetLowStockCount(ctx context.Context) (int64, error) {
 	var count int64
 	err := r.db.WithContext(ctx).Model(&models.Product{}).
-		Where("stock < ?", threshold).
+		Where("stock < min_stock").
 		Count(&count).Error
 	return count, err
 }
 
-// GetLowStockProducts returns products below the stock threshold
-func (r *dashboardRepository) GetLowStockProducts(ctx context.Context, threshold int, limit int) ([]LowStockProduct, error) {
+// GetLowStockProducts returns products below their minimum stock
+func (r *dashboardRepository) GetLowStockProducts(ctx context.Context, limit int) ([]LowStockProduct, error) {
 	var products []LowStockProduct
 	err := r.db.WithContext(ctx).Model(&models.Product{}).
-		Select("id, name, sku, stock").
-		Where("stock < ?", threshold).
+		Select("id, name, sku, stock, min_stock").
+		Where("stock < min_stock").
 		Order("stock ASC").
 		Limit(limit).
 		Scan(&products).Error
@@ -0,0 +1,99 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"pos-api/internal/models"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// InventoryIntegrityRepository reads the inventory log chain of each product and posts
+// the corrections found by the inventory integrity check.
+type InventoryIntegrityRepository interface {
+	// GetProducts returns the products to check, including deleted ones since
+	// their logs and stock remain; productID 0 returns every product.
+	GetProducts(ctx context.Context, productID uint) ([]models.Product, error)
+	// GetLocationLevels returns the product's stock level at each location.
+	GetLocationLevels(ctx context.Context, productID uint) ([]models.ProductStock, error)
+	// GetEntries returns the product's inventory logs in the order they were written.
+	GetEntries(ctx context.Context, productID uint) ([]models.InventoryLog, error)
+	// PostCorrection writes an audit entry that books a stock change the logs
+	// never recorded. The stock itself is left alone; the entry is refused with
+	// a conflict when the location's level is no longer log.StockAfter.
+	PostCorrection(ctx context.Context, log *models.InventoryLog) error
+	// SyncProductStock sets products.stock to the total over all locations
+	// and returns the total.
+	SyncProductStock(ctx context.Context, productID uint) (float64, error)
+}
+
+type inventoryIntegrityRepository struct {
+	DB *gorm.DB
+}
+
+func NewInventoryIntegrityRepository(db *gorm.DB) InventoryIntegrityRepository {
+	return &inventoryIntegrityRepository{DB: db}
+}
+
+func (r *inventoryIntegrityRepository) GetProducts(ctx context.Context, productID uint) ([]models.Product, error) {
+	var products []models.Product
+	query := r.DB.WithContext(ctx).Unscoped().Select("id", "name", "sku", "stock")
+	if productID != 0 {
+		query = query.Where("id = ?", productID)
+	}
+	err := query.Order("id ASC").Find(&products).Error
+	return products, err
+}
+
+func (r *inventoryIntegrityRepository) GetLocationLevels(ctx context.Context, productID uint) ([]models.ProductStock, error) {
+	var levels []models.ProductStock
+	err := r.DB.WithContext(ctx).Where("product_id = ?", productID).
+		Order("location_id ASC").Find(&levels).Error
+	return levels, err
+}
+
+func (r *inventoryIntegrityRepository) GetEntries(ctx context.Context, productID uint) ([]models.InventoryLog, error) {
+	var logs []models.InventoryLog
+	// Deleted logs still moved stock, so they stay in the chain
+	err := r.DB.WithContext(ctx).Unscoped().
+		Select("id", "product_id", "location_id", "type", "source", "quantity", "stock_before", "stock_after", "created_at").
+		Where("product_id = ?", productID).
+		Order("id ASC").Find(&logs).Error
+	return logs, err
+}
+
+func (r *inventoryIntegrityRepository) PostCorrection(ctx context.Context, log *models.InventoryLog) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		level, err := lockLocationStock(tx, log.ProductID, log.LocationID)
+		if err != nil {
+			return err
+		}
+		if roundLot(level.Quantity) != roundLot(log.StockAfter) {
+			return fmt.Errorf("%w: stock of product %d at location %d moved since the check (now %g, checked %g)",
+				customErrors.ErrConflict, log.ProductID, log.LocationID, level.Quantity, log.StockAfter)
+		}
+		return tx.Omit("Lots").Create(log).Error
+	})
+}
+
+func (r *inventoryIntegrityRepository) SyncProductStock(ctx context.Context, productID uint) (float64, error) {
+	var total float64
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var product models.Product
+		if err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id").First(&product, productID).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.ProductStock{}).Where("product_id = ?", productID).
+			Select("COALESCE(SUM(quantity), 0)").Scan(&total).Error; err != nil {
+			return err
+		}
+		total = roundLot(total)
+		return tx.Unscoped().Model(&models.Product{}).Where("id = ?", productID).
+			UpdateColumn("stock", total).Error
+	})
+	return total, err
+}
@@ -2,19 +2,46 @@ package repositories
 
 import (
 	"context"
+	"fmt"
+	"math"
 	"pos-api/internal/models"
 	"pos-api/internal/pkg/events"
 	"time"
 
+	customErrors "pos-api/internal/pkg/errors"
+
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type InventoryLogRepository interface {
 	Create(ctx context.Context, log *models.InventoryLog) error
-	ProcessAdjustment(ctx context.Context, log *models.InventoryLog, product *models.Product) error
+	// ProcessAdjustment applies the log's movement at its location, consumes
+	// lots first-expiry-first-out on decreases and receives into lot (when
+	// not nil) on increases, moves the log's serial numbers in or out of
+	// stock, values it with the costing method (updating product.Cost on
+	// increases), then saves the log and publishes it.
+	ProcessAdjustment(ctx context.Context, log *models.InventoryLog, product *models.Product, lot *models.ProductLot) error
+	// ProcessReceipt books the goods receipt lines of a purchase order like
+	// ProcessAdjustment, all in one DB transaction with the order locked and
+	// still open for receipt, so a receipt is booked in full or not at all.
+	ProcessReceipt(ctx context.Context, purchaseOrderID uint, adjustments []StockAdjustment) error
 	GetByProductID(ctx context.Context, productID uint, limit, offset int) ([]models.InventoryLog, int64, error)
 	GetAll(ctx context.Context, limit, offset int, logType, source string, startDate, endDate *time.Time) ([]models.InventoryLog, int64, error)
 	GetStats(ctx context.Context, startDate, endDate *time.Time) (map[string]int64, error)
+	// GetShortfalls lists sales that went below the stock on record; with
+	// onlyOpen, just those flagged for review and not reviewed yet.
+	GetShortfalls(ctx context.Context, limit, offset int, onlyOpen bool) ([]models.InventoryLog, int64, error)
+	// ReviewShortfall marks a flagged shortfall as reviewed by userID.
+	ReviewShortfall(ctx context.Context, id, userID uint, notes string) (*models.InventoryLog, error)
+}
+
+// StockAdjustment is one movement for ProcessReceipt, with the arguments of
+// ProcessAdjustment.
+type StockAdjustment struct {
+	Log     *models.InventoryLog
+	Product *models.Product
+	Lot     *models.ProductLot
 }
 
 type inventoryLogRepository struct {
@@ -33,29 +60,127 @@ func (r *inventoryLogRepository) Create(ctx context.Context, log *models.Invento
 	return r.DB.WithContext(ctx).Create(log).Error
 }
 
-func (r *inventoryLogRepository) ProcessAdjustment(ctx context.Context, log *models.InventoryLog, product *models.Product) error {
-	tx := r.DB.WithContext(ctx).Begin()
-	if tx.Error != nil {
-		return tx.Error
+func (r *inventoryLogRepository) ProcessAdjustment(ctx context.Context, log *models.InventoryLog, product *models.Product, lot *models.ProductLot) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return r.applyAdjustment(ctx, tx, StockAdjustment{Log: log, Product: product, Lot: lot})
+	})
+}
+
+func (r *inventoryLogRepository) ProcessReceipt(ctx context.Context, purchaseOrderID uint, adjustments []StockAdjustment) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Lock the order so a cancellation or another receipt waits for this one
+		var po models.PurchaseOrder
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&po, purchaseOrderID).Error; err != nil {
+			return err
+		}
+		if po.Status != models.PurchaseOrderSent && po.Status != models.PurchaseOrderPartiallyReceived {
+			return fmt.Errorf("%w: purchase order is '%s', only sent orders can be received", customErrors.ErrConflict, po.Status)
+		}
+		// Lock every line's stock up front, in LockStock's order, so receipts
+		// listing the same products differently cannot deadlock
+		keys := make([]StockKey, 0, len(adjustments))
+		for _, adjustment := range adjustments {
+			keys = append(keys, StockKey{ProductID: adjustment.Log.ProductID, LocationID: adjustment.Log.LocationID})
+		}
+		if err := LockStock(tx, keys...); err != nil {
+			return err
+		}
+		for _, adjustment := range adjustments {
+			if err := r.applyAdjustment(ctx, tx, adjustment); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// applyAdjustment does the work of ProcessAdjustment within tx.
+func (r *inventoryLogRepository) applyAdjustment(ctx context.Context, tx *gorm.DB, adjustment StockAdjustment) error {
+	log, product, lot := adjustment.Log, adjustment.Product, adjustment.Lot
+
+	// Apply the movement at the log's location; the levels read under the row
+	// lock replace the ones the service computed beforehand
+	level, err := lockLocationStock(tx, log.ProductID, log.LocationID)
+	if err != nil {
+		return err
 	}
-	defer func() {
-		if rec := recover(); rec != nil {
-			tx.Rollback()
+	delta := log.Quantity
+	switch log.Type {
+	case "out":
+		delta = -log.Quantity
+	case "adjustment":
+		// An adjustment sets the level counted; sales since the service read
+		// the stock must not be undone, so the change is taken from the
+		// locked level
+		delta = roundLot(log.StockAfter - level.Quantity)
+		if len(log.SerialNumbers) > 0 && math.Abs(delta) != float64(len(log.SerialNumbers)) {
+			return fmt.Errorf("%w: stock changed while adjusting, %g serial numbers are needed now", customErrors.ErrConflict, math.Abs(delta))
 		}
-	}()
+		log.Quantity = delta
+		log.TotalCost = roundAmount(math.Abs(delta) * log.CostPrice)
+	}
+	stockBefore, stockAfter, err := ApplyLocationStock(tx, log.ProductID, log.LocationID, delta)
+	if err != nil {
+		return err
+	}
+	log.StockBefore, log.StockAfter = stockBefore, stockAfter
+	if err := tx.Unscoped().Model(&models.Product{}).Select("stock").
+		Where("id = ?", log.ProductID).Scan(&product.Stock).Error; err != nil {
+		return err
+	}
 
-	// Update product stock carefully to avoid cascading save deadlocks on associations
-	if err := tx.Model(product).Select("Stock", "Cost").Updates(product).Error; err != nil {
-		tx.Rollback()
+	var lots []models.InventoryLogLot
+	switch {
+	case delta < 0:
+		lots, err = ConsumeLots(tx, log.ProductID, log.LocationID, -delta, stockBefore)
+	case delta > 0 && lot != nil:
+		var received models.InventoryLogLot
+		received, err = ReceiveLot(tx, lot, delta)
+		lots = []models.InventoryLogLot{received}
+	}
+	if err != nil {
 		return err
 	}
 
+	// Stock leaving is valued by the costing method rather than the cost the
+	// service guessed from the product
+	if delta < 0 {
+		cost, err := ConsumeCost(tx, log.ProductID, -delta)
+		if err != nil {
+			return err
+		}
+		log.CostPrice = cost
+		log.TotalCost = roundAmount(cost * -delta)
+	}
+
 	// Create inventory log
-	if err := tx.Create(log).Error; err != nil {
-		tx.Rollback()
+	if err := tx.Omit("Lots").Create(log).Error; err != nil {
+		return err
+	}
+	if err := LinkLots(tx, log, lots); err != nil {
 		return err
 	}
 
+	// Stock coming in opens a cost layer and moves the average cost
+	if delta > 0 {
+		average, err := ReceiveCost(tx, log.ProductID, &log.ID, delta, log.CostPrice)
+		if err != nil {
+			return err
+		}
+		product.Cost = average
+	}
+
+	if len(log.SerialNumbers) > 0 {
+		if delta > 0 {
+			err = ReceiveSerials(tx, log)
+		} else {
+			err = TakeSerials(tx, log.ProductID, log.LocationID, log.SerialNumbers, models.SerialRemoved, nil)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
 	// Publish Event
 	payload := events.InventoryAdjustedPayload{
 		TX:           tx,
@@ -63,12 +188,7 @@ func (r *inventoryLogRepository) ProcessAdjustment(ctx context.Context, log *mod
 		UserID:       log.UserID,
 	}
 
-	if err := r.EventBus.Publish(ctx, events.EventInventoryAdjusted, payload); err != nil {
-		tx.Rollback()
-		return err
-	}
-
-	return tx.Commit().Error
+	return r.EventBus.Publish(ctx, events.EventInventoryAdjusted, payload)
 }
 
 func (r *inventoryLogRepository) GetByProductID(ctx context.Context, productID uint, limit, offset int) ([]models.InventoryLog, int64, error) {
@@ -83,7 +203,7 @@ func (r *inventoryLogRepository) GetByProductID(ctx context.Context, productID u
 
 	err := query.Order("created_at DESC").
 		Limit(limit).Offset(offset).
-		Preload("Product").Preload("User").
+		Preload("Product").Preload("User").Preload("Lots.ProductLot").
 		Find(&logs).Error
 
 	return logs, total, err
@@ -114,7 +234,7 @@ func (r *inventoryLogRepository) GetAll(ctx context.Context, limit, offset int,
 
 	err := query.Order("created_at DESC").
 		Limit(limit).Offset(offset).
-		Preload("Product").Preload("User").
+		Preload("Product").Preload("User").Preload("Lots.ProductLot").
 		Find(&logs).Error
 
 	return logs, total, err
@@ -155,3 +275,47 @@ func (r *inventoryLogRepository) GetStats(ctx context.Context, startDate, endDat
 
 	return stats, nil
 }
+
+func (r *inventoryLogRepository) GetShortfalls(ctx context.Context, limit, offset int, onlyOpen bool) ([]models.InventoryLog, int64, error) {
+	var logs []models.InventoryLog
+	var total int64
+
+	query := r.DB.WithContext(ctx).Model(&models.InventoryLog{}).Where("shortfall > 0")
+	if onlyOpen {
+		query = query.Where("needs_review = ?", true)
+	}
+
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("created_at DESC").
+		Limit(limit).Offset(offset).
+		Preload("Product").Preload("Location").Preload("User").
+		Find(&logs).Error
+
+	return logs, total, err
+}
+
+func (r *inventoryLogRepository) ReviewShortfall(ctx context.Context, id, userID uint, notes string) (*models.InventoryLog, error) {
+	var log models.InventoryLog
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&log, id).Error; err != nil {
+			return err
+		}
+		if !log.NeedsReview {
+			return fmt.Errorf("%w: inventory log %d is not waiting for review", customErrors.ErrConflict, id)
+		}
+		now := time.Now()
+		return tx.Model(&log).Updates(map[string]interface{}{
+			"needs_review": false,
+			"reviewed_at":  now,
+			"reviewed_by":  userID,
+			"review_notes": notes,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &log, nil
+}
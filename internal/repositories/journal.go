@@ -0,0 +1,233 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"pos-api/internal/models"
+	"strings"
+	"time"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// systemAccounts is the chart of accounts the listeners post to. The
+// migration creates them; SystemAccount recreates any that is missing.
+var systemAccounts = map[string]models.Account{
+	models.AccountCodeCash:          {Code: models.AccountCodeCash, Name: "Kas", Type: models.AccountAsset},
+	models.AccountCodeBank:          {Code: models.AccountCodeBank, Name: "Bank", Type: models.AccountAsset},
+	models.AccountCodeInventory:     {Code: models.AccountCodeInventory, Name: "Persediaan Barang", Type: models.AccountAsset},
+	models.AccountCodePayables:      {Code: models.AccountCodePayables, Name: "Utang Usaha", Type: models.AccountLiability},
+	models.AccountCodeCapital:       {Code: models.AccountCodeCapital, Name: "Modal Pemilik", Type: models.AccountEquity},
+	models.AccountCodeOpening:       {Code: models.AccountCodeOpening, Name: "Ekuitas Saldo Awal", Type: models.AccountEquity},
+	models.AccountCodeSales:         {Code: models.AccountCodeSales, Name: "Penjualan", Type: models.AccountRevenue},
+	models.AccountCodeSalesDiscount: {Code: models.AccountCodeSalesDiscount, Name: "Diskon Penjualan", Type: models.AccountRevenue},
+	models.AccountCodeOtherIncome:   {Code: models.AccountCodeOtherIncome, Name: "Pendapatan Lain-lain", Type: models.AccountRevenue},
+	models.AccountCodeCOGS:          {Code: models.AccountCodeCOGS, Name: "Harga Pokok Penjualan", Type: models.AccountExpense},
+	models.AccountCodeShrinkage:     {Code: models.AccountCodeShrinkage, Name: "Selisih Persediaan", Type: models.AccountExpense},
+	models.AccountCodeOperating:     {Code: models.AccountCodeOperating, Name: "Beban Operasional", Type: models.AccountExpense},
+	models.AccountCodeMerchantFees:  {Code: models.AccountCodeMerchantFees, Name: "Biaya Merchant", Type: models.AccountExpense},
+}
+
+// SystemAccount returns the system account with code inside tx, creating it
+// when it is missing.
+func SystemAccount(tx *gorm.DB, code string) (*models.Account, error) {
+	account, err := findAccount(tx, "code = ?", code)
+	if err == nil || !errors.Is(err, gorm.ErrRecordNotFound) {
+		return account, err
+	}
+
+	def, ok := systemAccounts[code]
+	if !ok {
+		return nil, fmt.Errorf("account %s: %w", code, customErrors.ErrNotFound)
+	}
+	def.IsSystem, def.IsActive = true, true
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&def).Error; err != nil {
+		return nil, err
+	}
+	return findAccount(tx, "code = ?", code)
+}
+
+// PaymentAccount returns the account money paid with the payment method
+// named method lands in: cash for cash methods, otherwise the method's own
+// bank account, opened on first use. Methods that no longer exist fall back
+// to the general bank account.
+func PaymentAccount(tx *gorm.DB, method string) (*models.Account, error) {
+	var pm models.PaymentMethod
+	err := tx.Unscoped().Where("LOWER(name) = LOWER(?)", method).First(&pm).Error
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		if method == "" || isCashName(method) {
+			return SystemAccount(tx, models.AccountCodeCash)
+		}
+		return SystemAccount(tx, models.AccountCodeBank)
+	case err != nil:
+		return nil, err
+	case pm.IsCash:
+		return SystemAccount(tx, models.AccountCodeCash)
+	}
+
+	account, err := findAccount(tx, "payment_method_id = ?", pm.ID)
+	if err == nil || !errors.Is(err, gorm.ErrRecordNotFound) {
+		return account, err
+	}
+	account = &models.Account{
+		Code:            fmt.Sprintf("%s.%d", models.AccountCodeBank, pm.ID),
+		Name:            "Bank - " + pm.Name,
+		Type:            models.AccountAsset,
+		PaymentMethodID: &pm.ID,
+		IsSystem:        true,
+		IsActive:        true,
+	}
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(account).Error; err != nil {
+		return nil, err
+	}
+	return findAccount(tx, "payment_method_id = ?", pm.ID)
+}
+
+func findAccount(tx *gorm.DB, query string, args ...interface{}) (*models.Account, error) {
+	var account models.Account
+	if err := tx.Where(query, args...).First(&account).Error; err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+func isCashName(method string) bool {
+	return strings.EqualFold(method, "cash") || strings.EqualFold(method, "tunai")
+}
+
+// Debit builds a line debiting account; a negative amount credits it.
+func Debit(account *models.Account, amount float64, memo string) models.JournalLine {
+	return models.JournalLine{AccountID: account.ID, Debit: amount, Memo: memo}
+}
+
+// Credit builds a line crediting account; a negative amount debits it.
+func Credit(account *models.Account, amount float64, memo string) models.JournalLine {
+	return models.JournalLine{AccountID: account.ID, Credit: amount, Memo: memo}
+}
+
+// PostJournal writes entry inside tx. Each line is rounded to cents and
+// netted to one side, lines that net to zero are dropped, and the entry is
+// refused unless its debits equal its credits. An entry with nothing left to
+// post is skipped and keeps ID 0.
+func PostJournal(tx *gorm.DB, entry *models.JournalEntry) error {
+	lines := make([]models.JournalLine, 0, len(entry.Lines))
+	var debits, credits float64
+	for _, line := range entry.Lines {
+		net := roundAmount(roundAmount(line.Debit) - roundAmount(line.Credit))
+		line.Debit, line.Credit = 0, 0
+		switch {
+		case net > 0:
+			line.Debit = net
+		case net < 0:
+			line.Credit = -net
+		default:
+			continue
+		}
+		debits += line.Debit
+		credits += line.Credit
+		lines = append(lines, line)
+	}
+	if len(lines) == 0 {
+		entry.Lines = nil
+		return nil
+	}
+	if roundAmount(debits) != roundAmount(credits) {
+		return fmt.Errorf("%w: debits %.2f, credits %.2f", customErrors.ErrUnbalancedJournal, debits, credits)
+	}
+
+	entry.Lines = lines
+	if entry.Date.IsZero() {
+		entry.Date = time.Now()
+	}
+	if err := EnsurePeriodOpen(tx, entry.Date); err != nil {
+		return err
+	}
+	return tx.Create(entry).Error
+}
+
+// ReverseJournal posts reversal as the contra entry of entry id: its lines
+// with debits and credits swapped, referencing the same documents. An entry
+// can only be reversed once.
+func ReverseJournal(tx *gorm.DB, id uint, reversal *models.JournalEntry) error {
+	if err := BuildReversal(tx, id, reversal); err != nil {
+		return err
+	}
+	return PostJournal(tx, reversal)
+}
+
+// BuildReversal fills reversal in as the contra entry of entry id, like
+// ReverseJournal, without posting it.
+func BuildReversal(tx *gorm.DB, id uint, reversal *models.JournalEntry) error {
+	var original models.JournalEntry
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&original, id).Error; err != nil {
+		return err
+	}
+	var reversed int64
+	if err := tx.Model(&models.JournalEntry{}).Where("reversal_of_id = ?", id).Count(&reversed).Error; err != nil {
+		return err
+	}
+	if reversed > 0 {
+		return fmt.Errorf("%w: journal entry %d is already reversed", customErrors.ErrConflict, id)
+	}
+	var lines []models.JournalLine
+	if err := tx.Where("journal_entry_id = ?", id).Order("id ASC").Find(&lines).Error; err != nil {
+		return err
+	}
+
+	reversal.ReversalOfID = &original.ID
+	if reversal.Source == "" {
+		reversal.Source = models.JournalReversal
+	}
+	if reversal.TransactionID == nil {
+		reversal.TransactionID = original.TransactionID
+	}
+	if reversal.PurchaseOrderID == nil {
+		reversal.PurchaseOrderID = original.PurchaseOrderID
+	}
+	if reversal.InventoryLogID == nil {
+		reversal.InventoryLogID = original.InventoryLogID
+	}
+	reversal.Lines = make([]models.JournalLine, 0, len(lines))
+	for _, line := range lines {
+		reversal.Lines = append(reversal.Lines, models.JournalLine{
+			AccountID: line.AccountID,
+			Debit:     line.Credit,
+			Credit:    line.Debit,
+			Memo:      line.Memo,
+		})
+	}
+	return nil
+}
+
+// CashFlowEntry builds the journal entry behind a cash book entry: cash in
+// against counter for income, counter against cash out for an expense.
+func CashFlowEntry(cf *models.CashFlow, cash, counter *models.Account, source string) *models.JournalEntry {
+	entry := &models.JournalEntry{
+		Date:            cf.Date,
+		Source:          source,
+		Description:     cf.Notes,
+		PurchaseOrderID: cf.PurchaseOrderID,
+		UserID:          cf.UserID,
+	}
+	if cf.Type == "income" {
+		entry.Lines = []models.JournalLine{Debit(cash, cf.Amount, ""), Credit(counter, cf.Amount, "")}
+	} else {
+		entry.Lines = []models.JournalLine{Debit(counter, cf.Amount, ""), Credit(cash, cf.Amount, "")}
+	}
+	return entry
+}
+
+// RecordCashFlow posts entry inside tx and writes cf as its cash side.
+func RecordCashFlow(tx *gorm.DB, cf *models.CashFlow, entry *models.JournalEntry) error {
+	if err := PostJournal(tx, entry); err != nil {
+		return err
+	}
+	if entry.ID != 0 {
+		cf.JournalEntryID = &entry.ID
+	}
+	return tx.Omit("JournalEntry").Create(cf).Error
+}
@@ -0,0 +1,182 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"pos-api/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type JournalExportRepository interface {
+	CreateTemplate(ctx context.Context, template *models.JournalExportTemplate) error
+	UpdateTemplate(ctx context.Context, template *models.JournalExportTemplate) error
+	DeleteTemplate(ctx context.Context, id uint) error
+	GetTemplate(ctx context.Context, id uint) (*models.JournalExportTemplate, error)
+	GetTemplateByName(ctx context.Context, name string) (*models.JournalExportTemplate, error)
+	GetTemplates(ctx context.Context) ([]models.JournalExportTemplate, error)
+
+	// Create saves the export with the journal entries dated from start to
+	// end, both inclusive, posted from one of sources, or reversing such an
+	// entry (refunds of sales made before the ledger count as reversing a
+	// sale), that no other export holds yet, and fills in its counts and
+	// total. It reports false, saving nothing, when there are no such entries.
+	Create(ctx context.Context, export *models.JournalExport, sources []string, start, end time.Time) (bool, error)
+	GetExports(ctx context.Context, limit, offset int) ([]models.JournalExport, int64, error)
+	GetExport(ctx context.Context, id uint) (*models.JournalExport, error)
+	// GetRows returns the lines of the export's entries, by entry date and
+	// entry, debits before credits.
+	GetRows(ctx context.Context, exportID uint) ([]JournalExportRow, error)
+	// Delete removes the export, so that its entries are exported again by
+	// the next export of their period.
+	Delete(ctx context.Context, id uint) error
+}
+
+// JournalExportRow is a journal line with what the export writes of its
+// entry and account.
+type JournalExportRow struct {
+	EntryID     uint
+	Date        time.Time
+	Source      string
+	Description string
+	Reference   string // Transaction code or PO number of the document posted
+	AccountCode string
+	AccountName string
+	Debit       float64
+	Credit      float64
+	Memo        string
+}
+
+type journalExportRepository struct {
+	DB *gorm.DB
+}
+
+func NewJournalExportRepository(db *gorm.DB) JournalExportRepository {
+	return &journalExportRepository{DB: db}
+}
+
+func (r *journalExportRepository) CreateTemplate(ctx context.Context, template *models.JournalExportTemplate) error {
+	return r.DB.WithContext(ctx).Create(template).Error
+}
+
+func (r *journalExportRepository) UpdateTemplate(ctx context.Context, template *models.JournalExportTemplate) error {
+	return r.DB.WithContext(ctx).Save(template).Error
+}
+
+func (r *journalExportRepository) DeleteTemplate(ctx context.Context, id uint) error {
+	return r.DB.WithContext(ctx).Delete(&models.JournalExportTemplate{}, id).Error
+}
+
+func (r *journalExportRepository) GetTemplate(ctx context.Context, id uint) (*models.JournalExportTemplate, error) {
+	var template models.JournalExportTemplate
+	err := r.DB.WithContext(ctx).First(&template, id).Error
+	return &template, err
+}
+
+func (r *journalExportRepository) GetTemplateByName(ctx context.Context, name string) (*models.JournalExportTemplate, error) {
+	var template models.JournalExportTemplate
+	err := r.DB.WithContext(ctx).Where("LOWER(name) = LOWER(?)", name).First(&template).Error
+	return &template, err
+}
+
+func (r *journalExportRepository) GetTemplates(ctx context.Context) ([]models.JournalExportTemplate, error) {
+	var templates []models.JournalExportTemplate
+	err := r.DB.WithContext(ctx).Order("name ASC").Find(&templates).Error
+	return templates, err
+}
+
+func (r *journalExportRepository) Create(ctx context.Context, export *models.JournalExport, sources []string, start, end time.Time) (bool, error) {
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("User").Create(export).Error; err != nil {
+			return err
+		}
+
+		// Entries exported concurrently by another batch are skipped
+		reversed := tx.Model(&models.JournalEntry{}).Select("id").Where("source IN ?", sources)
+		exported := tx.Model(&models.JournalExportEntry{}).Select("journal_entry_id")
+		entries := tx.Model(&models.JournalEntry{}).
+			Select("id, ?", export.ID).
+			Where("date >= ? AND date < ?", start, end.AddDate(0, 0, 1)).
+			// Refunds of sales made before the ledger reverse no entry; they go with the sales
+			Where("source IN ? OR (source = ? AND reversal_of_id IN (?)) OR (source = ? AND reversal_of_id IS NULL AND transaction_id IS NOT NULL AND ? IN ?)",
+				sources, models.JournalReversal, reversed, models.JournalReversal, models.JournalSale, sources).
+			Where("id NOT IN (?)", exported)
+		result := tx.Exec("INSERT INTO journal_export_entries (journal_entry_id, journal_export_id) ? ON CONFLICT DO NOTHING", entries)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errNothingToExport
+		}
+
+		var totals struct {
+			LineCount  int
+			TotalDebit float64
+		}
+		if err := tx.Table("journal_lines").
+			Joins("JOIN journal_export_entries ON journal_export_entries.journal_entry_id = journal_lines.journal_entry_id").
+			Where("journal_export_entries.journal_export_id = ?", export.ID).
+			Select("COUNT(*) AS line_count, COALESCE(SUM(journal_lines.debit), 0) AS total_debit").
+			Scan(&totals).Error; err != nil {
+			return err
+		}
+		export.EntryCount = int(result.RowsAffected)
+		export.LineCount = totals.LineCount
+		export.TotalDebit = totals.TotalDebit
+		return tx.Model(export).Select("EntryCount", "LineCount", "TotalDebit").Updates(export).Error
+	})
+	if errors.Is(err, errNothingToExport) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// errNothingToExport rolls back an export without entries.
+var errNothingToExport = errors.New("no journal entries to export")
+
+func (r *journalExportRepository) GetExports(ctx context.Context, limit, offset int) ([]models.JournalExport, int64, error) {
+	var exports []models.JournalExport
+	var total int64
+
+	query := r.DB.WithContext(ctx).Model(&models.JournalExport{})
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("id DESC").Limit(limit).Offset(offset).Preload("User").Find(&exports).Error
+	return exports, total, err
+}
+
+func (r *journalExportRepository) GetExport(ctx context.Context, id uint) (*models.JournalExport, error) {
+	var export models.JournalExport
+	err := r.DB.WithContext(ctx).Preload("User").First(&export, id).Error
+	return &export, err
+}
+
+func (r *journalExportRepository) GetRows(ctx context.Context, exportID uint) ([]JournalExportRow, error) {
+	var rows []JournalExportRow
+	err := r.DB.WithContext(ctx).Table("journal_lines").
+		Joins("JOIN journal_export_entries ON journal_export_entries.journal_entry_id = journal_lines.journal_entry_id").
+		Joins("JOIN journal_entries ON journal_entries.id = journal_lines.journal_entry_id").
+		Joins("JOIN accounts ON accounts.id = journal_lines.account_id").
+		Joins("LEFT JOIN transactions ON transactions.id = journal_entries.transaction_id").
+		Joins("LEFT JOIN purchase_orders ON purchase_orders.id = journal_entries.purchase_order_id").
+		Where("journal_export_entries.journal_export_id = ?", exportID).
+		Select(`journal_entries.id AS entry_id, journal_entries.date, journal_entries.source, journal_entries.description,
+			COALESCE(transactions.transaction_code, purchase_orders.po_number, '') AS reference,
+			accounts.code AS account_code, accounts.name AS account_name,
+			journal_lines.debit, journal_lines.credit, COALESCE(journal_lines.memo, '') AS memo`).
+		Order("journal_entries.date ASC, journal_entries.id ASC, journal_lines.credit ASC, journal_lines.id ASC").
+		Scan(&rows).Error
+	return rows, err
+}
+
+func (r *journalExportRepository) Delete(ctx context.Context, id uint) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("journal_export_id = ?", id).Delete(&models.JournalExportEntry{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.JournalExport{}, id).Error
+	})
+}
@@ -0,0 +1,150 @@
+package repositories
+
+import (
+	"context"
+	"pos-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type LocationRepository interface {
+	Create(ctx context.Context, location *models.Location) error
+	Update(ctx context.Context, location *models.Location) error
+	Delete(ctx context.Context, id uint) error
+	GetByID(ctx context.Context, id uint) (*models.Location, error)
+	GetAll(ctx context.Context, onlyActive bool) ([]models.Location, error)
+	GetDefault(ctx context.Context) (*models.Location, error)
+	// SetDefault makes id the only default location.
+	SetDefault(ctx context.Context, id uint) error
+	// HasStock reports whether any product has stock left at the location.
+	HasStock(ctx context.Context, id uint) (bool, error)
+
+	// GetStockLevel returns a product's stock at a location, 0 when it has never been stocked there.
+	GetStockLevel(ctx context.Context, productID, locationID uint) (float64, error)
+	GetStockLevels(ctx context.Context, limit, offset int, locationID, productID uint) ([]models.ProductStock, int64, error)
+
+	CreateRegister(ctx context.Context, register *models.Register) error
+	UpdateRegister(ctx context.Context, register *models.Register) error
+	DeleteRegister(ctx context.Context, id uint) error
+	GetRegisterByID(ctx context.Context, id uint) (*models.Register, error)
+	GetRegisters(ctx context.Context, locationID uint) ([]models.Register, error)
+}
+
+type locationRepository struct {
+	DB *gorm.DB
+}
+
+func NewLocationRepository(db *gorm.DB) LocationRepository {
+	return &locationRepository{DB: db}
+}
+
+func (r *locationRepository) Create(ctx context.Context, location *models.Location) error {
+	return r.DB.WithContext(ctx).Create(location).Error
+}
+
+func (r *locationRepository) Update(ctx context.Context, location *models.Location) error {
+	return r.DB.WithContext(ctx).Save(location).Error
+}
+
+func (r *locationRepository) Delete(ctx context.Context, id uint) error {
+	return r.DB.WithContext(ctx).Delete(&models.Location{}, id).Error
+}
+
+func (r *locationRepository) GetByID(ctx context.Context, id uint) (*models.Location, error) {
+	var location models.Location
+	err := r.DB.WithContext(ctx).First(&location, id).Error
+	return &location, err
+}
+
+func (r *locationRepository) GetAll(ctx context.Context, onlyActive bool) ([]models.Location, error) {
+	var locations []models.Location
+	query := r.DB.WithContext(ctx)
+	if onlyActive {
+		query = query.Where("is_active = ?", true)
+	}
+	err := query.Order("is_default DESC, name ASC").Find(&locations).Error
+	return locations, err
+}
+
+func (r *locationRepository) GetDefault(ctx context.Context) (*models.Location, error) {
+	var location models.Location
+	err := r.DB.WithContext(ctx).Where("is_default = ?", true).First(&location).Error
+	return &location, err
+}
+
+func (r *locationRepository) SetDefault(ctx context.Context, id uint) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Location{}).Where("is_default = ? AND id != ?", true, id).Update("is_default", false).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.Location{}).Where("id = ?", id).Update("is_default", true).Error
+	})
+}
+
+func (r *locationRepository) HasStock(ctx context.Context, id uint) (bool, error) {
+	var count int64
+	err := r.DB.WithContext(ctx).Model(&models.ProductStock{}).
+		Where("location_id = ? AND quantity != 0", id).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *locationRepository) GetStockLevel(ctx context.Context, productID, locationID uint) (float64, error) {
+	var level models.ProductStock
+	err := r.DB.WithContext(ctx).
+		Where("product_id = ? AND location_id = ?", productID, locationID).
+		Limit(1).Find(&level).Error
+	return level.Quantity, err
+}
+
+func (r *locationRepository) GetStockLevels(ctx context.Context, limit, offset int, locationID, productID uint) ([]models.ProductStock, int64, error) {
+	var levels []models.ProductStock
+	var total int64
+
+	query := r.DB.WithContext(ctx).Model(&models.ProductStock{})
+	if locationID != 0 {
+		query = query.Where("location_id = ?", locationID)
+	}
+	if productID != 0 {
+		query = query.Where("product_id = ?", productID)
+	}
+
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("product_id ASC, location_id ASC").
+		Limit(limit).Offset(offset).
+		Preload("Product").
+		Preload("Location").
+		Find(&levels).Error
+	return levels, total, err
+}
+
+func (r *locationRepository) CreateRegister(ctx context.Context, register *models.Register) error {
+	return r.DB.WithContext(ctx).Omit("Location").Create(register).Error
+}
+
+func (r *locationRepository) UpdateRegister(ctx context.Context, register *models.Register) error {
+	return r.DB.WithContext(ctx).Omit("Location").Save(register).Error
+}
+
+func (r *locationRepository) DeleteRegister(ctx context.Context, id uint) error {
+	return r.DB.WithContext(ctx).Delete(&models.Register{}, id).Error
+}
+
+func (r *locationRepository) GetRegisterByID(ctx context.Context, id uint) (*models.Register, error) {
+	var register models.Register
+	err := r.DB.WithContext(ctx).Preload("Location").First(&register, id).Error
+	return &register, err
+}
+
+func (r *locationRepository) GetRegisters(ctx context.Context, locationID uint) ([]models.Register, error) {
+	var registers []models.Register
+	query := r.DB.WithContext(ctx)
+	if locationID != 0 {
+		query = query.Where("location_id = ?", locationID)
+	}
+	err := query.Order("code ASC").Preload("Location").Find(&registers).Error
+	return registers, err
+}
@@ -0,0 +1,142 @@
+package repositories
+
+import (
+	"fmt"
+	"math"
+	"pos-api/internal/models"
+	"sort"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DefaultLocationID returns the location used when none is given.
+func DefaultLocationID(tx *gorm.DB) (uint, error) {
+	var location models.Location
+	if err := tx.Select("id").Where("is_default = ?", true).First(&location).Error; err != nil {
+		return 0, fmt.Errorf("default location not found: %w", err)
+	}
+	return location.ID, nil
+}
+
+// ApplyLocationStock changes a product's stock at one location by delta inside
+// tx. It locks the stock, refuses to go below zero, keeps products.stock as the
+// total over all locations, and returns the location's stock level before and
+// after the change. Every stock movement must go through here (or
+// TakeSaleStock for sales) so per-location and total stock stay in step.
+func ApplyLocationStock(tx *gorm.DB, productID, locationID uint, delta float64) (before, after float64, err error) {
+	return applyLocationStock(tx, productID, locationID, delta, false)
+}
+
+// StockKey identifies a product's stock at one location.
+type StockKey struct {
+	ProductID  uint
+	LocationID uint
+}
+
+// LockStock locks the stock of every key inside tx: the products rows by
+// ascending ID first, then the product_stocks rows by product and location,
+// creating the ones that do not exist yet. Transactions that move several
+// products or locations lock them all up front, so concurrent transactions
+// always wait on rows in the same order and cannot deadlock. Taking a lock the
+// transaction already holds again is free, which lets the movements that
+// follow lock their own row as usual.
+func LockStock(tx *gorm.DB, keys ...StockKey) error {
+	keys = sortStockKeys(keys)
+
+	productIDs := make([]uint, 0, len(keys))
+	for _, k := range keys {
+		if len(productIDs) == 0 || productIDs[len(productIDs)-1] != k.ProductID {
+			productIDs = append(productIDs, k.ProductID)
+		}
+	}
+	if err := lockProducts(tx, productIDs...); err != nil {
+		return err
+	}
+
+	for _, k := range keys {
+		if _, err := lockStockRow(tx, k.ProductID, k.LocationID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sortStockKeys returns keys without duplicates, ordered by product then location.
+func sortStockKeys(keys []StockKey) []StockKey {
+	sorted := append([]StockKey(nil), keys...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].ProductID != sorted[j].ProductID {
+			return sorted[i].ProductID < sorted[j].ProductID
+		}
+		return sorted[i].LocationID < sorted[j].LocationID
+	})
+	unique := sorted[:0]
+	for i, k := range sorted {
+		if i == 0 || k != sorted[i-1] {
+			unique = append(unique, k)
+		}
+	}
+	return unique
+}
+
+// lockProducts locks the products rows, deleted ones included, by ascending ID.
+func lockProducts(tx *gorm.DB, productIDs ...uint) error {
+	var locked []uint
+	return tx.Unscoped().Model(&models.Product{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id IN ?", productIDs).
+		Order("id ASC").
+		Pluck("id", &locked).Error
+}
+
+// lockLocationStock locks a product's stock at a location inside tx, creating
+// its row first when the product never had stock there.
+func lockLocationStock(tx *gorm.DB, productID, locationID uint) (models.ProductStock, error) {
+	if err := lockProducts(tx, productID); err != nil {
+		return models.ProductStock{}, err
+	}
+	return lockStockRow(tx, productID, locationID)
+}
+
+func lockStockRow(tx *gorm.DB, productID, locationID uint) (models.ProductStock, error) {
+	// Make sure the row exists so it can be locked
+	if err := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "product_id"}, {Name: "location_id"}},
+		DoNothing: true,
+	}).Create(&models.ProductStock{ProductID: productID, LocationID: locationID}).Error; err != nil {
+		return models.ProductStock{}, err
+	}
+
+	var level models.ProductStock
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("product_id = ? AND location_id = ?", productID, locationID).
+		First(&level).Error
+	return level, err
+}
+
+func applyLocationStock(tx *gorm.DB, productID, locationID uint, delta float64, allowNegative bool) (before, after float64, err error) {
+	level, err := lockLocationStock(tx, productID, locationID)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	before = level.Quantity
+	after = math.Round((before+delta)*1000) / 1000
+	if after < 0 && delta < 0 && !allowNegative {
+		return before, after, fmt.Errorf("%w at location %d: have %g, need %g",
+			customErrors.ErrInsufficientStock, locationID, before, -delta)
+	}
+
+	if err := tx.Model(&level).Update("quantity", after).Error; err != nil {
+		return 0, 0, err
+	}
+	if err := tx.Model(&models.Product{}).Where("id = ?", productID).
+		UpdateColumn("stock", gorm.Expr("stock + ?", delta)).Error; err != nil {
+		return 0, 0, err
+	}
+
+	return before, after, nil
+}
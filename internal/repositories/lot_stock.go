@@ -0,0 +1,169 @@
+package repositories
+
+import (
+	"fmt"
+	"math"
+	"pos-api/internal/models"
+	"time"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ReceiveLot adds quantity to a lot inside tx, creating the lot on its first
+// receipt. A lot number already in use at the location must keep its expiry
+// date. Call it after the location's stock was raised: when the location was
+// below zero, the units that cover the shortfall were already sold and do not
+// go into the lot, so the lots never hold more than the location's stock.
+func ReceiveLot(tx *gorm.DB, lot *models.ProductLot, quantity float64) (models.InventoryLogLot, error) {
+	var existing models.ProductLot
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("product_id = ? AND location_id = ? AND lot_number = ?", lot.ProductID, lot.LocationID, lot.LotNumber).
+		Limit(1).Find(&existing).Error; err != nil {
+		return models.InventoryLogLot{}, err
+	}
+
+	kept, err := lotRoom(tx, lot.ProductID, lot.LocationID, quantity)
+	if err != nil {
+		return models.InventoryLogLot{}, err
+	}
+
+	if existing.ID == 0 {
+		lot.Quantity = kept
+		lot.ReceivedQuantity = quantity
+		if err := tx.Omit("Product", "Location").Create(lot).Error; err != nil {
+			return models.InventoryLogLot{}, err
+		}
+		return models.InventoryLogLot{ProductLotID: lot.ID, Quantity: kept}, nil
+	}
+
+	if formatDate(existing.ExpiryDate) != formatDate(lot.ExpiryDate) {
+		return models.InventoryLogLot{}, fmt.Errorf("%w: lot %s already expires on %s",
+			customErrors.ErrConflict, lot.LotNumber, formatDate(existing.ExpiryDate))
+	}
+	if err := tx.Model(&existing).Updates(map[string]interface{}{
+		"quantity":          roundLot(existing.Quantity + kept),
+		"received_quantity": roundLot(existing.ReceivedQuantity + quantity),
+	}).Error; err != nil {
+		return models.InventoryLogLot{}, err
+	}
+	*lot = existing
+	return models.InventoryLogLot{ProductLotID: existing.ID, Quantity: kept}, nil
+}
+
+// lotRoom returns how much of quantity fits into a product's lots at a
+// location without the lots holding more than the location's stock.
+func lotRoom(tx *gorm.DB, productID, locationID uint, quantity float64) (float64, error) {
+	var level models.ProductStock
+	if err := tx.Where("product_id = ? AND location_id = ?", productID, locationID).
+		Limit(1).Find(&level).Error; err != nil {
+		return 0, err
+	}
+	var inLots float64
+	if err := tx.Model(&models.ProductLot{}).
+		Where("product_id = ? AND location_id = ? AND quantity > 0", productID, locationID).
+		Select("COALESCE(SUM(quantity), 0)").Scan(&inLots).Error; err != nil {
+		return 0, err
+	}
+	return math.Min(quantity, math.Max(0, roundLot(level.Quantity-inLots))), nil
+}
+
+// ConsumeLots takes quantity out of a product's lots at a location inside tx,
+// first-expiry-first-out. Unexpired lots go first, then untracked stock (the
+// part of stockBefore not held in any lot), and expired lots only when nothing
+// else is left, so the lots never hold more than the location's stock. It
+// returns how much was taken from each lot.
+func ConsumeLots(tx *gorm.DB, productID, locationID uint, quantity, stockBefore float64) ([]models.InventoryLogLot, error) {
+	var lots []models.ProductLot
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("product_id = ? AND location_id = ? AND quantity > 0", productID, locationID).
+		Order("expiry_date ASC NULLS LAST, id ASC").
+		Find(&lots).Error; err != nil {
+		return nil, err
+	}
+	if len(lots) == 0 {
+		return nil, nil
+	}
+
+	today := time.Now()
+	var fresh, expired []*models.ProductLot
+	var inLots float64
+	for i := range lots {
+		inLots += lots[i].Quantity
+		if lots[i].IsExpired(today) {
+			expired = append(expired, &lots[i])
+		} else {
+			fresh = append(fresh, &lots[i])
+		}
+	}
+
+	var taken []models.InventoryLogLot
+	remaining := roundLot(quantity)
+	take := func(group []*models.ProductLot) error {
+		for _, lot := range group {
+			if remaining <= 0 {
+				return nil
+			}
+			qty := math.Min(remaining, lot.Quantity)
+			if err := tx.Model(lot).Update("quantity", roundLot(lot.Quantity-qty)).Error; err != nil {
+				return err
+			}
+			taken = append(taken, models.InventoryLogLot{ProductLotID: lot.ID, Quantity: qty})
+			remaining = roundLot(remaining - qty)
+		}
+		return nil
+	}
+
+	if err := take(fresh); err != nil {
+		return nil, err
+	}
+	untracked := math.Max(0, roundLot(stockBefore-inLots))
+	remaining = math.Max(0, roundLot(remaining-untracked))
+	if err := take(expired); err != nil {
+		return nil, err
+	}
+
+	return taken, nil
+}
+
+// RestoreLots puts quantities taken by ConsumeLots back into their lots, e.g.
+// when a sale is returned, and returns what was put back.
+func RestoreLots(tx *gorm.DB, taken []models.InventoryLogLot) ([]models.InventoryLogLot, error) {
+	restored := make([]models.InventoryLogLot, 0, len(taken))
+	for _, t := range taken {
+		if err := tx.Model(&models.ProductLot{}).Where("id = ?", t.ProductLotID).
+			UpdateColumn("quantity", gorm.Expr("quantity + ?", t.Quantity)).Error; err != nil {
+			return nil, err
+		}
+		restored = append(restored, models.InventoryLogLot{ProductLotID: t.ProductLotID, Quantity: t.Quantity})
+	}
+	return restored, nil
+}
+
+// LinkLots records the lots an inventory log moved and sets them on the log.
+func LinkLots(tx *gorm.DB, log *models.InventoryLog, lots []models.InventoryLogLot) error {
+	if len(lots) == 0 {
+		return nil
+	}
+	for i := range lots {
+		lots[i].InventoryLogID = log.ID
+	}
+	if err := tx.Omit("ProductLot").Create(&lots).Error; err != nil {
+		return err
+	}
+	log.Lots = lots
+	return nil
+}
+
+func roundLot(v float64) float64 {
+	return math.Round(v*1000) / 1000
+}
+
+func formatDate(t *time.Time) string {
+	if t == nil {
+		return "never"
+	}
+	return t.Format("2006-01-02")
+}
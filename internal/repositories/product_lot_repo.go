@@ -0,0 +1,148 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"pos-api/internal/models"
+	"pos-api/internal/pkg/events"
+	"time"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ProductLotRepository interface {
+	GetByID(ctx context.Context, id uint) (*models.ProductLot, error)
+	// GetAll lists lots, soonest expiry first. Empty lots are left out unless includeEmpty is set.
+	GetAll(ctx context.Context, limit, offset int, productID, locationID uint, includeEmpty bool) ([]models.ProductLot, int64, error)
+	// GetExpiring lists lots with stock left that expire on or before the given day, expired ones included.
+	GetExpiring(ctx context.Context, until time.Time, locationID uint) ([]models.ProductLot, error)
+	// WriteOff takes everything left in the lot out of stock as an 'out/expired'
+	// InventoryLog, in one DB transaction.
+	WriteOff(ctx context.Context, id, userID uint, notes string) (*models.InventoryLog, error)
+}
+
+type productLotRepository struct {
+	DB       *gorm.DB
+	EventBus events.EventBus
+}
+
+func NewProductLotRepository(db *gorm.DB, eventBus events.EventBus) ProductLotRepository {
+	return &productLotRepository{
+		DB:       db,
+		EventBus: eventBus,
+	}
+}
+
+func (r *productLotRepository) GetByID(ctx context.Context, id uint) (*models.ProductLot, error) {
+	var lot models.ProductLot
+	err := r.DB.WithContext(ctx).Preload("Product").Preload("Location").First(&lot, id).Error
+	return &lot, err
+}
+
+func (r *productLotRepository) GetAll(ctx context.Context, limit, offset int, productID, locationID uint, includeEmpty bool) ([]models.ProductLot, int64, error) {
+	var lots []models.ProductLot
+	var total int64
+
+	query := r.DB.WithContext(ctx).Model(&models.ProductLot{})
+	if productID != 0 {
+		query = query.Where("product_id = ?", productID)
+	}
+	if locationID != 0 {
+		query = query.Where("location_id = ?", locationID)
+	}
+	if !includeEmpty {
+		query = query.Where("quantity > 0")
+	}
+
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("expiry_date ASC NULLS LAST, id ASC").
+		Limit(limit).Offset(offset).
+		Preload("Product").
+		Preload("Location").
+		Find(&lots).Error
+	return lots, total, err
+}
+
+func (r *productLotRepository) GetExpiring(ctx context.Context, until time.Time, locationID uint) ([]models.ProductLot, error) {
+	var lots []models.ProductLot
+	query := r.DB.WithContext(ctx).
+		Where("quantity > 0 AND expiry_date IS NOT NULL AND expiry_date <= ?", until.Format("2006-01-02"))
+	if locationID != 0 {
+		query = query.Where("location_id = ?", locationID)
+	}
+	err := query.Order("expiry_date ASC, id ASC").
+		Preload("Product").
+		Preload("Location").
+		Find(&lots).Error
+	return lots, err
+}
+
+func (r *productLotRepository) WriteOff(ctx context.Context, id, userID uint, notes string) (*models.InventoryLog, error) {
+	var log models.InventoryLog
+
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Stock is locked before the lot, the order sales take them in
+		var lot models.ProductLot
+		if err := tx.Select("id", "product_id", "location_id").First(&lot, id).Error; err != nil {
+			return err
+		}
+		if err := LockStock(tx, StockKey{ProductID: lot.ProductID, LocationID: lot.LocationID}); err != nil {
+			return err
+		}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&lot, id).Error; err != nil {
+			return err
+		}
+		if lot.Quantity <= 0 {
+			return fmt.Errorf("%w: lot %s has no stock left", customErrors.ErrConflict, lot.LotNumber)
+		}
+
+		stockBefore, stockAfter, err := ApplyLocationStock(tx, lot.ProductID, lot.LocationID, -lot.Quantity)
+		if err != nil {
+			return err
+		}
+		if err := tx.Model(&lot).Update("quantity", 0).Error; err != nil {
+			return err
+		}
+		costPrice, err := ConsumeCost(tx, lot.ProductID, lot.Quantity)
+		if err != nil {
+			return err
+		}
+
+		log = models.InventoryLog{
+			ProductID:   lot.ProductID,
+			LocationID:  lot.LocationID,
+			Type:        "out",
+			Source:      "expired",
+			Quantity:    lot.Quantity,
+			CostPrice:   costPrice,
+			TotalCost:   roundAmount(lot.Quantity * costPrice),
+			StockBefore: stockBefore,
+			StockAfter:  stockAfter,
+			Notes:       "Write-off lot " + lot.LotNumber,
+			UserID:      userID,
+		}
+		if notes != "" {
+			log.Notes += ": " + notes
+		}
+		if err := tx.Create(&log).Error; err != nil {
+			return err
+		}
+		if err := LinkLots(tx, &log, []models.InventoryLogLot{{ProductLotID: lot.ID, Quantity: lot.Quantity}}); err != nil {
+			return err
+		}
+
+		return r.EventBus.Publish(ctx, events.EventInventoryAdjusted, events.InventoryAdjustedPayload{
+			TX:           tx,
+			InventoryLog: &log,
+			UserID:       userID,
+		})
+	})
+
+	return &log, err
+}
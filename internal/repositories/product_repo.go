@@ -3,21 +3,54 @@ package repositories
 import (
 	"context"
 	"pos-api/internal/models"
+	"time"
 
 	"gorm.io/gorm"
 )
 
 // ProductRepository mendefinisikan kontrak untuk interaksi database produk.
 type ProductRepository interface {
-	CreateProduct(ctx context.Context, product *models.Product) error
+	// CreateProduct menyimpan produk baru; stok awal masuk ke lokasi default
+	// dan dicatat sebagai log inventori "opening" atas nama userID.
+	CreateProduct(ctx context.Context, product *models.Product, userID uint) error
 	GetProductByID(ctx context.Context, id uint) (*models.Product, error)
+	GetProductByBarcode(ctx context.Context, barcode string) (*models.Product, error)
+	GetProductByPLU(ctx context.Context, plu string) (*models.Product, error)
 	GetAllProducts(ctx context.Context, limit, offset int, search string, stockFilter string, sortBy string, sortOrder string, onlyTrashed bool) ([]models.Product, int64, error)
+	// GetLowStockProducts returns products at or below threshold, or below
+	// their own min_stock when threshold is 0.
 	GetLowStockProducts(ctx context.Context, threshold int) ([]models.Product, error)
 	GetStockCounts(ctx context.Context) (map[string]int64, error)
+	// GetReorderCandidates returns every active product with its reorder
+	// levels, the quantity sold in completed transactions since the given
+	// time, the quantity still on order from open purchase orders, and its
+	// supplier: the preferred one, else the one it was last ordered from.
+	GetReorderCandidates(ctx context.Context, since time.Time) ([]ReorderCandidate, error)
+	// UpdateProduct menyimpan perubahan data produk; stok tidak ikut diubah.
 	UpdateProduct(ctx context.Context, product *models.Product) error
 	DeleteProduct(ctx context.Context, id uint) error
 	RestoreProduct(ctx context.Context, id uint) error
 	ForceDeleteProduct(ctx context.Context, id uint) error
+	NextBarcodeSequence(ctx context.Context) (int64, error)
+	BarcodeExists(ctx context.Context, barcode string) (bool, error)
+}
+
+// ReorderCandidate is a product with the figures needed to decide whether and
+// how much to reorder.
+type ReorderCandidate struct {
+	ProductID    uint    `json:"product_id"`
+	Name         string  `json:"name"`
+	SKU          string  `json:"sku"`
+	SoldByWeight bool    `json:"sold_by_weight"`
+	Stock        float64 `json:"stock"`
+	MinStock     float64 `json:"min_stock"`
+	MaxStock     float64 `json:"max_stock"`
+	LeadTimeDays int     `json:"lead_time_days"`
+	Cost         float64 `json:"cost"`
+	QuantitySold float64 `json:"quantity_sold"`
+	OnOrder      float64 `json:"on_order"`
+	SupplierID   *uint   `json:"supplier_id"`
+	SupplierName string  `json:"supplier_name"`
 }
 
 type productRepository struct {
@@ -31,15 +64,92 @@ func NewProductRepository(db *gorm.DB) ProductRepository {
 	}
 }
 
-func (r *productRepository) CreateProduct(ctx context.Context, product *models.Product) error {
-	result := r.DB.WithContext(ctx).Create(product)
-	return result.Error
+// CreateProduct menyimpan produk dan mencatat stok awalnya di lokasi default.
+func (r *productRepository) CreateProduct(ctx context.Context, product *models.Product, userID uint) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		locationID, err := DefaultLocationID(tx)
+		if err != nil {
+			return err
+		}
+		if err := tx.Create(product).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&models.ProductStock{
+			ProductID:  product.ID,
+			LocationID: locationID,
+			Quantity:   product.Stock,
+		}).Error; err != nil {
+			return err
+		}
+		if product.Stock <= 0 {
+			return nil
+		}
+		// Stok awal dicatat di log agar riwayat stok bisa diputar ulang dari nol
+		opening := &models.InventoryLog{
+			ProductID:   product.ID,
+			LocationID:  locationID,
+			Type:        "adjustment",
+			Source:      "opening",
+			Quantity:    product.Stock,
+			CostPrice:   product.Cost,
+			TotalCost:   roundAmount(product.Stock * product.Cost),
+			StockBefore: 0,
+			StockAfter:  product.Stock,
+			Notes:       "Stok awal",
+			UserID:      userID,
+		}
+		if err := tx.Omit("Lots").Create(opening).Error; err != nil {
+			return err
+		}
+		// Stok awal menjadi lapisan biaya pertama
+		if _, err := ReceiveCost(tx, product.ID, nil, product.Stock, product.Cost); err != nil {
+			return err
+		}
+		return postOpeningStock(tx, product, opening)
+	})
+}
+
+// postOpeningStock membukukan nilai stok awal ke persediaan terhadap ekuitas
+// saldo awal, agar saldo akun persediaan sama dengan nilai stok.
+func postOpeningStock(tx *gorm.DB, product *models.Product, log *models.InventoryLog) error {
+	inventory, err := SystemAccount(tx, models.AccountCodeInventory)
+	if err != nil {
+		return err
+	}
+	opening, err := SystemAccount(tx, models.AccountCodeOpening)
+	if err != nil {
+		return err
+	}
+	return PostJournal(tx, &models.JournalEntry{
+		Source:         models.JournalInventory,
+		Description:    "Stok awal " + product.Name,
+		InventoryLogID: &log.ID,
+		UserID:         log.UserID,
+		Lines: []models.JournalLine{
+			Debit(inventory, log.TotalCost, log.Source),
+			Credit(opening, log.TotalCost, log.Source),
+		},
+	})
 }
 
 func (r *productRepository) GetProductByID(ctx context.Context, id uint) (*models.Product, error) {
 	var product models.Product
-	// Preload Category untuk mendapatkan data kategori sekalian
-	result := r.DB.WithContext(ctx).Preload("Category").First(&product, id)
+	// Preload Category dan Supplier untuk mendapatkan datanya sekalian
+	result := r.DB.WithContext(ctx).Preload("Category").Preload("Supplier").First(&product, id)
+	return &product, result.Error
+}
+
+// GetProductByBarcode mencari produk aktif berdasarkan barcode (exact match).
+func (r *productRepository) GetProductByBarcode(ctx context.Context, barcode string) (*models.Product, error) {
+	var product models.Product
+	result := r.DB.WithContext(ctx).Preload("Category").Where("barcode = ?", barcode).First(&product)
+	return &product, result.Error
+}
+
+// GetProductByPLU mencari produk aktif berdasarkan kode PLU timbangan.
+func (r *productRepository) GetProductByPLU(ctx context.Context, plu string) (*models.Product, error) {
+	var product models.Product
+	result := r.DB.WithContext(ctx).Preload("Category").Where("plu = ?", plu).First(&product)
 	return &product, result.Error
 }
 
@@ -62,11 +172,11 @@ func (r *productRepository) GetAllProducts(ctx context.Context, limit, offset in
 	// Apply stock filter
 	switch stockFilter {
 	case "low":
-		query = query.Where("stock > 0 AND stock < 10")
+		query = query.Where("stock > 0 AND stock < min_stock")
 	case "out":
 		query = query.Where("stock <= 0")
 	case "high":
-		query = query.Where("stock >= 10")
+		query = query.Where("stock >= min_stock")
 	}
 
 	// Hitung total items sebelum limit/offset
@@ -93,18 +203,70 @@ func (r *productRepository) GetAllProducts(ctx context.Context, limit, offset in
 	return products, totalItems, err
 }
 
+// UpdateProduct menyimpan perubahan produk. Stok hanya berubah lewat log
+// inventori, jadi kolom stock tidak ikut disimpan.
 func (r *productRepository) UpdateProduct(ctx context.Context, product *models.Product) error {
 	// Save akan mengupdate semua field, termasuk CategoryID
-	result := r.DB.WithContext(ctx).Save(product)
-	return result.Error
+	return r.DB.WithContext(ctx).Omit("Stock").Save(product).Error
 }
 
 func (r *productRepository) GetLowStockProducts(ctx context.Context, threshold int) ([]models.Product, error) {
 	var products []models.Product
-	result := r.DB.WithContext(ctx).Preload("Category").Where("stock <= ?", threshold).Order("stock ASC").Find(&products)
+	query := r.DB.WithContext(ctx).Preload("Category")
+	if threshold > 0 {
+		query = query.Where("stock <= ?", threshold)
+	} else {
+		query = query.Where("stock < min_stock")
+	}
+	result := query.Order("stock ASC").Find(&products)
 	return products, result.Error
 }
 
+func (r *productRepository) GetReorderCandidates(ctx context.Context, since time.Time) ([]ReorderCandidate, error) {
+	var candidates []ReorderCandidate
+
+	query := `
+		SELECT
+			p.id AS product_id, p.name, p.sku, p.sold_by_weight, p.stock,
+			p.min_stock, p.max_stock, p.lead_time_days, p.cost,
+			COALESCE(sold.quantity, 0) AS quantity_sold,
+			COALESCE(ordered.quantity, 0) AS on_order,
+			s.id AS supplier_id,
+			COALESCE(s.name, '') AS supplier_name
+		FROM products p
+		LEFT JOIN (
+			SELECT td.product_id, SUM(td.quantity) AS quantity
+			FROM transaction_details td
+			JOIN transactions t ON t.id = td.transaction_id
+			WHERE t.status = 'completed' AND t.deleted_at IS NULL AND t.created_at >= ?
+			GROUP BY td.product_id
+		) sold ON sold.product_id = p.id
+		LEFT JOIN (
+			SELECT poi.product_id, SUM(GREATEST(poi.quantity - poi.received_quantity, 0)) AS quantity
+			FROM purchase_order_items poi
+			JOIN purchase_orders po ON po.id = poi.purchase_order_id
+			WHERE po.status IN (?, ?) AND po.deleted_at IS NULL
+			GROUP BY poi.product_id
+		) ordered ON ordered.product_id = p.id
+		LEFT JOIN LATERAL (
+			SELECT po.supplier_id
+			FROM purchase_order_items poi
+			JOIN purchase_orders po ON po.id = poi.purchase_order_id
+			WHERE poi.product_id = p.id AND po.status <> ? AND po.deleted_at IS NULL
+			ORDER BY po.created_at DESC, po.id DESC
+			LIMIT 1
+		) last_po ON true
+		LEFT JOIN suppliers s ON s.id = COALESCE(p.supplier_id, last_po.supplier_id) AND s.deleted_at IS NULL
+		WHERE p.deleted_at IS NULL
+		ORDER BY p.name ASC
+	`
+
+	err := r.DB.WithContext(ctx).Raw(query, since,
+		models.PurchaseOrderSent, models.PurchaseOrderPartiallyReceived,
+		models.PurchaseOrderCancelled).Scan(&candidates).Error
+	return candidates, err
+}
+
 func (r *productRepository) DeleteProduct(ctx context.Context, id uint) error {
 	result := r.DB.WithContext(ctx).Delete(&models.Product{}, id)
 	return result.Error
@@ -124,9 +286,9 @@ func (r *productRepository) GetStockCounts(ctx context.Context) (map[string]int6
 	var all, high, low, out int64
 
 	r.DB.WithContext(ctx).Model(&models.Product{}).Count(&all)
-	r.DB.WithContext(ctx).Model(&models.Product{}).Where("stock >= 10").Count(&high)
-	r.DB.WithContext(ctx).Model(&models.Product{}).Where("stock > 0 AND stock < 10").Count(&low)
-	r.DB.WithContext(ctx).Model(&models.Product{}).Where("stock = 0").Count(&out)
+	r.DB.WithContext(ctx).Model(&models.Product{}).Where("stock >= min_stock").Count(&high)
+	r.DB.WithContext(ctx).Model(&models.Product{}).Where("stock > 0 AND stock < min_stock").Count(&low)
+	r.DB.WithContext(ctx).Model(&models.Product{}).Where("stock <= 0").Count(&out)
 
 	return map[string]int64{
 		"all":  all,
@@ -135,3 +297,17 @@ func (r *productRepository) GetStockCounts(ctx context.Context) (map[string]int6
 		"out":  out,
 	}, nil
 }
+
+// NextBarcodeSequence returns the next value of the internal barcode sequence.
+func (r *productRepository) NextBarcodeSequence(ctx context.Context) (int64, error) {
+	var next int64
+	err := r.DB.WithContext(ctx).Raw("SELECT nextval('product_barcode_seq')").Scan(&next).Error
+	return next, err
+}
+
+// BarcodeExists checks whether any product, including soft-deleted ones, already uses the barcode.
+func (r *productRepository) BarcodeExists(ctx context.Context, barcode string) (bool, error) {
+	var count int64
+	err := r.DB.WithContext(ctx).Unscoped().Model(&models.Product{}).Where("barcode = ?", barcode).Count(&count).Error
+	return count > 0, err
+}
@@ -0,0 +1,125 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"pos-api/internal/models"
+	"time"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"gorm.io/gorm"
+)
+
+type ProductSerialRepository interface {
+	GetAll(ctx context.Context, limit, offset int, productID, locationID uint, status, search string) ([]models.ProductSerial, int64, error)
+	// FindBySerial returns every unit carrying the serial number, with the
+	// purchase order it came in on and the sale it left with.
+	FindBySerial(ctx context.Context, serial string) ([]models.ProductSerial, error)
+	// Register records serial numbers for units already in stock at a location
+	// without changing the stock level, in one DB transaction.
+	Register(ctx context.Context, productID, locationID uint, serials []string) ([]models.ProductSerial, error)
+}
+
+type productSerialRepository struct {
+	DB *gorm.DB
+}
+
+func NewProductSerialRepository(db *gorm.DB) ProductSerialRepository {
+	return &productSerialRepository{DB: db}
+}
+
+func (r *productSerialRepository) GetAll(ctx context.Context, limit, offset int, productID, locationID uint, status, search string) ([]models.ProductSerial, int64, error) {
+	var serials []models.ProductSerial
+	var total int64
+
+	query := r.DB.WithContext(ctx).Model(&models.ProductSerial{})
+	if productID != 0 {
+		query = query.Where("product_id = ?", productID)
+	}
+	if locationID != 0 {
+		query = query.Where("location_id = ?", locationID)
+	}
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if search != "" {
+		query = query.Where("serial_number ILIKE ?", "%"+search+"%")
+	}
+
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("received_at DESC, id DESC").
+		Limit(limit).Offset(offset).
+		Preload("Product").
+		Preload("Location").
+		Find(&serials).Error
+	return serials, total, err
+}
+
+func (r *productSerialRepository) FindBySerial(ctx context.Context, serial string) ([]models.ProductSerial, error) {
+	var serials []models.ProductSerial
+	err := r.DB.WithContext(ctx).
+		Where("serial_number = ?", serial).
+		Order("id ASC").
+		Preload("Product").
+		Preload("Location").
+		Preload("PurchaseOrder").
+		Preload("PurchaseOrder.Supplier").
+		Preload("Transaction").
+		Find(&serials).Error
+	return serials, err
+}
+
+func (r *productSerialRepository) Register(ctx context.Context, productID, locationID uint, serials []string) ([]models.ProductSerial, error) {
+	units := make([]models.ProductSerial, 0, len(serials))
+
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Lock the stock level so sales cannot change it while we count
+		level, err := lockLocationStock(tx, productID, locationID)
+		if err != nil {
+			return err
+		}
+
+		var inStock int64
+		if err := tx.Model(&models.ProductSerial{}).
+			Where("product_id = ? AND location_id = ? AND status = ?", productID, locationID, models.SerialInStock).
+			Count(&inStock).Error; err != nil {
+			return err
+		}
+		if float64(inStock)+float64(len(serials)) > level.Quantity {
+			return fmt.Errorf("%w: location %d holds %g units and %d already have serial numbers",
+				customErrors.ErrConflict, locationID, level.Quantity, inStock)
+		}
+
+		now := time.Now()
+		for _, serial := range serials {
+			var unit models.ProductSerial
+			if err := lockSerial(tx, productID, serial, &unit); err != nil {
+				return err
+			}
+			if unit.ID != 0 && unit.Status == models.SerialInStock {
+				return fmt.Errorf("%w: serial %s is already in stock", customErrors.ErrConflict, serial)
+			}
+
+			unit.ProductID = productID
+			unit.SerialNumber = serial
+			unit.LocationID = locationID
+			unit.Status = models.SerialInStock
+			unit.ReceivedAt = now
+			unit.ReceivedLogID = nil
+			unit.PurchaseOrderID = nil
+			unit.TransactionID = nil
+			unit.SoldAt = nil
+			if err := tx.Omit("Product", "Location", "PurchaseOrder", "Transaction").Save(&unit).Error; err != nil {
+				return err
+			}
+			units = append(units, unit)
+		}
+		return nil
+	})
+
+	return units, err
+}
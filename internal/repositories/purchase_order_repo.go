@@ -0,0 +1,108 @@
+package repositories
+
+import (
+	"context"
+	"pos-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type PurchaseOrderRepository interface {
+	Create(ctx context.Context, po *models.PurchaseOrder) error
+	// Update saves the header and replaces all line items in a single DB transaction.
+	Update(ctx context.Context, po *models.PurchaseOrder) error
+	// UpdateStatus moves the order to status if it is still in one of from,
+	// reporting whether it was; a goods receipt holding the order is waited for.
+	UpdateStatus(ctx context.Context, id uint, status string, from ...string) (bool, error)
+	Delete(ctx context.Context, id uint) error
+	GetByID(ctx context.Context, id uint) (*models.PurchaseOrder, error)
+	GetAll(ctx context.Context, limit, offset int, status string, supplierID uint) ([]models.PurchaseOrder, int64, error)
+}
+
+type purchaseOrderRepository struct {
+	DB *gorm.DB
+}
+
+func NewPurchaseOrderRepository(db *gorm.DB) PurchaseOrderRepository {
+	return &purchaseOrderRepository{DB: db}
+}
+
+func (r *purchaseOrderRepository) Create(ctx context.Context, po *models.PurchaseOrder) error {
+	return r.DB.WithContext(ctx).Create(po).Error
+}
+
+func (r *purchaseOrderRepository) Update(ctx context.Context, po *models.PurchaseOrder) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("purchase_order_id = ?", po.ID).Delete(&models.PurchaseOrderItem{}).Error; err != nil {
+			return err
+		}
+
+		for i := range po.Items {
+			po.Items[i].ID = 0
+			po.Items[i].PurchaseOrderID = po.ID
+		}
+
+		// Omit associations so Save does not upsert Supplier/User/Location/Product rows
+		if err := tx.Omit("Items", "Supplier", "User", "Location").Save(po).Error; err != nil {
+			return err
+		}
+		if len(po.Items) == 0 {
+			return nil
+		}
+		return tx.Omit("Product").Create(&po.Items).Error
+	})
+}
+
+func (r *purchaseOrderRepository) UpdateStatus(ctx context.Context, id uint, status string, from ...string) (bool, error) {
+	result := r.DB.WithContext(ctx).Model(&models.PurchaseOrder{}).
+		Where("id = ? AND status IN ?", id, from).
+		Update("status", status)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *purchaseOrderRepository) Delete(ctx context.Context, id uint) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("purchase_order_id = ?", id).Delete(&models.PurchaseOrderItem{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.PurchaseOrder{}, id).Error
+	})
+}
+
+func (r *purchaseOrderRepository) GetByID(ctx context.Context, id uint) (*models.PurchaseOrder, error) {
+	var po models.PurchaseOrder
+	err := r.DB.WithContext(ctx).
+		Preload("Supplier").
+		Preload("Location").
+		Preload("User").
+		Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Preload("Items.Product").
+		First(&po, id).Error
+	return &po, err
+}
+
+func (r *purchaseOrderRepository) GetAll(ctx context.Context, limit, offset int, status string, supplierID uint) ([]models.PurchaseOrder, int64, error) {
+	var orders []models.PurchaseOrder
+	var total int64
+
+	query := r.DB.WithContext(ctx).Model(&models.PurchaseOrder{})
+
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if supplierID != 0 {
+		query = query.Where("supplier_id = ?", supplierID)
+	}
+
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("created_at DESC").
+		Limit(limit).Offset(offset).
+		Preload("Supplier").
+		Preload("Items").
+		Preload("Items.Product").
+		Find(&orders).Error
+	return orders, total, err
+}
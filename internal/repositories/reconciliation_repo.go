@@ -0,0 +1,307 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"pos-api/internal/models"
+	"sort"
+	"strings"
+	"time"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReconciliationRepository interface {
+	// Create saves the statement with its lines and books their fees as
+	// merchant fee expenses paid out of the payment method's bank account,
+	// one entry per date.
+	Create(ctx context.Context, statement *models.BankStatement) error
+	GetStatements(ctx context.Context, paymentMethodID *uint) ([]models.BankStatement, error)
+	// GetStatement returns the statement with its lines and the sales they
+	// settle.
+	GetStatement(ctx context.Context, id uint) (*models.BankStatement, error)
+	// LatestMapping returns the column mapping of the payment method's last
+	// import, nil when it has none.
+	LatestMapping(ctx context.Context, paymentMethodID uint) (*models.StatementMapping, error)
+	// ImportedFingerprints returns which of fingerprints are already imported.
+	ImportedFingerprints(ctx context.Context, fingerprints []string) (map[string]bool, error)
+	// GetUnsettled lists the completed sales made between from and to that no
+	// statement line settles, paid with the method named method, or with any
+	// non-cash method when method is empty.
+	GetUnsettled(ctx context.Context, method string, from, to time.Time) ([]models.Transaction, error)
+	// GetUnmatchedLines lists the unmatched statement lines dated from to to,
+	// of one payment method when paymentMethodID is set.
+	GetUnmatchedLines(ctx context.Context, paymentMethodID *uint, from, to time.Time) ([]models.BankStatementLine, error)
+	GetLine(ctx context.Context, id uint) (*models.BankStatementLine, error)
+	// Match settles the completed sale transactionID with the line. Either
+	// being matched already, or the sale being paid with another method, is
+	// ErrConflict.
+	Match(ctx context.Context, lineID, transactionID uint) (*models.BankStatementLine, error)
+	// Unmatch puts a matched or ignored line back to unmatched.
+	Unmatch(ctx context.Context, lineID uint) (*models.BankStatementLine, error)
+	// Ignore marks an unmatched line as not settling any sale.
+	Ignore(ctx context.Context, lineID uint) (*models.BankStatementLine, error)
+}
+
+type reconciliationRepository struct {
+	DB *gorm.DB
+}
+
+func NewReconciliationRepository(db *gorm.DB) ReconciliationRepository {
+	return &reconciliationRepository{DB: db}
+}
+
+func (r *reconciliationRepository) Create(ctx context.Context, statement *models.BankStatement) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := bookStatementFees(tx, statement); err != nil {
+			return err
+		}
+		return tx.Omit("PaymentMethod", "User", "Lines.BankStatement", "Lines.Transaction").Create(statement).Error
+	})
+}
+
+// bookStatementFees books the fees of the statement's lines, summed per date,
+// as merchant fee expenses against the payment method's bank account, and
+// points each line with a fee at its expense.
+func bookStatementFees(tx *gorm.DB, statement *models.BankStatement) error {
+	fees := make(map[time.Time]float64)
+	var dates []time.Time
+	for _, line := range statement.Lines {
+		if line.Fee == 0 {
+			continue
+		}
+		if _, ok := fees[line.Date]; !ok {
+			dates = append(dates, line.Date)
+		}
+		fees[line.Date] += line.Fee
+	}
+	if len(dates) == 0 {
+		return nil
+	}
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+
+	var method models.PaymentMethod
+	if err := tx.Unscoped().First(&method, statement.PaymentMethodID).Error; err != nil {
+		return err
+	}
+	var category models.CashFlowCategory
+	if err := tx.Where("code = ?", models.CashFlowSourceMerchantFees).First(&category).Error; err != nil {
+		return fmt.Errorf("cash flow category %q: %w", models.CashFlowSourceMerchantFees, err)
+	}
+	bank, err := PaymentAccount(tx, method.Name)
+	if err != nil {
+		return err
+	}
+	counter, err := SystemAccount(tx, category.AccountCode)
+	if err != nil {
+		return err
+	}
+
+	booked := make(map[time.Time]uint, len(dates))
+	for _, date := range dates {
+		cf := models.CashFlow{
+			Type:   "expense",
+			Source: category.Code,
+			Amount: roundAmount(fees[date]),
+			Date:   date,
+			Notes: fmt.Sprintf("Merchant fees %s %s (statement %s)",
+				method.Name, date.Format("2006-01-02"), statement.FileName),
+			UserID: statement.UserID,
+		}
+		if cf.Amount == 0 {
+			continue
+		}
+		if err := RecordCashFlow(tx, &cf, CashFlowEntry(&cf, bank, counter, models.JournalBankFee)); err != nil {
+			return fmt.Errorf("failed to book merchant fees of %s: %w", date.Format("2006-01-02"), err)
+		}
+		booked[date] = cf.ID
+	}
+	for i := range statement.Lines {
+		line := &statement.Lines[i]
+		if id, ok := booked[line.Date]; ok && line.Fee != 0 {
+			line.FeeCashFlowID = &id
+		}
+	}
+	return nil
+}
+
+func (r *reconciliationRepository) GetStatements(ctx context.Context, paymentMethodID *uint) ([]models.BankStatement, error) {
+	var statements []models.BankStatement
+	query := r.DB.WithContext(ctx).Preload("PaymentMethod").Preload("User")
+	if paymentMethodID != nil {
+		query = query.Where("payment_method_id = ?", *paymentMethodID)
+	}
+	err := query.Order("id DESC").Find(&statements).Error
+	return statements, err
+}
+
+func (r *reconciliationRepository) GetStatement(ctx context.Context, id uint) (*models.BankStatement, error) {
+	var statement models.BankStatement
+	err := r.DB.WithContext(ctx).
+		Preload("PaymentMethod").
+		Preload("User").
+		Preload("Lines", func(db *gorm.DB) *gorm.DB { return db.Order("line_no ASC") }).
+		Preload("Lines.Transaction").
+		First(&statement, id).Error
+	return &statement, err
+}
+
+func (r *reconciliationRepository) LatestMapping(ctx context.Context, paymentMethodID uint) (*models.StatementMapping, error) {
+	var statements []models.BankStatement
+	if err := r.DB.WithContext(ctx).Select("id", "mapping").
+		Where("payment_method_id = ?", paymentMethodID).
+		Order("id DESC").Limit(1).Find(&statements).Error; err != nil {
+		return nil, err
+	}
+	if len(statements) == 0 {
+		return nil, nil
+	}
+	return &statements[0].Mapping, nil
+}
+
+func (r *reconciliationRepository) ImportedFingerprints(ctx context.Context, fingerprints []string) (map[string]bool, error) {
+	imported := make(map[string]bool)
+	if len(fingerprints) == 0 {
+		return imported, nil
+	}
+	var found []string
+	if err := r.DB.WithContext(ctx).Model(&models.BankStatementLine{}).
+		Where("fingerprint IN ?", fingerprints).
+		Pluck("fingerprint", &found).Error; err != nil {
+		return nil, err
+	}
+	for _, f := range found {
+		imported[f] = true
+	}
+	return imported, nil
+}
+
+func (r *reconciliationRepository) GetUnsettled(ctx context.Context, method string, from, to time.Time) ([]models.Transaction, error) {
+	var transactions []models.Transaction
+	query := r.DB.WithContext(ctx).
+		Where("status = ? AND created_at >= ? AND created_at < ?", "completed", from, to).
+		Where("NOT EXISTS (SELECT 1 FROM bank_statement_lines WHERE bank_statement_lines.transaction_id = transactions.id)")
+	if method != "" {
+		query = query.Where("LOWER(payment_method) = LOWER(?)", method)
+	} else {
+		query = query.Where("EXISTS (SELECT 1 FROM payment_methods WHERE LOWER(payment_methods.name) = LOWER(transactions.payment_method) AND payment_methods.is_cash = ?)", false)
+	}
+	err := query.Order("created_at ASC").Find(&transactions).Error
+	return transactions, err
+}
+
+func (r *reconciliationRepository) GetUnmatchedLines(ctx context.Context, paymentMethodID *uint, from, to time.Time) ([]models.BankStatementLine, error) {
+	var lines []models.BankStatementLine
+	query := r.DB.WithContext(ctx).Preload("BankStatement.PaymentMethod").
+		Where("bank_statement_lines.status = ?", models.StatementLineUnmatched).
+		Where("bank_statement_lines.date BETWEEN ? AND ?", from.Format("2006-01-02"), to.Format("2006-01-02"))
+	if paymentMethodID != nil {
+		query = query.Joins("JOIN bank_statements ON bank_statements.id = bank_statement_lines.bank_statement_id").
+			Where("bank_statements.payment_method_id = ?", *paymentMethodID)
+	}
+	err := query.Order("bank_statement_lines.date ASC, bank_statement_lines.id ASC").Find(&lines).Error
+	return lines, err
+}
+
+func (r *reconciliationRepository) GetLine(ctx context.Context, id uint) (*models.BankStatementLine, error) {
+	var line models.BankStatementLine
+	err := r.DB.WithContext(ctx).Preload("BankStatement.PaymentMethod").Preload("Transaction").First(&line, id).Error
+	return &line, err
+}
+
+func (r *reconciliationRepository) Match(ctx context.Context, lineID, transactionID uint) (*models.BankStatementLine, error) {
+	var line *models.BankStatementLine
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		if line, err = lockStatementLine(tx, lineID); err != nil {
+			return err
+		}
+		if line.Status == models.StatementLineMatched {
+			return fmt.Errorf("%w: line %d already settles transaction %d", customErrors.ErrConflict, line.ID, *line.TransactionID)
+		}
+
+		var transaction models.Transaction
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&transaction, transactionID).Error; err != nil {
+			return err
+		}
+		if transaction.Status != "completed" {
+			return fmt.Errorf("%w: transaction %s is %s", customErrors.ErrConflict, transaction.TransactionCode, transaction.Status)
+		}
+		var method models.PaymentMethod
+		if err := tx.Unscoped().Select("name").First(&method, line.BankStatement.PaymentMethodID).Error; err != nil {
+			return err
+		}
+		if !strings.EqualFold(transaction.PaymentMethod, method.Name) {
+			return fmt.Errorf("%w: transaction %s was paid with %s, not %s",
+				customErrors.ErrConflict, transaction.TransactionCode, transaction.PaymentMethod, method.Name)
+		}
+		var settled int64
+		if err := tx.Model(&models.BankStatementLine{}).Where("transaction_id = ?", transactionID).Count(&settled).Error; err != nil {
+			return err
+		}
+		if settled > 0 {
+			return fmt.Errorf("%w: transaction %s is already settled by another line", customErrors.ErrConflict, transaction.TransactionCode)
+		}
+
+		now := time.Now()
+		line.Status, line.TransactionID, line.MatchedAt = models.StatementLineMatched, &transaction.ID, &now
+		line.Transaction = &transaction
+		return saveStatementLine(tx, line)
+	})
+	return line, err
+}
+
+func (r *reconciliationRepository) Unmatch(ctx context.Context, lineID uint) (*models.BankStatementLine, error) {
+	var line *models.BankStatementLine
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		if line, err = lockStatementLine(tx, lineID); err != nil {
+			return err
+		}
+		if line.Status == models.StatementLineUnmatched {
+			return fmt.Errorf("%w: line %d is not matched", customErrors.ErrConflict, line.ID)
+		}
+		line.Status, line.TransactionID, line.MatchedAt = models.StatementLineUnmatched, nil, nil
+		return saveStatementLine(tx, line)
+	})
+	return line, err
+}
+
+func (r *reconciliationRepository) Ignore(ctx context.Context, lineID uint) (*models.BankStatementLine, error) {
+	var line *models.BankStatementLine
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		if line, err = lockStatementLine(tx, lineID); err != nil {
+			return err
+		}
+		if line.Status != models.StatementLineUnmatched {
+			return fmt.Errorf("%w: line %d is %s; unmatch it first", customErrors.ErrConflict, line.ID, line.Status)
+		}
+		line.Status = models.StatementLineIgnored
+		return saveStatementLine(tx, line)
+	})
+	return line, err
+}
+
+// lockStatementLine locks a statement line inside tx, with its statement.
+func lockStatementLine(tx *gorm.DB, id uint) (*models.BankStatementLine, error) {
+	var line models.BankStatementLine
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Preload("BankStatement").First(&line, id).Error; err != nil {
+		return nil, err
+	}
+	return &line, nil
+}
+
+func saveStatementLine(tx *gorm.DB, line *models.BankStatementLine) error {
+	line.UpdatedAt = time.Now()
+	return tx.Model(&models.BankStatementLine{ID: line.ID}).Updates(map[string]interface{}{
+		"status":         line.Status,
+		"transaction_id": line.TransactionID,
+		"matched_at":     line.MatchedAt,
+		"updated_at":     line.UpdatedAt,
+	}).Error
+}
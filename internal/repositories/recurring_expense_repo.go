@@ -0,0 +1,148 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"pos-api/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RecurringExpenseRepository interface {
+	Create(ctx context.Context, expense *models.RecurringExpense) error
+	Update(ctx context.Context, expense *models.RecurringExpense) error
+	Delete(ctx context.Context, id uint) error
+	GetByID(ctx context.Context, id uint) (*models.RecurringExpense, error)
+	GetAll(ctx context.Context, onlyActive bool) ([]models.RecurringExpense, error)
+	// GetDue lists the active templates not yet booked through today.
+	GetDue(ctx context.Context, today time.Time) ([]models.RecurringExpense, error)
+	// Book books the template's occurrences up to today that are not booked
+	// yet as cash flow expenses and moves its BookedThrough to today.
+	// Occurrences in closed periods are skipped. It holds the template's row
+	// lock, so concurrent runs book each date once.
+	Book(ctx context.Context, id uint, today time.Time) ([]models.CashFlow, error)
+}
+
+type recurringExpenseRepository struct {
+	DB *gorm.DB
+}
+
+func NewRecurringExpenseRepository(db *gorm.DB) RecurringExpenseRepository {
+	return &recurringExpenseRepository{DB: db}
+}
+
+func (r *recurringExpenseRepository) Create(ctx context.Context, expense *models.RecurringExpense) error {
+	return r.DB.WithContext(ctx).Omit("Category", "User").Create(expense).Error
+}
+
+func (r *recurringExpenseRepository) Update(ctx context.Context, expense *models.RecurringExpense) error {
+	return r.DB.WithContext(ctx).Omit("Category", "User").Save(expense).Error
+}
+
+func (r *recurringExpenseRepository) Delete(ctx context.Context, id uint) error {
+	return r.DB.WithContext(ctx).Delete(&models.RecurringExpense{}, id).Error
+}
+
+func (r *recurringExpenseRepository) GetByID(ctx context.Context, id uint) (*models.RecurringExpense, error) {
+	var expense models.RecurringExpense
+	err := r.DB.WithContext(ctx).Preload("Category").Preload("User").First(&expense, id).Error
+	return &expense, err
+}
+
+func (r *recurringExpenseRepository) GetAll(ctx context.Context, onlyActive bool) ([]models.RecurringExpense, error) {
+	var expenses []models.RecurringExpense
+	query := r.DB.WithContext(ctx)
+	if onlyActive {
+		query = query.Where("is_active = ?", true)
+	}
+	err := query.Preload("Category").Order("name ASC").Find(&expenses).Error
+	return expenses, err
+}
+
+func (r *recurringExpenseRepository) GetDue(ctx context.Context, today time.Time) ([]models.RecurringExpense, error) {
+	var expenses []models.RecurringExpense
+	day := today.Format("2006-01-02") // Compared as a date, whatever the session time zone
+	err := r.DB.WithContext(ctx).
+		Where("is_active = ? AND start_date <= ?", true, day).
+		Where("booked_through IS NULL OR booked_through < ?", day).
+		Where("end_date IS NULL OR booked_through IS NULL OR booked_through < end_date").
+		Order("id ASC").
+		Find(&expenses).Error
+	return expenses, err
+}
+
+func (r *recurringExpenseRepository) Book(ctx context.Context, id uint, today time.Time) ([]models.CashFlow, error) {
+	var booked []models.CashFlow
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var expense models.RecurringExpense
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&expense, id).Error; err != nil {
+			return err
+		}
+		if !expense.IsActive {
+			return nil
+		}
+
+		from := expense.StartDate
+		if expense.BookedThrough != nil {
+			if !expense.BookedThrough.Before(today) {
+				return nil
+			}
+			from = expense.BookedThrough.AddDate(0, 0, 1)
+		}
+
+		for _, due := range expense.Occurrences(from, today) {
+			// The books of a closed period are final; such an occurrence is
+			// left for whoever closed it to account for
+			closed, err := PeriodClosed(tx, due)
+			if err != nil {
+				return err
+			}
+			if closed {
+				slog.Warn("Recurring expense not booked in a closed period", "recurring_expense_id", expense.ID, "date", due.Format("2006-01-02"))
+				continue
+			}
+
+			// Entries deleted by hand count too: they were booked once
+			var count int64
+			if err := tx.Unscoped().Model(&models.CashFlow{}).
+				Where("recurring_expense_id = ? AND date = ?", expense.ID, due).
+				Count(&count).Error; err != nil {
+				return err
+			}
+			if count > 0 {
+				continue
+			}
+
+			cf := models.CashFlow{
+				Type:               "expense",
+				Source:             expense.Source,
+				Amount:             expense.Amount,
+				Date:               due,
+				Notes:              recurringExpenseNotes(&expense),
+				RecurringExpenseID: &expense.ID,
+				UserID:             expense.UserID,
+			}
+			entry, err := manualCashFlowEntry(tx, &cf)
+			if err != nil {
+				return err
+			}
+			if err := RecordCashFlow(tx, &cf, entry); err != nil {
+				return err
+			}
+			booked = append(booked, cf)
+		}
+
+		return tx.Model(&expense).Update("booked_through", today.Format("2006-01-02")).Error
+	})
+	return booked, err
+}
+
+func recurringExpenseNotes(expense *models.RecurringExpense) string {
+	if expense.Notes == "" {
+		return fmt.Sprintf("%s (recurring)", expense.Name)
+	}
+	return fmt.Sprintf("%s (recurring): %s", expense.Name, expense.Notes)
+}
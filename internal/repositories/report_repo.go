@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"pos-api/internal/models"
 	"time"
 
 	"gorm.io/gorm"
@@ -12,7 +13,7 @@ type SalesReport struct {
 	Date               string  `json:"date"`
 	TotalSales         float64 `json:"total_sales"`
 	TotalTransactions  int64   `json:"total_transactions"`
-	TotalItemsSold     int64   `json:"total_items_sold"`
+	TotalItemsSold     float64 `json:"total_items_sold"`
 	AverageTransaction float64 `json:"average_transaction"`
 }
 
@@ -21,9 +22,9 @@ type ProductReport struct {
 	ProductID    uint    `json:"product_id"`
 	ProductName  string  `json:"product_name"`
 	CategoryName string  `json:"category_name"`
-	TotalSold    int64   `json:"total_sold"`
+	TotalSold    float64 `json:"total_sold"`
 	TotalRevenue float64 `json:"total_revenue"`
-	CurrentStock int     `json:"current_stock"`
+	CurrentStock float64 `json:"current_stock"`
 }
 
 // HourlySales represents sales grouped by hour
@@ -36,9 +37,30 @@ type HourlySales struct {
 // StockValue represents the total inventory value
 type StockValue struct {
 	TotalProducts int64   `json:"total_products"`
-	TotalUnits    int64   `json:"total_units"`
-	TotalValue    float64 `json:"total_value"`  // SUM(stock * cost)
-	TotalRetail   float64 `json:"total_retail"` // SUM(stock * price)
+	TotalUnits    float64 `json:"total_units"`
+	TotalValue    float64 `json:"total_value"`    // Stock at cost, valued by CostingMethod
+	TotalRetail   float64 `json:"total_retail"`   // SUM(stock * price)
+	CostingMethod string  `json:"costing_method"` // "average" or "fifo"
+}
+
+// DailyProductSales is the quantity of a product sold on one day
+type DailyProductSales struct {
+	Date      string  `json:"date"` // YYYY-MM-DD
+	ProductID uint    `json:"product_id"`
+	Quantity  float64 `json:"quantity"`
+	Revenue   float64 `json:"revenue"`
+}
+
+// ProductStockLevel is a product's current stock with its category, the
+// starting point for stock-out estimates
+type ProductStockLevel struct {
+	ProductID    uint    `json:"product_id"`
+	ProductName  string  `json:"product_name"`
+	SKU          string  `json:"sku"`
+	CategoryID   uint    `json:"category_id"`
+	CategoryName string  `json:"category_name"`
+	Price        float64 `json:"price"`
+	Stock        float64 `json:"stock"`
 }
 
 // ReportRepository defines the contract for report data access
@@ -48,13 +70,30 @@ type ReportRepository interface {
 	GetSalesSummary(ctx context.Context, startDate, endDate time.Time) (*SalesSummary, error)
 	GetSalesByHour(ctx context.Context, startDate, endDate time.Time) ([]HourlySales, error)
 	GetStockValue(ctx context.Context) (*StockValue, error)
+	// GetDailyProductSales returns completed sales per product per day; days
+	// without sales are left out
+	GetDailyProductSales(ctx context.Context, startDate, endDate time.Time) ([]DailyProductSales, error)
+	GetProductStockLevels(ctx context.Context) ([]ProductStockLevel, error)
+	// GetAccountBalances sums the journal lines posted to each account from
+	// startDate (from the first entry when nil) to the end of endDate's day
+	GetAccountBalances(ctx context.Context, startDate *time.Time, endDate time.Time) ([]AccountBalance, error)
+}
+
+// AccountBalance is what has been posted to one account over a period
+type AccountBalance struct {
+	AccountID uint    `json:"account_id"`
+	Code      string  `json:"code"`
+	Name      string  `json:"name"`
+	Type      string  `json:"type"`
+	Debit     float64 `json:"debit"`
+	Credit    float64 `json:"credit"`
 }
 
 // SalesSummary represents the summary of sales for a period
 type SalesSummary struct {
 	TotalSales        float64 `json:"total_sales"`
 	TotalTransactions int64   `json:"total_transactions"`
-	TotalItemsSold    int64   `json:"total_items_sold"`
+	TotalItemsSold    float64 `json:"total_items_sold"`
 	AveragePerDay     float64 `json:"average_per_day"`
 	GrossProfit       float64 `json:"gross_profit"`
 	ProfitMargin      float64 `json:"profit_margin"` // percentage
@@ -89,7 +128,7 @@ func (r *reportRepository) GetSalesReport(ctx context.Context, startDate, endDat
 
 	// Get items sold per day
 	for i := range reports {
-		var itemsSold int64
+		var itemsSold float64
 		dateStr := reports[i].Date
 		r.db.WithContext(ctx).Table("transaction_details").
 			Joins("JOIN transactions ON transactions.id = transaction_details.transaction_id").
@@ -150,7 +189,7 @@ func (r *reportRepository) GetSalesSummary(ctx context.Context, startDate, endDa
 	}
 
 	// Get total items sold
-	var itemsSold int64
+	var itemsSold float64
 	r.db.WithContext(ctx).Table("transaction_details").
 		Joins("JOIN transactions ON transactions.id = transaction_details.transaction_id").
 		Where("transactions.created_at >= ? AND transactions.created_at < ?", startDate, endDate.Add(24*time.Hour)).
@@ -200,21 +239,113 @@ func (r *reportRepository) GetSalesByHour(ctx context.Context, startDate, endDat
 	return hourly, nil
 }
 
-// GetStockValue calculates the total inventory value
+// GetStockValue calculates the total inventory value. Under the average
+// method stock is valued at the moving average cost; under FIFO at the cost
+// of the layers still in stock, with any stock no layer accounts for at the
+// average cost.
 func (r *reportRepository) GetStockValue(ctx context.Context) (*StockValue, error) {
 	var sv StockValue
 
-	err := r.db.WithContext(ctx).Table("products").
-		Select(`
-			COUNT(*) as total_products,
-			COALESCE(SUM(stock), 0) as total_units,
-			COALESCE(SUM(stock * cost), 0) as total_value,
-			COALESCE(SUM(stock * price), 0) as total_retail
-		`).
-		Scan(&sv).Error
+	db := r.db.WithContext(ctx)
+	method, err := CostingMethod(db)
 	if err != nil {
 		return nil, err
 	}
 
+	if method == models.CostingFIFO {
+		err = db.Table("products").
+			Select(`
+				COUNT(*) as total_products,
+				COALESCE(SUM(products.stock), 0) as total_units,
+				COALESCE(SUM(COALESCE(layers.value, 0) + GREATEST(products.stock - COALESCE(layers.remaining, 0), 0) * products.cost), 0) as total_value,
+				COALESCE(SUM(products.stock * products.price), 0) as total_retail
+			`).
+			Joins(`LEFT JOIN (
+				SELECT product_id, SUM(remaining * unit_cost) as value, SUM(remaining) as remaining
+				FROM cost_layers WHERE remaining > 0 GROUP BY product_id
+			) layers ON layers.product_id = products.id`).
+			Scan(&sv).Error
+	} else {
+		err = db.Table("products").
+			Select(`
+				COUNT(*) as total_products,
+				COALESCE(SUM(stock), 0) as total_units,
+				COALESCE(SUM(stock * cost), 0) as total_value,
+				COALESCE(SUM(stock * price), 0) as total_retail
+			`).
+			Scan(&sv).Error
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sv.TotalValue = roundAmount(sv.TotalValue)
+	sv.CostingMethod = method
 	return &sv, nil
 }
+
+// GetDailyProductSales retrieves quantity and revenue per product per day for a date range
+func (r *reportRepository) GetDailyProductSales(ctx context.Context, startDate, endDate time.Time) ([]DailyProductSales, error) {
+	var sales []DailyProductSales
+
+	err := r.db.WithContext(ctx).Table("transaction_details").
+		Select(`
+			TO_CHAR(DATE(transactions.created_at), 'YYYY-MM-DD') as date,
+			transaction_details.product_id,
+			SUM(transaction_details.quantity) as quantity,
+			SUM(transaction_details.sub_total) as revenue
+		`).
+		Joins("JOIN transactions ON transactions.id = transaction_details.transaction_id").
+		Where("transactions.status = ? AND transactions.deleted_at IS NULL", "completed").
+		Where("transactions.created_at >= ? AND transactions.created_at < ?", startDate, endDate.Add(24*time.Hour)).
+		Group("DATE(transactions.created_at), transaction_details.product_id").
+		Order("date ASC").
+		Scan(&sales).Error
+
+	return sales, err
+}
+
+// GetProductStockLevels retrieves the current stock of every active product
+func (r *reportRepository) GetProductStockLevels(ctx context.Context) ([]ProductStockLevel, error) {
+	var levels []ProductStockLevel
+
+	err := r.db.WithContext(ctx).Model(&models.Product{}).
+		Select(`
+			products.id as product_id,
+			products.name as product_name,
+			products.sku,
+			products.category_id,
+			COALESCE(categories.name, 'Uncategorized') as category_name,
+			products.price,
+			products.stock
+		`).
+		Joins("LEFT JOIN categories ON categories.id = products.category_id").
+		Order("products.name ASC").
+		Scan(&levels).Error
+
+	return levels, err
+}
+
+// GetAccountBalances sums the journal lines of each account for a date range
+func (r *reportRepository) GetAccountBalances(ctx context.Context, startDate *time.Time, endDate time.Time) ([]AccountBalance, error) {
+	var balances []AccountBalance
+	query := r.db.WithContext(ctx).Table("journal_lines").
+		Joins("JOIN journal_entries ON journal_entries.id = journal_lines.journal_entry_id").
+		Joins("JOIN accounts ON accounts.id = journal_lines.account_id").
+		Where("journal_entries.date < ?", endDate.Add(24*time.Hour))
+	if startDate != nil {
+		query = query.Where("journal_entries.date >= ?", *startDate)
+	}
+	err := query.Select(`
+			accounts.id as account_id,
+			accounts.code,
+			accounts.name,
+			accounts.type,
+			COALESCE(SUM(journal_lines.debit), 0) as debit,
+			COALESCE(SUM(journal_lines.credit), 0) as credit
+		`).
+		Group("accounts.id, accounts.code, accounts.name, accounts.type").
+		Order("accounts.code ASC").
+		Scan(&balances).Error
+	return balances, err
+}
@@ -0,0 +1,145 @@
+package repositories
+
+import (
+	"fmt"
+	"math"
+	"pos-api/internal/models"
+	"time"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ReservedStock returns the units of a product held at a location by active,
+// unexpired reservations inside tx, leaving out the reservation excluded
+// (the one a sale is fulfilling) when it is not nil.
+func ReservedStock(tx *gorm.DB, productID, locationID uint, exclude *uint) (float64, error) {
+	query := tx.Model(&models.StockReservationItem{}).
+		Joins("JOIN stock_reservations ON stock_reservations.id = stock_reservation_items.reservation_id").
+		Where("stock_reservation_items.product_id = ? AND stock_reservations.location_id = ?", productID, locationID).
+		Where("stock_reservations.status = ? AND stock_reservations.expires_at > ?", models.ReservationActive, time.Now())
+	if exclude != nil {
+		query = query.Where("stock_reservations.id <> ?", *exclude)
+	}
+
+	var reserved float64
+	err := query.Select("COALESCE(SUM(stock_reservation_items.quantity), 0)").Scan(&reserved).Error
+	return roundLot(reserved), err
+}
+
+// NegativeStockPolicy returns how a sale of product may go beyond its stock:
+// the product's own policy, else the store's. Serialized products always
+// block since every unit sold needs its serial number in stock.
+func NegativeStockPolicy(tx *gorm.DB, product *models.Product) (string, error) {
+	if product.Serialized {
+		return models.NegativeStockBlock, nil
+	}
+	if product.NegativeStockPolicy != "" {
+		return product.NegativeStockPolicy, nil
+	}
+
+	var setting models.StoreSetting
+	if err := tx.Select("id", "negative_stock_policy").Limit(1).Find(&setting).Error; err != nil {
+		return "", err
+	}
+	switch setting.NegativeStockPolicy {
+	case models.NegativeStockWarn, models.NegativeStockAllow:
+		return setting.NegativeStockPolicy, nil
+	}
+	return models.NegativeStockBlock, nil
+}
+
+// TakeSaleStock takes quantity units of a product sold at a location inside
+// tx. Units held by other reservations are never sold; past that, selling more
+// than the stock on record follows the product's negative stock policy. It
+// returns the location's stock before and after, the units sold beyond the
+// stock on record, and the policy that allowed them.
+func TakeSaleStock(tx *gorm.DB, product *models.Product, locationID uint, quantity float64, reservationID *uint) (before, after, shortfall float64, policy string, err error) {
+	level, err := lockLocationStock(tx, product.ID, locationID)
+	if err != nil {
+		return 0, 0, 0, "", err
+	}
+	reserved, err := ReservedStock(tx, product.ID, locationID, reservationID)
+	if err != nil {
+		return 0, 0, 0, "", err
+	}
+	if reserved > 0 && quantity > roundLot(level.Quantity-reserved) {
+		return level.Quantity, 0, 0, "", fmt.Errorf("%w at location %d: %g of %g units are reserved for held orders",
+			customErrors.ErrInsufficientStock, locationID, reserved, level.Quantity)
+	}
+
+	policy, err = NegativeStockPolicy(tx, product)
+	if err != nil {
+		return 0, 0, 0, "", err
+	}
+	before, after, err = applyLocationStock(tx, product.ID, locationID, -quantity, policy != models.NegativeStockBlock)
+	if err != nil {
+		return before, after, 0, policy, err
+	}
+	shortfall = roundLot(math.Min(quantity, math.Max(0, -after)))
+	return before, after, shortfall, policy, nil
+}
+
+// ReserveStock holds the reservation's items at its location inside tx. Every
+// item must fit in the stock not already held by other reservations; a
+// reservation never takes stock below zero whatever the negative stock policy.
+func ReserveStock(tx *gorm.DB, reservation *models.StockReservation) error {
+	keys := make([]StockKey, 0, len(reservation.Items))
+	for _, item := range reservation.Items {
+		keys = append(keys, StockKey{ProductID: item.ProductID, LocationID: reservation.LocationID})
+	}
+	if err := LockStock(tx, keys...); err != nil {
+		return err
+	}
+
+	for _, item := range reservation.Items {
+		level, err := lockLocationStock(tx, item.ProductID, reservation.LocationID)
+		if err != nil {
+			return err
+		}
+		reserved, err := ReservedStock(tx, item.ProductID, reservation.LocationID, nil)
+		if err != nil {
+			return err
+		}
+		if free := roundLot(level.Quantity - reserved); item.Quantity > free {
+			return fmt.Errorf("%w: product %d has %g units free at location %d, need %g",
+				customErrors.ErrInsufficientStock, item.ProductID, math.Max(free, 0), reservation.LocationID, item.Quantity)
+		}
+	}
+	return tx.Omit("Location", "User", "Items.Product").Create(reservation).Error
+}
+
+// FulfilReservation marks a reservation as fulfilled by a sale at locationID
+// inside tx. Only active, unexpired reservations at the sale's location can be
+// fulfilled, and only by a sale of every reserved product in at least its
+// reserved quantity, so a sale cannot release stock it does not take.
+func FulfilReservation(tx *gorm.DB, reservationID, locationID uint, transaction *models.Transaction) error {
+	var reservation models.StockReservation
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Items").First(&reservation, reservationID).Error; err != nil {
+		return fmt.Errorf("reservation %d not found: %w", reservationID, err)
+	}
+	if !reservation.IsHolding(time.Now()) {
+		return fmt.Errorf("%w: reservation %s is %s or expired", customErrors.ErrConflict, reservation.Code, reservation.Status)
+	}
+	if reservation.LocationID != locationID {
+		return fmt.Errorf("%w: reservation %s holds stock at another location", customErrors.ErrConflict, reservation.Code)
+	}
+
+	sold := make(map[uint]float64, len(transaction.TransactionDetails))
+	for _, detail := range transaction.TransactionDetails {
+		sold[detail.ProductID] = roundLot(sold[detail.ProductID] + detail.Quantity)
+	}
+	for _, item := range reservation.Items {
+		if sold[item.ProductID] < item.Quantity {
+			return fmt.Errorf("%w: reservation %s holds %g of product %d, the sale has %g",
+				customErrors.ErrConflict, reservation.Code, item.Quantity, item.ProductID, sold[item.ProductID])
+		}
+	}
+
+	return tx.Model(&reservation).Updates(map[string]interface{}{
+		"status":         models.ReservationFulfilled,
+		"transaction_id": transaction.ID,
+	}).Error
+}
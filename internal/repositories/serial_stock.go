@@ -0,0 +1,125 @@
+package repositories
+
+import (
+	"fmt"
+	"pos-api/internal/models"
+	"time"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ReceiveSerials puts the serial numbers on a stock-in log into stock at the
+// log's location inside tx. A unit that left stock before (sold or removed)
+// can come back in; one still in stock cannot be received twice.
+func ReceiveSerials(tx *gorm.DB, log *models.InventoryLog) error {
+	now := time.Now()
+	for _, serial := range log.SerialNumbers {
+		var unit models.ProductSerial
+		if err := lockSerial(tx, log.ProductID, serial, &unit); err != nil {
+			return err
+		}
+		if unit.ID != 0 && unit.Status == models.SerialInStock {
+			return fmt.Errorf("%w: serial %s is already in stock", customErrors.ErrConflict, serial)
+		}
+
+		unit.ProductID = log.ProductID
+		unit.SerialNumber = serial
+		unit.LocationID = log.LocationID
+		unit.Status = models.SerialInStock
+		unit.ReceivedAt = now
+		unit.ReceivedLogID = &log.ID
+		unit.PurchaseOrderID = log.PurchaseOrderID
+		unit.TransactionID = nil
+		unit.SoldAt = nil
+		if err := tx.Omit("Product", "Location", "PurchaseOrder", "Transaction").Save(&unit).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TakeSerials takes serial numbers out of stock at a location inside tx,
+// leaving them with status (sold or removed). Sold units record the
+// transaction they left with.
+func TakeSerials(tx *gorm.DB, productID, locationID uint, serials []string, status string, transactionID *uint) error {
+	now := time.Now()
+	for _, serial := range serials {
+		var unit models.ProductSerial
+		if err := lockInStockSerial(tx, productID, locationID, serial, &unit); err != nil {
+			return err
+		}
+
+		updates := map[string]interface{}{"status": status}
+		if status == models.SerialSold {
+			updates["transaction_id"] = transactionID
+			updates["sold_at"] = now
+		}
+		if err := tx.Model(&unit).Updates(updates).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MoveSerials moves units in stock at one location to another inside tx.
+func MoveSerials(tx *gorm.DB, productID, fromLocationID, toLocationID uint, serials []string) error {
+	for _, serial := range serials {
+		var unit models.ProductSerial
+		if err := lockInStockSerial(tx, productID, fromLocationID, serial, &unit); err != nil {
+			return err
+		}
+		if err := tx.Model(&unit).Update("location_id", toLocationID).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RestockSerials puts units sold in a transaction back into stock at a
+// location inside tx, e.g. when the sale is returned or cancelled.
+func RestockSerials(tx *gorm.DB, productID, locationID, transactionID uint, serials []string) error {
+	for _, serial := range serials {
+		var unit models.ProductSerial
+		if err := lockSerial(tx, productID, serial, &unit); err != nil {
+			return err
+		}
+		if unit.ID == 0 || unit.Status != models.SerialSold || unit.TransactionID == nil || *unit.TransactionID != transactionID {
+			return fmt.Errorf("%w: serial %s was not sold in this transaction", customErrors.ErrConflict, serial)
+		}
+		if err := tx.Model(&unit).Updates(map[string]interface{}{
+			"status":         models.SerialInStock,
+			"location_id":    locationID,
+			"transaction_id": nil,
+			"sold_at":        nil,
+		}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lockSerial loads and locks a product's serial; unit is left zero when the
+// serial was never seen.
+func lockSerial(tx *gorm.DB, productID uint, serial string, unit *models.ProductSerial) error {
+	return tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("product_id = ? AND serial_number = ?", productID, serial).
+		Limit(1).Find(unit).Error
+}
+
+func lockInStockSerial(tx *gorm.DB, productID, locationID uint, serial string, unit *models.ProductSerial) error {
+	if err := lockSerial(tx, productID, serial, unit); err != nil {
+		return err
+	}
+	switch {
+	case unit.ID == 0:
+		return fmt.Errorf("%w: serial %s", customErrors.ErrNotFound, serial)
+	case unit.Status != models.SerialInStock:
+		return fmt.Errorf("%w: serial %s is %s", customErrors.ErrConflict, serial, unit.Status)
+	case unit.LocationID != locationID:
+		return fmt.Errorf("%w: serial %s is at location %d", customErrors.ErrConflict, serial, unit.LocationID)
+	}
+	return nil
+}
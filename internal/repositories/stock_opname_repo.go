@@ -0,0 +1,346 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"pos-api/internal/models"
+	"pos-api/internal/pkg/events"
+	"time"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type StockOpnameRepository interface {
+	// Create saves the session and freezes the current stock at the session's
+	// location (the default one when unset) and the cost of every product in
+	// scope (the session's category, or all products) as its items.
+	// It returns gorm.ErrRecordNotFound when there is nothing to count.
+	Create(ctx context.Context, opname *models.StockOpname) error
+	GetByID(ctx context.Context, id uint) (*models.StockOpname, error)
+	GetAll(ctx context.Context, limit, offset int, status string) ([]models.StockOpname, int64, error)
+	GetItem(ctx context.Context, opnameID, productID uint) (*models.StockOpnameItem, error)
+	// AddCount stores a count and refreshes the item's counted total.
+	AddCount(ctx context.Context, count *models.StockOpnameCount) error
+	// DeleteCount removes a mistaken count and refreshes the item's counted total.
+	DeleteCount(ctx context.Context, opnameID, countID uint) error
+	UpdateStatus(ctx context.Context, id uint, status string) error
+	// Approve posts every counted variance as an 'adjustment' InventoryLog and
+	// marks the session approved, all in one DB transaction.
+	Approve(ctx context.Context, id, userID uint) ([]models.InventoryLog, error)
+}
+
+type stockOpnameRepository struct {
+	DB       *gorm.DB
+	EventBus events.EventBus
+}
+
+func NewStockOpnameRepository(db *gorm.DB, eventBus events.EventBus) StockOpnameRepository {
+	return &stockOpnameRepository{
+		DB:       db,
+		EventBus: eventBus,
+	}
+}
+
+func (r *stockOpnameRepository) Create(ctx context.Context, opname *models.StockOpname) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if opname.LocationID == 0 {
+			locationID, err := DefaultLocationID(tx)
+			if err != nil {
+				return err
+			}
+			opname.LocationID = locationID
+		}
+
+		// Products never stocked at the location are expected to be at zero
+		var products []struct {
+			ID    uint
+			Stock float64
+			Cost  float64
+		}
+		query := tx.Model(&models.Product{}).
+			Select("products.id, COALESCE(product_stocks.quantity, 0) AS stock, products.cost").
+			Joins("LEFT JOIN product_stocks ON product_stocks.product_id = products.id AND product_stocks.location_id = ?", opname.LocationID)
+		if opname.CategoryID != nil {
+			query = query.Where("products.category_id = ?", *opname.CategoryID)
+		}
+		if err := query.Order("products.id ASC").Scan(&products).Error; err != nil {
+			return err
+		}
+		if len(products) == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		if err := tx.Omit("Items", "Category", "User", "Location").Create(opname).Error; err != nil {
+			return err
+		}
+
+		items := make([]models.StockOpnameItem, len(products))
+		for i, p := range products {
+			items[i] = models.StockOpnameItem{
+				StockOpnameID: opname.ID,
+				ProductID:     p.ID,
+				ExpectedStock: p.Stock,
+				CostPrice:     p.Cost,
+			}
+		}
+		return tx.Omit("Product").CreateInBatches(&items, 500).Error
+	})
+}
+
+func (r *stockOpnameRepository) GetByID(ctx context.Context, id uint) (*models.StockOpname, error) {
+	var opname models.StockOpname
+	err := r.DB.WithContext(ctx).
+		Preload("Category").
+		Preload("Location").
+		Preload("User").
+		Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Preload("Items.Product").
+		Preload("Items.Counts", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Preload("Items.Counts.User").
+		First(&opname, id).Error
+	return &opname, err
+}
+
+func (r *stockOpnameRepository) GetAll(ctx context.Context, limit, offset int, status string) ([]models.StockOpname, int64, error) {
+	var sessions []models.StockOpname
+	var total int64
+
+	query := r.DB.WithContext(ctx).Model(&models.StockOpname{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("created_at DESC").
+		Limit(limit).Offset(offset).
+		Preload("Category").
+		Preload("Location").
+		Preload("User").
+		Find(&sessions).Error
+	return sessions, total, err
+}
+
+func (r *stockOpnameRepository) GetItem(ctx context.Context, opnameID, productID uint) (*models.StockOpnameItem, error) {
+	var item models.StockOpnameItem
+	err := r.DB.WithContext(ctx).
+		Preload("Product").
+		Where("stock_opname_id = ? AND product_id = ?", opnameID, productID).
+		First(&item).Error
+	return &item, err
+}
+
+// lockOpenSession locks the session row and checks it is still open. Counts
+// take a SHARE lock and approval/cancellation an UPDATE lock, so a session
+// cannot be closed while a count is being written.
+func lockOpenSession(tx *gorm.DB, id uint, strength string) (*models.StockOpname, error) {
+	var opname models.StockOpname
+	if err := tx.Clauses(clause.Locking{Strength: strength}).First(&opname, id).Error; err != nil {
+		return nil, err
+	}
+	if opname.Status != models.StockOpnameOpen {
+		return nil, fmt.Errorf("%w: stock opname %s is %s", customErrors.ErrConflict, opname.Code, opname.Status)
+	}
+	return &opname, nil
+}
+
+// refreshCounted recalculates the item's counted total from its counts (NULL when none are left).
+func refreshCounted(tx *gorm.DB, itemID uint) error {
+	return tx.Exec(
+		"UPDATE stock_opname_items SET counted_quantity = (SELECT SUM(quantity) FROM stock_opname_counts WHERE stock_opname_item_id = ?) WHERE id = ?",
+		itemID, itemID,
+	).Error
+}
+
+func (r *stockOpnameRepository) AddCount(ctx context.Context, count *models.StockOpnameCount) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if _, err := lockOpenSession(tx, count.StockOpnameID, "SHARE"); err != nil {
+			return err
+		}
+		if err := tx.Omit("User").Create(count).Error; err != nil {
+			return err
+		}
+		return refreshCounted(tx, count.StockOpnameItemID)
+	})
+}
+
+func (r *stockOpnameRepository) DeleteCount(ctx context.Context, opnameID, countID uint) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if _, err := lockOpenSession(tx, opnameID, "SHARE"); err != nil {
+			return err
+		}
+
+		var count models.StockOpnameCount
+		if err := tx.Where("stock_opname_id = ?", opnameID).First(&count, countID).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&count).Error; err != nil {
+			return err
+		}
+		return refreshCounted(tx, count.StockOpnameItemID)
+	})
+}
+
+func (r *stockOpnameRepository) UpdateStatus(ctx context.Context, id uint, status string) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if _, err := lockOpenSession(tx, id, "UPDATE"); err != nil {
+			return err
+		}
+		return tx.Model(&models.StockOpname{}).Where("id = ?", id).Update("status", status).Error
+	})
+}
+
+func (r *stockOpnameRepository) Approve(ctx context.Context, id, userID uint) ([]models.InventoryLog, error) {
+	var logs []models.InventoryLog
+
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		opname, err := lockOpenSession(tx, id, "UPDATE")
+		if err != nil {
+			return err
+		}
+
+		var items []models.StockOpnameItem
+		if err := tx.Where("stock_opname_id = ? AND counted_quantity IS NOT NULL", id).
+			Order("product_id ASC").
+			Find(&items).Error; err != nil {
+			return err
+		}
+
+		keys := make([]StockKey, 0, len(items))
+		for _, item := range items {
+			if item.Variance() != 0 {
+				keys = append(keys, StockKey{ProductID: item.ProductID, LocationID: opname.LocationID})
+			}
+		}
+		if err := LockStock(tx, keys...); err != nil {
+			return err
+		}
+
+		for _, item := range items {
+			variance := item.Variance()
+			if variance == 0 {
+				continue
+			}
+
+			var serials []string
+			if variance < 0 {
+				if serials, err = missingSerials(tx, item); err != nil {
+					return err
+				}
+			}
+
+			// The variance is applied to the current stock, so sales made while
+			// counting are kept instead of being overwritten by the count
+			stockBefore, stockAfter, err := ApplyLocationStock(tx, item.ProductID, opname.LocationID, variance)
+			if err != nil {
+				return fmt.Errorf("product %d: %w", item.ProductID, err)
+			}
+
+			// Missing stock comes out of the lots first-expiry-first-out and is
+			// valued by the costing method
+			var lots []models.InventoryLogLot
+			costPrice := item.CostPrice
+			if variance < 0 {
+				if lots, err = ConsumeLots(tx, item.ProductID, opname.LocationID, -variance, stockBefore); err != nil {
+					return err
+				}
+				if costPrice, err = ConsumeCost(tx, item.ProductID, -variance); err != nil {
+					return err
+				}
+			}
+
+			log := models.InventoryLog{
+				ProductID:     item.ProductID,
+				LocationID:    opname.LocationID,
+				Type:          "adjustment",
+				Source:        "opname",
+				Quantity:      variance,
+				CostPrice:     costPrice,
+				TotalCost:     roundAmount(math.Abs(variance) * costPrice),
+				StockBefore:   stockBefore,
+				StockAfter:    stockAfter,
+				Notes:         "Stock opname " + opname.Code,
+				UserID:        userID,
+				StockOpnameID: &opname.ID,
+				SerialNumbers: serials,
+			}
+			if err := tx.Create(&log).Error; err != nil {
+				return err
+			}
+			if err := LinkLots(tx, &log, lots); err != nil {
+				return err
+			}
+			if len(serials) > 0 {
+				if err := TakeSerials(tx, item.ProductID, opname.LocationID, serials, models.SerialRemoved, nil); err != nil {
+					return err
+				}
+			}
+			// Found stock comes in at the cost frozen with the count
+			if variance > 0 {
+				if _, err := ReceiveCost(tx, item.ProductID, &log.ID, variance, costPrice); err != nil {
+					return err
+				}
+			}
+
+			if err := r.EventBus.Publish(ctx, events.EventInventoryAdjusted, events.InventoryAdjustedPayload{
+				TX:           tx,
+				InventoryLog: &log,
+				UserID:       userID,
+			}); err != nil {
+				return err
+			}
+
+			logs = append(logs, log)
+		}
+
+		now := time.Now()
+		return tx.Model(&models.StockOpname{}).Where("id = ?", id).Updates(map[string]interface{}{
+			"status":      models.StockOpnameApproved,
+			"approved_by": userID,
+			"approved_at": now,
+		}).Error
+	})
+
+	return logs, err
+}
+
+// missingSerials returns the serial numbers the counts of a short item list
+// as missing. A serialized product needs exactly one per missing unit, so its
+// serials keep agreeing with its stock; other products need none.
+func missingSerials(tx *gorm.DB, item models.StockOpnameItem) ([]string, error) {
+	var serialized bool
+	if err := tx.Unscoped().Model(&models.Product{}).Select("serialized").
+		Where("id = ?", item.ProductID).Scan(&serialized).Error; err != nil {
+		return nil, err
+	}
+	if !serialized {
+		return nil, nil
+	}
+
+	var counts []models.StockOpnameCount
+	if err := tx.Where("stock_opname_item_id = ?", item.ID).Order("id ASC").Find(&counts).Error; err != nil {
+		return nil, err
+	}
+	var serials []string
+	seen := make(map[string]bool)
+	for _, count := range counts {
+		for _, serial := range count.MissingSerials {
+			if !seen[serial] {
+				seen[serial] = true
+				serials = append(serials, serial)
+			}
+		}
+	}
+
+	if short := -item.Variance(); float64(len(serials)) != short {
+		return nil, fmt.Errorf("%w: product %d is %g short but its counts list %d missing serial numbers; list one per missing unit",
+			customErrors.ErrConflict, item.ProductID, short, len(serials))
+	}
+	return serials, nil
+}
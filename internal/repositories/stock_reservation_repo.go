@@ -0,0 +1,98 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"pos-api/internal/models"
+	"time"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Reservation list filter for active reservations whose time ran out
+const ReservationExpired = "expired"
+
+type StockReservationRepository interface {
+	// Create holds the reservation's items at its location in one DB
+	// transaction, refusing items that do not fit in the unreserved stock.
+	Create(ctx context.Context, reservation *models.StockReservation) error
+	GetByID(ctx context.Context, id uint) (*models.StockReservation, error)
+	// GetAll lists reservations; status "active" only matches reservations
+	// still holding stock and "expired" active ones whose time ran out.
+	GetAll(ctx context.Context, limit, offset int, locationID uint, status string) ([]models.StockReservation, int64, error)
+	// Release stops an active (or expired) reservation holding stock.
+	Release(ctx context.Context, id uint) error
+}
+
+type stockReservationRepository struct {
+	DB *gorm.DB
+}
+
+func NewStockReservationRepository(db *gorm.DB) StockReservationRepository {
+	return &stockReservationRepository{DB: db}
+}
+
+func (r *stockReservationRepository) Create(ctx context.Context, reservation *models.StockReservation) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return ReserveStock(tx, reservation)
+	})
+}
+
+func (r *stockReservationRepository) GetByID(ctx context.Context, id uint) (*models.StockReservation, error) {
+	var reservation models.StockReservation
+	err := r.DB.WithContext(ctx).
+		Preload("Location").
+		Preload("User").
+		Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Preload("Items.Product").
+		First(&reservation, id).Error
+	return &reservation, err
+}
+
+func (r *stockReservationRepository) GetAll(ctx context.Context, limit, offset int, locationID uint, status string) ([]models.StockReservation, int64, error) {
+	var reservations []models.StockReservation
+	var total int64
+
+	query := r.DB.WithContext(ctx).Model(&models.StockReservation{})
+	if locationID != 0 {
+		query = query.Where("location_id = ?", locationID)
+	}
+	now := time.Now()
+	switch status {
+	case "":
+	case models.ReservationActive:
+		query = query.Where("status = ? AND expires_at > ?", models.ReservationActive, now)
+	case ReservationExpired:
+		query = query.Where("status = ? AND expires_at <= ?", models.ReservationActive, now)
+	default:
+		query = query.Where("status = ?", status)
+	}
+
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("created_at DESC").
+		Limit(limit).Offset(offset).
+		Preload("Location").
+		Preload("User").
+		Preload("Items.Product").
+		Find(&reservations).Error
+	return reservations, total, err
+}
+
+func (r *stockReservationRepository) Release(ctx context.Context, id uint) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var reservation models.StockReservation
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&reservation, id).Error; err != nil {
+			return err
+		}
+		if reservation.Status != models.ReservationActive {
+			return fmt.Errorf("%w: reservation %s is already %s", customErrors.ErrConflict, reservation.Code, reservation.Status)
+		}
+		return tx.Model(&reservation).Update("status", models.ReservationReleased).Error
+	})
+}
@@ -0,0 +1,169 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"pos-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type StockTransferRepository interface {
+	// Create saves the transfer and moves every line (with its lots and serial
+	// numbers) out of the source location and into the destination, logging
+	// both legs, all in one DB transaction.
+	Create(ctx context.Context, transfer *models.StockTransfer) error
+	GetByID(ctx context.Context, id uint) (*models.StockTransfer, error)
+	GetAll(ctx context.Context, limit, offset int, locationID uint) ([]models.StockTransfer, int64, error)
+}
+
+type stockTransferRepository struct {
+	DB *gorm.DB
+}
+
+func NewStockTransferRepository(db *gorm.DB) StockTransferRepository {
+	return &stockTransferRepository{DB: db}
+}
+
+func (r *stockTransferRepository) Create(ctx context.Context, transfer *models.StockTransfer) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("FromLocation", "ToLocation", "User", "Items.Product").Create(transfer).Error; err != nil {
+			return err
+		}
+
+		// Lock both ends of every line up front so concurrent transfers in
+		// opposite directions cannot deadlock
+		keys := make([]StockKey, 0, 2*len(transfer.Items))
+		for _, item := range transfer.Items {
+			keys = append(keys,
+				StockKey{ProductID: item.ProductID, LocationID: transfer.FromLocationID},
+				StockKey{ProductID: item.ProductID, LocationID: transfer.ToLocationID})
+		}
+		if err := LockStock(tx, keys...); err != nil {
+			return err
+		}
+
+		notes := "Stock transfer " + transfer.Code
+		for _, item := range transfer.Items {
+			var product models.Product
+			if err := tx.Select("id", "name", "cost").First(&product, item.ProductID).Error; err != nil {
+				return fmt.Errorf("product %d not found: %w", item.ProductID, err)
+			}
+
+			// Total stock does not change, so no event is published: the
+			// cash flow listener must not book a transfer as a purchase
+			legs := []struct {
+				locationID uint
+				logType    string
+				delta      float64
+			}{
+				{transfer.FromLocationID, "out", -item.Quantity},
+				{transfer.ToLocationID, "in", item.Quantity},
+			}
+			if err := MoveSerials(tx, item.ProductID, transfer.FromLocationID, transfer.ToLocationID, item.SerialNumbers); err != nil {
+				return fmt.Errorf("%s: %w", product.Name, err)
+			}
+
+			// Lots leave the source first-expiry-first-out and arrive at the
+			// destination under the same lot number and expiry date
+			var moved []models.InventoryLogLot
+			for _, leg := range legs {
+				stockBefore, stockAfter, err := ApplyLocationStock(tx, item.ProductID, leg.locationID, leg.delta)
+				if err != nil {
+					return fmt.Errorf("%s: %w", product.Name, err)
+				}
+
+				var lots []models.InventoryLogLot
+				if leg.delta < 0 {
+					if moved, err = ConsumeLots(tx, item.ProductID, leg.locationID, item.Quantity, stockBefore); err != nil {
+						return err
+					}
+					lots = moved
+				} else if lots, err = transferLots(tx, moved, leg.locationID); err != nil {
+					return fmt.Errorf("%s: %w", product.Name, err)
+				}
+
+				log := models.InventoryLog{
+					ProductID:       item.ProductID,
+					LocationID:      leg.locationID,
+					Type:            leg.logType,
+					Source:          "transfer",
+					Quantity:        item.Quantity,
+					CostPrice:       product.Cost,
+					TotalCost:       item.Quantity * product.Cost,
+					StockBefore:     stockBefore,
+					StockAfter:      stockAfter,
+					Notes:           notes,
+					UserID:          transfer.UserID,
+					StockTransferID: &transfer.ID,
+					SerialNumbers:   item.SerialNumbers,
+				}
+				if err := tx.Create(&log).Error; err != nil {
+					return err
+				}
+				if err := LinkLots(tx, &log, lots); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// transferLots receives the lots taken at the source into matching lots at locationID.
+func transferLots(tx *gorm.DB, taken []models.InventoryLogLot, locationID uint) ([]models.InventoryLogLot, error) {
+	received := make([]models.InventoryLogLot, 0, len(taken))
+	for _, t := range taken {
+		var source models.ProductLot
+		if err := tx.First(&source, t.ProductLotID).Error; err != nil {
+			return nil, err
+		}
+		lot, err := ReceiveLot(tx, &models.ProductLot{
+			ProductID:  source.ProductID,
+			LocationID: locationID,
+			LotNumber:  source.LotNumber,
+			ExpiryDate: source.ExpiryDate,
+			CostPrice:  source.CostPrice,
+		}, t.Quantity)
+		if err != nil {
+			return nil, err
+		}
+		received = append(received, lot)
+	}
+	return received, nil
+}
+
+func (r *stockTransferRepository) GetByID(ctx context.Context, id uint) (*models.StockTransfer, error) {
+	var transfer models.StockTransfer
+	err := r.DB.WithContext(ctx).
+		Preload("FromLocation").
+		Preload("ToLocation").
+		Preload("User").
+		Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Preload("Items.Product").
+		First(&transfer, id).Error
+	return &transfer, err
+}
+
+func (r *stockTransferRepository) GetAll(ctx context.Context, limit, offset int, locationID uint) ([]models.StockTransfer, int64, error) {
+	var transfers []models.StockTransfer
+	var total int64
+
+	query := r.DB.WithContext(ctx).Model(&models.StockTransfer{})
+	if locationID != 0 {
+		query = query.Where("from_location_id = ? OR to_location_id = ?", locationID, locationID)
+	}
+
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("created_at DESC").
+		Limit(limit).Offset(offset).
+		Preload("FromLocation").
+		Preload("ToLocation").
+		Preload("User").
+		Preload("Items.Product").
+		Find(&transfers).Error
+	return transfers, total, err
+}
@@ -10,6 +10,9 @@ import (
 type StoreSettingRepository interface {
 	GetSettings(ctx context.Context) (*models.StoreSetting, error)
 	UpsertSettings(ctx context.Context, settings *models.StoreSetting) (*models.StoreSetting, error)
+	// HasActivity reports whether the store has recorded any transaction or
+	// journal entry yet, deleted ones included
+	HasActivity(ctx context.Context) (bool, error)
 }
 
 type storeSettingRepository struct {
@@ -27,10 +30,14 @@ func (r *storeSettingRepository) GetSettings(ctx context.Context) (*models.Store
 		if err == gorm.ErrRecordNotFound {
 			// Return default settings
 			return &models.StoreSetting{
-				StoreName:  "My Store",
-				Address:    "",
-				Phone:      "",
-				FooterText: "Thank you for your purchase!",
+				StoreName:     "My Store",
+				Address:       "",
+				Phone:         "",
+				FooterText:    "Thank you for your purchase!",
+				CostingMethod: models.CostingAverage,
+
+				NegativeStockPolicy: models.NegativeStockBlock,
+				BaseCurrency:        "IDR",
 			}, nil
 		}
 		return nil, err
@@ -44,6 +51,15 @@ func (r *storeSettingRepository) UpsertSettings(ctx context.Context, settings *m
 
 	if err == gorm.ErrRecordNotFound {
 		// Create
+		if settings.CostingMethod == "" {
+			settings.CostingMethod = models.CostingAverage
+		}
+		if settings.NegativeStockPolicy == "" {
+			settings.NegativeStockPolicy = models.NegativeStockBlock
+		}
+		if settings.BaseCurrency == "" {
+			settings.BaseCurrency = "IDR"
+		}
 		if err := r.db.WithContext(ctx).Create(settings).Error; err != nil {
 			return nil, err
 		}
@@ -57,9 +73,33 @@ func (r *storeSettingRepository) UpsertSettings(ctx context.Context, settings *m
 	existing.Address = settings.Address
 	existing.Phone = settings.Phone
 	existing.FooterText = settings.FooterText
+	if settings.CostingMethod != "" {
+		existing.CostingMethod = settings.CostingMethod
+	}
+	if settings.NegativeStockPolicy != "" {
+		existing.NegativeStockPolicy = settings.NegativeStockPolicy
+	}
+	if settings.BaseCurrency != "" {
+		existing.BaseCurrency = settings.BaseCurrency
+	}
 
 	if err := r.db.WithContext(ctx).Save(&existing).Error; err != nil {
 		return nil, err
 	}
 	return &existing, nil
 }
+
+func (r *storeSettingRepository) HasActivity(ctx context.Context) (bool, error) {
+	var transactions, entries int64
+	db := r.db.WithContext(ctx)
+	if err := db.Unscoped().Model(&models.Transaction{}).Limit(1).Count(&transactions).Error; err != nil {
+		return false, err
+	}
+	if transactions > 0 {
+		return true, nil
+	}
+	if err := db.Model(&models.JournalEntry{}).Limit(1).Count(&entries).Error; err != nil {
+		return false, err
+	}
+	return entries > 0, nil
+}
@@ -0,0 +1,127 @@
+package repositories
+
+import (
+	"context"
+	"math"
+	"pos-api/internal/models"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SupplierPayableRepository interface {
+	GetByID(ctx context.Context, id uint) (*models.SupplierPayable, error)
+	GetAll(ctx context.Context, limit, offset int, supplierID uint, status string) ([]models.SupplierPayable, int64, error)
+	// GetOutstanding returns every payable that is not fully paid, optionally for a single supplier.
+	GetOutstanding(ctx context.Context, supplierID uint) ([]models.SupplierPayable, error)
+	// RecordPayment posts the payment to the ledger, creates its cash flow
+	// expense and the payment and updates the payable's paid amount and status
+	// in a single DB transaction.
+	RecordPayment(ctx context.Context, payment *models.SupplierPayment, cashFlow *models.CashFlow) error
+}
+
+type supplierPayableRepository struct {
+	DB *gorm.DB
+}
+
+func NewSupplierPayableRepository(db *gorm.DB) SupplierPayableRepository {
+	return &supplierPayableRepository{DB: db}
+}
+
+func (r *supplierPayableRepository) GetByID(ctx context.Context, id uint) (*models.SupplierPayable, error) {
+	var payable models.SupplierPayable
+	err := r.DB.WithContext(ctx).
+		Preload("Supplier").
+		Preload("PurchaseOrder").
+		Preload("Payments", func(db *gorm.DB) *gorm.DB { return db.Order("date ASC, id ASC") }).
+		First(&payable, id).Error
+	return &payable, err
+}
+
+func (r *supplierPayableRepository) GetAll(ctx context.Context, limit, offset int, supplierID uint, status string) ([]models.SupplierPayable, int64, error) {
+	var payables []models.SupplierPayable
+	var total int64
+
+	query := r.DB.WithContext(ctx).Model(&models.SupplierPayable{})
+
+	if supplierID != 0 {
+		query = query.Where("supplier_id = ?", supplierID)
+	}
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("due_date ASC, id ASC").
+		Limit(limit).Offset(offset).
+		Preload("Supplier").
+		Preload("PurchaseOrder").
+		Find(&payables).Error
+	return payables, total, err
+}
+
+func (r *supplierPayableRepository) GetOutstanding(ctx context.Context, supplierID uint) ([]models.SupplierPayable, error) {
+	var payables []models.SupplierPayable
+
+	query := r.DB.WithContext(ctx).Where("status != ?", models.PayablePaid)
+	if supplierID != 0 {
+		query = query.Where("supplier_id = ?", supplierID)
+	}
+
+	err := query.Order("due_date ASC, id ASC").Preload("Supplier").Find(&payables).Error
+	return payables, err
+}
+
+func (r *supplierPayableRepository) RecordPayment(ctx context.Context, payment *models.SupplierPayment, cashFlow *models.CashFlow) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Lock the payable so concurrent payments cannot overpay it
+		var payable models.SupplierPayable
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&payable, payment.PayableID).Error; err != nil {
+			return err
+		}
+
+		paid := roundAmount(payable.PaidAmount + payment.Amount)
+		if paid > payable.Amount {
+			return customErrors.ErrOverpayment
+		}
+
+		status := models.PayablePartial
+		if paid == payable.Amount {
+			status = models.PayablePaid
+		}
+
+		// Paying the supplier settles the payable out of cash
+		cash, err := SystemAccount(tx, models.AccountCodeCash)
+		if err != nil {
+			return err
+		}
+		payables, err := SystemAccount(tx, models.AccountCodePayables)
+		if err != nil {
+			return err
+		}
+		entry := CashFlowEntry(cashFlow, cash, payables, models.JournalSupplierPayment)
+		if err := RecordCashFlow(tx, cashFlow, entry); err != nil {
+			return err
+		}
+
+		payment.CashFlowID = cashFlow.ID
+		if err := tx.Create(payment).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&payable).Updates(map[string]interface{}{
+			"paid_amount": paid,
+			"status":      status,
+		}).Error
+	})
+}
+
+// roundAmount rounds a money amount to 2 decimals so repeated partial payments settle exactly.
+func roundAmount(v float64) float64 {
+	return math.Round(v*100) / 100
+}
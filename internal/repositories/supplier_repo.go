@@ -0,0 +1,64 @@
+package repositories
+
+import (
+	"context"
+	"pos-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type SupplierRepository interface {
+	Create(ctx context.Context, supplier *models.Supplier) error
+	Update(ctx context.Context, supplier *models.Supplier) error
+	Delete(ctx context.Context, id uint) error
+	GetByID(ctx context.Context, id uint) (*models.Supplier, error)
+	GetAll(ctx context.Context, limit, offset int, search string, onlyActive bool) ([]models.Supplier, int64, error)
+}
+
+type supplierRepository struct {
+	DB *gorm.DB
+}
+
+func NewSupplierRepository(db *gorm.DB) SupplierRepository {
+	return &supplierRepository{DB: db}
+}
+
+func (r *supplierRepository) Create(ctx context.Context, supplier *models.Supplier) error {
+	return r.DB.WithContext(ctx).Create(supplier).Error
+}
+
+func (r *supplierRepository) Update(ctx context.Context, supplier *models.Supplier) error {
+	return r.DB.WithContext(ctx).Save(supplier).Error
+}
+
+func (r *supplierRepository) Delete(ctx context.Context, id uint) error {
+	return r.DB.WithContext(ctx).Delete(&models.Supplier{}, id).Error
+}
+
+func (r *supplierRepository) GetByID(ctx context.Context, id uint) (*models.Supplier, error) {
+	var supplier models.Supplier
+	err := r.DB.WithContext(ctx).First(&supplier, id).Error
+	return &supplier, err
+}
+
+func (r *supplierRepository) GetAll(ctx context.Context, limit, offset int, search string, onlyActive bool) ([]models.Supplier, int64, error) {
+	var suppliers []models.Supplier
+	var total int64
+
+	query := r.DB.WithContext(ctx).Model(&models.Supplier{})
+
+	if search != "" {
+		searchTerm := "%" + search + "%"
+		query = query.Where("name ILIKE ? OR contact_name ILIKE ? OR phone ILIKE ?", searchTerm, searchTerm, searchTerm)
+	}
+	if onlyActive {
+		query = query.Where("is_active = ?", true)
+	}
+
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("name ASC").Limit(limit).Offset(offset).Find(&suppliers).Error
+	return suppliers, total, err
+}
@@ -34,7 +34,7 @@ func (r *transactionRepository) GetTransactionByID(ctx context.Context, id uint)
 	var transaction models.Transaction
 
 	// Gunakan Preload untuk mengambil relasi TransactionDetails dan Product di dalamnya
-	result := r.DB.WithContext(ctx).Preload("TransactionDetails").Preload("TransactionDetails.Product").First(&transaction, id)
+	result := r.DB.WithContext(ctx).Preload("TransactionDetails").Preload("TransactionDetails.Product").Preload("Payments").First(&transaction, id)
 
 	if result.Error != nil {
 		return nil, result.Error
@@ -70,6 +70,7 @@ func (r *transactionRepository) ListTransactions(ctx context.Context, page int,
 	result := query.WithContext(ctx).
 		Preload("TransactionDetails").
 		Preload("TransactionDetails.Product").
+		Preload("Payments").
 		Order("created_at DESC"). // Best practice to show newest first
 		Limit(limit).
 		Offset(offset).
@@ -143,6 +144,15 @@ func (r *transactionRepository) UpdateTransactionState(ctx context.Context, tran
 		}
 	}()
 
+	// A cancelled sale is voided, which a closed period no longer allows;
+	// returns are refunds of the current period, whenever the sale was made
+	if status == "cancelled" {
+		if err := EnsurePeriodOpen(tx, transaction.CreatedAt); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
 	// 1. Update status
 	if err := tx.Model(transaction).Update("status", status).Error; err != nil {
 		tx.Rollback()
@@ -22,6 +22,26 @@ func ProtectedRoutes(
 	inventoryLogHandler *handlers.InventoryLogHandler,
 	cashFlowHandler *handlers.CashFlowHandler,
 	paymentMethodHandler *handlers.PaymentMethodHandler,
+	supplierHandler *handlers.SupplierHandler,
+	purchaseOrderHandler *handlers.PurchaseOrderHandler,
+	supplierPayableHandler *handlers.SupplierPayableHandler,
+	stockOpnameHandler *handlers.StockOpnameHandler,
+	locationHandler *handlers.LocationHandler,
+	stockTransferHandler *handlers.StockTransferHandler,
+	productLotHandler *handlers.ProductLotHandler,
+	productSerialHandler *handlers.ProductSerialHandler,
+	forecastHandler *handlers.ForecastHandler,
+	stockReservationHandler *handlers.StockReservationHandler,
+	inventoryIntegrityHandler *handlers.InventoryIntegrityHandler,
+	accountingHandler *handlers.AccountingHandler,
+	recurringExpenseHandler *handlers.RecurringExpenseHandler,
+	cashFlowAttachmentHandler *handlers.AttachmentHandler,
+	inventoryLogAttachmentHandler *handlers.AttachmentHandler,
+	accountingPeriodHandler *handlers.AccountingPeriodHandler,
+	reconciliationHandler *handlers.ReconciliationHandler,
+	budgetHandler *handlers.BudgetHandler,
+	journalExportHandler *handlers.JournalExportHandler,
+	currencyHandler *handlers.CurrencyHandler,
 ) {
 	// Middleware JWT digunakan untuk semua route di bawah ini
 	jwtMiddleware := middlewares.JWTMiddleware()
@@ -38,9 +58,12 @@ func ProtectedRoutes(
 
 	// --- REPORTS Routes --- (Admin/Manager)
 	reportGroup := router.Group("/reports", jwtMiddleware, adminManager)
-	reportGroup.Get("/sales", reportHandler.GetSalesReport)      // GET /api/v1/reports/sales
-	reportGroup.Get("/products", reportHandler.GetProductReport) // GET /api/v1/reports/products
-	reportGroup.Get("/stock-value", reportHandler.GetStockValue) // GET /api/v1/reports/stock-value
+	reportGroup.Get("/sales", reportHandler.GetSalesReport)          // GET /api/v1/reports/sales
+	reportGroup.Get("/products", reportHandler.GetProductReport)     // GET /api/v1/reports/products
+	reportGroup.Get("/stock-value", reportHandler.GetStockValue)     // GET /api/v1/reports/stock-value
+	reportGroup.Get("/forecast", forecastHandler.GetForecast)        // GET /api/v1/reports/forecast?days=14&history_days=56
+	reportGroup.Get("/profit-loss", reportHandler.GetProfitAndLoss)  // GET /api/v1/reports/profit-loss?month=2026-02&compare=previous
+	reportGroup.Get("/balance-sheet", reportHandler.GetBalanceSheet) // GET /api/v1/reports/balance-sheet?date=2026-02-28&compare_date=2026-01-31
 
 	// --- STORE SETTINGS Routes ---
 	storeSettingsGroup := router.Group("/store-settings", jwtMiddleware)
@@ -51,8 +74,10 @@ func ProtectedRoutes(
 	// READ: All authenticated users can view products (cashiers need this for POS)
 	productGroup := router.Group("/products", jwtMiddleware, allRoles)
 	productGroup.Get("/", productHandler.ListProducts)
-	productGroup.Get("/low-stock", productHandler.GetLowStockProducts) // GET /api/v1/products/low-stock
-	productGroup.Get("/stock-counts", productHandler.GetStockCounts)   // GET /api/v1/products/stock-counts
+	productGroup.Get("/low-stock", productHandler.GetLowStockProducts)                           // GET /api/v1/products/low-stock
+	productGroup.Get("/stock-counts", productHandler.GetStockCounts)                             // GET /api/v1/products/stock-counts
+	productGroup.Get("/reorder-suggestions", adminManager, productHandler.GetReorderSuggestions) // GET /api/v1/products/reorder-suggestions
+	productGroup.Get("/scan/:code", productHandler.ScanBarcode)                                  // GET /api/v1/products/scan/:code
 	productGroup.Get("/:id", productHandler.GetProduct)
 
 	// WRITE: Only Admin/Manager can create, update, delete products
@@ -100,24 +125,88 @@ func ProtectedRoutes(
 
 	// --- BARCODE Routes --- (All authenticated roles)
 	barcodeGroup := router.Group("/barcode", jwtMiddleware, allRoles)
-	barcodeGroup.Get("/:id", barcodeHandler.GenerateBarcode)          // GET /api/v1/barcode/:id
-	barcodeGroup.Post("/batch", barcodeHandler.GenerateBatchBarcodes) // POST /api/v1/barcode/batch
+	barcodeGroup.Get("/:id", barcodeHandler.GenerateBarcode)             // GET /api/v1/barcode/:id
+	barcodeGroup.Post("/batch", barcodeHandler.GenerateBatchBarcodes)    // POST /api/v1/barcode/batch
+	barcodeGroup.Get("/labels/layouts", barcodeHandler.ListLabelLayouts) // GET /api/v1/barcode/labels/layouts
+	barcodeGroup.Post("/labels", barcodeHandler.GenerateLabelSheet)      // POST /api/v1/barcode/labels
 
 	// --- INVENTORY LOG Routes --- (Admin/Manager)
 	inventoryGroup := router.Group("/inventory", jwtMiddleware, adminManager)
-	inventoryGroup.Get("/stats", inventoryLogHandler.GetInventoryStats)      // GET /api/v1/inventory/stats
-	inventoryGroup.Get("/", inventoryLogHandler.GetAllLogs)                  // GET /api/v1/inventory
-	inventoryGroup.Post("/", inventoryLogHandler.AdjustStock)                // POST /api/v1/inventory
-	inventoryGroup.Get("/product/:id", inventoryLogHandler.GetLogsByProduct) // GET /api/v1/inventory/product/:id
+	inventoryGroup.Get("/stats", inventoryLogHandler.GetInventoryStats)                                     // GET /api/v1/inventory/stats
+	inventoryGroup.Get("/", inventoryLogHandler.GetAllLogs)                                                 // GET /api/v1/inventory
+	inventoryGroup.Post("/", inventoryLogHandler.AdjustStock)                                               // POST /api/v1/inventory
+	inventoryGroup.Get("/product/:id", inventoryLogHandler.GetLogsByProduct)                                // GET /api/v1/inventory/product/:id
+	inventoryGroup.Get("/shortfalls", inventoryLogHandler.GetShortfalls)                                    // GET /api/v1/inventory/shortfalls?status=open|all
+	inventoryGroup.Post("/shortfalls/:id/review", inventoryLogHandler.ReviewShortfall)                      // POST /api/v1/inventory/shortfalls/:id/review
+	inventoryGroup.Get("/integrity", inventoryIntegrityHandler.Check)                                       // GET /api/v1/inventory/integrity?product_id=
+	inventoryGroup.Post("/integrity/fix", adminOnly, inventoryIntegrityHandler.Fix)                         // POST /api/v1/inventory/integrity/fix?product_id= (admin only)
+	inventoryGroup.Get("/:id/attachments", inventoryLogAttachmentHandler.ListAttachments)                   // GET /api/v1/inventory/:id/attachments
+	inventoryGroup.Post("/:id/attachments", inventoryLogAttachmentHandler.UploadAttachment)                 // POST /api/v1/inventory/:id/attachments (multipart "file")
+	inventoryGroup.Get("/:id/attachments/:attachmentId", inventoryLogAttachmentHandler.DownloadAttachment)  // GET /api/v1/inventory/:id/attachments/:attachmentId
+	inventoryGroup.Delete("/:id/attachments/:attachmentId", inventoryLogAttachmentHandler.DeleteAttachment) // DELETE /api/v1/inventory/:id/attachments/:attachmentId
 
 	// --- CASH FLOW Routes --- (Admin/Manager)
 	cashFlowGroup := router.Group("/cash-flow", jwtMiddleware, adminManager)
-	cashFlowGroup.Get("/", cashFlowHandler.ListCashFlows)        // GET /api/v1/cash-flow
-	cashFlowGroup.Post("/", cashFlowHandler.CreateCashFlow)      // POST /api/v1/cash-flow
-	cashFlowGroup.Get("/summary", cashFlowHandler.GetSummary)    // GET /api/v1/cash-flow/summary
-	cashFlowGroup.Get("/:id", cashFlowHandler.GetCashFlow)       // GET /api/v1/cash-flow/:id
-	cashFlowGroup.Put("/:id", cashFlowHandler.UpdateCashFlow)    // PUT /api/v1/cash-flow/:id
-	cashFlowGroup.Delete("/:id", cashFlowHandler.DeleteCashFlow) // DELETE /api/v1/cash-flow/:id
+	cashFlowGroup.Get("/", cashFlowHandler.ListCashFlows)                                              // GET /api/v1/cash-flow
+	cashFlowGroup.Post("/", cashFlowHandler.CreateCashFlow)                                            // POST /api/v1/cash-flow
+	cashFlowGroup.Get("/summary", cashFlowHandler.GetSummary)                                          // GET /api/v1/cash-flow/summary
+	cashFlowGroup.Get("/categories", cashFlowHandler.ListCategories)                                   // GET /api/v1/cash-flow/categories?type=&active=true
+	cashFlowGroup.Post("/categories", adminOnly, cashFlowHandler.CreateCategory)                       // POST /api/v1/cash-flow/categories (admin only)
+	cashFlowGroup.Get("/categories/:id", cashFlowHandler.GetCategory)                                  // GET /api/v1/cash-flow/categories/:id
+	cashFlowGroup.Put("/categories/:id", adminOnly, cashFlowHandler.UpdateCategory)                    // PUT /api/v1/cash-flow/categories/:id (admin only)
+	cashFlowGroup.Delete("/categories/:id", adminOnly, cashFlowHandler.DeleteCategory)                 // DELETE /api/v1/cash-flow/categories/:id (admin only)
+	cashFlowGroup.Get("/recurring", recurringExpenseHandler.ListRecurringExpenses)                     // GET /api/v1/cash-flow/recurring?active=true
+	cashFlowGroup.Post("/recurring", recurringExpenseHandler.CreateRecurringExpense)                   // POST /api/v1/cash-flow/recurring
+	cashFlowGroup.Get("/recurring/upcoming", recurringExpenseHandler.GetUpcoming)                      // GET /api/v1/cash-flow/recurring/upcoming?days=30
+	cashFlowGroup.Post("/recurring/run", recurringExpenseHandler.RunDue)                               // POST /api/v1/cash-flow/recurring/run
+	cashFlowGroup.Get("/recurring/:id", recurringExpenseHandler.GetRecurringExpense)                   // GET /api/v1/cash-flow/recurring/:id
+	cashFlowGroup.Put("/recurring/:id", recurringExpenseHandler.UpdateRecurringExpense)                // PUT /api/v1/cash-flow/recurring/:id
+	cashFlowGroup.Delete("/recurring/:id", recurringExpenseHandler.DeleteRecurringExpense)             // DELETE /api/v1/cash-flow/recurring/:id
+	cashFlowGroup.Get("/budgets", budgetHandler.ListBudgets)                                           // GET /api/v1/cash-flow/budgets?month=2026-03
+	cashFlowGroup.Post("/budgets", budgetHandler.CreateBudget)                                         // POST /api/v1/cash-flow/budgets
+	cashFlowGroup.Get("/budgets/report", budgetHandler.GetReport)                                      // GET /api/v1/cash-flow/budgets/report?month=2026-03
+	cashFlowGroup.Get("/budgets/:id", budgetHandler.GetBudget)                                         // GET /api/v1/cash-flow/budgets/:id
+	cashFlowGroup.Put("/budgets/:id", budgetHandler.UpdateBudget)                                      // PUT /api/v1/cash-flow/budgets/:id
+	cashFlowGroup.Delete("/budgets/:id", budgetHandler.DeleteBudget)                                   // DELETE /api/v1/cash-flow/budgets/:id
+	cashFlowGroup.Get("/:id", cashFlowHandler.GetCashFlow)                                             // GET /api/v1/cash-flow/:id
+	cashFlowGroup.Put("/:id", cashFlowHandler.UpdateCashFlow)                                          // PUT /api/v1/cash-flow/:id
+	cashFlowGroup.Delete("/:id", cashFlowHandler.DeleteCashFlow)                                       // DELETE /api/v1/cash-flow/:id
+	cashFlowGroup.Delete("/:id/force", adminOnly, cashFlowHandler.ForceDeleteCashFlow)                 // DELETE /api/v1/cash-flow/:id/force (admin only)
+	cashFlowGroup.Get("/:id/attachments", cashFlowAttachmentHandler.ListAttachments)                   // GET /api/v1/cash-flow/:id/attachments
+	cashFlowGroup.Post("/:id/attachments", cashFlowAttachmentHandler.UploadAttachment)                 // POST /api/v1/cash-flow/:id/attachments (multipart "file")
+	cashFlowGroup.Get("/:id/attachments/:attachmentId", cashFlowAttachmentHandler.DownloadAttachment)  // GET /api/v1/cash-flow/:id/attachments/:attachmentId
+	cashFlowGroup.Delete("/:id/attachments/:attachmentId", cashFlowAttachmentHandler.DeleteAttachment) // DELETE /api/v1/cash-flow/:id/attachments/:attachmentId
+
+	// --- ACCOUNTING Routes --- (Admin/Manager; entries are posted by sales, stock and the cash book, never through here)
+	accountingGroup := router.Group("/accounting", jwtMiddleware, adminManager)
+	accountingGroup.Get("/accounts", accountingHandler.GetAccounts)                                 // GET /api/v1/accounting/accounts
+	accountingGroup.Get("/journal", accountingHandler.GetJournal)                                   // GET /api/v1/accounting/journal?source=&account_id=&start_date=&end_date=
+	accountingGroup.Get("/journal/:id", accountingHandler.GetJournalEntry)                          // GET /api/v1/accounting/journal/:id
+	accountingGroup.Get("/trial-balance", accountingHandler.GetTrialBalance)                        // GET /api/v1/accounting/trial-balance?date=YYYY-MM-DD
+	accountingGroup.Get("/periods", accountingPeriodHandler.ListPeriods)                            // GET /api/v1/accounting/periods
+	accountingGroup.Get("/periods/logs", accountingPeriodHandler.GetPeriodLogs)                     // GET /api/v1/accounting/periods/logs?month=YYYY-MM
+	accountingGroup.Post("/periods/:month/close", adminOnly, accountingPeriodHandler.ClosePeriod)   // POST /api/v1/accounting/periods/2026-01/close (admin only)
+	accountingGroup.Post("/periods/:month/reopen", adminOnly, accountingPeriodHandler.ReopenPeriod) // POST /api/v1/accounting/periods/2026-01/reopen (admin only)
+	accountingGroup.Get("/export-templates", journalExportHandler.ListTemplates)                    // GET /api/v1/accounting/export-templates
+	accountingGroup.Post("/export-templates", journalExportHandler.CreateTemplate)                  // POST /api/v1/accounting/export-templates
+	accountingGroup.Get("/export-templates/:id", journalExportHandler.GetTemplate)                  // GET /api/v1/accounting/export-templates/:id
+	accountingGroup.Put("/export-templates/:id", journalExportHandler.UpdateTemplate)               // PUT /api/v1/accounting/export-templates/:id
+	accountingGroup.Delete("/export-templates/:id", journalExportHandler.DeleteTemplate)            // DELETE /api/v1/accounting/export-templates/:id
+	accountingGroup.Get("/exports", journalExportHandler.ListExports)                               // GET /api/v1/accounting/exports
+	accountingGroup.Post("/exports", journalExportHandler.CreateExport)                             // POST /api/v1/accounting/exports
+	accountingGroup.Get("/exports/:id", journalExportHandler.GetExport)                             // GET /api/v1/accounting/exports/:id
+	accountingGroup.Get("/exports/:id/download", journalExportHandler.DownloadExport)               // GET /api/v1/accounting/exports/:id/download (CSV)
+	accountingGroup.Delete("/exports/:id", adminOnly, journalExportHandler.DeleteExport)            // DELETE /api/v1/accounting/exports/:id (admin only)
+
+	// --- RECONCILIATION Routes --- (Admin/Manager)
+	reconciliationGroup := router.Group("/reconciliation", jwtMiddleware, adminManager)
+	reconciliationGroup.Post("/statements", reconciliationHandler.ImportStatement)    // POST /api/v1/reconciliation/statements (multipart: file, payment_method_id, mapping, settlement_days)
+	reconciliationGroup.Get("/statements", reconciliationHandler.ListStatements)      // GET /api/v1/reconciliation/statements?payment_method_id=
+	reconciliationGroup.Get("/statements/:id", reconciliationHandler.GetStatement)    // GET /api/v1/reconciliation/statements/:id
+	reconciliationGroup.Get("/unmatched", reconciliationHandler.GetUnmatched)         // GET /api/v1/reconciliation/unmatched?payment_method_id=&start_date=&end_date=
+	reconciliationGroup.Post("/lines/:id/match", reconciliationHandler.MatchLine)     // POST /api/v1/reconciliation/lines/:id/match
+	reconciliationGroup.Post("/lines/:id/unmatch", reconciliationHandler.UnmatchLine) // POST /api/v1/reconciliation/lines/:id/unmatch
+	reconciliationGroup.Post("/lines/:id/ignore", reconciliationHandler.IgnoreLine)   // POST /api/v1/reconciliation/lines/:id/ignore
 
 	// --- PAYMENT METHOD Routes ---
 	paymentMethodGroup := router.Group("/payment-methods", jwtMiddleware)
@@ -126,4 +215,102 @@ func ProtectedRoutes(
 	paymentMethodGroup.Post("/", adminManager, paymentMethodHandler.CreatePaymentMethod)      // POST /api/v1/payment-methods
 	paymentMethodGroup.Put("/:id", adminManager, paymentMethodHandler.UpdatePaymentMethod)    // PUT /api/v1/payment-methods/:id
 	paymentMethodGroup.Delete("/:id", adminOnly, paymentMethodHandler.DeletePaymentMethod)    // DELETE /api/v1/payment-methods/:id
+
+	// --- CURRENCY Routes ---
+	// Reading: All roles (cashiers take foreign cash at the current rate), managing: Admin/Manager
+	currencyGroup := router.Group("/currencies", jwtMiddleware)
+	currencyGroup.Get("/", allRoles, currencyHandler.List)                         // GET /api/v1/currencies?active=true
+	currencyGroup.Post("/", adminManager, currencyHandler.Create)                  // POST /api/v1/currencies
+	currencyGroup.Post("/rates/import", adminManager, currencyHandler.ImportRates) // POST /api/v1/currencies/rates/import (multipart CSV)
+	currencyGroup.Get("/receipts", adminManager, currencyHandler.Receipts)         // GET /api/v1/currencies/receipts?start_date=&end_date=
+	currencyGroup.Get("/:id", allRoles, currencyHandler.Get)                       // GET /api/v1/currencies/:id
+	currencyGroup.Put("/:id", adminManager, currencyHandler.Update)                // PUT /api/v1/currencies/:id
+	currencyGroup.Post("/:id/rate", adminManager, currencyHandler.SetRate)         // POST /api/v1/currencies/:id/rate
+	currencyGroup.Get("/:id/rates", adminManager, currencyHandler.RateHistory)     // GET /api/v1/currencies/:id/rates
+
+	// --- SUPPLIER Routes --- (Admin/Manager)
+	supplierGroup := router.Group("/suppliers", jwtMiddleware, adminManager)
+	supplierGroup.Get("/", supplierHandler.ListSuppliers)        // GET /api/v1/suppliers
+	supplierGroup.Post("/", supplierHandler.CreateSupplier)      // POST /api/v1/suppliers
+	supplierGroup.Get("/:id", supplierHandler.GetSupplier)       // GET /api/v1/suppliers/:id
+	supplierGroup.Put("/:id", supplierHandler.UpdateSupplier)    // PUT /api/v1/suppliers/:id
+	supplierGroup.Delete("/:id", supplierHandler.DeleteSupplier) // DELETE /api/v1/suppliers/:id
+
+	// --- PURCHASE ORDER Routes --- (Admin/Manager)
+	purchaseOrderGroup := router.Group("/purchase-orders", jwtMiddleware, adminManager)
+	purchaseOrderGroup.Get("/", purchaseOrderHandler.ListPurchaseOrders)               // GET /api/v1/purchase-orders
+	purchaseOrderGroup.Post("/", purchaseOrderHandler.CreatePurchaseOrder)             // POST /api/v1/purchase-orders
+	purchaseOrderGroup.Get("/:id", purchaseOrderHandler.GetPurchaseOrder)              // GET /api/v1/purchase-orders/:id
+	purchaseOrderGroup.Put("/:id", purchaseOrderHandler.UpdatePurchaseOrder)           // PUT /api/v1/purchase-orders/:id (draft only)
+	purchaseOrderGroup.Delete("/:id", purchaseOrderHandler.DeletePurchaseOrder)        // DELETE /api/v1/purchase-orders/:id (draft only)
+	purchaseOrderGroup.Post("/:id/send", purchaseOrderHandler.SendPurchaseOrder)       // POST /api/v1/purchase-orders/:id/send
+	purchaseOrderGroup.Post("/:id/cancel", purchaseOrderHandler.CancelPurchaseOrder)   // POST /api/v1/purchase-orders/:id/cancel
+	purchaseOrderGroup.Post("/:id/receive", purchaseOrderHandler.ReceivePurchaseOrder) // POST /api/v1/purchase-orders/:id/receive
+
+	// --- SUPPLIER PAYABLE Routes --- (Admin/Manager)
+	payableGroup := router.Group("/payables", jwtMiddleware, adminManager)
+	payableGroup.Get("/", supplierPayableHandler.ListPayables)               // GET /api/v1/payables
+	payableGroup.Get("/aging", supplierPayableHandler.GetAging)              // GET /api/v1/payables/aging
+	payableGroup.Get("/:id", supplierPayableHandler.GetPayable)              // GET /api/v1/payables/:id
+	payableGroup.Post("/:id/payments", supplierPayableHandler.RecordPayment) // POST /api/v1/payables/:id/payments
+
+	// --- STOCK OPNAME Routes ---
+	// Counting: All roles (any staff member can count), managing sessions: Admin/Manager.
+	// Session details show expected stock, so counters only get the list (blind count).
+	stockOpnameGroup := router.Group("/stock-opnames", jwtMiddleware)
+	stockOpnameGroup.Get("/", allRoles, stockOpnameHandler.ListStockOpnames)                   // GET /api/v1/stock-opnames
+	stockOpnameGroup.Post("/", adminManager, stockOpnameHandler.CreateStockOpname)             // POST /api/v1/stock-opnames
+	stockOpnameGroup.Get("/:id", adminManager, stockOpnameHandler.GetStockOpname)              // GET /api/v1/stock-opnames/:id
+	stockOpnameGroup.Post("/:id/counts", allRoles, stockOpnameHandler.SubmitCount)             // POST /api/v1/stock-opnames/:id/counts
+	stockOpnameGroup.Delete("/:id/counts/:countId", allRoles, stockOpnameHandler.DeleteCount)  // DELETE /api/v1/stock-opnames/:id/counts/:countId
+	stockOpnameGroup.Get("/:id/variances", adminManager, stockOpnameHandler.GetVariances)      // GET /api/v1/stock-opnames/:id/variances
+	stockOpnameGroup.Post("/:id/approve", adminManager, stockOpnameHandler.ApproveStockOpname) // POST /api/v1/stock-opnames/:id/approve
+	stockOpnameGroup.Post("/:id/cancel", adminManager, stockOpnameHandler.CancelStockOpname)   // POST /api/v1/stock-opnames/:id/cancel
+
+	// --- LOCATION Routes ---
+	// Reading: All roles (cashiers pick their register), managing: Admin/Manager
+	locationGroup := router.Group("/locations", jwtMiddleware)
+	locationGroup.Get("/", allRoles, locationHandler.ListLocations)                      // GET /api/v1/locations
+	locationGroup.Get("/stock-levels", adminManager, locationHandler.GetStockLevels)     // GET /api/v1/locations/stock-levels?location_id=&product_id=
+	locationGroup.Post("/", adminManager, locationHandler.CreateLocation)                // POST /api/v1/locations
+	locationGroup.Get("/:id", allRoles, locationHandler.GetLocation)                     // GET /api/v1/locations/:id
+	locationGroup.Put("/:id", adminManager, locationHandler.UpdateLocation)              // PUT /api/v1/locations/:id
+	locationGroup.Delete("/:id", adminManager, locationHandler.DeleteLocation)           // DELETE /api/v1/locations/:id
+	locationGroup.Post("/:id/default", adminManager, locationHandler.SetDefaultLocation) // POST /api/v1/locations/:id/default
+
+	// --- REGISTER Routes ---
+	registerGroup := router.Group("/registers", jwtMiddleware)
+	registerGroup.Get("/", allRoles, locationHandler.ListRegisters)            // GET /api/v1/registers?location_id=
+	registerGroup.Post("/", adminManager, locationHandler.CreateRegister)      // POST /api/v1/registers
+	registerGroup.Get("/:id", allRoles, locationHandler.GetRegister)           // GET /api/v1/registers/:id
+	registerGroup.Put("/:id", adminManager, locationHandler.UpdateRegister)    // PUT /api/v1/registers/:id
+	registerGroup.Delete("/:id", adminManager, locationHandler.DeleteRegister) // DELETE /api/v1/registers/:id
+
+	// --- STOCK TRANSFER Routes --- (Admin/Manager)
+	stockTransferGroup := router.Group("/stock-transfers", jwtMiddleware, adminManager)
+	stockTransferGroup.Get("/", stockTransferHandler.ListStockTransfers)   // GET /api/v1/stock-transfers?location_id=
+	stockTransferGroup.Post("/", stockTransferHandler.CreateStockTransfer) // POST /api/v1/stock-transfers
+	stockTransferGroup.Get("/:id", stockTransferHandler.GetStockTransfer)  // GET /api/v1/stock-transfers/:id
+
+	// --- LOT Routes --- (Admin/Manager)
+	lotGroup := router.Group("/lots", jwtMiddleware, adminManager)
+	lotGroup.Get("/", productLotHandler.ListLots)                          // GET /api/v1/lots?product_id=&location_id=
+	lotGroup.Get("/expiring", productLotHandler.GetExpiring)               // GET /api/v1/lots/expiring?days=30&location_id=
+	lotGroup.Post("/write-off-expired", productLotHandler.WriteOffExpired) // POST /api/v1/lots/write-off-expired?location_id=
+	lotGroup.Get("/:id", productLotHandler.GetLot)                         // GET /api/v1/lots/:id
+	lotGroup.Post("/:id/write-off", productLotHandler.WriteOffLot)         // POST /api/v1/lots/:id/write-off
+
+	// --- SERIAL NUMBER Routes ---
+	serialGroup := router.Group("/serials", jwtMiddleware)
+	serialGroup.Get("/lookup/:serial", allRoles, productSerialHandler.LookupSerial) // GET /api/v1/serials/lookup/:serial (klaim garansi)
+	serialGroup.Get("/", adminManager, productSerialHandler.ListSerials)            // GET /api/v1/serials?product_id=&status=
+	serialGroup.Post("/", adminManager, productSerialHandler.RegisterSerials)       // POST /api/v1/serials
+
+	// --- STOCK RESERVATION Routes ---
+	// Held orders from any channel, including the register, hold stock
+	reservationGroup := router.Group("/reservations", jwtMiddleware, allRoles)
+	reservationGroup.Get("/", stockReservationHandler.ListReservations)               // GET /api/v1/reservations?location_id=&status=active|expired|released|fulfilled
+	reservationGroup.Post("/", stockReservationHandler.CreateReservation)             // POST /api/v1/reservations
+	reservationGroup.Get("/:id", stockReservationHandler.GetReservation)              // GET /api/v1/reservations/:id
+	reservationGroup.Post("/:id/release", stockReservationHandler.ReleaseReservation) // POST /api/v1/reservations/:id/release
 }
@@ -0,0 +1,80 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"pos-api/internal/models"
+	"pos-api/internal/repositories"
+)
+
+type AccountingPeriodService interface {
+	// Close locks a month ("2026-01") that has ended: nothing dated in it can
+	// be posted, changed, deleted or cancelled any more.
+	Close(ctx context.Context, month string, now time.Time, userID uint, reason string) (*models.AccountingPeriod, error)
+	// Reopen unlocks a closed month. The reason is required; it is kept in
+	// the period's log with who reopened it.
+	Reopen(ctx context.Context, month string, userID uint, reason string) (*models.AccountingPeriod, error)
+	GetAll(ctx context.Context) ([]models.AccountingPeriod, error)
+	// GetLogs lists the closes and reopens, of one month when month is set.
+	GetLogs(ctx context.Context, month string) ([]models.AccountingPeriodLog, error)
+}
+
+type accountingPeriodService struct {
+	repo repositories.AccountingPeriodRepository
+}
+
+func NewAccountingPeriodService(repo repositories.AccountingPeriodRepository) AccountingPeriodService {
+	return &accountingPeriodService{repo: repo}
+}
+
+func (s *accountingPeriodService) Close(ctx context.Context, month string, now time.Time, userID uint, reason string) (*models.AccountingPeriod, error) {
+	period, err := parsePeriod(month)
+	if err != nil {
+		return nil, err
+	}
+	// Today's sales must still post, so only months that are over can close
+	if !period.Before(repositories.PeriodStart(now)) {
+		return nil, fmt.Errorf("%s has not ended yet and cannot be closed", period.Format("January 2006"))
+	}
+	return s.repo.Close(ctx, period, userID, strings.TrimSpace(reason))
+}
+
+func (s *accountingPeriodService) Reopen(ctx context.Context, month string, userID uint, reason string) (*models.AccountingPeriod, error) {
+	period, err := parsePeriod(month)
+	if err != nil {
+		return nil, err
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, errors.New("a reason is required to reopen a period")
+	}
+	return s.repo.Reopen(ctx, period, userID, reason)
+}
+
+func (s *accountingPeriodService) GetAll(ctx context.Context) ([]models.AccountingPeriod, error) {
+	return s.repo.GetAll(ctx)
+}
+
+func (s *accountingPeriodService) GetLogs(ctx context.Context, month string) ([]models.AccountingPeriodLog, error) {
+	if month == "" {
+		return s.repo.GetLogs(ctx, nil)
+	}
+	period, err := parsePeriod(month)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.GetLogs(ctx, &period)
+}
+
+// parsePeriod parses a "2026-01" month into its first day.
+func parsePeriod(month string) (time.Time, error) {
+	period, err := time.Parse("2006-01", month)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid month format, use YYYY-MM: %w", err)
+	}
+	return period, nil
+}
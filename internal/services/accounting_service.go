@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"pos-api/internal/models"
+	"pos-api/internal/repositories"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"gorm.io/gorm"
+)
+
+// TrialBalanceLine is one account's balance, shown on the side it falls on.
+type TrialBalanceLine struct {
+	AccountID uint    `json:"account_id"`
+	Code      string  `json:"code"`
+	Name      string  `json:"name"`
+	Type      string  `json:"type"`
+	Debit     float64 `json:"debit"`
+	Credit    float64 `json:"credit"`
+}
+
+// TrialBalance lists every account's balance as of a date. Since every entry
+// is balanced, the debit and credit columns must add up to the same total.
+type TrialBalance struct {
+	AsOf        time.Time          `json:"as_of"`
+	Lines       []TrialBalanceLine `json:"lines"`
+	TotalDebit  float64            `json:"total_debit"`
+	TotalCredit float64            `json:"total_credit"`
+	Balanced    bool               `json:"balanced"`
+}
+
+type AccountingService interface {
+	GetAccounts(ctx context.Context) ([]models.Account, error)
+	GetJournal(ctx context.Context, page, pageSize int, source string, accountID uint, startDate, endDate *time.Time) ([]models.JournalEntry, int64, error)
+	GetJournalEntry(ctx context.Context, id uint) (*models.JournalEntry, error)
+	// GetTrialBalance balances every account over the entries dated up to
+	// the end of asOf's day.
+	GetTrialBalance(ctx context.Context, asOf time.Time) (*TrialBalance, error)
+}
+
+type accountingService struct {
+	repo repositories.AccountingRepository
+}
+
+func NewAccountingService(repo repositories.AccountingRepository) AccountingService {
+	return &accountingService{repo: repo}
+}
+
+func (s *accountingService) GetAccounts(ctx context.Context) ([]models.Account, error) {
+	return s.repo.GetAccounts(ctx)
+}
+
+func (s *accountingService) GetJournal(ctx context.Context, page, pageSize int, source string, accountID uint, startDate, endDate *time.Time) ([]models.JournalEntry, int64, error) {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+	return s.repo.GetEntries(ctx, pageSize, (page-1)*pageSize, source, accountID, startDate, endDate)
+}
+
+func (s *accountingService) GetJournalEntry(ctx context.Context, id uint) (*models.JournalEntry, error) {
+	entry, err := s.repo.GetEntryByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customErrors.ErrNotFound
+		}
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (s *accountingService) GetTrialBalance(ctx context.Context, asOf time.Time) (*TrialBalance, error) {
+	asOf = time.Date(asOf.Year(), asOf.Month(), asOf.Day(), 23, 59, 59, 0, asOf.Location())
+
+	accounts, err := s.repo.GetAccounts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+	totals, err := s.repo.GetAccountTotals(ctx, &asOf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account totals: %w", err)
+	}
+	byAccount := make(map[uint]repositories.AccountTotal, len(totals))
+	for _, t := range totals {
+		byAccount[t.AccountID] = t
+	}
+
+	balance := &TrialBalance{AsOf: asOf, Lines: []TrialBalanceLine{}}
+	for _, account := range accounts {
+		t, ok := byAccount[account.ID]
+		if !ok {
+			continue
+		}
+		line := TrialBalanceLine{AccountID: account.ID, Code: account.Code, Name: account.Name, Type: account.Type}
+		if net := roundMoney(t.Debit - t.Credit); net >= 0 {
+			line.Debit = net
+		} else {
+			line.Credit = -net
+		}
+		balance.TotalDebit = roundMoney(balance.TotalDebit + line.Debit)
+		balance.TotalCredit = roundMoney(balance.TotalCredit + line.Credit)
+		balance.Lines = append(balance.Lines, line)
+	}
+	balance.Balanced = balance.TotalDebit == balance.TotalCredit
+	return balance, nil
+}
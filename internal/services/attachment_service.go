@@ -0,0 +1,197 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"pos-api/internal/models"
+	"pos-api/internal/pkg/storage"
+	"pos-api/internal/repositories"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"gorm.io/gorm"
+)
+
+// attachmentTypes are the file types accepted, with the extension they are
+// stored under.
+var attachmentTypes = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"image/webp":      ".webp",
+	"application/pdf": ".pdf",
+}
+
+// UploadedFile is a file received for attaching.
+type UploadedFile struct {
+	Name    string
+	Size    int64
+	Content io.Reader
+}
+
+type AttachmentService interface {
+	// Upload attaches an image or PDF to a cash flow entry or stock movement,
+	// owner being one of the models.AttachmentOwner* kinds. The type is
+	// detected from the content, not taken from the file name.
+	Upload(ctx context.Context, owner string, ownerID uint, file UploadedFile, userID uint) (*models.Attachment, error)
+	GetByOwner(ctx context.Context, owner string, ownerID uint) ([]models.Attachment, error)
+	// Open returns an attachment of the record with its file; the caller
+	// closes the file.
+	Open(ctx context.Context, owner string, ownerID, id uint) (*models.Attachment, io.ReadCloser, error)
+	Delete(ctx context.Context, owner string, ownerID, id uint) error
+}
+
+type attachmentService struct {
+	repo    repositories.AttachmentRepository
+	files   storage.Storage
+	maxSize int64
+}
+
+// NewAttachmentService stores attachments of up to maxSize bytes in files.
+func NewAttachmentService(repo repositories.AttachmentRepository, files storage.Storage, maxSize int64) AttachmentService {
+	return &attachmentService{repo: repo, files: files, maxSize: maxSize}
+}
+
+func (s *attachmentService) Upload(ctx context.Context, owner string, ownerID uint, file UploadedFile, userID uint) (*models.Attachment, error) {
+	name := strings.TrimSpace(filepath.Base(strings.ReplaceAll(file.Name, "\\", "/")))
+	switch {
+	case file.Size <= 0:
+		return nil, errors.New("file is empty")
+	case file.Size > s.maxSize:
+		return nil, fmt.Errorf("file is larger than the %d KB allowed", s.maxSize/1024)
+	case name == "" || name == "." || name == "/":
+		return nil, errors.New("file name is required")
+	case len(name) > 255:
+		return nil, errors.New("file name is longer than 255 characters")
+	}
+
+	exists, err := s.repo.OwnerExists(ctx, owner, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check %s %d: %w", owner, ownerID, err)
+	}
+	if !exists {
+		return nil, customErrors.ErrNotFound
+	}
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file.Content, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	head = head[:n]
+	contentType := http.DetectContentType(head)
+	ext, ok := attachmentTypes[contentType]
+	if !ok {
+		return nil, fmt.Errorf("file type %s is not allowed; upload a JPEG, PNG or WebP image or a PDF", contentType)
+	}
+
+	key, err := attachmentKey(owner, ownerID, ext)
+	if err != nil {
+		return nil, err
+	}
+	content := io.MultiReader(bytes.NewReader(head), file.Content)
+	if err := s.files.Put(ctx, key, content, file.Size, contentType); err != nil {
+		return nil, fmt.Errorf("failed to store file: %w", err)
+	}
+
+	attachment := &models.Attachment{
+		FileName:    name,
+		ContentType: contentType,
+		Size:        file.Size,
+		StorageKey:  key,
+		UserID:      userID,
+	}
+	switch owner {
+	case models.AttachmentOwnerCashFlow:
+		attachment.CashFlowID = &ownerID
+	case models.AttachmentOwnerInventoryLog:
+		attachment.InventoryLogID = &ownerID
+	}
+	if err := s.repo.Create(ctx, attachment); err != nil {
+		removeAttachmentFiles(ctx, s.files, []models.Attachment{*attachment})
+		return nil, fmt.Errorf("failed to save attachment: %w", err)
+	}
+	return attachment, nil
+}
+
+func (s *attachmentService) GetByOwner(ctx context.Context, owner string, ownerID uint) ([]models.Attachment, error) {
+	return s.repo.GetByOwner(ctx, owner, ownerID)
+}
+
+func (s *attachmentService) Open(ctx context.Context, owner string, ownerID, id uint) (*models.Attachment, io.ReadCloser, error) {
+	attachment, err := s.get(ctx, owner, ownerID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	file, err := s.files.Open(ctx, attachment.StorageKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, nil, fmt.Errorf("%w: the file of attachment %d is missing from storage", customErrors.ErrNotFound, id)
+		}
+		return nil, nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	return attachment, file, nil
+}
+
+func (s *attachmentService) Delete(ctx context.Context, owner string, ownerID, id uint) error {
+	attachment, err := s.get(ctx, owner, ownerID, id)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete attachment: %w", err)
+	}
+	removeAttachmentFiles(ctx, s.files, []models.Attachment{*attachment})
+	return nil
+}
+
+// get returns the attachment with id if it belongs to the record.
+func (s *attachmentService) get(ctx context.Context, owner string, ownerID, id uint) (*models.Attachment, error) {
+	attachment, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customErrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get attachment: %w", err)
+	}
+	var attachedTo *uint
+	switch owner {
+	case models.AttachmentOwnerCashFlow:
+		attachedTo = attachment.CashFlowID
+	case models.AttachmentOwnerInventoryLog:
+		attachedTo = attachment.InventoryLogID
+	}
+	if attachedTo == nil || *attachedTo != ownerID {
+		return nil, customErrors.ErrNotFound
+	}
+	return attachment, nil
+}
+
+// attachmentKey returns a new, unguessable storage key for a file of the record.
+func attachmentKey(owner string, ownerID uint, ext string) (string, error) {
+	token := make([]byte, 16)
+	if _, err := rand.Read(token); err != nil {
+		return "", fmt.Errorf("failed to generate file key: %w", err)
+	}
+	return fmt.Sprintf("%s/%d/%s%s", owner, ownerID, hex.EncodeToString(token), ext), nil
+}
+
+// removeAttachmentFiles deletes the stored files of attachments whose records
+// are gone. A file that cannot be deleted is only logged: the record is what
+// counts, and a leftover file is never served again.
+func removeAttachmentFiles(ctx context.Context, files storage.Storage, attachments []models.Attachment) {
+	for _, a := range attachments {
+		if err := files.Delete(ctx, a.StorageKey); err != nil {
+			slog.Error("Failed to delete attachment file", "attachment_id", a.ID, "key", a.StorageKey, "error", err)
+		}
+	}
+}
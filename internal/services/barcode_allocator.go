@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"pos-api/internal/pkg/gtin"
+	"pos-api/internal/repositories"
+)
+
+// itemRefSpace is the number of 10-digit item references available per 2-digit prefix.
+const itemRefSpace int64 = 10_000_000_000
+
+// Prefixes internal barcodes may use: the GS1 restricted circulation range
+// 20-29 without 28 and 29, which are the variable-measure prefixes of
+// weighing scale labels.
+const (
+	InStorePrefixMin = 20
+	InStorePrefixMax = 27
+)
+
+// maxAllocationAttempts bounds how many taken codes are skipped before giving up.
+const maxAllocationAttempts = 100
+
+// BarcodeAllocator issues internal EAN-13 barcodes within the in-store prefix range.
+type BarcodeAllocator interface {
+	Allocate(ctx context.Context) (string, error)
+}
+
+type barcodeAllocator struct {
+	repo      repositories.ProductRepository
+	prefixMin int
+	prefixMax int
+}
+
+// NewBarcodeAllocator creates an allocator for prefixes prefixMin..prefixMax (e.g. 20..27).
+func NewBarcodeAllocator(repo repositories.ProductRepository, prefixMin, prefixMax int) BarcodeAllocator {
+	return &barcodeAllocator{
+		repo:      repo,
+		prefixMin: prefixMin,
+		prefixMax: prefixMax,
+	}
+}
+
+// Allocate reserves the next free code from the database sequence. The sequence
+// value is laid out across the prefix range, so prefix 20 is used up before 21.
+// Codes already taken (e.g. entered manually) are skipped.
+func (a *barcodeAllocator) Allocate(ctx context.Context) (string, error) {
+	if a.prefixMin < InStorePrefixMin || a.prefixMax > InStorePrefixMax || a.prefixMin > a.prefixMax {
+		return "", fmt.Errorf("invalid barcode prefix range %d-%d: internal barcodes use %d-%d, as 28 and 29 are read as scale labels",
+			a.prefixMin, a.prefixMax, InStorePrefixMin, InStorePrefixMax)
+	}
+
+	for attempt := 0; attempt < maxAllocationAttempts; attempt++ {
+		seq, err := a.repo.NextBarcodeSequence(ctx)
+		if err != nil {
+			return "", fmt.Errorf("failed to get next barcode sequence: %w", err)
+		}
+
+		body := int64(a.prefixMin)*itemRefSpace + seq
+		if body >= int64(a.prefixMax+1)*itemRefSpace {
+			return "", errors.New("internal barcode range exhausted")
+		}
+
+		code, err := gtin.BuildEAN13(int(body/itemRefSpace), body%itemRefSpace)
+		if err != nil {
+			return "", err
+		}
+
+		exists, err := a.repo.BarcodeExists(ctx, code)
+		if err != nil {
+			return "", fmt.Errorf("failed to check barcode uniqueness: %w", err)
+		}
+		if !exists {
+			return code, nil
+		}
+	}
+
+	return "", errors.New("failed to allocate a unique barcode")
+}
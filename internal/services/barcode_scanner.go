@@ -0,0 +1,134 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"pos-api/internal/models"
+	"pos-api/internal/pkg/scale"
+	"pos-api/internal/repositories"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"gorm.io/gorm"
+)
+
+// ScanResult adalah hasil pencarian barcode di kasir, siap ditambahkan ke keranjang.
+type ScanResult struct {
+	Product  *models.Product `json:"product"`
+	Quantity float64         `json:"quantity"` // Jumlah (kg untuk produk timbangan)
+	// UnitPrice adalah harga per satuan yang dicatat sebagai PriceAtSale. Untuk
+	// label harga nilainya SubTotal / Quantity: harga di label yang berlaku,
+	// harga per kg produk hanya dipakai untuk menghitung beratnya.
+	UnitPrice float64       `json:"unit_price"`
+	SubTotal  float64       `json:"subtotal"`        // Quantity * Price, atau harga yang tertera di label timbangan
+	Scale     *scale.Result `json:"scale,omitempty"` // Terisi jika barcode adalah label timbangan
+}
+
+// BarcodeScanner me-resolve barcode hasil scan menjadi produk dan kuantitasnya.
+type BarcodeScanner interface {
+	Scan(ctx context.Context, code string) (*ScanResult, error)
+}
+
+type barcodeScanner struct {
+	repo   repositories.ProductRepository
+	parser *scale.Parser
+}
+
+// NewBarcodeScanner membuat scanner yang mengenali barcode produk biasa dan
+// label timbangan (GS1 variable measure) sesuai pola pada parser.
+func NewBarcodeScanner(repo repositories.ProductRepository, parser *scale.Parser) BarcodeScanner {
+	return &barcodeScanner{
+		repo:   repo,
+		parser: parser,
+	}
+}
+
+// Scan mencari produk dengan barcode yang sama persis terlebih dahulu, sehingga
+// barcode internal tidak pernah salah dibaca sebagai label timbangan. Jika tidak
+// ada, barcode dicocokkan ke pola timbangan dan PLU-nya dicari.
+func (s *barcodeScanner) Scan(ctx context.Context, code string) (*ScanResult, error) {
+	if code == "" {
+		return nil, errors.New("validasi gagal: barcode wajib diisi")
+	}
+
+	product, err := s.repo.GetProductByBarcode(ctx, code)
+	if err == nil {
+		return &ScanResult{Product: product, Quantity: 1, UnitPrice: product.Price, SubTotal: product.Price}, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("gagal mencari produk: %w", err)
+	}
+
+	label, ok := s.parser.Parse(code)
+	if !ok {
+		return nil, customErrors.ErrNotFound
+	}
+
+	product, err = s.repo.GetProductByPLU(ctx, label.PLU)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customErrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("gagal mencari produk: %w", err)
+	}
+
+	return scaleLine(product, label)
+}
+
+// scaleLine menghitung kuantitas dan subtotal dari nilai yang tertanam di label timbangan.
+func scaleLine(product *models.Product, label *scale.Result) (*ScanResult, error) {
+	if !product.SoldByWeight {
+		return nil, fmt.Errorf("produk %s tidak dijual per berat", product.Name)
+	}
+	if label.Value <= 0 {
+		return nil, errors.New("berat atau harga pada label timbangan tidak valid")
+	}
+
+	result := &ScanResult{Product: product, Scale: label}
+	switch label.Measure {
+	case scale.MeasureWeight:
+		result.Quantity = roundQuantity(label.Value)
+		result.UnitPrice = product.Price
+		result.SubTotal = roundMoney(label.Value * product.Price)
+	case scale.MeasurePrice:
+		if product.Price <= 0 {
+			return nil, fmt.Errorf("harga per kg produk %s belum diatur", product.Name)
+		}
+		result.Quantity = roundQuantity(label.Value / product.Price)
+		result.SubTotal = label.Value
+	default:
+		return nil, fmt.Errorf("jenis label timbangan '%s' tidak dikenal", label.Measure)
+	}
+
+	if result.Quantity <= 0 {
+		return nil, errors.New("berat atau harga pada label timbangan tidak valid")
+	}
+	if label.Measure == scale.MeasurePrice {
+		result.UnitPrice = roundMoney(result.SubTotal / result.Quantity)
+	}
+	return result, nil
+}
+
+// roundQuantity membulatkan kuantitas ke 3 desimal (gram), sesuai presisi kolom numeric(14,3).
+func roundQuantity(q float64) float64 {
+	return math.Round(q*1000) / 1000
+}
+
+// roundMoney membulatkan nominal ke 2 desimal.
+func roundMoney(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// validateQuantity memastikan kuantitas positif dan hanya produk timbangan yang boleh desimal.
+func validateQuantity(product *models.Product, qty float64) error {
+	if qty <= 0 {
+		return fmt.Errorf("kuantitas produk %s harus lebih besar dari 0", product.Name)
+	}
+	if !product.SoldByWeight && qty != math.Trunc(qty) {
+		return fmt.Errorf("kuantitas produk %s harus bilangan bulat", product.Name)
+	}
+	return nil
+}
@@ -0,0 +1,340 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"pos-api/internal/models"
+	"pos-api/internal/pkg/events"
+	"pos-api/internal/repositories"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+// Budget line statuses
+const (
+	BudgetOK    = "ok"    // Below the alert percentage
+	BudgetAlert = "alert" // At or past the alert percentage, within the budget
+	BudgetOver  = "over"  // Spent more than the budget
+)
+
+type BudgetRequest struct {
+	Source string  `json:"source" validate:"required"` // Code of an active expense category
+	Month  string  `json:"month" validate:"required"`  // "2026-03"
+	Amount float64 `json:"amount" validate:"required,gt=0"`
+	// AlertPercent is the share of the budget, in percent, whose spending
+	// raises an alert; 0 uses the default (BUDGET_ALERT_PERCENT)
+	AlertPercent float64 `json:"alert_percent" validate:"gte=0,lte=1000"`
+	Notes        string  `json:"notes"`
+}
+
+// BudgetLine compares a category's budget with what it spent.
+type BudgetLine struct {
+	BudgetID     uint    `json:"budget_id"`
+	Source       string  `json:"source"`
+	Name         string  `json:"name"` // The category's name
+	Budget       float64 `json:"budget"`
+	Actual       float64 `json:"actual"`
+	Variance     float64 `json:"variance"`     // Budget - Actual; negative when over budget
+	PercentUsed  float64 `json:"percent_used"` // Actual as a percentage of Budget
+	AlertPercent float64 `json:"alert_percent"`
+	Status       string  `json:"status"` // "ok", "alert" or "over"
+}
+
+// BudgetReport is the budget versus actual expenses of a month.
+type BudgetReport struct {
+	Month         string       `json:"month"`
+	Lines         []BudgetLine `json:"lines"`
+	TotalBudget   float64      `json:"total_budget"`
+	TotalActual   float64      `json:"total_actual"` // Of the budgeted categories
+	TotalVariance float64      `json:"total_variance"`
+	PercentUsed   float64      `json:"percent_used"`
+	// UnbudgetedActual is what the expense categories without a budget spent
+	UnbudgetedActual float64 `json:"unbudgeted_actual"`
+}
+
+type BudgetService interface {
+	Create(ctx context.Context, req BudgetRequest, userID uint) (*models.Budget, error)
+	Update(ctx context.Context, id uint, req BudgetRequest) (*models.Budget, error)
+	Delete(ctx context.Context, id uint) error
+	GetByID(ctx context.Context, id uint) (*models.Budget, error)
+	// GetByMonth lists the budgets of a month ("2026-03"), of now's month
+	// when month is empty.
+	GetByMonth(ctx context.Context, month string, now time.Time) ([]models.Budget, error)
+	// GetReport compares the budgets of a month, now's month when month is
+	// empty, with the cash flow expenses of their categories in it.
+	GetReport(ctx context.Context, month string, now time.Time) (*BudgetReport, error)
+	// CheckAlerts publishes EventBudgetThresholdCrossed for each budget of
+	// now's month whose spending has reached its alert percentage since the
+	// last check, and returns their lines. A budget alerts again only after
+	// its spending fell back below the percentage or it was changed.
+	CheckAlerts(ctx context.Context, now time.Time) ([]BudgetLine, error)
+}
+
+type budgetService struct {
+	repo                repositories.BudgetRepository
+	categoryRepo        repositories.CashFlowCategoryRepository
+	cashFlowRepo        repositories.CashFlowRepository
+	eventBus            events.EventBus
+	defaultAlertPercent float64
+	validator           *validator.Validate
+}
+
+// NewBudgetService alerts at defaultAlertPercent percent of budgets that do
+// not set their own.
+func NewBudgetService(repo repositories.BudgetRepository, categoryRepo repositories.CashFlowCategoryRepository,
+	cashFlowRepo repositories.CashFlowRepository, eventBus events.EventBus, defaultAlertPercent float64) BudgetService {
+	return &budgetService{
+		repo:                repo,
+		categoryRepo:        categoryRepo,
+		cashFlowRepo:        cashFlowRepo,
+		eventBus:            eventBus,
+		defaultAlertPercent: defaultAlertPercent,
+		validator:           validator.New(),
+	}
+}
+
+func (s *budgetService) Create(ctx context.Context, req BudgetRequest, userID uint) (*models.Budget, error) {
+	budget := &models.Budget{UserID: userID}
+	if err := s.apply(ctx, budget, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, budget); err != nil {
+		return nil, fmt.Errorf("failed to create budget: %w", err)
+	}
+	return budget, nil
+}
+
+func (s *budgetService) Update(ctx context.Context, id uint, req BudgetRequest) (*models.Budget, error) {
+	budget, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	before := *budget
+	if err := s.apply(ctx, budget, req); err != nil {
+		return nil, err
+	}
+	// A changed budget is checked afresh
+	if budget.Source != before.Source || !budget.Month.Equal(before.Month) ||
+		budget.Amount != before.Amount || budget.AlertPercent != before.AlertPercent {
+		budget.AlertedAt = nil
+	}
+
+	budget.Category = nil
+	if err := s.repo.Update(ctx, budget); err != nil {
+		return nil, fmt.Errorf("failed to update budget: %w", err)
+	}
+	return budget, nil
+}
+
+// apply validates req and copies it onto budget.
+func (s *budgetService) apply(ctx context.Context, budget *models.Budget, req BudgetRequest) error {
+	if err := s.validator.Struct(req); err != nil {
+		return errors.New("validation failed: " + err.Error())
+	}
+	month, err := parsePeriod(req.Month)
+	if err != nil {
+		return err
+	}
+
+	category, err := s.categoryRepo.GetByCode(ctx, req.Source)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("unknown cash flow category %q", req.Source)
+		}
+		return fmt.Errorf("failed to get cash flow category: %w", err)
+	}
+	if category.Type != models.CashFlowCategoryExpense || !category.IsActive {
+		return fmt.Errorf("category %q is not an active expense category", req.Source)
+	}
+
+	existing, err := s.repo.GetBySourceMonth(ctx, req.Source, month)
+	switch {
+	case err == nil && existing.ID != budget.ID:
+		return fmt.Errorf("%w: %s already has a budget for %s", customErrors.ErrConflict, category.Name, month.Format("January 2006"))
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound):
+		return fmt.Errorf("failed to check existing budget: %w", err)
+	}
+
+	budget.Source = req.Source
+	budget.Month = month
+	budget.Amount = roundMoney(req.Amount)
+	budget.AlertPercent = req.AlertPercent
+	if budget.AlertPercent == 0 {
+		budget.AlertPercent = s.defaultAlertPercent
+	}
+	budget.Notes = strings.TrimSpace(req.Notes)
+	return nil
+}
+
+func (s *budgetService) Delete(ctx context.Context, id uint) error {
+	if _, err := s.GetByID(ctx, id); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *budgetService) GetByID(ctx context.Context, id uint) (*models.Budget, error) {
+	budget, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customErrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get budget: %w", err)
+	}
+	return budget, nil
+}
+
+func (s *budgetService) GetByMonth(ctx context.Context, month string, now time.Time) ([]models.Budget, error) {
+	period, err := budgetMonth(month, now)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.GetByMonth(ctx, period)
+}
+
+func (s *budgetService) GetReport(ctx context.Context, month string, now time.Time) (*BudgetReport, error) {
+	period, err := budgetMonth(month, now)
+	if err != nil {
+		return nil, err
+	}
+	report, _, err := s.report(ctx, period, now.Location())
+	return report, err
+}
+
+// report builds the budget report of the month starting at period, counting
+// the expenses dated in that month in loc, and returns the budgets by ID.
+func (s *budgetService) report(ctx context.Context, period time.Time, loc *time.Location) (*BudgetReport, map[uint]*models.Budget, error) {
+	budgets, err := s.repo.GetByMonth(ctx, period)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get budgets: %w", err)
+	}
+	start := time.Date(period.Year(), period.Month(), 1, 0, 0, 0, 0, loc)
+	flows, err := s.cashFlowRepo.GetSourceBreakdown(ctx, start, start.AddDate(0, 1, 0).Add(-time.Microsecond))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get cash flow expenses: %w", err)
+	}
+	actuals := make(map[string]float64)
+	for _, f := range flows {
+		if f.Type == "expense" && f.CategoryType == models.CashFlowCategoryExpense {
+			actuals[f.Source] += f.TotalAmount
+		}
+	}
+
+	report := &BudgetReport{Month: period.Format("2006-01"), Lines: []BudgetLine{}}
+	byID := make(map[uint]*models.Budget, len(budgets))
+	for i := range budgets {
+		b := &budgets[i]
+		byID[b.ID] = b
+		line := budgetLine(b, roundMoney(actuals[b.Source]))
+		delete(actuals, b.Source)
+		report.Lines = append(report.Lines, line)
+		report.TotalBudget = roundMoney(report.TotalBudget + line.Budget)
+		report.TotalActual = roundMoney(report.TotalActual + line.Actual)
+	}
+	for _, actual := range actuals {
+		report.UnbudgetedActual = roundMoney(report.UnbudgetedActual + actual)
+	}
+	report.TotalVariance = roundMoney(report.TotalBudget - report.TotalActual)
+	if report.TotalBudget > 0 {
+		report.PercentUsed = roundMoney(report.TotalActual / report.TotalBudget * 100)
+	}
+	return report, byID, nil
+}
+
+func budgetLine(b *models.Budget, actual float64) BudgetLine {
+	line := BudgetLine{
+		BudgetID:     b.ID,
+		Source:       b.Source,
+		Name:         b.Source,
+		Budget:       b.Amount,
+		Actual:       actual,
+		Variance:     roundMoney(b.Amount - actual),
+		AlertPercent: b.AlertPercent,
+		Status:       BudgetOK,
+	}
+	if b.Category != nil {
+		line.Name = b.Category.Name
+	}
+	if b.Amount > 0 {
+		line.PercentUsed = roundMoney(actual / b.Amount * 100)
+	}
+	switch {
+	case actual > b.Amount:
+		line.Status = BudgetOver
+	case line.PercentUsed >= b.AlertPercent:
+		line.Status = BudgetAlert
+	}
+	return line
+}
+
+func (s *budgetService) CheckAlerts(ctx context.Context, now time.Time) ([]BudgetLine, error) {
+	report, budgets, err := s.report(ctx, repositories.PeriodStart(now), now.Location())
+	if err != nil {
+		return nil, err
+	}
+
+	var raised []BudgetLine
+	for _, line := range report.Lines {
+		budget := budgets[line.BudgetID]
+		crossed := line.PercentUsed >= line.AlertPercent
+		switch {
+		case crossed && budget.AlertedAt == nil:
+			at := now
+			ok, err := s.repo.SetAlerted(ctx, budget.ID, &at)
+			if err != nil {
+				return raised, fmt.Errorf("failed to mark budget %d alerted: %w", budget.ID, err)
+			}
+			if !ok {
+				continue
+			}
+			budget.AlertedAt = &at
+			if err := s.eventBus.Publish(ctx, events.EventBudgetThresholdCrossed, events.BudgetThresholdPayload{
+				Budget:      budget,
+				Actual:      line.Actual,
+				PercentUsed: line.PercentUsed,
+			}); err != nil {
+				slog.Error("Failed to publish budget alert", "budget_id", budget.ID, "error", err)
+			}
+			raised = append(raised, line)
+		case !crossed && budget.AlertedAt != nil:
+			if _, err := s.repo.SetAlerted(ctx, budget.ID, nil); err != nil {
+				return raised, fmt.Errorf("failed to reset budget %d alert: %w", budget.ID, err)
+			}
+		}
+	}
+	return raised, nil
+}
+
+// OverBudget returns the lines of report over their budget, the most
+// overspent first, at most limit of them.
+func OverBudget(report *BudgetReport, limit int) []BudgetLine {
+	over := []BudgetLine{}
+	for _, line := range report.Lines {
+		if line.Status == BudgetOver {
+			over = append(over, line)
+		}
+	}
+	sort.SliceStable(over, func(i, j int) bool { return over[i].Variance < over[j].Variance })
+	if len(over) > limit {
+		over = over[:limit]
+	}
+	return over
+}
+
+// budgetMonth parses a "2026-03" month, defaulting to now's.
+func budgetMonth(month string, now time.Time) (time.Time, error) {
+	if month == "" {
+		return repositories.PeriodStart(now), nil
+	}
+	return parsePeriod(month)
+}
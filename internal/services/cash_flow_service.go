@@ -5,13 +5,20 @@ import (
 	"errors"
 	"fmt"
 	"pos-api/internal/models"
+	"pos-api/internal/pkg/storage"
 	"pos-api/internal/repositories"
+	"strings"
 	"time"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
 )
 
 type CreateCashFlowRequest struct {
-	Type   string  `json:"type" validate:"required,oneof=income expense"`
-	Source string  `json:"source" validate:"required"`
+	Type   string  `json:"type" validate:"omitempty,oneof=income expense"` // Taken from the category; required for capital
+	Source string  `json:"source" validate:"required"`                     // Code of an active, non-system category
 	Amount float64 `json:"amount" validate:"required,gt=0"`
 	Date   string  `json:"date" validate:"required"` // "2026-02-16"
 	Notes  string  `json:"notes"`
@@ -25,6 +32,15 @@ type UpdateCashFlowRequest struct {
 	Notes  string  `json:"notes"`
 }
 
+type CashFlowCategoryRequest struct {
+	Code string `json:"code" validate:"required,min=2,max=50"`
+	Name string `json:"name" validate:"required,max=100"`
+	Type string `json:"type" validate:"required,oneof=income expense capital"`
+	// AccountCode defaults to other income, operating expenses or capital by type
+	AccountCode string `json:"account_code" validate:"max=20"`
+	IsActive    *bool  `json:"is_active"` // Defaults to true on create, unchanged on update when omitted
+}
+
 type CashFlowSummary struct {
 	TotalCapital float64 `json:"total_capital"`
 	TotalIncome  float64 `json:"total_income"`
@@ -36,21 +52,49 @@ type CashFlowService interface {
 	Create(ctx context.Context, req CreateCashFlowRequest, userID uint) (*models.CashFlow, error)
 	Update(ctx context.Context, id uint, req UpdateCashFlowRequest) (*models.CashFlow, error)
 	Delete(ctx context.Context, id uint) error
+	// ForceDelete removes an entry made by hand for good, deleted or not,
+	// with its attachments and their files.
+	ForceDelete(ctx context.Context, id uint) error
 	GetByID(ctx context.Context, id uint) (*models.CashFlow, error)
 	GetAll(ctx context.Context, page, pageSize int, cfType, source string, startDate, endDate *time.Time) ([]models.CashFlow, int64, error)
 	GetSummary(ctx context.Context, startDate, endDate time.Time) (*CashFlowSummary, error)
+
+	CreateCategory(ctx context.Context, req CashFlowCategoryRequest) (*models.CashFlowCategory, error)
+	// UpdateCategory changes an admin-defined category. Its code and type
+	// stay fixed once entries use it.
+	UpdateCategory(ctx context.Context, id uint, req CashFlowCategoryRequest) (*models.CashFlowCategory, error)
+	// DeleteCategory removes an admin-defined category no entry uses;
+	// deactivate a used one instead.
+	DeleteCategory(ctx context.Context, id uint) error
+	GetCategoryByID(ctx context.Context, id uint) (*models.CashFlowCategory, error)
+	GetCategories(ctx context.Context, cfType string, onlyActive bool) ([]models.CashFlowCategory, error)
 }
 
 type cashFlowService struct {
-	repo repositories.CashFlowRepository
+	repo         repositories.CashFlowRepository
+	categoryRepo repositories.CashFlowCategoryRepository
+	files        storage.Storage // Where attachment files are kept
+	validator    *validator.Validate
+}
+
+func NewCashFlowService(repo repositories.CashFlowRepository, categoryRepo repositories.CashFlowCategoryRepository, files storage.Storage) CashFlowService {
+	return &cashFlowService{
+		repo:         repo,
+		categoryRepo: categoryRepo,
+		files:        files,
+		validator:    validator.New(),
+	}
 }
 
-func NewCashFlowService(repo repositories.CashFlowRepository) CashFlowService {
-	return &cashFlowService{repo: repo}
+// categoryAccounts is the account each category type posts to by default.
+var categoryAccounts = map[string]string{
+	models.CashFlowCategoryIncome:  models.AccountCodeOtherIncome,
+	models.CashFlowCategoryExpense: models.AccountCodeOperating,
+	models.CashFlowCategoryCapital: models.AccountCodeCapital,
 }
 
 func (s *cashFlowService) Create(ctx context.Context, req CreateCashFlowRequest, userID uint) (*models.CashFlow, error) {
-	if req.Type != "income" && req.Type != "expense" {
+	if req.Type != "" && req.Type != "income" && req.Type != "expense" {
 		return nil, errors.New("type must be 'income' or 'expense'")
 	}
 
@@ -59,8 +103,13 @@ func (s *cashFlowService) Create(ctx context.Context, req CreateCashFlowRequest,
 		return nil, fmt.Errorf("invalid date format, use YYYY-MM-DD: %w", err)
 	}
 
+	cfType, err := s.entryType(ctx, req.Source, req.Type, "")
+	if err != nil {
+		return nil, err
+	}
+
 	cf := &models.CashFlow{
-		Type:   req.Type,
+		Type:   cfType,
 		Source: req.Source,
 		Amount: req.Amount,
 		Date:   date,
@@ -81,11 +130,17 @@ func (s *cashFlowService) Update(ctx context.Context, id uint, req UpdateCashFlo
 		return nil, fmt.Errorf("cash flow entry with ID %d not found", id)
 	}
 
-	if req.Type != "" {
-		cf.Type = req.Type
-	}
-	if req.Source != "" {
-		cf.Source = req.Source
+	if req.Source != "" || req.Type != "" {
+		if req.Type != "" && req.Type != "income" && req.Type != "expense" {
+			return nil, errors.New("type must be 'income' or 'expense'")
+		}
+		if req.Source != "" {
+			cf.Source = req.Source
+		}
+		if cf.Type, err = s.entryType(ctx, cf.Source, req.Type, cf.Type); err != nil {
+			return nil, err
+		}
+		cf.Category = nil
 	}
 	if req.Amount > 0 {
 		cf.Amount = req.Amount
@@ -116,6 +171,18 @@ func (s *cashFlowService) Delete(ctx context.Context, id uint) error {
 	return s.repo.Delete(ctx, id)
 }
 
+func (s *cashFlowService) ForceDelete(ctx context.Context, id uint) error {
+	attachments, err := s.repo.ForceDelete(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return customErrors.ErrNotFound
+		}
+		return err
+	}
+	removeAttachmentFiles(ctx, s.files, attachments)
+	return nil
+}
+
 func (s *cashFlowService) GetByID(ctx context.Context, id uint) (*models.CashFlow, error) {
 	return s.repo.GetByID(ctx, id)
 }
@@ -140,3 +207,168 @@ func (s *cashFlowService) GetSummary(ctx context.Context, startDate, endDate tim
 		NetProfit:    income - expense,
 	}, nil
 }
+
+// entryType checks that entries made by hand can use the category with code
+// and returns the type such an entry gets: the category's own, or for
+// capital the requested one, falling back to current.
+func (s *cashFlowService) entryType(ctx context.Context, code, requested, current string) (string, error) {
+	category, err := s.categoryRepo.GetByCode(ctx, code)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", fmt.Errorf("unknown cash flow category %q", code)
+		}
+		return "", fmt.Errorf("failed to get cash flow category: %w", err)
+	}
+	if category.IsSystem {
+		return "", fmt.Errorf("category %q is reserved for entries the system posts", code)
+	}
+	if !category.IsActive {
+		return "", fmt.Errorf("category %q is inactive", code)
+	}
+
+	cfType := category.EntryType(requested)
+	if cfType == "" {
+		cfType = current
+	}
+	if cfType == "" {
+		return "", fmt.Errorf("type is required for capital category %q", code)
+	}
+	if requested != "" && requested != cfType {
+		return "", fmt.Errorf("category %q only takes %s entries", code, cfType)
+	}
+	return cfType, nil
+}
+
+func (s *cashFlowService) CreateCategory(ctx context.Context, req CashFlowCategoryRequest) (*models.CashFlowCategory, error) {
+	if err := s.validator.Struct(req); err != nil {
+		return nil, errors.New("validation failed: " + err.Error())
+	}
+
+	category := &models.CashFlowCategory{
+		Code:     categoryCode(req.Code),
+		Name:     strings.TrimSpace(req.Name),
+		Type:     req.Type,
+		IsActive: true,
+	}
+	if req.IsActive != nil {
+		category.IsActive = *req.IsActive
+	}
+	if err := s.setCategoryAccount(ctx, category, req.AccountCode); err != nil {
+		return nil, err
+	}
+
+	if err := s.categoryRepo.Create(ctx, category); err != nil {
+		if isDuplicateKey(err) {
+			return nil, customErrors.ErrConflict
+		}
+		return nil, fmt.Errorf("failed to create cash flow category: %w", err)
+	}
+
+	return category, nil
+}
+
+func (s *cashFlowService) UpdateCategory(ctx context.Context, id uint, req CashFlowCategoryRequest) (*models.CashFlowCategory, error) {
+	if err := s.validator.Struct(req); err != nil {
+		return nil, errors.New("validation failed: " + err.Error())
+	}
+
+	category, err := s.GetCategoryByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if category.IsSystem {
+		return nil, errors.New("system categories cannot be changed")
+	}
+
+	code := categoryCode(req.Code)
+	if code != category.Code || req.Type != category.Type {
+		used, err := s.categoryRepo.IsUsed(ctx, category.Code)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check category usage: %w", err)
+		}
+		if used {
+			return nil, fmt.Errorf("%w: category %q is used by cash flow entries, its code and type cannot change",
+				customErrors.ErrConflict, category.Code)
+		}
+	}
+
+	category.Code = code
+	category.Name = strings.TrimSpace(req.Name)
+	category.Type = req.Type
+	if req.IsActive != nil {
+		category.IsActive = *req.IsActive
+	}
+	if err := s.setCategoryAccount(ctx, category, req.AccountCode); err != nil {
+		return nil, err
+	}
+
+	if err := s.categoryRepo.Update(ctx, category); err != nil {
+		if isDuplicateKey(err) {
+			return nil, customErrors.ErrConflict
+		}
+		return nil, fmt.Errorf("failed to update cash flow category: %w", err)
+	}
+
+	return category, nil
+}
+
+func (s *cashFlowService) DeleteCategory(ctx context.Context, id uint) error {
+	category, err := s.GetCategoryByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if category.IsSystem {
+		return errors.New("system categories cannot be deleted")
+	}
+
+	used, err := s.categoryRepo.IsUsed(ctx, category.Code)
+	if err != nil {
+		return fmt.Errorf("failed to check category usage: %w", err)
+	}
+	if used {
+		return fmt.Errorf("%w: category %q is used by cash flow entries, deactivate it instead",
+			customErrors.ErrConflict, category.Code)
+	}
+
+	return s.categoryRepo.Delete(ctx, id)
+}
+
+func (s *cashFlowService) GetCategoryByID(ctx context.Context, id uint) (*models.CashFlowCategory, error) {
+	category, err := s.categoryRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customErrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get cash flow category: %w", err)
+	}
+	return category, nil
+}
+
+func (s *cashFlowService) GetCategories(ctx context.Context, cfType string, onlyActive bool) ([]models.CashFlowCategory, error) {
+	return s.categoryRepo.GetAll(ctx, cfType, onlyActive)
+}
+
+// setCategoryAccount sets the account the category posts to, the type's
+// default when accountCode is empty.
+func (s *cashFlowService) setCategoryAccount(ctx context.Context, category *models.CashFlowCategory, accountCode string) error {
+	accountCode = strings.TrimSpace(accountCode)
+	if accountCode == "" {
+		category.AccountCode = categoryAccounts[category.Type]
+		return nil
+	}
+
+	exists, err := s.categoryRepo.AccountExists(ctx, accountCode)
+	if err != nil {
+		return fmt.Errorf("failed to check account: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("account %s does not exist", accountCode)
+	}
+	category.AccountCode = accountCode
+	return nil
+}
+
+// categoryCode normalizes a category code to lower snake case.
+func categoryCode(code string) string {
+	return strings.Join(strings.Fields(strings.ToLower(code)), "_")
+}
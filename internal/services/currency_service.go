@@ -0,0 +1,377 @@
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"pos-api/internal/models"
+	"pos-api/internal/repositories"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+// defaultBaseCurrency is the base currency of a store that has not set one.
+const defaultBaseCurrency = "IDR"
+
+// rateHistoryLimit caps the rate history returned for a currency.
+const rateHistoryLimit = 100
+
+type CurrencyRequest struct {
+	Code   string  `json:"code" validate:"required,len=3,alpha"` // ISO 4217, e.g. USD
+	Name   string  `json:"name" validate:"required"`
+	Symbol string  `json:"symbol"`
+	Rate   float64 `json:"rate" validate:"required,gt=0"` // Base currency per unit
+}
+
+type UpdateCurrencyRequest struct {
+	Name     string `json:"name" validate:"required"`
+	Symbol   string `json:"symbol"`
+	IsActive *bool  `json:"is_active"`
+}
+
+// RateImport is an uploaded rate file: a CSV with a header row and "code"
+// and "rate" columns, one currency per row.
+type RateImport struct {
+	Content      io.Reader
+	Delimiter    string // One character, defaults to ","
+	DecimalComma bool   // Rates written as 15.850,25
+}
+
+// PaymentRequest is what a customer hands over in one currency.
+type PaymentRequest struct {
+	Currency string  `json:"currency"` // ISO 4217; the base currency when empty
+	Amount   float64 `json:"amount" validate:"gt=0"`
+}
+
+// CurrencyReceiptReport is what the completed sales of a period took in each
+// currency, with its worth in the base currency.
+type CurrencyReceiptReport struct {
+	BaseCurrency string                         `json:"base_currency"`
+	StartDate    time.Time                      `json:"start_date"`
+	EndDate      time.Time                      `json:"end_date"`
+	Receipts     []repositories.CurrencyReceipt `json:"receipts"`
+	TotalBase    float64                        `json:"total_base"`
+}
+
+type CurrencyService interface {
+	Create(ctx context.Context, req CurrencyRequest, userID uint) (*models.Currency, error)
+	Update(ctx context.Context, id uint, req UpdateCurrencyRequest) (*models.Currency, error)
+	GetByID(ctx context.Context, id uint) (*models.Currency, error)
+	GetAll(ctx context.Context, activeOnly bool) ([]models.Currency, error)
+	// SetRate sets the currency's rate by hand. Sales already made keep the
+	// rate they were converted at.
+	SetRate(ctx context.Context, id uint, rate float64, userID uint) (*models.Currency, error)
+	// ImportRates sets the rates of the currencies in a rate file, all of
+	// them or, when a row is wrong, none.
+	ImportRates(ctx context.Context, req RateImport, userID uint) ([]models.ExchangeRate, error)
+	GetRateHistory(ctx context.Context, id uint) ([]models.ExchangeRate, error)
+	// GetReceipts reports what was paid in each currency from startDate to
+	// endDate ("2026-03-01"), the current month up to now when both are empty.
+	GetReceipts(ctx context.Context, startDate, endDate string, now time.Time) (*CurrencyReceiptReport, error)
+	// ConvertPayments converts the payments of a sale to the base currency at
+	// the current rates. Only active currencies and the base currency are
+	// accepted.
+	ConvertPayments(ctx context.Context, payments []PaymentRequest) ([]models.TransactionPayment, error)
+}
+
+type currencyService struct {
+	repo        repositories.CurrencyRepository
+	settingRepo repositories.StoreSettingRepository
+	validator   *validator.Validate
+}
+
+func NewCurrencyService(repo repositories.CurrencyRepository, settingRepo repositories.StoreSettingRepository) CurrencyService {
+	return &currencyService{
+		repo:        repo,
+		settingRepo: settingRepo,
+		validator:   validator.New(),
+	}
+}
+
+// baseCurrency returns the store's base currency.
+func (s *currencyService) baseCurrency(ctx context.Context) (string, error) {
+	settings, err := s.settingRepo.GetSettings(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get store settings: %w", err)
+	}
+	if settings.BaseCurrency == "" {
+		return defaultBaseCurrency, nil
+	}
+	return settings.BaseCurrency, nil
+}
+
+func (s *currencyService) Create(ctx context.Context, req CurrencyRequest, userID uint) (*models.Currency, error) {
+	if err := s.validator.Struct(req); err != nil {
+		return nil, errors.New("validation failed: " + err.Error())
+	}
+	code := strings.ToUpper(req.Code)
+	base, err := s.baseCurrency(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if code == base {
+		return nil, fmt.Errorf("%s is the base currency", code)
+	}
+	if _, err := s.repo.GetByCode(ctx, code); err == nil {
+		return nil, fmt.Errorf("%w: currency %s already exists", customErrors.ErrConflict, code)
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to check existing currency: %w", err)
+	}
+
+	now := time.Now()
+	currency := &models.Currency{
+		Code:          code,
+		Name:          strings.TrimSpace(req.Name),
+		Symbol:        strings.TrimSpace(req.Symbol),
+		Rate:          req.Rate,
+		RateUpdatedAt: &now,
+		IsActive:      true,
+	}
+	if err := s.repo.Create(ctx, currency, userID); err != nil {
+		return nil, fmt.Errorf("failed to create currency: %w", err)
+	}
+	return currency, nil
+}
+
+func (s *currencyService) Update(ctx context.Context, id uint, req UpdateCurrencyRequest) (*models.Currency, error) {
+	if err := s.validator.Struct(req); err != nil {
+		return nil, errors.New("validation failed: " + err.Error())
+	}
+	currency, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	currency.Name = strings.TrimSpace(req.Name)
+	currency.Symbol = strings.TrimSpace(req.Symbol)
+	if req.IsActive != nil {
+		currency.IsActive = *req.IsActive
+	}
+	if err := s.repo.Update(ctx, currency); err != nil {
+		return nil, fmt.Errorf("failed to update currency: %w", err)
+	}
+	return currency, nil
+}
+
+func (s *currencyService) GetByID(ctx context.Context, id uint) (*models.Currency, error) {
+	currency, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customErrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get currency: %w", err)
+	}
+	return currency, nil
+}
+
+func (s *currencyService) GetAll(ctx context.Context, activeOnly bool) ([]models.Currency, error) {
+	return s.repo.GetAll(ctx, activeOnly)
+}
+
+func (s *currencyService) SetRate(ctx context.Context, id uint, rate float64, userID uint) (*models.Currency, error) {
+	if rate <= 0 {
+		return nil, errors.New("rate must be greater than zero")
+	}
+	currency, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err := s.repo.SetRates(ctx, []models.ExchangeRate{{
+		CurrencyID: id,
+		Rate:       rate,
+		Source:     models.ExchangeRateManual,
+		UserID:     userID,
+	}}, now); err != nil {
+		return nil, fmt.Errorf("failed to set exchange rate: %w", err)
+	}
+	currency.Rate, currency.RateUpdatedAt = rate, &now
+	return currency, nil
+}
+
+func (s *currencyService) ImportRates(ctx context.Context, req RateImport, userID uint) ([]models.ExchangeRate, error) {
+	if req.Delimiter != "" && utf8.RuneCountInString(req.Delimiter) != 1 {
+		return nil, errors.New("delimiter must be a single character")
+	}
+	reader := csv.NewReader(req.Content)
+	reader.TrimLeadingSpace = true
+	if req.Delimiter != "" {
+		reader.Comma, _ = utf8.DecodeRuneInString(req.Delimiter)
+	}
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("rate file is empty")
+		}
+		return nil, fmt.Errorf("failed to read rate file header: %w", err)
+	}
+	codeCol, rateCol := -1, -1
+	for i, name := range header {
+		switch strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))) {
+		case "code", "currency":
+			codeCol = i
+		case "rate":
+			rateCol = i
+		}
+	}
+	if codeCol < 0 || rateCol < 0 {
+		return nil, errors.New(`rate file needs a "code" and a "rate" column`)
+	}
+
+	currencies, err := s.repo.GetAll(ctx, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get currencies: %w", err)
+	}
+	byCode := make(map[string]*models.Currency, len(currencies))
+	for i := range currencies {
+		byCode[currencies[i].Code] = &currencies[i]
+	}
+
+	var rates []models.ExchangeRate
+	seen := make(map[string]int)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		row, _ := reader.FieldPos(0)
+		if err != nil {
+			return nil, fmt.Errorf("rate file: %w", err)
+		}
+		if codeCol >= len(record) || rateCol >= len(record) {
+			return nil, fmt.Errorf("row %d: missing code or rate", row)
+		}
+		code := strings.ToUpper(strings.TrimSpace(record[codeCol]))
+		if code == "" && strings.TrimSpace(record[rateCol]) == "" {
+			continue
+		}
+		currency, ok := byCode[code]
+		if !ok {
+			return nil, fmt.Errorf("row %d: unknown currency %q", row, code)
+		}
+		if first, ok := seen[code]; ok {
+			return nil, fmt.Errorf("row %d: %s is already on row %d", row, code, first)
+		}
+		seen[code] = row
+		rate, err := parseStatementAmount(record[rateCol], req.DecimalComma)
+		if err != nil || rate <= 0 {
+			return nil, fmt.Errorf("row %d: invalid rate %q", row, record[rateCol])
+		}
+		rates = append(rates, models.ExchangeRate{
+			CurrencyID: currency.ID,
+			Currency:   currency,
+			Rate:       rate,
+			Source:     models.ExchangeRateImport,
+			UserID:     userID,
+		})
+	}
+	if len(rates) == 0 {
+		return nil, errors.New("rate file has no rates")
+	}
+
+	if err := s.repo.SetRates(ctx, rates, time.Now()); err != nil {
+		return nil, fmt.Errorf("failed to import exchange rates: %w", err)
+	}
+	for i := range rates {
+		rates[i].Currency.Rate = rates[i].Rate
+		rates[i].Currency.RateUpdatedAt = &rates[i].CreatedAt
+	}
+	return rates, nil
+}
+
+func (s *currencyService) GetRateHistory(ctx context.Context, id uint) ([]models.ExchangeRate, error) {
+	if _, err := s.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.repo.GetRateHistory(ctx, id, rateHistoryLimit)
+}
+
+func (s *currencyService) GetReceipts(ctx context.Context, startDate, endDate string, now time.Time) (*CurrencyReceiptReport, error) {
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	end := dateOnly(now)
+	if startDate != "" {
+		t, err := time.ParseInLocation("2006-01-02", startDate, now.Location())
+		if err != nil {
+			return nil, fmt.Errorf("invalid start_date format, use YYYY-MM-DD: %w", err)
+		}
+		start = t
+	}
+	if endDate != "" {
+		t, err := time.ParseInLocation("2006-01-02", endDate, now.Location())
+		if err != nil {
+			return nil, fmt.Errorf("invalid end_date format, use YYYY-MM-DD: %w", err)
+		}
+		end = t
+	}
+	if end.Before(start) {
+		return nil, errors.New("end_date must not be before start_date")
+	}
+
+	base, err := s.baseCurrency(ctx)
+	if err != nil {
+		return nil, err
+	}
+	receipts, err := s.repo.GetReceipts(ctx, start, end.AddDate(0, 0, 1).Add(-time.Microsecond))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get currency receipts: %w", err)
+	}
+
+	report := &CurrencyReceiptReport{BaseCurrency: base, StartDate: start, EndDate: end, Receipts: receipts}
+	if report.Receipts == nil {
+		report.Receipts = []repositories.CurrencyReceipt{}
+	}
+	for i := range report.Receipts {
+		r := &report.Receipts[i]
+		if r.Amount != 0 {
+			r.AverageRate = r.BaseAmount / r.Amount
+		}
+		report.TotalBase = roundMoney(report.TotalBase + r.BaseAmount)
+	}
+	return report, nil
+}
+
+func (s *currencyService) ConvertPayments(ctx context.Context, payments []PaymentRequest) ([]models.TransactionPayment, error) {
+	base, err := s.baseCurrency(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := make([]models.TransactionPayment, 0, len(payments))
+	for _, p := range payments {
+		if p.Amount <= 0 {
+			return nil, errors.New("payment amount must be greater than zero")
+		}
+		line := models.TransactionPayment{
+			Currency: strings.ToUpper(strings.TrimSpace(p.Currency)),
+			Amount:   roundMoney(p.Amount),
+			Rate:     1,
+		}
+		if line.Currency == "" {
+			line.Currency = base
+		}
+		if line.Currency != base {
+			currency, err := s.repo.GetByCode(ctx, line.Currency)
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, fmt.Errorf("failed to get currency %s: %w", line.Currency, err)
+			}
+			if err != nil || !currency.IsActive {
+				return nil, fmt.Errorf("currency %s is not accepted", line.Currency)
+			}
+			line.Rate = currency.Rate
+		}
+		line.BaseAmount = roundMoney(line.Amount * line.Rate)
+		lines = append(lines, line)
+	}
+	return lines, nil
+}
@@ -12,7 +12,7 @@ import (
 type DashboardResponse struct {
 	TodaySales             float64                           `json:"today_sales"`
 	TodayTransactions      int64                             `json:"today_transactions"`
-	TodayItemsSold         int64                             `json:"today_items_sold"`
+	TodayItemsSold         float64                           `json:"today_items_sold"`
 	LowStockCount          int64                             `json:"low_stock_count"`
 	SalesDiff              float64                           `json:"sales_diff"`
 	TransactionsDiff       float64                           `json:"transactions_diff"`
@@ -23,6 +23,7 @@ type DashboardResponse struct {
 	LowStockProducts       []repositories.LowStockProduct    `json:"low_stock_products"`
 	PaymentMethodBreakdown []repositories.PaymentMethodData  `json:"payment_method_breakdown"`
 	CashFlowBreakdown      []repositories.CashFlowSourceData `json:"cash_flow_breakdown"`
+	OverBudgetCategories   []BudgetLine                      `json:"over_budget_categories"` // This month's, most overspent first
 }
 
 // DashboardService defines the contract for dashboard business logic
@@ -33,13 +34,15 @@ type DashboardService interface {
 type dashboardService struct {
 	repo         repositories.DashboardRepository
 	cashFlowRepo repositories.CashFlowRepository
+	budgets      BudgetService
 }
 
 // NewDashboardService creates a new dashboard service
-func NewDashboardService(repo repositories.DashboardRepository, cfRepo repositories.CashFlowRepository) DashboardService {
+func NewDashboardService(repo repositories.DashboardRepository, cfRepo repositories.CashFlowRepository, budgets BudgetService) DashboardService {
 	return &dashboardService{
 		repo:         repo,
 		cashFlowRepo: cfRepo,
+		budgets:      budgets,
 	}
 }
 
@@ -130,7 +133,7 @@ func (s *dashboardService) GetDashboard(ctx context.Context, dateRange string) (
 	// Calculate percentage diffs
 	salesDiff := calcDiffPercent(stats.TodaySales, prevStats.TodaySales)
 	transactionsDiff := calcDiffPercent(float64(stats.TodayTransactions), float64(prevStats.TodayTransactions))
-	itemsSoldDiff := calcDiffPercent(float64(stats.TodayItemsSold), float64(prevStats.TodayItemsSold))
+	itemsSoldDiff := calcDiffPercent(stats.TodayItemsSold, prevStats.TodayItemsSold)
 
 	// Get top 5 products
 	topProducts, err := s.repo.GetTopProducts(ctx, startDate, endDate, 5)
@@ -138,14 +141,14 @@ func (s *dashboardService) GetDashboard(ctx context.Context, dateRange string) (
 		return nil, err
 	}
 
-	// Get low stock count (threshold: 10 items)
-	lowStockCount, err := s.repo.GetLowStockCount(ctx, 10)
+	// Get low stock count (below each product's minimum stock)
+	lowStockCount, err := s.repo.GetLowStockCount(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	// Get low stock products (top 10 lowest stock)
-	lowStockProducts, err := s.repo.GetLowStockProducts(ctx, 10, 10)
+	lowStockProducts, err := s.repo.GetLowStockProducts(ctx, 10)
 	if err != nil {
 		fmt.Println("Error fetching low stock products:", err)
 		lowStockProducts = []repositories.LowStockProduct{}
@@ -185,6 +188,14 @@ func (s *dashboardService) GetDashboard(ctx context.Context, dateRange string) (
 		cashFlowBreakdown = []repositories.CashFlowSourceData{}
 	}
 
+	// Get the top 5 categories over this month's budget
+	overBudget := []BudgetLine{}
+	if budgetReport, err := s.budgets.GetReport(ctx, "", time.Now()); err != nil {
+		fmt.Println("Error fetching budget report:", err)
+	} else {
+		overBudget = OverBudget(budgetReport, 5)
+	}
+
 	return &DashboardResponse{
 		TodaySales:             stats.TodaySales,
 		TodayTransactions:      stats.TodayTransactions,
@@ -199,6 +210,6 @@ func (s *dashboardService) GetDashboard(ctx context.Context, dateRange string) (
 		LowStockProducts:       lowStockProducts,
 		PaymentMethodBreakdown: paymentMethods,
 		CashFlowBreakdown:      cashFlowBreakdown,
+		OverBudgetCategories:   overBudget,
 	}, nil
 }
-
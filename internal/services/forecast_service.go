@@ -0,0 +1,242 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"pos-api/internal/pkg/forecast"
+	"pos-api/internal/repositories"
+)
+
+// stockOutHorizon is how far ahead a stock-out date is searched for.
+const stockOutHorizon = 365
+
+// ForecastDay is the projected store-wide sales of one day
+type ForecastDay struct {
+	Date  string  `json:"date"`
+	Sales float64 `json:"sales"`
+	Items float64 `json:"items"`
+}
+
+// ForecastQuantity is the projected quantity sold on one day
+type ForecastQuantity struct {
+	Date     string  `json:"date"`
+	Quantity float64 `json:"quantity"`
+}
+
+// ProductForecast is the projected demand of one product and when its current
+// stock is expected to run out
+type ProductForecast struct {
+	ProductID    uint               `json:"product_id"`
+	ProductName  string             `json:"product_name"`
+	SKU          string             `json:"sku"`
+	CategoryID   uint               `json:"category_id"`
+	CategoryName string             `json:"category_name"`
+	Stock        float64            `json:"stock"`
+	AverageDaily float64            `json:"average_daily"` // Average sold per day over the history
+	Quantity     float64            `json:"quantity"`      // Projected total over the forecast days
+	Revenue      float64            `json:"revenue"`       // Quantity at the current price
+	Daily        []ForecastQuantity `json:"daily"`
+	// StockOutDate is nil when stock lasts beyond the search horizon
+	StockOutDate *string `json:"stock_out_date"`
+	DaysOfStock  *int    `json:"days_of_stock"`
+}
+
+// CategoryForecast is the projected demand of all products in a category
+type CategoryForecast struct {
+	CategoryID   uint               `json:"category_id"`
+	CategoryName string             `json:"category_name"`
+	Quantity     float64            `json:"quantity"`
+	Revenue      float64            `json:"revenue"`
+	Daily        []ForecastQuantity `json:"daily"`
+}
+
+// SalesForecast is the projected sales for the next Days days, fitted on the
+// last HistoryDays days
+type SalesForecast struct {
+	HistoryDays int                `json:"history_days"`
+	Days        int                `json:"days"`
+	StartDate   string             `json:"start_date"`
+	EndDate     string             `json:"end_date"`
+	Store       []ForecastDay      `json:"store"`
+	TotalSales  float64            `json:"total_sales"`
+	TotalItems  float64            `json:"total_items"`
+	Categories  []CategoryForecast `json:"categories"`
+	Products    []ProductForecast  `json:"products"`
+}
+
+// ForecastService defines the contract for demand forecasting
+type ForecastService interface {
+	// GetForecast projects sales for the next days per product, category and
+	// for the whole store from the last historyDays days of sales, and
+	// estimates when each product runs out of stock.
+	GetForecast(ctx context.Context, days, historyDays int) (*SalesForecast, error)
+}
+
+type forecastService struct {
+	repo repositories.ReportRepository
+}
+
+// NewForecastService creates a new forecast service
+func NewForecastService(repo repositories.ReportRepository) ForecastService {
+	return &forecastService{repo: repo}
+}
+
+func (s *forecastService) GetForecast(ctx context.Context, days, historyDays int) (*SalesForecast, error) {
+	if days <= 0 {
+		days = 14
+	}
+	if historyDays <= 0 {
+		historyDays = 56
+	}
+	if days > 90 {
+		return nil, errors.New("periode prakiraan maksimal 90 hari")
+	}
+	if historyDays < forecast.MinSeasonalDays || historyDays > 365 {
+		return nil, errors.New("periode histori harus antara 14 dan 365 hari")
+	}
+
+	// History ends yesterday so today's partial sales do not drag the level down
+	today := forecast.Day(time.Now())
+	start := today.AddDate(0, 0, -historyDays)
+	end := today.AddDate(0, 0, -1)
+
+	daily, err := s.repo.GetSalesReport(ctx, start, end)
+	if err != nil {
+		return nil, errors.New("gagal mengambil data penjualan harian")
+	}
+	productSales, err := s.repo.GetDailyProductSales(ctx, start, end)
+	if err != nil {
+		return nil, errors.New("gagal mengambil histori penjualan produk")
+	}
+	levels, err := s.repo.GetProductStockLevels(ctx)
+	if err != nil {
+		return nil, errors.New("gagal mengambil stok produk")
+	}
+
+	result := &SalesForecast{
+		HistoryDays: historyDays,
+		Days:        days,
+		StartDate:   today.Format("2006-01-02"),
+		EndDate:     today.AddDate(0, 0, days-1).Format("2006-01-02"),
+		Store:       make([]ForecastDay, 0, days),
+		Categories:  []CategoryForecast{},
+		Products:    make([]ProductForecast, 0, len(levels)),
+	}
+
+	// Store-wide sales and items from the daily sales report
+	salesSeries := make([]float64, historyDays)
+	itemSeries := make([]float64, historyDays)
+	for _, d := range daily {
+		if i, ok := seriesIndex(start, d.Date, historyDays); ok {
+			salesSeries[i] = d.TotalSales
+			itemSeries[i] = d.TotalItemsSold
+		}
+	}
+	salesPoints := forecast.Fit(start, salesSeries).Project(days)
+	itemPoints := forecast.Fit(start, itemSeries).Project(days)
+	for i := range salesPoints {
+		day := ForecastDay{
+			Date:  salesPoints[i].Date.Format("2006-01-02"),
+			Sales: roundMoney(salesPoints[i].Value),
+			Items: roundQuantity(itemPoints[i].Value),
+		}
+		result.Store = append(result.Store, day)
+		result.TotalSales = roundMoney(result.TotalSales + day.Sales)
+		result.TotalItems = roundQuantity(result.TotalItems + day.Items)
+	}
+
+	// Per product quantity series
+	series := make(map[uint][]float64)
+	for _, sale := range productSales {
+		i, ok := seriesIndex(start, sale.Date, historyDays)
+		if !ok {
+			continue
+		}
+		if series[sale.ProductID] == nil {
+			series[sale.ProductID] = make([]float64, historyDays)
+		}
+		series[sale.ProductID][i] += sale.Quantity
+	}
+
+	categories := make(map[uint]int)
+	for _, level := range levels {
+		values := series[level.ProductID]
+		if values == nil {
+			values = make([]float64, historyDays)
+		}
+		var sold float64
+		for _, v := range values {
+			sold += v
+		}
+
+		points := forecast.Fit(start, values).Project(stockOutHorizon)
+		product := ProductForecast{
+			ProductID:    level.ProductID,
+			ProductName:  level.ProductName,
+			SKU:          level.SKU,
+			CategoryID:   level.CategoryID,
+			CategoryName: level.CategoryName,
+			Stock:        level.Stock,
+			AverageDaily: roundQuantity(sold / float64(historyDays)),
+			Daily:        make([]ForecastQuantity, 0, days),
+		}
+		for _, p := range points[:days] {
+			quantity := roundQuantity(p.Value)
+			product.Daily = append(product.Daily, ForecastQuantity{Date: p.Date.Format("2006-01-02"), Quantity: quantity})
+			product.Quantity = roundQuantity(product.Quantity + quantity)
+		}
+		product.Revenue = roundMoney(product.Quantity * level.Price)
+
+		if date := forecast.StockOut(level.Stock, points); date != nil {
+			formatted := date.Format("2006-01-02")
+			daysLeft := int(math.Round(date.Sub(today).Hours() / 24))
+			product.StockOutDate = &formatted
+			product.DaysOfStock = &daysLeft
+		}
+		result.Products = append(result.Products, product)
+
+		i, ok := categories[level.CategoryID]
+		if !ok {
+			category := CategoryForecast{
+				CategoryID:   level.CategoryID,
+				CategoryName: level.CategoryName,
+				Daily:        make([]ForecastQuantity, days),
+			}
+			for d := range category.Daily {
+				category.Daily[d].Date = product.Daily[d].Date
+			}
+			result.Categories = append(result.Categories, category)
+			i = len(result.Categories) - 1
+			categories[level.CategoryID] = i
+		}
+		category := &result.Categories[i]
+		for d := range category.Daily {
+			category.Daily[d].Quantity = roundQuantity(category.Daily[d].Quantity + product.Daily[d].Quantity)
+		}
+		category.Quantity = roundQuantity(category.Quantity + product.Quantity)
+		category.Revenue = roundMoney(category.Revenue + product.Revenue)
+	}
+
+	sort.SliceStable(result.Categories, func(i, j int) bool {
+		return result.Categories[i].CategoryName < result.Categories[j].CategoryName
+	})
+	return result, nil
+}
+
+// seriesIndex returns the position of a YYYY-MM-DD date in a daily series
+// starting at start.
+func seriesIndex(start time.Time, date string, length int) (int, bool) {
+	if len(date) < 10 {
+		return 0, false
+	}
+	day, err := time.ParseInLocation("2006-01-02", date[:10], start.Location())
+	if err != nil {
+		return 0, false
+	}
+	i := int(math.Round(day.Sub(start).Hours() / 24))
+	return i, i >= 0 && i < length
+}
@@ -0,0 +1,278 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"pos-api/internal/models"
+	"pos-api/internal/repositories"
+
+	customErrors "pos-api/internal/pkg/errors"
+)
+
+// Integrity issue kinds
+const (
+	// IntegrityGap is an entry whose StockBefore is not the previous entry's StockAfter
+	IntegrityGap = "gap"
+	// IntegrityBadEntry is an entry whose StockAfter is not StockBefore plus its quantity
+	IntegrityBadEntry = "bad_entry"
+	// IntegrityBalance is a location whose stock differs from what its logs add up to
+	IntegrityBalance = "balance"
+	// IntegrityTotal is a product whose total stock differs from the sum over its locations
+	IntegrityTotal = "total"
+)
+
+// IntegrityIssue is one inconsistency found while replaying a product's logs.
+type IntegrityIssue struct {
+	Kind       string  `json:"kind"`
+	ProductID  uint    `json:"product_id"`
+	LocationID uint    `json:"location_id,omitempty"`
+	LogID      uint    `json:"log_id,omitempty"`
+	Expected   float64 `json:"expected"`
+	Recorded   float64 `json:"recorded"`
+	Difference float64 `json:"difference"` // Recorded - Expected
+	Message    string  `json:"message"`
+}
+
+// LocationReplay is the replay of a product's logs at one location.
+type LocationReplay struct {
+	LocationID uint    `json:"location_id"`
+	Entries    int     `json:"entries"`
+	Replayed   float64 `json:"replayed"` // Sum of the logged movements
+	Stock      float64 `json:"stock"`    // product_stocks level
+}
+
+// ProductReplay is the replay of one product's logs.
+type ProductReplay struct {
+	ProductID   uint                  `json:"product_id"`
+	Name        string                `json:"name"`
+	SKU         string                `json:"sku"`
+	Stock       float64               `json:"stock"`
+	Locations   []LocationReplay      `json:"locations"`
+	Issues      []IntegrityIssue      `json:"issues"`
+	Corrections []models.InventoryLog `json:"corrections,omitempty"`
+	// Unresolved lists what fix could not correct, e.g. stock that moved during the check
+	Unresolved []string `json:"unresolved,omitempty"`
+}
+
+// IntegrityReport is the result of an inventory integrity check.
+type IntegrityReport struct {
+	ProductsChecked    int             `json:"products_checked"`
+	ProductsWithIssues int             `json:"products_with_issues"`
+	Issues             int             `json:"issues"`
+	Corrections        int             `json:"corrections"`
+	Fixed              bool            `json:"fixed"`
+	Products           []ProductReplay `json:"products"` // Only products with issues
+}
+
+type InventoryIntegrityService interface {
+	// Check replays every product's inventory logs (or just productID's when
+	// it is not 0) and reports gaps and mismatches with the stock on record.
+	// With fix, each location whose stock differs from its logs gets an audit
+	// adjustment by userID booking the difference, and totals that differ
+	// from the sum over locations are recomputed. Stock levels never change.
+	Check(ctx context.Context, productID uint, fix bool, userID uint) (*IntegrityReport, error)
+}
+
+type inventoryIntegrityService struct {
+	repo repositories.InventoryIntegrityRepository
+}
+
+func NewInventoryIntegrityService(repo repositories.InventoryIntegrityRepository) InventoryIntegrityService {
+	return &inventoryIntegrityService{repo: repo}
+}
+
+func (s *inventoryIntegrityService) Check(ctx context.Context, productID uint, fix bool, userID uint) (*IntegrityReport, error) {
+	if fix && userID == 0 {
+		return nil, errors.New("validation failed: a user is required to post corrections")
+	}
+
+	products, err := s.repo.GetProducts(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load products: %w", err)
+	}
+	if productID != 0 && len(products) == 0 {
+		return nil, customErrors.ErrNotFound
+	}
+
+	report := &IntegrityReport{ProductsChecked: len(products), Fixed: fix, Products: []ProductReplay{}}
+	for _, product := range products {
+		replay, err := s.replayProduct(ctx, product)
+		if err != nil {
+			return nil, err
+		}
+		if len(replay.Issues) == 0 {
+			continue
+		}
+		if fix {
+			if err := s.correct(ctx, &replay, userID); err != nil {
+				return nil, err
+			}
+			report.Corrections += len(replay.Corrections)
+		}
+		report.ProductsWithIssues++
+		report.Issues += len(replay.Issues)
+		report.Products = append(report.Products, replay)
+	}
+	return report, nil
+}
+
+// replayProduct walks the product's logs location by location, comparing
+// each entry with the one before and the end result with the stock on record.
+func (s *inventoryIntegrityService) replayProduct(ctx context.Context, product models.Product) (ProductReplay, error) {
+	replay := ProductReplay{ProductID: product.ID, Name: product.Name, SKU: product.SKU, Stock: product.Stock, Issues: []IntegrityIssue{}}
+
+	levels, err := s.repo.GetLocationLevels(ctx, product.ID)
+	if err != nil {
+		return replay, fmt.Errorf("failed to load stock levels of product %d: %w", product.ID, err)
+	}
+	entries, err := s.repo.GetEntries(ctx, product.ID)
+	if err != nil {
+		return replay, fmt.Errorf("failed to load inventory logs of product %d: %w", product.ID, err)
+	}
+
+	// Locations in the order they first appear, stock rows before logs
+	var order []uint
+	byLocation := make(map[uint][]models.InventoryLog)
+	stock := make(map[uint]float64)
+	seen := make(map[uint]bool)
+	for _, level := range levels {
+		stock[level.LocationID] = level.Quantity
+		if !seen[level.LocationID] {
+			seen[level.LocationID] = true
+			order = append(order, level.LocationID)
+		}
+	}
+	for _, entry := range entries {
+		byLocation[entry.LocationID] = append(byLocation[entry.LocationID], entry)
+		if !seen[entry.LocationID] {
+			seen[entry.LocationID] = true
+			order = append(order, entry.LocationID)
+		}
+	}
+
+	var total float64
+	for _, locationID := range order {
+		location, issues := replayLocation(product.ID, locationID, byLocation[locationID], stock[locationID])
+		replay.Locations = append(replay.Locations, location)
+		replay.Issues = append(replay.Issues, issues...)
+		total += location.Stock
+	}
+
+	total = roundQuantity(total)
+	if diff := roundQuantity(product.Stock - total); diff != 0 {
+		replay.Issues = append(replay.Issues, IntegrityIssue{
+			Kind:       IntegrityTotal,
+			ProductID:  product.ID,
+			Expected:   total,
+			Recorded:   product.Stock,
+			Difference: diff,
+			Message:    fmt.Sprintf("product stock is %g but its locations add up to %g", product.Stock, total),
+		})
+	}
+	return replay, nil
+}
+
+// replayLocation checks one location's chain. An audit entry settles the
+// chain, so gaps before it are no longer reported.
+func replayLocation(productID, locationID uint, entries []models.InventoryLog, stock float64) (LocationReplay, []IntegrityIssue) {
+	location := LocationReplay{LocationID: locationID, Entries: len(entries), Stock: roundQuantity(stock)}
+	var issues []IntegrityIssue
+	var previous float64
+
+	for _, entry := range entries {
+		delta := movement(entry)
+		if entry.Type == "adjustment" && entry.Source == models.InventoryAuditSource {
+			issues = issues[:0]
+		} else if diff := roundQuantity(entry.StockBefore - previous); diff != 0 {
+			issues = append(issues, IntegrityIssue{
+				Kind:       IntegrityGap,
+				ProductID:  productID,
+				LocationID: locationID,
+				LogID:      entry.ID,
+				Expected:   previous,
+				Recorded:   entry.StockBefore,
+				Difference: diff,
+				Message:    fmt.Sprintf("log %d starts at %g but the previous entry ended at %g", entry.ID, entry.StockBefore, previous),
+			})
+		}
+		if expected := roundQuantity(entry.StockBefore + delta); expected != roundQuantity(entry.StockAfter) {
+			issues = append(issues, IntegrityIssue{
+				Kind:       IntegrityBadEntry,
+				ProductID:  productID,
+				LocationID: locationID,
+				LogID:      entry.ID,
+				Expected:   expected,
+				Recorded:   entry.StockAfter,
+				Difference: roundQuantity(entry.StockAfter - expected),
+				Message:    fmt.Sprintf("log %d moves %g from %g but ends at %g", entry.ID, delta, entry.StockBefore, entry.StockAfter),
+			})
+		}
+		location.Replayed = roundQuantity(location.Replayed + delta)
+		previous = entry.StockAfter
+	}
+
+	if diff := roundQuantity(location.Stock - location.Replayed); diff != 0 {
+		issues = append(issues, IntegrityIssue{
+			Kind:       IntegrityBalance,
+			ProductID:  productID,
+			LocationID: locationID,
+			Expected:   location.Replayed,
+			Recorded:   location.Stock,
+			Difference: diff,
+			Message:    fmt.Sprintf("stock at location %d is %g but its logs add up to %g", locationID, location.Stock, location.Replayed),
+		})
+	}
+	return location, issues
+}
+
+// movement is the signed change an entry made to its location's stock;
+// adjustments carry their sign in the quantity.
+func movement(entry models.InventoryLog) float64 {
+	if entry.Type == "out" {
+		return -entry.Quantity
+	}
+	return entry.Quantity
+}
+
+// correct books each location's unexplained difference as an audit entry and
+// recomputes the product total when it is off.
+func (s *inventoryIntegrityService) correct(ctx context.Context, replay *ProductReplay, userID uint) error {
+	for _, location := range replay.Locations {
+		diff := roundQuantity(location.Stock - location.Replayed)
+		if diff == 0 {
+			continue
+		}
+		log := models.InventoryLog{
+			ProductID:   replay.ProductID,
+			LocationID:  location.LocationID,
+			Type:        "adjustment",
+			Source:      models.InventoryAuditSource,
+			Quantity:    diff,
+			StockBefore: location.Replayed,
+			StockAfter:  location.Stock,
+			Notes:       fmt.Sprintf("Integrity check: %g units changed without an inventory log", math.Abs(diff)),
+			UserID:      userID,
+		}
+		if err := s.repo.PostCorrection(ctx, &log); err != nil {
+			if errors.Is(err, customErrors.ErrConflict) {
+				replay.Unresolved = append(replay.Unresolved, err.Error())
+				continue
+			}
+			return fmt.Errorf("failed to post correction for product %d: %w", replay.ProductID, err)
+		}
+		replay.Corrections = append(replay.Corrections, log)
+	}
+
+	for _, issue := range replay.Issues {
+		if issue.Kind != IntegrityTotal {
+			continue
+		}
+		if _, err := s.repo.SyncProductStock(ctx, replay.ProductID); err != nil {
+			return fmt.Errorf("failed to recompute stock of product %d: %w", replay.ProductID, err)
+		}
+	}
+	return nil
+}
@@ -4,63 +4,163 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"pos-api/internal/models"
 	"pos-api/internal/repositories"
+	"strings"
 	"time"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"gorm.io/gorm"
 )
 
 type StockAdjustmentRequest struct {
 	ProductID uint    `json:"product_id" validate:"required"`
 	Type      string  `json:"type" validate:"required,oneof=in out adjustment"` // "in", "out", "adjustment"
 	Source    string  `json:"source" validate:"required"`                       // "purchase", "return", "damage", "expired", "opname"
-	Quantity  int     `json:"quantity" validate:"required,gt=0"`
+	Quantity  float64 `json:"quantity" validate:"required,gt=0"`                // Fractional (kg) only for products sold by weight
 	CostPrice float64 `json:"cost_price" validate:"gte=0"`
 	Notes     string  `json:"notes"`
+	// LocationID is where the stock moves; 0 uses the default location
+	LocationID uint `json:"location_id"`
+	// LotNumber and ExpiryDate ("2026-02-16") put stock-in into a lot; leave
+	// both empty for untracked stock. A lot with only an expiry date is
+	// numbered after it.
+	LotNumber  string `json:"lot_number" validate:"max=50"`
+	ExpiryDate string `json:"expiry_date"`
+	// SerialNumbers lists the units moved; required, one per unit, for serialized products
+	SerialNumbers []string `json:"serial_numbers"`
+
+	// PurchaseOrderID is set internally by goods receipts, never from the request body
+	PurchaseOrderID *uint `json:"-"`
 }
 
 type InventoryLogService interface {
 	AdjustStock(ctx context.Context, req StockAdjustmentRequest, userID uint) (*models.InventoryLog, error)
+	// ReceivePurchaseOrder books the goods receipt lines of a purchase order
+	// as stock in, all of them or, when one fails, none.
+	ReceivePurchaseOrder(ctx context.Context, purchaseOrderID uint, reqs []StockAdjustmentRequest, userID uint) ([]models.InventoryLog, error)
 	GetLogsByProduct(ctx context.Context, productID uint, page, pageSize int) ([]models.InventoryLog, int64, error)
 	GetAllLogs(ctx context.Context, page, pageSize int, logType, source string, startDate, endDate *time.Time) ([]models.InventoryLog, int64, error)
 	GetInventoryStats(ctx context.Context, startDate, endDate *time.Time) (map[string]int64, error)
+	// GetShortfalls lists sales sold below the stock on record; status "open"
+	// (the default) only returns those still waiting for review, "all" every one.
+	GetShortfalls(ctx context.Context, page, pageSize int, status string) ([]models.InventoryLog, int64, error)
+	ReviewShortfall(ctx context.Context, id, userID uint, notes string) (*models.InventoryLog, error)
 }
 
 type inventoryLogService struct {
-	logRepo     repositories.InventoryLogRepository
-	productRepo repositories.ProductRepository
+	logRepo      repositories.InventoryLogRepository
+	productRepo  repositories.ProductRepository
+	locationRepo repositories.LocationRepository
 }
 
-func NewInventoryLogService(logRepo repositories.InventoryLogRepository, productRepo repositories.ProductRepository) InventoryLogService {
+func NewInventoryLogService(logRepo repositories.InventoryLogRepository, productRepo repositories.ProductRepository, locationRepo repositories.LocationRepository) InventoryLogService {
 	return &inventoryLogService{
-		logRepo:     logRepo,
-		productRepo: productRepo,
+		logRepo:      logRepo,
+		productRepo:  productRepo,
+		locationRepo: locationRepo,
 	}
 }
 
 func (s *inventoryLogService) AdjustStock(ctx context.Context, req StockAdjustmentRequest, userID uint) (*models.InventoryLog, error) {
+	adjustment, err := s.prepareAdjustment(ctx, req, userID)
+	if err != nil {
+		return nil, err
+	}
+	log := adjustment.Log
+
+	fmt.Println("DEBUG: Calling logRepo.ProcessAdjustment")
+	// Process atomically and publish event
+	if err := s.logRepo.ProcessAdjustment(ctx, log, adjustment.Product, adjustment.Lot); err != nil {
+		fmt.Println("DEBUG: logRepo.ProcessAdjustment failed", err)
+		return nil, fmt.Errorf("failed to process inventory adjustment: %w", err)
+	}
+	fmt.Println("DEBUG: logRepo.ProcessAdjustment succeeded")
+
+	return log, nil
+}
+
+func (s *inventoryLogService) ReceivePurchaseOrder(ctx context.Context, purchaseOrderID uint, reqs []StockAdjustmentRequest, userID uint) ([]models.InventoryLog, error) {
+	adjustments := make([]repositories.StockAdjustment, 0, len(reqs))
+	for _, req := range reqs {
+		req.Type = "in"
+		req.PurchaseOrderID = &purchaseOrderID
+		adjustment, err := s.prepareAdjustment(ctx, req, userID)
+		if err != nil {
+			return nil, fmt.Errorf("product %d: %w", req.ProductID, err)
+		}
+		adjustments = append(adjustments, adjustment)
+	}
+
+	if err := s.logRepo.ProcessReceipt(ctx, purchaseOrderID, adjustments); err != nil {
+		return nil, fmt.Errorf("failed to process goods receipt: %w", err)
+	}
+
+	logs := make([]models.InventoryLog, len(adjustments))
+	for i, adjustment := range adjustments {
+		logs[i] = *adjustment.Log
+	}
+	return logs, nil
+}
+
+// prepareAdjustment checks req and builds the log, with the product and the
+// lot stock in goes into, for the repository to book.
+func (s *inventoryLogService) prepareAdjustment(ctx context.Context, req StockAdjustmentRequest, userID uint) (repositories.StockAdjustment, error) {
+	var none repositories.StockAdjustment
+
 	// Get current product
 	product, err := s.productRepo.GetProductByID(ctx, req.ProductID)
 	if err != nil {
-		return nil, fmt.Errorf("product with ID %d not found", req.ProductID)
+		return none, fmt.Errorf("product with ID %d not found", req.ProductID)
 	}
 
-	stockBefore := product.Stock
-	var stockAfter int
+	req.Quantity = roundQuantity(req.Quantity)
+	if !product.SoldByWeight && req.Quantity != math.Trunc(req.Quantity) {
+		return none, errors.New("quantity must be a whole number for products not sold by weight")
+	}
+
+	lot, err := buildLot(req)
+	if err != nil {
+		return none, err
+	}
+
+	locationID := req.LocationID
+	if locationID == 0 {
+		location, err := s.locationRepo.GetDefault(ctx)
+		if err != nil {
+			return none, fmt.Errorf("failed to get default location: %w", err)
+		}
+		locationID = location.ID
+	} else {
+		location, err := s.locationRepo.GetByID(ctx, locationID)
+		if err != nil || !location.IsActive {
+			return none, fmt.Errorf("location with ID %d not found or inactive", locationID)
+		}
+	}
+
+	// Stock levels are per location; the total on the product is kept by the repository
+	stockBefore, err := s.locationRepo.GetStockLevel(ctx, req.ProductID, locationID)
+	if err != nil {
+		return none, fmt.Errorf("failed to get stock level: %w", err)
+	}
+	var stockAfter float64
 
 	switch req.Type {
 	case "in":
-		stockAfter = stockBefore + req.Quantity
+		stockAfter = roundQuantity(stockBefore + req.Quantity)
 	case "out":
 		if stockBefore < req.Quantity {
-			return nil, errors.New("insufficient stock for this operation")
+			return none, errors.New("insufficient stock for this operation")
 		}
-		stockAfter = stockBefore - req.Quantity
+		stockAfter = roundQuantity(stockBefore - req.Quantity)
 	case "adjustment":
 		// For adjustment, quantity is the NEW absolute stock level
 		stockAfter = req.Quantity
-		req.Quantity = stockAfter - stockBefore // Store the delta
+		req.Quantity = roundQuantity(stockAfter - stockBefore) // Store the delta
 	default:
-		return nil, errors.New("invalid stock operation type")
+		return none, errors.New("invalid stock operation type")
 	}
 
 	// Calculate total cost
@@ -74,7 +174,12 @@ func (s *inventoryLogService) AdjustStock(ctx context.Context, req StockAdjustme
 		absQuantity = -absQuantity
 	}
 
-	totalCost := costPrice * float64(absQuantity)
+	serials, err := normalizeSerials(product, absQuantity, req.SerialNumbers)
+	if err != nil {
+		return none, err
+	}
+
+	totalCost := costPrice * absQuantity
 
 	// Create the inventory log
 	log := &models.InventoryLog{
@@ -88,23 +193,45 @@ func (s *inventoryLogService) AdjustStock(ctx context.Context, req StockAdjustme
 		StockAfter:  stockAfter,
 		Notes:       req.Notes,
 		UserID:      userID,
+		LocationID:  locationID,
+
+		SerialNumbers:   serials,
+		PurchaseOrderID: req.PurchaseOrderID,
 	}
 
-	// Update the product stock before passing to repo
-	product.Stock = stockAfter
-	if req.Type == "in" && req.CostPrice > 0 {
-		product.Cost = req.CostPrice // Update cost price on purchase
+	if lot != nil {
+		lot.ProductID = req.ProductID
+		lot.LocationID = locationID
+		lot.CostPrice = costPrice
 	}
+	return repositories.StockAdjustment{Log: log, Product: product, Lot: lot}, nil
+}
 
-	fmt.Println("DEBUG: Calling logRepo.ProcessAdjustment")
-	// Process atomically and publish event
-	if err := s.logRepo.ProcessAdjustment(ctx, log, product); err != nil {
-		fmt.Println("DEBUG: logRepo.ProcessAdjustment failed", err)
-		return nil, fmt.Errorf("failed to process inventory adjustment: %w", err)
+// buildLot returns the lot a stock-in goes into, or nil for untracked stock.
+func buildLot(req StockAdjustmentRequest) (*models.ProductLot, error) {
+	lotNumber := strings.TrimSpace(req.LotNumber)
+	if lotNumber == "" && req.ExpiryDate == "" {
+		return nil, nil
+	}
+	if req.Type != "in" {
+		return nil, errors.New("lot_number and expiry_date can only be given for stock in")
+	}
+	if len(lotNumber) > 50 {
+		return nil, errors.New("lot_number must be at most 50 characters")
 	}
-	fmt.Println("DEBUG: logRepo.ProcessAdjustment succeeded")
 
-	return log, nil
+	lot := &models.ProductLot{LotNumber: lotNumber}
+	if req.ExpiryDate != "" {
+		expiry, err := time.Parse("2006-01-02", req.ExpiryDate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid expiry_date format, use YYYY-MM-DD: %w", err)
+		}
+		lot.ExpiryDate = &expiry
+		if lot.LotNumber == "" {
+			lot.LotNumber = "EXP-" + expiry.Format("20060102")
+		}
+	}
+	return lot, nil
 }
 
 func (s *inventoryLogService) GetLogsByProduct(ctx context.Context, productID uint, page, pageSize int) ([]models.InventoryLog, int64, error) {
@@ -126,3 +253,31 @@ func (s *inventoryLogService) GetAllLogs(ctx context.Context, page, pageSize int
 func (s *inventoryLogService) GetInventoryStats(ctx context.Context, startDate, endDate *time.Time) (map[string]int64, error) {
 	return s.logRepo.GetStats(ctx, startDate, endDate)
 }
+
+func (s *inventoryLogService) GetShortfalls(ctx context.Context, page, pageSize int, status string) ([]models.InventoryLog, int64, error) {
+	if status != "" && status != "open" && status != "all" {
+		return nil, 0, errors.New("status must be 'open' or 'all'")
+	}
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+	offset := (page - 1) * pageSize
+	return s.logRepo.GetShortfalls(ctx, pageSize, offset, status != "all")
+}
+
+func (s *inventoryLogService) ReviewShortfall(ctx context.Context, id, userID uint, notes string) (*models.InventoryLog, error) {
+	log, err := s.logRepo.ReviewShortfall(ctx, id, userID, strings.TrimSpace(notes))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customErrors.ErrNotFound
+		}
+		if errors.Is(err, customErrors.ErrConflict) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to review shortfall: %w", err)
+	}
+	return log, nil
+}
@@ -0,0 +1,391 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"pos-api/internal/models"
+	"pos-api/internal/repositories"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+// journalScopeSources lists the journal sources each export scope covers;
+// reversals go with the entries they reverse.
+var journalScopeSources = map[string][]string{
+	models.JournalScopeSales:     {models.JournalSale},
+	models.JournalScopeExpenses:  {models.JournalCashFlow, models.JournalBankFee, models.JournalSupplierPayment},
+	models.JournalScopeInventory: {models.JournalPurchase, models.JournalInventory},
+}
+
+// exportFields are the fields an export column can hold.
+var exportFields = map[string]bool{
+	models.ExportFieldDate:        true,
+	models.ExportFieldEntryNo:     true,
+	models.ExportFieldReference:   true,
+	models.ExportFieldSource:      true,
+	models.ExportFieldDescription: true,
+	models.ExportFieldAccountCode: true,
+	models.ExportFieldAccountName: true,
+	models.ExportFieldDebit:       true,
+	models.ExportFieldCredit:      true,
+	models.ExportFieldAmount:      true,
+	models.ExportFieldMemo:        true,
+}
+
+// DefaultExportLayout is the generic journal CSV exports are written as when
+// no template is chosen: one row per journal line with its account code.
+func DefaultExportLayout() models.ExportLayout {
+	return models.ExportLayout{
+		Columns: []models.ExportColumn{
+			{Header: "Date", Field: models.ExportFieldDate},
+			{Header: "Journal No", Field: models.ExportFieldEntryNo},
+			{Header: "Reference", Field: models.ExportFieldReference},
+			{Header: "Source", Field: models.ExportFieldSource},
+			{Header: "Description", Field: models.ExportFieldDescription},
+			{Header: "Account Code", Field: models.ExportFieldAccountCode},
+			{Header: "Account Name", Field: models.ExportFieldAccountName},
+			{Header: "Debit", Field: models.ExportFieldDebit},
+			{Header: "Credit", Field: models.ExportFieldCredit},
+			{Header: "Memo", Field: models.ExportFieldMemo},
+		},
+	}
+}
+
+type JournalExportTemplateRequest struct {
+	Name   string              `json:"name" validate:"required"`
+	Layout models.ExportLayout `json:"layout"`
+}
+
+type JournalExportRequest struct {
+	StartDate string `json:"start_date" validate:"required"` // "2026-03-01"
+	EndDate   string `json:"end_date" validate:"required"`
+	// Scopes are "sales", "expenses" and "inventory"; every scope when empty
+	Scopes     []string `json:"scopes" validate:"dive,oneof=sales expenses inventory"`
+	TemplateID *uint    `json:"template_id"` // The generic layout when nil
+}
+
+// JournalExportFile is an export written out with its layout.
+type JournalExportFile struct {
+	FileName string
+	Content  []byte
+}
+
+type JournalExportService interface {
+	CreateTemplate(ctx context.Context, req JournalExportTemplateRequest, userID uint) (*models.JournalExportTemplate, error)
+	UpdateTemplate(ctx context.Context, id uint, req JournalExportTemplateRequest) (*models.JournalExportTemplate, error)
+	DeleteTemplate(ctx context.Context, id uint) error
+	GetTemplate(ctx context.Context, id uint) (*models.JournalExportTemplate, error)
+	GetTemplates(ctx context.Context) ([]models.JournalExportTemplate, error)
+
+	// Create exports the journal entries of the period and scopes that no
+	// earlier export holds.
+	Create(ctx context.Context, req JournalExportRequest, userID uint) (*models.JournalExport, error)
+	GetExports(ctx context.Context, page, pageSize int) ([]models.JournalExport, int64, error)
+	GetExport(ctx context.Context, id uint) (*models.JournalExport, error)
+	// Download writes the export's entries as CSV in the layout it was made
+	// with.
+	Download(ctx context.Context, id uint) (*JournalExportFile, error)
+	// Delete drops the export, e.g. when the bookkeeper's import failed, so
+	// that its entries are exported again.
+	Delete(ctx context.Context, id uint) error
+}
+
+type journalExportService struct {
+	repo      repositories.JournalExportRepository
+	validator *validator.Validate
+}
+
+func NewJournalExportService(repo repositories.JournalExportRepository) JournalExportService {
+	return &journalExportService{
+		repo:      repo,
+		validator: validator.New(),
+	}
+}
+
+func (s *journalExportService) CreateTemplate(ctx context.Context, req JournalExportTemplateRequest, userID uint) (*models.JournalExportTemplate, error) {
+	template := &models.JournalExportTemplate{UserID: userID}
+	if err := s.applyTemplate(ctx, template, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreateTemplate(ctx, template); err != nil {
+		return nil, fmt.Errorf("failed to create export template: %w", err)
+	}
+	return template, nil
+}
+
+func (s *journalExportService) UpdateTemplate(ctx context.Context, id uint, req JournalExportTemplateRequest) (*models.JournalExportTemplate, error) {
+	template, err := s.GetTemplate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applyTemplate(ctx, template, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateTemplate(ctx, template); err != nil {
+		return nil, fmt.Errorf("failed to update export template: %w", err)
+	}
+	return template, nil
+}
+
+// applyTemplate validates req and copies it onto template.
+func (s *journalExportService) applyTemplate(ctx context.Context, template *models.JournalExportTemplate, req JournalExportTemplateRequest) error {
+	if err := s.validator.Struct(req); err != nil {
+		return errors.New("validation failed: " + err.Error())
+	}
+	if err := validateExportLayout(req.Layout); err != nil {
+		return err
+	}
+
+	name := strings.TrimSpace(req.Name)
+	existing, err := s.repo.GetTemplateByName(ctx, name)
+	switch {
+	case err == nil && existing.ID != template.ID:
+		return fmt.Errorf("%w: an export template named %q already exists", customErrors.ErrConflict, existing.Name)
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound):
+		return fmt.Errorf("failed to check existing export template: %w", err)
+	}
+
+	template.Name = name
+	template.Layout = req.Layout
+	return nil
+}
+
+// validateExportLayout checks that every column of the layout holds a known
+// field or a fixed value, and that its delimiter is a single character.
+func validateExportLayout(layout models.ExportLayout) error {
+	if len(layout.Columns) == 0 {
+		return errors.New("layout needs at least one column")
+	}
+	for i, col := range layout.Columns {
+		if strings.TrimSpace(col.Header) == "" && !layout.OmitHeader {
+			return fmt.Errorf("column %d needs a header", i+1)
+		}
+		if col.Field != "" && !exportFields[col.Field] {
+			return fmt.Errorf("column %d: unknown field %q", i+1, col.Field)
+		}
+		if col.Field != "" && col.Value != "" {
+			return fmt.Errorf("column %d: set either field or value, not both", i+1)
+		}
+	}
+	if layout.Delimiter != "" && utf8.RuneCountInString(layout.Delimiter) != 1 {
+		return errors.New("delimiter must be a single character")
+	}
+	for code := range layout.AccountCodes {
+		if strings.TrimSpace(code) == "" {
+			return errors.New("account_codes cannot map an empty account code")
+		}
+	}
+	return nil
+}
+
+func (s *journalExportService) DeleteTemplate(ctx context.Context, id uint) error {
+	if _, err := s.GetTemplate(ctx, id); err != nil {
+		return err
+	}
+	return s.repo.DeleteTemplate(ctx, id)
+}
+
+func (s *journalExportService) GetTemplate(ctx context.Context, id uint) (*models.JournalExportTemplate, error) {
+	template, err := s.repo.GetTemplate(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customErrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get export template: %w", err)
+	}
+	return template, nil
+}
+
+func (s *journalExportService) GetTemplates(ctx context.Context) ([]models.JournalExportTemplate, error) {
+	return s.repo.GetTemplates(ctx)
+}
+
+func (s *journalExportService) Create(ctx context.Context, req JournalExportRequest, userID uint) (*models.JournalExport, error) {
+	if err := s.validator.Struct(req); err != nil {
+		return nil, errors.New("validation failed: " + err.Error())
+	}
+	start, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start_date format, use YYYY-MM-DD: %w", err)
+	}
+	end, err := time.Parse("2006-01-02", req.EndDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid end_date format, use YYYY-MM-DD: %w", err)
+	}
+	if end.Before(start) {
+		return nil, errors.New("end_date must not be before start_date")
+	}
+
+	export := &models.JournalExport{
+		TemplateName: "Generic",
+		Layout:       DefaultExportLayout(),
+		StartDate:    start,
+		EndDate:      end,
+		UserID:       userID,
+	}
+	if req.TemplateID != nil {
+		template, err := s.GetTemplate(ctx, *req.TemplateID)
+		if err != nil {
+			return nil, err
+		}
+		export.TemplateName, export.Layout = template.Name, template.Layout
+	}
+
+	scopes := make(map[string]bool)
+	for _, scope := range req.Scopes {
+		scopes[scope] = true
+	}
+	if len(scopes) == 0 {
+		for scope := range journalScopeSources {
+			scopes[scope] = true
+		}
+	}
+	var sources []string
+	for scope := range scopes {
+		export.Scopes = append(export.Scopes, scope)
+		sources = append(sources, journalScopeSources[scope]...)
+	}
+	sort.Strings(export.Scopes)
+
+	created, err := s.repo.Create(ctx, export, sources, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create journal export: %w", err)
+	}
+	if !created {
+		return nil, fmt.Errorf("no journal entries left to export from %s to %s", req.StartDate, req.EndDate)
+	}
+	return export, nil
+}
+
+func (s *journalExportService) GetExports(ctx context.Context, page, pageSize int) ([]models.JournalExport, int64, error) {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+	return s.repo.GetExports(ctx, pageSize, (page-1)*pageSize)
+}
+
+func (s *journalExportService) GetExport(ctx context.Context, id uint) (*models.JournalExport, error) {
+	export, err := s.repo.GetExport(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customErrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get journal export: %w", err)
+	}
+	return export, nil
+}
+
+func (s *journalExportService) Download(ctx context.Context, id uint) (*JournalExportFile, error) {
+	export, err := s.GetExport(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.repo.GetRows(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get journal export lines: %w", err)
+	}
+
+	content, err := writeJournalExport(export.Layout, rows)
+	if err != nil {
+		return nil, err
+	}
+	return &JournalExportFile{
+		FileName: fmt.Sprintf("journal_%s_%s_%d.csv", export.StartDate.Format("20060102"), export.EndDate.Format("20060102"), export.ID),
+		Content:  content,
+	}, nil
+}
+
+// writeJournalExport writes rows as CSV in layout. Unlike the other CSV
+// exports it has no byte order mark, which accounting imports read as part
+// of the first header.
+func writeJournalExport(layout models.ExportLayout, rows []repositories.JournalExportRow) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if layout.Delimiter != "" {
+		writer.Comma, _ = utf8.DecodeRuneInString(layout.Delimiter)
+	}
+	dateLayout := "2006-01-02"
+	if layout.DateFormat != "" {
+		dateLayout = dateFormatTokens.Replace(layout.DateFormat)
+	}
+	amount := func(v float64) string {
+		s := strconv.FormatFloat(v, 'f', 2, 64)
+		if layout.DecimalComma {
+			s = strings.Replace(s, ".", ",", 1)
+		}
+		return s
+	}
+
+	if !layout.OmitHeader {
+		header := make([]string, len(layout.Columns))
+		for i, col := range layout.Columns {
+			header[i] = col.Header
+		}
+		if err := writer.Write(header); err != nil {
+			return nil, fmt.Errorf("failed to write journal export: %w", err)
+		}
+	}
+	record := make([]string, len(layout.Columns))
+	for _, row := range rows {
+		for i, col := range layout.Columns {
+			switch col.Field {
+			case "":
+				record[i] = col.Value
+			case models.ExportFieldDate:
+				record[i] = row.Date.Format(dateLayout)
+			case models.ExportFieldEntryNo:
+				record[i] = strconv.FormatUint(uint64(row.EntryID), 10)
+			case models.ExportFieldReference:
+				record[i] = row.Reference
+			case models.ExportFieldSource:
+				record[i] = row.Source
+			case models.ExportFieldDescription:
+				record[i] = row.Description
+			case models.ExportFieldAccountCode:
+				record[i] = row.AccountCode
+				if code, ok := layout.AccountCodes[row.AccountCode]; ok {
+					record[i] = code
+				}
+			case models.ExportFieldAccountName:
+				record[i] = row.AccountName
+			case models.ExportFieldDebit:
+				record[i] = amount(row.Debit)
+			case models.ExportFieldCredit:
+				record[i] = amount(row.Credit)
+			case models.ExportFieldAmount:
+				record[i] = amount(row.Debit - row.Credit)
+			case models.ExportFieldMemo:
+				record[i] = row.Memo
+			}
+		}
+		if err := writer.Write(record); err != nil {
+			return nil, fmt.Errorf("failed to write journal export: %w", err)
+		}
+	}
+	writer.Flush()
+	return buf.Bytes(), writer.Error()
+}
+
+func (s *journalExportService) Delete(ctx context.Context, id uint) error {
+	if _, err := s.GetExport(ctx, id); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, id)
+}
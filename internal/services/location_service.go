@@ -0,0 +1,277 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"pos-api/internal/models"
+	"pos-api/internal/repositories"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+type LocationRequest struct {
+	Code     string `json:"code" validate:"required,min=2,max=20"`
+	Name     string `json:"name" validate:"required,min=2,max=100"`
+	Type     string `json:"type" validate:"omitempty,oneof=store warehouse"` // Defaults to "store"
+	IsActive *bool  `json:"is_active"`                                       // Defaults to true on create, unchanged on update when omitted
+}
+
+type RegisterRequest struct {
+	Code       string `json:"code" validate:"required,min=2,max=20"`
+	Name       string `json:"name" validate:"max=100"`
+	LocationID uint   `json:"location_id" validate:"required"`
+	IsActive   *bool  `json:"is_active"`
+}
+
+type LocationService interface {
+	Create(ctx context.Context, req LocationRequest) (*models.Location, error)
+	Update(ctx context.Context, id uint, req LocationRequest) (*models.Location, error)
+	Delete(ctx context.Context, id uint) error
+	GetByID(ctx context.Context, id uint) (*models.Location, error)
+	GetAll(ctx context.Context, onlyActive bool) ([]models.Location, error)
+	SetDefault(ctx context.Context, id uint) (*models.Location, error)
+	GetStockLevels(ctx context.Context, page, pageSize int, locationID, productID uint) ([]models.ProductStock, int64, error)
+
+	CreateRegister(ctx context.Context, req RegisterRequest) (*models.Register, error)
+	UpdateRegister(ctx context.Context, id uint, req RegisterRequest) (*models.Register, error)
+	DeleteRegister(ctx context.Context, id uint) error
+	GetRegisterByID(ctx context.Context, id uint) (*models.Register, error)
+	GetRegisters(ctx context.Context, locationID uint) ([]models.Register, error)
+}
+
+type locationService struct {
+	repo      repositories.LocationRepository
+	validator *validator.Validate
+}
+
+func NewLocationService(repo repositories.LocationRepository) LocationService {
+	return &locationService{
+		repo:      repo,
+		validator: validator.New(),
+	}
+}
+
+func (s *locationService) Create(ctx context.Context, req LocationRequest) (*models.Location, error) {
+	if err := s.validator.Struct(req); err != nil {
+		return nil, errors.New("validation failed: " + err.Error())
+	}
+
+	location := &models.Location{
+		Code:     strings.ToUpper(strings.TrimSpace(req.Code)),
+		Name:     strings.TrimSpace(req.Name),
+		Type:     req.Type,
+		IsActive: true,
+	}
+	if location.Type == "" {
+		location.Type = "store"
+	}
+	if req.IsActive != nil {
+		location.IsActive = *req.IsActive
+	}
+
+	if err := s.repo.Create(ctx, location); err != nil {
+		if isDuplicateKey(err) {
+			return nil, customErrors.ErrConflict
+		}
+		return nil, fmt.Errorf("failed to create location: %w", err)
+	}
+
+	return location, nil
+}
+
+func (s *locationService) Update(ctx context.Context, id uint, req LocationRequest) (*models.Location, error) {
+	if err := s.validator.Struct(req); err != nil {
+		return nil, errors.New("validation failed: " + err.Error())
+	}
+
+	location, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	location.Code = strings.ToUpper(strings.TrimSpace(req.Code))
+	location.Name = strings.TrimSpace(req.Name)
+	if req.Type != "" {
+		location.Type = req.Type
+	}
+	if req.IsActive != nil {
+		if location.IsDefault && !*req.IsActive {
+			return nil, errors.New("the default location cannot be deactivated")
+		}
+		location.IsActive = *req.IsActive
+	}
+
+	if err := s.repo.Update(ctx, location); err != nil {
+		if isDuplicateKey(err) {
+			return nil, customErrors.ErrConflict
+		}
+		return nil, fmt.Errorf("failed to update location: %w", err)
+	}
+
+	return location, nil
+}
+
+// Delete removes a location. The default location and locations that still
+// hold stock are refused; transfer the stock out first.
+func (s *locationService) Delete(ctx context.Context, id uint) error {
+	location, err := s.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if location.IsDefault {
+		return errors.New("the default location cannot be deleted")
+	}
+
+	hasStock, err := s.repo.HasStock(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to check location stock: %w", err)
+	}
+	if hasStock {
+		return fmt.Errorf("location %s still has stock, transfer it out first", location.Code)
+	}
+
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *locationService) GetByID(ctx context.Context, id uint) (*models.Location, error) {
+	location, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customErrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get location: %w", err)
+	}
+	return location, nil
+}
+
+func (s *locationService) GetAll(ctx context.Context, onlyActive bool) ([]models.Location, error) {
+	return s.repo.GetAll(ctx, onlyActive)
+}
+
+// SetDefault makes the location the one used when no location is given.
+func (s *locationService) SetDefault(ctx context.Context, id uint) (*models.Location, error) {
+	location, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !location.IsActive {
+		return nil, errors.New("an inactive location cannot be the default")
+	}
+
+	if err := s.repo.SetDefault(ctx, id); err != nil {
+		return nil, fmt.Errorf("failed to set default location: %w", err)
+	}
+	return s.GetByID(ctx, id)
+}
+
+func (s *locationService) GetStockLevels(ctx context.Context, page, pageSize int, locationID, productID uint) ([]models.ProductStock, int64, error) {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+	offset := (page - 1) * pageSize
+	return s.repo.GetStockLevels(ctx, pageSize, offset, locationID, productID)
+}
+
+func (s *locationService) CreateRegister(ctx context.Context, req RegisterRequest) (*models.Register, error) {
+	if err := s.validator.Struct(req); err != nil {
+		return nil, errors.New("validation failed: " + err.Error())
+	}
+	if err := s.checkLocation(ctx, req.LocationID); err != nil {
+		return nil, err
+	}
+
+	register := &models.Register{
+		Code:       strings.ToUpper(strings.TrimSpace(req.Code)),
+		Name:       strings.TrimSpace(req.Name),
+		LocationID: req.LocationID,
+		IsActive:   true,
+	}
+	if req.IsActive != nil {
+		register.IsActive = *req.IsActive
+	}
+
+	if err := s.repo.CreateRegister(ctx, register); err != nil {
+		if isDuplicateKey(err) {
+			return nil, customErrors.ErrConflict
+		}
+		return nil, fmt.Errorf("failed to create register: %w", err)
+	}
+
+	return s.GetRegisterByID(ctx, register.ID)
+}
+
+func (s *locationService) UpdateRegister(ctx context.Context, id uint, req RegisterRequest) (*models.Register, error) {
+	if err := s.validator.Struct(req); err != nil {
+		return nil, errors.New("validation failed: " + err.Error())
+	}
+
+	register, err := s.GetRegisterByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkLocation(ctx, req.LocationID); err != nil {
+		return nil, err
+	}
+
+	register.Code = strings.ToUpper(strings.TrimSpace(req.Code))
+	register.Name = strings.TrimSpace(req.Name)
+	register.LocationID = req.LocationID
+	if req.IsActive != nil {
+		register.IsActive = *req.IsActive
+	}
+
+	if err := s.repo.UpdateRegister(ctx, register); err != nil {
+		if isDuplicateKey(err) {
+			return nil, customErrors.ErrConflict
+		}
+		return nil, fmt.Errorf("failed to update register: %w", err)
+	}
+
+	return s.GetRegisterByID(ctx, id)
+}
+
+func (s *locationService) DeleteRegister(ctx context.Context, id uint) error {
+	if _, err := s.GetRegisterByID(ctx, id); err != nil {
+		return err
+	}
+	return s.repo.DeleteRegister(ctx, id)
+}
+
+func (s *locationService) GetRegisterByID(ctx context.Context, id uint) (*models.Register, error) {
+	register, err := s.repo.GetRegisterByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customErrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get register: %w", err)
+	}
+	return register, nil
+}
+
+func (s *locationService) GetRegisters(ctx context.Context, locationID uint) ([]models.Register, error) {
+	return s.repo.GetRegisters(ctx, locationID)
+}
+
+// checkLocation makes sure registers are assigned to an existing, active location.
+func (s *locationService) checkLocation(ctx context.Context, id uint) error {
+	location, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("location with ID %d not found", id)
+		}
+		return fmt.Errorf("failed to get location: %w", err)
+	}
+	if !location.IsActive {
+		return fmt.Errorf("location %s is inactive", location.Code)
+	}
+	return nil
+}
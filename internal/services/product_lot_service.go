@@ -0,0 +1,157 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"pos-api/internal/models"
+	"pos-api/internal/repositories"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"gorm.io/gorm"
+)
+
+// ExpiringLot is a lot with stock left that expires within the report window.
+type ExpiringLot struct {
+	models.ProductLot
+	DaysLeft int     `json:"days_left"` // Negative when already expired
+	Expired  bool    `json:"expired"`
+	Value    float64 `json:"value"` // Remaining quantity * cost price
+}
+
+type ExpiringLotsReport struct {
+	Days          int           `json:"days"`
+	AsOf          string        `json:"as_of"`
+	ExpiredValue  float64       `json:"expired_value"`  // Cost of stock already past its expiry date
+	ExpiringValue float64       `json:"expiring_value"` // Cost of stock expiring within the window
+	Items         []ExpiringLot `json:"items"`
+}
+
+type WriteOffRequest struct {
+	Notes string `json:"notes"`
+}
+
+type ProductLotService interface {
+	GetByID(ctx context.Context, id uint) (*models.ProductLot, error)
+	GetAll(ctx context.Context, page, pageSize int, productID, locationID uint, includeEmpty bool) ([]models.ProductLot, int64, error)
+	// GetExpiring reports lots expiring within the next days days, plus those already expired.
+	GetExpiring(ctx context.Context, days int, locationID uint) (*ExpiringLotsReport, error)
+	// WriteOff removes the remaining stock of an expired lot.
+	WriteOff(ctx context.Context, id uint, req WriteOffRequest, userID uint) (*models.InventoryLog, error)
+	// WriteOffExpired writes off every expired lot, optionally at one location.
+	WriteOffExpired(ctx context.Context, locationID uint, req WriteOffRequest, userID uint) ([]models.InventoryLog, error)
+}
+
+type productLotService struct {
+	repo repositories.ProductLotRepository
+}
+
+func NewProductLotService(repo repositories.ProductLotRepository) ProductLotService {
+	return &productLotService{repo: repo}
+}
+
+func (s *productLotService) GetByID(ctx context.Context, id uint) (*models.ProductLot, error) {
+	lot, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customErrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get lot: %w", err)
+	}
+	return lot, nil
+}
+
+func (s *productLotService) GetAll(ctx context.Context, page, pageSize int, productID, locationID uint, includeEmpty bool) ([]models.ProductLot, int64, error) {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+	offset := (page - 1) * pageSize
+	return s.repo.GetAll(ctx, pageSize, offset, productID, locationID, includeEmpty)
+}
+
+func (s *productLotService) GetExpiring(ctx context.Context, days int, locationID uint) (*ExpiringLotsReport, error) {
+	if days < 0 {
+		return nil, errors.New("days must not be negative")
+	}
+
+	today := dateOnly(time.Now())
+	lots, err := s.repo.GetExpiring(ctx, today.AddDate(0, 0, days), locationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expiring lots: %w", err)
+	}
+
+	report := &ExpiringLotsReport{
+		Days:  days,
+		AsOf:  today.Format("2006-01-02"),
+		Items: make([]ExpiringLot, 0, len(lots)),
+	}
+	for _, lot := range lots {
+		item := ExpiringLot{
+			ProductLot: lot,
+			DaysLeft:   int(math.Round(dateOnly(*lot.ExpiryDate).Sub(today).Hours() / 24)),
+			Expired:    lot.IsExpired(today),
+			Value:      roundMoney(lot.Quantity * lot.CostPrice),
+		}
+		if item.Expired {
+			report.ExpiredValue = roundMoney(report.ExpiredValue + item.Value)
+		} else {
+			report.ExpiringValue = roundMoney(report.ExpiringValue + item.Value)
+		}
+		report.Items = append(report.Items, item)
+	}
+
+	return report, nil
+}
+
+func (s *productLotService) WriteOff(ctx context.Context, id uint, req WriteOffRequest, userID uint) (*models.InventoryLog, error) {
+	lot, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !lot.IsExpired(time.Now()) {
+		return nil, fmt.Errorf("lot %s has not expired yet", lot.LotNumber)
+	}
+
+	log, err := s.repo.WriteOff(ctx, id, userID, req.Notes)
+	if err != nil {
+		if errors.Is(err, customErrors.ErrConflict) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to write off lot: %w", err)
+	}
+	return log, nil
+}
+
+func (s *productLotService) WriteOffExpired(ctx context.Context, locationID uint, req WriteOffRequest, userID uint) ([]models.InventoryLog, error) {
+	yesterday := time.Now().AddDate(0, 0, -1)
+	lots, err := s.repo.GetExpiring(ctx, yesterday, locationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expired lots: %w", err)
+	}
+
+	logs := make([]models.InventoryLog, 0, len(lots))
+	for _, lot := range lots {
+		log, err := s.repo.WriteOff(ctx, lot.ID, userID, req.Notes)
+		if err != nil {
+			// Sold or written off since the list was read
+			if errors.Is(err, customErrors.ErrConflict) {
+				continue
+			}
+			return logs, fmt.Errorf("failed to write off lot %s: %w", lot.LotNumber, err)
+		}
+		logs = append(logs, *log)
+	}
+	return logs, nil
+}
+
+// dateOnly drops the time of day so dates compare as calendar days.
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,153 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"pos-api/internal/models"
+	"pos-api/internal/repositories"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+type RegisterSerialsRequest struct {
+	ProductID     uint     `json:"product_id" validate:"required"`
+	LocationID    uint     `json:"location_id"` // 0 uses the default location
+	SerialNumbers []string `json:"serial_numbers" validate:"required,min=1"`
+}
+
+type ProductSerialService interface {
+	GetAll(ctx context.Context, page, pageSize int, productID, locationID uint, status, search string) ([]models.ProductSerial, int64, error)
+	// Lookup finds a unit by serial number for warranty claims: when and how
+	// it was received, and the sale and customer it left with.
+	Lookup(ctx context.Context, serial string) ([]models.ProductSerial, error)
+	// Register records serial numbers for units of a serialized product that
+	// were already in stock before serials were captured.
+	Register(ctx context.Context, req RegisterSerialsRequest) ([]models.ProductSerial, error)
+}
+
+type productSerialService struct {
+	repo         repositories.ProductSerialRepository
+	productRepo  repositories.ProductRepository
+	locationRepo repositories.LocationRepository
+	validator    *validator.Validate
+}
+
+func NewProductSerialService(repo repositories.ProductSerialRepository, productRepo repositories.ProductRepository, locationRepo repositories.LocationRepository) ProductSerialService {
+	return &productSerialService{
+		repo:         repo,
+		productRepo:  productRepo,
+		locationRepo: locationRepo,
+		validator:    validator.New(),
+	}
+}
+
+func (s *productSerialService) GetAll(ctx context.Context, page, pageSize int, productID, locationID uint, status, search string) ([]models.ProductSerial, int64, error) {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+	offset := (page - 1) * pageSize
+	return s.repo.GetAll(ctx, pageSize, offset, productID, locationID, status, strings.TrimSpace(search))
+}
+
+func (s *productSerialService) Lookup(ctx context.Context, serial string) ([]models.ProductSerial, error) {
+	serial = strings.TrimSpace(serial)
+	if serial == "" {
+		return nil, errors.New("serial number is required")
+	}
+
+	units, err := s.repo.FindBySerial(ctx, serial)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up serial: %w", err)
+	}
+	if len(units) == 0 {
+		return nil, customErrors.ErrNotFound
+	}
+	return units, nil
+}
+
+func (s *productSerialService) Register(ctx context.Context, req RegisterSerialsRequest) ([]models.ProductSerial, error) {
+	if err := s.validator.Struct(req); err != nil {
+		return nil, errors.New("validation failed: " + err.Error())
+	}
+
+	product, err := s.productRepo.GetProductByID(ctx, req.ProductID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("product with ID %d not found", req.ProductID)
+		}
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+	if !product.Serialized {
+		return nil, fmt.Errorf("product %s does not track serial numbers", product.Name)
+	}
+
+	serials, err := normalizeSerials(product, float64(len(req.SerialNumbers)), req.SerialNumbers)
+	if err != nil {
+		return nil, err
+	}
+
+	locationID := req.LocationID
+	if locationID == 0 {
+		location, err := s.locationRepo.GetDefault(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get default location: %w", err)
+		}
+		locationID = location.ID
+	} else {
+		location, err := s.locationRepo.GetByID(ctx, locationID)
+		if err != nil || !location.IsActive {
+			return nil, fmt.Errorf("location with ID %d not found or inactive", locationID)
+		}
+	}
+
+	units, err := s.repo.Register(ctx, product.ID, locationID, serials)
+	if err != nil {
+		if errors.Is(err, customErrors.ErrConflict) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to register serials: %w", err)
+	}
+	return units, nil
+}
+
+// normalizeSerials trims the serial numbers given for moving quantity units of
+// a product and checks them: a serialized product needs exactly one distinct
+// serial per unit, any other product none.
+func normalizeSerials(product *models.Product, quantity float64, serials []string) ([]string, error) {
+	if !product.Serialized {
+		if len(serials) > 0 {
+			return nil, fmt.Errorf("product %s does not track serial numbers", product.Name)
+		}
+		return nil, nil
+	}
+
+	if float64(len(serials)) != quantity {
+		return nil, fmt.Errorf("product %s needs one serial number per unit: got %d for quantity %g", product.Name, len(serials), quantity)
+	}
+	normalized := make([]string, 0, len(serials))
+	seen := make(map[string]bool, len(serials))
+	for _, serial := range serials {
+		serial = strings.TrimSpace(serial)
+		if serial == "" {
+			return nil, errors.New("serial numbers must not be empty")
+		}
+		if len(serial) > 100 {
+			return nil, fmt.Errorf("serial number %s is longer than 100 characters", serial)
+		}
+		if seen[serial] {
+			return nil, fmt.Errorf("serial number %s is listed more than once", serial)
+		}
+		seen[serial] = true
+		normalized = append(normalized, serial)
+	}
+	return normalized, nil
+}
@@ -4,10 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
+	"sort"
 	"strings"
 	"time"
 
 	"pos-api/internal/models"
+	"pos-api/internal/pkg/gtin"
 	"pos-api/internal/repositories"
 
 	"github.com/go-playground/validator/v10"
@@ -18,71 +21,191 @@ import (
 
 // ProductRequest mendefinisikan DTO untuk membuat atau mengupdate produk.
 type ProductRequest struct {
-	Name        string  `json:"name" validate:"required,min=3,max=100"`
-	SKU         string  `json:"sku"`
-	Barcode     string  `json:"barcode"` // Optional, will be generated if empty
-	Description string  `json:"description"`
-	Price       float64 `json:"price" validate:"required,gt=0"` // Harus lebih besar dari 0
-	Cost        float64 `json:"cost" validate:"gt=0"`           // Harus lebih besar atau sama dengan 0
-	Stock       int     `json:"stock" validate:"gte=0"`
-	CategoryID  uint    `json:"category_id" validate:"required"`
+	Name         string  `json:"name" validate:"required,min=3,max=100"`
+	SKU          string  `json:"sku"`
+	Barcode      string  `json:"barcode"`                                // Optional, an internal EAN-13 will be allocated if empty
+	PLU          string  `json:"plu" validate:"omitempty,numeric,max=6"` // Kode PLU di timbangan, hanya untuk produk timbangan
+	SoldByWeight bool    `json:"sold_by_weight"`                         // Jika true, Price adalah harga per kg dan Stock dalam kg
+	Serialized   bool    `json:"serialized"`                             // Jika true, stok masuk dan penjualan wajib menyertakan nomor seri per unit
+	Description  string  `json:"description"`
+	Price        float64 `json:"price" validate:"required,gt=0"` // Harus lebih besar dari 0
+	Cost         float64 `json:"cost" validate:"gt=0"`           // Harus lebih besar atau sama dengan 0
+	Stock        float64 `json:"stock" validate:"gte=0"`         // Stok awal; saat update harus sama dengan stok sekarang
+	CategoryID   uint    `json:"category_id" validate:"required"`
+	// Level stok untuk peringatan stok rendah dan saran pemesanan ulang
+	MinStock     *float64 `json:"min_stock" validate:"omitempty,gte=0"` // Kosong = 10 saat membuat, tidak berubah saat update
+	MaxStock     float64  `json:"max_stock" validate:"gte=0"`           // 0 = tidak diatur
+	LeadTimeDays int      `json:"lead_time_days" validate:"gte=0,lte=365"`
+	SupplierID   *uint    `json:"supplier_id"` // Supplier utama, kosong = supplier PO terakhir
+	// Kebijakan stok minus khusus produk ini ("block", "warn", "allow"), kosong = ikut pengaturan toko
+	NegativeStockPolicy string `json:"negative_stock_policy"`
+}
+
+// defaultMinStock adalah batas stok rendah untuk produk yang tidak mengatur min_stock.
+const defaultMinStock = 10
+
+// ReorderSuggestion adalah saran jumlah pemesanan ulang untuk satu produk.
+type ReorderSuggestion struct {
+	repositories.ReorderCandidate
+	DailySales        float64 `json:"daily_sales"`   // Rata-rata terjual per hari dalam periode
+	ReorderPoint      float64 `json:"reorder_point"` // min_stock + penjualan selama lead time
+	TargetStock       float64 `json:"target_stock"`
+	SuggestedQuantity float64 `json:"suggested_quantity"`
+	EstimatedCost     float64 `json:"estimated_cost"`
+}
+
+// ReorderSupplierGroup mengelompokkan saran pemesanan per supplier.
+// SupplierID kosong berarti produk belum punya supplier.
+type ReorderSupplierGroup struct {
+	SupplierID    *uint               `json:"supplier_id"`
+	SupplierName  string              `json:"supplier_name"`
+	Items         []ReorderSuggestion `json:"items"`
+	EstimatedCost float64             `json:"estimated_cost"`
+}
+
+// ReorderSuggestions adalah hasil saran pemesanan ulang berdasarkan penjualan
+// selama Days hari terakhir.
+type ReorderSuggestions struct {
+	Days          int                    `json:"days"`
+	Groups        []ReorderSupplierGroup `json:"groups"`
+	TotalItems    int                    `json:"total_items"`
+	EstimatedCost float64                `json:"estimated_cost"`
 }
 
 // ProductService mendefinisikan kontrak untuk logika bisnis produk.
 type ProductService interface {
-	CreateProduct(ctx context.Context, req ProductRequest) (*models.Product, error)
+	// CreateProduct membuat produk baru; stok awal dicatat sebagai log inventori atas nama userID.
+	CreateProduct(ctx context.Context, req ProductRequest, userID uint) (*models.Product, error)
 	GetProduct(ctx context.Context, id uint) (*models.Product, error)
 	ListProducts(ctx context.Context, page, pageSize int, search string, stockFilter string, sortBy string, sortOrder string, onlyTrashed bool) ([]models.Product, int64, error)
 	GetLowStockProducts(ctx context.Context, threshold int) ([]models.Product, error)
 	GetStockCounts(ctx context.Context) (map[string]int64, error)
+	// GetReorderSuggestions mengusulkan jumlah pemesanan ulang per produk dari
+	// kecepatan penjualan days hari terakhir, dikelompokkan per supplier.
+	GetReorderSuggestions(ctx context.Context, days int) (*ReorderSuggestions, error)
+	// UpdateProduct mengubah data produk. Stok tidak bisa diubah di sini,
+	// gunakan penyesuaian stok di inventori agar setiap perubahan tercatat.
 	UpdateProduct(ctx context.Context, id uint, req ProductRequest) (*models.Product, error)
 	DeleteProduct(ctx context.Context, id uint) error
 	RestoreProduct(ctx context.Context, id uint) error
 	ForceDeleteProduct(ctx context.Context, id uint) error
+	ScanBarcode(ctx context.Context, code string) (*ScanResult, error)
 }
 
 type productService struct {
 	repo      repositories.ProductRepository
+	barcodes  BarcodeAllocator
+	scanner   BarcodeScanner
 	validator *validator.Validate
 }
 
 // NewProductService membuat instance ProductService baru.
-func NewProductService(repo repositories.ProductRepository) ProductService {
+func NewProductService(repo repositories.ProductRepository, barcodes BarcodeAllocator, scanner BarcodeScanner) ProductService {
 	return &productService{
 		repo:      repo,
+		barcodes:  barcodes,
+		scanner:   scanner,
 		validator: validator.New(),
 	}
 }
 
+// validateBarcode menolak barcode EAN/UPC yang check digit-nya salah.
+// Barcode non-numerik atau dengan panjang lain (kode internal lama) tetap diterima.
+func validateBarcode(barcode string) error {
+	if gtin.IsNumeric(barcode) && gtin.IsGTINLength(len(barcode)) && !gtin.Valid(barcode) {
+		return errors.New("validasi gagal: check digit barcode tidak valid")
+	}
+	return nil
+}
+
+// normalizeWeighing memvalidasi field produk timbangan, nomor seri dan
+// kebijakan stok minus, lalu menormalkan PLU (tanpa nol di depan) agar cocok
+// dengan hasil parsing label timbangan.
+func normalizeWeighing(req *ProductRequest) error {
+	req.PLU = strings.TrimLeft(req.PLU, "0")
+	if req.PLU != "" && !req.SoldByWeight {
+		return errors.New("validasi gagal: PLU hanya untuk produk yang dijual per berat")
+	}
+	if req.SoldByWeight && req.Serialized {
+		return errors.New("validasi gagal: produk timbangan tidak bisa memakai nomor seri")
+	}
+	if req.NegativeStockPolicy != "" && !validNegativeStockPolicy(req.NegativeStockPolicy) {
+		return ErrInvalidNegativeStockPolicy
+	}
+	if req.Serialized && req.NegativeStockPolicy != "" && req.NegativeStockPolicy != models.NegativeStockBlock {
+		return errors.New("validasi gagal: produk bernomor seri tidak bisa dijual melebihi stok")
+	}
+	if !req.SoldByWeight && req.Stock != math.Trunc(req.Stock) {
+		return errors.New("validasi gagal: stok produk non-timbangan harus bilangan bulat")
+	}
+	req.Stock = roundQuantity(req.Stock)
+	return nil
+}
+
+// validateReorderLevels memastikan stok maksimum tidak di bawah stok minimum.
+func validateReorderLevels(minStock, maxStock float64) error {
+	if maxStock > 0 && maxStock < minStock {
+		return errors.New("validasi gagal: max_stock tidak boleh lebih kecil dari min_stock")
+	}
+	return nil
+}
+
 // CreateProduct menangani pembuatan produk baru.
-func (s *productService) CreateProduct(ctx context.Context, req ProductRequest) (*models.Product, error) {
+func (s *productService) CreateProduct(ctx context.Context, req ProductRequest, userID uint) (*models.Product, error) {
 	// 1. Validasi Request DTO
 	if err := s.validator.Struct(req); err != nil {
 		return nil, errors.New("validasi gagal: " + err.Error())
 	}
+	if err := normalizeWeighing(&req); err != nil {
+		return nil, err
+	}
+	if req.Serialized && req.Stock > 0 {
+		return nil, errors.New("validasi gagal: stok awal produk bernomor seri harus 0, tambahkan stok lewat inventori beserta nomor serinya")
+	}
+	minStock := float64(defaultMinStock)
+	if req.MinStock != nil {
+		minStock = roundQuantity(*req.MinStock)
+	}
+	if err := validateReorderLevels(minStock, req.MaxStock); err != nil {
+		return nil, err
+	}
 
 	// 2. Logika Bisnis: Generate SKU dan Barcode jika kosong
 	if req.SKU == "" {
 		req.SKU = fmt.Sprintf("SKU-%d", time.Now().UnixNano())
 	}
 	if req.Barcode == "" {
-		// Simple numeric barcode generation: 8 + timestamp (micro)
-		req.Barcode = fmt.Sprintf("8%d", time.Now().UnixMicro())
+		barcode, err := s.barcodes.Allocate(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("gagal membuat barcode: %w", err)
+		}
+		req.Barcode = barcode
+	} else if err := validateBarcode(req.Barcode); err != nil {
+		return nil, err
 	}
 
 	product := models.Product{
-		Name:        req.Name,
-		SKU:         req.SKU,
-		Barcode:     req.Barcode,
-		Description: req.Description,
-		Price:       req.Price,
-		Cost:        req.Cost,
-		Stock:       req.Stock,
-		CategoryID:  req.CategoryID,
+		Name:         req.Name,
+		SKU:          req.SKU,
+		Barcode:      req.Barcode,
+		PLU:          req.PLU,
+		SoldByWeight: req.SoldByWeight,
+		Serialized:   req.Serialized,
+		Description:  req.Description,
+		Price:        req.Price,
+		Cost:         req.Cost,
+		Stock:        req.Stock,
+		CategoryID:   req.CategoryID,
+		MinStock:     minStock,
+		MaxStock:     roundQuantity(req.MaxStock),
+		LeadTimeDays: req.LeadTimeDays,
+		SupplierID:   req.SupplierID,
+
+		NegativeStockPolicy: req.NegativeStockPolicy,
 	}
 
 	// 3. Simpan ke Repository
-	if err := s.repo.CreateProduct(ctx, &product); err != nil {
+	if err := s.repo.CreateProduct(ctx, &product, userID); err != nil {
 		// Pengecekan Duplikat Key (Constraint Conflict)
 		if strings.Contains(err.Error(), "unique constrain") || strings.Contains(err.Error(), "duplicate key") {
 			return nil, customErrors.ErrConflict // <-- Mengembalikan Custom Error 409
@@ -124,18 +247,119 @@ func (s *productService) ListProducts(ctx context.Context, page, pageSize int, s
 	return products, count, nil
 }
 
+// GetLowStockProducts memakai threshold jika diisi, selain itu min_stock tiap produk.
 func (s *productService) GetLowStockProducts(ctx context.Context, threshold int) ([]models.Product, error) {
-	if threshold <= 0 {
-		threshold = 10 // Default threshold
+	if threshold < 0 {
+		threshold = 0
 	}
 	return s.repo.GetLowStockProducts(ctx, threshold)
 }
 
+func (s *productService) GetReorderSuggestions(ctx context.Context, days int) (*ReorderSuggestions, error) {
+	if days <= 0 {
+		days = 30
+	}
+	if days > 365 {
+		return nil, errors.New("validasi gagal: periode penjualan maksimal 365 hari")
+	}
+
+	since := time.Now().AddDate(0, 0, -days)
+	candidates, err := s.repo.GetReorderCandidates(ctx, since)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil data pemesanan ulang: %w", err)
+	}
+
+	result := &ReorderSuggestions{Days: days, Groups: []ReorderSupplierGroup{}}
+	groups := make(map[uint]int)
+	var unassigned *ReorderSupplierGroup
+	for _, candidate := range candidates {
+		suggestion, ok := suggestReorder(candidate, days)
+		if !ok {
+			continue
+		}
+
+		var group *ReorderSupplierGroup
+		if candidate.SupplierID == nil {
+			if unassigned == nil {
+				unassigned = &ReorderSupplierGroup{SupplierName: "Tanpa supplier"}
+			}
+			group = unassigned
+		} else {
+			i, found := groups[*candidate.SupplierID]
+			if !found {
+				result.Groups = append(result.Groups, ReorderSupplierGroup{
+					SupplierID:   candidate.SupplierID,
+					SupplierName: candidate.SupplierName,
+				})
+				i = len(result.Groups) - 1
+				groups[*candidate.SupplierID] = i
+			}
+			group = &result.Groups[i]
+		}
+
+		group.Items = append(group.Items, suggestion)
+		group.EstimatedCost = roundMoney(group.EstimatedCost + suggestion.EstimatedCost)
+		result.TotalItems++
+		result.EstimatedCost = roundMoney(result.EstimatedCost + suggestion.EstimatedCost)
+	}
+
+	sort.SliceStable(result.Groups, func(i, j int) bool {
+		return result.Groups[i].SupplierName < result.Groups[j].SupplierName
+	})
+	// Produk tanpa supplier ditampilkan paling akhir
+	if unassigned != nil {
+		result.Groups = append(result.Groups, *unassigned)
+	}
+	return result, nil
+}
+
+// suggestReorder menghitung saran pemesanan untuk satu produk. Produk perlu
+// dipesan jika stok ditambah yang sedang dipesan di bawah titik pemesanan
+// ulang (min_stock + penjualan selama lead time). Jumlah pesanan mengisi stok
+// sampai max_stock, atau jika tidak diatur, sampai titik pemesanan ulang
+// ditambah perkiraan penjualan satu periode.
+func suggestReorder(c repositories.ReorderCandidate, days int) (ReorderSuggestion, bool) {
+	suggestion := ReorderSuggestion{ReorderCandidate: c}
+	daily := c.QuantitySold / float64(days)
+	suggestion.DailySales = roundQuantity(daily)
+
+	reorderPoint := c.MinStock + daily*float64(c.LeadTimeDays)
+	target := c.MaxStock
+	if target < reorderPoint {
+		target = reorderPoint + daily*float64(days)
+	}
+	if !c.SoldByWeight {
+		reorderPoint = math.Ceil(roundQuantity(reorderPoint))
+		target = math.Ceil(roundQuantity(target))
+	}
+	suggestion.ReorderPoint = roundQuantity(reorderPoint)
+	suggestion.TargetStock = roundQuantity(target)
+
+	available := c.Stock + c.OnOrder
+	if available >= suggestion.ReorderPoint {
+		return suggestion, false
+	}
+
+	quantity := roundQuantity(suggestion.TargetStock - available)
+	if !c.SoldByWeight {
+		quantity = math.Ceil(quantity)
+	}
+	if quantity <= 0 {
+		return suggestion, false
+	}
+	suggestion.SuggestedQuantity = quantity
+	suggestion.EstimatedCost = roundMoney(quantity * c.Cost)
+	return suggestion, true
+}
+
 func (s *productService) UpdateProduct(ctx context.Context, id uint, req ProductRequest) (*models.Product, error) {
 	// 1. Validasi Request DTO
 	if err := s.validator.Struct(req); err != nil {
 		return nil, errors.New("validasi gagal: " + err.Error())
 	}
+	if err := normalizeWeighing(&req); err != nil {
+		return nil, err
+	}
 
 	// 2. Ambil produk yang akan diupdate
 	product, err := s.repo.GetProductByID(ctx, id)
@@ -146,6 +370,22 @@ func (s *productService) UpdateProduct(ctx context.Context, id uint, req Product
 		return nil, errors.New("gagal mengambil produk untuk di update")
 	}
 
+	// Stok hanya boleh berubah lewat inventori agar setiap perubahan tercatat
+	// di log; produk bernomor seri juga harus menyertakan nomor serinya
+	if req.Stock != product.Stock {
+		if product.Serialized || req.Serialized {
+			return nil, errors.New("validasi gagal: stok produk bernomor seri hanya bisa diubah lewat inventori beserta nomor serinya")
+		}
+		return nil, errors.New("validasi gagal: stok hanya bisa diubah lewat penyesuaian stok di inventori")
+	}
+	minStock := product.MinStock
+	if req.MinStock != nil {
+		minStock = roundQuantity(*req.MinStock)
+	}
+	if err := validateReorderLevels(minStock, req.MaxStock); err != nil {
+		return nil, err
+	}
+
 	// 3. Update field-field produk dengan data baru dari request
 	product.Name = req.Name
 	if req.SKU == "" {
@@ -155,19 +395,34 @@ func (s *productService) UpdateProduct(ctx context.Context, id uint, req Product
 	}
 
 	if req.Barcode == "" {
-		// Keep existing Barcode if not provided, OR generate if existing is empty (migration scenario)
+		// Keep existing Barcode if not provided, OR allocate if existing is empty (migration scenario)
 		if product.Barcode == "" {
-			product.Barcode = fmt.Sprintf("8%d", time.Now().UnixMicro())
+			barcode, err := s.barcodes.Allocate(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("gagal membuat barcode: %w", err)
+			}
+			product.Barcode = barcode
 		}
 	} else {
+		if err := validateBarcode(req.Barcode); err != nil {
+			return nil, err
+		}
 		product.Barcode = req.Barcode
 	}
 
+	product.PLU = req.PLU
+	product.SoldByWeight = req.SoldByWeight
+	product.Serialized = req.Serialized
 	product.Description = req.Description
 	product.Price = req.Price
 	product.Cost = req.Cost
-	product.Stock = req.Stock
 	product.CategoryID = req.CategoryID
+	product.MinStock = minStock
+	product.MaxStock = roundQuantity(req.MaxStock)
+	product.LeadTimeDays = req.LeadTimeDays
+	product.SupplierID = req.SupplierID
+	product.Supplier = nil
+	product.NegativeStockPolicy = req.NegativeStockPolicy
 
 	// 4. Simpan perubahan ke repository
 	if err := s.repo.UpdateProduct(ctx, product); err != nil {
@@ -217,3 +472,8 @@ func (s *productService) RestoreProduct(ctx context.Context, id uint) error {
 func (s *productService) ForceDeleteProduct(ctx context.Context, id uint) error {
 	return s.repo.ForceDeleteProduct(ctx, id)
 }
+
+// ScanBarcode me-resolve barcode yang di-scan kasir, termasuk label timbangan.
+func (s *productService) ScanBarcode(ctx context.Context, code string) (*ScanResult, error) {
+	return s.scanner.Scan(ctx, code)
+}
@@ -0,0 +1,385 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"pos-api/internal/models"
+	"pos-api/internal/repositories"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+type PurchaseOrderItemRequest struct {
+	ProductID uint    `json:"product_id" validate:"required"`
+	Quantity  float64 `json:"quantity" validate:"required,gt=0"`
+	UnitCost  float64 `json:"unit_cost" validate:"gte=0"` // Defaults to the product's current cost when 0
+}
+
+type PurchaseOrderRequest struct {
+	SupplierID   uint   `json:"supplier_id" validate:"required"`
+	ExpectedDate string `json:"expected_date"` // Optional, "2026-02-16"
+	Notes        string `json:"notes"`
+	// PaymentTermDays overrides the supplier's default credit term when set
+	PaymentTermDays *int `json:"payment_term_days" validate:"omitempty,gte=0,lte=365"`
+	// LocationID is where the goods will be received; omit for the default location
+	LocationID *uint                      `json:"location_id"`
+	Items      []PurchaseOrderItemRequest `json:"items" validate:"required,min=1,dive"`
+}
+
+type ReceiveItemRequest struct {
+	ProductID  uint    `json:"product_id" validate:"required"`
+	Quantity   float64 `json:"quantity" validate:"required,gt=0"`
+	UnitCost   float64 `json:"unit_cost" validate:"gte=0"` // Actual cost, defaults to the ordered unit cost when 0
+	LotNumber  string  `json:"lot_number"`
+	ExpiryDate string  `json:"expiry_date"` // YYYY-MM-DD
+	// SerialNumbers lists the units received; required, one per unit, for serialized products
+	SerialNumbers []string `json:"serial_numbers"`
+}
+
+// ReceivePurchaseOrderRequest records a goods receipt (full or partial) against a purchase order.
+type ReceivePurchaseOrderRequest struct {
+	Items []ReceiveItemRequest `json:"items" validate:"required,min=1,dive"`
+	Notes string               `json:"notes"`
+}
+
+type PurchaseOrderService interface {
+	Create(ctx context.Context, req PurchaseOrderRequest, userID uint) (*models.PurchaseOrder, error)
+	Update(ctx context.Context, id uint, req PurchaseOrderRequest) (*models.PurchaseOrder, error)
+	Delete(ctx context.Context, id uint) error
+	GetByID(ctx context.Context, id uint) (*models.PurchaseOrder, error)
+	GetAll(ctx context.Context, page, pageSize int, status string, supplierID uint) ([]models.PurchaseOrder, int64, error)
+	Send(ctx context.Context, id uint) (*models.PurchaseOrder, error)
+	Cancel(ctx context.Context, id uint) (*models.PurchaseOrder, error)
+	Receive(ctx context.Context, id uint, req ReceivePurchaseOrderRequest, userID uint) (*models.PurchaseOrder, error)
+}
+
+type purchaseOrderService struct {
+	repo         repositories.PurchaseOrderRepository
+	supplierRepo repositories.SupplierRepository
+	productRepo  repositories.ProductRepository
+	inventory    InventoryLogService
+	validator    *validator.Validate
+}
+
+func NewPurchaseOrderService(
+	repo repositories.PurchaseOrderRepository,
+	supplierRepo repositories.SupplierRepository,
+	productRepo repositories.ProductRepository,
+	inventory InventoryLogService,
+) PurchaseOrderService {
+	return &purchaseOrderService{
+		repo:         repo,
+		supplierRepo: supplierRepo,
+		productRepo:  productRepo,
+		inventory:    inventory,
+		validator:    validator.New(),
+	}
+}
+
+// buildItems validates the requested lines and prices them, returning the lines and the order total.
+func (s *purchaseOrderService) buildItems(ctx context.Context, reqs []PurchaseOrderItemRequest) ([]models.PurchaseOrderItem, float64, error) {
+	items := make([]models.PurchaseOrderItem, 0, len(reqs))
+	seen := make(map[uint]bool, len(reqs))
+	var total float64
+
+	for _, req := range reqs {
+		if seen[req.ProductID] {
+			return nil, 0, fmt.Errorf("product %d is listed more than once", req.ProductID)
+		}
+		seen[req.ProductID] = true
+
+		product, err := s.productRepo.GetProductByID(ctx, req.ProductID)
+		if err != nil {
+			return nil, 0, fmt.Errorf("product with ID %d not found", req.ProductID)
+		}
+
+		qty := roundQuantity(req.Quantity)
+		if !product.SoldByWeight && qty != math.Trunc(qty) {
+			return nil, 0, fmt.Errorf("quantity for %s must be a whole number", product.Name)
+		}
+
+		unitCost := req.UnitCost
+		if unitCost == 0 {
+			unitCost = product.Cost
+		}
+
+		subTotal := roundMoney(qty * unitCost)
+		total += subTotal
+		items = append(items, models.PurchaseOrderItem{
+			ProductID: req.ProductID,
+			Quantity:  qty,
+			UnitCost:  unitCost,
+			SubTotal:  subTotal,
+		})
+	}
+
+	return items, roundMoney(total), nil
+}
+
+func parseExpectedDate(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expected_date format, use YYYY-MM-DD: %w", err)
+	}
+	return &date, nil
+}
+
+func (s *purchaseOrderService) checkSupplier(ctx context.Context, id uint) (*models.Supplier, error) {
+	supplier, err := s.supplierRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("supplier with ID %d not found", id)
+	}
+	if !supplier.IsActive {
+		return nil, fmt.Errorf("supplier %s is inactive", supplier.Name)
+	}
+	return supplier, nil
+}
+
+// paymentTerm returns the requested credit term, falling back to the supplier default.
+func paymentTerm(req PurchaseOrderRequest, supplier *models.Supplier) int {
+	if req.PaymentTermDays != nil {
+		return *req.PaymentTermDays
+	}
+	return supplier.PaymentTermDays
+}
+
+func (s *purchaseOrderService) Create(ctx context.Context, req PurchaseOrderRequest, userID uint) (*models.PurchaseOrder, error) {
+	if err := s.validator.Struct(req); err != nil {
+		return nil, errors.New("validation failed: " + err.Error())
+	}
+	supplier, err := s.checkSupplier(ctx, req.SupplierID)
+	if err != nil {
+		return nil, err
+	}
+
+	expectedDate, err := parseExpectedDate(req.ExpectedDate)
+	if err != nil {
+		return nil, err
+	}
+
+	items, total, err := s.buildItems(ctx, req.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	po := &models.PurchaseOrder{
+		PONumber:        fmt.Sprintf("PO-%d", time.Now().UnixNano()),
+		SupplierID:      req.SupplierID,
+		Status:          models.PurchaseOrderDraft,
+		ExpectedDate:    expectedDate,
+		TotalAmount:     total,
+		PaymentTermDays: paymentTerm(req, supplier),
+		LocationID:      req.LocationID,
+		Notes:           req.Notes,
+		UserID:          userID,
+		Items:           items,
+	}
+
+	if err := s.repo.Create(ctx, po); err != nil {
+		return nil, fmt.Errorf("failed to create purchase order: %w", err)
+	}
+
+	return s.GetByID(ctx, po.ID)
+}
+
+func (s *purchaseOrderService) Update(ctx context.Context, id uint, req PurchaseOrderRequest) (*models.PurchaseOrder, error) {
+	if err := s.validator.Struct(req); err != nil {
+		return nil, errors.New("validation failed: " + err.Error())
+	}
+
+	po, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if po.Status != models.PurchaseOrderDraft {
+		return nil, fmt.Errorf("purchase order is '%s', only drafts can be edited", po.Status)
+	}
+	supplier, err := s.checkSupplier(ctx, req.SupplierID)
+	if err != nil {
+		return nil, err
+	}
+
+	expectedDate, err := parseExpectedDate(req.ExpectedDate)
+	if err != nil {
+		return nil, err
+	}
+
+	items, total, err := s.buildItems(ctx, req.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	po.SupplierID = req.SupplierID
+	po.ExpectedDate = expectedDate
+	po.Notes = req.Notes
+	po.TotalAmount = total
+	po.PaymentTermDays = paymentTerm(req, supplier)
+	po.LocationID = req.LocationID
+	po.Items = items
+
+	if err := s.repo.Update(ctx, po); err != nil {
+		return nil, fmt.Errorf("failed to update purchase order: %w", err)
+	}
+
+	return s.GetByID(ctx, id)
+}
+
+func (s *purchaseOrderService) Delete(ctx context.Context, id uint) error {
+	po, err := s.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if po.Status != models.PurchaseOrderDraft {
+		return fmt.Errorf("purchase order is '%s', only drafts can be deleted", po.Status)
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *purchaseOrderService) GetByID(ctx context.Context, id uint) (*models.PurchaseOrder, error) {
+	po, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customErrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get purchase order: %w", err)
+	}
+	return po, nil
+}
+
+func (s *purchaseOrderService) GetAll(ctx context.Context, page, pageSize int, status string, supplierID uint) ([]models.PurchaseOrder, int64, error) {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+	offset := (page - 1) * pageSize
+	return s.repo.GetAll(ctx, pageSize, offset, status, supplierID)
+}
+
+// Send marks a draft as sent to the supplier; only sent orders can be received.
+func (s *purchaseOrderService) Send(ctx context.Context, id uint) (*models.PurchaseOrder, error) {
+	return s.transition(ctx, id, models.PurchaseOrderSent, models.PurchaseOrderDraft)
+}
+
+// Cancel closes an order that has not received any goods yet.
+func (s *purchaseOrderService) Cancel(ctx context.Context, id uint) (*models.PurchaseOrder, error) {
+	return s.transition(ctx, id, models.PurchaseOrderCancelled, models.PurchaseOrderDraft, models.PurchaseOrderSent)
+}
+
+func (s *purchaseOrderService) transition(ctx context.Context, id uint, to string, from ...string) (*models.PurchaseOrder, error) {
+	po, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	allowed := false
+	for _, status := range from {
+		if po.Status == status {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return nil, fmt.Errorf("cannot change purchase order from '%s' to '%s'", po.Status, to)
+	}
+
+	updated, err := s.repo.UpdateStatus(ctx, id, to, from...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update purchase order status: %w", err)
+	}
+	if !updated {
+		return nil, fmt.Errorf("%w: purchase order changed while updating, try again", customErrors.ErrConflict)
+	}
+	po.Status = to
+	return po, nil
+}
+
+// Receive books a goods receipt. Each line goes through the regular inventory
+// adjustment flow (stock, InventoryLog and the penambahan_stok cash flow
+// expense), tagged with the purchase order so the purchase order listener can
+// update received quantities and status. All lines share one DB transaction
+// with the order locked, so a failed line leaves nothing received.
+func (s *purchaseOrderService) Receive(ctx context.Context, id uint, req ReceivePurchaseOrderRequest, userID uint) (*models.PurchaseOrder, error) {
+	if err := s.validator.Struct(req); err != nil {
+		return nil, errors.New("validation failed: " + err.Error())
+	}
+
+	po, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if po.Status != models.PurchaseOrderSent && po.Status != models.PurchaseOrderPartiallyReceived {
+		return nil, fmt.Errorf("purchase order is '%s', only sent orders can be received", po.Status)
+	}
+
+	lines := make(map[uint]models.PurchaseOrderItem, len(po.Items))
+	for _, item := range po.Items {
+		lines[item.ProductID] = item
+	}
+
+	// Validate every line up front so obvious mistakes do not leave a half-booked receipt
+	seen := make(map[uint]bool, len(req.Items))
+	for _, r := range req.Items {
+		line, ok := lines[r.ProductID]
+		if !ok {
+			return nil, fmt.Errorf("product %d is not on purchase order %s", r.ProductID, po.PONumber)
+		}
+		if seen[r.ProductID] {
+			return nil, fmt.Errorf("product %d is listed more than once", r.ProductID)
+		}
+		seen[r.ProductID] = true
+
+		outstanding := roundQuantity(line.Quantity - line.ReceivedQuantity)
+		if roundQuantity(r.Quantity) > outstanding {
+			return nil, fmt.Errorf("received quantity for %s exceeds the outstanding %g", line.Product.Name, outstanding)
+		}
+	}
+
+	notes := "Goods receipt " + po.PONumber
+	if req.Notes != "" {
+		notes += ": " + req.Notes
+	}
+
+	var locationID uint
+	if po.LocationID != nil {
+		locationID = *po.LocationID
+	}
+
+	adjustments := make([]StockAdjustmentRequest, 0, len(req.Items))
+	for _, r := range req.Items {
+		line := lines[r.ProductID]
+		unitCost := r.UnitCost
+		if unitCost == 0 {
+			unitCost = line.UnitCost
+		}
+
+		adjustments = append(adjustments, StockAdjustmentRequest{
+			ProductID:     r.ProductID,
+			Type:          "in",
+			Source:        "purchase",
+			Quantity:      r.Quantity,
+			CostPrice:     unitCost,
+			Notes:         notes,
+			LocationID:    locationID,
+			LotNumber:     r.LotNumber,
+			ExpiryDate:    r.ExpiryDate,
+			SerialNumbers: r.SerialNumbers,
+		})
+	}
+	if _, err := s.inventory.ReceivePurchaseOrder(ctx, po.ID, adjustments, userID); err != nil {
+		return nil, err
+	}
+
+	return s.GetByID(ctx, id)
+}
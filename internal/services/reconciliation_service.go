@@ -0,0 +1,378 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"pos-api/internal/models"
+	"pos-api/internal/repositories"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// defaultSettlementDays is how many days a settlement may be dated apart
+	// from its sale when the import does not say
+	defaultSettlementDays = 3
+	maxSettlementDays     = 31
+	// unmatchedLookbackDays is the period the unmatched items cover by default
+	unmatchedLookbackDays = 30
+)
+
+// StatementImport is a settlement statement file to import.
+type StatementImport struct {
+	PaymentMethodID uint
+	FileName        string
+	Content         io.Reader
+	// Mapping names the file's columns; when nil the mapping of the payment
+	// method's last import is used
+	Mapping *models.StatementMapping
+	// SettlementDays is how many days apart a line and its sale may be
+	// dated; 0 means the default of 3
+	SettlementDays int
+}
+
+// StatementImportResult reports what an import added and matched.
+type StatementImportResult struct {
+	Statement  *models.BankStatement `json:"statement"`
+	Imported   int                   `json:"imported"`   // Lines added
+	Duplicates int                   `json:"duplicates"` // Lines skipped, imported before
+	Matched    int                   `json:"matched"`    // Lines matched to a sale
+	Unmatched  int                   `json:"unmatched"`
+	TotalFee   float64               `json:"total_fee"` // Booked as merchant fee expenses
+}
+
+// UnmatchedItems is what is left to reconcile in a period: statement lines
+// without a sale, and non-cash sales no statement line settles.
+type UnmatchedItems struct {
+	StartDate        string                     `json:"start_date"`
+	EndDate          string                     `json:"end_date"`
+	Lines            []models.BankStatementLine `json:"lines"`
+	Transactions     []models.Transaction       `json:"transactions"`
+	LinesTotal       float64                    `json:"lines_total"`
+	TransactionTotal float64                    `json:"transactions_total"`
+}
+
+type ReconciliationService interface {
+	// Import reads a settlement statement of a non-cash payment method, skips
+	// the lines imported before, matches the rest to the method's unsettled
+	// sales by amount and date, and books their fees as merchant fee
+	// expenses.
+	Import(ctx context.Context, req StatementImport, userID uint) (*StatementImportResult, error)
+	GetStatements(ctx context.Context, paymentMethodID *uint) ([]models.BankStatement, error)
+	GetStatement(ctx context.Context, id uint) (*models.BankStatement, error)
+	// GetUnmatched lists the unmatched lines and unsettled sales from
+	// startDate to endDate ("2006-01-02"), the last 30 days up to now by
+	// default, of one payment method when paymentMethodID is set.
+	GetUnmatched(ctx context.Context, paymentMethodID *uint, startDate, endDate string, now time.Time) (*UnmatchedItems, error)
+	// Match settles a sale with a line by hand, whatever their amounts and dates.
+	Match(ctx context.Context, lineID, transactionID uint) (*models.BankStatementLine, error)
+	// Unmatch puts a matched or ignored line back to unmatched.
+	Unmatch(ctx context.Context, lineID uint) (*models.BankStatementLine, error)
+	// Ignore sets an unmatched line aside as not settling any sale.
+	Ignore(ctx context.Context, lineID uint) (*models.BankStatementLine, error)
+}
+
+type reconciliationService struct {
+	repo              repositories.ReconciliationRepository
+	paymentMethodRepo repositories.PaymentMethodRepository
+}
+
+func NewReconciliationService(repo repositories.ReconciliationRepository, paymentMethodRepo repositories.PaymentMethodRepository) ReconciliationService {
+	return &reconciliationService{repo: repo, paymentMethodRepo: paymentMethodRepo}
+}
+
+func (s *reconciliationService) Import(ctx context.Context, req StatementImport, userID uint) (*StatementImportResult, error) {
+	days := req.SettlementDays
+	switch {
+	case days == 0:
+		days = defaultSettlementDays
+	case days < 0 || days > maxSettlementDays:
+		return nil, fmt.Errorf("settlement_days must be between 1 and %d", maxSettlementDays)
+	}
+	name := strings.TrimSpace(filepath.Base(strings.ReplaceAll(req.FileName, "\\", "/")))
+	if name == "" || name == "." || name == "/" {
+		name = "statement.csv"
+	}
+
+	method, err := s.paymentMethodRepo.GetByID(ctx, req.PaymentMethodID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: payment method %d", customErrors.ErrNotFound, req.PaymentMethodID)
+		}
+		return nil, fmt.Errorf("failed to get payment method: %w", err)
+	}
+	if method.IsCash {
+		return nil, fmt.Errorf("%s is a cash payment method; only non-cash methods are settled by statements", method.Name)
+	}
+
+	mapping := req.Mapping
+	if mapping == nil {
+		if mapping, err = s.repo.LatestMapping(ctx, method.ID); err != nil {
+			return nil, fmt.Errorf("failed to get the last column mapping: %w", err)
+		}
+		if mapping == nil {
+			return nil, fmt.Errorf("a column mapping is required for the first %s statement", method.Name)
+		}
+	}
+	if err := validateStatementMapping(*mapping); err != nil {
+		return nil, errors.New("validation failed: " + err.Error())
+	}
+
+	parsed, err := parseStatement(req.Content, *mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	// Identical lines within a file are told apart by their occurrence, so a
+	// file imported again matches its earlier import line for line
+	occurrences := make(map[string]int)
+	fingerprints := make([]string, len(parsed))
+	for i, line := range parsed {
+		key := fmt.Sprintf("%d|%s|%s|%.2f|%.2f|%.2f|%s", method.ID, line.Date.Format("2006-01-02"),
+			line.Reference, line.Amount, line.Fee, line.NetAmount, line.Description)
+		occurrences[key]++
+		sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%d", key, occurrences[key])))
+		fingerprints[i] = hex.EncodeToString(sum[:])
+	}
+	imported, err := s.repo.ImportedFingerprints(ctx, fingerprints)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check imported lines: %w", err)
+	}
+
+	result := &StatementImportResult{}
+	statement := &models.BankStatement{
+		PaymentMethodID: method.ID,
+		FileName:        name,
+		Mapping:         *mapping,
+		UserID:          userID,
+	}
+	for i, line := range parsed {
+		if imported[fingerprints[i]] {
+			result.Duplicates++
+			continue
+		}
+		line.Fingerprint = fingerprints[i]
+		statement.Lines = append(statement.Lines, line)
+	}
+	if len(statement.Lines) == 0 {
+		return nil, fmt.Errorf("%w: all %d lines of the statement were imported before", customErrors.ErrConflict, result.Duplicates)
+	}
+
+	statement.StartDate, statement.EndDate = statement.Lines[0].Date, statement.Lines[0].Date
+	for _, line := range statement.Lines {
+		if line.Date.Before(statement.StartDate) {
+			statement.StartDate = line.Date
+		}
+		if line.Date.After(statement.EndDate) {
+			statement.EndDate = line.Date
+		}
+		statement.TotalAmount = roundMoney(statement.TotalAmount + line.Amount)
+		statement.TotalFee = roundMoney(statement.TotalFee + line.Fee)
+		statement.TotalNet = roundMoney(statement.TotalNet + line.NetAmount)
+	}
+	statement.LineCount = len(statement.Lines)
+
+	// Sales are fetched a day wider on each side, as their times are local
+	// while line dates are not; matchStatementLines compares the days
+	from := statement.StartDate.AddDate(0, 0, -days-1)
+	to := statement.EndDate.AddDate(0, 0, days+2)
+	sales, err := s.repo.GetUnsettled(ctx, method.Name, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get unsettled sales: %w", err)
+	}
+	matchStatementLines(statement.Lines, sales, days, time.Now())
+
+	if err := s.repo.Create(ctx, statement); err != nil {
+		if customErrors.Is(err, customErrors.ErrPeriodClosed) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to save statement: %w", err)
+	}
+
+	result.Statement = statement
+	result.Imported = len(statement.Lines)
+	for _, line := range statement.Lines {
+		if line.Status == models.StatementLineMatched {
+			result.Matched++
+		}
+	}
+	result.Unmatched = result.Imported - result.Matched
+	result.TotalFee = statement.TotalFee
+	return result, nil
+}
+
+// matchStatementLines matches each line to a sale of the same amount dated at
+// most days apart, the closest in date first, then a sale made before its
+// settlement over one made after, then the earliest. Each sale settles one
+// line.
+func matchStatementLines(lines []models.BankStatementLine, sales []models.Transaction, days int, now time.Time) {
+	order := make([]int, len(lines))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return lines[order[a]].Date.Before(lines[order[b]].Date) })
+
+	used := make([]bool, len(sales))
+	for _, i := range order {
+		line := &lines[i]
+		best, bestGap := -1, 0
+		for j := range sales {
+			if used[j] || roundMoney(sales[j].GrandTotal) != line.Amount {
+				continue
+			}
+			// Days from the sale to its settlement; negative when settled "before" it
+			gap := int(line.Date.Sub(dateOnly(sales[j].CreatedAt)).Hours() / 24)
+			if gap < -days || gap > days {
+				continue
+			}
+			if best < 0 || closerSettlement(gap, bestGap) {
+				best, bestGap = j, gap
+			}
+		}
+		if best < 0 {
+			continue
+		}
+		used[best] = true
+		matchedAt := now
+		line.Status = models.StatementLineMatched
+		line.TransactionID = &sales[best].ID
+		line.MatchedAt = &matchedAt
+	}
+}
+
+// closerSettlement reports whether a sale gap days before its settlement is
+// a better match than one bestGap days before it. Sales come oldest first, so
+// on a tie the one found first stays.
+func closerSettlement(gap, bestGap int) bool {
+	abs := func(n int) int {
+		if n < 0 {
+			return -n
+		}
+		return n
+	}
+	if abs(gap) != abs(bestGap) {
+		return abs(gap) < abs(bestGap)
+	}
+	return gap >= 0 && bestGap < 0
+}
+
+func (s *reconciliationService) GetStatements(ctx context.Context, paymentMethodID *uint) ([]models.BankStatement, error) {
+	return s.repo.GetStatements(ctx, paymentMethodID)
+}
+
+func (s *reconciliationService) GetStatement(ctx context.Context, id uint) (*models.BankStatement, error) {
+	statement, err := s.repo.GetStatement(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customErrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get statement: %w", err)
+	}
+	return statement, nil
+}
+
+func (s *reconciliationService) GetUnmatched(ctx context.Context, paymentMethodID *uint, startDate, endDate string, now time.Time) (*UnmatchedItems, error) {
+	end := dateOnly(now)
+	if endDate != "" {
+		t, err := time.Parse("2006-01-02", endDate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid end_date format, use YYYY-MM-DD: %w", err)
+		}
+		end = t
+	}
+	start := end.AddDate(0, 0, -unmatchedLookbackDays)
+	if startDate != "" {
+		t, err := time.Parse("2006-01-02", startDate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid start_date format, use YYYY-MM-DD: %w", err)
+		}
+		start = t
+	}
+	if end.Before(start) {
+		return nil, errors.New("end_date must not be before start_date")
+	}
+
+	method := ""
+	if paymentMethodID != nil {
+		pm, err := s.paymentMethodRepo.GetByID(ctx, *paymentMethodID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, fmt.Errorf("%w: payment method %d", customErrors.ErrNotFound, *paymentMethodID)
+			}
+			return nil, fmt.Errorf("failed to get payment method: %w", err)
+		}
+		method = pm.Name
+	}
+
+	lines, err := s.repo.GetUnmatchedLines(ctx, paymentMethodID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get unmatched lines: %w", err)
+	}
+	startLocal := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, now.Location())
+	endLocal := time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, now.Location()).AddDate(0, 0, 1)
+	sales, err := s.repo.GetUnsettled(ctx, method, startLocal, endLocal)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get unsettled sales: %w", err)
+	}
+
+	items := &UnmatchedItems{
+		StartDate:    start.Format("2006-01-02"),
+		EndDate:      end.Format("2006-01-02"),
+		Lines:        lines,
+		Transactions: sales,
+	}
+	for _, line := range lines {
+		items.LinesTotal = roundMoney(items.LinesTotal + line.Amount)
+	}
+	for _, sale := range sales {
+		items.TransactionTotal = roundMoney(items.TransactionTotal + sale.GrandTotal)
+	}
+	return items, nil
+}
+
+func (s *reconciliationService) Match(ctx context.Context, lineID, transactionID uint) (*models.BankStatementLine, error) {
+	line, err := s.repo.Match(ctx, lineID, transactionID)
+	if err != nil {
+		return nil, statementLineError(err)
+	}
+	return line, nil
+}
+
+func (s *reconciliationService) Unmatch(ctx context.Context, lineID uint) (*models.BankStatementLine, error) {
+	line, err := s.repo.Unmatch(ctx, lineID)
+	if err != nil {
+		return nil, statementLineError(err)
+	}
+	return line, nil
+}
+
+func (s *reconciliationService) Ignore(ctx context.Context, lineID uint) (*models.BankStatementLine, error) {
+	line, err := s.repo.Ignore(ctx, lineID)
+	if err != nil {
+		return nil, statementLineError(err)
+	}
+	return line, nil
+}
+
+// statementLineError maps the errors of changing a statement line.
+func statementLineError(err error) error {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return customErrors.ErrNotFound
+	case customErrors.Is(err, customErrors.ErrConflict):
+		return err
+	default:
+		return fmt.Errorf("failed to update statement line: %w", err)
+	}
+}
@@ -0,0 +1,243 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"pos-api/internal/models"
+	"pos-api/internal/repositories"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+type RecurringExpenseRequest struct {
+	Name      string  `json:"name" validate:"required,max=100"`
+	Source    string  `json:"source" validate:"required"` // Code of an active expense category
+	Amount    float64 `json:"amount" validate:"required,gt=0"`
+	Frequency string  `json:"frequency" validate:"required,oneof=weekly monthly yearly"`
+	// DayOfWeek is for weekly expenses, 0 = Sunday
+	DayOfWeek int `json:"day_of_week" validate:"gte=0,lte=6"`
+	// DayOfMonth is for monthly and yearly expenses; 31 means the last day of the month
+	DayOfMonth int    `json:"day_of_month" validate:"gte=0,lte=31"`
+	Month      int    `json:"month" validate:"gte=0,lte=12"`  // For yearly expenses
+	StartDate  string `json:"start_date" validate:"required"` // "2026-02-01"
+	EndDate    string `json:"end_date"`                       // Optional last day
+	Notes      string `json:"notes"`
+	IsActive   *bool  `json:"is_active"` // Defaults to true on create, unchanged on update when omitted
+}
+
+// UpcomingExpense is one occurrence of a recurring expense still to be booked
+type UpcomingExpense struct {
+	RecurringExpenseID uint      `json:"recurring_expense_id"`
+	Name               string    `json:"name"`
+	Source             string    `json:"source"`
+	Amount             float64   `json:"amount"`
+	DueDate            time.Time `json:"due_date"`
+}
+
+// UpcomingObligations previews the recurring expenses falling due in a period
+type UpcomingObligations struct {
+	From        time.Time         `json:"from"`
+	To          time.Time         `json:"to"`
+	Items       []UpcomingExpense `json:"items"`
+	TotalAmount float64           `json:"total_amount"`
+}
+
+type RecurringExpenseService interface {
+	Create(ctx context.Context, req RecurringExpenseRequest, userID uint) (*models.RecurringExpense, error)
+	// Update changes the template for the occurrences not booked yet; booked
+	// entries stay as they are.
+	Update(ctx context.Context, id uint, req RecurringExpenseRequest) (*models.RecurringExpense, error)
+	Delete(ctx context.Context, id uint) error
+	GetByID(ctx context.Context, id uint) (*models.RecurringExpense, error)
+	GetAll(ctx context.Context, onlyActive bool) ([]models.RecurringExpense, error)
+	// GetUpcoming previews the occurrences of the active templates falling
+	// due from today through the next days days that are not booked yet.
+	GetUpcoming(ctx context.Context, now time.Time, days int) (*UpcomingObligations, error)
+	// RunDue books every occurrence due up to now's date. It is safe to run
+	// again, or from several processes at once: each date is booked once.
+	RunDue(ctx context.Context, now time.Time) ([]models.CashFlow, error)
+}
+
+type recurringExpenseService struct {
+	repo         repositories.RecurringExpenseRepository
+	categoryRepo repositories.CashFlowCategoryRepository
+	validator    *validator.Validate
+}
+
+func NewRecurringExpenseService(repo repositories.RecurringExpenseRepository, categoryRepo repositories.CashFlowCategoryRepository) RecurringExpenseService {
+	return &recurringExpenseService{
+		repo:         repo,
+		categoryRepo: categoryRepo,
+		validator:    validator.New(),
+	}
+}
+
+func (s *recurringExpenseService) Create(ctx context.Context, req RecurringExpenseRequest, userID uint) (*models.RecurringExpense, error) {
+	expense := &models.RecurringExpense{IsActive: true, UserID: userID}
+	if err := s.apply(ctx, expense, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, expense); err != nil {
+		return nil, fmt.Errorf("failed to create recurring expense: %w", err)
+	}
+	return expense, nil
+}
+
+func (s *recurringExpenseService) Update(ctx context.Context, id uint, req RecurringExpenseRequest) (*models.RecurringExpense, error) {
+	expense, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.apply(ctx, expense, req); err != nil {
+		return nil, err
+	}
+
+	expense.Category = nil
+	if err := s.repo.Update(ctx, expense); err != nil {
+		return nil, fmt.Errorf("failed to update recurring expense: %w", err)
+	}
+	return expense, nil
+}
+
+// apply validates req and copies it onto expense.
+func (s *recurringExpenseService) apply(ctx context.Context, expense *models.RecurringExpense, req RecurringExpenseRequest) error {
+	if err := s.validator.Struct(req); err != nil {
+		return errors.New("validation failed: " + err.Error())
+	}
+	switch {
+	case req.Frequency != models.RecurrenceWeekly && req.DayOfMonth == 0:
+		return errors.New("day_of_month is required for monthly and yearly expenses")
+	case req.Frequency == models.RecurrenceYearly && req.Month == 0:
+		return errors.New("month is required for yearly expenses")
+	}
+
+	startDate, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		return fmt.Errorf("invalid start_date format, use YYYY-MM-DD: %w", err)
+	}
+	var endDate *time.Time
+	if req.EndDate != "" {
+		t, err := time.Parse("2006-01-02", req.EndDate)
+		if err != nil {
+			return fmt.Errorf("invalid end_date format, use YYYY-MM-DD: %w", err)
+		}
+		if t.Before(startDate) {
+			return errors.New("end_date must not be before start_date")
+		}
+		endDate = &t
+	}
+
+	category, err := s.categoryRepo.GetByCode(ctx, req.Source)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("unknown cash flow category %q", req.Source)
+		}
+		return fmt.Errorf("failed to get cash flow category: %w", err)
+	}
+	if category.Type != models.CashFlowCategoryExpense || category.IsSystem || !category.IsActive {
+		return fmt.Errorf("category %q is not an active expense category", req.Source)
+	}
+
+	expense.Name = strings.TrimSpace(req.Name)
+	expense.Source = req.Source
+	expense.Amount = roundMoney(req.Amount)
+	expense.Frequency = req.Frequency
+	expense.DayOfWeek, expense.DayOfMonth, expense.Month = req.DayOfWeek, req.DayOfMonth, req.Month
+	expense.StartDate = startDate
+	expense.EndDate = endDate
+	expense.Notes = req.Notes
+	if req.IsActive != nil {
+		expense.IsActive = *req.IsActive
+	}
+	return nil
+}
+
+func (s *recurringExpenseService) Delete(ctx context.Context, id uint) error {
+	if _, err := s.GetByID(ctx, id); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *recurringExpenseService) GetByID(ctx context.Context, id uint) (*models.RecurringExpense, error) {
+	expense, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customErrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get recurring expense: %w", err)
+	}
+	return expense, nil
+}
+
+func (s *recurringExpenseService) GetAll(ctx context.Context, onlyActive bool) ([]models.RecurringExpense, error) {
+	return s.repo.GetAll(ctx, onlyActive)
+}
+
+func (s *recurringExpenseService) GetUpcoming(ctx context.Context, now time.Time, days int) (*UpcomingObligations, error) {
+	if days <= 0 {
+		days = 30
+	}
+	today := dateOnly(now)
+	upcoming := &UpcomingObligations{From: today, To: today.AddDate(0, 0, days), Items: []UpcomingExpense{}}
+
+	expenses, err := s.repo.GetAll(ctx, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recurring expenses: %w", err)
+	}
+	for _, expense := range expenses {
+		from := today
+		if expense.BookedThrough != nil && !expense.BookedThrough.Before(today) {
+			from = expense.BookedThrough.AddDate(0, 0, 1)
+		}
+		for _, due := range expense.Occurrences(from, upcoming.To) {
+			upcoming.Items = append(upcoming.Items, UpcomingExpense{
+				RecurringExpenseID: expense.ID,
+				Name:               expense.Name,
+				Source:             expense.Source,
+				Amount:             expense.Amount,
+				DueDate:            due,
+			})
+			upcoming.TotalAmount = roundMoney(upcoming.TotalAmount + expense.Amount)
+		}
+	}
+
+	sort.SliceStable(upcoming.Items, func(i, j int) bool {
+		return upcoming.Items[i].DueDate.Before(upcoming.Items[j].DueDate)
+	})
+	return upcoming, nil
+}
+
+func (s *recurringExpenseService) RunDue(ctx context.Context, now time.Time) ([]models.CashFlow, error) {
+	today := dateOnly(now)
+	expenses, err := s.repo.GetDue(ctx, today)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get due recurring expenses: %w", err)
+	}
+
+	// One template failing must not hold the others back
+	var booked []models.CashFlow
+	var errs []error
+	for _, expense := range expenses {
+		flows, err := s.repo.Book(ctx, expense.ID, today)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("recurring expense %d: %w", expense.ID, err))
+			continue
+		}
+		if len(flows) > 0 {
+			slog.Info("Recurring expense booked", "recurring_expense_id", expense.ID, "entries", len(flows))
+		}
+		booked = append(booked, flows...)
+	}
+	return booked, errors.Join(errs...)
+}
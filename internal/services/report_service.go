@@ -3,8 +3,11 @@ package services
 import (
 	"context"
 	"errors"
+	"math"
+	"strings"
 	"time"
 
+	"pos-api/internal/models"
 	"pos-api/internal/repositories"
 )
 
@@ -30,11 +33,91 @@ type ProductReportResponse struct {
 	EndDate   string                       `json:"end_date"`
 }
 
+// Periods a profit & loss statement can be compared with
+const (
+	CompareNone     = ""
+	ComparePrevious = "previous" // The period of the same length just before; the previous month(s) for whole months
+	CompareYear     = "year"     // The same period a year earlier
+)
+
+// StatementLine is the balance of one ledger account on a statement
+type StatementLine struct {
+	Code   string  `json:"code"`
+	Name   string  `json:"name"`
+	Amount float64 `json:"amount"`
+}
+
+// ProfitAndLoss is the profit & loss statement of a period, from the
+// revenue and expense accounts of the ledger
+type ProfitAndLoss struct {
+	StartDate              string          `json:"start_date"`
+	EndDate                string          `json:"end_date"`
+	GrossSales             float64         `json:"gross_sales"` // Sales account, less the returns posted in the period
+	Discounts              float64         `json:"discounts"`
+	NetSales               float64         `json:"net_sales"`
+	COGS                   float64         `json:"cogs"`      // From the cost each item was sold at
+	Shrinkage              float64         `json:"shrinkage"` // Stock damaged, expired or counted off, less stock found
+	GrossProfit            float64         `json:"gross_profit"`
+	GrossMargin            float64         `json:"gross_margin"` // percentage of net sales
+	OtherIncome            []StatementLine `json:"other_income"`
+	TotalOtherIncome       float64         `json:"total_other_income"`
+	OperatingExpenses      []StatementLine `json:"operating_expenses"` // Expense accounts other than COGS and shrinkage
+	TotalOperatingExpenses float64         `json:"total_operating_expenses"`
+	NetProfit              float64         `json:"net_profit"`
+	NetMargin              float64         `json:"net_margin"` // percentage of net sales
+}
+
+// BalanceSheet is what the store owns and owes at the end of a day, from
+// the ledger balances on that day
+type BalanceSheet struct {
+	AsOf        string  `json:"as_of"`
+	Cash        float64 `json:"cash"`        // Cash account
+	Bank        float64 `json:"bank"`        // Bank accounts, including those of non-cash payment methods
+	Receivables float64 `json:"receivables"` // Asset accounts other than cash, bank and inventory
+	Inventory   float64 `json:"inventory"`   // Stock at cost
+	TotalAssets float64 `json:"total_assets"`
+	Payables    float64 `json:"payables"` // Liability accounts: supplier payables
+	Capital     float64 `json:"capital"`  // Owner capital and opening balance equity, less drawings
+	// RetainedEarnings is the profit kept in the business: revenue less
+	// expenses up to the date
+	RetainedEarnings float64 `json:"retained_earnings"`
+	TotalEquity      float64 `json:"total_equity"`
+}
+
+// StatementChange is how much a figure moved against the comparison
+type StatementChange struct {
+	Amount  float64  `json:"amount"`
+	Percent *float64 `json:"percent"` // nil when the comparison figure is 0
+}
+
+// ProfitAndLossReport is a profit & loss statement, optionally with the one
+// it is compared with and the changes of its main figures
+type ProfitAndLossReport struct {
+	Current  *ProfitAndLoss             `json:"current"`
+	Previous *ProfitAndLoss             `json:"previous,omitempty"`
+	Changes  map[string]StatementChange `json:"changes,omitempty"`
+}
+
+// BalanceSheetReport is a balance sheet, optionally with the one it is
+// compared with and the changes of its figures
+type BalanceSheetReport struct {
+	Current  *BalanceSheet              `json:"current"`
+	Previous *BalanceSheet              `json:"previous,omitempty"`
+	Changes  map[string]StatementChange `json:"changes,omitempty"`
+}
+
 // ReportService defines the contract for report business logic
 type ReportService interface {
 	GetSalesReport(ctx context.Context, startDate, endDate string) (*SalesReportResponse, error)
 	GetProductReport(ctx context.Context, startDate, endDate string, limit int) (*ProductReportResponse, error)
 	GetStockValue(ctx context.Context) (*repositories.StockValue, error)
+	// GetProfitAndLoss builds the profit & loss statement of a date range,
+	// compared with the previous period or the same period a year earlier
+	// when compare says so.
+	GetProfitAndLoss(ctx context.Context, startDate, endDate, compare string) (*ProfitAndLossReport, error)
+	// GetBalanceSheet builds the balance sheet at the end of date, compared
+	// with the one at the end of compareDate when it is not empty.
+	GetBalanceSheet(ctx context.Context, date, compareDate string) (*BalanceSheetReport, error)
 }
 
 type reportService struct {
@@ -126,3 +209,209 @@ func (s *reportService) GetProductReport(ctx context.Context, startDateStr, endD
 func (s *reportService) GetStockValue(ctx context.Context) (*repositories.StockValue, error) {
 	return s.repo.GetStockValue(ctx)
 }
+
+// GetProfitAndLoss builds the profit & loss statement for a date range
+func (s *reportService) GetProfitAndLoss(ctx context.Context, startDateStr, endDateStr, compare string) (*ProfitAndLossReport, error) {
+	startDate, err := time.Parse("2006-01-02", startDateStr)
+	if err != nil {
+		return nil, errors.New("format tanggal mulai tidak valid (gunakan YYYY-MM-DD)")
+	}
+
+	endDate, err := time.Parse("2006-01-02", endDateStr)
+	if err != nil {
+		return nil, errors.New("format tanggal akhir tidak valid (gunakan YYYY-MM-DD)")
+	}
+
+	if endDate.Before(startDate) {
+		return nil, errors.New("tanggal akhir harus setelah tanggal mulai")
+	}
+
+	var prevStart, prevEnd time.Time
+	switch compare {
+	case CompareNone:
+	case ComparePrevious:
+		prevStart, prevEnd = previousPeriod(startDate, endDate)
+	case CompareYear:
+		prevStart, prevEnd = startDate.AddDate(-1, 0, 0), endDate.AddDate(-1, 0, 0)
+	default:
+		return nil, errors.New("pembanding tidak valid (gunakan previous atau year)")
+	}
+
+	report := &ProfitAndLossReport{}
+	if report.Current, err = s.profitAndLoss(ctx, startDate, endDate); err != nil {
+		return nil, err
+	}
+	if compare == CompareNone {
+		return report, nil
+	}
+
+	if report.Previous, err = s.profitAndLoss(ctx, prevStart, prevEnd); err != nil {
+		return nil, err
+	}
+	report.Changes = map[string]StatementChange{
+		"net_sales":          statementChange(report.Current.NetSales, report.Previous.NetSales),
+		"cogs":               statementChange(report.Current.COGS, report.Previous.COGS),
+		"shrinkage":          statementChange(report.Current.Shrinkage, report.Previous.Shrinkage),
+		"gross_profit":       statementChange(report.Current.GrossProfit, report.Previous.GrossProfit),
+		"other_income":       statementChange(report.Current.TotalOtherIncome, report.Previous.TotalOtherIncome),
+		"operating_expenses": statementChange(report.Current.TotalOperatingExpenses, report.Previous.TotalOperatingExpenses),
+		"net_profit":         statementChange(report.Current.NetProfit, report.Previous.NetProfit),
+	}
+	return report, nil
+}
+
+// profitAndLoss sums the revenue and expense accounts over the period.
+// Returns and cancellations are reversals posted when they happen, so a
+// sale returned later stays in its own period.
+func (s *reportService) profitAndLoss(ctx context.Context, startDate, endDate time.Time) (*ProfitAndLoss, error) {
+	balances, err := s.repo.GetAccountBalances(ctx, &startDate, endDate)
+	if err != nil {
+		return nil, errors.New("gagal mengambil saldo akun")
+	}
+
+	pl := &ProfitAndLoss{
+		StartDate:         startDate.Format("2006-01-02"),
+		EndDate:           endDate.Format("2006-01-02"),
+		OtherIncome:       []StatementLine{},
+		OperatingExpenses: []StatementLine{},
+	}
+	for _, b := range balances {
+		switch b.Type {
+		case models.AccountRevenue:
+			amount := roundMoney(b.Credit - b.Debit)
+			switch b.Code {
+			case models.AccountCodeSales:
+				pl.GrossSales = amount
+			case models.AccountCodeSalesDiscount:
+				pl.Discounts = -amount
+			default:
+				if amount != 0 {
+					pl.OtherIncome = append(pl.OtherIncome, StatementLine{Code: b.Code, Name: b.Name, Amount: amount})
+					pl.TotalOtherIncome = roundMoney(pl.TotalOtherIncome + amount)
+				}
+			}
+		case models.AccountExpense:
+			amount := roundMoney(b.Debit - b.Credit)
+			switch b.Code {
+			case models.AccountCodeCOGS:
+				pl.COGS = amount
+			case models.AccountCodeShrinkage:
+				pl.Shrinkage = amount
+			default:
+				if amount != 0 {
+					pl.OperatingExpenses = append(pl.OperatingExpenses, StatementLine{Code: b.Code, Name: b.Name, Amount: amount})
+					pl.TotalOperatingExpenses = roundMoney(pl.TotalOperatingExpenses + amount)
+				}
+			}
+		}
+	}
+
+	pl.NetSales = roundMoney(pl.GrossSales - pl.Discounts)
+	pl.GrossProfit = roundMoney(pl.NetSales - pl.COGS - pl.Shrinkage)
+	pl.NetProfit = roundMoney(pl.GrossProfit + pl.TotalOtherIncome - pl.TotalOperatingExpenses)
+	if pl.NetSales != 0 {
+		pl.GrossMargin = roundMoney(pl.GrossProfit / pl.NetSales * 100)
+		pl.NetMargin = roundMoney(pl.NetProfit / pl.NetSales * 100)
+	}
+	return pl, nil
+}
+
+// GetBalanceSheet builds the balance sheet at the end of a day
+func (s *reportService) GetBalanceSheet(ctx context.Context, dateStr, compareDateStr string) (*BalanceSheetReport, error) {
+	date, err := time.Parse("2006-01-02", dateStr)
+	if err != nil {
+		return nil, errors.New("format tanggal tidak valid (gunakan YYYY-MM-DD)")
+	}
+
+	report := &BalanceSheetReport{}
+	if report.Current, err = s.balanceSheet(ctx, date); err != nil {
+		return nil, err
+	}
+	if compareDateStr == "" {
+		return report, nil
+	}
+
+	compareDate, err := time.Parse("2006-01-02", compareDateStr)
+	if err != nil {
+		return nil, errors.New("format tanggal pembanding tidak valid (gunakan YYYY-MM-DD)")
+	}
+	if report.Previous, err = s.balanceSheet(ctx, compareDate); err != nil {
+		return nil, err
+	}
+	report.Changes = map[string]StatementChange{
+		"cash":              statementChange(report.Current.Cash, report.Previous.Cash),
+		"bank":              statementChange(report.Current.Bank, report.Previous.Bank),
+		"receivables":       statementChange(report.Current.Receivables, report.Previous.Receivables),
+		"inventory":         statementChange(report.Current.Inventory, report.Previous.Inventory),
+		"total_assets":      statementChange(report.Current.TotalAssets, report.Previous.TotalAssets),
+		"payables":          statementChange(report.Current.Payables, report.Previous.Payables),
+		"capital":           statementChange(report.Current.Capital, report.Previous.Capital),
+		"retained_earnings": statementChange(report.Current.RetainedEarnings, report.Previous.RetainedEarnings),
+		"total_equity":      statementChange(report.Current.TotalEquity, report.Previous.TotalEquity),
+	}
+	return report, nil
+}
+
+// balanceSheet sums every account up to the end of date. Revenue and
+// expenses not yet closed to capital are the retained earnings.
+func (s *reportService) balanceSheet(ctx context.Context, date time.Time) (*BalanceSheet, error) {
+	balances, err := s.repo.GetAccountBalances(ctx, nil, date)
+	if err != nil {
+		return nil, errors.New("gagal mengambil saldo neraca")
+	}
+
+	sheet := &BalanceSheet{AsOf: date.Format("2006-01-02")}
+	for _, b := range balances {
+		debit := b.Debit - b.Credit
+		switch b.Type {
+		case models.AccountAsset:
+			switch {
+			case b.Code == models.AccountCodeCash:
+				sheet.Cash += debit
+			case b.Code == models.AccountCodeBank, strings.HasPrefix(b.Code, models.AccountCodeBank+"."):
+				sheet.Bank += debit
+			case b.Code == models.AccountCodeInventory:
+				sheet.Inventory += debit
+			default:
+				sheet.Receivables += debit
+			}
+		case models.AccountLiability:
+			sheet.Payables -= debit
+		case models.AccountEquity:
+			sheet.Capital -= debit
+		case models.AccountRevenue, models.AccountExpense:
+			sheet.RetainedEarnings -= debit
+		}
+	}
+
+	sheet.Cash = roundMoney(sheet.Cash)
+	sheet.Bank = roundMoney(sheet.Bank)
+	sheet.Receivables = roundMoney(sheet.Receivables)
+	sheet.Inventory = roundMoney(sheet.Inventory)
+	sheet.Payables = roundMoney(sheet.Payables)
+	sheet.Capital = roundMoney(sheet.Capital)
+	sheet.RetainedEarnings = roundMoney(sheet.RetainedEarnings)
+	sheet.TotalAssets = roundMoney(sheet.Cash + sheet.Bank + sheet.Receivables + sheet.Inventory)
+	sheet.TotalEquity = roundMoney(sheet.Capital + sheet.RetainedEarnings)
+	return sheet, nil
+}
+
+// previousPeriod returns the period of the same length just before start;
+// for whole months, the same number of calendar months before.
+func previousPeriod(start, end time.Time) (time.Time, time.Time) {
+	if start.Day() == 1 && end.AddDate(0, 0, 1).Day() == 1 {
+		months := (end.Year()-start.Year())*12 + int(end.Month()-start.Month()) + 1
+		return start.AddDate(0, -months, 0), start.AddDate(0, 0, -1)
+	}
+	days := int(end.Sub(start).Hours()/24) + 1
+	return start.AddDate(0, 0, -days), start.AddDate(0, 0, -1)
+}
+
+func statementChange(current, previous float64) StatementChange {
+	change := StatementChange{Amount: roundMoney(current - previous)}
+	if previous != 0 {
+		percent := roundMoney((current - previous) / math.Abs(previous) * 100)
+		change.Percent = &percent
+	}
+	return change
+}
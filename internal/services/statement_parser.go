@@ -0,0 +1,176 @@
+package services
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"pos-api/internal/models"
+)
+
+// dateFormatTokens turns a date format such as "DD/MM/YYYY" into a Go layout.
+var dateFormatTokens = strings.NewReplacer("YYYY", "2006", "MM", "01", "DD", "02", "HH", "15", "mm", "04", "ss", "05")
+
+// validateStatementMapping checks that mapping names the columns a line needs.
+func validateStatementMapping(mapping models.StatementMapping) error {
+	switch {
+	case strings.TrimSpace(mapping.Date) == "":
+		return errors.New("mapping.date is required")
+	case strings.TrimSpace(mapping.Amount) == "" && strings.TrimSpace(mapping.NetAmount) == "":
+		return errors.New("mapping.amount or mapping.net_amount is required")
+	case mapping.Delimiter != "" && utf8.RuneCountInString(mapping.Delimiter) != 1:
+		return errors.New("mapping.delimiter must be a single character")
+	}
+	return nil
+}
+
+// parseStatement reads the lines of a CSV settlement statement whose first
+// row is the header, with the columns named in mapping. Blank rows are
+// skipped; any other row that cannot be read fails the whole file, naming it.
+// A missing gross, fee or net amount is worked out from the other two.
+func parseStatement(r io.Reader, mapping models.StatementMapping) ([]models.BankStatementLine, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+	if mapping.Delimiter != "" {
+		reader.Comma, _ = utf8.DecodeRuneInString(mapping.Delimiter)
+	}
+	layout := "2006-01-02"
+	if mapping.DateFormat != "" {
+		layout = dateFormatTokens.Replace(mapping.DateFormat)
+	}
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("statement file is empty")
+		}
+		return nil, fmt.Errorf("failed to read statement header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	column := func(name string) (int, error) {
+		if strings.TrimSpace(name) == "" {
+			return -1, nil
+		}
+		i, ok := columns[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			return -1, fmt.Errorf("column %q not found in the statement header", name)
+		}
+		return i, nil
+	}
+	var dateCol, amountCol, feeCol, netCol, refCol, descCol int
+	for _, c := range []struct {
+		index *int
+		name  string
+	}{
+		{&dateCol, mapping.Date}, {&amountCol, mapping.Amount}, {&feeCol, mapping.Fee},
+		{&netCol, mapping.NetAmount}, {&refCol, mapping.Reference}, {&descCol, mapping.Description},
+	} {
+		if *c.index, err = column(c.name); err != nil {
+			return nil, err
+		}
+	}
+
+	var lines []models.BankStatementLine
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read statement: %w", err)
+		}
+		row, _ := reader.FieldPos(0)
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+		field := func(i int) string {
+			if i < 0 || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		date, err := time.Parse(layout, field(dateCol))
+		if err != nil {
+			return nil, fmt.Errorf("row %d: invalid date %q, expected format %q", row, field(dateCol), layout)
+		}
+		var amount, fee, net float64
+		for _, a := range []struct {
+			value *float64
+			index int
+			name  string
+		}{
+			{&amount, amountCol, "amount"}, {&fee, feeCol, "fee"}, {&net, netCol, "net amount"},
+		} {
+			if a.index < 0 {
+				continue
+			}
+			if *a.value, err = parseStatementAmount(field(a.index), mapping.DecimalComma); err != nil {
+				return nil, fmt.Errorf("row %d: invalid %s %q", row, a.name, field(a.index))
+			}
+		}
+		switch {
+		case amountCol < 0:
+			amount = net + fee
+		case netCol < 0:
+			net = amount - fee
+		case feeCol < 0:
+			fee = amount - net
+		}
+
+		lines = append(lines, models.BankStatementLine{
+			LineNo:      row,
+			Date:        dateOnly(date),
+			Reference:   field(refCol),
+			Description: field(descCol),
+			Amount:      roundMoney(amount),
+			Fee:         roundMoney(fee),
+			NetAmount:   roundMoney(net),
+			Status:      models.StatementLineUnmatched,
+		})
+	}
+	if len(lines) == 0 {
+		return nil, errors.New("statement file has no lines")
+	}
+	return lines, nil
+}
+
+// parseStatementAmount reads an amount as banks write it: with a currency
+// prefix, thousands separators, and negatives in parentheses or with a minus.
+// An empty field is zero.
+func parseStatementAmount(s string, decimalComma bool) (float64, error) {
+	s = strings.NewReplacer("Rp", "", "IDR", "", " ", "", "\u00a0", "").Replace(s)
+	if s == "" || s == "-" {
+		return 0, nil
+	}
+	negative := false
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		negative, s = true, s[1:len(s)-1]
+	}
+	if decimalComma {
+		s = strings.ReplaceAll(strings.ReplaceAll(s, ".", ""), ",", ".")
+	} else {
+		s = strings.ReplaceAll(s, ",", "")
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, err
+	}
+	if negative {
+		v = -v
+	}
+	return v, nil
+}
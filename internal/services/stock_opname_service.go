@@ -0,0 +1,297 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"pos-api/internal/models"
+	"pos-api/internal/repositories"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+type CreateStockOpnameRequest struct {
+	LocationID uint   `json:"location_id"` // Omit to count at the default location
+	CategoryID *uint  `json:"category_id"` // Omit to count all products
+	Notes      string `json:"notes"`
+}
+
+// StockCountRequest is one count for a product, identified by ID or by a scanned barcode.
+type StockCountRequest struct {
+	ProductID uint   `json:"product_id" validate:"required_without=Barcode"`
+	Barcode   string `json:"barcode" validate:"required_without=ProductID"`
+	// Quantity counted; 0 records that none were found. When omitted for a
+	// scanned barcode, the scanned quantity is used (1, or the weight on a scale label).
+	Quantity *float64 `json:"quantity" validate:"omitempty,gte=0"`
+	// MissingSerials lists the serial numbers of a serialized product that
+	// were not found. A shortfall on a serialized product can only be
+	// approved once its counts list exactly the missing units.
+	MissingSerials []string `json:"missing_serials"`
+}
+
+// StockOpnameVariance is the difference between the frozen expected stock and the count for one product.
+type StockOpnameVariance struct {
+	ProductID       uint     `json:"product_id"`
+	ProductName     string   `json:"product_name"`
+	SKU             string   `json:"sku"`
+	ExpectedStock   float64  `json:"expected_stock"`
+	CountedQuantity *float64 `json:"counted_quantity"` // nil when not counted yet
+	Variance        float64  `json:"variance"`         // Counted - expected
+	CostPrice       float64  `json:"cost_price"`
+	VarianceValue   float64  `json:"variance_value"` // Variance * cost price
+}
+
+type StockOpnameVarianceReport struct {
+	StockOpnameID uint                  `json:"stock_opname_id"`
+	Code          string                `json:"code"`
+	Status        string                `json:"status"`
+	TotalItems    int                   `json:"total_items"`
+	CountedItems  int                   `json:"counted_items"`
+	ShortageValue float64               `json:"shortage_value"` // Cost of missing stock
+	SurplusValue  float64               `json:"surplus_value"`  // Cost of extra stock found
+	NetValue      float64               `json:"net_value"`      // Surplus - shortage
+	Items         []StockOpnameVariance `json:"items"`
+}
+
+type StockOpnameService interface {
+	Create(ctx context.Context, req CreateStockOpnameRequest, userID uint) (*models.StockOpname, error)
+	GetByID(ctx context.Context, id uint) (*models.StockOpname, error)
+	GetAll(ctx context.Context, page, pageSize int, status string) ([]models.StockOpname, int64, error)
+	SubmitCount(ctx context.Context, id uint, req StockCountRequest, userID uint) (*models.StockOpnameCount, error)
+	DeleteCount(ctx context.Context, id, countID uint) error
+	GetVariances(ctx context.Context, id uint, onlyDifferences bool) (*StockOpnameVarianceReport, error)
+	Approve(ctx context.Context, id, userID uint) (*models.StockOpname, error)
+	Cancel(ctx context.Context, id uint) (*models.StockOpname, error)
+}
+
+type stockOpnameService struct {
+	repo      repositories.StockOpnameRepository
+	scanner   BarcodeScanner
+	validator *validator.Validate
+}
+
+func NewStockOpnameService(repo repositories.StockOpnameRepository, scanner BarcodeScanner) StockOpnameService {
+	return &stockOpnameService{
+		repo:      repo,
+		scanner:   scanner,
+		validator: validator.New(),
+	}
+}
+
+// Create starts a count session and freezes the expected stock of every product in scope.
+func (s *stockOpnameService) Create(ctx context.Context, req CreateStockOpnameRequest, userID uint) (*models.StockOpname, error) {
+	opname := &models.StockOpname{
+		Code:       fmt.Sprintf("SO-%d", time.Now().UnixNano()),
+		LocationID: req.LocationID,
+		CategoryID: req.CategoryID,
+		Status:     models.StockOpnameOpen,
+		Notes:      req.Notes,
+		UserID:     userID,
+	}
+
+	if err := s.repo.Create(ctx, opname); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("no products to count in this category")
+		}
+		return nil, fmt.Errorf("failed to create stock opname: %w", err)
+	}
+
+	return s.GetByID(ctx, opname.ID)
+}
+
+func (s *stockOpnameService) GetByID(ctx context.Context, id uint) (*models.StockOpname, error) {
+	opname, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customErrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get stock opname: %w", err)
+	}
+	return opname, nil
+}
+
+func (s *stockOpnameService) GetAll(ctx context.Context, page, pageSize int, status string) ([]models.StockOpname, int64, error) {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+	offset := (page - 1) * pageSize
+	return s.repo.GetAll(ctx, pageSize, offset, status)
+}
+
+// SubmitCount adds a count to the session. Counts for the same product are
+// summed, so staff counting different shelves can each submit their own.
+func (s *stockOpnameService) SubmitCount(ctx context.Context, id uint, req StockCountRequest, userID uint) (*models.StockOpnameCount, error) {
+	if err := s.validator.Struct(req); err != nil {
+		return nil, errors.New("validation failed: " + err.Error())
+	}
+
+	productID := req.ProductID
+	var quantity float64
+	if req.Quantity != nil {
+		quantity = *req.Quantity
+	}
+
+	if req.Barcode != "" {
+		scan, err := s.scanner.Scan(ctx, req.Barcode)
+		if err != nil {
+			if errors.Is(err, customErrors.ErrNotFound) {
+				return nil, fmt.Errorf("no product found for barcode %s", req.Barcode)
+			}
+			return nil, err
+		}
+		productID = scan.Product.ID
+		if req.Quantity == nil {
+			quantity = scan.Quantity
+		}
+	} else if req.Quantity == nil {
+		return nil, errors.New("validation failed: quantity is required when counting by product_id")
+	}
+
+	item, err := s.repo.GetItem(ctx, id, productID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("product %d is not part of this stock opname", productID)
+		}
+		return nil, fmt.Errorf("failed to get stock opname item: %w", err)
+	}
+
+	quantity = roundQuantity(quantity)
+	if !item.Product.SoldByWeight && quantity != math.Trunc(quantity) {
+		return nil, fmt.Errorf("quantity for %s must be a whole number", item.Product.Name)
+	}
+
+	missing, err := normalizeSerials(&item.Product, float64(len(req.MissingSerials)), req.MissingSerials)
+	if err != nil {
+		return nil, err
+	}
+
+	count := &models.StockOpnameCount{
+		StockOpnameID:     id,
+		StockOpnameItemID: item.ID,
+		ProductID:         productID,
+		Quantity:          quantity,
+		Barcode:           req.Barcode,
+		MissingSerials:    missing,
+		UserID:            userID,
+	}
+
+	if err := s.repo.AddCount(ctx, count); err != nil {
+		return nil, stockOpnameError(err, "failed to submit count")
+	}
+
+	return count, nil
+}
+
+func (s *stockOpnameService) DeleteCount(ctx context.Context, id, countID uint) error {
+	if err := s.repo.DeleteCount(ctx, id, countID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return customErrors.ErrNotFound
+		}
+		return stockOpnameError(err, "failed to delete count")
+	}
+	return nil
+}
+
+// GetVariances lists expected vs counted stock with the cost value of each difference.
+func (s *stockOpnameService) GetVariances(ctx context.Context, id uint, onlyDifferences bool) (*StockOpnameVarianceReport, error) {
+	opname, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &StockOpnameVarianceReport{
+		StockOpnameID: opname.ID,
+		Code:          opname.Code,
+		Status:        opname.Status,
+		TotalItems:    len(opname.Items),
+		Items:         []StockOpnameVariance{},
+	}
+
+	for _, item := range opname.Items {
+		variance := item.Variance()
+		value := roundMoney(variance * item.CostPrice)
+
+		if item.CountedQuantity != nil {
+			report.CountedItems++
+		}
+		if value < 0 {
+			report.ShortageValue = roundMoney(report.ShortageValue - value)
+		} else {
+			report.SurplusValue = roundMoney(report.SurplusValue + value)
+		}
+
+		if onlyDifferences && variance == 0 {
+			continue
+		}
+		report.Items = append(report.Items, StockOpnameVariance{
+			ProductID:       item.ProductID,
+			ProductName:     item.Product.Name,
+			SKU:             item.Product.SKU,
+			ExpectedStock:   item.ExpectedStock,
+			CountedQuantity: item.CountedQuantity,
+			Variance:        variance,
+			CostPrice:       item.CostPrice,
+			VarianceValue:   value,
+		})
+	}
+	report.NetValue = roundMoney(report.SurplusValue - report.ShortageValue)
+
+	return report, nil
+}
+
+// Approve posts the variance of every counted product as an adjustment.
+// Products that were never counted are left untouched.
+func (s *stockOpnameService) Approve(ctx context.Context, id, userID uint) (*models.StockOpname, error) {
+	opname, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if opname.Status != models.StockOpnameOpen {
+		return nil, fmt.Errorf("stock opname is '%s', only open sessions can be approved", opname.Status)
+	}
+
+	counted := false
+	for _, item := range opname.Items {
+		if item.CountedQuantity != nil {
+			counted = true
+			break
+		}
+	}
+	if !counted {
+		return nil, errors.New("no products have been counted yet")
+	}
+
+	if _, err := s.repo.Approve(ctx, id, userID); err != nil {
+		return nil, stockOpnameError(err, "failed to approve stock opname")
+	}
+
+	return s.GetByID(ctx, id)
+}
+
+func (s *stockOpnameService) Cancel(ctx context.Context, id uint) (*models.StockOpname, error) {
+	if err := s.repo.UpdateStatus(ctx, id, models.StockOpnameCancelled); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customErrors.ErrNotFound
+		}
+		return nil, stockOpnameError(err, "failed to cancel stock opname")
+	}
+	return s.GetByID(ctx, id)
+}
+
+// stockOpnameError passes through errors the caller can act on (session no
+// longer open, stock would go negative) and wraps anything else.
+func stockOpnameError(err error, msg string) error {
+	if errors.Is(err, customErrors.ErrConflict) || errors.Is(err, customErrors.ErrInsufficientStock) {
+		return err
+	}
+	return fmt.Errorf("%s: %w", msg, err)
+}
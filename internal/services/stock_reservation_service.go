@@ -0,0 +1,159 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"pos-api/internal/models"
+	"pos-api/internal/repositories"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+// defaultReservationMinutes is how long a held order keeps its stock when the
+// request does not say.
+const defaultReservationMinutes = 30
+
+type StockReservationItemRequest struct {
+	ProductID uint    `json:"product_id" validate:"required"`
+	Quantity  float64 `json:"quantity" validate:"required,gt=0"` // Fractional (kg) only for products sold by weight
+}
+
+type StockReservationRequest struct {
+	LocationID uint   `json:"location_id"` // 0 uses the default location
+	Channel    string `json:"channel" validate:"max=30"`
+	Reference  string `json:"reference" validate:"max=100"` // Held order or external order number
+	Notes      string `json:"notes"`
+	// ExpiresInMinutes is how long the stock is held, 30 minutes when 0 and at most 7 days
+	ExpiresInMinutes int                           `json:"expires_in_minutes" validate:"gte=0,lte=10080"`
+	Items            []StockReservationItemRequest `json:"items" validate:"required,min=1,dive"`
+}
+
+type StockReservationService interface {
+	// Create holds stock for a held order so no other channel can sell it
+	// until the reservation is sold, released or expires.
+	Create(ctx context.Context, req StockReservationRequest, userID uint) (*models.StockReservation, error)
+	GetByID(ctx context.Context, id uint) (*models.StockReservation, error)
+	GetAll(ctx context.Context, page, pageSize int, locationID uint, status string) ([]models.StockReservation, int64, error)
+	Release(ctx context.Context, id uint) (*models.StockReservation, error)
+}
+
+type stockReservationService struct {
+	repo         repositories.StockReservationRepository
+	locationRepo repositories.LocationRepository
+	productRepo  repositories.ProductRepository
+	validator    *validator.Validate
+}
+
+func NewStockReservationService(repo repositories.StockReservationRepository, locationRepo repositories.LocationRepository, productRepo repositories.ProductRepository) StockReservationService {
+	return &stockReservationService{
+		repo:         repo,
+		locationRepo: locationRepo,
+		productRepo:  productRepo,
+		validator:    validator.New(),
+	}
+}
+
+func (s *stockReservationService) Create(ctx context.Context, req StockReservationRequest, userID uint) (*models.StockReservation, error) {
+	if err := s.validator.Struct(req); err != nil {
+		return nil, errors.New("validation failed: " + err.Error())
+	}
+
+	locationID := req.LocationID
+	if locationID == 0 {
+		location, err := s.locationRepo.GetDefault(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get default location: %w", err)
+		}
+		locationID = location.ID
+	} else {
+		location, err := s.locationRepo.GetByID(ctx, locationID)
+		if err != nil || !location.IsActive {
+			return nil, fmt.Errorf("location with ID %d not found or inactive", locationID)
+		}
+	}
+
+	items := make([]models.StockReservationItem, 0, len(req.Items))
+	seen := make(map[uint]bool, len(req.Items))
+	for _, it := range req.Items {
+		if seen[it.ProductID] {
+			return nil, fmt.Errorf("product %d is listed more than once", it.ProductID)
+		}
+		seen[it.ProductID] = true
+
+		product, err := s.productRepo.GetProductByID(ctx, it.ProductID)
+		if err != nil {
+			return nil, fmt.Errorf("product with ID %d not found", it.ProductID)
+		}
+		quantity := roundQuantity(it.Quantity)
+		if err := validateQuantity(product, quantity); err != nil {
+			return nil, err
+		}
+		items = append(items, models.StockReservationItem{ProductID: product.ID, Quantity: quantity})
+	}
+
+	minutes := req.ExpiresInMinutes
+	if minutes == 0 {
+		minutes = defaultReservationMinutes
+	}
+	reservation := &models.StockReservation{
+		Code:       fmt.Sprintf("RSV-%d", time.Now().UnixNano()),
+		LocationID: locationID,
+		Channel:    req.Channel,
+		Reference:  req.Reference,
+		Notes:      req.Notes,
+		Status:     models.ReservationActive,
+		ExpiresAt:  time.Now().Add(time.Duration(minutes) * time.Minute),
+		UserID:     userID,
+		Items:      items,
+	}
+
+	if err := s.repo.Create(ctx, reservation); err != nil {
+		if errors.Is(err, customErrors.ErrInsufficientStock) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to create reservation: %w", err)
+	}
+
+	return s.GetByID(ctx, reservation.ID)
+}
+
+func (s *stockReservationService) GetByID(ctx context.Context, id uint) (*models.StockReservation, error) {
+	reservation, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customErrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get reservation: %w", err)
+	}
+	return reservation, nil
+}
+
+func (s *stockReservationService) GetAll(ctx context.Context, page, pageSize int, locationID uint, status string) ([]models.StockReservation, int64, error) {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+	offset := (page - 1) * pageSize
+	return s.repo.GetAll(ctx, pageSize, offset, locationID, status)
+}
+
+func (s *stockReservationService) Release(ctx context.Context, id uint) (*models.StockReservation, error) {
+	if err := s.repo.Release(ctx, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customErrors.ErrNotFound
+		}
+		if errors.Is(err, customErrors.ErrConflict) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to release reservation: %w", err)
+	}
+	return s.GetByID(ctx, id)
+}
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"pos-api/internal/models"
+	"pos-api/internal/repositories"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+type StockTransferItemRequest struct {
+	ProductID uint    `json:"product_id" validate:"required"`
+	Quantity  float64 `json:"quantity" validate:"required,gt=0"` // Fractional (kg) only for products sold by weight
+	// SerialNumbers lists the units moved; required, one per unit, for serialized products
+	SerialNumbers []string `json:"serial_numbers"`
+}
+
+type StockTransferRequest struct {
+	FromLocationID uint                       `json:"from_location_id" validate:"required"`
+	ToLocationID   uint                       `json:"to_location_id" validate:"required,nefield=FromLocationID"`
+	Notes          string                     `json:"notes"`
+	Items          []StockTransferItemRequest `json:"items" validate:"required,min=1,dive"`
+}
+
+type StockTransferService interface {
+	Create(ctx context.Context, req StockTransferRequest, userID uint) (*models.StockTransfer, error)
+	GetByID(ctx context.Context, id uint) (*models.StockTransfer, error)
+	GetAll(ctx context.Context, page, pageSize int, locationID uint) ([]models.StockTransfer, int64, error)
+}
+
+type stockTransferService struct {
+	repo         repositories.StockTransferRepository
+	locationRepo repositories.LocationRepository
+	productRepo  repositories.ProductRepository
+	validator    *validator.Validate
+}
+
+func NewStockTransferService(repo repositories.StockTransferRepository, locationRepo repositories.LocationRepository, productRepo repositories.ProductRepository) StockTransferService {
+	return &stockTransferService{
+		repo:         repo,
+		locationRepo: locationRepo,
+		productRepo:  productRepo,
+		validator:    validator.New(),
+	}
+}
+
+// Create posts a transfer immediately: stock leaves the source location and
+// arrives at the destination in the same DB transaction.
+func (s *stockTransferService) Create(ctx context.Context, req StockTransferRequest, userID uint) (*models.StockTransfer, error) {
+	if err := s.validator.Struct(req); err != nil {
+		return nil, errors.New("validation failed: " + err.Error())
+	}
+
+	for _, id := range []uint{req.FromLocationID, req.ToLocationID} {
+		location, err := s.locationRepo.GetByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, fmt.Errorf("location with ID %d not found", id)
+			}
+			return nil, fmt.Errorf("failed to get location: %w", err)
+		}
+		if !location.IsActive {
+			return nil, fmt.Errorf("location %s is inactive", location.Code)
+		}
+	}
+
+	items := make([]models.StockTransferItem, 0, len(req.Items))
+	seen := make(map[uint]bool, len(req.Items))
+	for _, it := range req.Items {
+		if seen[it.ProductID] {
+			return nil, fmt.Errorf("product %d is listed more than once", it.ProductID)
+		}
+		seen[it.ProductID] = true
+
+		product, err := s.productRepo.GetProductByID(ctx, it.ProductID)
+		if err != nil {
+			return nil, fmt.Errorf("product with ID %d not found", it.ProductID)
+		}
+
+		quantity := roundQuantity(it.Quantity)
+		if !product.SoldByWeight && quantity != math.Trunc(quantity) {
+			return nil, fmt.Errorf("quantity for %s must be a whole number", product.Name)
+		}
+
+		serials, err := normalizeSerials(product, quantity, it.SerialNumbers)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, models.StockTransferItem{
+			ProductID:     it.ProductID,
+			Quantity:      quantity,
+			SerialNumbers: serials,
+		})
+	}
+
+	transfer := &models.StockTransfer{
+		Code:           fmt.Sprintf("TRF-%d", time.Now().UnixNano()),
+		FromLocationID: req.FromLocationID,
+		ToLocationID:   req.ToLocationID,
+		Notes:          req.Notes,
+		UserID:         userID,
+		Items:          items,
+	}
+
+	if err := s.repo.Create(ctx, transfer); err != nil {
+		if errors.Is(err, customErrors.ErrInsufficientStock) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to create stock transfer: %w", err)
+	}
+
+	return s.GetByID(ctx, transfer.ID)
+}
+
+func (s *stockTransferService) GetByID(ctx context.Context, id uint) (*models.StockTransfer, error) {
+	transfer, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customErrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get stock transfer: %w", err)
+	}
+	return transfer, nil
+}
+
+func (s *stockTransferService) GetAll(ctx context.Context, page, pageSize int, locationID uint) ([]models.StockTransfer, int64, error) {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+	offset := (page - 1) * pageSize
+	return s.repo.GetAll(ctx, pageSize, offset, locationID)
+}
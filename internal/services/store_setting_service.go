@@ -2,27 +2,102 @@ package services
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"pos-api/internal/models"
 	"pos-api/internal/repositories"
+	"strings"
 )
 
+// ErrInvalidCostingMethod is returned for a costing_method other than "average" or "fifo"
+var ErrInvalidCostingMethod = errors.New("validasi gagal: costing_method harus 'average' atau 'fifo'")
+
+// ErrInvalidNegativeStockPolicy is returned for a negative_stock_policy other than "block", "warn" or "allow"
+var ErrInvalidNegativeStockPolicy = errors.New("validasi gagal: negative_stock_policy harus 'block', 'warn' atau 'allow'")
+
+// ErrInvalidBaseCurrency is returned for a base_currency that is not a three letter ISO 4217 code
+var ErrInvalidBaseCurrency = errors.New("validasi gagal: base_currency harus kode mata uang 3 huruf, mis. IDR")
+
+// ErrBaseCurrencyInUse is returned when changing the base currency of a store
+// that already has foreign currencies, whose rates are quoted against it, or
+// transactions and journal entries, whose amounts are in it
+var ErrBaseCurrencyInUse = errors.New("base_currency hanya bisa diubah selama belum ada mata uang asing, transaksi, maupun jurnal; kurs dan semua nominal tercatat dalam mata uang dasar")
+
 type StoreSettingService interface {
 	GetSettings(ctx context.Context) (*models.StoreSetting, error)
 	UpdateSettings(ctx context.Context, settings *models.StoreSetting) (*models.StoreSetting, error)
 }
 
 type storeSettingService struct {
-	repo repositories.StoreSettingRepository
+	repo         repositories.StoreSettingRepository
+	currencyRepo repositories.CurrencyRepository
 }
 
-func NewStoreSettingService(repo repositories.StoreSettingRepository) StoreSettingService {
-	return &storeSettingService{repo: repo}
+func NewStoreSettingService(repo repositories.StoreSettingRepository, currencyRepo repositories.CurrencyRepository) StoreSettingService {
+	return &storeSettingService{repo: repo, currencyRepo: currencyRepo}
 }
 
 func (s *storeSettingService) GetSettings(ctx context.Context) (*models.StoreSetting, error) {
 	return s.repo.GetSettings(ctx)
 }
 
+// UpdateSettings menyimpan pengaturan toko. CostingMethod, NegativeStockPolicy
+// atau BaseCurrency kosong berarti nilai yang sedang dipakai tidak diubah.
 func (s *storeSettingService) UpdateSettings(ctx context.Context, settings *models.StoreSetting) (*models.StoreSetting, error) {
+	switch settings.CostingMethod {
+	case "", models.CostingAverage, models.CostingFIFO:
+	default:
+		return nil, ErrInvalidCostingMethod
+	}
+	if settings.NegativeStockPolicy != "" && !validNegativeStockPolicy(settings.NegativeStockPolicy) {
+		return nil, ErrInvalidNegativeStockPolicy
+	}
+	if settings.BaseCurrency != "" {
+		if err := s.checkBaseCurrency(ctx, settings); err != nil {
+			return nil, err
+		}
+	}
 	return s.repo.UpsertSettings(ctx, settings)
 }
+
+// checkBaseCurrency menormalkan BaseCurrency dan hanya mengizinkan
+// penggantiannya di toko yang belum punya mata uang asing, transaksi,
+// maupun jurnal.
+func (s *storeSettingService) checkBaseCurrency(ctx context.Context, settings *models.StoreSetting) error {
+	settings.BaseCurrency = strings.ToUpper(strings.TrimSpace(settings.BaseCurrency))
+	if len(settings.BaseCurrency) != 3 || strings.Trim(settings.BaseCurrency, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "" {
+		return ErrInvalidBaseCurrency
+	}
+
+	current, err := s.repo.GetSettings(ctx)
+	if err != nil {
+		return fmt.Errorf("gagal mengambil pengaturan toko: %w", err)
+	}
+	if current.BaseCurrency == settings.BaseCurrency {
+		return nil
+	}
+	count, err := s.currencyRepo.Count(ctx)
+	if err != nil {
+		return fmt.Errorf("gagal menghitung mata uang: %w", err)
+	}
+	if count > 0 {
+		return ErrBaseCurrencyInUse
+	}
+	active, err := s.repo.HasActivity(ctx)
+	if err != nil {
+		return fmt.Errorf("gagal memeriksa transaksi dan jurnal: %w", err)
+	}
+	if active {
+		return ErrBaseCurrencyInUse
+	}
+	return nil
+}
+
+// validNegativeStockPolicy reports whether policy is one of the negative stock policies.
+func validNegativeStockPolicy(policy string) bool {
+	switch policy {
+	case models.NegativeStockBlock, models.NegativeStockWarn, models.NegativeStockAllow:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,200 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"pos-api/internal/models"
+	"pos-api/internal/repositories"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+type SupplierPaymentRequest struct {
+	Amount    float64 `json:"amount" validate:"required,gt=0"`
+	Date      string  `json:"date"` // Optional, "2026-02-16", defaults to today
+	Reference string  `json:"reference"`
+	Notes     string  `json:"notes"`
+}
+
+// AgingBuckets splits outstanding payables by how many days they are past due.
+type AgingBuckets struct {
+	Current    float64 `json:"current"` // Not yet due
+	Days1To30  float64 `json:"days_1_30"`
+	Days31To60 float64 `json:"days_31_60"`
+	Days61To90 float64 `json:"days_61_90"`
+	Over90     float64 `json:"over_90"`
+	Total      float64 `json:"total"`
+}
+
+type SupplierAging struct {
+	SupplierID   uint   `json:"supplier_id"`
+	SupplierName string `json:"supplier_name"`
+	AgingBuckets
+}
+
+type PayablesAging struct {
+	AsOf      time.Time       `json:"as_of"`
+	Suppliers []SupplierAging `json:"suppliers"`
+	Total     AgingBuckets    `json:"total"`
+}
+
+type SupplierPayableService interface {
+	GetByID(ctx context.Context, id uint) (*models.SupplierPayable, error)
+	GetAll(ctx context.Context, page, pageSize int, supplierID uint, status string) ([]models.SupplierPayable, int64, error)
+	RecordPayment(ctx context.Context, payableID uint, req SupplierPaymentRequest, userID uint) (*models.SupplierPayable, error)
+	GetAging(ctx context.Context, asOf time.Time, supplierID uint) (*PayablesAging, error)
+}
+
+type supplierPayableService struct {
+	repo      repositories.SupplierPayableRepository
+	validator *validator.Validate
+}
+
+func NewSupplierPayableService(repo repositories.SupplierPayableRepository) SupplierPayableService {
+	return &supplierPayableService{
+		repo:      repo,
+		validator: validator.New(),
+	}
+}
+
+func (s *supplierPayableService) GetByID(ctx context.Context, id uint) (*models.SupplierPayable, error) {
+	payable, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customErrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get payable: %w", err)
+	}
+	return payable, nil
+}
+
+func (s *supplierPayableService) GetAll(ctx context.Context, page, pageSize int, supplierID uint, status string) ([]models.SupplierPayable, int64, error) {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+	offset := (page - 1) * pageSize
+	return s.repo.GetAll(ctx, pageSize, offset, supplierID, status)
+}
+
+// RecordPayment pays (part of) a payable. The stock purchase expense is booked
+// in the cash flow now, on the payment date, rather than when goods were received.
+func (s *supplierPayableService) RecordPayment(ctx context.Context, payableID uint, req SupplierPaymentRequest, userID uint) (*models.SupplierPayable, error) {
+	if err := s.validator.Struct(req); err != nil {
+		return nil, errors.New("validation failed: " + err.Error())
+	}
+
+	date := time.Now()
+	if req.Date != "" {
+		parsed, err := time.Parse("2006-01-02", req.Date)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date format, use YYYY-MM-DD: %w", err)
+		}
+		date = parsed
+	}
+
+	payable, err := s.GetByID(ctx, payableID)
+	if err != nil {
+		return nil, err
+	}
+
+	amount := roundMoney(req.Amount)
+	if payable.Status == models.PayablePaid {
+		return nil, errors.New("payable is already paid")
+	}
+	if amount > roundMoney(payable.Outstanding()) {
+		return nil, fmt.Errorf("%w: outstanding is %.2f", customErrors.ErrOverpayment, payable.Outstanding())
+	}
+
+	notes := "Payment to " + payable.Supplier.Name
+	if payable.PurchaseOrder != nil {
+		notes += ", PO: " + payable.PurchaseOrder.PONumber
+	}
+	if req.Reference != "" {
+		notes += ", Ref: " + req.Reference
+	}
+
+	cashFlow := &models.CashFlow{
+		Type:            "expense",
+		Source:          models.CashFlowSourceStockPurchase,
+		Amount:          amount,
+		Date:            date,
+		Notes:           notes,
+		PurchaseOrderID: payable.PurchaseOrderID,
+		UserID:          userID,
+	}
+	payment := &models.SupplierPayment{
+		PayableID:  payable.ID,
+		SupplierID: payable.SupplierID,
+		Amount:     amount,
+		Date:       date,
+		Reference:  req.Reference,
+		Notes:      req.Notes,
+		UserID:     userID,
+	}
+
+	if err := s.repo.RecordPayment(ctx, payment, cashFlow); err != nil {
+		if errors.Is(err, customErrors.ErrOverpayment) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to record payment: %w", err)
+	}
+
+	return s.GetByID(ctx, payableID)
+}
+
+// GetAging groups outstanding payables per supplier into past-due buckets as of asOf.
+func (s *supplierPayableService) GetAging(ctx context.Context, asOf time.Time, supplierID uint) (*PayablesAging, error) {
+	payables, err := s.repo.GetOutstanding(ctx, supplierID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get outstanding payables: %w", err)
+	}
+
+	report := &PayablesAging{AsOf: asOf, Suppliers: []SupplierAging{}}
+	index := make(map[uint]int)
+	asOfDay := truncateDay(asOf)
+
+	for _, p := range payables {
+		i, ok := index[p.SupplierID]
+		if !ok {
+			i = len(report.Suppliers)
+			index[p.SupplierID] = i
+			report.Suppliers = append(report.Suppliers, SupplierAging{SupplierID: p.SupplierID, SupplierName: p.Supplier.Name})
+		}
+
+		daysOverdue := int(asOfDay.Sub(truncateDay(p.DueDate.In(asOf.Location()))).Hours() / 24)
+		outstanding := p.Outstanding()
+		report.Suppliers[i].add(daysOverdue, outstanding)
+		report.Total.add(daysOverdue, outstanding)
+	}
+
+	return report, nil
+}
+
+func (b *AgingBuckets) add(daysOverdue int, amount float64) {
+	switch {
+	case daysOverdue <= 0:
+		b.Current = roundMoney(b.Current + amount)
+	case daysOverdue <= 30:
+		b.Days1To30 = roundMoney(b.Days1To30 + amount)
+	case daysOverdue <= 60:
+		b.Days31To60 = roundMoney(b.Days31To60 + amount)
+	case daysOverdue <= 90:
+		b.Days61To90 = roundMoney(b.Days61To90 + amount)
+	default:
+		b.Over90 = roundMoney(b.Over90 + amount)
+	}
+	b.Total = roundMoney(b.Total + amount)
+}
+
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
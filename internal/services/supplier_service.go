@@ -0,0 +1,141 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"pos-api/internal/models"
+	"pos-api/internal/repositories"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+type SupplierRequest struct {
+	Name        string `json:"name" validate:"required,min=2,max=100"`
+	ContactName string `json:"contact_name"`
+	Phone       string `json:"phone"`
+	Email       string `json:"email" validate:"omitempty,email"`
+	Address     string `json:"address"`
+	Notes       string `json:"notes"`
+	// PaymentTermDays is the credit term in days, 0 for cash on receipt
+	PaymentTermDays int   `json:"payment_term_days" validate:"gte=0,lte=365"`
+	IsActive        *bool `json:"is_active"` // Defaults to true on create, unchanged on update when omitted
+}
+
+type SupplierService interface {
+	Create(ctx context.Context, req SupplierRequest) (*models.Supplier, error)
+	Update(ctx context.Context, id uint, req SupplierRequest) (*models.Supplier, error)
+	Delete(ctx context.Context, id uint) error
+	GetByID(ctx context.Context, id uint) (*models.Supplier, error)
+	GetAll(ctx context.Context, page, pageSize int, search string, onlyActive bool) ([]models.Supplier, int64, error)
+}
+
+type supplierService struct {
+	repo      repositories.SupplierRepository
+	validator *validator.Validate
+}
+
+func NewSupplierService(repo repositories.SupplierRepository) SupplierService {
+	return &supplierService{
+		repo:      repo,
+		validator: validator.New(),
+	}
+}
+
+func isDuplicateKey(err error) bool {
+	return strings.Contains(err.Error(), "duplicate key") || strings.Contains(err.Error(), "unique constraint")
+}
+
+func (s *supplierService) Create(ctx context.Context, req SupplierRequest) (*models.Supplier, error) {
+	if err := s.validator.Struct(req); err != nil {
+		return nil, errors.New("validation failed: " + err.Error())
+	}
+
+	supplier := &models.Supplier{
+		Name:            strings.TrimSpace(req.Name),
+		ContactName:     req.ContactName,
+		Phone:           req.Phone,
+		Email:           req.Email,
+		Address:         req.Address,
+		Notes:           req.Notes,
+		PaymentTermDays: req.PaymentTermDays,
+		IsActive:        true,
+	}
+	if req.IsActive != nil {
+		supplier.IsActive = *req.IsActive
+	}
+
+	if err := s.repo.Create(ctx, supplier); err != nil {
+		if isDuplicateKey(err) {
+			return nil, customErrors.ErrConflict
+		}
+		return nil, fmt.Errorf("failed to create supplier: %w", err)
+	}
+
+	return supplier, nil
+}
+
+func (s *supplierService) Update(ctx context.Context, id uint, req SupplierRequest) (*models.Supplier, error) {
+	if err := s.validator.Struct(req); err != nil {
+		return nil, errors.New("validation failed: " + err.Error())
+	}
+
+	supplier, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	supplier.Name = strings.TrimSpace(req.Name)
+	supplier.ContactName = req.ContactName
+	supplier.Phone = req.Phone
+	supplier.Email = req.Email
+	supplier.Address = req.Address
+	supplier.Notes = req.Notes
+	supplier.PaymentTermDays = req.PaymentTermDays
+	if req.IsActive != nil {
+		supplier.IsActive = *req.IsActive
+	}
+
+	if err := s.repo.Update(ctx, supplier); err != nil {
+		if isDuplicateKey(err) {
+			return nil, customErrors.ErrConflict
+		}
+		return nil, fmt.Errorf("failed to update supplier: %w", err)
+	}
+
+	return supplier, nil
+}
+
+func (s *supplierService) Delete(ctx context.Context, id uint) error {
+	if _, err := s.GetByID(ctx, id); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *supplierService) GetByID(ctx context.Context, id uint) (*models.Supplier, error) {
+	supplier, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customErrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get supplier: %w", err)
+	}
+	return supplier, nil
+}
+
+func (s *supplierService) GetAll(ctx context.Context, page, pageSize int, search string, onlyActive bool) ([]models.Supplier, int64, error) {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+	offset := (page - 1) * pageSize
+	return s.repo.GetAll(ctx, pageSize, offset, search, onlyActive)
+}
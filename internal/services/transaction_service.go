@@ -4,29 +4,45 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"pos-api/internal/models"
 	"pos-api/internal/pkg/events"
 	"pos-api/internal/repositories"
 
+	customErrors "pos-api/internal/pkg/errors"
+
 	"github.com/go-playground/validator/v10"
 	"gorm.io/gorm"
 )
 
-// ItemRequest merepresentasikan satu item yang dibeli dalam request API
+// ItemRequest merepresentasikan satu item yang dibeli dalam request API.
+// Untuk label timbangan, kirim Barcode hasil scan; berat/harga dibaca ulang dari
+// barcode di server sehingga Quantity dari client diabaikan.
 type ItemRequest struct {
-	ProductID uint `json:"product_id" validate:"required"`
-	Quantity  int  `json:"quantity" validate:"required,gt=0"`
+	ProductID uint    `json:"product_id" validate:"required_without=Barcode"`
+	Barcode   string  `json:"barcode"`
+	Quantity  float64 `json:"quantity" validate:"gte=0"` // Desimal (kg) hanya untuk produk timbangan
+	// SerialNumbers wajib untuk produk bernomor seri, satu per unit
+	SerialNumbers []string `json:"serial_numbers"`
 }
 
 // TransactionRequest mendefinisikan DTO untuk pencatatan transaksi penjualan
 type TransactionRequest struct {
-	PaymentMethod string        `json:"payment_method" validate:"required"` // e.g., "Cash", "QRIS"
-	Cash          float64       `json:"cash" validate:"required,gte=0"`     // Uang yang dibayarkan pelanggan
+	PaymentMethod string        `json:"payment_method" validate:"required"`              // e.g., "Cash", "QRIS"
+	Cash          float64       `json:"cash" validate:"required_without=Payments,gte=0"` // Uang yang dibayarkan pelanggan, dalam mata uang dasar
 	Discount      float64       `json:"discount" validate:"gte=0"`
-	Items         []ItemRequest `json:"items" validate:"required,min=1"` // Daftar produk yang dibeli
-	UserID        uint          // Added for Event-Driven Architecture (Cashier ID)
+	Items         []ItemRequest `json:"items" validate:"required,min=1"`  // Daftar produk yang dibeli
+	RegisterID    *uint         `json:"register_id"`                      // Kasir; stok dikurangi dari lokasinya. Kosong = lokasi default
+	CustomerName  string        `json:"customer_name" validate:"max=100"` // Opsional, dicatat untuk klaim garansi
+	CustomerPhone string        `json:"customer_phone" validate:"max=30"`
+	ReservationID *uint         `json:"reservation_id"` // Reservasi pesanan yang ditahan; stok yang ditahannya boleh dijual di transaksi ini
+	// Payments diisi sebagai pengganti Cash bila pelanggan membayar (sebagian)
+	// dengan mata uang asing; tiap baris dikonversi dengan kurs saat ini dan
+	// kembalian selalu dalam mata uang dasar.
+	Payments []PaymentRequest `json:"payments" validate:"omitempty,dive"`
+	UserID   uint             // Added for Event-Driven Architecture (Cashier ID)
 }
 
 // PaginationResult wraps data with metadata
@@ -47,16 +63,22 @@ type TransactionService interface {
 }
 
 type transactionService struct {
-	repo        repositories.TransactionRepository
-	productRepo repositories.ProductRepository
-	validator   *validator.Validate
+	repo         repositories.TransactionRepository
+	productRepo  repositories.ProductRepository
+	locationRepo repositories.LocationRepository
+	scanner      BarcodeScanner
+	currencies   CurrencyService
+	validator    *validator.Validate
 }
 
-func NewTransactionService(repo repositories.TransactionRepository, productRepo repositories.ProductRepository) TransactionService {
+func NewTransactionService(repo repositories.TransactionRepository, productRepo repositories.ProductRepository, locationRepo repositories.LocationRepository, scanner BarcodeScanner, currencies CurrencyService) TransactionService {
 	return &transactionService{
-		repo:        repo,
-		productRepo: productRepo,
-		validator:   validator.New(),
+		repo:         repo,
+		productRepo:  productRepo,
+		locationRepo: locationRepo,
+		scanner:      scanner,
+		currencies:   currencies,
+		validator:    validator.New(),
 	}
 }
 
@@ -108,6 +130,14 @@ func (s *transactionService) ProcessTransaction(ctx context.Context, req Transac
 	if err := s.validator.Struct(req); err != nil {
 		return nil, errors.New("validasi gagal: " + err.Error())
 	}
+	if len(req.Payments) > 0 && req.Cash > 0 {
+		return nil, errors.New("validasi gagal: isi cash atau payments, tidak keduanya")
+	}
+
+	locationID, err := s.resolveLocation(ctx, req.RegisterID)
+	if err != nil {
+		return nil, err
+	}
 
 	// Inisiasi variabel kalkulasi
 	var (
@@ -117,31 +147,50 @@ func (s *transactionService) ProcessTransaction(ctx context.Context, req Transac
 	// Note: We don't check for stock here anymore, because the Repository does it atomically.
 	// However, we can still do a read-only check for better UX (fail fast), but we won't rely on it for data integrity.
 	for _, itemReq := range req.Items {
-		// 2a. Get Product
-		product, err := s.productRepo.GetProductByID(ctx, itemReq.ProductID)
+		// 2a. Resolve Product, Quantity & Subtotal
+		line, err := s.resolveItem(ctx, itemReq)
 		if err != nil {
-			return nil, fmt.Errorf("produk dengan ID %d tidak ditemukan", itemReq.ProductID)
+			return nil, err
 		}
+		product, quantity, subTotal := line.Product, line.Quantity, line.SubTotal
 
-		// 2c. Calculate Subtotal & Total
-		priceAtSale := product.Price
-		subTotal := priceAtSale * float64(itemReq.Quantity)
+		// 2b. Nomor seri unit yang dijual (dicek ketersediaannya saat stok dikurangi)
+		serials, err := normalizeSerials(product, quantity, itemReq.SerialNumbers)
+		if err != nil {
+			return nil, err
+		}
+
+		// 2c. Calculate Total
 		totalAmount += subTotal
 
 		// 2d. Prepare Transaction Detail
 		transactionDetails = append(transactionDetails, models.TransactionDetail{
-			ProductID:   itemReq.ProductID,
-			ProductName: product.Name,
-			Quantity:    itemReq.Quantity,
-			PriceAtSale: priceAtSale,
-			CostAtSale:  product.Cost,
-			SubTotal:    subTotal,
+			ProductID:     product.ID,
+			ProductName:   product.Name,
+			Quantity:      quantity,
+			PriceAtSale:   line.UnitPrice,
+			CostAtSale:    product.Cost,
+			SubTotal:      subTotal,
+			SerialNumbers: serials,
 		})
 	}
 
 	// 3. Final Calculation
 	grandTotal := totalAmount - req.Discount
-	change := req.Cash - grandTotal
+	cash := req.Cash
+	var payments []models.TransactionPayment
+	if len(req.Payments) > 0 {
+		payments, err = s.currencies.ConvertPayments(ctx, req.Payments)
+		if err != nil {
+			return nil, err
+		}
+		cash = 0
+		for _, p := range payments {
+			cash += p.BaseAmount
+		}
+		cash = roundMoney(cash)
+	}
+	change := cash - grandTotal
 
 	if change < 0 {
 		return nil, errors.New("jumlah uang tunai kurang")
@@ -153,9 +202,15 @@ func (s *transactionService) ProcessTransaction(ctx context.Context, req Transac
 		TotalAmount:        totalAmount,
 		Discount:           req.Discount,
 		GrandTotal:         grandTotal,
-		Cash:               req.Cash,
+		Cash:               cash,
 		Change:             change,
+		Payments:           payments,
 		PaymentMethod:      req.PaymentMethod,
+		LocationID:         locationID,
+		RegisterID:         req.RegisterID,
+		ReservationID:      req.ReservationID,
+		CustomerName:       strings.TrimSpace(req.CustomerName),
+		CustomerPhone:      strings.TrimSpace(req.CustomerPhone),
 		TransactionDetails: transactionDetails,
 	}
 
@@ -169,10 +224,75 @@ func (s *transactionService) ProcessTransaction(ctx context.Context, req Transac
 	if err != nil {
 		return &transaction, nil
 	}
+	// Peringatan stok minus tidak disimpan, bawa dari hasil listener
+	finalTransaction.StockWarnings = transaction.StockWarnings
 
 	return finalTransaction, nil
 }
 
+// resolveLocation menentukan lokasi stok untuk penjualan: lokasi kasir bila
+// register_id dikirim, selain itu lokasi default.
+func (s *transactionService) resolveLocation(ctx context.Context, registerID *uint) (uint, error) {
+	if registerID == nil {
+		location, err := s.locationRepo.GetDefault(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("gagal mengambil lokasi default: %w", err)
+		}
+		return location.ID, nil
+	}
+
+	register, err := s.locationRepo.GetRegisterByID(ctx, *registerID)
+	if err != nil {
+		return 0, fmt.Errorf("kasir dengan ID %d tidak ditemukan", *registerID)
+	}
+	if !register.IsActive {
+		return 0, fmt.Errorf("kasir %s tidak aktif", register.Code)
+	}
+	return register.LocationID, nil
+}
+
+// resolveItem menentukan produk, kuantitas, harga satuan dan subtotal satu
+// item. Item dengan barcode label timbangan memakai berat/harga yang tertanam
+// di barcode.
+func (s *transactionService) resolveItem(ctx context.Context, item ItemRequest) (*ScanResult, error) {
+	if item.Barcode != "" {
+		scan, err := s.scanner.Scan(ctx, item.Barcode)
+		if err != nil {
+			if errors.Is(err, customErrors.ErrNotFound) {
+				return nil, fmt.Errorf("produk dengan barcode %s tidak ditemukan", item.Barcode)
+			}
+			return nil, err
+		}
+		if item.ProductID != 0 && item.ProductID != scan.Product.ID {
+			return nil, fmt.Errorf("barcode %s bukan milik produk dengan ID %d", item.Barcode, item.ProductID)
+		}
+		if scan.Scale != nil {
+			return scan, nil
+		}
+
+		// Barcode produk biasa: kuantitas dari request, default 1
+		qty := item.Quantity
+		if qty == 0 {
+			qty = 1
+		}
+		if err := validateQuantity(scan.Product, qty); err != nil {
+			return nil, err
+		}
+		return &ScanResult{Product: scan.Product, Quantity: qty, UnitPrice: scan.Product.Price, SubTotal: roundMoney(scan.Product.Price * qty)}, nil
+	}
+
+	product, err := s.productRepo.GetProductByID(ctx, item.ProductID)
+	if err != nil {
+		return nil, fmt.Errorf("produk dengan ID %d tidak ditemukan", item.ProductID)
+	}
+
+	qty := roundQuantity(item.Quantity)
+	if err := validateQuantity(product, qty); err != nil {
+		return nil, err
+	}
+	return &ScanResult{Product: product, Quantity: qty, UnitPrice: product.Price, SubTotal: roundMoney(product.Price * qty)}, nil
+}
+
 func (s *transactionService) CancelTransaction(ctx context.Context, id uint) error {
 	tx, err := s.repo.GetTransactionByID(ctx, id)
 	if err != nil {
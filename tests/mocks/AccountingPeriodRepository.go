@@ -0,0 +1,151 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	models "pos-api/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// AccountingPeriodRepository is an autogenerated mock type for the AccountingPeriodRepository type
+type AccountingPeriodRepository struct {
+	mock.Mock
+}
+
+// Close provides a mock function with given fields: ctx, period, userID, reason
+func (_m *AccountingPeriodRepository) Close(ctx context.Context, period time.Time, userID uint, reason string) (*models.AccountingPeriod, error) {
+	ret := _m.Called(ctx, period, userID, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Close")
+	}
+
+	var r0 *models.AccountingPeriod
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, uint, string) (*models.AccountingPeriod, error)); ok {
+		return rf(ctx, period, userID, reason)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, uint, string) *models.AccountingPeriod); ok {
+		r0 = rf(ctx, period, userID, reason)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AccountingPeriod)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, uint, string) error); ok {
+		r1 = rf(ctx, period, userID, reason)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAll provides a mock function with given fields: ctx
+func (_m *AccountingPeriodRepository) GetAll(ctx context.Context) ([]models.AccountingPeriod, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []models.AccountingPeriod
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]models.AccountingPeriod, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []models.AccountingPeriod); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.AccountingPeriod)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLogs provides a mock function with given fields: ctx, period
+func (_m *AccountingPeriodRepository) GetLogs(ctx context.Context, period *time.Time) ([]models.AccountingPeriodLog, error) {
+	ret := _m.Called(ctx, period)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLogs")
+	}
+
+	var r0 []models.AccountingPeriodLog
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *time.Time) ([]models.AccountingPeriodLog, error)); ok {
+		return rf(ctx, period)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *time.Time) []models.AccountingPeriodLog); ok {
+		r0 = rf(ctx, period)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.AccountingPeriodLog)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *time.Time) error); ok {
+		r1 = rf(ctx, period)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Reopen provides a mock function with given fields: ctx, period, userID, reason
+func (_m *AccountingPeriodRepository) Reopen(ctx context.Context, period time.Time, userID uint, reason string) (*models.AccountingPeriod, error) {
+	ret := _m.Called(ctx, period, userID, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reopen")
+	}
+
+	var r0 *models.AccountingPeriod
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, uint, string) (*models.AccountingPeriod, error)); ok {
+		return rf(ctx, period, userID, reason)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, uint, string) *models.AccountingPeriod); ok {
+		r0 = rf(ctx, period, userID, reason)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AccountingPeriod)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, uint, string) error); ok {
+		r1 = rf(ctx, period, userID, reason)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewAccountingPeriodRepository creates a new instance of AccountingPeriodRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAccountingPeriodRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AccountingPeriodRepository {
+	mock := &AccountingPeriodRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,160 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	models "pos-api/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+
+	repositories "pos-api/internal/repositories"
+
+	time "time"
+)
+
+// AccountingRepository is an autogenerated mock type for the AccountingRepository type
+type AccountingRepository struct {
+	mock.Mock
+}
+
+// GetAccountTotals provides a mock function with given fields: ctx, asOf
+func (_m *AccountingRepository) GetAccountTotals(ctx context.Context, asOf *time.Time) ([]repositories.AccountTotal, error) {
+	ret := _m.Called(ctx, asOf)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccountTotals")
+	}
+
+	var r0 []repositories.AccountTotal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *time.Time) ([]repositories.AccountTotal, error)); ok {
+		return rf(ctx, asOf)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *time.Time) []repositories.AccountTotal); ok {
+		r0 = rf(ctx, asOf)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repositories.AccountTotal)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *time.Time) error); ok {
+		r1 = rf(ctx, asOf)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAccounts provides a mock function with given fields: ctx
+func (_m *AccountingRepository) GetAccounts(ctx context.Context) ([]models.Account, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccounts")
+	}
+
+	var r0 []models.Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]models.Account, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []models.Account); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Account)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetEntries provides a mock function with given fields: ctx, limit, offset, source, accountID, startDate, endDate
+func (_m *AccountingRepository) GetEntries(ctx context.Context, limit int, offset int, source string, accountID uint, startDate *time.Time, endDate *time.Time) ([]models.JournalEntry, int64, error) {
+	ret := _m.Called(ctx, limit, offset, source, accountID, startDate, endDate)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEntries")
+	}
+
+	var r0 []models.JournalEntry
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, string, uint, *time.Time, *time.Time) ([]models.JournalEntry, int64, error)); ok {
+		return rf(ctx, limit, offset, source, accountID, startDate, endDate)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, string, uint, *time.Time, *time.Time) []models.JournalEntry); ok {
+		r0 = rf(ctx, limit, offset, source, accountID, startDate, endDate)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.JournalEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, string, uint, *time.Time, *time.Time) int64); ok {
+		r1 = rf(ctx, limit, offset, source, accountID, startDate, endDate)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int, int, string, uint, *time.Time, *time.Time) error); ok {
+		r2 = rf(ctx, limit, offset, source, accountID, startDate, endDate)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetEntryByID provides a mock function with given fields: ctx, id
+func (_m *AccountingRepository) GetEntryByID(ctx context.Context, id uint) (*models.JournalEntry, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEntryByID")
+	}
+
+	var r0 *models.JournalEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (*models.JournalEntry, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) *models.JournalEntry); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.JournalEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewAccountingRepository creates a new instance of AccountingRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAccountingRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AccountingRepository {
+	mock := &AccountingRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,203 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	models "pos-api/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// BudgetRepository is an autogenerated mock type for the BudgetRepository type
+type BudgetRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, budget
+func (_m *BudgetRepository) Create(ctx context.Context, budget *models.Budget) error {
+	ret := _m.Called(ctx, budget)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Budget) error); ok {
+		r0 = rf(ctx, budget)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *BudgetRepository) Delete(ctx context.Context, id uint) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *BudgetRepository) GetByID(ctx context.Context, id uint) (*models.Budget, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *models.Budget
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (*models.Budget, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) *models.Budget); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Budget)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByMonth provides a mock function with given fields: ctx, month
+func (_m *BudgetRepository) GetByMonth(ctx context.Context, month time.Time) ([]models.Budget, error) {
+	ret := _m.Called(ctx, month)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByMonth")
+	}
+
+	var r0 []models.Budget
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]models.Budget, error)); ok {
+		return rf(ctx, month)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []models.Budget); ok {
+		r0 = rf(ctx, month)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Budget)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, month)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBySourceMonth provides a mock function with given fields: ctx, source, month
+func (_m *BudgetRepository) GetBySourceMonth(ctx context.Context, source string, month time.Time) (*models.Budget, error) {
+	ret := _m.Called(ctx, source, month)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBySourceMonth")
+	}
+
+	var r0 *models.Budget
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) (*models.Budget, error)); ok {
+		return rf(ctx, source, month)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) *models.Budget); ok {
+		r0 = rf(ctx, source, month)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Budget)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, source, month)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetAlerted provides a mock function with given fields: ctx, id, at
+func (_m *BudgetRepository) SetAlerted(ctx context.Context, id uint, at *time.Time) (bool, error) {
+	ret := _m.Called(ctx, id, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetAlerted")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, *time.Time) (bool, error)); ok {
+		return rf(ctx, id, at)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, *time.Time) bool); ok {
+		r0 = rf(ctx, id, at)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, *time.Time) error); ok {
+		r1 = rf(ctx, id, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, budget
+func (_m *BudgetRepository) Update(ctx context.Context, budget *models.Budget) error {
+	ret := _m.Called(ctx, budget)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Budget) error); ok {
+		r0 = rf(ctx, budget)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewBudgetRepository creates a new instance of BudgetRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBudgetRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BudgetRepository {
+	mock := &BudgetRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,229 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	models "pos-api/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// CashFlowCategoryRepository is an autogenerated mock type for the CashFlowCategoryRepository type
+type CashFlowCategoryRepository struct {
+	mock.Mock
+}
+
+// AccountExists provides a mock function with given fields: ctx, code
+func (_m *CashFlowCategoryRepository) AccountExists(ctx context.Context, code string) (bool, error) {
+	ret := _m.Called(ctx, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AccountExists")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, code)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: ctx, category
+func (_m *CashFlowCategoryRepository) Create(ctx context.Context, category *models.CashFlowCategory) error {
+	ret := _m.Called(ctx, category)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.CashFlowCategory) error); ok {
+		r0 = rf(ctx, category)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *CashFlowCategoryRepository) Delete(ctx context.Context, id uint) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields: ctx, cfType, onlyActive
+func (_m *CashFlowCategoryRepository) GetAll(ctx context.Context, cfType string, onlyActive bool) ([]models.CashFlowCategory, error) {
+	ret := _m.Called(ctx, cfType, onlyActive)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []models.CashFlowCategory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) ([]models.CashFlowCategory, error)); ok {
+		return rf(ctx, cfType, onlyActive)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) []models.CashFlowCategory); ok {
+		r0 = rf(ctx, cfType, onlyActive)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.CashFlowCategory)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, bool) error); ok {
+		r1 = rf(ctx, cfType, onlyActive)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByCode provides a mock function with given fields: ctx, code
+func (_m *CashFlowCategoryRepository) GetByCode(ctx context.Context, code string) (*models.CashFlowCategory, error) {
+	ret := _m.Called(ctx, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByCode")
+	}
+
+	var r0 *models.CashFlowCategory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.CashFlowCategory, error)); ok {
+		return rf(ctx, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.CashFlowCategory); ok {
+		r0 = rf(ctx, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CashFlowCategory)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *CashFlowCategoryRepository) GetByID(ctx context.Context, id uint) (*models.CashFlowCategory, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *models.CashFlowCategory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (*models.CashFlowCategory, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) *models.CashFlowCategory); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CashFlowCategory)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsUsed provides a mock function with given fields: ctx, code
+func (_m *CashFlowCategoryRepository) IsUsed(ctx context.Context, code string) (bool, error) {
+	ret := _m.Called(ctx, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsUsed")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, code)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, category
+func (_m *CashFlowCategoryRepository) Update(ctx context.Context, category *models.CashFlowCategory) error {
+	ret := _m.Called(ctx, category)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.CashFlowCategory) error); ok {
+		r0 = rf(ctx, category)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewCashFlowCategoryRepository creates a new instance of CashFlowCategoryRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCashFlowCategoryRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CashFlowCategoryRepository {
+	mock := &CashFlowCategoryRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
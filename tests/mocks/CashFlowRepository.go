@@ -5,10 +5,11 @@ package mocks
 import (
 	context "context"
 	models "pos-api/internal/models"
-	repositories "pos-api/internal/repositories"
 
 	mock "github.com/stretchr/testify/mock"
 
+	repositories "pos-api/internal/repositories"
+
 	time "time"
 )
 
@@ -53,6 +54,36 @@ func (_m *CashFlowRepository) Delete(ctx context.Context, id uint) error {
 	return r0
 }
 
+// ForceDelete provides a mock function with given fields: ctx, id
+func (_m *CashFlowRepository) ForceDelete(ctx context.Context, id uint) ([]models.Attachment, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ForceDelete")
+	}
+
+	var r0 []models.Attachment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) ([]models.Attachment, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []models.Attachment); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Attachment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetAll provides a mock function with given fields: ctx, limit, offset, cfType, source, startDate, endDate
 func (_m *CashFlowRepository) GetAll(ctx context.Context, limit int, offset int, cfType string, source string, startDate *time.Time, endDate *time.Time) ([]models.CashFlow, int64, error) {
 	ret := _m.Called(ctx, limit, offset, cfType, source, startDate, endDate)
@@ -120,6 +151,36 @@ func (_m *CashFlowRepository) GetByID(ctx context.Context, id uint) (*models.Cas
 	return r0, r1
 }
 
+// GetSourceBreakdown provides a mock function with given fields: ctx, startDate, endDate
+func (_m *CashFlowRepository) GetSourceBreakdown(ctx context.Context, startDate time.Time, endDate time.Time) ([]repositories.CashFlowSourceData, error) {
+	ret := _m.Called(ctx, startDate, endDate)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSourceBreakdown")
+	}
+
+	var r0 []repositories.CashFlowSourceData
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) ([]repositories.CashFlowSourceData, error)); ok {
+		return rf(ctx, startDate, endDate)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) []repositories.CashFlowSourceData); ok {
+		r0 = rf(ctx, startDate, endDate)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repositories.CashFlowSourceData)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, startDate, endDate)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetSummary provides a mock function with given fields: ctx, startDate, endDate
 func (_m *CashFlowRepository) GetSummary(ctx context.Context, startDate time.Time, endDate time.Time) (float64, float64, float64, error) {
 	ret := _m.Called(ctx, startDate, endDate)
@@ -162,36 +223,6 @@ func (_m *CashFlowRepository) GetSummary(ctx context.Context, startDate time.Tim
 	return r0, r1, r2, r3
 }
 
-// GetSourceBreakdown provides a mock function with given fields: ctx, startDate, endDate
-func (_m *CashFlowRepository) GetSourceBreakdown(ctx context.Context, startDate time.Time, endDate time.Time) ([]repositories.CashFlowSourceData, error) {
-	ret := _m.Called(ctx, startDate, endDate)
-
-	if len(ret) == 0 {
-		panic("no return value specified for GetSourceBreakdown")
-	}
-
-	var r0 []repositories.CashFlowSourceData
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) ([]repositories.CashFlowSourceData, error)); ok {
-		return rf(ctx, startDate, endDate)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) []repositories.CashFlowSourceData); ok {
-		r0 = rf(ctx, startDate, endDate)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]repositories.CashFlowSourceData)
-		}
-	}
-
-	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time) error); ok {
-		r1 = rf(ctx, startDate, endDate)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // Update provides a mock function with given fields: ctx, cf
 func (_m *CashFlowRepository) Update(ctx context.Context, cf *models.CashFlow) error {
 	ret := _m.Called(ctx, cf)
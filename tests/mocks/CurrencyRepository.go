@@ -0,0 +1,265 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	models "pos-api/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+
+	repositories "pos-api/internal/repositories"
+
+	time "time"
+)
+
+// CurrencyRepository is an autogenerated mock type for the CurrencyRepository type
+type CurrencyRepository struct {
+	mock.Mock
+}
+
+// Count provides a mock function with given fields: ctx
+func (_m *CurrencyRepository) Count(ctx context.Context) (int64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Count")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: ctx, currency, userID
+func (_m *CurrencyRepository) Create(ctx context.Context, currency *models.Currency, userID uint) error {
+	ret := _m.Called(ctx, currency, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Currency, uint) error); ok {
+		r0 = rf(ctx, currency, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields: ctx, activeOnly
+func (_m *CurrencyRepository) GetAll(ctx context.Context, activeOnly bool) ([]models.Currency, error) {
+	ret := _m.Called(ctx, activeOnly)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []models.Currency
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bool) ([]models.Currency, error)); ok {
+		return rf(ctx, activeOnly)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bool) []models.Currency); ok {
+		r0 = rf(ctx, activeOnly)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Currency)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bool) error); ok {
+		r1 = rf(ctx, activeOnly)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByCode provides a mock function with given fields: ctx, code
+func (_m *CurrencyRepository) GetByCode(ctx context.Context, code string) (*models.Currency, error) {
+	ret := _m.Called(ctx, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByCode")
+	}
+
+	var r0 *models.Currency
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Currency, error)); ok {
+		return rf(ctx, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Currency); ok {
+		r0 = rf(ctx, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Currency)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *CurrencyRepository) GetByID(ctx context.Context, id uint) (*models.Currency, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *models.Currency
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (*models.Currency, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) *models.Currency); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Currency)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRateHistory provides a mock function with given fields: ctx, currencyID, limit
+func (_m *CurrencyRepository) GetRateHistory(ctx context.Context, currencyID uint, limit int) ([]models.ExchangeRate, error) {
+	ret := _m.Called(ctx, currencyID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRateHistory")
+	}
+
+	var r0 []models.ExchangeRate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, int) ([]models.ExchangeRate, error)); ok {
+		return rf(ctx, currencyID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, int) []models.ExchangeRate); ok {
+		r0 = rf(ctx, currencyID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ExchangeRate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, int) error); ok {
+		r1 = rf(ctx, currencyID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReceipts provides a mock function with given fields: ctx, from, to
+func (_m *CurrencyRepository) GetReceipts(ctx context.Context, from time.Time, to time.Time) ([]repositories.CurrencyReceipt, error) {
+	ret := _m.Called(ctx, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReceipts")
+	}
+
+	var r0 []repositories.CurrencyReceipt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) ([]repositories.CurrencyReceipt, error)); ok {
+		return rf(ctx, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) []repositories.CurrencyReceipt); ok {
+		r0 = rf(ctx, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repositories.CurrencyReceipt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetRates provides a mock function with given fields: ctx, rates, at
+func (_m *CurrencyRepository) SetRates(ctx context.Context, rates []models.ExchangeRate, at time.Time) error {
+	ret := _m.Called(ctx, rates, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetRates")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []models.ExchangeRate, time.Time) error); ok {
+		r0 = rf(ctx, rates, at)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: ctx, currency
+func (_m *CurrencyRepository) Update(ctx context.Context, currency *models.Currency) error {
+	ret := _m.Called(ctx, currency)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Currency) error); ok {
+		r0 = rf(ctx, currency)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewCurrencyRepository creates a new instance of CurrencyRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCurrencyRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CurrencyRepository {
+	mock := &CurrencyRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
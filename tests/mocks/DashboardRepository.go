@@ -76,9 +76,9 @@ func (_m *DashboardRepository) GetHourlyRevenueTrend(ctx context.Context, startD
 	return r0, r1
 }
 
-// GetLowStockCount provides a mock function with given fields: ctx, threshold
-func (_m *DashboardRepository) GetLowStockCount(ctx context.Context, threshold int) (int64, error) {
-	ret := _m.Called(ctx, threshold)
+// GetLowStockCount provides a mock function with given fields: ctx
+func (_m *DashboardRepository) GetLowStockCount(ctx context.Context) (int64, error) {
+	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetLowStockCount")
@@ -86,17 +86,17 @@ func (_m *DashboardRepository) GetLowStockCount(ctx context.Context, threshold i
 
 	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int) (int64, error)); ok {
-		return rf(ctx, threshold)
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int) int64); ok {
-		r0 = rf(ctx, threshold)
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
-		r1 = rf(ctx, threshold)
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -104,9 +104,9 @@ func (_m *DashboardRepository) GetLowStockCount(ctx context.Context, threshold i
 	return r0, r1
 }
 
-// GetLowStockProducts provides a mock function with given fields: ctx, threshold, limit
-func (_m *DashboardRepository) GetLowStockProducts(ctx context.Context, threshold int, limit int) ([]repositories.LowStockProduct, error) {
-	ret := _m.Called(ctx, threshold, limit)
+// GetLowStockProducts provides a mock function with given fields: ctx, limit
+func (_m *DashboardRepository) GetLowStockProducts(ctx context.Context, limit int) ([]repositories.LowStockProduct, error) {
+	ret := _m.Called(ctx, limit)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetLowStockProducts")
@@ -114,19 +114,19 @@ func (_m *DashboardRepository) GetLowStockProducts(ctx context.Context, threshol
 
 	var r0 []repositories.LowStockProduct
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int, int) ([]repositories.LowStockProduct, error)); ok {
-		return rf(ctx, threshold, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]repositories.LowStockProduct, error)); ok {
+		return rf(ctx, limit)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int, int) []repositories.LowStockProduct); ok {
-		r0 = rf(ctx, threshold, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, int) []repositories.LowStockProduct); ok {
+		r0 = rf(ctx, limit)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]repositories.LowStockProduct)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
-		r1 = rf(ctx, threshold, limit)
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
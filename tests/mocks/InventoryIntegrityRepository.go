@@ -0,0 +1,165 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	models "pos-api/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// InventoryIntegrityRepository is an autogenerated mock type for the InventoryIntegrityRepository type
+type InventoryIntegrityRepository struct {
+	mock.Mock
+}
+
+// GetEntries provides a mock function with given fields: ctx, productID
+func (_m *InventoryIntegrityRepository) GetEntries(ctx context.Context, productID uint) ([]models.InventoryLog, error) {
+	ret := _m.Called(ctx, productID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEntries")
+	}
+
+	var r0 []models.InventoryLog
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) ([]models.InventoryLog, error)); ok {
+		return rf(ctx, productID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []models.InventoryLog); ok {
+		r0 = rf(ctx, productID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.InventoryLog)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, productID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLocationLevels provides a mock function with given fields: ctx, productID
+func (_m *InventoryIntegrityRepository) GetLocationLevels(ctx context.Context, productID uint) ([]models.ProductStock, error) {
+	ret := _m.Called(ctx, productID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLocationLevels")
+	}
+
+	var r0 []models.ProductStock
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) ([]models.ProductStock, error)); ok {
+		return rf(ctx, productID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []models.ProductStock); ok {
+		r0 = rf(ctx, productID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ProductStock)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, productID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetProducts provides a mock function with given fields: ctx, productID
+func (_m *InventoryIntegrityRepository) GetProducts(ctx context.Context, productID uint) ([]models.Product, error) {
+	ret := _m.Called(ctx, productID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProducts")
+	}
+
+	var r0 []models.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) ([]models.Product, error)); ok {
+		return rf(ctx, productID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []models.Product); ok {
+		r0 = rf(ctx, productID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Product)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, productID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PostCorrection provides a mock function with given fields: ctx, log
+func (_m *InventoryIntegrityRepository) PostCorrection(ctx context.Context, log *models.InventoryLog) error {
+	ret := _m.Called(ctx, log)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PostCorrection")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.InventoryLog) error); ok {
+		r0 = rf(ctx, log)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SyncProductStock provides a mock function with given fields: ctx, productID
+func (_m *InventoryIntegrityRepository) SyncProductStock(ctx context.Context, productID uint) (float64, error) {
+	ret := _m.Called(ctx, productID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SyncProductStock")
+	}
+
+	var r0 float64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (float64, error)); ok {
+		return rf(ctx, productID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) float64); ok {
+		r0 = rf(ctx, productID)
+	} else {
+		r0 = ret.Get(0).(float64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, productID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewInventoryIntegrityRepository creates a new instance of InventoryIntegrityRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewInventoryIntegrityRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *InventoryIntegrityRepository {
+	mock := &InventoryIntegrityRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
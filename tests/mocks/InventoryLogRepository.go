@@ -5,9 +5,12 @@ package mocks
 import (
 	context "context"
 	models "pos-api/internal/models"
-	time "time"
 
 	mock "github.com/stretchr/testify/mock"
+
+	repositories "pos-api/internal/repositories"
+
+	time "time"
 )
 
 // InventoryLogRepository is an autogenerated mock type for the InventoryLogRepository type
@@ -33,22 +36,41 @@ func (_m *InventoryLogRepository) Create(ctx context.Context, log *models.Invent
 	return r0
 }
 
-// ProcessAdjustment provides a mock function with given fields: ctx, log, product
-func (_m *InventoryLogRepository) ProcessAdjustment(ctx context.Context, log *models.InventoryLog, product *models.Product) error {
-	ret := _m.Called(ctx, log, product)
+// GetAll provides a mock function with given fields: ctx, limit, offset, logType, source, startDate, endDate
+func (_m *InventoryLogRepository) GetAll(ctx context.Context, limit int, offset int, logType string, source string, startDate *time.Time, endDate *time.Time) ([]models.InventoryLog, int64, error) {
+	ret := _m.Called(ctx, limit, offset, logType, source, startDate, endDate)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ProcessAdjustment")
+		panic("no return value specified for GetAll")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *models.InventoryLog, *models.Product) error); ok {
-		r0 = rf(ctx, log, product)
+	var r0 []models.InventoryLog
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, string, string, *time.Time, *time.Time) ([]models.InventoryLog, int64, error)); ok {
+		return rf(ctx, limit, offset, logType, source, startDate, endDate)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, string, string, *time.Time, *time.Time) []models.InventoryLog); ok {
+		r0 = rf(ctx, limit, offset, logType, source, startDate, endDate)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.InventoryLog)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, string, string, *time.Time, *time.Time) int64); ok {
+		r1 = rf(ctx, limit, offset, logType, source, startDate, endDate)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int, int, string, string, *time.Time, *time.Time) error); ok {
+		r2 = rf(ctx, limit, offset, logType, source, startDate, endDate)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
 // GetByProductID provides a mock function with given fields: ctx, productID, limit, offset
@@ -88,36 +110,36 @@ func (_m *InventoryLogRepository) GetByProductID(ctx context.Context, productID
 	return r0, r1, r2
 }
 
-// GetAll provides a mock function with given fields: ctx, limit, offset, logType, source, startDate, endDate
-func (_m *InventoryLogRepository) GetAll(ctx context.Context, limit int, offset int, logType string, source string, startDate *time.Time, endDate *time.Time) ([]models.InventoryLog, int64, error) {
-	ret := _m.Called(ctx, limit, offset, logType, source, startDate, endDate)
+// GetShortfalls provides a mock function with given fields: ctx, limit, offset, onlyOpen
+func (_m *InventoryLogRepository) GetShortfalls(ctx context.Context, limit int, offset int, onlyOpen bool) ([]models.InventoryLog, int64, error) {
+	ret := _m.Called(ctx, limit, offset, onlyOpen)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAll")
+		panic("no return value specified for GetShortfalls")
 	}
 
 	var r0 []models.InventoryLog
 	var r1 int64
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, int, int, string, string, *time.Time, *time.Time) ([]models.InventoryLog, int64, error)); ok {
-		return rf(ctx, limit, offset, logType, source, startDate, endDate)
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, bool) ([]models.InventoryLog, int64, error)); ok {
+		return rf(ctx, limit, offset, onlyOpen)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int, int, string, string, *time.Time, *time.Time) []models.InventoryLog); ok {
-		r0 = rf(ctx, limit, offset, logType, source, startDate, endDate)
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, bool) []models.InventoryLog); ok {
+		r0 = rf(ctx, limit, offset, onlyOpen)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]models.InventoryLog)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int, int, string, string, *time.Time, *time.Time) int64); ok {
-		r1 = rf(ctx, limit, offset, logType, source, startDate, endDate)
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, bool) int64); ok {
+		r1 = rf(ctx, limit, offset, onlyOpen)
 	} else {
 		r1 = ret.Get(1).(int64)
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, int, int, string, string, *time.Time, *time.Time) error); ok {
-		r2 = rf(ctx, limit, offset, logType, source, startDate, endDate)
+	if rf, ok := ret.Get(2).(func(context.Context, int, int, bool) error); ok {
+		r2 = rf(ctx, limit, offset, onlyOpen)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -155,6 +177,72 @@ func (_m *InventoryLogRepository) GetStats(ctx context.Context, startDate *time.
 	return r0, r1
 }
 
+// ProcessAdjustment provides a mock function with given fields: ctx, log, product, lot
+func (_m *InventoryLogRepository) ProcessAdjustment(ctx context.Context, log *models.InventoryLog, product *models.Product, lot *models.ProductLot) error {
+	ret := _m.Called(ctx, log, product, lot)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProcessAdjustment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.InventoryLog, *models.Product, *models.ProductLot) error); ok {
+		r0 = rf(ctx, log, product, lot)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ProcessReceipt provides a mock function with given fields: ctx, purchaseOrderID, adjustments
+func (_m *InventoryLogRepository) ProcessReceipt(ctx context.Context, purchaseOrderID uint, adjustments []repositories.StockAdjustment) error {
+	ret := _m.Called(ctx, purchaseOrderID, adjustments)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProcessReceipt")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, []repositories.StockAdjustment) error); ok {
+		r0 = rf(ctx, purchaseOrderID, adjustments)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReviewShortfall provides a mock function with given fields: ctx, id, userID, notes
+func (_m *InventoryLogRepository) ReviewShortfall(ctx context.Context, id uint, userID uint, notes string) (*models.InventoryLog, error) {
+	ret := _m.Called(ctx, id, userID, notes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReviewShortfall")
+	}
+
+	var r0 *models.InventoryLog
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, string) (*models.InventoryLog, error)); ok {
+		return rf(ctx, id, userID, notes)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, string) *models.InventoryLog); ok {
+		r0 = rf(ctx, id, userID, notes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.InventoryLog)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint, string) error); ok {
+		r1 = rf(ctx, id, userID, notes)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewInventoryLogRepository creates a new instance of InventoryLogRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewInventoryLogRepository(t interface {
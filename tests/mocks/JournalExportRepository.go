@@ -0,0 +1,320 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	models "pos-api/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+
+	repositories "pos-api/internal/repositories"
+
+	time "time"
+)
+
+// JournalExportRepository is an autogenerated mock type for the JournalExportRepository type
+type JournalExportRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, export, sources, start, end
+func (_m *JournalExportRepository) Create(ctx context.Context, export *models.JournalExport, sources []string, start time.Time, end time.Time) (bool, error) {
+	ret := _m.Called(ctx, export, sources, start, end)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.JournalExport, []string, time.Time, time.Time) (bool, error)); ok {
+		return rf(ctx, export, sources, start, end)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.JournalExport, []string, time.Time, time.Time) bool); ok {
+		r0 = rf(ctx, export, sources, start, end)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.JournalExport, []string, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, export, sources, start, end)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateTemplate provides a mock function with given fields: ctx, template
+func (_m *JournalExportRepository) CreateTemplate(ctx context.Context, template *models.JournalExportTemplate) error {
+	ret := _m.Called(ctx, template)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTemplate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.JournalExportTemplate) error); ok {
+		r0 = rf(ctx, template)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *JournalExportRepository) Delete(ctx context.Context, id uint) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteTemplate provides a mock function with given fields: ctx, id
+func (_m *JournalExportRepository) DeleteTemplate(ctx context.Context, id uint) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTemplate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetExport provides a mock function with given fields: ctx, id
+func (_m *JournalExportRepository) GetExport(ctx context.Context, id uint) (*models.JournalExport, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetExport")
+	}
+
+	var r0 *models.JournalExport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (*models.JournalExport, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) *models.JournalExport); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.JournalExport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetExports provides a mock function with given fields: ctx, limit, offset
+func (_m *JournalExportRepository) GetExports(ctx context.Context, limit int, offset int) ([]models.JournalExport, int64, error) {
+	ret := _m.Called(ctx, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetExports")
+	}
+
+	var r0 []models.JournalExport
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) ([]models.JournalExport, int64, error)); ok {
+		return rf(ctx, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []models.JournalExport); ok {
+		r0 = rf(ctx, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.JournalExport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) int64); ok {
+		r1 = rf(ctx, limit, offset)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int, int) error); ok {
+		r2 = rf(ctx, limit, offset)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetRows provides a mock function with given fields: ctx, exportID
+func (_m *JournalExportRepository) GetRows(ctx context.Context, exportID uint) ([]repositories.JournalExportRow, error) {
+	ret := _m.Called(ctx, exportID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRows")
+	}
+
+	var r0 []repositories.JournalExportRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) ([]repositories.JournalExportRow, error)); ok {
+		return rf(ctx, exportID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []repositories.JournalExportRow); ok {
+		r0 = rf(ctx, exportID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repositories.JournalExportRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, exportID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTemplate provides a mock function with given fields: ctx, id
+func (_m *JournalExportRepository) GetTemplate(ctx context.Context, id uint) (*models.JournalExportTemplate, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTemplate")
+	}
+
+	var r0 *models.JournalExportTemplate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (*models.JournalExportTemplate, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) *models.JournalExportTemplate); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.JournalExportTemplate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTemplateByName provides a mock function with given fields: ctx, name
+func (_m *JournalExportRepository) GetTemplateByName(ctx context.Context, name string) (*models.JournalExportTemplate, error) {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTemplateByName")
+	}
+
+	var r0 *models.JournalExportTemplate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.JournalExportTemplate, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.JournalExportTemplate); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.JournalExportTemplate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTemplates provides a mock function with given fields: ctx
+func (_m *JournalExportRepository) GetTemplates(ctx context.Context) ([]models.JournalExportTemplate, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTemplates")
+	}
+
+	var r0 []models.JournalExportTemplate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]models.JournalExportTemplate, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []models.JournalExportTemplate); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.JournalExportTemplate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateTemplate provides a mock function with given fields: ctx, template
+func (_m *JournalExportRepository) UpdateTemplate(ctx context.Context, template *models.JournalExportTemplate) error {
+	ret := _m.Called(ctx, template)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTemplate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.JournalExportTemplate) error); ok {
+		r0 = rf(ctx, template)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewJournalExportRepository creates a new instance of JournalExportRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewJournalExportRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *JournalExportRepository {
+	mock := &JournalExportRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,398 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	models "pos-api/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// LocationRepository is an autogenerated mock type for the LocationRepository type
+type LocationRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, location
+func (_m *LocationRepository) Create(ctx context.Context, location *models.Location) error {
+	ret := _m.Called(ctx, location)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Location) error); ok {
+		r0 = rf(ctx, location)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateRegister provides a mock function with given fields: ctx, register
+func (_m *LocationRepository) CreateRegister(ctx context.Context, register *models.Register) error {
+	ret := _m.Called(ctx, register)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRegister")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Register) error); ok {
+		r0 = rf(ctx, register)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *LocationRepository) Delete(ctx context.Context, id uint) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteRegister provides a mock function with given fields: ctx, id
+func (_m *LocationRepository) DeleteRegister(ctx context.Context, id uint) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRegister")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields: ctx, onlyActive
+func (_m *LocationRepository) GetAll(ctx context.Context, onlyActive bool) ([]models.Location, error) {
+	ret := _m.Called(ctx, onlyActive)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []models.Location
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bool) ([]models.Location, error)); ok {
+		return rf(ctx, onlyActive)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bool) []models.Location); ok {
+		r0 = rf(ctx, onlyActive)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Location)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bool) error); ok {
+		r1 = rf(ctx, onlyActive)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *LocationRepository) GetByID(ctx context.Context, id uint) (*models.Location, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *models.Location
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (*models.Location, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) *models.Location); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Location)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDefault provides a mock function with given fields: ctx
+func (_m *LocationRepository) GetDefault(ctx context.Context) (*models.Location, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDefault")
+	}
+
+	var r0 *models.Location
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*models.Location, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *models.Location); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Location)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRegisterByID provides a mock function with given fields: ctx, id
+func (_m *LocationRepository) GetRegisterByID(ctx context.Context, id uint) (*models.Register, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRegisterByID")
+	}
+
+	var r0 *models.Register
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (*models.Register, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) *models.Register); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Register)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRegisters provides a mock function with given fields: ctx, locationID
+func (_m *LocationRepository) GetRegisters(ctx context.Context, locationID uint) ([]models.Register, error) {
+	ret := _m.Called(ctx, locationID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRegisters")
+	}
+
+	var r0 []models.Register
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) ([]models.Register, error)); ok {
+		return rf(ctx, locationID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []models.Register); ok {
+		r0 = rf(ctx, locationID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Register)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, locationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStockLevel provides a mock function with given fields: ctx, productID, locationID
+func (_m *LocationRepository) GetStockLevel(ctx context.Context, productID uint, locationID uint) (float64, error) {
+	ret := _m.Called(ctx, productID, locationID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStockLevel")
+	}
+
+	var r0 float64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) (float64, error)); ok {
+		return rf(ctx, productID, locationID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) float64); ok {
+		r0 = rf(ctx, productID, locationID)
+	} else {
+		r0 = ret.Get(0).(float64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, productID, locationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStockLevels provides a mock function with given fields: ctx, limit, offset, locationID, productID
+func (_m *LocationRepository) GetStockLevels(ctx context.Context, limit int, offset int, locationID uint, productID uint) ([]models.ProductStock, int64, error) {
+	ret := _m.Called(ctx, limit, offset, locationID, productID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStockLevels")
+	}
+
+	var r0 []models.ProductStock
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, uint, uint) ([]models.ProductStock, int64, error)); ok {
+		return rf(ctx, limit, offset, locationID, productID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, uint, uint) []models.ProductStock); ok {
+		r0 = rf(ctx, limit, offset, locationID, productID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ProductStock)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, uint, uint) int64); ok {
+		r1 = rf(ctx, limit, offset, locationID, productID)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int, int, uint, uint) error); ok {
+		r2 = rf(ctx, limit, offset, locationID, productID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// HasStock provides a mock function with given fields: ctx, id
+func (_m *LocationRepository) HasStock(ctx context.Context, id uint) (bool, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HasStock")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (bool, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) bool); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetDefault provides a mock function with given fields: ctx, id
+func (_m *LocationRepository) SetDefault(ctx context.Context, id uint) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetDefault")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: ctx, location
+func (_m *LocationRepository) Update(ctx context.Context, location *models.Location) error {
+	ret := _m.Called(ctx, location)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Location) error); ok {
+		r0 = rf(ctx, location)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateRegister provides a mock function with given fields: ctx, register
+func (_m *LocationRepository) UpdateRegister(ctx context.Context, register *models.Register) error {
+	ret := _m.Called(ctx, register)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateRegister")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Register) error); ok {
+		r0 = rf(ctx, register)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewLocationRepository creates a new instance of LocationRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewLocationRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *LocationRepository {
+	mock := &LocationRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
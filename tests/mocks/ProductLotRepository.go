@@ -0,0 +1,158 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	models "pos-api/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// ProductLotRepository is an autogenerated mock type for the ProductLotRepository type
+type ProductLotRepository struct {
+	mock.Mock
+}
+
+// GetAll provides a mock function with given fields: ctx, limit, offset, productID, locationID, includeEmpty
+func (_m *ProductLotRepository) GetAll(ctx context.Context, limit int, offset int, productID uint, locationID uint, includeEmpty bool) ([]models.ProductLot, int64, error) {
+	ret := _m.Called(ctx, limit, offset, productID, locationID, includeEmpty)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []models.ProductLot
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, uint, uint, bool) ([]models.ProductLot, int64, error)); ok {
+		return rf(ctx, limit, offset, productID, locationID, includeEmpty)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, uint, uint, bool) []models.ProductLot); ok {
+		r0 = rf(ctx, limit, offset, productID, locationID, includeEmpty)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ProductLot)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, uint, uint, bool) int64); ok {
+		r1 = rf(ctx, limit, offset, productID, locationID, includeEmpty)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int, int, uint, uint, bool) error); ok {
+		r2 = rf(ctx, limit, offset, productID, locationID, includeEmpty)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *ProductLotRepository) GetByID(ctx context.Context, id uint) (*models.ProductLot, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *models.ProductLot
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (*models.ProductLot, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) *models.ProductLot); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ProductLot)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetExpiring provides a mock function with given fields: ctx, until, locationID
+func (_m *ProductLotRepository) GetExpiring(ctx context.Context, until time.Time, locationID uint) ([]models.ProductLot, error) {
+	ret := _m.Called(ctx, until, locationID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetExpiring")
+	}
+
+	var r0 []models.ProductLot
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, uint) ([]models.ProductLot, error)); ok {
+		return rf(ctx, until, locationID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, uint) []models.ProductLot); ok {
+		r0 = rf(ctx, until, locationID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ProductLot)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, uint) error); ok {
+		r1 = rf(ctx, until, locationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WriteOff provides a mock function with given fields: ctx, id, userID, notes
+func (_m *ProductLotRepository) WriteOff(ctx context.Context, id uint, userID uint, notes string) (*models.InventoryLog, error) {
+	ret := _m.Called(ctx, id, userID, notes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WriteOff")
+	}
+
+	var r0 *models.InventoryLog
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, string) (*models.InventoryLog, error)); ok {
+		return rf(ctx, id, userID, notes)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, string) *models.InventoryLog); ok {
+		r0 = rf(ctx, id, userID, notes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.InventoryLog)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint, string) error); ok {
+		r1 = rf(ctx, id, userID, notes)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewProductLotRepository creates a new instance of ProductLotRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewProductLotRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ProductLotRepository {
+	mock := &ProductLotRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
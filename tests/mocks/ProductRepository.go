@@ -7,6 +7,10 @@ import (
 	models "pos-api/internal/models"
 
 	mock "github.com/stretchr/testify/mock"
+
+	repositories "pos-api/internal/repositories"
+
+	time "time"
 )
 
 // ProductRepository is an autogenerated mock type for the ProductRepository type
@@ -14,17 +18,45 @@ type ProductRepository struct {
 	mock.Mock
 }
 
-// CreateProduct provides a mock function with given fields: ctx, product
-func (_m *ProductRepository) CreateProduct(ctx context.Context, product *models.Product) error {
-	ret := _m.Called(ctx, product)
+// BarcodeExists provides a mock function with given fields: ctx, barcode
+func (_m *ProductRepository) BarcodeExists(ctx context.Context, barcode string) (bool, error) {
+	ret := _m.Called(ctx, barcode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BarcodeExists")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, barcode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, barcode)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, barcode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateProduct provides a mock function with given fields: ctx, product, userID
+func (_m *ProductRepository) CreateProduct(ctx context.Context, product *models.Product, userID uint) error {
+	ret := _m.Called(ctx, product, userID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateProduct")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *models.Product) error); ok {
-		r0 = rf(ctx, product)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Product, uint) error); ok {
+		r0 = rf(ctx, product, userID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -50,6 +82,24 @@ func (_m *ProductRepository) DeleteProduct(ctx context.Context, id uint) error {
 	return r0
 }
 
+// ForceDeleteProduct provides a mock function with given fields: ctx, id
+func (_m *ProductRepository) ForceDeleteProduct(ctx context.Context, id uint) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ForceDeleteProduct")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetAllProducts provides a mock function with given fields: ctx, limit, offset, search, stockFilter, sortBy, sortOrder, onlyTrashed
 func (_m *ProductRepository) GetAllProducts(ctx context.Context, limit int, offset int, search string, stockFilter string, sortBy string, sortOrder string, onlyTrashed bool) ([]models.Product, int64, error) {
 	ret := _m.Called(ctx, limit, offset, search, stockFilter, sortBy, sortOrder, onlyTrashed)
@@ -117,29 +167,29 @@ func (_m *ProductRepository) GetLowStockProducts(ctx context.Context, threshold
 	return r0, r1
 }
 
-// GetStockCounts provides a mock function with given fields: ctx
-func (_m *ProductRepository) GetStockCounts(ctx context.Context) (map[string]int64, error) {
-	ret := _m.Called(ctx)
+// GetProductByBarcode provides a mock function with given fields: ctx, barcode
+func (_m *ProductRepository) GetProductByBarcode(ctx context.Context, barcode string) (*models.Product, error) {
+	ret := _m.Called(ctx, barcode)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetStockCounts")
+		panic("no return value specified for GetProductByBarcode")
 	}
 
-	var r0 map[string]int64
+	var r0 *models.Product
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) (map[string]int64, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Product, error)); ok {
+		return rf(ctx, barcode)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) map[string]int64); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Product); ok {
+		r0 = rf(ctx, barcode)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(map[string]int64)
+			r0 = ret.Get(0).(*models.Product)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, barcode)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -177,40 +227,122 @@ func (_m *ProductRepository) GetProductByID(ctx context.Context, id uint) (*mode
 	return r0, r1
 }
 
-// UpdateProduct provides a mock function with given fields: ctx, product
-func (_m *ProductRepository) UpdateProduct(ctx context.Context, product *models.Product) error {
-	ret := _m.Called(ctx, product)
+// GetProductByPLU provides a mock function with given fields: ctx, plu
+func (_m *ProductRepository) GetProductByPLU(ctx context.Context, plu string) (*models.Product, error) {
+	ret := _m.Called(ctx, plu)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateProduct")
+		panic("no return value specified for GetProductByPLU")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *models.Product) error); ok {
-		r0 = rf(ctx, product)
+	var r0 *models.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Product, error)); ok {
+		return rf(ctx, plu)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Product); ok {
+		r0 = rf(ctx, plu)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Product)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, plu)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// ForceDeleteProduct provides a mock function with given fields: ctx, id
-func (_m *ProductRepository) ForceDeleteProduct(ctx context.Context, id uint) error {
-	ret := _m.Called(ctx, id)
+// GetReorderCandidates provides a mock function with given fields: ctx, since
+func (_m *ProductRepository) GetReorderCandidates(ctx context.Context, since time.Time) ([]repositories.ReorderCandidate, error) {
+	ret := _m.Called(ctx, since)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ForceDeleteProduct")
+		panic("no return value specified for GetReorderCandidates")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
-		r0 = rf(ctx, id)
+	var r0 []repositories.ReorderCandidate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]repositories.ReorderCandidate, error)); ok {
+		return rf(ctx, since)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []repositories.ReorderCandidate); ok {
+		r0 = rf(ctx, since)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repositories.ReorderCandidate)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStockCounts provides a mock function with given fields: ctx
+func (_m *ProductRepository) GetStockCounts(ctx context.Context) (map[string]int64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStockCounts")
+	}
+
+	var r0 map[string]int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (map[string]int64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) map[string]int64); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]int64)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NextBarcodeSequence provides a mock function with given fields: ctx
+func (_m *ProductRepository) NextBarcodeSequence(ctx context.Context) (int64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NextBarcodeSequence")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // RestoreProduct provides a mock function with given fields: ctx, id
@@ -231,6 +363,24 @@ func (_m *ProductRepository) RestoreProduct(ctx context.Context, id uint) error
 	return r0
 }
 
+// UpdateProduct provides a mock function with given fields: ctx, product
+func (_m *ProductRepository) UpdateProduct(ctx context.Context, product *models.Product) error {
+	ret := _m.Called(ctx, product)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateProduct")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Product) error); ok {
+		r0 = rf(ctx, product)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewProductRepository creates a new instance of ProductRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewProductRepository(t interface {
@@ -0,0 +1,126 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	models "pos-api/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ProductSerialRepository is an autogenerated mock type for the ProductSerialRepository type
+type ProductSerialRepository struct {
+	mock.Mock
+}
+
+// FindBySerial provides a mock function with given fields: ctx, serial
+func (_m *ProductSerialRepository) FindBySerial(ctx context.Context, serial string) ([]models.ProductSerial, error) {
+	ret := _m.Called(ctx, serial)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindBySerial")
+	}
+
+	var r0 []models.ProductSerial
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]models.ProductSerial, error)); ok {
+		return rf(ctx, serial)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []models.ProductSerial); ok {
+		r0 = rf(ctx, serial)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ProductSerial)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, serial)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAll provides a mock function with given fields: ctx, limit, offset, productID, locationID, status, search
+func (_m *ProductSerialRepository) GetAll(ctx context.Context, limit int, offset int, productID uint, locationID uint, status string, search string) ([]models.ProductSerial, int64, error) {
+	ret := _m.Called(ctx, limit, offset, productID, locationID, status, search)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []models.ProductSerial
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, uint, uint, string, string) ([]models.ProductSerial, int64, error)); ok {
+		return rf(ctx, limit, offset, productID, locationID, status, search)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, uint, uint, string, string) []models.ProductSerial); ok {
+		r0 = rf(ctx, limit, offset, productID, locationID, status, search)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ProductSerial)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, uint, uint, string, string) int64); ok {
+		r1 = rf(ctx, limit, offset, productID, locationID, status, search)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int, int, uint, uint, string, string) error); ok {
+		r2 = rf(ctx, limit, offset, productID, locationID, status, search)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Register provides a mock function with given fields: ctx, productID, locationID, serials
+func (_m *ProductSerialRepository) Register(ctx context.Context, productID uint, locationID uint, serials []string) ([]models.ProductSerial, error) {
+	ret := _m.Called(ctx, productID, locationID, serials)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Register")
+	}
+
+	var r0 []models.ProductSerial
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, []string) ([]models.ProductSerial, error)); ok {
+		return rf(ctx, productID, locationID, serials)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, []string) []models.ProductSerial); ok {
+		r0 = rf(ctx, productID, locationID, serials)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ProductSerial)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint, []string) error); ok {
+		r1 = rf(ctx, productID, locationID, serials)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewProductSerialRepository creates a new instance of ProductSerialRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewProductSerialRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ProductSerialRepository {
+	mock := &ProductSerialRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
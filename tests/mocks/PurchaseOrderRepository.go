@@ -0,0 +1,185 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	models "pos-api/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PurchaseOrderRepository is an autogenerated mock type for the PurchaseOrderRepository type
+type PurchaseOrderRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, po
+func (_m *PurchaseOrderRepository) Create(ctx context.Context, po *models.PurchaseOrder) error {
+	ret := _m.Called(ctx, po)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.PurchaseOrder) error); ok {
+		r0 = rf(ctx, po)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *PurchaseOrderRepository) Delete(ctx context.Context, id uint) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields: ctx, limit, offset, status, supplierID
+func (_m *PurchaseOrderRepository) GetAll(ctx context.Context, limit int, offset int, status string, supplierID uint) ([]models.PurchaseOrder, int64, error) {
+	ret := _m.Called(ctx, limit, offset, status, supplierID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []models.PurchaseOrder
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, string, uint) ([]models.PurchaseOrder, int64, error)); ok {
+		return rf(ctx, limit, offset, status, supplierID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, string, uint) []models.PurchaseOrder); ok {
+		r0 = rf(ctx, limit, offset, status, supplierID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.PurchaseOrder)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, string, uint) int64); ok {
+		r1 = rf(ctx, limit, offset, status, supplierID)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int, int, string, uint) error); ok {
+		r2 = rf(ctx, limit, offset, status, supplierID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *PurchaseOrderRepository) GetByID(ctx context.Context, id uint) (*models.PurchaseOrder, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *models.PurchaseOrder
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (*models.PurchaseOrder, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) *models.PurchaseOrder); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PurchaseOrder)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, po
+func (_m *PurchaseOrderRepository) Update(ctx context.Context, po *models.PurchaseOrder) error {
+	ret := _m.Called(ctx, po)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.PurchaseOrder) error); ok {
+		r0 = rf(ctx, po)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateStatus provides a mock function with given fields: ctx, id, status, from
+func (_m *PurchaseOrderRepository) UpdateStatus(ctx context.Context, id uint, status string, from ...string) (bool, error) {
+	_va := make([]interface{}, len(from))
+	for _i := range from {
+		_va[_i] = from[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, id, status)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateStatus")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string, ...string) (bool, error)); ok {
+		return rf(ctx, id, status, from...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string, ...string) bool); ok {
+		r0 = rf(ctx, id, status, from...)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, string, ...string) error); ok {
+		r1 = rf(ctx, id, status, from...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewPurchaseOrderRepository creates a new instance of PurchaseOrderRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPurchaseOrderRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PurchaseOrderRepository {
+	mock := &PurchaseOrderRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
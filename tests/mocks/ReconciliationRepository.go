@@ -0,0 +1,349 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	models "pos-api/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// ReconciliationRepository is an autogenerated mock type for the ReconciliationRepository type
+type ReconciliationRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, statement
+func (_m *ReconciliationRepository) Create(ctx context.Context, statement *models.BankStatement) error {
+	ret := _m.Called(ctx, statement)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.BankStatement) error); ok {
+		r0 = rf(ctx, statement)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetLine provides a mock function with given fields: ctx, id
+func (_m *ReconciliationRepository) GetLine(ctx context.Context, id uint) (*models.BankStatementLine, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLine")
+	}
+
+	var r0 *models.BankStatementLine
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (*models.BankStatementLine, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) *models.BankStatementLine); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.BankStatementLine)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStatement provides a mock function with given fields: ctx, id
+func (_m *ReconciliationRepository) GetStatement(ctx context.Context, id uint) (*models.BankStatement, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStatement")
+	}
+
+	var r0 *models.BankStatement
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (*models.BankStatement, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) *models.BankStatement); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.BankStatement)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStatements provides a mock function with given fields: ctx, paymentMethodID
+func (_m *ReconciliationRepository) GetStatements(ctx context.Context, paymentMethodID *uint) ([]models.BankStatement, error) {
+	ret := _m.Called(ctx, paymentMethodID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStatements")
+	}
+
+	var r0 []models.BankStatement
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *uint) ([]models.BankStatement, error)); ok {
+		return rf(ctx, paymentMethodID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *uint) []models.BankStatement); ok {
+		r0 = rf(ctx, paymentMethodID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.BankStatement)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *uint) error); ok {
+		r1 = rf(ctx, paymentMethodID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUnmatchedLines provides a mock function with given fields: ctx, paymentMethodID, from, to
+func (_m *ReconciliationRepository) GetUnmatchedLines(ctx context.Context, paymentMethodID *uint, from time.Time, to time.Time) ([]models.BankStatementLine, error) {
+	ret := _m.Called(ctx, paymentMethodID, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUnmatchedLines")
+	}
+
+	var r0 []models.BankStatementLine
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *uint, time.Time, time.Time) ([]models.BankStatementLine, error)); ok {
+		return rf(ctx, paymentMethodID, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *uint, time.Time, time.Time) []models.BankStatementLine); ok {
+		r0 = rf(ctx, paymentMethodID, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.BankStatementLine)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *uint, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, paymentMethodID, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUnsettled provides a mock function with given fields: ctx, method, from, to
+func (_m *ReconciliationRepository) GetUnsettled(ctx context.Context, method string, from time.Time, to time.Time) ([]models.Transaction, error) {
+	ret := _m.Called(ctx, method, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUnsettled")
+	}
+
+	var r0 []models.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) ([]models.Transaction, error)); ok {
+		return rf(ctx, method, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) []models.Transaction); ok {
+		r0 = rf(ctx, method, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, method, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Ignore provides a mock function with given fields: ctx, lineID
+func (_m *ReconciliationRepository) Ignore(ctx context.Context, lineID uint) (*models.BankStatementLine, error) {
+	ret := _m.Called(ctx, lineID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Ignore")
+	}
+
+	var r0 *models.BankStatementLine
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (*models.BankStatementLine, error)); ok {
+		return rf(ctx, lineID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) *models.BankStatementLine); ok {
+		r0 = rf(ctx, lineID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.BankStatementLine)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, lineID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ImportedFingerprints provides a mock function with given fields: ctx, fingerprints
+func (_m *ReconciliationRepository) ImportedFingerprints(ctx context.Context, fingerprints []string) (map[string]bool, error) {
+	ret := _m.Called(ctx, fingerprints)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportedFingerprints")
+	}
+
+	var r0 map[string]bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) (map[string]bool, error)); ok {
+		return rf(ctx, fingerprints)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) map[string]bool); ok {
+		r0 = rf(ctx, fingerprints)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]bool)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, fingerprints)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LatestMapping provides a mock function with given fields: ctx, paymentMethodID
+func (_m *ReconciliationRepository) LatestMapping(ctx context.Context, paymentMethodID uint) (*models.StatementMapping, error) {
+	ret := _m.Called(ctx, paymentMethodID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LatestMapping")
+	}
+
+	var r0 *models.StatementMapping
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (*models.StatementMapping, error)); ok {
+		return rf(ctx, paymentMethodID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) *models.StatementMapping); ok {
+		r0 = rf(ctx, paymentMethodID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.StatementMapping)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, paymentMethodID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Match provides a mock function with given fields: ctx, lineID, transactionID
+func (_m *ReconciliationRepository) Match(ctx context.Context, lineID uint, transactionID uint) (*models.BankStatementLine, error) {
+	ret := _m.Called(ctx, lineID, transactionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Match")
+	}
+
+	var r0 *models.BankStatementLine
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) (*models.BankStatementLine, error)); ok {
+		return rf(ctx, lineID, transactionID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) *models.BankStatementLine); ok {
+		r0 = rf(ctx, lineID, transactionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.BankStatementLine)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, lineID, transactionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Unmatch provides a mock function with given fields: ctx, lineID
+func (_m *ReconciliationRepository) Unmatch(ctx context.Context, lineID uint) (*models.BankStatementLine, error) {
+	ret := _m.Called(ctx, lineID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Unmatch")
+	}
+
+	var r0 *models.BankStatementLine
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (*models.BankStatementLine, error)); ok {
+		return rf(ctx, lineID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) *models.BankStatementLine); ok {
+		r0 = rf(ctx, lineID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.BankStatementLine)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, lineID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewReconciliationRepository creates a new instance of ReconciliationRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewReconciliationRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ReconciliationRepository {
+	mock := &ReconciliationRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,205 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	models "pos-api/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// RecurringExpenseRepository is an autogenerated mock type for the RecurringExpenseRepository type
+type RecurringExpenseRepository struct {
+	mock.Mock
+}
+
+// Book provides a mock function with given fields: ctx, id, today
+func (_m *RecurringExpenseRepository) Book(ctx context.Context, id uint, today time.Time) ([]models.CashFlow, error) {
+	ret := _m.Called(ctx, id, today)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Book")
+	}
+
+	var r0 []models.CashFlow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, time.Time) ([]models.CashFlow, error)); ok {
+		return rf(ctx, id, today)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, time.Time) []models.CashFlow); ok {
+		r0 = rf(ctx, id, today)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.CashFlow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, time.Time) error); ok {
+		r1 = rf(ctx, id, today)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: ctx, expense
+func (_m *RecurringExpenseRepository) Create(ctx context.Context, expense *models.RecurringExpense) error {
+	ret := _m.Called(ctx, expense)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.RecurringExpense) error); ok {
+		r0 = rf(ctx, expense)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *RecurringExpenseRepository) Delete(ctx context.Context, id uint) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields: ctx, onlyActive
+func (_m *RecurringExpenseRepository) GetAll(ctx context.Context, onlyActive bool) ([]models.RecurringExpense, error) {
+	ret := _m.Called(ctx, onlyActive)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []models.RecurringExpense
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bool) ([]models.RecurringExpense, error)); ok {
+		return rf(ctx, onlyActive)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bool) []models.RecurringExpense); ok {
+		r0 = rf(ctx, onlyActive)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.RecurringExpense)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bool) error); ok {
+		r1 = rf(ctx, onlyActive)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *RecurringExpenseRepository) GetByID(ctx context.Context, id uint) (*models.RecurringExpense, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *models.RecurringExpense
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (*models.RecurringExpense, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) *models.RecurringExpense); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.RecurringExpense)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDue provides a mock function with given fields: ctx, today
+func (_m *RecurringExpenseRepository) GetDue(ctx context.Context, today time.Time) ([]models.RecurringExpense, error) {
+	ret := _m.Called(ctx, today)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDue")
+	}
+
+	var r0 []models.RecurringExpense
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]models.RecurringExpense, error)); ok {
+		return rf(ctx, today)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []models.RecurringExpense); ok {
+		r0 = rf(ctx, today)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.RecurringExpense)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, today)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, expense
+func (_m *RecurringExpenseRepository) Update(ctx context.Context, expense *models.RecurringExpense) error {
+	ret := _m.Called(ctx, expense)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.RecurringExpense) error); ok {
+		r0 = rf(ctx, expense)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewRecurringExpenseRepository creates a new instance of RecurringExpenseRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRecurringExpenseRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RecurringExpenseRepository {
+	mock := &RecurringExpenseRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
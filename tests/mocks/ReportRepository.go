@@ -16,6 +16,66 @@ type ReportRepository struct {
 	mock.Mock
 }
 
+// GetAccountBalances provides a mock function with given fields: ctx, startDate, endDate
+func (_m *ReportRepository) GetAccountBalances(ctx context.Context, startDate *time.Time, endDate time.Time) ([]repositories.AccountBalance, error) {
+	ret := _m.Called(ctx, startDate, endDate)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccountBalances")
+	}
+
+	var r0 []repositories.AccountBalance
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *time.Time, time.Time) ([]repositories.AccountBalance, error)); ok {
+		return rf(ctx, startDate, endDate)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *time.Time, time.Time) []repositories.AccountBalance); ok {
+		r0 = rf(ctx, startDate, endDate)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repositories.AccountBalance)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *time.Time, time.Time) error); ok {
+		r1 = rf(ctx, startDate, endDate)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDailyProductSales provides a mock function with given fields: ctx, startDate, endDate
+func (_m *ReportRepository) GetDailyProductSales(ctx context.Context, startDate time.Time, endDate time.Time) ([]repositories.DailyProductSales, error) {
+	ret := _m.Called(ctx, startDate, endDate)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDailyProductSales")
+	}
+
+	var r0 []repositories.DailyProductSales
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) ([]repositories.DailyProductSales, error)); ok {
+		return rf(ctx, startDate, endDate)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) []repositories.DailyProductSales); ok {
+		r0 = rf(ctx, startDate, endDate)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repositories.DailyProductSales)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, startDate, endDate)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetProductReport provides a mock function with given fields: ctx, startDate, endDate, limit
 func (_m *ReportRepository) GetProductReport(ctx context.Context, startDate time.Time, endDate time.Time, limit int) ([]repositories.ProductReport, error) {
 	ret := _m.Called(ctx, startDate, endDate, limit)
@@ -46,6 +106,36 @@ func (_m *ReportRepository) GetProductReport(ctx context.Context, startDate time
 	return r0, r1
 }
 
+// GetProductStockLevels provides a mock function with given fields: ctx
+func (_m *ReportRepository) GetProductStockLevels(ctx context.Context) ([]repositories.ProductStockLevel, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProductStockLevels")
+	}
+
+	var r0 []repositories.ProductStockLevel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]repositories.ProductStockLevel, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []repositories.ProductStockLevel); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repositories.ProductStockLevel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetSalesByHour provides a mock function with given fields: ctx, startDate, endDate
 func (_m *ReportRepository) GetSalesByHour(ctx context.Context, startDate time.Time, endDate time.Time) ([]repositories.HourlySales, error) {
 	ret := _m.Called(ctx, startDate, endDate)
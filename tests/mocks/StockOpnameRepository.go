@@ -0,0 +1,228 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	models "pos-api/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// StockOpnameRepository is an autogenerated mock type for the StockOpnameRepository type
+type StockOpnameRepository struct {
+	mock.Mock
+}
+
+// AddCount provides a mock function with given fields: ctx, count
+func (_m *StockOpnameRepository) AddCount(ctx context.Context, count *models.StockOpnameCount) error {
+	ret := _m.Called(ctx, count)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddCount")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.StockOpnameCount) error); ok {
+		r0 = rf(ctx, count)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Approve provides a mock function with given fields: ctx, id, userID
+func (_m *StockOpnameRepository) Approve(ctx context.Context, id uint, userID uint) ([]models.InventoryLog, error) {
+	ret := _m.Called(ctx, id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Approve")
+	}
+
+	var r0 []models.InventoryLog
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) ([]models.InventoryLog, error)); ok {
+		return rf(ctx, id, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) []models.InventoryLog); ok {
+		r0 = rf(ctx, id, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.InventoryLog)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, id, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: ctx, opname
+func (_m *StockOpnameRepository) Create(ctx context.Context, opname *models.StockOpname) error {
+	ret := _m.Called(ctx, opname)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.StockOpname) error); ok {
+		r0 = rf(ctx, opname)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteCount provides a mock function with given fields: ctx, opnameID, countID
+func (_m *StockOpnameRepository) DeleteCount(ctx context.Context, opnameID uint, countID uint) error {
+	ret := _m.Called(ctx, opnameID, countID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCount")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) error); ok {
+		r0 = rf(ctx, opnameID, countID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields: ctx, limit, offset, status
+func (_m *StockOpnameRepository) GetAll(ctx context.Context, limit int, offset int, status string) ([]models.StockOpname, int64, error) {
+	ret := _m.Called(ctx, limit, offset, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []models.StockOpname
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, string) ([]models.StockOpname, int64, error)); ok {
+		return rf(ctx, limit, offset, status)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, string) []models.StockOpname); ok {
+		r0 = rf(ctx, limit, offset, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.StockOpname)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, string) int64); ok {
+		r1 = rf(ctx, limit, offset, status)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int, int, string) error); ok {
+		r2 = rf(ctx, limit, offset, status)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *StockOpnameRepository) GetByID(ctx context.Context, id uint) (*models.StockOpname, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *models.StockOpname
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (*models.StockOpname, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) *models.StockOpname); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.StockOpname)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetItem provides a mock function with given fields: ctx, opnameID, productID
+func (_m *StockOpnameRepository) GetItem(ctx context.Context, opnameID uint, productID uint) (*models.StockOpnameItem, error) {
+	ret := _m.Called(ctx, opnameID, productID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetItem")
+	}
+
+	var r0 *models.StockOpnameItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) (*models.StockOpnameItem, error)); ok {
+		return rf(ctx, opnameID, productID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) *models.StockOpnameItem); ok {
+		r0 = rf(ctx, opnameID, productID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.StockOpnameItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, opnameID, productID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateStatus provides a mock function with given fields: ctx, id, status
+func (_m *StockOpnameRepository) UpdateStatus(ctx context.Context, id uint, status string) error {
+	ret := _m.Called(ctx, id, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string) error); ok {
+		r0 = rf(ctx, id, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewStockOpnameRepository creates a new instance of StockOpnameRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewStockOpnameRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *StockOpnameRepository {
+	mock := &StockOpnameRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
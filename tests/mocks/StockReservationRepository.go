@@ -0,0 +1,132 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	models "pos-api/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// StockReservationRepository is an autogenerated mock type for the StockReservationRepository type
+type StockReservationRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, reservation
+func (_m *StockReservationRepository) Create(ctx context.Context, reservation *models.StockReservation) error {
+	ret := _m.Called(ctx, reservation)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.StockReservation) error); ok {
+		r0 = rf(ctx, reservation)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields: ctx, limit, offset, locationID, status
+func (_m *StockReservationRepository) GetAll(ctx context.Context, limit int, offset int, locationID uint, status string) ([]models.StockReservation, int64, error) {
+	ret := _m.Called(ctx, limit, offset, locationID, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []models.StockReservation
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, uint, string) ([]models.StockReservation, int64, error)); ok {
+		return rf(ctx, limit, offset, locationID, status)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, uint, string) []models.StockReservation); ok {
+		r0 = rf(ctx, limit, offset, locationID, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.StockReservation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, uint, string) int64); ok {
+		r1 = rf(ctx, limit, offset, locationID, status)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int, int, uint, string) error); ok {
+		r2 = rf(ctx, limit, offset, locationID, status)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *StockReservationRepository) GetByID(ctx context.Context, id uint) (*models.StockReservation, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *models.StockReservation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (*models.StockReservation, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) *models.StockReservation); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.StockReservation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Release provides a mock function with given fields: ctx, id
+func (_m *StockReservationRepository) Release(ctx context.Context, id uint) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Release")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewStockReservationRepository creates a new instance of StockReservationRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewStockReservationRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *StockReservationRepository {
+	mock := &StockReservationRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,114 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	models "pos-api/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// StockTransferRepository is an autogenerated mock type for the StockTransferRepository type
+type StockTransferRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, transfer
+func (_m *StockTransferRepository) Create(ctx context.Context, transfer *models.StockTransfer) error {
+	ret := _m.Called(ctx, transfer)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.StockTransfer) error); ok {
+		r0 = rf(ctx, transfer)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields: ctx, limit, offset, locationID
+func (_m *StockTransferRepository) GetAll(ctx context.Context, limit int, offset int, locationID uint) ([]models.StockTransfer, int64, error) {
+	ret := _m.Called(ctx, limit, offset, locationID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []models.StockTransfer
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, uint) ([]models.StockTransfer, int64, error)); ok {
+		return rf(ctx, limit, offset, locationID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, uint) []models.StockTransfer); ok {
+		r0 = rf(ctx, limit, offset, locationID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.StockTransfer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, uint) int64); ok {
+		r1 = rf(ctx, limit, offset, locationID)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int, int, uint) error); ok {
+		r2 = rf(ctx, limit, offset, locationID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *StockTransferRepository) GetByID(ctx context.Context, id uint) (*models.StockTransfer, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *models.StockTransfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (*models.StockTransfer, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) *models.StockTransfer); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.StockTransfer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewStockTransferRepository creates a new instance of StockTransferRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewStockTransferRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *StockTransferRepository {
+	mock := &StockTransferRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -44,6 +44,34 @@ func (_m *StoreSettingRepository) GetSettings(ctx context.Context) (*models.Stor
 	return r0, r1
 }
 
+// HasActivity provides a mock function with given fields: ctx
+func (_m *StoreSettingRepository) HasActivity(ctx context.Context) (bool, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HasActivity")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (bool, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) bool); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpsertSettings provides a mock function with given fields: ctx, settings
 func (_m *StoreSettingRepository) UpsertSettings(ctx context.Context, settings *models.StoreSetting) (*models.StoreSetting, error) {
 	ret := _m.Called(ctx, settings)
@@ -0,0 +1,144 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	models "pos-api/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SupplierPayableRepository is an autogenerated mock type for the SupplierPayableRepository type
+type SupplierPayableRepository struct {
+	mock.Mock
+}
+
+// GetAll provides a mock function with given fields: ctx, limit, offset, supplierID, status
+func (_m *SupplierPayableRepository) GetAll(ctx context.Context, limit int, offset int, supplierID uint, status string) ([]models.SupplierPayable, int64, error) {
+	ret := _m.Called(ctx, limit, offset, supplierID, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []models.SupplierPayable
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, uint, string) ([]models.SupplierPayable, int64, error)); ok {
+		return rf(ctx, limit, offset, supplierID, status)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, uint, string) []models.SupplierPayable); ok {
+		r0 = rf(ctx, limit, offset, supplierID, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.SupplierPayable)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, uint, string) int64); ok {
+		r1 = rf(ctx, limit, offset, supplierID, status)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int, int, uint, string) error); ok {
+		r2 = rf(ctx, limit, offset, supplierID, status)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *SupplierPayableRepository) GetByID(ctx context.Context, id uint) (*models.SupplierPayable, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *models.SupplierPayable
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (*models.SupplierPayable, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) *models.SupplierPayable); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SupplierPayable)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOutstanding provides a mock function with given fields: ctx, supplierID
+func (_m *SupplierPayableRepository) GetOutstanding(ctx context.Context, supplierID uint) ([]models.SupplierPayable, error) {
+	ret := _m.Called(ctx, supplierID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOutstanding")
+	}
+
+	var r0 []models.SupplierPayable
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) ([]models.SupplierPayable, error)); ok {
+		return rf(ctx, supplierID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []models.SupplierPayable); ok {
+		r0 = rf(ctx, supplierID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.SupplierPayable)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, supplierID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecordPayment provides a mock function with given fields: ctx, payment, cashFlow
+func (_m *SupplierPayableRepository) RecordPayment(ctx context.Context, payment *models.SupplierPayment, cashFlow *models.CashFlow) error {
+	ret := _m.Called(ctx, payment, cashFlow)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordPayment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.SupplierPayment, *models.CashFlow) error); ok {
+		r0 = rf(ctx, payment, cashFlow)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewSupplierPayableRepository creates a new instance of SupplierPayableRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSupplierPayableRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SupplierPayableRepository {
+	mock := &SupplierPayableRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,150 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	models "pos-api/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SupplierRepository is an autogenerated mock type for the SupplierRepository type
+type SupplierRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, supplier
+func (_m *SupplierRepository) Create(ctx context.Context, supplier *models.Supplier) error {
+	ret := _m.Called(ctx, supplier)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Supplier) error); ok {
+		r0 = rf(ctx, supplier)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *SupplierRepository) Delete(ctx context.Context, id uint) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields: ctx, limit, offset, search, onlyActive
+func (_m *SupplierRepository) GetAll(ctx context.Context, limit int, offset int, search string, onlyActive bool) ([]models.Supplier, int64, error) {
+	ret := _m.Called(ctx, limit, offset, search, onlyActive)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []models.Supplier
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, string, bool) ([]models.Supplier, int64, error)); ok {
+		return rf(ctx, limit, offset, search, onlyActive)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, string, bool) []models.Supplier); ok {
+		r0 = rf(ctx, limit, offset, search, onlyActive)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Supplier)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, string, bool) int64); ok {
+		r1 = rf(ctx, limit, offset, search, onlyActive)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int, int, string, bool) error); ok {
+		r2 = rf(ctx, limit, offset, search, onlyActive)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *SupplierRepository) GetByID(ctx context.Context, id uint) (*models.Supplier, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *models.Supplier
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (*models.Supplier, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) *models.Supplier); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Supplier)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, supplier
+func (_m *SupplierRepository) Update(ctx context.Context, supplier *models.Supplier) error {
+	ret := _m.Called(ctx, supplier)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Supplier) error); ok {
+		r0 = rf(ctx, supplier)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewSupplierRepository creates a new instance of SupplierRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSupplierRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SupplierRepository {
+	mock := &SupplierRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
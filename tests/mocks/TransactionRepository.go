@@ -44,9 +44,9 @@ func (_m *TransactionRepository) GetTransactionByID(ctx context.Context, id uint
 	return r0, r1
 }
 
-// ListTransactions provides a mock function with given fields: ctx, page, limit
-func (_m *TransactionRepository) ListTransactions(ctx context.Context, page int, limit int) ([]models.Transaction, int64, error) {
-	ret := _m.Called(ctx, page, limit)
+// ListTransactions provides a mock function with given fields: ctx, page, limit, search, startDate, endDate
+func (_m *TransactionRepository) ListTransactions(ctx context.Context, page int, limit int, search string, startDate string, endDate string) ([]models.Transaction, int64, error) {
+	ret := _m.Called(ctx, page, limit, search, startDate, endDate)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ListTransactions")
@@ -55,25 +55,25 @@ func (_m *TransactionRepository) ListTransactions(ctx context.Context, page int,
 	var r0 []models.Transaction
 	var r1 int64
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, int, int) ([]models.Transaction, int64, error)); ok {
-		return rf(ctx, page, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, string, string, string) ([]models.Transaction, int64, error)); ok {
+		return rf(ctx, page, limit, search, startDate, endDate)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int, int) []models.Transaction); ok {
-		r0 = rf(ctx, page, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, string, string, string) []models.Transaction); ok {
+		r0 = rf(ctx, page, limit, search, startDate, endDate)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]models.Transaction)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int, int) int64); ok {
-		r1 = rf(ctx, page, limit)
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, string, string, string) int64); ok {
+		r1 = rf(ctx, page, limit, search, startDate, endDate)
 	} else {
 		r1 = ret.Get(1).(int64)
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, int, int) error); ok {
-		r2 = rf(ctx, page, limit)
+	if rf, ok := ret.Get(2).(func(context.Context, int, int, string, string, string) error); ok {
+		r2 = rf(ctx, page, limit, search, startDate, endDate)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -0,0 +1,92 @@
+package forecast_test
+
+import (
+	"testing"
+	"time"
+
+	"pos-api/internal/pkg/forecast"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// A Monday, so index i falls on weekday (i+1)%7
+var monday = time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+
+func TestFit_FlatSeriesProjectsFlat(t *testing.T) {
+	values := make([]float64, 28)
+	for i := range values {
+		values[i] = 10
+	}
+
+	points := forecast.Fit(monday, values).Project(7)
+
+	assert.Len(t, points, 7)
+	assert.Equal(t, monday.AddDate(0, 0, 28), points[0].Date)
+	for _, p := range points {
+		assert.InDelta(t, 10, p.Value, 0.01)
+	}
+}
+
+func TestFit_WeeklySeasonality(t *testing.T) {
+	// Saturdays sell three times a weekday
+	values := make([]float64, 56)
+	for i := range values {
+		values[i] = 10
+		if monday.AddDate(0, 0, i).Weekday() == time.Saturday {
+			values[i] = 30
+		}
+	}
+
+	points := forecast.Fit(monday, values).Project(7)
+
+	for _, p := range points {
+		if p.Date.Weekday() == time.Saturday {
+			assert.InDelta(t, 30, p.Value, 0.5)
+		} else {
+			assert.InDelta(t, 10, p.Value, 0.5)
+		}
+	}
+}
+
+func TestFit_ShortHistoryIgnoresSeasonality(t *testing.T) {
+	values := []float64{10, 10, 10, 10, 10, 40, 10}
+
+	model := forecast.Fit(monday, values)
+
+	for _, index := range model.Seasonal {
+		assert.Equal(t, float64(1), index)
+	}
+}
+
+func TestFit_EmptySeries(t *testing.T) {
+	points := forecast.Fit(monday, nil).Project(3)
+
+	assert.Len(t, points, 3)
+	assert.Equal(t, monday, points[0].Date)
+	for _, p := range points {
+		assert.Equal(t, float64(0), p.Value)
+	}
+}
+
+func TestProject_NeverNegative(t *testing.T) {
+	values := make([]float64, 28)
+	for i := range values {
+		values[i] = float64(28 - i)
+	}
+
+	for _, p := range forecast.Fit(monday, values).Project(60) {
+		assert.GreaterOrEqual(t, p.Value, float64(0))
+	}
+}
+
+func TestStockOut(t *testing.T) {
+	points := []forecast.Point{
+		{Date: monday, Value: 4},
+		{Date: monday.AddDate(0, 0, 1), Value: 4},
+		{Date: monday.AddDate(0, 0, 2), Value: 4},
+	}
+
+	assert.Equal(t, monday.AddDate(0, 0, 1), *forecast.StockOut(8, points))
+	assert.Equal(t, monday, *forecast.StockOut(0, points))
+	assert.Nil(t, forecast.StockOut(20, points))
+}
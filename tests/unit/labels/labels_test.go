@@ -0,0 +1,80 @@
+package labels_test
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+
+	"pos-api/internal/pkg/labels"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sampleLabels(n int) []labels.Label {
+	items := make([]labels.Label, n)
+	for i := range items {
+		items[i] = labels.Label{Name: "Nasi Goreng Spesial", Price: 25000, Code: "8991234567891"}
+	}
+	return items
+}
+
+func TestWritePDF_PageCount(t *testing.T) {
+	layout, ok := labels.GetLayout("a4_3x10")
+	require.True(t, ok)
+
+	var buf bytes.Buffer
+	err := labels.WritePDF(&buf, labels.Options{Layout: layout, Symbology: labels.SymbologyEAN13, DPI: 100}, sampleLabels(31))
+
+	assert.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(buf.Bytes(), []byte("%PDF-1.4")))
+	assert.Contains(t, buf.String(), "/Count 2")
+	assert.True(t, bytes.HasSuffix(buf.Bytes(), []byte("%%EOF\n")))
+}
+
+func TestWritePNG_ThermalStrip(t *testing.T) {
+	layout, ok := labels.GetLayout("thermal_33x15")
+	require.True(t, ok)
+
+	var buf bytes.Buffer
+	err := labels.WritePNG(&buf, labels.Options{Layout: layout, Symbology: labels.SymbologyQR}, sampleLabels(3))
+	require.NoError(t, err)
+
+	img, err := png.Decode(&buf)
+	require.NoError(t, err)
+
+	// 33 x 15 mm at 203 DPI, one label per page stacked vertically
+	assert.Equal(t, 263, img.Bounds().Dx())
+	assert.Equal(t, 3*119, img.Bounds().Dy())
+}
+
+func TestWritePDF_InvalidEAN(t *testing.T) {
+	layout, _ := labels.GetLayout("a4_3x10")
+
+	items := []labels.Label{{Name: "Legacy", Price: 1000, Code: "SKU-123"}}
+	err := labels.WritePDF(&bytes.Buffer{}, labels.Options{Layout: layout, Symbology: labels.SymbologyEAN13}, items)
+
+	assert.Error(t, err)
+}
+
+func TestWritePDF_NoLabels(t *testing.T) {
+	layout, _ := labels.GetLayout("a4_3x10")
+
+	err := labels.WritePDF(&bytes.Buffer{}, labels.Options{Layout: layout}, nil)
+
+	assert.Error(t, err)
+}
+
+func TestWritePDF_DPIOutOfRange(t *testing.T) {
+	layout, _ := labels.GetLayout("a4_3x10")
+
+	err := labels.WritePDF(&bytes.Buffer{}, labels.Options{Layout: layout, DPI: 10000}, sampleLabels(1))
+
+	assert.ErrorContains(t, err, "dpi must be between 72 and 600")
+}
+
+func TestFormatRupiah(t *testing.T) {
+	assert.Equal(t, "Rp 25.000", labels.FormatRupiah(25000))
+	assert.Equal(t, "Rp 1.250.000", labels.FormatRupiah(1250000))
+	assert.Equal(t, "Rp 500", labels.FormatRupiah(500))
+}
@@ -0,0 +1,77 @@
+package scale_test
+
+import (
+	"testing"
+
+	"pos-api/internal/pkg/scale"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func defaultParser(t *testing.T) *scale.Parser {
+	patterns, err := scale.ParsePatterns(scale.DefaultPatterns)
+	require.NoError(t, err)
+	return scale.NewParser(patterns)
+}
+
+func TestParse_WeightLabel(t *testing.T) {
+	result, ok := defaultParser(t).Parse("2800101012500")
+
+	require.True(t, ok)
+	assert.Equal(t, "101", result.PLU)
+	assert.Equal(t, scale.MeasureWeight, result.Measure)
+	assert.InDelta(t, 1.25, result.Value, 1e-9)
+}
+
+func TestParse_PriceLabel(t *testing.T) {
+	result, ok := defaultParser(t).Parse("2901020337504")
+
+	require.True(t, ok)
+	assert.Equal(t, "102", result.PLU)
+	assert.Equal(t, scale.MeasurePrice, result.Measure)
+	assert.Equal(t, 33750.0, result.Value)
+}
+
+func TestParse_InvalidCheckDigit(t *testing.T) {
+	_, ok := defaultParser(t).Parse("2800101012501")
+	assert.False(t, ok)
+}
+
+func TestParse_NoMatchingPrefix(t *testing.T) {
+	_, ok := defaultParser(t).Parse("8991234567891")
+	assert.False(t, ok)
+}
+
+func TestParse_ZeroPLU(t *testing.T) {
+	_, ok := defaultParser(t).Parse("2800000012502")
+	assert.False(t, ok)
+}
+
+func TestParsePatterns_IgnoredDigit(t *testing.T) {
+	// Scales that print a price check digit between the PLU and the price
+	patterns, err := scale.ParsePatterns("2PPPPPPXRRRRC")
+	require.NoError(t, err)
+	require.Len(t, patterns, 1)
+	assert.Equal(t, "2", patterns[0].Prefix())
+	assert.Equal(t, 0, patterns[0].Decimals)
+}
+
+func TestParsePatterns_Invalid(t *testing.T) {
+	cases := map[string]string{
+		"too short":      "28PPPPPWWWWC:3",
+		"no check digit": "28PPPPPWWWWWW:3",
+		"mixed measure":  "28PPPPPWWRRRC:0",
+		"split PLU":      "28PPWPPWWWWWC:3",
+		"no PLU":         "28WWWWWWWWWWC:3",
+		"bad decimals":   "28PPPPPWWWWWC:x",
+		"bad character":  "28PPPPPWWWWZC:3",
+	}
+
+	for name, spec := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := scale.ParsePatterns(spec)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package scheduler_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"pos-api/internal/pkg/scheduler"
+)
+
+func TestEvery_RunsRightAwayAndRepeats(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	runs := make(chan struct{}, 10)
+	scheduler.Every(ctx, "test", 10*time.Millisecond, func(ctx context.Context) error {
+		runs <- struct{}{}
+		return nil
+	})
+
+	for i := 0; i < 3; i++ {
+		select {
+		case <-runs:
+		case <-time.After(time.Second):
+			t.Fatalf("expected run %d", i+1)
+		}
+	}
+}
+
+func TestEvery_KeepsRunningAfterPanic(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var count atomic.Int32
+	done := make(chan struct{})
+	scheduler.Every(ctx, "test", 10*time.Millisecond, func(ctx context.Context) error {
+		if count.Add(1) == 1 {
+			panic("boom")
+		}
+		close(done)
+		cancel()
+		return nil
+	})
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected the job to run again after panicking")
+	}
+}
+
+func TestEvery_StopsWhenContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var count atomic.Int32
+	scheduler.Every(ctx, "test", 10*time.Millisecond, func(ctx context.Context) error {
+		count.Add(1)
+		return nil
+	})
+	time.Sleep(30 * time.Millisecond)
+	cancel()
+	time.Sleep(20 * time.Millisecond)
+
+	stopped := count.Load()
+	time.Sleep(50 * time.Millisecond)
+	if got := count.Load(); got != stopped {
+		t.Fatalf("expected no runs after cancel, got %d more", got-stopped)
+	}
+}
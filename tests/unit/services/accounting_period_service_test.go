@@ -0,0 +1,107 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"pos-api/internal/models"
+	"pos-api/internal/services"
+	"pos-api/tests/mocks"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func setupAccountingPeriodTest(t *testing.T) (*mocks.AccountingPeriodRepository, services.AccountingPeriodService) {
+	mockRepo := mocks.NewAccountingPeriodRepository(t)
+	return mockRepo, services.NewAccountingPeriodService(mockRepo)
+}
+
+var periodNow = time.Date(2026, 3, 10, 14, 0, 0, 0, time.UTC)
+
+// --- Close ---
+
+func TestAccountingPeriodService_Close_Success(t *testing.T) {
+	mockRepo, service := setupAccountingPeriodTest(t)
+	ctx := context.Background()
+
+	january := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockRepo.On("Close", ctx, january, uint(1), "Laporan Januari sudah dilaporkan").
+		Return(&models.AccountingPeriod{Period: january, IsClosed: true}, nil).Once()
+
+	period, err := service.Close(ctx, "2026-01", periodNow, 1, " Laporan Januari sudah dilaporkan ")
+
+	assert.NoError(t, err)
+	assert.True(t, period.IsClosed)
+}
+
+func TestAccountingPeriodService_Close_CurrentMonth(t *testing.T) {
+	_, service := setupAccountingPeriodTest(t)
+
+	_, err := service.Close(context.Background(), "2026-03", periodNow, 1, "")
+
+	assert.ErrorContains(t, err, "has not ended yet")
+}
+
+func TestAccountingPeriodService_Close_InvalidMonth(t *testing.T) {
+	_, service := setupAccountingPeriodTest(t)
+
+	_, err := service.Close(context.Background(), "2026-1-5", periodNow, 1, "")
+
+	assert.ErrorContains(t, err, "invalid month format")
+}
+
+func TestAccountingPeriodService_Close_AlreadyClosed(t *testing.T) {
+	mockRepo, service := setupAccountingPeriodTest(t)
+	ctx := context.Background()
+
+	february := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	mockRepo.On("Close", ctx, february, uint(1), "").Return(nil, customErrors.ErrConflict).Once()
+
+	_, err := service.Close(ctx, "2026-02", periodNow, 1, "")
+
+	assert.ErrorIs(t, err, customErrors.ErrConflict)
+}
+
+// --- Reopen ---
+
+func TestAccountingPeriodService_Reopen_RequiresReason(t *testing.T) {
+	_, service := setupAccountingPeriodTest(t)
+
+	_, err := service.Reopen(context.Background(), "2026-01", 1, "   ")
+
+	assert.ErrorContains(t, err, "reason is required")
+}
+
+func TestAccountingPeriodService_Reopen_Success(t *testing.T) {
+	mockRepo, service := setupAccountingPeriodTest(t)
+	ctx := context.Background()
+
+	january := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockRepo.On("Reopen", ctx, january, uint(1), "Faktur listrik terlewat").
+		Return(&models.AccountingPeriod{Period: january}, nil).Once()
+
+	period, err := service.Reopen(ctx, "2026-01", 1, "Faktur listrik terlewat")
+
+	assert.NoError(t, err)
+	assert.False(t, period.IsClosed)
+}
+
+// --- GetLogs ---
+
+func TestAccountingPeriodService_GetLogs_ForMonth(t *testing.T) {
+	mockRepo, service := setupAccountingPeriodTest(t)
+	ctx := context.Background()
+
+	january := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockRepo.On("GetLogs", ctx, &january).Return([]models.AccountingPeriodLog{
+		{Action: models.PeriodActionReopen}, {Action: models.PeriodActionClose},
+	}, nil).Once()
+
+	logs, err := service.GetLogs(ctx, "2026-01")
+
+	assert.NoError(t, err)
+	assert.Len(t, logs, 2)
+}
@@ -0,0 +1,109 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"pos-api/internal/models"
+	customErrors "pos-api/internal/pkg/errors"
+	"pos-api/internal/repositories"
+	"pos-api/internal/services"
+	"pos-api/tests/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func setupAccountingTest(t *testing.T) (*mocks.AccountingRepository, services.AccountingService) {
+	mockRepo := mocks.NewAccountingRepository(t)
+	return mockRepo, services.NewAccountingService(mockRepo)
+}
+
+var testAccounts = []models.Account{
+	{ID: 1, Code: models.AccountCodeCash, Name: "Kas", Type: models.AccountAsset},
+	{ID: 2, Code: models.AccountCodeInventory, Name: "Persediaan Barang", Type: models.AccountAsset},
+	{ID: 3, Code: models.AccountCodeSales, Name: "Penjualan", Type: models.AccountRevenue},
+	{ID: 4, Code: models.AccountCodeSalesDiscount, Name: "Diskon Penjualan", Type: models.AccountRevenue},
+	{ID: 5, Code: models.AccountCodeCOGS, Name: "Harga Pokok Penjualan", Type: models.AccountExpense},
+	{ID: 6, Code: models.AccountCodeOperating, Name: "Beban Operasional", Type: models.AccountExpense},
+}
+
+func TestAccountingService_GetTrialBalance_Balanced(t *testing.T) {
+	mockRepo, service := setupAccountingTest(t)
+	ctx := context.Background()
+
+	// A sale of 100,000 with a 5,000 discount costing 60,000, stock bought for
+	// 200,000 and a refunded sale of 20,000 posted with its reversal
+	mockRepo.On("GetAccounts", ctx).Return(testAccounts, nil).Once()
+	mockRepo.On("GetAccountTotals", ctx, mock.MatchedBy(func(asOf *time.Time) bool {
+		return asOf != nil && asOf.Hour() == 23 && asOf.Day() == 31
+	})).Return([]repositories.AccountTotal{
+		{AccountID: 1, Debit: 95000 + 20000, Credit: 200000 + 20000},
+		{AccountID: 2, Debit: 200000, Credit: 60000},
+		{AccountID: 3, Debit: 20000, Credit: 100000 + 20000},
+		{AccountID: 4, Debit: 5000},
+		{AccountID: 5, Debit: 60000},
+	}, nil).Once()
+
+	balance, err := service.GetTrialBalance(ctx, time.Date(2026, 3, 31, 8, 0, 0, 0, time.UTC))
+
+	assert.NoError(t, err)
+	assert.True(t, balance.Balanced)
+	assert.Equal(t, 205000.0, balance.TotalDebit)
+	assert.Equal(t, 205000.0, balance.TotalCredit)
+	// Accounts nothing was posted to are left out
+	assert.Len(t, balance.Lines, 5)
+
+	cash := balance.Lines[0]
+	assert.Equal(t, models.AccountCodeCash, cash.Code)
+	assert.Equal(t, 0.0, cash.Debit)
+	assert.Equal(t, 105000.0, cash.Credit)
+	assert.Equal(t, 140000.0, balance.Lines[1].Debit)
+	assert.Equal(t, 100000.0, balance.Lines[2].Credit)
+}
+
+func TestAccountingService_GetTrialBalance_Unbalanced(t *testing.T) {
+	mockRepo, service := setupAccountingTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("GetAccounts", ctx).Return(testAccounts, nil).Once()
+	mockRepo.On("GetAccountTotals", ctx, mock.Anything).Return([]repositories.AccountTotal{
+		{AccountID: 1, Debit: 50000},
+		{AccountID: 6, Credit: 49999.99},
+	}, nil).Once()
+
+	balance, err := service.GetTrialBalance(ctx, time.Now())
+
+	assert.NoError(t, err)
+	assert.False(t, balance.Balanced)
+	assert.Equal(t, 50000.0, balance.TotalDebit)
+	assert.Equal(t, 49999.99, balance.TotalCredit)
+}
+
+func TestAccountingService_GetJournalEntry_NotFound(t *testing.T) {
+	mockRepo, service := setupAccountingTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("GetEntryByID", ctx, uint(9)).Return(nil, gorm.ErrRecordNotFound).Once()
+
+	entry, err := service.GetJournalEntry(ctx, 9)
+
+	assert.Nil(t, entry)
+	assert.ErrorIs(t, err, customErrors.ErrNotFound)
+}
+
+func TestAccountingService_GetJournal_DefaultsPaging(t *testing.T) {
+	mockRepo, service := setupAccountingTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("GetEntries", ctx, 20, 0, models.JournalSale, uint(0), (*time.Time)(nil), (*time.Time)(nil)).
+		Return([]models.JournalEntry{{ID: 1, Source: models.JournalSale}}, int64(1), nil).Once()
+
+	entries, total, err := service.GetJournal(ctx, 0, 0, models.JournalSale, 0, nil, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Len(t, entries, 1)
+}
@@ -0,0 +1,127 @@
+package services_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"pos-api/internal/models"
+	"pos-api/internal/pkg/storage"
+	"pos-api/internal/services"
+	"pos-api/tests/mocks"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// pngHeader is enough of a PNG file for its type to be detected
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func setupAttachmentTest(t *testing.T) (*mocks.AttachmentRepository, *storage.LocalStorage, services.AttachmentService) {
+	mockRepo := mocks.NewAttachmentRepository(t)
+	files, err := storage.NewLocalStorage(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return mockRepo, files, services.NewAttachmentService(mockRepo, files, 1024)
+}
+
+func uploadedFile(name string, content []byte) services.UploadedFile {
+	return services.UploadedFile{Name: name, Size: int64(len(content)), Content: bytes.NewReader(content)}
+}
+
+// --- Upload ---
+
+func TestAttachmentService_Upload_Success(t *testing.T) {
+	mockRepo, files, service := setupAttachmentTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("OwnerExists", ctx, models.AttachmentOwnerCashFlow, uint(5)).Return(true, nil).Once()
+	mockRepo.On("Create", ctx, mock.AnythingOfType("*models.Attachment")).Return(nil).Once()
+
+	attachment, err := service.Upload(ctx, models.AttachmentOwnerCashFlow, 5, uploadedFile(`C:\photos\struk listrik.png`, pngHeader), 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "struk listrik.png", attachment.FileName)
+	assert.Equal(t, "image/png", attachment.ContentType)
+	assert.Equal(t, uint(5), *attachment.CashFlowID)
+	assert.Nil(t, attachment.InventoryLogID)
+	assert.True(t, strings.HasPrefix(attachment.StorageKey, "cash_flow/5/"))
+
+	stored, err := files.Open(ctx, attachment.StorageKey)
+	if assert.NoError(t, err) {
+		defer stored.Close()
+		content, _ := io.ReadAll(stored)
+		assert.Equal(t, pngHeader, content)
+	}
+}
+
+func TestAttachmentService_Upload_RejectsType(t *testing.T) {
+	mockRepo, _, service := setupAttachmentTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("OwnerExists", ctx, models.AttachmentOwnerInventoryLog, uint(3)).Return(true, nil).Once()
+
+	// A script renamed to look like an image
+	_, err := service.Upload(ctx, models.AttachmentOwnerInventoryLog, 3, uploadedFile("receipt.jpg", []byte("<script>alert(1)</script>")), 1)
+
+	assert.ErrorContains(t, err, "is not allowed")
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestAttachmentService_Upload_TooLarge(t *testing.T) {
+	_, _, service := setupAttachmentTest(t)
+
+	_, err := service.Upload(context.Background(), models.AttachmentOwnerCashFlow, 5, uploadedFile("scan.pdf", make([]byte, 2048)), 1)
+
+	assert.ErrorContains(t, err, "larger than")
+}
+
+func TestAttachmentService_Upload_OwnerNotFound(t *testing.T) {
+	mockRepo, _, service := setupAttachmentTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("OwnerExists", ctx, models.AttachmentOwnerCashFlow, uint(99)).Return(false, nil).Once()
+
+	_, err := service.Upload(ctx, models.AttachmentOwnerCashFlow, 99, uploadedFile("a.png", pngHeader), 1)
+
+	assert.ErrorIs(t, err, customErrors.ErrNotFound)
+}
+
+// --- Open / Delete ---
+
+func TestAttachmentService_Open_OtherRecord(t *testing.T) {
+	mockRepo, _, service := setupAttachmentTest(t)
+	ctx := context.Background()
+
+	cashFlowID := uint(5)
+	mockRepo.On("GetByID", ctx, uint(1)).Return(&models.Attachment{ID: 1, CashFlowID: &cashFlowID}, nil).Once()
+
+	// Same ID, but a stock movement instead of the cash flow entry
+	_, _, err := service.Open(ctx, models.AttachmentOwnerInventoryLog, 5, 1)
+
+	assert.ErrorIs(t, err, customErrors.ErrNotFound)
+}
+
+func TestAttachmentService_Delete_RemovesFile(t *testing.T) {
+	mockRepo, files, service := setupAttachmentTest(t)
+	ctx := context.Background()
+
+	key := "inventory_log/3/a.png"
+	if err := files.Put(ctx, key, bytes.NewReader(pngHeader), int64(len(pngHeader)), "image/png"); err != nil {
+		t.Fatal(err)
+	}
+	logID := uint(3)
+	mockRepo.On("GetByID", ctx, uint(1)).Return(&models.Attachment{ID: 1, InventoryLogID: &logID, StorageKey: key}, nil).Once()
+	mockRepo.On("Delete", ctx, uint(1)).Return(nil).Once()
+
+	err := service.Delete(ctx, models.AttachmentOwnerInventoryLog, 3, 1)
+
+	assert.NoError(t, err)
+	_, err = files.Open(ctx, key)
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+}
@@ -0,0 +1,105 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"pos-api/internal/pkg/gtin"
+	"pos-api/internal/services"
+	"pos-api/tests/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBarcodeAllocator_Allocate_ValidEAN13InPrefixRange(t *testing.T) {
+	mockRepo := mocks.NewProductRepository(t)
+	allocator := services.NewBarcodeAllocator(mockRepo, 20, 27)
+	ctx := context.Background()
+
+	mockRepo.On("NextBarcodeSequence", ctx).Return(int64(42), nil).Once()
+	mockRepo.On("BarcodeExists", ctx, "2000000000428").Return(false, nil).Once()
+
+	code, err := allocator.Allocate(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "2000000000428", code)
+	assert.True(t, gtin.ValidEAN13(code))
+}
+
+func TestBarcodeAllocator_Allocate_RollsIntoNextPrefix(t *testing.T) {
+	mockRepo := mocks.NewProductRepository(t)
+	allocator := services.NewBarcodeAllocator(mockRepo, 20, 27)
+	ctx := context.Background()
+
+	mockRepo.On("NextBarcodeSequence", ctx).Return(int64(10_000_000_001), nil).Once()
+	mockRepo.On("BarcodeExists", ctx, "2100000000012").Return(false, nil).Once()
+
+	code, err := allocator.Allocate(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "21", code[:2])
+}
+
+func TestBarcodeAllocator_Allocate_SkipsTakenCodes(t *testing.T) {
+	mockRepo := mocks.NewProductRepository(t)
+	allocator := services.NewBarcodeAllocator(mockRepo, 20, 27)
+	ctx := context.Background()
+
+	mockRepo.On("NextBarcodeSequence", ctx).Return(int64(1), nil).Once()
+	mockRepo.On("BarcodeExists", ctx, "2000000000015").Return(true, nil).Once()
+	mockRepo.On("NextBarcodeSequence", ctx).Return(int64(2), nil).Once()
+	mockRepo.On("BarcodeExists", ctx, "2000000000022").Return(false, nil).Once()
+
+	code, err := allocator.Allocate(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "2000000000022", code)
+}
+
+func TestBarcodeAllocator_Allocate_RangeExhausted(t *testing.T) {
+	mockRepo := mocks.NewProductRepository(t)
+	allocator := services.NewBarcodeAllocator(mockRepo, 20, 20)
+	ctx := context.Background()
+
+	mockRepo.On("NextBarcodeSequence", ctx).Return(int64(10_000_000_000), nil).Once()
+
+	code, err := allocator.Allocate(ctx)
+
+	assert.Error(t, err)
+	assert.Empty(t, code)
+	assert.Contains(t, err.Error(), "exhausted")
+}
+
+func TestBarcodeAllocator_Allocate_RangeOverlapsScaleLabels(t *testing.T) {
+	mockRepo := mocks.NewProductRepository(t)
+	allocator := services.NewBarcodeAllocator(mockRepo, 20, 29)
+
+	code, err := allocator.Allocate(context.Background())
+
+	assert.Error(t, err)
+	assert.Empty(t, code)
+	assert.Contains(t, err.Error(), "scale labels")
+	mockRepo.AssertNotCalled(t, "NextBarcodeSequence", mock.Anything)
+}
+
+func TestBarcodeAllocator_Allocate_SequenceError(t *testing.T) {
+	mockRepo := mocks.NewProductRepository(t)
+	allocator := services.NewBarcodeAllocator(mockRepo, 20, 27)
+	ctx := context.Background()
+
+	mockRepo.On("NextBarcodeSequence", ctx).Return(int64(0), errors.New("db down")).Once()
+
+	_, err := allocator.Allocate(ctx)
+
+	assert.Error(t, err)
+}
+
+func TestGTIN_CheckDigit(t *testing.T) {
+	assert.True(t, gtin.Valid("8991234567891"))   // EAN-13
+	assert.True(t, gtin.Valid("036000291452"))    // UPC-A
+	assert.True(t, gtin.Valid("96385074"))        // EAN-8
+	assert.False(t, gtin.Valid("8991234567893"))  // Wrong check digit
+	assert.False(t, gtin.Valid("81739999999999")) // Legacy 8<unix micro> style
+}
@@ -0,0 +1,123 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"pos-api/internal/models"
+	"pos-api/internal/pkg/scale"
+	"pos-api/internal/services"
+	"pos-api/tests/mocks"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func setupScannerTest(t *testing.T) (*mocks.ProductRepository, services.BarcodeScanner) {
+	mockRepo := mocks.NewProductRepository(t)
+	patterns, err := scale.ParsePatterns(scale.DefaultPatterns)
+	require.NoError(t, err)
+	return mockRepo, services.NewBarcodeScanner(mockRepo, scale.NewParser(patterns))
+}
+
+func TestBarcodeScanner_Scan_ExactBarcode(t *testing.T) {
+	mockRepo, scanner := setupScannerTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("GetProductByBarcode", ctx, "8991234567891").Return(&models.Product{ID: 1, Price: 5000}, nil).Once()
+
+	result, err := scanner.Scan(ctx, "8991234567891")
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), result.Product.ID)
+	assert.Equal(t, 1.0, result.Quantity)
+	assert.Equal(t, 5000.0, result.SubTotal)
+	assert.Nil(t, result.Scale)
+}
+
+func TestBarcodeScanner_Scan_WeightLabel(t *testing.T) {
+	mockRepo, scanner := setupScannerTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("GetProductByBarcode", ctx, "2800101012500").Return(nil, gorm.ErrRecordNotFound).Once()
+	mockRepo.On("GetProductByPLU", ctx, "101").Return(&models.Product{ID: 7, Name: "Daging Sapi", Price: 150000, SoldByWeight: true}, nil).Once()
+
+	result, err := scanner.Scan(ctx, "2800101012500")
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(7), result.Product.ID)
+	assert.Equal(t, 1.25, result.Quantity)
+	assert.Equal(t, 187500.0, result.SubTotal)
+	assert.NotNil(t, result.Scale)
+}
+
+func TestBarcodeScanner_Scan_PriceLabel(t *testing.T) {
+	mockRepo, scanner := setupScannerTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("GetProductByBarcode", ctx, "2901020337504").Return(nil, gorm.ErrRecordNotFound).Once()
+	mockRepo.On("GetProductByPLU", ctx, "102").Return(&models.Product{ID: 8, Name: "Apel Fuji", Price: 45000, SoldByWeight: true}, nil).Once()
+
+	result, err := scanner.Scan(ctx, "2901020337504")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0.75, result.Quantity)
+	assert.Equal(t, 45000.0, result.UnitPrice)
+	assert.Equal(t, 33750.0, result.SubTotal)
+}
+
+func TestBarcodeScanner_Scan_PriceLabelRoundedWeight(t *testing.T) {
+	mockRepo, scanner := setupScannerTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("GetProductByBarcode", ctx, "2901020100009").Return(nil, gorm.ErrRecordNotFound).Once()
+	mockRepo.On("GetProductByPLU", ctx, "102").Return(&models.Product{ID: 8, Name: "Apel Fuji", Price: 45000, SoldByWeight: true}, nil).Once()
+
+	result, err := scanner.Scan(ctx, "2901020100009")
+
+	assert.NoError(t, err)
+	// 10000 / 45000 = 0.2222 kg, rounded to grams; the label price still wins
+	assert.Equal(t, 0.222, result.Quantity)
+	assert.Equal(t, 10000.0, result.SubTotal)
+	assert.Equal(t, 45045.05, result.UnitPrice)
+}
+
+func TestBarcodeScanner_Scan_ProductNotSoldByWeight(t *testing.T) {
+	mockRepo, scanner := setupScannerTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("GetProductByBarcode", ctx, "2800101012500").Return(nil, gorm.ErrRecordNotFound).Once()
+	mockRepo.On("GetProductByPLU", ctx, "101").Return(&models.Product{ID: 7, Name: "Beras 5kg", Price: 70000}, nil).Once()
+
+	result, err := scanner.Scan(ctx, "2800101012500")
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "tidak dijual per berat")
+}
+
+func TestBarcodeScanner_Scan_UnknownPLU(t *testing.T) {
+	mockRepo, scanner := setupScannerTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("GetProductByBarcode", ctx, "2800101012500").Return(nil, gorm.ErrRecordNotFound).Once()
+	mockRepo.On("GetProductByPLU", ctx, "101").Return(nil, gorm.ErrRecordNotFound).Once()
+
+	_, err := scanner.Scan(ctx, "2800101012500")
+
+	assert.ErrorIs(t, err, customErrors.ErrNotFound)
+}
+
+func TestBarcodeScanner_Scan_UnknownBarcode(t *testing.T) {
+	mockRepo, scanner := setupScannerTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("GetProductByBarcode", ctx, "8991234567891").Return(nil, gorm.ErrRecordNotFound).Once()
+
+	_, err := scanner.Scan(ctx, "8991234567891")
+
+	assert.ErrorIs(t, err, customErrors.ErrNotFound)
+}
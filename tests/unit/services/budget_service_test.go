@@ -0,0 +1,215 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"pos-api/internal/models"
+	"pos-api/internal/pkg/events"
+	"pos-api/internal/repositories"
+	"pos-api/internal/services"
+	"pos-api/tests/mocks"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type budgetTest struct {
+	repo         *mocks.BudgetRepository
+	categoryRepo *mocks.CashFlowCategoryRepository
+	cashFlowRepo *mocks.CashFlowRepository
+	alerts       []events.BudgetThresholdPayload
+	service      services.BudgetService
+}
+
+func setupBudgetTest(t *testing.T) *budgetTest {
+	bt := &budgetTest{
+		repo:         mocks.NewBudgetRepository(t),
+		categoryRepo: mocks.NewCashFlowCategoryRepository(t),
+		cashFlowRepo: mocks.NewCashFlowRepository(t),
+	}
+	bus := events.NewMemoryEventBus()
+	bus.Subscribe(events.EventBudgetThresholdCrossed, func(ctx context.Context, payload interface{}) error {
+		bt.alerts = append(bt.alerts, payload.(events.BudgetThresholdPayload))
+		return nil
+	})
+	bt.service = services.NewBudgetService(bt.repo, bt.categoryRepo, bt.cashFlowRepo, bus, 80)
+	return bt
+}
+
+var marketingCategory = &models.CashFlowCategory{Code: "pemasaran", Name: "Pemasaran", Type: models.CashFlowCategoryExpense, IsActive: true}
+
+// --- Create ---
+
+func TestBudgetService_Create_Success(t *testing.T) {
+	bt := setupBudgetTest(t)
+	ctx := context.Background()
+
+	bt.categoryRepo.On("GetByCode", ctx, "pemasaran").Return(marketingCategory, nil).Once()
+	bt.repo.On("GetBySourceMonth", ctx, "pemasaran", date(2026, 3, 1)).Return(nil, gorm.ErrRecordNotFound).Once()
+	bt.repo.On("Create", ctx, mock.AnythingOfType("*models.Budget")).Return(nil).Once()
+
+	budget, err := bt.service.Create(ctx, services.BudgetRequest{Source: "pemasaran", Month: "2026-03", Amount: 2000000}, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, date(2026, 3, 1), budget.Month)
+	assert.Equal(t, 80.0, budget.AlertPercent)
+	assert.Equal(t, uint(1), budget.UserID)
+}
+
+func TestBudgetService_Create_NotExpenseCategory(t *testing.T) {
+	bt := setupBudgetTest(t)
+	ctx := context.Background()
+
+	bt.categoryRepo.On("GetByCode", ctx, "penjualan").Return(&models.CashFlowCategory{
+		Code: "penjualan", Type: models.CashFlowCategoryIncome, IsActive: true,
+	}, nil).Once()
+
+	_, err := bt.service.Create(ctx, services.BudgetRequest{Source: "penjualan", Month: "2026-03", Amount: 1000}, 1)
+
+	assert.ErrorContains(t, err, "not an active expense category")
+	bt.repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestBudgetService_Create_InvalidMonth(t *testing.T) {
+	bt := setupBudgetTest(t)
+
+	_, err := bt.service.Create(context.Background(), services.BudgetRequest{Source: "pemasaran", Month: "Maret", Amount: 1000}, 1)
+
+	assert.ErrorContains(t, err, "invalid month format")
+}
+
+func TestBudgetService_Create_Duplicate(t *testing.T) {
+	bt := setupBudgetTest(t)
+	ctx := context.Background()
+
+	bt.categoryRepo.On("GetByCode", ctx, "pemasaran").Return(marketingCategory, nil).Once()
+	bt.repo.On("GetBySourceMonth", ctx, "pemasaran", date(2026, 3, 1)).Return(&models.Budget{ID: 4}, nil).Once()
+
+	_, err := bt.service.Create(ctx, services.BudgetRequest{Source: "pemasaran", Month: "2026-03", Amount: 1000}, 1)
+
+	assert.True(t, customErrors.Is(err, customErrors.ErrConflict))
+}
+
+// --- Update ---
+
+func TestBudgetService_Update_ClearsAlertOnAmountChange(t *testing.T) {
+	bt := setupBudgetTest(t)
+	ctx := context.Background()
+	alertedAt := time.Now()
+
+	bt.repo.On("GetByID", ctx, uint(4)).Return(&models.Budget{
+		ID: 4, Source: "pemasaran", Month: date(2026, 3, 1), Amount: 1000, AlertPercent: 80, AlertedAt: &alertedAt,
+	}, nil).Once()
+	bt.categoryRepo.On("GetByCode", ctx, "pemasaran").Return(marketingCategory, nil).Once()
+	bt.repo.On("GetBySourceMonth", ctx, "pemasaran", date(2026, 3, 1)).Return(&models.Budget{ID: 4}, nil).Once()
+	bt.repo.On("Update", ctx, mock.MatchedBy(func(b *models.Budget) bool {
+		return b.Amount == 1500 && b.AlertedAt == nil
+	})).Return(nil).Once()
+
+	_, err := bt.service.Update(ctx, 4, services.BudgetRequest{Source: "pemasaran", Month: "2026-03", Amount: 1500, AlertPercent: 80})
+
+	assert.NoError(t, err)
+}
+
+// --- GetReport ---
+
+func TestBudgetService_GetReport(t *testing.T) {
+	bt := setupBudgetTest(t)
+	ctx := context.Background()
+	now := time.Date(2026, 3, 20, 10, 0, 0, 0, time.UTC)
+
+	bt.repo.On("GetByMonth", ctx, date(2026, 3, 1)).Return([]models.Budget{
+		{ID: 1, Source: "listrik", Amount: 1000000, AlertPercent: 80, Category: &models.CashFlowCategory{Name: "Listrik"}},
+		{ID: 2, Source: "pemasaran", Amount: 2000000, AlertPercent: 80, Category: marketingCategory},
+		{ID: 3, Source: "sewa", Amount: 5000000, AlertPercent: 90},
+	}, nil).Once()
+	bt.cashFlowRepo.On("GetSourceBreakdown", ctx, date(2026, 3, 1), date(2026, 4, 1).Add(-time.Microsecond)).Return([]repositories.CashFlowSourceData{
+		{Source: "listrik", Type: "expense", CategoryType: models.CashFlowCategoryExpense, TotalAmount: 850000},
+		{Source: "pemasaran", Type: "expense", CategoryType: models.CashFlowCategoryExpense, TotalAmount: 2500000},
+		{Source: "gaji_karyawan", Type: "expense", CategoryType: models.CashFlowCategoryExpense, TotalAmount: 3000000},
+		{Source: "penjualan", Type: "income", CategoryType: models.CashFlowCategoryIncome, TotalAmount: 9000000},
+		{Source: "prive", Type: "expense", CategoryType: models.CashFlowCategoryCapital, TotalAmount: 400000},
+	}, nil).Once()
+
+	report, err := bt.service.GetReport(ctx, "", now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "2026-03", report.Month)
+	assert.Len(t, report.Lines, 3)
+
+	assert.Equal(t, "Listrik", report.Lines[0].Name)
+	assert.Equal(t, 150000.0, report.Lines[0].Variance)
+	assert.Equal(t, 85.0, report.Lines[0].PercentUsed)
+	assert.Equal(t, services.BudgetAlert, report.Lines[0].Status)
+
+	assert.Equal(t, -500000.0, report.Lines[1].Variance)
+	assert.Equal(t, 125.0, report.Lines[1].PercentUsed)
+	assert.Equal(t, services.BudgetOver, report.Lines[1].Status)
+
+	assert.Equal(t, 0.0, report.Lines[2].Actual)
+	assert.Equal(t, services.BudgetOK, report.Lines[2].Status)
+
+	assert.Equal(t, 8000000.0, report.TotalBudget)
+	assert.Equal(t, 3350000.0, report.TotalActual)
+	assert.Equal(t, 3000000.0, report.UnbudgetedActual)
+
+	over := services.OverBudget(report, 5)
+	assert.Len(t, over, 1)
+	assert.Equal(t, "pemasaran", over[0].Source)
+}
+
+// --- CheckAlerts ---
+
+func TestBudgetService_CheckAlerts(t *testing.T) {
+	bt := setupBudgetTest(t)
+	ctx := context.Background()
+	now := time.Date(2026, 3, 20, 10, 0, 0, 0, time.UTC)
+	earlier := now.Add(-time.Hour)
+
+	bt.repo.On("GetByMonth", ctx, date(2026, 3, 1)).Return([]models.Budget{
+		{ID: 1, Source: "listrik", Amount: 1000000, AlertPercent: 80},                        // Newly crossed
+		{ID: 2, Source: "pemasaran", Amount: 2000000, AlertPercent: 80, AlertedAt: &earlier}, // Already alerted
+		{ID: 3, Source: "sewa", Amount: 5000000, AlertPercent: 90, AlertedAt: &earlier},      // Back below
+	}, nil).Once()
+	bt.cashFlowRepo.On("GetSourceBreakdown", ctx, mock.Anything, mock.Anything).Return([]repositories.CashFlowSourceData{
+		{Source: "listrik", Type: "expense", CategoryType: models.CashFlowCategoryExpense, TotalAmount: 900000},
+		{Source: "pemasaran", Type: "expense", CategoryType: models.CashFlowCategoryExpense, TotalAmount: 2500000},
+		{Source: "sewa", Type: "expense", CategoryType: models.CashFlowCategoryExpense, TotalAmount: 1000000},
+	}, nil).Once()
+	bt.repo.On("SetAlerted", ctx, uint(1), &now).Return(true, nil).Once()
+	bt.repo.On("SetAlerted", ctx, uint(3), (*time.Time)(nil)).Return(true, nil).Once()
+
+	raised, err := bt.service.CheckAlerts(ctx, now)
+
+	assert.NoError(t, err)
+	assert.Len(t, raised, 1)
+	assert.Equal(t, "listrik", raised[0].Source)
+	assert.Len(t, bt.alerts, 1)
+	assert.Equal(t, uint(1), bt.alerts[0].Budget.ID)
+	assert.Equal(t, 90.0, bt.alerts[0].PercentUsed)
+}
+
+func TestBudgetService_CheckAlerts_AlreadyRaisedConcurrently(t *testing.T) {
+	bt := setupBudgetTest(t)
+	ctx := context.Background()
+	now := time.Date(2026, 3, 20, 10, 0, 0, 0, time.UTC)
+
+	bt.repo.On("GetByMonth", ctx, date(2026, 3, 1)).Return([]models.Budget{
+		{ID: 1, Source: "listrik", Amount: 1000000, AlertPercent: 80},
+	}, nil).Once()
+	bt.cashFlowRepo.On("GetSourceBreakdown", ctx, mock.Anything, mock.Anything).Return([]repositories.CashFlowSourceData{
+		{Source: "listrik", Type: "expense", CategoryType: models.CashFlowCategoryExpense, TotalAmount: 1200000},
+	}, nil).Once()
+	bt.repo.On("SetAlerted", ctx, uint(1), &now).Return(false, nil).Once()
+
+	raised, err := bt.service.CheckAlerts(ctx, now)
+
+	assert.NoError(t, err)
+	assert.Empty(t, raised)
+	assert.Empty(t, bt.alerts)
+}
@@ -3,29 +3,52 @@ package services_test
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
 	"pos-api/internal/models"
+	"pos-api/internal/pkg/storage"
 	"pos-api/internal/services"
 	"pos-api/tests/mocks"
 
+	customErrors "pos-api/internal/pkg/errors"
+
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
 )
 
 func setupCashFlowTest(t *testing.T) (*mocks.CashFlowRepository, services.CashFlowService) {
-	mockRepo := mocks.NewCashFlowRepository(t)
-	service := services.NewCashFlowService(mockRepo)
+	mockRepo, _, service := setupCashFlowCategoryTest(t)
 	return mockRepo, service
 }
 
+func setupCashFlowCategoryTest(t *testing.T) (*mocks.CashFlowRepository, *mocks.CashFlowCategoryRepository, services.CashFlowService) {
+	mockRepo, mockCategoryRepo, _, service := setupCashFlowFilesTest(t)
+	return mockRepo, mockCategoryRepo, service
+}
+
+func setupCashFlowFilesTest(t *testing.T) (*mocks.CashFlowRepository, *mocks.CashFlowCategoryRepository, *storage.LocalStorage, services.CashFlowService) {
+	mockRepo := mocks.NewCashFlowRepository(t)
+	mockCategoryRepo := mocks.NewCashFlowCategoryRepository(t)
+	files, err := storage.NewLocalStorage(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	service := services.NewCashFlowService(mockRepo, mockCategoryRepo, files)
+	return mockRepo, mockCategoryRepo, files, service
+}
+
 // --- Create ---
 
 func TestCashFlowService_Create_Income_Success(t *testing.T) {
-	mockRepo, service := setupCashFlowTest(t)
+	mockRepo, mockCategoryRepo, service := setupCashFlowCategoryTest(t)
 	ctx := context.Background()
 
+	mockCategoryRepo.On("GetByCode", ctx, "modal_awal").Return(&models.CashFlowCategory{
+		Code: "modal_awal", Type: models.CashFlowCategoryCapital, IsActive: true,
+	}, nil).Once()
 	mockRepo.On("Create", ctx, mock.AnythingOfType("*models.CashFlow")).Return(nil).Once()
 
 	cf, err := service.Create(ctx, services.CreateCashFlowRequest{
@@ -43,14 +66,16 @@ func TestCashFlowService_Create_Income_Success(t *testing.T) {
 }
 
 func TestCashFlowService_Create_Expense_Success(t *testing.T) {
-	mockRepo, service := setupCashFlowTest(t)
+	mockRepo, mockCategoryRepo, service := setupCashFlowCategoryTest(t)
 	ctx := context.Background()
 
+	mockCategoryRepo.On("GetByCode", ctx, "listrik").Return(&models.CashFlowCategory{
+		Code: "listrik", Type: models.CashFlowCategoryExpense, IsActive: true,
+	}, nil).Once()
 	mockRepo.On("Create", ctx, mock.AnythingOfType("*models.CashFlow")).Return(nil).Once()
 
 	cf, err := service.Create(ctx, services.CreateCashFlowRequest{
-		Type:   "expense",
-		Source: "electricity",
+		Source: "listrik",
 		Amount: 200000,
 		Date:   "2026-01-20",
 		Notes:  "Bayar listrik bulan Januari",
@@ -77,6 +102,82 @@ func TestCashFlowService_Create_InvalidType(t *testing.T) {
 	assert.Contains(t, err.Error(), "income")
 }
 
+func TestCashFlowService_Create_UnknownCategory(t *testing.T) {
+	_, mockCategoryRepo, service := setupCashFlowCategoryTest(t)
+	ctx := context.Background()
+
+	mockCategoryRepo.On("GetByCode", ctx, "listrk").Return(nil, gorm.ErrRecordNotFound).Once()
+
+	cf, err := service.Create(ctx, services.CreateCashFlowRequest{
+		Type:   "expense",
+		Source: "listrk",
+		Amount: 100,
+		Date:   "2026-01-01",
+	}, 1)
+
+	assert.Error(t, err)
+	assert.Nil(t, cf)
+	assert.Contains(t, err.Error(), "unknown cash flow category")
+}
+
+func TestCashFlowService_Create_SystemCategory(t *testing.T) {
+	_, mockCategoryRepo, service := setupCashFlowCategoryTest(t)
+	ctx := context.Background()
+
+	mockCategoryRepo.On("GetByCode", ctx, models.CashFlowSourceSales).Return(&models.CashFlowCategory{
+		Code: models.CashFlowSourceSales, Type: models.CashFlowCategoryIncome, IsSystem: true, IsActive: true,
+	}, nil).Once()
+
+	cf, err := service.Create(ctx, services.CreateCashFlowRequest{
+		Source: models.CashFlowSourceSales,
+		Amount: 100,
+		Date:   "2026-01-01",
+	}, 1)
+
+	assert.Error(t, err)
+	assert.Nil(t, cf)
+	assert.Contains(t, err.Error(), "reserved")
+}
+
+func TestCashFlowService_Create_TypeMismatch(t *testing.T) {
+	_, mockCategoryRepo, service := setupCashFlowCategoryTest(t)
+	ctx := context.Background()
+
+	mockCategoryRepo.On("GetByCode", ctx, "sewa").Return(&models.CashFlowCategory{
+		Code: "sewa", Type: models.CashFlowCategoryExpense, IsActive: true,
+	}, nil).Once()
+
+	cf, err := service.Create(ctx, services.CreateCashFlowRequest{
+		Type:   "income",
+		Source: "sewa",
+		Amount: 100,
+		Date:   "2026-01-01",
+	}, 1)
+
+	assert.Error(t, err)
+	assert.Nil(t, cf)
+	assert.Contains(t, err.Error(), "only takes expense entries")
+}
+
+func TestCashFlowService_Create_CapitalNeedsType(t *testing.T) {
+	_, mockCategoryRepo, service := setupCashFlowCategoryTest(t)
+	ctx := context.Background()
+
+	mockCategoryRepo.On("GetByCode", ctx, "prive").Return(&models.CashFlowCategory{
+		Code: "prive", Type: models.CashFlowCategoryCapital, IsActive: true,
+	}, nil).Once()
+
+	cf, err := service.Create(ctx, services.CreateCashFlowRequest{
+		Source: "prive",
+		Amount: 100,
+		Date:   "2026-01-01",
+	}, 1)
+
+	assert.Error(t, err)
+	assert.Nil(t, cf)
+	assert.Contains(t, err.Error(), "type is required")
+}
+
 func TestCashFlowService_Create_InvalidDateFormat(t *testing.T) {
 	_, service := setupCashFlowTest(t)
 	ctx := context.Background()
@@ -125,6 +226,24 @@ func TestCashFlowService_Update_NotFound(t *testing.T) {
 	assert.Contains(t, err.Error(), "not found")
 }
 
+func TestCashFlowService_Update_CategoryTakesItsType(t *testing.T) {
+	mockRepo, mockCategoryRepo, service := setupCashFlowCategoryTest(t)
+	ctx := context.Background()
+
+	existing := &models.CashFlow{ID: 1, Type: "income", Source: "pendapatan_lain", Amount: 50000}
+	mockRepo.On("GetByID", ctx, uint(1)).Return(existing, nil).Once()
+	mockCategoryRepo.On("GetByCode", ctx, "sewa").Return(&models.CashFlowCategory{
+		Code: "sewa", Type: models.CashFlowCategoryExpense, IsActive: true,
+	}, nil).Once()
+	mockRepo.On("Update", ctx, mock.AnythingOfType("*models.CashFlow")).Return(nil).Once()
+
+	cf, err := service.Update(ctx, 1, services.UpdateCashFlowRequest{Source: "sewa"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "sewa", cf.Source)
+	assert.Equal(t, "expense", cf.Type)
+}
+
 // --- Delete ---
 
 func TestCashFlowService_Delete_Success(t *testing.T) {
@@ -152,6 +271,36 @@ func TestCashFlowService_Delete_NotFound(t *testing.T) {
 	assert.Contains(t, err.Error(), "not found")
 }
 
+// --- ForceDelete ---
+
+func TestCashFlowService_ForceDelete_RemovesAttachmentFiles(t *testing.T) {
+	mockRepo, _, files, service := setupCashFlowFilesTest(t)
+	ctx := context.Background()
+
+	key := "cash_flow/1/receipt.jpg"
+	if err := files.Put(ctx, key, strings.NewReader("jpeg"), 4, "image/jpeg"); err != nil {
+		t.Fatal(err)
+	}
+	mockRepo.On("ForceDelete", ctx, uint(1)).Return([]models.Attachment{{ID: 7, StorageKey: key}}, nil).Once()
+
+	err := service.ForceDelete(ctx, 1)
+
+	assert.NoError(t, err)
+	_, err = files.Open(ctx, key)
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+}
+
+func TestCashFlowService_ForceDelete_NotFound(t *testing.T) {
+	mockRepo, service := setupCashFlowTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("ForceDelete", ctx, uint(99)).Return(nil, gorm.ErrRecordNotFound).Once()
+
+	err := service.ForceDelete(ctx, 99)
+
+	assert.ErrorIs(t, err, customErrors.ErrNotFound)
+}
+
 // --- GetByID ---
 
 func TestCashFlowService_GetByID_Success(t *testing.T) {
@@ -235,3 +384,107 @@ func TestCashFlowService_GetSummary_Error(t *testing.T) {
 	assert.Error(t, err)
 	assert.Nil(t, summary)
 }
+
+// --- Categories ---
+
+func TestCashFlowService_CreateCategory_DefaultAccount(t *testing.T) {
+	_, mockCategoryRepo, service := setupCashFlowCategoryTest(t)
+	ctx := context.Background()
+
+	mockCategoryRepo.On("Create", ctx, mock.AnythingOfType("*models.CashFlowCategory")).Return(nil).Once()
+
+	category, err := service.CreateCategory(ctx, services.CashFlowCategoryRequest{
+		Code: "Biaya Internet",
+		Name: "Biaya Internet",
+		Type: models.CashFlowCategoryExpense,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "biaya_internet", category.Code)
+	assert.Equal(t, models.AccountCodeOperating, category.AccountCode)
+	assert.True(t, category.IsActive)
+	assert.False(t, category.IsSystem)
+}
+
+func TestCashFlowService_CreateCategory_UnknownAccount(t *testing.T) {
+	_, mockCategoryRepo, service := setupCashFlowCategoryTest(t)
+	ctx := context.Background()
+
+	mockCategoryRepo.On("AccountExists", ctx, "9999").Return(false, nil).Once()
+
+	category, err := service.CreateCategory(ctx, services.CashFlowCategoryRequest{
+		Code:        "bunga_bank",
+		Name:        "Bunga Bank",
+		Type:        models.CashFlowCategoryIncome,
+		AccountCode: "9999",
+	})
+
+	assert.Error(t, err)
+	assert.Nil(t, category)
+	assert.Contains(t, err.Error(), "does not exist")
+}
+
+func TestCashFlowService_UpdateCategory_System(t *testing.T) {
+	_, mockCategoryRepo, service := setupCashFlowCategoryTest(t)
+	ctx := context.Background()
+
+	mockCategoryRepo.On("GetByID", ctx, uint(1)).Return(&models.CashFlowCategory{
+		ID: 1, Code: models.CashFlowSourceSales, Type: models.CashFlowCategoryIncome, IsSystem: true,
+	}, nil).Once()
+
+	category, err := service.UpdateCategory(ctx, 1, services.CashFlowCategoryRequest{
+		Code: models.CashFlowSourceSales,
+		Name: "Sales",
+		Type: models.CashFlowCategoryIncome,
+	})
+
+	assert.Error(t, err)
+	assert.Nil(t, category)
+	assert.Contains(t, err.Error(), "system categories")
+}
+
+func TestCashFlowService_UpdateCategory_UsedCodeIsFixed(t *testing.T) {
+	_, mockCategoryRepo, service := setupCashFlowCategoryTest(t)
+	ctx := context.Background()
+
+	mockCategoryRepo.On("GetByID", ctx, uint(5)).Return(&models.CashFlowCategory{
+		ID: 5, Code: "sewa", Type: models.CashFlowCategoryExpense,
+	}, nil).Once()
+	mockCategoryRepo.On("IsUsed", ctx, "sewa").Return(true, nil).Once()
+
+	category, err := service.UpdateCategory(ctx, 5, services.CashFlowCategoryRequest{
+		Code: "sewa_toko",
+		Name: "Sewa Toko",
+		Type: models.CashFlowCategoryExpense,
+	})
+
+	assert.ErrorIs(t, err, customErrors.ErrConflict)
+	assert.Nil(t, category)
+}
+
+func TestCashFlowService_DeleteCategory_Used(t *testing.T) {
+	_, mockCategoryRepo, service := setupCashFlowCategoryTest(t)
+	ctx := context.Background()
+
+	mockCategoryRepo.On("GetByID", ctx, uint(5)).Return(&models.CashFlowCategory{
+		ID: 5, Code: "sewa", Type: models.CashFlowCategoryExpense,
+	}, nil).Once()
+	mockCategoryRepo.On("IsUsed", ctx, "sewa").Return(true, nil).Once()
+
+	err := service.DeleteCategory(ctx, 5)
+
+	assert.ErrorIs(t, err, customErrors.ErrConflict)
+}
+
+func TestCashFlowService_DeleteCategory_Unused(t *testing.T) {
+	_, mockCategoryRepo, service := setupCashFlowCategoryTest(t)
+	ctx := context.Background()
+
+	mockCategoryRepo.On("GetByID", ctx, uint(6)).Return(&models.CashFlowCategory{
+		ID: 6, Code: "parkir", Type: models.CashFlowCategoryExpense,
+	}, nil).Once()
+	mockCategoryRepo.On("IsUsed", ctx, "parkir").Return(false, nil).Once()
+	mockCategoryRepo.On("Delete", ctx, uint(6)).Return(nil).Once()
+
+	assert.NoError(t, service.DeleteCategory(ctx, 6))
+}
@@ -0,0 +1,155 @@
+package services_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"pos-api/internal/models"
+	"pos-api/internal/repositories"
+	"pos-api/internal/services"
+	"pos-api/tests/mocks"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func setupCurrencyTest(t *testing.T) (*mocks.CurrencyRepository, services.CurrencyService) {
+	mockRepo := mocks.NewCurrencyRepository(t)
+	mockSettingRepo := mocks.NewStoreSettingRepository(t)
+	mockSettingRepo.On("GetSettings", mock.Anything).Return(&models.StoreSetting{BaseCurrency: "IDR"}, nil).Maybe()
+	return mockRepo, services.NewCurrencyService(mockRepo, mockSettingRepo)
+}
+
+// --- Create ---
+
+func TestCurrencyService_Create_Success(t *testing.T) {
+	mockRepo, service := setupCurrencyTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("GetByCode", ctx, "USD").Return(nil, gorm.ErrRecordNotFound).Once()
+	mockRepo.On("Create", ctx, mock.MatchedBy(func(c *models.Currency) bool {
+		return c.Code == "USD" && c.Rate == 15850 && c.IsActive
+	}), uint(1)).Return(nil).Once()
+
+	currency, err := service.Create(ctx, services.CurrencyRequest{Code: "usd", Name: "US Dollar", Symbol: "$", Rate: 15850}, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "USD", currency.Code)
+	assert.NotNil(t, currency.RateUpdatedAt)
+}
+
+func TestCurrencyService_Create_BaseCurrency(t *testing.T) {
+	_, service := setupCurrencyTest(t)
+
+	_, err := service.Create(context.Background(), services.CurrencyRequest{Code: "IDR", Name: "Rupiah", Rate: 1}, 1)
+
+	assert.ErrorContains(t, err, "IDR is the base currency")
+}
+
+func TestCurrencyService_Create_Duplicate(t *testing.T) {
+	mockRepo, service := setupCurrencyTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("GetByCode", ctx, "USD").Return(&models.Currency{ID: 1, Code: "USD"}, nil).Once()
+
+	_, err := service.Create(ctx, services.CurrencyRequest{Code: "USD", Name: "US Dollar", Rate: 15850}, 1)
+
+	assert.True(t, customErrors.Is(err, customErrors.ErrConflict))
+}
+
+// --- ImportRates ---
+
+func TestCurrencyService_ImportRates_Success(t *testing.T) {
+	mockRepo, service := setupCurrencyTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("GetAll", ctx, false).Return([]models.Currency{{ID: 1, Code: "USD"}, {ID: 2, Code: "SGD"}}, nil).Once()
+	mockRepo.On("SetRates", ctx, mock.MatchedBy(func(rates []models.ExchangeRate) bool {
+		return len(rates) == 2 &&
+			rates[0].CurrencyID == 1 && rates[0].Rate == 15850.25 && rates[0].Source == models.ExchangeRateImport &&
+			rates[1].CurrencyID == 2 && rates[1].Rate == 11900 && rates[1].UserID == 4
+	}), mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+	rates, err := service.ImportRates(ctx, services.RateImport{
+		Content:      strings.NewReader("Code;Rate\nusd;15.850,25\nSGD;11.900\n"),
+		Delimiter:    ";",
+		DecimalComma: true,
+	}, 4)
+
+	assert.NoError(t, err)
+	assert.Len(t, rates, 2)
+	assert.Equal(t, 15850.25, rates[0].Currency.Rate)
+}
+
+func TestCurrencyService_ImportRates_UnknownCurrency(t *testing.T) {
+	mockRepo, service := setupCurrencyTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("GetAll", ctx, false).Return([]models.Currency{{ID: 1, Code: "USD"}}, nil).Once()
+
+	_, err := service.ImportRates(ctx, services.RateImport{Content: strings.NewReader("code,rate\nUSD,15850\nXYZ,10\n")}, 1)
+
+	assert.ErrorContains(t, err, `row 3: unknown currency "XYZ"`)
+	mockRepo.AssertNotCalled(t, "SetRates", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCurrencyService_ImportRates_MissingColumn(t *testing.T) {
+	_, service := setupCurrencyTest(t)
+
+	_, err := service.ImportRates(context.Background(), services.RateImport{Content: strings.NewReader("code,kurs\nUSD,15850\n")}, 1)
+
+	assert.Error(t, err)
+}
+
+// --- ConvertPayments ---
+
+func TestCurrencyService_ConvertPayments(t *testing.T) {
+	mockRepo, service := setupCurrencyTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("GetByCode", ctx, "USD").Return(&models.Currency{Code: "USD", Rate: 15850.5, IsActive: true}, nil).Once()
+
+	lines, err := service.ConvertPayments(ctx, []services.PaymentRequest{{Currency: "usd", Amount: 20}, {Amount: 10000}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []models.TransactionPayment{
+		{Currency: "USD", Amount: 20, Rate: 15850.5, BaseAmount: 317010},
+		{Currency: "IDR", Amount: 10000, Rate: 1, BaseAmount: 10000},
+	}, lines)
+}
+
+func TestCurrencyService_ConvertPayments_InactiveCurrency(t *testing.T) {
+	mockRepo, service := setupCurrencyTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("GetByCode", ctx, "EUR").Return(&models.Currency{Code: "EUR", Rate: 17000, IsActive: false}, nil).Once()
+
+	_, err := service.ConvertPayments(ctx, []services.PaymentRequest{{Currency: "EUR", Amount: 5}})
+
+	assert.ErrorContains(t, err, "EUR is not accepted")
+}
+
+// --- GetReceipts ---
+
+func TestCurrencyService_GetReceipts_DefaultsToCurrentMonth(t *testing.T) {
+	mockRepo, service := setupCurrencyTest(t)
+	ctx := context.Background()
+	now := date(2026, 3, 18).Add(15 * time.Hour)
+
+	mockRepo.On("GetReceipts", ctx, date(2026, 3, 1), date(2026, 3, 19).Add(-time.Microsecond)).Return([]repositories.CurrencyReceipt{
+		{Currency: "IDR", Payments: 3, Amount: 150000, BaseAmount: 150000, Transactions: 3},
+		{Currency: "USD", Payments: 2, Amount: 30, BaseAmount: 475500, Transactions: 2},
+	}, nil).Once()
+
+	report, err := service.GetReceipts(ctx, "", "", now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "IDR", report.BaseCurrency)
+	assert.Equal(t, 625500.0, report.TotalBase)
+	assert.Equal(t, 15850.0, report.Receipts[1].AverageRate)
+}
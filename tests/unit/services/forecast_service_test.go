@@ -0,0 +1,108 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"pos-api/internal/pkg/forecast"
+	"pos-api/internal/repositories"
+	"pos-api/internal/services"
+	"pos-api/tests/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupForecastTest(t *testing.T) (*mocks.ReportRepository, services.ForecastService) {
+	mockRepo := mocks.NewReportRepository(t)
+	return mockRepo, services.NewForecastService(mockRepo)
+}
+
+func TestForecastService_GetForecast_Success(t *testing.T) {
+	mockRepo, service := setupForecastTest(t)
+	ctx := context.Background()
+
+	today := forecast.Day(time.Now())
+	start := today.AddDate(0, 0, -28)
+	end := today.AddDate(0, 0, -1)
+
+	var daily []repositories.SalesReport
+	var productSales []repositories.DailyProductSales
+	for d := start; d.Before(today); d = d.AddDate(0, 0, 1) {
+		date := d.Format("2006-01-02")
+		daily = append(daily, repositories.SalesReport{Date: date, TotalSales: 50000, TotalItemsSold: 5})
+		productSales = append(productSales,
+			repositories.DailyProductSales{Date: date, ProductID: 1, Quantity: 3, Revenue: 30000},
+			repositories.DailyProductSales{Date: date, ProductID: 2, Quantity: 2, Revenue: 20000},
+		)
+	}
+
+	mockRepo.On("GetSalesReport", ctx, start, end).Return(daily, nil).Once()
+	mockRepo.On("GetDailyProductSales", ctx, start, end).Return(productSales, nil).Once()
+	mockRepo.On("GetProductStockLevels", ctx).Return([]repositories.ProductStockLevel{
+		{ProductID: 1, ProductName: "Kopi", CategoryID: 1, CategoryName: "Minuman", Price: 10000, Stock: 9},
+		{ProductID: 2, ProductName: "Teh", CategoryID: 1, CategoryName: "Minuman", Price: 10000, Stock: 1000},
+		{ProductID: 3, ProductName: "Gula", CategoryID: 2, CategoryName: "Bahan", Price: 15000, Stock: 0},
+	}, nil).Once()
+
+	result, err := service.GetForecast(ctx, 7, 28)
+
+	assert.NoError(t, err)
+	assert.Equal(t, today.Format("2006-01-02"), result.StartDate)
+	assert.Len(t, result.Store, 7)
+	assert.InDelta(t, 350000, result.TotalSales, 1)
+	assert.InDelta(t, 35, result.TotalItems, 0.01)
+
+	if assert.Len(t, result.Products, 3) {
+		kopi := result.Products[0]
+		assert.Equal(t, float64(3), kopi.AverageDaily)
+		assert.InDelta(t, 21, kopi.Quantity, 0.01)
+		assert.InDelta(t, 210000, kopi.Revenue, 1)
+		// 9 units at 3 a day run out on the third day
+		if assert.NotNil(t, kopi.StockOutDate) {
+			assert.Equal(t, today.AddDate(0, 0, 2).Format("2006-01-02"), *kopi.StockOutDate)
+			assert.Equal(t, 2, *kopi.DaysOfStock)
+		}
+
+		// 1000 units at 2 a day outlast the search horizon
+		assert.Nil(t, result.Products[1].StockOutDate)
+
+		// Already out of stock
+		gula := result.Products[2]
+		assert.Equal(t, float64(0), gula.Quantity)
+		if assert.NotNil(t, gula.DaysOfStock) {
+			assert.Equal(t, 0, *gula.DaysOfStock)
+		}
+	}
+
+	if assert.Len(t, result.Categories, 2) {
+		assert.Equal(t, "Bahan", result.Categories[0].CategoryName)
+		assert.Equal(t, "Minuman", result.Categories[1].CategoryName)
+		assert.InDelta(t, 35, result.Categories[1].Quantity, 0.01)
+		assert.Len(t, result.Categories[1].Daily, 7)
+	}
+}
+
+func TestForecastService_GetForecast_InvalidPeriod(t *testing.T) {
+	_, service := setupForecastTest(t)
+	ctx := context.Background()
+
+	_, err := service.GetForecast(ctx, 120, 56)
+	assert.Error(t, err)
+
+	_, err = service.GetForecast(ctx, 14, 7)
+	assert.Error(t, err)
+}
+
+func TestForecastService_GetForecast_RepoError(t *testing.T) {
+	mockRepo, service := setupForecastTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("GetSalesReport", ctx, mock.Anything, mock.Anything).Return(nil, errors.New("db error")).Once()
+
+	_, err := service.GetForecast(ctx, 14, 56)
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,143 @@
+package services_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"pos-api/internal/models"
+	customErrors "pos-api/internal/pkg/errors"
+	"pos-api/internal/services"
+	"pos-api/tests/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupInventoryIntegrityTest(t *testing.T) (*mocks.InventoryIntegrityRepository, services.InventoryIntegrityService) {
+	mockRepo := mocks.NewInventoryIntegrityRepository(t)
+	return mockRepo, services.NewInventoryIntegrityService(mockRepo)
+}
+
+// expectLogs makes product 1 hold stock at location 1 with the given logs.
+func expectLogs(m *mocks.InventoryIntegrityRepository, ctx context.Context, total, level float64, logs []models.InventoryLog) {
+	m.On("GetProducts", ctx, uint(1)).Return([]models.Product{{ID: 1, Name: "Beras", Stock: total}}, nil).Once()
+	m.On("GetLocationLevels", ctx, uint(1)).Return([]models.ProductStock{{ProductID: 1, LocationID: 1, Quantity: level}}, nil).Once()
+	m.On("GetEntries", ctx, uint(1)).Return(logs, nil).Once()
+}
+
+func TestInventoryIntegrityService_Check_Consistent(t *testing.T) {
+	mockRepo, service := setupInventoryIntegrityTest(t)
+	ctx := context.Background()
+
+	expectLogs(mockRepo, ctx, 7, 7, []models.InventoryLog{
+		{ID: 1, LocationID: 1, Type: "adjustment", Source: "opening", Quantity: 10, StockBefore: 0, StockAfter: 10},
+		{ID: 2, LocationID: 1, Type: "out", Source: "sale", Quantity: 3, StockBefore: 10, StockAfter: 7},
+	})
+
+	report, err := service.Check(ctx, 1, false, 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.ProductsChecked)
+	assert.Equal(t, 0, report.ProductsWithIssues)
+	assert.Empty(t, report.Products)
+}
+
+func TestInventoryIntegrityService_Check_UnloggedStockChange(t *testing.T) {
+	mockRepo, service := setupInventoryIntegrityTest(t)
+	ctx := context.Background()
+
+	// Stock was overwritten from 10 to 15 without a log, then 2 were sold
+	expectLogs(mockRepo, ctx, 13, 13, []models.InventoryLog{
+		{ID: 1, LocationID: 1, Type: "in", Source: "purchase", Quantity: 10, StockBefore: 0, StockAfter: 10},
+		{ID: 2, LocationID: 1, Type: "out", Source: "sale", Quantity: 2, StockBefore: 15, StockAfter: 13},
+	})
+
+	report, err := service.Check(ctx, 1, false, 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.ProductsWithIssues)
+	issues := report.Products[0].Issues
+	assert.Len(t, issues, 2)
+	assert.Equal(t, services.IntegrityGap, issues[0].Kind)
+	assert.Equal(t, uint(2), issues[0].LogID)
+	assert.Equal(t, float64(5), issues[0].Difference)
+	assert.Equal(t, services.IntegrityBalance, issues[1].Kind)
+	assert.Equal(t, float64(8), issues[1].Expected)
+	assert.Equal(t, float64(5), issues[1].Difference)
+	mockRepo.AssertNotCalled(t, "PostCorrection", mock.Anything, mock.Anything)
+}
+
+func TestInventoryIntegrityService_Check_FixPostsAuditAdjustment(t *testing.T) {
+	mockRepo, service := setupInventoryIntegrityTest(t)
+	ctx := context.Background()
+
+	expectLogs(mockRepo, ctx, 14, 13, []models.InventoryLog{
+		{ID: 1, LocationID: 1, Type: "in", Source: "purchase", Quantity: 10, StockBefore: 0, StockAfter: 10},
+		{ID: 2, LocationID: 1, Type: "out", Source: "sale", Quantity: 2, StockBefore: 15, StockAfter: 13},
+	})
+	mockRepo.On("PostCorrection", ctx, mock.MatchedBy(func(l *models.InventoryLog) bool {
+		return l.Type == "adjustment" && l.Source == models.InventoryAuditSource && l.LocationID == 1 &&
+			l.Quantity == 5 && l.StockBefore == 8 && l.StockAfter == 13 && l.UserID == 4
+	})).Return(nil).Once()
+	mockRepo.On("SyncProductStock", ctx, uint(1)).Return(float64(13), nil).Once()
+
+	report, err := service.Check(ctx, 1, true, 4)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.Corrections)
+	assert.Len(t, report.Products[0].Issues, 3) // gap, balance and total
+	assert.Empty(t, report.Products[0].Unresolved)
+}
+
+func TestInventoryIntegrityService_Check_AuditEntrySettlesEarlierGaps(t *testing.T) {
+	mockRepo, service := setupInventoryIntegrityTest(t)
+	ctx := context.Background()
+
+	expectLogs(mockRepo, ctx, 12, 12, []models.InventoryLog{
+		{ID: 1, LocationID: 1, Type: "in", Source: "purchase", Quantity: 10, StockBefore: 0, StockAfter: 10},
+		{ID: 2, LocationID: 1, Type: "out", Source: "sale", Quantity: 2, StockBefore: 15, StockAfter: 13},
+		{ID: 3, LocationID: 1, Type: "adjustment", Source: models.InventoryAuditSource, Quantity: 5, StockBefore: 8, StockAfter: 13},
+		{ID: 4, LocationID: 1, Type: "out", Source: "sale", Quantity: 1, StockBefore: 13, StockAfter: 12},
+	})
+
+	report, err := service.Check(ctx, 1, false, 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, report.ProductsWithIssues)
+}
+
+func TestInventoryIntegrityService_Check_FixStockMovedMeanwhile(t *testing.T) {
+	mockRepo, service := setupInventoryIntegrityTest(t)
+	ctx := context.Background()
+
+	expectLogs(mockRepo, ctx, 4, 4, nil)
+	mockRepo.On("PostCorrection", ctx, mock.AnythingOfType("*models.InventoryLog")).
+		Return(fmt.Errorf("%w: stock moved since the check", customErrors.ErrConflict)).Once()
+
+	report, err := service.Check(ctx, 1, true, 4)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, report.Corrections)
+	assert.Len(t, report.Products[0].Unresolved, 1)
+}
+
+func TestInventoryIntegrityService_Check_FixRequiresUser(t *testing.T) {
+	mockRepo, service := setupInventoryIntegrityTest(t)
+
+	_, err := service.Check(context.Background(), 0, true, 0)
+
+	assert.Error(t, err)
+	mockRepo.AssertNotCalled(t, "GetProducts", mock.Anything, mock.Anything)
+}
+
+func TestInventoryIntegrityService_Check_ProductNotFound(t *testing.T) {
+	mockRepo, service := setupInventoryIntegrityTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("GetProducts", ctx, uint(9)).Return([]models.Product{}, nil).Once()
+
+	_, err := service.Check(ctx, 9, false, 0)
+
+	assert.ErrorIs(t, err, customErrors.ErrNotFound)
+}
@@ -3,9 +3,11 @@ package services_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 
 	"pos-api/internal/models"
+	customErrors "pos-api/internal/pkg/errors"
 	"pos-api/internal/services"
 	"pos-api/tests/mocks"
 
@@ -13,22 +15,30 @@ import (
 	"github.com/stretchr/testify/mock"
 )
 
-func setupInventoryTest(t *testing.T) (*mocks.InventoryLogRepository, *mocks.ProductRepository, services.InventoryLogService) {
+func setupInventoryTest(t *testing.T) (*mocks.InventoryLogRepository, *mocks.ProductRepository, *mocks.LocationRepository, services.InventoryLogService) {
 	mockLogRepo := mocks.NewInventoryLogRepository(t)
 	mockProductRepo := mocks.NewProductRepository(t)
-	service := services.NewInventoryLogService(mockLogRepo, mockProductRepo)
-	return mockLogRepo, mockProductRepo, service
+	mockLocationRepo := mocks.NewLocationRepository(t)
+	service := services.NewInventoryLogService(mockLogRepo, mockProductRepo, mockLocationRepo)
+	return mockLogRepo, mockProductRepo, mockLocationRepo, service
+}
+
+// expectStockAtDefault makes the default location (ID 1) hold stock of the product.
+func expectStockAtDefault(m *mocks.LocationRepository, ctx context.Context, productID uint, stock float64) {
+	m.On("GetDefault", ctx).Return(&models.Location{ID: 1, Code: "MAIN", IsDefault: true, IsActive: true}, nil).Once()
+	m.On("GetStockLevel", ctx, productID, uint(1)).Return(stock, nil).Once()
 }
 
 // --- AdjustStock: Stock In ---
 
 func TestInventoryService_AdjustStock_In_Success(t *testing.T) {
-	mockLogRepo, mockProductRepo, service := setupInventoryTest(t)
+	mockLogRepo, mockProductRepo, mockLocationRepo, service := setupInventoryTest(t)
 	ctx := context.Background()
 
 	product := &models.Product{ID: 1, Name: "Test Product", Stock: 10, Cost: 5000}
 	mockProductRepo.On("GetProductByID", ctx, uint(1)).Return(product, nil).Once()
-	mockLogRepo.On("ProcessAdjustment", ctx, mock.AnythingOfType("*models.InventoryLog"), mock.AnythingOfType("*models.Product")).Return(nil).Once()
+	expectStockAtDefault(mockLocationRepo, ctx, 1, 10)
+	mockLogRepo.On("ProcessAdjustment", ctx, mock.AnythingOfType("*models.InventoryLog"), mock.AnythingOfType("*models.Product"), mock.Anything).Return(nil).Once()
 
 	log, err := service.AdjustStock(ctx, services.StockAdjustmentRequest{
 		ProductID: 1,
@@ -40,20 +50,24 @@ func TestInventoryService_AdjustStock_In_Success(t *testing.T) {
 
 	assert.NoError(t, err)
 	assert.NotNil(t, log)
-	assert.Equal(t, 10, log.StockBefore)
-	assert.Equal(t, 30, log.StockAfter)
+	assert.Equal(t, 10.0, log.StockBefore)
+	assert.Equal(t, 30.0, log.StockAfter)
 	assert.Equal(t, "in", log.Type)
+	assert.Equal(t, 5500.0, log.CostPrice)
+	// The average cost is recomputed by the repository, not overwritten with the purchase price
+	assert.Equal(t, 5000.0, product.Cost)
 }
 
 // --- AdjustStock: Stock Out ---
 
 func TestInventoryService_AdjustStock_Out_Success(t *testing.T) {
-	mockLogRepo, mockProductRepo, service := setupInventoryTest(t)
+	mockLogRepo, mockProductRepo, mockLocationRepo, service := setupInventoryTest(t)
 	ctx := context.Background()
 
 	product := &models.Product{ID: 1, Name: "Test", Stock: 10, Cost: 3000}
 	mockProductRepo.On("GetProductByID", ctx, uint(1)).Return(product, nil).Once()
-	mockLogRepo.On("ProcessAdjustment", ctx, mock.AnythingOfType("*models.InventoryLog"), mock.AnythingOfType("*models.Product")).Return(nil).Once()
+	expectStockAtDefault(mockLocationRepo, ctx, 1, 10)
+	mockLogRepo.On("ProcessAdjustment", ctx, mock.AnythingOfType("*models.InventoryLog"), mock.AnythingOfType("*models.Product"), mock.Anything).Return(nil).Once()
 
 	log, err := service.AdjustStock(ctx, services.StockAdjustmentRequest{
 		ProductID: 1,
@@ -64,16 +78,17 @@ func TestInventoryService_AdjustStock_Out_Success(t *testing.T) {
 
 	assert.NoError(t, err)
 	assert.NotNil(t, log)
-	assert.Equal(t, 10, log.StockBefore)
-	assert.Equal(t, 7, log.StockAfter)
+	assert.Equal(t, 10.0, log.StockBefore)
+	assert.Equal(t, 7.0, log.StockAfter)
 }
 
 func TestInventoryService_AdjustStock_Out_InsufficientStock(t *testing.T) {
-	_, mockProductRepo, service := setupInventoryTest(t)
+	_, mockProductRepo, mockLocationRepo, service := setupInventoryTest(t)
 	ctx := context.Background()
 
 	product := &models.Product{ID: 1, Name: "Test", Stock: 5, Cost: 3000}
 	mockProductRepo.On("GetProductByID", ctx, uint(1)).Return(product, nil).Once()
+	expectStockAtDefault(mockLocationRepo, ctx, 1, 5)
 
 	log, err := service.AdjustStock(ctx, services.StockAdjustmentRequest{
 		ProductID: 1,
@@ -87,15 +102,55 @@ func TestInventoryService_AdjustStock_Out_InsufficientStock(t *testing.T) {
 	assert.Contains(t, err.Error(), "insufficient stock")
 }
 
+func TestInventoryService_AdjustStock_Out_FractionalSoldByWeight(t *testing.T) {
+	mockLogRepo, mockProductRepo, mockLocationRepo, service := setupInventoryTest(t)
+	ctx := context.Background()
+
+	product := &models.Product{ID: 1, Name: "Daging Sapi", Stock: 12.5, Cost: 120000, SoldByWeight: true}
+	mockProductRepo.On("GetProductByID", ctx, uint(1)).Return(product, nil).Once()
+	expectStockAtDefault(mockLocationRepo, ctx, 1, 12.5)
+	mockLogRepo.On("ProcessAdjustment", ctx, mock.AnythingOfType("*models.InventoryLog"), mock.AnythingOfType("*models.Product"), mock.Anything).Return(nil).Once()
+
+	log, err := service.AdjustStock(ctx, services.StockAdjustmentRequest{
+		ProductID: 1,
+		Type:      "out",
+		Source:    "damage",
+		Quantity:  0.35,
+	}, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 12.15, log.StockAfter)
+}
+
+func TestInventoryService_AdjustStock_FractionalNotSoldByWeight(t *testing.T) {
+	_, mockProductRepo, _, service := setupInventoryTest(t)
+	ctx := context.Background()
+
+	product := &models.Product{ID: 1, Name: "Test Product", Stock: 10, Cost: 5000}
+	mockProductRepo.On("GetProductByID", ctx, uint(1)).Return(product, nil).Once()
+
+	log, err := service.AdjustStock(ctx, services.StockAdjustmentRequest{
+		ProductID: 1,
+		Type:      "in",
+		Source:    "purchase",
+		Quantity:  2.5,
+	}, 1)
+
+	assert.Error(t, err)
+	assert.Nil(t, log)
+	assert.Contains(t, err.Error(), "whole number")
+}
+
 // --- AdjustStock: Adjustment ---
 
 func TestInventoryService_AdjustStock_Adjustment_Success(t *testing.T) {
-	mockLogRepo, mockProductRepo, service := setupInventoryTest(t)
+	mockLogRepo, mockProductRepo, mockLocationRepo, service := setupInventoryTest(t)
 	ctx := context.Background()
 
 	product := &models.Product{ID: 1, Name: "Test", Stock: 10, Cost: 3000}
 	mockProductRepo.On("GetProductByID", ctx, uint(1)).Return(product, nil).Once()
-	mockLogRepo.On("ProcessAdjustment", ctx, mock.AnythingOfType("*models.InventoryLog"), mock.AnythingOfType("*models.Product")).Return(nil).Once()
+	expectStockAtDefault(mockLocationRepo, ctx, 1, 10)
+	mockLogRepo.On("ProcessAdjustment", ctx, mock.AnythingOfType("*models.InventoryLog"), mock.AnythingOfType("*models.Product"), mock.Anything).Return(nil).Once()
 
 	log, err := service.AdjustStock(ctx, services.StockAdjustmentRequest{
 		ProductID: 1,
@@ -106,14 +161,74 @@ func TestInventoryService_AdjustStock_Adjustment_Success(t *testing.T) {
 
 	assert.NoError(t, err)
 	assert.NotNil(t, log)
-	assert.Equal(t, 10, log.StockBefore)
-	assert.Equal(t, 25, log.StockAfter)
+	assert.Equal(t, 10.0, log.StockBefore)
+	assert.Equal(t, 25.0, log.StockAfter)
+}
+
+// --- AdjustStock: Locations ---
+
+func TestInventoryService_AdjustStock_UsesLocationStockLevel(t *testing.T) {
+	mockLogRepo, mockProductRepo, mockLocationRepo, service := setupInventoryTest(t)
+	ctx := context.Background()
+
+	// 40 in total, but only 4 on the shop floor
+	product := &models.Product{ID: 1, Name: "Test", Stock: 40, Cost: 3000}
+	mockProductRepo.On("GetProductByID", ctx, uint(1)).Return(product, nil).Once()
+	mockLocationRepo.On("GetByID", ctx, uint(2)).Return(&models.Location{ID: 2, Code: "TOKO", IsActive: true}, nil).Once()
+	mockLocationRepo.On("GetStockLevel", ctx, uint(1), uint(2)).Return(4.0, nil).Once()
+	mockLogRepo.On("ProcessAdjustment", ctx, mock.MatchedBy(func(l *models.InventoryLog) bool {
+		return l.LocationID == 2
+	}), mock.AnythingOfType("*models.Product"), mock.Anything).Return(nil).Once()
+
+	log, err := service.AdjustStock(ctx, services.StockAdjustmentRequest{
+		ProductID:  1,
+		Type:       "out",
+		Source:     "damage",
+		Quantity:   3,
+		LocationID: 2,
+	}, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 4.0, log.StockBefore)
+	assert.Equal(t, 1.0, log.StockAfter)
+
+	// More than the location holds is refused even though the total would cover it
+	mockProductRepo.On("GetProductByID", ctx, uint(1)).Return(product, nil).Once()
+	mockLocationRepo.On("GetByID", ctx, uint(2)).Return(&models.Location{ID: 2, Code: "TOKO", IsActive: true}, nil).Once()
+	mockLocationRepo.On("GetStockLevel", ctx, uint(1), uint(2)).Return(1.0, nil).Once()
+
+	_, err = service.AdjustStock(ctx, services.StockAdjustmentRequest{
+		ProductID:  1,
+		Type:       "out",
+		Source:     "damage",
+		Quantity:   3,
+		LocationID: 2,
+	}, 1)
+	assert.ErrorContains(t, err, "insufficient stock")
+}
+
+func TestInventoryService_AdjustStock_InactiveLocation(t *testing.T) {
+	_, mockProductRepo, mockLocationRepo, service := setupInventoryTest(t)
+	ctx := context.Background()
+
+	mockProductRepo.On("GetProductByID", ctx, uint(1)).Return(&models.Product{ID: 1, Name: "Test"}, nil).Once()
+	mockLocationRepo.On("GetByID", ctx, uint(3)).Return(&models.Location{ID: 3, Code: "LAMA", IsActive: false}, nil).Once()
+
+	_, err := service.AdjustStock(ctx, services.StockAdjustmentRequest{
+		ProductID:  1,
+		Type:       "in",
+		Source:     "purchase",
+		Quantity:   5,
+		LocationID: 3,
+	}, 1)
+
+	assert.ErrorContains(t, err, "inactive")
 }
 
 // --- AdjustStock: Product Not Found ---
 
 func TestInventoryService_AdjustStock_ProductNotFound(t *testing.T) {
-	_, mockProductRepo, service := setupInventoryTest(t)
+	_, mockProductRepo, _, service := setupInventoryTest(t)
 	ctx := context.Background()
 
 	mockProductRepo.On("GetProductByID", ctx, uint(999)).Return(nil, errors.New("not found")).Once()
@@ -133,7 +248,7 @@ func TestInventoryService_AdjustStock_ProductNotFound(t *testing.T) {
 // --- GetLogsByProduct ---
 
 func TestInventoryService_GetLogsByProduct_Success(t *testing.T) {
-	mockLogRepo, _, service := setupInventoryTest(t)
+	mockLogRepo, _, _, service := setupInventoryTest(t)
 	ctx := context.Background()
 
 	logs := []models.InventoryLog{
@@ -152,7 +267,7 @@ func TestInventoryService_GetLogsByProduct_Success(t *testing.T) {
 // --- GetAllLogs ---
 
 func TestInventoryService_GetAllLogs_Success(t *testing.T) {
-	mockLogRepo, _, service := setupInventoryTest(t)
+	mockLogRepo, _, _, service := setupInventoryTest(t)
 	ctx := context.Background()
 
 	logs := []models.InventoryLog{{ID: 1}, {ID: 2}, {ID: 3}}
@@ -172,7 +287,7 @@ func TestInventoryService_GetAllLogs_Success(t *testing.T) {
 // --- GetInventoryStats ---
 
 func TestInventoryService_GetStats_Success(t *testing.T) {
-	mockLogRepo, _, service := setupInventoryTest(t)
+	mockLogRepo, _, _, service := setupInventoryTest(t)
 	ctx := context.Background()
 
 	stats := map[string]int64{"in": 50, "out": 10, "adjustment": 3}
@@ -184,3 +299,185 @@ func TestInventoryService_GetStats_Success(t *testing.T) {
 	assert.Equal(t, int64(50), result["in"])
 	assert.Equal(t, int64(10), result["out"])
 }
+
+// --- AdjustStock: Lots ---
+
+func TestInventoryService_AdjustStock_In_WithExpiryCreatesLot(t *testing.T) {
+	mockLogRepo, mockProductRepo, mockLocationRepo, service := setupInventoryTest(t)
+	ctx := context.Background()
+
+	product := &models.Product{ID: 1, Name: "Yogurt", Stock: 0, Cost: 4000}
+	mockProductRepo.On("GetProductByID", ctx, uint(1)).Return(product, nil).Once()
+	expectStockAtDefault(mockLocationRepo, ctx, 1, 0)
+	mockLogRepo.On("ProcessAdjustment", ctx, mock.AnythingOfType("*models.InventoryLog"), mock.AnythingOfType("*models.Product"),
+		mock.MatchedBy(func(l *models.ProductLot) bool {
+			// Without a lot number the lot is named after its expiry date
+			return l.LotNumber == "EXP-20270131" && l.ExpiryDate.Format("2006-01-02") == "2027-01-31" &&
+				l.ProductID == 1 && l.LocationID == 1 && l.CostPrice == 4200
+		})).Return(nil).Once()
+
+	_, err := service.AdjustStock(ctx, services.StockAdjustmentRequest{
+		ProductID:  1,
+		Type:       "in",
+		Source:     "purchase",
+		Quantity:   12,
+		CostPrice:  4200,
+		ExpiryDate: "2027-01-31",
+	}, 1)
+
+	assert.NoError(t, err)
+}
+
+func TestInventoryService_AdjustStock_LotRejectedForStockOut(t *testing.T) {
+	_, mockProductRepo, _, service := setupInventoryTest(t)
+	ctx := context.Background()
+
+	product := &models.Product{ID: 1, Name: "Yogurt", Stock: 10, Cost: 4000}
+	mockProductRepo.On("GetProductByID", ctx, uint(1)).Return(product, nil).Once()
+
+	_, err := service.AdjustStock(ctx, services.StockAdjustmentRequest{
+		ProductID: 1,
+		Type:      "out",
+		Source:    "damage",
+		Quantity:  2,
+		LotNumber: "B-001",
+	}, 1)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "only be given for stock in")
+}
+
+func TestInventoryService_AdjustStock_InvalidExpiryDate(t *testing.T) {
+	_, mockProductRepo, _, service := setupInventoryTest(t)
+	ctx := context.Background()
+
+	product := &models.Product{ID: 1, Name: "Yogurt", Stock: 10, Cost: 4000}
+	mockProductRepo.On("GetProductByID", ctx, uint(1)).Return(product, nil).Once()
+
+	_, err := service.AdjustStock(ctx, services.StockAdjustmentRequest{
+		ProductID:  1,
+		Type:       "in",
+		Source:     "purchase",
+		Quantity:   2,
+		ExpiryDate: "31/01/2027",
+	}, 1)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid expiry_date")
+}
+
+// --- AdjustStock: Serial numbers ---
+
+func TestInventoryService_AdjustStock_SerializedNeedsOneSerialPerUnit(t *testing.T) {
+	_, mockProductRepo, mockLocationRepo, service := setupInventoryTest(t)
+	ctx := context.Background()
+
+	product := &models.Product{ID: 3, Name: "Powerbank", Stock: 0, Cost: 150000, Serialized: true}
+	mockProductRepo.On("GetProductByID", ctx, uint(3)).Return(product, nil).Once()
+	expectStockAtDefault(mockLocationRepo, ctx, 3, 0)
+
+	_, err := service.AdjustStock(ctx, services.StockAdjustmentRequest{
+		ProductID:     3,
+		Type:          "in",
+		Source:        "purchase",
+		Quantity:      2,
+		SerialNumbers: []string{"PB-001", "PB-001"},
+	}, 1)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "more than once")
+}
+
+func TestInventoryService_AdjustStock_SerialsOnLog(t *testing.T) {
+	mockLogRepo, mockProductRepo, mockLocationRepo, service := setupInventoryTest(t)
+	ctx := context.Background()
+
+	product := &models.Product{ID: 3, Name: "Powerbank", Stock: 0, Cost: 150000, Serialized: true}
+	mockProductRepo.On("GetProductByID", ctx, uint(3)).Return(product, nil).Once()
+	expectStockAtDefault(mockLocationRepo, ctx, 3, 0)
+	mockLogRepo.On("ProcessAdjustment", ctx, mock.MatchedBy(func(l *models.InventoryLog) bool {
+		return len(l.SerialNumbers) == 2 && l.SerialNumbers[0] == "PB-001"
+	}), mock.AnythingOfType("*models.Product"), mock.Anything).Return(nil).Once()
+
+	_, err := service.AdjustStock(ctx, services.StockAdjustmentRequest{
+		ProductID:     3,
+		Type:          "in",
+		Source:        "purchase",
+		Quantity:      2,
+		SerialNumbers: []string{"PB-001", "PB-002"},
+	}, 1)
+
+	assert.NoError(t, err)
+}
+
+func TestInventoryService_AdjustStock_SerialsRejectedForUnserializedProduct(t *testing.T) {
+	_, mockProductRepo, mockLocationRepo, service := setupInventoryTest(t)
+	ctx := context.Background()
+
+	product := &models.Product{ID: 1, Name: "Kabel Data", Stock: 10, Cost: 10000}
+	mockProductRepo.On("GetProductByID", ctx, uint(1)).Return(product, nil).Once()
+	expectStockAtDefault(mockLocationRepo, ctx, 1, 10)
+
+	_, err := service.AdjustStock(ctx, services.StockAdjustmentRequest{
+		ProductID:     1,
+		Type:          "in",
+		Source:        "purchase",
+		Quantity:      1,
+		SerialNumbers: []string{"KD-001"},
+	}, 1)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "does not track serial numbers")
+}
+
+// --- Shortfalls ---
+
+func TestInventoryService_GetShortfalls_OpenByDefault(t *testing.T) {
+	mockLogRepo, _, _, service := setupInventoryTest(t)
+	ctx := context.Background()
+
+	logs := []models.InventoryLog{{ID: 4, ProductID: 1, Shortfall: 2, NeedsReview: true}}
+	mockLogRepo.On("GetShortfalls", ctx, 20, 0, true).Return(logs, int64(1), nil).Once()
+
+	result, total, err := service.GetShortfalls(ctx, 1, 20, "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, logs, result)
+}
+
+func TestInventoryService_GetShortfalls_InvalidStatus(t *testing.T) {
+	mockLogRepo, _, _, service := setupInventoryTest(t)
+	ctx := context.Background()
+
+	_, _, err := service.GetShortfalls(ctx, 1, 20, "closed")
+
+	assert.Error(t, err)
+	mockLogRepo.AssertNotCalled(t, "GetShortfalls", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestInventoryService_ReviewShortfall_Success(t *testing.T) {
+	mockLogRepo, _, _, service := setupInventoryTest(t)
+	ctx := context.Background()
+
+	reviewed := &models.InventoryLog{ID: 4, Shortfall: 2, ReviewNotes: "Stok rak belum diinput"}
+	mockLogRepo.On("ReviewShortfall", ctx, uint(4), uint(2), "Stok rak belum diinput").Return(reviewed, nil).Once()
+
+	log, err := service.ReviewShortfall(ctx, 4, 2, "  Stok rak belum diinput ")
+
+	assert.NoError(t, err)
+	assert.Equal(t, reviewed, log)
+}
+
+func TestInventoryService_ReviewShortfall_AlreadyReviewed(t *testing.T) {
+	mockLogRepo, _, _, service := setupInventoryTest(t)
+	ctx := context.Background()
+
+	mockLogRepo.On("ReviewShortfall", ctx, uint(4), uint(2), "").
+		Return(nil, fmt.Errorf("%w: inventory log 4 is not waiting for review", customErrors.ErrConflict)).Once()
+
+	log, err := service.ReviewShortfall(ctx, 4, 2, "")
+
+	assert.ErrorIs(t, err, customErrors.ErrConflict)
+	assert.Nil(t, log)
+}
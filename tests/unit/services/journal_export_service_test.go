@@ -0,0 +1,188 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"pos-api/internal/models"
+	"pos-api/internal/repositories"
+	"pos-api/internal/services"
+	"pos-api/tests/mocks"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func setupJournalExportTest(t *testing.T) (*mocks.JournalExportRepository, services.JournalExportService) {
+	mockRepo := mocks.NewJournalExportRepository(t)
+	return mockRepo, services.NewJournalExportService(mockRepo)
+}
+
+// saleRows are the lines of a cash sale with its cost of goods sold.
+var saleRows = []repositories.JournalExportRow{
+	{EntryID: 12, Date: date(2026, 3, 5), Source: models.JournalSale, Description: "Penjualan INV-1", Reference: "INV-1", AccountCode: "1100", AccountName: "Kas", Debit: 15000},
+	{EntryID: 12, Date: date(2026, 3, 5), Source: models.JournalSale, Description: "Penjualan INV-1", Reference: "INV-1", AccountCode: "4100", AccountName: "Penjualan", Credit: 15000},
+	{EntryID: 12, Date: date(2026, 3, 5), Source: models.JournalSale, Description: "Penjualan INV-1", Reference: "INV-1", AccountCode: "5100", AccountName: "HPP", Debit: 9500.5},
+	{EntryID: 12, Date: date(2026, 3, 5), Source: models.JournalSale, Description: "Penjualan INV-1", Reference: "INV-1", AccountCode: "1300", AccountName: "Persediaan", Credit: 9500.5},
+}
+
+// --- Create ---
+
+func TestJournalExportService_Create_ExpandsScopes(t *testing.T) {
+	mockRepo, service := setupJournalExportTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("Create", ctx, mock.AnythingOfType("*models.JournalExport"),
+		mock.MatchedBy(func(sources []string) bool {
+			return assert.ElementsMatch(t, []string{
+				models.JournalSale, models.JournalCashFlow, models.JournalBankFee, models.JournalSupplierPayment,
+			}, sources)
+		}), date(2026, 3, 1), date(2026, 3, 31)).Return(true, nil).Once()
+
+	export, err := service.Create(ctx, services.JournalExportRequest{
+		StartDate: "2026-03-01",
+		EndDate:   "2026-03-31",
+		Scopes:    []string{"sales", "expenses", "sales"},
+	}, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"expenses", "sales"}, export.Scopes)
+	assert.Equal(t, "Generic", export.TemplateName)
+	assert.Equal(t, uint(1), export.UserID)
+}
+
+func TestJournalExportService_Create_UsesTemplate(t *testing.T) {
+	mockRepo, service := setupJournalExportTest(t)
+	ctx := context.Background()
+	templateID := uint(3)
+	layout := models.ExportLayout{Columns: []models.ExportColumn{{Header: "Akun", Field: models.ExportFieldAccountCode}}}
+
+	mockRepo.On("GetTemplate", ctx, templateID).Return(&models.JournalExportTemplate{ID: 3, Name: "Accurate", Layout: layout}, nil).Once()
+	mockRepo.On("Create", ctx, mock.MatchedBy(func(e *models.JournalExport) bool {
+		return e.TemplateName == "Accurate" && len(e.Layout.Columns) == 1 && len(e.Scopes) == 3
+	}), mock.Anything, date(2026, 3, 1), date(2026, 3, 31)).Return(true, nil).Once()
+
+	_, err := service.Create(ctx, services.JournalExportRequest{StartDate: "2026-03-01", EndDate: "2026-03-31", TemplateID: &templateID}, 1)
+
+	assert.NoError(t, err)
+}
+
+func TestJournalExportService_Create_NothingLeft(t *testing.T) {
+	mockRepo, service := setupJournalExportTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("Create", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(false, nil).Once()
+
+	_, err := service.Create(ctx, services.JournalExportRequest{StartDate: "2026-03-01", EndDate: "2026-03-31"}, 1)
+
+	assert.ErrorContains(t, err, "no journal entries left to export")
+}
+
+func TestJournalExportService_Create_InvalidScope(t *testing.T) {
+	_, service := setupJournalExportTest(t)
+
+	_, err := service.Create(context.Background(), services.JournalExportRequest{
+		StartDate: "2026-03-01", EndDate: "2026-03-31", Scopes: []string{"payroll"},
+	}, 1)
+
+	assert.ErrorContains(t, err, "validation failed")
+}
+
+func TestJournalExportService_Create_EndBeforeStart(t *testing.T) {
+	_, service := setupJournalExportTest(t)
+
+	_, err := service.Create(context.Background(), services.JournalExportRequest{StartDate: "2026-03-31", EndDate: "2026-03-01"}, 1)
+
+	assert.ErrorContains(t, err, "end_date must not be before start_date")
+}
+
+// --- Templates ---
+
+func TestJournalExportService_CreateTemplate_DuplicateName(t *testing.T) {
+	mockRepo, service := setupJournalExportTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("GetTemplateByName", ctx, "Accurate").Return(&models.JournalExportTemplate{ID: 2, Name: "accurate"}, nil).Once()
+
+	_, err := service.CreateTemplate(ctx, services.JournalExportTemplateRequest{
+		Name:   " Accurate ",
+		Layout: services.DefaultExportLayout(),
+	}, 1)
+
+	assert.True(t, customErrors.Is(err, customErrors.ErrConflict))
+}
+
+func TestJournalExportService_CreateTemplate_UnknownField(t *testing.T) {
+	_, service := setupJournalExportTest(t)
+
+	_, err := service.CreateTemplate(context.Background(), services.JournalExportTemplateRequest{
+		Name:   "Jurnal",
+		Layout: models.ExportLayout{Columns: []models.ExportColumn{{Header: "Pajak", Field: "tax"}}},
+	}, 1)
+
+	assert.ErrorContains(t, err, `unknown field "tax"`)
+}
+
+// --- Download ---
+
+func TestJournalExportService_Download_Generic(t *testing.T) {
+	mockRepo, service := setupJournalExportTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("GetExport", ctx, uint(5)).Return(&models.JournalExport{
+		ID: 5, StartDate: date(2026, 3, 1), EndDate: date(2026, 3, 31), Layout: services.DefaultExportLayout(),
+	}, nil).Once()
+	mockRepo.On("GetRows", ctx, uint(5)).Return(saleRows[:2], nil).Once()
+
+	file, err := service.Download(ctx, 5)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "journal_20260301_20260331_5.csv", file.FileName)
+	assert.Equal(t, "Date,Journal No,Reference,Source,Description,Account Code,Account Name,Debit,Credit,Memo\n"+
+		"2026-03-05,12,INV-1,sale,Penjualan INV-1,1100,Kas,15000.00,0.00,\n"+
+		"2026-03-05,12,INV-1,sale,Penjualan INV-1,4100,Penjualan,0.00,15000.00,\n", string(file.Content))
+}
+
+func TestJournalExportService_Download_Template(t *testing.T) {
+	mockRepo, service := setupJournalExportTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("GetExport", ctx, uint(6)).Return(&models.JournalExport{
+		ID: 6, StartDate: date(2026, 3, 1), EndDate: date(2026, 3, 31),
+		Layout: models.ExportLayout{
+			Columns: []models.ExportColumn{
+				{Header: "Tanggal", Field: models.ExportFieldDate},
+				{Header: "No Bukti", Field: models.ExportFieldReference},
+				{Header: "Kode Akun", Field: models.ExportFieldAccountCode},
+				{Header: "Nilai", Field: models.ExportFieldAmount},
+				{Header: "Cabang", Value: "PUSAT"},
+			},
+			AccountCodes: map[string]string{"5100": "510-01", "1300": "110-300"},
+			DateFormat:   "DD/MM/YYYY",
+			Delimiter:    ";",
+			DecimalComma: true,
+		},
+	}, nil).Once()
+	mockRepo.On("GetRows", ctx, uint(6)).Return(saleRows[2:], nil).Once()
+
+	file, err := service.Download(ctx, 6)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Tanggal;No Bukti;Kode Akun;Nilai;Cabang\n"+
+		"05/03/2026;INV-1;510-01;9500,50;PUSAT\n"+
+		"05/03/2026;INV-1;110-300;-9500,50;PUSAT\n", string(file.Content))
+}
+
+func TestJournalExportService_Download_NotFound(t *testing.T) {
+	mockRepo, service := setupJournalExportTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("GetExport", ctx, uint(9)).Return(nil, gorm.ErrRecordNotFound).Once()
+
+	_, err := service.Download(ctx, 9)
+
+	assert.True(t, customErrors.Is(err, customErrors.ErrNotFound))
+}
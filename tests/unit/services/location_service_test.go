@@ -0,0 +1,140 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"pos-api/internal/models"
+	customErrors "pos-api/internal/pkg/errors"
+	"pos-api/internal/services"
+	"pos-api/tests/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func setupLocationTest(t *testing.T) (*mocks.LocationRepository, services.LocationService) {
+	mockRepo := mocks.NewLocationRepository(t)
+	return mockRepo, services.NewLocationService(mockRepo)
+}
+
+func TestLocationService_Create_Success(t *testing.T) {
+	mockRepo, service := setupLocationTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("Create", ctx, mock.MatchedBy(func(l *models.Location) bool {
+		return l.Code == "GUDANG" && l.Type == "store" && l.IsActive
+	})).Return(nil).Once()
+
+	location, err := service.Create(ctx, services.LocationRequest{Code: " gudang ", Name: "Gudang Belakang"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "GUDANG", location.Code)
+}
+
+func TestLocationService_Create_InvalidType(t *testing.T) {
+	_, service := setupLocationTest(t)
+
+	_, err := service.Create(context.Background(), services.LocationRequest{Code: "GD", Name: "Gudang", Type: "garage"})
+
+	assert.ErrorContains(t, err, "validation failed")
+}
+
+func TestLocationService_Delete_Default(t *testing.T) {
+	mockRepo, service := setupLocationTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("GetByID", ctx, uint(1)).Return(&models.Location{ID: 1, Code: "MAIN", IsDefault: true}, nil).Once()
+
+	err := service.Delete(ctx, 1)
+
+	assert.ErrorContains(t, err, "default location")
+}
+
+func TestLocationService_Delete_HasStock(t *testing.T) {
+	mockRepo, service := setupLocationTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("GetByID", ctx, uint(2)).Return(&models.Location{ID: 2, Code: "TOKO-1"}, nil).Once()
+	mockRepo.On("HasStock", ctx, uint(2)).Return(true, nil).Once()
+
+	err := service.Delete(ctx, 2)
+
+	assert.ErrorContains(t, err, "still has stock")
+}
+
+func TestLocationService_Delete_Success(t *testing.T) {
+	mockRepo, service := setupLocationTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("GetByID", ctx, uint(2)).Return(&models.Location{ID: 2, Code: "TOKO-1"}, nil).Once()
+	mockRepo.On("HasStock", ctx, uint(2)).Return(false, nil).Once()
+	mockRepo.On("Delete", ctx, uint(2)).Return(nil).Once()
+
+	assert.NoError(t, service.Delete(ctx, 2))
+}
+
+func TestLocationService_Update_CannotDeactivateDefault(t *testing.T) {
+	mockRepo, service := setupLocationTest(t)
+	ctx := context.Background()
+
+	inactive := false
+	mockRepo.On("GetByID", ctx, uint(1)).Return(&models.Location{ID: 1, Code: "MAIN", IsDefault: true, IsActive: true}, nil).Once()
+
+	_, err := service.Update(ctx, 1, services.LocationRequest{Code: "MAIN", Name: "Toko Utama", IsActive: &inactive})
+
+	assert.ErrorContains(t, err, "cannot be deactivated")
+}
+
+func TestLocationService_SetDefault_Inactive(t *testing.T) {
+	mockRepo, service := setupLocationTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("GetByID", ctx, uint(2)).Return(&models.Location{ID: 2, Code: "TOKO-1", IsActive: false}, nil).Once()
+
+	_, err := service.SetDefault(ctx, 2)
+
+	assert.ErrorContains(t, err, "inactive")
+}
+
+func TestLocationService_GetByID_NotFound(t *testing.T) {
+	mockRepo, service := setupLocationTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("GetByID", ctx, uint(9)).Return(nil, gorm.ErrRecordNotFound).Once()
+
+	_, err := service.GetByID(ctx, 9)
+
+	assert.True(t, errors.Is(err, customErrors.ErrNotFound))
+}
+
+func TestLocationService_CreateRegister_Success(t *testing.T) {
+	mockRepo, service := setupLocationTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("GetByID", ctx, uint(2)).Return(&models.Location{ID: 2, Code: "TOKO-1", IsActive: true}, nil).Once()
+	mockRepo.On("CreateRegister", ctx, mock.MatchedBy(func(r *models.Register) bool {
+		return r.Code == "KASIR-1" && r.LocationID == 2 && r.IsActive
+	})).Run(func(args mock.Arguments) {
+		args.Get(1).(*models.Register).ID = 4
+	}).Return(nil).Once()
+	mockRepo.On("GetRegisterByID", ctx, uint(4)).Return(&models.Register{ID: 4, Code: "KASIR-1", LocationID: 2}, nil).Once()
+
+	register, err := service.CreateRegister(ctx, services.RegisterRequest{Code: "kasir-1", LocationID: 2})
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(2), register.LocationID)
+}
+
+func TestLocationService_CreateRegister_InactiveLocation(t *testing.T) {
+	mockRepo, service := setupLocationTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("GetByID", ctx, uint(2)).Return(&models.Location{ID: 2, Code: "TOKO-1", IsActive: false}, nil).Once()
+
+	_, err := service.CreateRegister(ctx, services.RegisterRequest{Code: "KASIR-1", LocationID: 2})
+
+	assert.ErrorContains(t, err, "inactive")
+}
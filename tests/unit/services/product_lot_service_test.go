@@ -0,0 +1,105 @@
+package services_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"pos-api/internal/models"
+	customErrors "pos-api/internal/pkg/errors"
+	"pos-api/internal/services"
+	"pos-api/tests/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupProductLotTest(t *testing.T) (*mocks.ProductLotRepository, services.ProductLotService) {
+	mockRepo := mocks.NewProductLotRepository(t)
+	return mockRepo, services.NewProductLotService(mockRepo)
+}
+
+// daysFromToday returns the calendar day n days from today.
+func daysFromToday(n int) *time.Time {
+	now := time.Now()
+	d := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, n)
+	return &d
+}
+
+func TestProductLotService_GetExpiring_SplitsExpiredAndExpiring(t *testing.T) {
+	mockRepo, service := setupProductLotTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("GetExpiring", ctx, mock.AnythingOfType("time.Time"), uint(0)).Return([]models.ProductLot{
+		{ID: 1, LotNumber: "A", ExpiryDate: daysFromToday(-2), Quantity: 3, CostPrice: 1000},
+		{ID: 2, LotNumber: "B", ExpiryDate: daysFromToday(0), Quantity: 2, CostPrice: 1500},
+		{ID: 3, LotNumber: "C", ExpiryDate: daysFromToday(10), Quantity: 4, CostPrice: 2500},
+	}, nil).Once()
+
+	report, err := service.GetExpiring(ctx, 30, 0)
+
+	assert.NoError(t, err)
+	assert.Len(t, report.Items, 3)
+	assert.Equal(t, -2, report.Items[0].DaysLeft)
+	assert.True(t, report.Items[0].Expired)
+	// A lot can still be sold on its expiry date
+	assert.Equal(t, 0, report.Items[1].DaysLeft)
+	assert.False(t, report.Items[1].Expired)
+	assert.Equal(t, 10, report.Items[2].DaysLeft)
+	assert.Equal(t, 3000.0, report.ExpiredValue)
+	assert.Equal(t, 13000.0, report.ExpiringValue)
+}
+
+func TestProductLotService_GetExpiring_NegativeDays(t *testing.T) {
+	_, service := setupProductLotTest(t)
+
+	_, err := service.GetExpiring(context.Background(), -1, 0)
+
+	assert.Error(t, err)
+}
+
+func TestProductLotService_WriteOff_RefusesUnexpiredLot(t *testing.T) {
+	mockRepo, service := setupProductLotTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("GetByID", ctx, uint(2)).Return(&models.ProductLot{ID: 2, LotNumber: "B", ExpiryDate: daysFromToday(0), Quantity: 2}, nil).Once()
+
+	_, err := service.WriteOff(ctx, 2, services.WriteOffRequest{}, 1)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "has not expired yet")
+	mockRepo.AssertNotCalled(t, "WriteOff", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestProductLotService_WriteOff_Success(t *testing.T) {
+	mockRepo, service := setupProductLotTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("GetByID", ctx, uint(1)).Return(&models.ProductLot{ID: 1, LotNumber: "A", ExpiryDate: daysFromToday(-1), Quantity: 3}, nil).Once()
+	mockRepo.On("WriteOff", ctx, uint(1), uint(7), "spoiled").Return(&models.InventoryLog{ID: 9, Type: "out", Source: "expired", Quantity: 3}, nil).Once()
+
+	log, err := service.WriteOff(ctx, 1, services.WriteOffRequest{Notes: "spoiled"}, 7)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "expired", log.Source)
+}
+
+func TestProductLotService_WriteOffExpired_SkipsEmptiedLots(t *testing.T) {
+	mockRepo, service := setupProductLotTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("GetExpiring", ctx, mock.AnythingOfType("time.Time"), uint(2)).Return([]models.ProductLot{
+		{ID: 1, LotNumber: "A", ExpiryDate: daysFromToday(-3), Quantity: 3},
+		{ID: 4, LotNumber: "D", ExpiryDate: daysFromToday(-1), Quantity: 1},
+	}, nil).Once()
+	mockRepo.On("WriteOff", ctx, uint(1), uint(1), "").
+		Return(nil, fmt.Errorf("%w: lot A has no stock left", customErrors.ErrConflict)).Once()
+	mockRepo.On("WriteOff", ctx, uint(4), uint(1), "").Return(&models.InventoryLog{ID: 11, Quantity: 1}, nil).Once()
+
+	logs, err := service.WriteOffExpired(ctx, 2, services.WriteOffRequest{}, 1)
+
+	assert.NoError(t, err)
+	assert.Len(t, logs, 1)
+	assert.Equal(t, uint(11), logs[0].ID)
+}
@@ -0,0 +1,99 @@
+package services_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"pos-api/internal/models"
+	customErrors "pos-api/internal/pkg/errors"
+	"pos-api/internal/services"
+	"pos-api/tests/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupProductSerialTest(t *testing.T) (*mocks.ProductSerialRepository, *mocks.ProductRepository, *mocks.LocationRepository, services.ProductSerialService) {
+	mockRepo := mocks.NewProductSerialRepository(t)
+	mockProductRepo := mocks.NewProductRepository(t)
+	mockLocationRepo := mocks.NewLocationRepository(t)
+	return mockRepo, mockProductRepo, mockLocationRepo, services.NewProductSerialService(mockRepo, mockProductRepo, mockLocationRepo)
+}
+
+func TestProductSerialService_Lookup_ReturnsSale(t *testing.T) {
+	mockRepo, _, _, service := setupProductSerialTest(t)
+	ctx := context.Background()
+
+	trxID := uint(42)
+	mockRepo.On("FindBySerial", ctx, "PB-001").Return([]models.ProductSerial{{
+		ID: 1, ProductID: 3, SerialNumber: "PB-001", Status: models.SerialSold,
+		TransactionID: &trxID,
+		Transaction:   &models.Transaction{ID: trxID, TransactionCode: "INV-1", CustomerName: "Budi"},
+	}}, nil).Once()
+
+	units, err := service.Lookup(ctx, " PB-001 ")
+
+	assert.NoError(t, err)
+	assert.Len(t, units, 1)
+	assert.Equal(t, "Budi", units[0].Transaction.CustomerName)
+}
+
+func TestProductSerialService_Lookup_NotFound(t *testing.T) {
+	mockRepo, _, _, service := setupProductSerialTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("FindBySerial", ctx, "NOPE").Return([]models.ProductSerial{}, nil).Once()
+
+	_, err := service.Lookup(ctx, "NOPE")
+
+	assert.ErrorIs(t, err, customErrors.ErrNotFound)
+}
+
+func TestProductSerialService_Register_Success(t *testing.T) {
+	mockRepo, mockProductRepo, mockLocationRepo, service := setupProductSerialTest(t)
+	ctx := context.Background()
+
+	mockProductRepo.On("GetProductByID", ctx, uint(3)).Return(&models.Product{ID: 3, Name: "Powerbank", Stock: 5, Serialized: true}, nil).Once()
+	mockLocationRepo.On("GetDefault", ctx).Return(&models.Location{ID: 1, Code: "MAIN", IsDefault: true, IsActive: true}, nil).Once()
+	mockRepo.On("Register", ctx, uint(3), uint(1), []string{"PB-001", "PB-002"}).
+		Return([]models.ProductSerial{{ID: 1}, {ID: 2}}, nil).Once()
+
+	units, err := service.Register(ctx, services.RegisterSerialsRequest{
+		ProductID:     3,
+		SerialNumbers: []string{"PB-001 ", " PB-002"},
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, units, 2)
+}
+
+func TestProductSerialService_Register_UnserializedProduct(t *testing.T) {
+	mockRepo, mockProductRepo, _, service := setupProductSerialTest(t)
+	ctx := context.Background()
+
+	mockProductRepo.On("GetProductByID", ctx, uint(1)).Return(&models.Product{ID: 1, Name: "Kabel Data", Stock: 50}, nil).Once()
+
+	_, err := service.Register(ctx, services.RegisterSerialsRequest{ProductID: 1, SerialNumbers: []string{"KD-1"}})
+
+	assert.Error(t, err)
+	mockRepo.AssertNotCalled(t, "Register", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestProductSerialService_Register_MoreThanStock(t *testing.T) {
+	mockRepo, mockProductRepo, mockLocationRepo, service := setupProductSerialTest(t)
+	ctx := context.Background()
+
+	mockProductRepo.On("GetProductByID", ctx, uint(3)).Return(&models.Product{ID: 3, Name: "Powerbank", Stock: 1, Serialized: true}, nil).Once()
+	mockLocationRepo.On("GetByID", ctx, uint(2)).Return(&models.Location{ID: 2, Code: "TOKO", IsActive: true}, nil).Once()
+	mockRepo.On("Register", ctx, uint(3), uint(2), []string{"PB-001", "PB-002"}).
+		Return(nil, fmt.Errorf("%w: location 2 holds 1 units and 0 already have serial numbers", customErrors.ErrConflict)).Once()
+
+	_, err := service.Register(ctx, services.RegisterSerialsRequest{
+		ProductID:     3,
+		LocationID:    2,
+		SerialNumbers: []string{"PB-001", "PB-002"},
+	})
+
+	assert.ErrorIs(t, err, customErrors.ErrConflict)
+}
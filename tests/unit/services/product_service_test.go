@@ -6,6 +6,8 @@ import (
 	"testing"
 
 	"pos-api/internal/models"
+	"pos-api/internal/pkg/scale"
+	"pos-api/internal/repositories"
 	"pos-api/internal/services"
 	"pos-api/tests/mocks"
 
@@ -16,7 +18,8 @@ import (
 
 func setupProductTest(t *testing.T) (*mocks.ProductRepository, services.ProductService) {
 	mockRepo := mocks.NewProductRepository(t)
-	service := services.NewProductService(mockRepo)
+	parser := scale.NewParser(nil)
+	service := services.NewProductService(mockRepo, services.NewBarcodeAllocator(mockRepo, 20, 27), services.NewBarcodeScanner(mockRepo, parser))
 	return mockRepo, service
 }
 
@@ -26,7 +29,9 @@ func TestProductService_Create_Success(t *testing.T) {
 	mockRepo, service := setupProductTest(t)
 	ctx := context.Background()
 
-	mockRepo.On("CreateProduct", ctx, mock.AnythingOfType("*models.Product")).Return(nil).Once()
+	mockRepo.On("NextBarcodeSequence", ctx).Return(int64(1), nil).Once()
+	mockRepo.On("BarcodeExists", ctx, "2000000000015").Return(false, nil).Once()
+	mockRepo.On("CreateProduct", ctx, mock.AnythingOfType("*models.Product"), uint(1)).Return(nil).Once()
 
 	product, err := service.CreateProduct(ctx, services.ProductRequest{
 		Name:       "Mie Goreng",
@@ -34,20 +39,21 @@ func TestProductService_Create_Success(t *testing.T) {
 		Cost:       3000,
 		Stock:      100,
 		CategoryID: 1,
-	})
+	}, 1)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, product)
 	assert.Equal(t, "Mie Goreng", product.Name)
-	assert.NotEmpty(t, product.SKU)     // Auto-generated
-	assert.NotEmpty(t, product.Barcode) // Auto-generated
+	assert.NotEmpty(t, product.SKU)                   // Auto-generated
+	assert.Equal(t, "2000000000015", product.Barcode) // Auto-allocated internal EAN-13
+	assert.Equal(t, float64(10), product.MinStock)    // Default low-stock level
 }
 
 func TestProductService_Create_WithCustomSKUAndBarcode(t *testing.T) {
 	mockRepo, service := setupProductTest(t)
 	ctx := context.Background()
 
-	mockRepo.On("CreateProduct", ctx, mock.AnythingOfType("*models.Product")).Return(nil).Once()
+	mockRepo.On("CreateProduct", ctx, mock.AnythingOfType("*models.Product"), uint(1)).Return(nil).Once()
 
 	product, err := service.CreateProduct(ctx, services.ProductRequest{
 		Name:       "Indomie",
@@ -57,7 +63,7 @@ func TestProductService_Create_WithCustomSKUAndBarcode(t *testing.T) {
 		Cost:       2000,
 		Stock:      50,
 		CategoryID: 1,
-	})
+	}, 1)
 
 	assert.NoError(t, err)
 	assert.Equal(t, "SKU-CUSTOM", product.SKU)
@@ -73,7 +79,7 @@ func TestProductService_Create_ValidationFailed_EmptyName(t *testing.T) {
 		Price:      5000,
 		Cost:       3000,
 		CategoryID: 1,
-	})
+	}, 1)
 
 	assert.Error(t, err)
 	assert.Nil(t, product)
@@ -89,7 +95,7 @@ func TestProductService_Create_ValidationFailed_ZeroPrice(t *testing.T) {
 		Price:      0,
 		Cost:       1000,
 		CategoryID: 1,
-	})
+	}, 1)
 
 	assert.Error(t, err)
 	assert.Nil(t, product)
@@ -103,7 +109,7 @@ func TestProductService_Create_ValidationFailed_NoCategoryID(t *testing.T) {
 		Name:  "Test Product",
 		Price: 5000,
 		Cost:  3000,
-	})
+	}, 1)
 
 	assert.Error(t, err)
 	assert.Nil(t, product)
@@ -113,7 +119,9 @@ func TestProductService_Create_DuplicateSKU(t *testing.T) {
 	mockRepo, service := setupProductTest(t)
 	ctx := context.Background()
 
-	mockRepo.On("CreateProduct", ctx, mock.AnythingOfType("*models.Product")).
+	mockRepo.On("NextBarcodeSequence", ctx).Return(int64(2), nil).Once()
+	mockRepo.On("BarcodeExists", ctx, mock.AnythingOfType("string")).Return(false, nil).Once()
+	mockRepo.On("CreateProduct", ctx, mock.AnythingOfType("*models.Product"), uint(1)).
 		Return(errors.New("unique constraint violation")).Once()
 
 	product, err := service.CreateProduct(ctx, services.ProductRequest{
@@ -123,7 +131,99 @@ func TestProductService_Create_DuplicateSKU(t *testing.T) {
 		Cost:       3000,
 		Stock:      10,
 		CategoryID: 1,
-	})
+	}, 1)
+
+	assert.Error(t, err)
+	assert.Nil(t, product)
+}
+
+func TestProductService_Create_InvalidEANCheckDigit(t *testing.T) {
+	_, service := setupProductTest(t)
+	ctx := context.Background()
+
+	product, err := service.CreateProduct(ctx, services.ProductRequest{
+		Name:       "Indomie",
+		Barcode:    "8991234567893", // Correct check digit is 1
+		Price:      3000,
+		Cost:       2000,
+		CategoryID: 1,
+	}, 1)
+
+	assert.Error(t, err)
+	assert.Nil(t, product)
+	assert.Contains(t, err.Error(), "check digit")
+}
+
+func TestProductService_Create_PLURequiresSoldByWeight(t *testing.T) {
+	_, service := setupProductTest(t)
+	ctx := context.Background()
+
+	product, err := service.CreateProduct(ctx, services.ProductRequest{
+		Name:       "Beras 5kg",
+		PLU:        "101",
+		Price:      70000,
+		Cost:       60000,
+		CategoryID: 1,
+	}, 1)
+
+	assert.Error(t, err)
+	assert.Nil(t, product)
+	assert.Contains(t, err.Error(), "PLU")
+}
+
+func TestProductService_Create_SoldByWeight(t *testing.T) {
+	mockRepo, service := setupProductTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("CreateProduct", ctx, mock.MatchedBy(func(p *models.Product) bool {
+		return p.PLU == "101" && p.SoldByWeight && p.Stock == 12.5
+	}), uint(1)).Return(nil).Once()
+
+	product, err := service.CreateProduct(ctx, services.ProductRequest{
+		Name:         "Daging Sapi",
+		Barcode:      "8991234567891",
+		PLU:          "00101",
+		SoldByWeight: true,
+		Price:        150000,
+		Cost:         120000,
+		Stock:        12.5,
+		CategoryID:   1,
+	}, 1)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, product)
+}
+
+func TestProductService_Create_SerializedNeedsZeroStock(t *testing.T) {
+	_, service := setupProductTest(t)
+	ctx := context.Background()
+
+	product, err := service.CreateProduct(ctx, services.ProductRequest{
+		Name:       "Powerbank 10000mAh",
+		Serialized: true,
+		Price:      200000,
+		Cost:       150000,
+		Stock:      5,
+		CategoryID: 1,
+	}, 1)
+
+	assert.Error(t, err)
+	assert.Nil(t, product)
+	assert.Contains(t, err.Error(), "nomor seri")
+}
+
+func TestProductService_Create_SerializedNotSoldByWeight(t *testing.T) {
+	_, service := setupProductTest(t)
+	ctx := context.Background()
+
+	product, err := service.CreateProduct(ctx, services.ProductRequest{
+		Name:         "Daging Sapi",
+		SoldByWeight: true,
+		Serialized:   true,
+		Price:        150000,
+		Cost:         120000,
+		CategoryID:   1,
+	}, 1)
 
 	assert.Error(t, err)
 	assert.Nil(t, product)
@@ -208,22 +308,77 @@ func TestProductService_GetLowStock_DefaultThreshold(t *testing.T) {
 	mockRepo, service := setupProductTest(t)
 	ctx := context.Background()
 
-	mockRepo.On("GetLowStockProducts", ctx, 10).Return([]models.Product{}, nil).Once()
+	mockRepo.On("GetLowStockProducts", ctx, 0).Return([]models.Product{}, nil).Once()
 
-	// threshold=0 should default to 10
+	// threshold=0 uses each product's own min_stock
 	_, err := service.GetLowStockProducts(ctx, 0)
 
 	assert.NoError(t, err)
 }
 
+// --- GetReorderSuggestions ---
+
+func TestProductService_GetReorderSuggestions_GroupsBySupplier(t *testing.T) {
+	mockRepo, service := setupProductTest(t)
+	ctx := context.Background()
+
+	supplierA, supplierB := uint(1), uint(2)
+	candidates := []repositories.ReorderCandidate{
+		// 60 sold in 30 days = 2/day, lead 5 days: reorder point 10 + 10 = 20,
+		// no max so target 20 + 60 = 80, minus stock 5 and 10 on order
+		{ProductID: 1, Name: "Kopi", Stock: 5, OnOrder: 10, MinStock: 10, LeadTimeDays: 5, QuantitySold: 60, Cost: 1000, SupplierID: &supplierB, SupplierName: "Zeta"},
+		// Above its reorder point
+		{ProductID: 2, Name: "Teh", Stock: 50, MinStock: 10, QuantitySold: 30, SupplierID: &supplierB, SupplierName: "Zeta"},
+		// Filled up to max_stock
+		{ProductID: 3, Name: "Gula", Stock: 3, MinStock: 5, MaxStock: 40, Cost: 500, SupplierID: &supplierA, SupplierName: "Alfa"},
+		// No supplier known
+		{ProductID: 4, Name: "Beras", SoldByWeight: true, Stock: 1.5, MinStock: 2, QuantitySold: 15, Cost: 12000},
+	}
+	mockRepo.On("GetReorderCandidates", ctx, mock.AnythingOfType("time.Time")).Return(candidates, nil).Once()
+
+	result, err := service.GetReorderSuggestions(ctx, 30)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 30, result.Days)
+	assert.Equal(t, 3, result.TotalItems)
+	if assert.Len(t, result.Groups, 3) {
+		assert.Equal(t, "Alfa", result.Groups[0].SupplierName)
+		assert.Equal(t, float64(37), result.Groups[0].Items[0].SuggestedQuantity)
+
+		assert.Equal(t, "Zeta", result.Groups[1].SupplierName)
+		if assert.Len(t, result.Groups[1].Items, 1) {
+			kopi := result.Groups[1].Items[0]
+			assert.Equal(t, float64(2), kopi.DailySales)
+			assert.Equal(t, float64(20), kopi.ReorderPoint)
+			assert.Equal(t, float64(65), kopi.SuggestedQuantity)
+			assert.Equal(t, float64(65000), kopi.EstimatedCost)
+		}
+
+		assert.Nil(t, result.Groups[2].SupplierID)
+		// 0.5 kg/day: target 2 + 15 = 17 kg, minus 1.5 kg in stock
+		assert.Equal(t, 15.5, result.Groups[2].Items[0].SuggestedQuantity)
+	}
+	assert.Equal(t, float64(37*500+65000+15.5*12000), result.EstimatedCost)
+}
+
+func TestProductService_GetReorderSuggestions_InvalidPeriod(t *testing.T) {
+	mockRepo, service := setupProductTest(t)
+	ctx := context.Background()
+
+	_, err := service.GetReorderSuggestions(ctx, 400)
+
+	assert.Error(t, err)
+	mockRepo.AssertNotCalled(t, "GetReorderCandidates", mock.Anything, mock.Anything)
+}
+
 // --- UpdateProduct ---
 
 func TestProductService_Update_Success(t *testing.T) {
 	mockRepo, service := setupProductTest(t)
 	ctx := context.Background()
 
-	existing := &models.Product{ID: 1, Name: "Old Name", SKU: "SKU-OLD", Barcode: "111", Price: 1000, CategoryID: 1}
-	updated := &models.Product{ID: 1, Name: "New Name", SKU: "SKU-OLD", Barcode: "111", Price: 7000, CategoryID: 2}
+	existing := &models.Product{ID: 1, Name: "Old Name", SKU: "SKU-OLD", Barcode: "111", Price: 1000, Stock: 50, CategoryID: 1}
+	updated := &models.Product{ID: 1, Name: "New Name", SKU: "SKU-OLD", Barcode: "111", Price: 7000, Stock: 50, CategoryID: 2}
 
 	mockRepo.On("GetProductByID", ctx, uint(1)).Return(existing, nil).Once()
 	mockRepo.On("UpdateProduct", ctx, mock.AnythingOfType("*models.Product")).Return(nil).Once()
@@ -241,6 +396,126 @@ func TestProductService_Update_Success(t *testing.T) {
 	assert.Equal(t, "New Name", product.Name)
 }
 
+func TestProductService_Update_StockUnchangeable(t *testing.T) {
+	mockRepo, service := setupProductTest(t)
+	ctx := context.Background()
+
+	existing := &models.Product{ID: 1, Name: "Kopi", Barcode: "111", Price: 1000, Stock: 20, CategoryID: 1}
+	mockRepo.On("GetProductByID", ctx, uint(1)).Return(existing, nil).Once()
+
+	_, err := service.UpdateProduct(ctx, 1, services.ProductRequest{
+		Name:       "Kopi",
+		Price:      1000,
+		Cost:       800,
+		Stock:      35,
+		CategoryID: 1,
+	})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "inventori")
+	mockRepo.AssertNotCalled(t, "UpdateProduct", mock.Anything, mock.Anything)
+}
+
+func TestProductService_Update_KeepsMinStockWhenOmitted(t *testing.T) {
+	mockRepo, service := setupProductTest(t)
+	ctx := context.Background()
+
+	existing := &models.Product{ID: 1, Name: "Kopi", Barcode: "111", Price: 1000, CategoryID: 1, MinStock: 25}
+	mockRepo.On("GetProductByID", ctx, uint(1)).Return(existing, nil).Twice()
+	mockRepo.On("UpdateProduct", ctx, mock.MatchedBy(func(p *models.Product) bool {
+		return p.MinStock == 25 && p.MaxStock == 100 && p.LeadTimeDays == 7
+	})).Return(nil).Once()
+
+	_, err := service.UpdateProduct(ctx, 1, services.ProductRequest{
+		Name:         "Kopi",
+		Price:        1000,
+		Cost:         800,
+		CategoryID:   1,
+		MaxStock:     100,
+		LeadTimeDays: 7,
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestProductService_Update_MaxStockBelowMin(t *testing.T) {
+	mockRepo, service := setupProductTest(t)
+	ctx := context.Background()
+
+	existing := &models.Product{ID: 1, Name: "Kopi", Barcode: "111", Price: 1000, CategoryID: 1, MinStock: 10}
+	mockRepo.On("GetProductByID", ctx, uint(1)).Return(existing, nil).Once()
+
+	minStock := float64(20)
+	_, err := service.UpdateProduct(ctx, 1, services.ProductRequest{
+		Name:       "Kopi",
+		Price:      1000,
+		Cost:       800,
+		CategoryID: 1,
+		MinStock:   &minStock,
+		MaxStock:   15,
+	})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "max_stock")
+	mockRepo.AssertNotCalled(t, "UpdateProduct", mock.Anything, mock.Anything)
+}
+
+func TestProductService_Update_SerializedStockUnchangeable(t *testing.T) {
+	mockRepo, service := setupProductTest(t)
+	ctx := context.Background()
+
+	existing := &models.Product{ID: 1, Name: "Powerbank", Barcode: "111", Price: 200000, Stock: 3, Serialized: true, CategoryID: 1}
+	mockRepo.On("GetProductByID", ctx, uint(1)).Return(existing, nil).Once()
+
+	_, err := service.UpdateProduct(ctx, 1, services.ProductRequest{
+		Name:       "Powerbank",
+		Serialized: true,
+		Price:      210000,
+		Cost:       150000,
+		Stock:      5,
+		CategoryID: 1,
+	})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "nomor seri")
+	mockRepo.AssertNotCalled(t, "UpdateProduct", mock.Anything, mock.Anything)
+}
+
+func TestProductService_Update_InvalidNegativeStockPolicy(t *testing.T) {
+	mockRepo, service := setupProductTest(t)
+	ctx := context.Background()
+
+	_, err := service.UpdateProduct(ctx, 1, services.ProductRequest{
+		Name:                "Kopi",
+		Price:               1000,
+		Cost:                800,
+		CategoryID:          1,
+		NegativeStockPolicy: "ignore",
+	})
+
+	assert.ErrorIs(t, err, services.ErrInvalidNegativeStockPolicy)
+	mockRepo.AssertNotCalled(t, "UpdateProduct", mock.Anything, mock.Anything)
+}
+
+func TestProductService_Update_SerializedMustBlockNegativeStock(t *testing.T) {
+	mockRepo, service := setupProductTest(t)
+	ctx := context.Background()
+
+	_, err := service.UpdateProduct(ctx, 1, services.ProductRequest{
+		Name:                "Powerbank",
+		Serialized:          true,
+		Price:               200000,
+		Cost:                150000,
+		Stock:               3,
+		CategoryID:          1,
+		NegativeStockPolicy: models.NegativeStockWarn,
+	})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "nomor seri")
+	mockRepo.AssertNotCalled(t, "UpdateProduct", mock.Anything, mock.Anything)
+}
+
 func TestProductService_Update_NotFound(t *testing.T) {
 	mockRepo, service := setupProductTest(t)
 	ctx := context.Background()
@@ -0,0 +1,256 @@
+package services_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"pos-api/internal/models"
+	"pos-api/internal/repositories"
+	"pos-api/internal/services"
+	"pos-api/tests/mocks"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type purchaseOrderMocks struct {
+	po       *mocks.PurchaseOrderRepository
+	supplier *mocks.SupplierRepository
+	product  *mocks.ProductRepository
+	logs     *mocks.InventoryLogRepository
+	location *mocks.LocationRepository
+}
+
+func setupPurchaseOrderTest(t *testing.T) (purchaseOrderMocks, services.PurchaseOrderService) {
+	m := purchaseOrderMocks{
+		po:       mocks.NewPurchaseOrderRepository(t),
+		supplier: mocks.NewSupplierRepository(t),
+		product:  mocks.NewProductRepository(t),
+		logs:     mocks.NewInventoryLogRepository(t),
+		location: mocks.NewLocationRepository(t),
+	}
+	inventory := services.NewInventoryLogService(m.logs, m.product, m.location)
+	return m, services.NewPurchaseOrderService(m.po, m.supplier, m.product, inventory)
+}
+
+var warehouseID = uint(2)
+
+func sentPurchaseOrder() *models.PurchaseOrder {
+	return &models.PurchaseOrder{
+		ID:         1,
+		PONumber:   "PO-1",
+		LocationID: &warehouseID,
+		Status:     models.PurchaseOrderSent,
+		Items: []models.PurchaseOrderItem{
+			{ProductID: 1, Quantity: 10, ReceivedQuantity: 4, UnitCost: 5000, Product: models.Product{ID: 1, Name: "Beras"}},
+		},
+	}
+}
+
+func TestPurchaseOrderService_Create_Success(t *testing.T) {
+	m, service := setupPurchaseOrderTest(t)
+	ctx := context.Background()
+
+	m.supplier.On("GetByID", ctx, uint(1)).Return(&models.Supplier{ID: 1, Name: "PT Sumber", IsActive: true, PaymentTermDays: 30}, nil).Once()
+	m.product.On("GetProductByID", ctx, uint(1)).Return(&models.Product{ID: 1, Name: "Beras", Cost: 5000}, nil).Once()
+	m.product.On("GetProductByID", ctx, uint(2)).Return(&models.Product{ID: 2, Name: "Daging", Cost: 100000, SoldByWeight: true}, nil).Once()
+
+	var created *models.PurchaseOrder
+	m.po.On("Create", ctx, mock.AnythingOfType("*models.PurchaseOrder")).Run(func(args mock.Arguments) {
+		created = args.Get(1).(*models.PurchaseOrder)
+		created.ID = 5
+	}).Return(nil).Once()
+	m.po.On("GetByID", ctx, uint(5)).Return(func(context.Context, uint) *models.PurchaseOrder { return created }, nil).Once()
+
+	po, err := service.Create(ctx, services.PurchaseOrderRequest{
+		SupplierID:   1,
+		ExpectedDate: "2026-02-16",
+		Items: []services.PurchaseOrderItemRequest{
+			{ProductID: 1, Quantity: 10},
+			{ProductID: 2, Quantity: 2.5, UnitCost: 95000},
+		},
+	}, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.PurchaseOrderDraft, po.Status)
+	assert.Equal(t, 5000.0, po.Items[0].UnitCost)
+	assert.Equal(t, 287500.0, po.TotalAmount)
+	assert.NotEmpty(t, po.PONumber)
+	assert.NotNil(t, po.ExpectedDate)
+	assert.Equal(t, 30, po.PaymentTermDays) // Supplier default term
+}
+
+func TestPurchaseOrderService_Create_InactiveSupplier(t *testing.T) {
+	m, service := setupPurchaseOrderTest(t)
+	ctx := context.Background()
+
+	m.supplier.On("GetByID", ctx, uint(1)).Return(&models.Supplier{ID: 1, Name: "PT Lama", IsActive: false}, nil).Once()
+
+	_, err := service.Create(ctx, services.PurchaseOrderRequest{
+		SupplierID: 1,
+		Items:      []services.PurchaseOrderItemRequest{{ProductID: 1, Quantity: 1}},
+	}, 1)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "inactive")
+}
+
+func TestPurchaseOrderService_Create_DuplicateProduct(t *testing.T) {
+	m, service := setupPurchaseOrderTest(t)
+	ctx := context.Background()
+
+	m.supplier.On("GetByID", ctx, uint(1)).Return(&models.Supplier{ID: 1, IsActive: true}, nil).Once()
+	m.product.On("GetProductByID", ctx, uint(1)).Return(&models.Product{ID: 1, Name: "Beras"}, nil).Once()
+
+	_, err := service.Create(ctx, services.PurchaseOrderRequest{
+		SupplierID: 1,
+		Items: []services.PurchaseOrderItemRequest{
+			{ProductID: 1, Quantity: 1},
+			{ProductID: 1, Quantity: 2},
+		},
+	}, 1)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "more than once")
+}
+
+func TestPurchaseOrderService_Update_NotDraft(t *testing.T) {
+	m, service := setupPurchaseOrderTest(t)
+	ctx := context.Background()
+
+	m.po.On("GetByID", ctx, uint(1)).Return(sentPurchaseOrder(), nil).Once()
+
+	_, err := service.Update(ctx, 1, services.PurchaseOrderRequest{
+		SupplierID: 1,
+		Items:      []services.PurchaseOrderItemRequest{{ProductID: 1, Quantity: 1}},
+	})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "only drafts")
+}
+
+func TestPurchaseOrderService_Send_Success(t *testing.T) {
+	m, service := setupPurchaseOrderTest(t)
+	ctx := context.Background()
+
+	m.po.On("GetByID", ctx, uint(1)).Return(&models.PurchaseOrder{ID: 1, Status: models.PurchaseOrderDraft}, nil).Once()
+	m.po.On("UpdateStatus", ctx, uint(1), models.PurchaseOrderSent, models.PurchaseOrderDraft).Return(true, nil).Once()
+
+	po, err := service.Send(ctx, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.PurchaseOrderSent, po.Status)
+}
+
+func TestPurchaseOrderService_Cancel_AfterReceipt(t *testing.T) {
+	m, service := setupPurchaseOrderTest(t)
+	ctx := context.Background()
+
+	po := sentPurchaseOrder()
+	po.Status = models.PurchaseOrderPartiallyReceived
+	m.po.On("GetByID", ctx, uint(1)).Return(po, nil).Once()
+
+	_, err := service.Cancel(ctx, 1)
+
+	assert.Error(t, err)
+}
+
+func TestPurchaseOrderService_Receive_Success(t *testing.T) {
+	m, service := setupPurchaseOrderTest(t)
+	ctx := context.Background()
+
+	m.po.On("GetByID", ctx, uint(1)).Return(sentPurchaseOrder(), nil).Twice()
+	m.product.On("GetProductByID", ctx, uint(1)).Return(&models.Product{ID: 1, Name: "Beras", Stock: 9, Cost: 5000}, nil).Once()
+	m.location.On("GetByID", ctx, warehouseID).Return(&models.Location{ID: warehouseID, Code: "GUDANG", IsActive: true}, nil).Once()
+	m.location.On("GetStockLevel", ctx, uint(1), warehouseID).Return(2.0, nil).Once()
+
+	var booked *models.InventoryLog
+	m.logs.On("ProcessReceipt", ctx, uint(1), mock.AnythingOfType("[]repositories.StockAdjustment")).
+		Run(func(args mock.Arguments) {
+			adjustments := args.Get(2).([]repositories.StockAdjustment)
+			assert.Len(t, adjustments, 1)
+			booked = adjustments[0].Log
+		}).
+		Return(nil).Once()
+
+	_, err := service.Receive(ctx, 1, services.ReceivePurchaseOrderRequest{
+		Items: []services.ReceiveItemRequest{{ProductID: 1, Quantity: 6, UnitCost: 5200}},
+	}, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "in", booked.Type)
+	assert.Equal(t, "purchase", booked.Source)
+	assert.Equal(t, 6.0, booked.Quantity)
+	assert.Equal(t, 5200.0, booked.CostPrice)
+	assert.Equal(t, 8.0, booked.StockAfter)
+	assert.Equal(t, warehouseID, booked.LocationID)
+	if assert.NotNil(t, booked.PurchaseOrderID) {
+		assert.Equal(t, uint(1), *booked.PurchaseOrderID)
+	}
+	assert.Contains(t, booked.Notes, "PO-1")
+}
+
+func TestPurchaseOrderService_Receive_LineFailsBooksNothing(t *testing.T) {
+	m, service := setupPurchaseOrderTest(t)
+	ctx := context.Background()
+
+	po := sentPurchaseOrder()
+	po.Items = append(po.Items, models.PurchaseOrderItem{ProductID: 2, Quantity: 5, UnitCost: 8000, Product: models.Product{ID: 2, Name: "Gula"}})
+	m.po.On("GetByID", ctx, uint(1)).Return(po, nil).Once()
+	m.product.On("GetProductByID", ctx, uint(1)).Return(&models.Product{ID: 1, Name: "Beras"}, nil).Once()
+	m.product.On("GetProductByID", ctx, uint(2)).Return(&models.Product{ID: 2, Name: "Gula"}, nil).Once()
+	m.location.On("GetByID", ctx, warehouseID).Return(&models.Location{ID: warehouseID, IsActive: true}, nil).Twice()
+	m.location.On("GetStockLevel", ctx, mock.Anything, warehouseID).Return(0.0, nil).Twice()
+	m.logs.On("ProcessReceipt", ctx, uint(1), mock.Anything).
+		Return(fmt.Errorf("%w: purchase order is 'cancelled', only sent orders can be received", customErrors.ErrConflict)).Once()
+
+	_, err := service.Receive(ctx, 1, services.ReceivePurchaseOrderRequest{
+		Items: []services.ReceiveItemRequest{{ProductID: 1, Quantity: 6}, {ProductID: 2, Quantity: 5}},
+	}, 1)
+
+	assert.True(t, customErrors.Is(err, customErrors.ErrConflict))
+	m.logs.AssertNotCalled(t, "ProcessAdjustment", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPurchaseOrderService_Cancel_ChangedMeanwhile(t *testing.T) {
+	m, service := setupPurchaseOrderTest(t)
+	ctx := context.Background()
+
+	m.po.On("GetByID", ctx, uint(1)).Return(sentPurchaseOrder(), nil).Once()
+	m.po.On("UpdateStatus", ctx, uint(1), models.PurchaseOrderCancelled, models.PurchaseOrderDraft, models.PurchaseOrderSent).Return(false, nil).Once()
+
+	_, err := service.Cancel(ctx, 1)
+
+	assert.True(t, customErrors.Is(err, customErrors.ErrConflict))
+}
+
+func TestPurchaseOrderService_Receive_ExceedsOutstanding(t *testing.T) {
+	m, service := setupPurchaseOrderTest(t)
+	ctx := context.Background()
+
+	m.po.On("GetByID", ctx, uint(1)).Return(sentPurchaseOrder(), nil).Once()
+
+	_, err := service.Receive(ctx, 1, services.ReceivePurchaseOrderRequest{
+		Items: []services.ReceiveItemRequest{{ProductID: 1, Quantity: 7}},
+	}, 1)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "outstanding")
+}
+
+func TestPurchaseOrderService_Receive_Draft(t *testing.T) {
+	m, service := setupPurchaseOrderTest(t)
+	ctx := context.Background()
+
+	m.po.On("GetByID", ctx, uint(1)).Return(&models.PurchaseOrder{ID: 1, Status: models.PurchaseOrderDraft}, nil).Once()
+
+	_, err := service.Receive(ctx, 1, services.ReceivePurchaseOrderRequest{
+		Items: []services.ReceiveItemRequest{{ProductID: 1, Quantity: 1}},
+	}, 1)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "only sent orders")
+}
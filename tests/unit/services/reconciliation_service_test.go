@@ -0,0 +1,356 @@
+package services_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"pos-api/internal/models"
+	"pos-api/internal/services"
+	"pos-api/tests/mocks"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func setupReconciliationTest(t *testing.T) (*mocks.ReconciliationRepository, *mocks.PaymentMethodRepository, services.ReconciliationService) {
+	mockRepo := mocks.NewReconciliationRepository(t)
+	mockPMRepo := mocks.NewPaymentMethodRepository(t)
+	return mockRepo, mockPMRepo, services.NewReconciliationService(mockRepo, mockPMRepo)
+}
+
+var qris = &models.PaymentMethod{ID: 3, Name: "QRIS"}
+
+var qrisMapping = models.StatementMapping{
+	Date:      "Settlement Date",
+	Amount:    "Gross",
+	Fee:       "MDR",
+	Reference: "RRN",
+}
+
+// expectImport stubs the lookups of an import with no earlier lines and the
+// given unsettled sales, and captures the saved statement.
+func expectImport(mockRepo *mocks.ReconciliationRepository, sales []models.Transaction) *models.BankStatement {
+	saved := &models.BankStatement{}
+	mockRepo.On("ImportedFingerprints", mock.Anything, mock.Anything).Return(map[string]bool{}, nil).Once()
+	mockRepo.On("GetUnsettled", mock.Anything, "QRIS", mock.Anything, mock.Anything).Return(sales, nil).Once()
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.BankStatement")).
+		Run(func(args mock.Arguments) { *saved = *args.Get(1).(*models.BankStatement) }).
+		Return(nil).Once()
+	return saved
+}
+
+// --- Import ---
+
+func TestReconciliationService_Import_MatchesAndTotals(t *testing.T) {
+	mockRepo, mockPMRepo, service := setupReconciliationTest(t)
+	ctx := context.Background()
+
+	mockPMRepo.On("GetByID", ctx, uint(3)).Return(qris, nil).Once()
+	saved := expectImport(mockRepo, []models.Transaction{
+		{ID: 10, GrandTotal: 50000, PaymentMethod: "QRIS", CreatedAt: time.Date(2026, 3, 1, 19, 30, 0, 0, time.UTC)},
+		{ID: 11, GrandTotal: 20000, PaymentMethod: "QRIS", CreatedAt: time.Date(2026, 3, 1, 20, 0, 0, 0, time.UTC)},
+	})
+
+	csv := "RRN,Settlement Date,Gross,MDR\n" +
+		"A1,2026-03-02,\"50,000\",350\n" +
+		"A2,2026-03-02,75000,525\n"
+	result, err := service.Import(ctx, services.StatementImport{
+		PaymentMethodID: 3,
+		FileName:        "../qris-march.csv",
+		Content:         strings.NewReader(csv),
+		Mapping:         &qrisMapping,
+	}, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.Imported)
+	assert.Equal(t, 1, result.Matched)
+	assert.Equal(t, 1, result.Unmatched)
+	assert.Equal(t, 875.0, result.TotalFee)
+
+	assert.Equal(t, "qris-march.csv", saved.FileName)
+	assert.Equal(t, date(2026, 3, 2), saved.StartDate)
+	assert.Equal(t, 125000.0, saved.TotalAmount)
+	assert.Equal(t, 124125.0, saved.TotalNet)
+	if assert.Len(t, saved.Lines, 2) {
+		assert.Equal(t, models.StatementLineMatched, saved.Lines[0].Status)
+		assert.Equal(t, uint(10), *saved.Lines[0].TransactionID)
+		assert.Equal(t, 49650.0, saved.Lines[0].NetAmount)
+		assert.Equal(t, 2, saved.Lines[0].LineNo)
+		assert.Equal(t, models.StatementLineUnmatched, saved.Lines[1].Status)
+		assert.Nil(t, saved.Lines[1].TransactionID)
+		assert.NotEqual(t, saved.Lines[0].Fingerprint, saved.Lines[1].Fingerprint)
+	}
+}
+
+func TestReconciliationService_Import_CustomFormat(t *testing.T) {
+	mockRepo, mockPMRepo, service := setupReconciliationTest(t)
+	ctx := context.Background()
+
+	mockPMRepo.On("GetByID", ctx, uint(3)).Return(qris, nil).Once()
+	saved := expectImport(mockRepo, nil)
+
+	csv := "\ufeffTanggal;Nominal;Diterima\n" +
+		"05/03/2026 23:10;Rp 1.250.000,00;Rp 1.241.250,00\n" +
+		";;\n" +
+		"06/03/2026 08:00;(10.000,00);(10.000,00)\n"
+	_, err := service.Import(ctx, services.StatementImport{
+		PaymentMethodID: 3,
+		FileName:        "mutasi.csv",
+		Content:         strings.NewReader(csv),
+		Mapping: &models.StatementMapping{
+			Date:         "tanggal",
+			Amount:       "Nominal",
+			NetAmount:    "Diterima",
+			DateFormat:   "DD/MM/YYYY HH:mm",
+			Delimiter:    ";",
+			DecimalComma: true,
+		},
+	}, 1)
+
+	assert.NoError(t, err)
+	if assert.Len(t, saved.Lines, 2) {
+		assert.Equal(t, date(2026, 3, 5), saved.Lines[0].Date)
+		assert.Equal(t, 1250000.0, saved.Lines[0].Amount)
+		assert.Equal(t, 8750.0, saved.Lines[0].Fee)
+		assert.Equal(t, -10000.0, saved.Lines[1].Amount)
+		assert.Equal(t, 0.0, saved.Lines[1].Fee)
+	}
+}
+
+func TestReconciliationService_Import_PrefersClosestSale(t *testing.T) {
+	mockRepo, mockPMRepo, service := setupReconciliationTest(t)
+	ctx := context.Background()
+
+	mockPMRepo.On("GetByID", ctx, uint(3)).Return(qris, nil).Once()
+	saved := expectImport(mockRepo, []models.Transaction{
+		{ID: 20, GrandTotal: 30000, CreatedAt: time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)}, // 3 days before
+		{ID: 21, GrandTotal: 30000, CreatedAt: time.Date(2026, 3, 5, 10, 0, 0, 0, time.UTC)}, // 1 day after
+		{ID: 22, GrandTotal: 30000, CreatedAt: time.Date(2026, 3, 3, 10, 0, 0, 0, time.UTC)}, // 1 day before
+		{ID: 23, GrandTotal: 30000, CreatedAt: time.Date(2026, 2, 20, 10, 0, 0, 0, time.UTC)},
+	})
+
+	csv := "RRN,Settlement Date,Gross,MDR\n" +
+		"B1,2026-03-04,30000,210\n" +
+		"B2,2026-03-04,30000,210\n" +
+		"B3,2026-03-04,30000,210\n" +
+		"B4,2026-03-04,30000,210\n"
+	result, err := service.Import(ctx, services.StatementImport{
+		PaymentMethodID: 3,
+		FileName:        "qris.csv",
+		Content:         strings.NewReader(csv),
+		Mapping:         &qrisMapping,
+	}, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, result.Matched)
+	if assert.Len(t, saved.Lines, 4) {
+		assert.Equal(t, uint(22), *saved.Lines[0].TransactionID)
+		assert.Equal(t, uint(21), *saved.Lines[1].TransactionID)
+		assert.Equal(t, uint(20), *saved.Lines[2].TransactionID)
+		assert.Nil(t, saved.Lines[3].TransactionID) // 12 days off
+	}
+}
+
+func TestReconciliationService_Import_SkipsImportedLines(t *testing.T) {
+	mockRepo, mockPMRepo, service := setupReconciliationTest(t)
+	ctx := context.Background()
+
+	csv := "RRN,Settlement Date,Gross,MDR\n" +
+		"C1,2026-03-02,10000,70\n" +
+		"C1,2026-03-02,10000,70\n"
+	var fingerprints []string
+	mockPMRepo.On("GetByID", ctx, uint(3)).Return(qris, nil).Once()
+	mockRepo.On("ImportedFingerprints", ctx, mock.Anything).
+		Run(func(args mock.Arguments) { fingerprints = args.Get(1).([]string) }).
+		Return(func(_ context.Context, f []string) map[string]bool { return map[string]bool{f[0]: true} }, nil).Once()
+	mockRepo.On("GetUnsettled", ctx, "QRIS", mock.Anything, mock.Anything).Return(nil, nil).Once()
+	mockRepo.On("Create", ctx, mock.AnythingOfType("*models.BankStatement")).Return(nil).Once()
+
+	result, err := service.Import(ctx, services.StatementImport{
+		PaymentMethodID: 3,
+		Content:         strings.NewReader(csv),
+		Mapping:         &qrisMapping,
+	}, 1)
+
+	assert.NoError(t, err)
+	if assert.Len(t, fingerprints, 2) {
+		// The same line twice in a file is two settlements
+		assert.NotEqual(t, fingerprints[0], fingerprints[1])
+	}
+	assert.Equal(t, 1, result.Duplicates)
+	assert.Equal(t, 1, result.Imported)
+	assert.Equal(t, "statement.csv", result.Statement.FileName)
+}
+
+func TestReconciliationService_Import_AllImportedBefore(t *testing.T) {
+	mockRepo, mockPMRepo, service := setupReconciliationTest(t)
+	ctx := context.Background()
+
+	mockPMRepo.On("GetByID", ctx, uint(3)).Return(qris, nil).Once()
+	mockRepo.On("ImportedFingerprints", ctx, mock.Anything).
+		Return(func(_ context.Context, f []string) map[string]bool { return map[string]bool{f[0]: true} }, nil).Once()
+
+	_, err := service.Import(ctx, services.StatementImport{
+		PaymentMethodID: 3,
+		Content:         strings.NewReader("RRN,Settlement Date,Gross,MDR\nC1,2026-03-02,10000,70\n"),
+		Mapping:         &qrisMapping,
+	}, 1)
+
+	assert.ErrorIs(t, err, customErrors.ErrConflict)
+}
+
+func TestReconciliationService_Import_ReusesLastMapping(t *testing.T) {
+	mockRepo, mockPMRepo, service := setupReconciliationTest(t)
+	ctx := context.Background()
+
+	mockPMRepo.On("GetByID", ctx, uint(3)).Return(qris, nil).Once()
+	mockRepo.On("LatestMapping", ctx, uint(3)).Return(&qrisMapping, nil).Once()
+	saved := expectImport(mockRepo, nil)
+
+	_, err := service.Import(ctx, services.StatementImport{
+		PaymentMethodID: 3,
+		Content:         strings.NewReader("RRN,Settlement Date,Gross,MDR\nD1,2026-03-02,10000,70\n"),
+	}, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, qrisMapping, saved.Mapping)
+}
+
+func TestReconciliationService_Import_FirstImportNeedsMapping(t *testing.T) {
+	mockRepo, mockPMRepo, service := setupReconciliationTest(t)
+	ctx := context.Background()
+
+	mockPMRepo.On("GetByID", ctx, uint(3)).Return(qris, nil).Once()
+	mockRepo.On("LatestMapping", ctx, uint(3)).Return(nil, nil).Once()
+
+	_, err := service.Import(ctx, services.StatementImport{PaymentMethodID: 3, Content: strings.NewReader("")}, 1)
+
+	assert.ErrorContains(t, err, "column mapping is required")
+}
+
+func TestReconciliationService_Import_CashMethod(t *testing.T) {
+	_, mockPMRepo, service := setupReconciliationTest(t)
+	ctx := context.Background()
+
+	mockPMRepo.On("GetByID", ctx, uint(1)).Return(&models.PaymentMethod{ID: 1, Name: "Cash", IsCash: true}, nil).Once()
+
+	_, err := service.Import(ctx, services.StatementImport{PaymentMethodID: 1, Mapping: &qrisMapping}, 1)
+
+	assert.ErrorContains(t, err, "cash payment method")
+}
+
+func TestReconciliationService_Import_PaymentMethodNotFound(t *testing.T) {
+	_, mockPMRepo, service := setupReconciliationTest(t)
+	ctx := context.Background()
+
+	mockPMRepo.On("GetByID", ctx, uint(9)).Return(nil, gorm.ErrRecordNotFound).Once()
+
+	_, err := service.Import(ctx, services.StatementImport{PaymentMethodID: 9, Mapping: &qrisMapping}, 1)
+
+	assert.ErrorIs(t, err, customErrors.ErrNotFound)
+}
+
+func TestReconciliationService_Import_InvalidFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		csv     string
+		mapping models.StatementMapping
+		want    string
+	}{
+		{"missing column", "RRN,Date,Gross\nE1,2026-03-02,100\n", qrisMapping, `column "Settlement Date" not found`},
+		{"bad date", "RRN,Settlement Date,Gross,MDR\nE1,2026-03-02,100,1\nE2,Total,100,1\n", qrisMapping, `row 3: invalid date "Total"`},
+		{"bad amount", "RRN,Settlement Date,Gross,MDR\nE1,2026-03-02,abc,1\n", qrisMapping, `row 2: invalid amount "abc"`},
+		{"no lines", "RRN,Settlement Date,Gross,MDR\n", qrisMapping, "has no lines"},
+		{"no amount column", "RRN,Settlement Date,Gross,MDR\n", models.StatementMapping{Date: "Settlement Date"}, "validation failed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, mockPMRepo, service := setupReconciliationTest(t)
+			mockPMRepo.On("GetByID", mock.Anything, uint(3)).Return(qris, nil).Once()
+
+			_, err := service.Import(context.Background(), services.StatementImport{
+				PaymentMethodID: 3,
+				Content:         strings.NewReader(tt.csv),
+				Mapping:         &tt.mapping,
+			}, 1)
+
+			assert.ErrorContains(t, err, tt.want)
+		})
+	}
+}
+
+func TestReconciliationService_Import_PeriodClosed(t *testing.T) {
+	mockRepo, mockPMRepo, service := setupReconciliationTest(t)
+	ctx := context.Background()
+
+	mockPMRepo.On("GetByID", ctx, uint(3)).Return(qris, nil).Once()
+	mockRepo.On("ImportedFingerprints", ctx, mock.Anything).Return(map[string]bool{}, nil).Once()
+	mockRepo.On("GetUnsettled", ctx, "QRIS", mock.Anything, mock.Anything).Return(nil, nil).Once()
+	mockRepo.On("Create", ctx, mock.Anything).Return(customErrors.ErrPeriodClosed).Once()
+
+	_, err := service.Import(ctx, services.StatementImport{
+		PaymentMethodID: 3,
+		Content:         strings.NewReader("RRN,Settlement Date,Gross,MDR\nF1,2026-01-31,10000,70\n"),
+		Mapping:         &qrisMapping,
+	}, 1)
+
+	assert.ErrorIs(t, err, customErrors.ErrPeriodClosed)
+}
+
+// --- GetUnmatched ---
+
+func TestReconciliationService_GetUnmatched_DefaultPeriod(t *testing.T) {
+	mockRepo, _, service := setupReconciliationTest(t)
+	ctx := context.Background()
+	now := time.Date(2026, 3, 31, 15, 0, 0, 0, time.UTC)
+
+	mockRepo.On("GetUnmatchedLines", ctx, (*uint)(nil), date(2026, 3, 1), date(2026, 3, 31)).
+		Return([]models.BankStatementLine{{Amount: 10000}, {Amount: 2500.5}}, nil).Once()
+	mockRepo.On("GetUnsettled", ctx, "", date(2026, 3, 1), date(2026, 4, 1)).
+		Return([]models.Transaction{{GrandTotal: 45000}}, nil).Once()
+
+	items, err := service.GetUnmatched(ctx, nil, "", "", now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "2026-03-01", items.StartDate)
+	assert.Equal(t, 12500.5, items.LinesTotal)
+	assert.Equal(t, 45000.0, items.TransactionTotal)
+}
+
+func TestReconciliationService_GetUnmatched_InvalidRange(t *testing.T) {
+	_, _, service := setupReconciliationTest(t)
+
+	_, err := service.GetUnmatched(context.Background(), nil, "2026-03-10", "2026-03-01", time.Now())
+
+	assert.ErrorContains(t, err, "end_date must not be before start_date")
+}
+
+// --- Match ---
+
+func TestReconciliationService_Match_NotFound(t *testing.T) {
+	mockRepo, _, service := setupReconciliationTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("Match", ctx, uint(5), uint(99)).Return(nil, gorm.ErrRecordNotFound).Once()
+
+	_, err := service.Match(ctx, 5, 99)
+
+	assert.ErrorIs(t, err, customErrors.ErrNotFound)
+}
+
+func TestReconciliationService_Match_Conflict(t *testing.T) {
+	mockRepo, _, service := setupReconciliationTest(t)
+	ctx := context.Background()
+
+	conflict := customErrors.ErrConflict
+	mockRepo.On("Match", ctx, uint(5), uint(10)).Return(nil, conflict).Once()
+
+	_, err := service.Match(ctx, 5, 10)
+
+	assert.ErrorIs(t, err, customErrors.ErrConflict)
+}
@@ -0,0 +1,219 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"pos-api/internal/models"
+	"pos-api/internal/services"
+	"pos-api/tests/mocks"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func setupRecurringExpenseTest(t *testing.T) (*mocks.RecurringExpenseRepository, *mocks.CashFlowCategoryRepository, services.RecurringExpenseService) {
+	mockRepo := mocks.NewRecurringExpenseRepository(t)
+	mockCategoryRepo := mocks.NewCashFlowCategoryRepository(t)
+	service := services.NewRecurringExpenseService(mockRepo, mockCategoryRepo)
+	return mockRepo, mockCategoryRepo, service
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// --- Create ---
+
+func TestRecurringExpenseService_Create_Success(t *testing.T) {
+	mockRepo, mockCategoryRepo, service := setupRecurringExpenseTest(t)
+	ctx := context.Background()
+
+	mockCategoryRepo.On("GetByCode", ctx, "sewa").Return(&models.CashFlowCategory{
+		Code: "sewa", Type: models.CashFlowCategoryExpense, IsActive: true,
+	}, nil).Once()
+	mockRepo.On("Create", ctx, mock.AnythingOfType("*models.RecurringExpense")).Return(nil).Once()
+
+	expense, err := service.Create(ctx, services.RecurringExpenseRequest{
+		Name:       "Sewa ruko",
+		Source:     "sewa",
+		Amount:     5000000,
+		Frequency:  models.RecurrenceMonthly,
+		DayOfMonth: 1,
+		StartDate:  "2026-01-01",
+	}, 1)
+
+	assert.NoError(t, err)
+	assert.True(t, expense.IsActive)
+	assert.Equal(t, uint(1), expense.UserID)
+	assert.Equal(t, date(2026, 1, 1), expense.StartDate)
+}
+
+func TestRecurringExpenseService_Create_MonthlyWithoutDay(t *testing.T) {
+	_, _, service := setupRecurringExpenseTest(t)
+
+	_, err := service.Create(context.Background(), services.RecurringExpenseRequest{
+		Name:      "Listrik",
+		Source:    "listrik",
+		Amount:    750000,
+		Frequency: models.RecurrenceMonthly,
+		StartDate: "2026-01-01",
+	}, 1)
+
+	assert.ErrorContains(t, err, "day_of_month is required")
+}
+
+func TestRecurringExpenseService_Create_EndBeforeStart(t *testing.T) {
+	_, _, service := setupRecurringExpenseTest(t)
+
+	_, err := service.Create(context.Background(), services.RecurringExpenseRequest{
+		Name:      "Gaji",
+		Source:    "gaji_karyawan",
+		Amount:    3000000,
+		Frequency: models.RecurrenceWeekly,
+		StartDate: "2026-03-01",
+		EndDate:   "2026-02-01",
+	}, 1)
+
+	assert.ErrorContains(t, err, "end_date must not be before start_date")
+}
+
+func TestRecurringExpenseService_Create_NotExpenseCategory(t *testing.T) {
+	_, mockCategoryRepo, service := setupRecurringExpenseTest(t)
+	ctx := context.Background()
+
+	mockCategoryRepo.On("GetByCode", ctx, "modal_awal").Return(&models.CashFlowCategory{
+		Code: "modal_awal", Type: models.CashFlowCategoryCapital, IsActive: true,
+	}, nil).Once()
+
+	_, err := service.Create(ctx, services.RecurringExpenseRequest{
+		Name:       "Setoran modal",
+		Source:     "modal_awal",
+		Amount:     1000000,
+		Frequency:  models.RecurrenceMonthly,
+		DayOfMonth: 1,
+		StartDate:  "2026-01-01",
+	}, 1)
+
+	assert.ErrorContains(t, err, "is not an active expense category")
+}
+
+func TestRecurringExpenseService_Create_UnknownCategory(t *testing.T) {
+	_, mockCategoryRepo, service := setupRecurringExpenseTest(t)
+	ctx := context.Background()
+
+	mockCategoryRepo.On("GetByCode", ctx, "parkir").Return(nil, gorm.ErrRecordNotFound).Once()
+
+	_, err := service.Create(ctx, services.RecurringExpenseRequest{
+		Name:      "Parkir",
+		Source:    "parkir",
+		Amount:    50000,
+		Frequency: models.RecurrenceWeekly,
+		StartDate: "2026-01-01",
+	}, 1)
+
+	assert.ErrorContains(t, err, "unknown cash flow category")
+}
+
+// --- GetByID ---
+
+func TestRecurringExpenseService_GetByID_NotFound(t *testing.T) {
+	mockRepo, _, service := setupRecurringExpenseTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("GetByID", ctx, uint(99)).Return(nil, gorm.ErrRecordNotFound).Once()
+
+	_, err := service.GetByID(ctx, 99)
+
+	assert.ErrorIs(t, err, customErrors.ErrNotFound)
+}
+
+// --- GetUpcoming ---
+
+func TestRecurringExpenseService_GetUpcoming_ClampsToMonthEnd(t *testing.T) {
+	mockRepo, _, service := setupRecurringExpenseTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("GetAll", ctx, true).Return([]models.RecurringExpense{{
+		ID: 1, Name: "Gaji", Source: "gaji_karyawan", Amount: 3000000,
+		Frequency: models.RecurrenceMonthly, DayOfMonth: 31, StartDate: date(2025, 1, 1),
+	}}, nil).Once()
+
+	upcoming, err := service.GetUpcoming(ctx, date(2026, 1, 20), 60)
+
+	assert.NoError(t, err)
+	if assert.Len(t, upcoming.Items, 2) {
+		assert.Equal(t, date(2026, 1, 31), upcoming.Items[0].DueDate)
+		assert.Equal(t, date(2026, 2, 28), upcoming.Items[1].DueDate)
+	}
+	assert.Equal(t, float64(6000000), upcoming.TotalAmount)
+}
+
+func TestRecurringExpenseService_GetUpcoming_SkipsBookedAndSorts(t *testing.T) {
+	mockRepo, _, service := setupRecurringExpenseTest(t)
+	ctx := context.Background()
+
+	booked := date(2026, 3, 10)
+	mockRepo.On("GetAll", ctx, true).Return([]models.RecurringExpense{
+		{
+			ID: 1, Name: "Sewa", Source: "sewa", Amount: 5000000,
+			Frequency: models.RecurrenceMonthly, DayOfMonth: 10, StartDate: date(2026, 1, 1),
+			BookedThrough: &booked,
+		},
+		{
+			// Mondays; 2026-03-10 is a Tuesday
+			ID: 2, Name: "Kebersihan", Source: "beban_lain", Amount: 100000,
+			Frequency: models.RecurrenceWeekly, DayOfWeek: 1, StartDate: date(2026, 1, 1),
+		},
+	}, nil).Once()
+
+	upcoming, err := service.GetUpcoming(ctx, date(2026, 3, 10), 14)
+
+	assert.NoError(t, err)
+	if assert.Len(t, upcoming.Items, 2) {
+		assert.Equal(t, date(2026, 3, 16), upcoming.Items[0].DueDate)
+		assert.Equal(t, date(2026, 3, 23), upcoming.Items[1].DueDate)
+		assert.Equal(t, uint(2), upcoming.Items[1].RecurringExpenseID)
+	}
+}
+
+func TestRecurringExpenseService_GetUpcoming_YearlyWithinEndDate(t *testing.T) {
+	mockRepo, _, service := setupRecurringExpenseTest(t)
+	ctx := context.Background()
+
+	end := date(2026, 12, 31)
+	mockRepo.On("GetAll", ctx, true).Return([]models.RecurringExpense{{
+		ID: 1, Name: "Pajak reklame", Source: "beban_lain", Amount: 1200000,
+		Frequency: models.RecurrenceYearly, Month: 2, DayOfMonth: 15,
+		StartDate: date(2025, 1, 1), EndDate: &end,
+	}}, nil).Once()
+
+	upcoming, err := service.GetUpcoming(ctx, date(2026, 1, 1), 366*2)
+
+	assert.NoError(t, err)
+	if assert.Len(t, upcoming.Items, 1) {
+		assert.Equal(t, date(2026, 2, 15), upcoming.Items[0].DueDate)
+	}
+}
+
+// --- RunDue ---
+
+func TestRecurringExpenseService_RunDue_ContinuesAfterFailure(t *testing.T) {
+	mockRepo, _, service := setupRecurringExpenseTest(t)
+	ctx := context.Background()
+	today := date(2026, 3, 1)
+
+	mockRepo.On("GetDue", ctx, today).Return([]models.RecurringExpense{{ID: 1}, {ID: 2}}, nil).Once()
+	mockRepo.On("Book", ctx, uint(1), today).Return(nil, errors.New("db down")).Once()
+	mockRepo.On("Book", ctx, uint(2), today).Return([]models.CashFlow{{ID: 10, Amount: 750000}}, nil).Once()
+
+	booked, err := service.RunDue(ctx, time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC))
+
+	assert.ErrorContains(t, err, "recurring expense 1")
+	assert.Len(t, booked, 1)
+}
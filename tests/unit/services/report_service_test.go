@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"pos-api/internal/models"
 	"pos-api/internal/repositories"
 	"pos-api/internal/services"
 	"pos-api/tests/mocks"
@@ -127,7 +128,7 @@ func TestReportService_GetStockValue_Success(t *testing.T) {
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
-	assert.Equal(t, int64(500), result.TotalUnits)
+	assert.Equal(t, 500.0, result.TotalUnits)
 	assert.Equal(t, float64(15000000), result.TotalValue)
 }
 
@@ -142,3 +143,141 @@ func TestReportService_GetStockValue_Error(t *testing.T) {
 	assert.Error(t, err)
 	assert.Nil(t, result)
 }
+
+// --- GetProfitAndLoss ---
+
+func TestReportService_GetProfitAndLoss_Success(t *testing.T) {
+	mockRepo, service := setupReportTest(t)
+	ctx := context.Background()
+
+	startDate, _ := time.Parse("2006-01-02", "2026-02-01")
+	endDate, _ := time.Parse("2006-01-02", "2026-02-28")
+
+	mockRepo.On("GetAccountBalances", ctx, &startDate, endDate).Return([]repositories.AccountBalance{
+		{Code: "1100", Type: models.AccountAsset, Debit: 6000000, Credit: 900000},
+		{Code: "3100", Type: models.AccountEquity, Debit: 400000, Credit: 5000000},
+		// A return posted this month of a sale made in January
+		{Code: "4100", Type: models.AccountRevenue, Debit: 100000, Credit: 1200000},
+		{Code: "4110", Type: models.AccountRevenue, Debit: 100000},
+		{Code: "4200", Name: "Pendapatan Lain-lain", Type: models.AccountRevenue, Credit: 50000},
+		{Code: "5100", Type: models.AccountExpense, Debit: 600000},
+		{Code: "5200", Type: models.AccountExpense, Debit: 25000, Credit: 5000},
+		{Code: "6100", Name: "Beban Operasional", Type: models.AccountExpense, Debit: 300000},
+		{Code: "6200", Name: "Biaya Merchant", Type: models.AccountExpense},
+	}, nil).Once()
+
+	report, err := service.GetProfitAndLoss(ctx, "2026-02-01", "2026-02-28", services.CompareNone)
+
+	assert.NoError(t, err)
+	assert.Nil(t, report.Previous)
+	pl := report.Current
+	assert.Equal(t, 1100000.0, pl.GrossSales)
+	assert.Equal(t, 100000.0, pl.Discounts)
+	assert.Equal(t, 1000000.0, pl.NetSales)
+	assert.Equal(t, 20000.0, pl.Shrinkage)
+	assert.Equal(t, 380000.0, pl.GrossProfit)
+	assert.Equal(t, 38.0, pl.GrossMargin)
+	assert.Equal(t, []services.StatementLine{{Code: "4200", Name: "Pendapatan Lain-lain", Amount: 50000}}, pl.OtherIncome)
+	assert.Equal(t, []services.StatementLine{{Code: "6100", Name: "Beban Operasional", Amount: 300000}}, pl.OperatingExpenses)
+	assert.Equal(t, 130000.0, pl.NetProfit)
+	assert.Equal(t, 13.0, pl.NetMargin)
+}
+
+func TestReportService_GetProfitAndLoss_ComparePreviousMonth(t *testing.T) {
+	mockRepo, service := setupReportTest(t)
+	ctx := context.Background()
+
+	startDate, _ := time.Parse("2006-01-02", "2026-03-01")
+	endDate, _ := time.Parse("2006-01-02", "2026-03-31")
+	prevStart, _ := time.Parse("2006-01-02", "2026-02-01")
+	prevEnd, _ := time.Parse("2006-01-02", "2026-02-28")
+
+	mockRepo.On("GetAccountBalances", ctx, &startDate, endDate).Return([]repositories.AccountBalance{
+		{Code: "4100", Type: models.AccountRevenue, Credit: 1200000},
+		{Code: "5100", Type: models.AccountExpense, Debit: 700000},
+	}, nil).Once()
+	mockRepo.On("GetAccountBalances", ctx, &prevStart, prevEnd).Return([]repositories.AccountBalance{
+		{Code: "4100", Type: models.AccountRevenue, Credit: 1000000},
+		{Code: "5100", Type: models.AccountExpense, Debit: 600000},
+	}, nil).Once()
+
+	report, err := service.GetProfitAndLoss(ctx, "2026-03-01", "2026-03-31", services.ComparePrevious)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "2026-02-01", report.Previous.StartDate)
+	assert.Equal(t, "2026-02-28", report.Previous.EndDate)
+	assert.Equal(t, 100000.0, report.Changes["gross_profit"].Amount)
+	assert.Equal(t, 25.0, *report.Changes["gross_profit"].Percent)
+	assert.Equal(t, 20.0, *report.Changes["net_sales"].Percent)
+}
+
+func TestReportService_GetProfitAndLoss_InvalidCompare(t *testing.T) {
+	_, service := setupReportTest(t)
+
+	report, err := service.GetProfitAndLoss(context.Background(), "2026-02-01", "2026-02-28", "quarter")
+
+	assert.Error(t, err)
+	assert.Nil(t, report)
+	assert.Contains(t, err.Error(), "pembanding tidak valid")
+}
+
+// --- GetBalanceSheet ---
+
+func TestReportService_GetBalanceSheet_CompareDate(t *testing.T) {
+	mockRepo, service := setupReportTest(t)
+	ctx := context.Background()
+
+	date, _ := time.Parse("2006-01-02", "2026-02-28")
+	compareDate, _ := time.Parse("2006-01-02", "2026-01-31")
+	noStart := (*time.Time)(nil)
+
+	mockRepo.On("GetAccountBalances", ctx, noStart, date).Return([]repositories.AccountBalance{
+		{Code: "1100", Type: models.AccountAsset, Debit: 2500000, Credit: 1000000},
+		{Code: "1200.2", Type: models.AccountAsset, Debit: 500000},
+		{Code: "1300", Type: models.AccountAsset, Debit: 3500000, Credit: 500000},
+		{Code: "2100", Type: models.AccountLiability, Debit: 200000, Credit: 700000},
+		{Code: "3100", Type: models.AccountEquity, Credit: 3000000},
+		{Code: "3200", Type: models.AccountEquity, Credit: 1000000},
+		{Code: "4100", Type: models.AccountRevenue, Credit: 2000000},
+		{Code: "5100", Type: models.AccountExpense, Debit: 1500000},
+	}, nil).Once()
+	mockRepo.On("GetAccountBalances", ctx, noStart, compareDate).Return([]repositories.AccountBalance{
+		{Code: "1100", Type: models.AccountAsset, Debit: 1500000},
+		{Code: "1300", Type: models.AccountAsset, Debit: 2500000},
+		{Code: "3100", Type: models.AccountEquity, Credit: 3000000},
+		{Code: "3200", Type: models.AccountEquity, Credit: 1000000},
+	}, nil).Once()
+
+	report, err := service.GetBalanceSheet(ctx, "2026-02-28", "2026-01-31")
+
+	assert.NoError(t, err)
+	current := report.Current
+	assert.Equal(t, 1500000.0, current.Cash)
+	assert.Equal(t, 500000.0, current.Bank)
+	assert.Equal(t, 0.0, current.Receivables)
+	assert.Equal(t, 3000000.0, current.Inventory)
+	assert.Equal(t, 5000000.0, current.TotalAssets)
+	assert.Equal(t, 500000.0, current.Payables)
+	assert.Equal(t, 4000000.0, current.Capital)
+	assert.Equal(t, 500000.0, current.RetainedEarnings)
+	assert.Equal(t, 4500000.0, current.TotalEquity)
+
+	previous := report.Previous
+	assert.Equal(t, 2500000.0, previous.Inventory)
+	assert.Equal(t, 0.0, previous.RetainedEarnings)
+	assert.Equal(t, 1000000.0, report.Changes["total_assets"].Amount)
+	assert.Nil(t, report.Changes["payables"].Percent)
+}
+
+func TestReportService_GetBalanceSheet_Error(t *testing.T) {
+	mockRepo, service := setupReportTest(t)
+	ctx := context.Background()
+
+	date, _ := time.Parse("2006-01-02", "2026-02-28")
+	mockRepo.On("GetAccountBalances", ctx, (*time.Time)(nil), date).Return(nil, errors.New("db error")).Once()
+
+	report, err := service.GetBalanceSheet(ctx, "2026-02-28", "")
+
+	assert.Error(t, err)
+	assert.Nil(t, report)
+}
@@ -0,0 +1,211 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"pos-api/internal/models"
+	"pos-api/internal/pkg/scale"
+	"pos-api/internal/services"
+	"pos-api/tests/mocks"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func setupStockOpnameTest(t *testing.T) (*mocks.StockOpnameRepository, *mocks.ProductRepository, services.StockOpnameService) {
+	mockRepo := mocks.NewStockOpnameRepository(t)
+	mockProductRepo := mocks.NewProductRepository(t)
+	patterns, err := scale.ParsePatterns(scale.DefaultPatterns)
+	require.NoError(t, err)
+	scanner := services.NewBarcodeScanner(mockProductRepo, scale.NewParser(patterns))
+	return mockRepo, mockProductRepo, services.NewStockOpnameService(mockRepo, scanner)
+}
+
+func counted(q float64) *float64 { return &q }
+
+func TestStockOpnameService_Create_NoProducts(t *testing.T) {
+	mockRepo, _, service := setupStockOpnameTest(t)
+	ctx := context.Background()
+	categoryID := uint(9)
+
+	mockRepo.On("Create", ctx, mock.AnythingOfType("*models.StockOpname")).Return(gorm.ErrRecordNotFound).Once()
+
+	_, err := service.Create(ctx, services.CreateStockOpnameRequest{CategoryID: &categoryID}, 1)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "no products")
+}
+
+func TestStockOpnameService_SubmitCount_ByProductID(t *testing.T) {
+	mockRepo, _, service := setupStockOpnameTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("GetItem", ctx, uint(1), uint(5)).Return(&models.StockOpnameItem{ID: 11, ProductID: 5, Product: models.Product{ID: 5, Name: "Mie"}}, nil).Once()
+	mockRepo.On("AddCount", ctx, mock.AnythingOfType("*models.StockOpnameCount")).Return(nil).Once()
+
+	count, err := service.SubmitCount(ctx, 1, services.StockCountRequest{ProductID: 5, Quantity: counted(0)}, 3)
+
+	require.NoError(t, err)
+	assert.Equal(t, uint(11), count.StockOpnameItemID)
+	assert.Equal(t, 0.0, count.Quantity) // Zero is a valid count
+	assert.Equal(t, uint(3), count.UserID)
+}
+
+func TestStockOpnameService_SubmitCount_ByProductIDRequiresQuantity(t *testing.T) {
+	_, _, service := setupStockOpnameTest(t)
+
+	_, err := service.SubmitCount(context.Background(), 1, services.StockCountRequest{ProductID: 5}, 3)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "quantity is required")
+}
+
+func TestStockOpnameService_SubmitCount_ScaleLabel(t *testing.T) {
+	mockRepo, mockProductRepo, service := setupStockOpnameTest(t)
+	ctx := context.Background()
+
+	mockProductRepo.On("GetProductByBarcode", ctx, "2800101012500").Return(nil, gorm.ErrRecordNotFound).Once()
+	mockProductRepo.On("GetProductByPLU", ctx, "101").Return(&models.Product{ID: 7, Name: "Daging", Price: 150000, SoldByWeight: true}, nil).Once()
+	mockRepo.On("GetItem", ctx, uint(1), uint(7)).Return(&models.StockOpnameItem{ID: 12, ProductID: 7, Product: models.Product{ID: 7, SoldByWeight: true}}, nil).Once()
+	mockRepo.On("AddCount", ctx, mock.AnythingOfType("*models.StockOpnameCount")).Return(nil).Once()
+
+	count, err := service.SubmitCount(ctx, 1, services.StockCountRequest{Barcode: "2800101012500"}, 3)
+
+	require.NoError(t, err)
+	assert.Equal(t, uint(7), count.ProductID)
+	assert.Equal(t, 1.25, count.Quantity)
+	assert.Equal(t, "2800101012500", count.Barcode)
+}
+
+func TestStockOpnameService_SubmitCount_FractionalForUnitProduct(t *testing.T) {
+	mockRepo, _, service := setupStockOpnameTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("GetItem", ctx, uint(1), uint(5)).Return(&models.StockOpnameItem{ID: 11, ProductID: 5, Product: models.Product{ID: 5, Name: "Mie"}}, nil).Once()
+
+	_, err := service.SubmitCount(ctx, 1, services.StockCountRequest{ProductID: 5, Quantity: counted(1.5)}, 3)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "whole number")
+}
+
+func TestStockOpnameService_SubmitCount_MissingSerials(t *testing.T) {
+	mockRepo, _, service := setupStockOpnameTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("GetItem", ctx, uint(1), uint(9)).Return(&models.StockOpnameItem{ID: 13, ProductID: 9, Product: models.Product{ID: 9, Name: "Ponsel", Serialized: true}}, nil).Once()
+	mockRepo.On("AddCount", ctx, mock.AnythingOfType("*models.StockOpnameCount")).Return(nil).Once()
+
+	count, err := service.SubmitCount(ctx, 1, services.StockCountRequest{ProductID: 9, Quantity: counted(3), MissingSerials: []string{" SN-2 "}}, 3)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"SN-2"}, count.MissingSerials)
+}
+
+func TestStockOpnameService_SubmitCount_MissingSerialsNotSerialized(t *testing.T) {
+	mockRepo, _, service := setupStockOpnameTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("GetItem", ctx, uint(1), uint(5)).Return(&models.StockOpnameItem{ID: 11, ProductID: 5, Product: models.Product{ID: 5, Name: "Mie"}}, nil).Once()
+
+	_, err := service.SubmitCount(ctx, 1, services.StockCountRequest{ProductID: 5, Quantity: counted(3), MissingSerials: []string{"SN-2"}}, 3)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "does not track serial numbers")
+	mockRepo.AssertNotCalled(t, "AddCount", mock.Anything, mock.Anything)
+}
+
+func TestStockOpnameService_SubmitCount_ProductNotInSession(t *testing.T) {
+	mockRepo, _, service := setupStockOpnameTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("GetItem", ctx, uint(1), uint(99)).Return(nil, gorm.ErrRecordNotFound).Once()
+
+	_, err := service.SubmitCount(ctx, 1, services.StockCountRequest{ProductID: 99, Quantity: counted(1)}, 3)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not part of this stock opname")
+}
+
+func TestStockOpnameService_SubmitCount_SessionClosed(t *testing.T) {
+	mockRepo, _, service := setupStockOpnameTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("GetItem", ctx, uint(1), uint(5)).Return(&models.StockOpnameItem{ID: 11, ProductID: 5}, nil).Once()
+	mockRepo.On("AddCount", ctx, mock.AnythingOfType("*models.StockOpnameCount")).Return(customErrors.ErrConflict).Once()
+
+	_, err := service.SubmitCount(ctx, 1, services.StockCountRequest{ProductID: 5, Quantity: counted(2)}, 3)
+
+	assert.ErrorIs(t, err, customErrors.ErrConflict)
+}
+
+func TestStockOpnameService_GetVariances(t *testing.T) {
+	mockRepo, _, service := setupStockOpnameTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("GetByID", ctx, uint(1)).Return(&models.StockOpname{
+		ID:     1,
+		Code:   "SO-1",
+		Status: models.StockOpnameOpen,
+		Items: []models.StockOpnameItem{
+			{ProductID: 1, ExpectedStock: 10, CostPrice: 1000, CountedQuantity: counted(8), Product: models.Product{Name: "A"}}, // -2
+			{ProductID: 2, ExpectedStock: 5, CostPrice: 2000, CountedQuantity: counted(6), Product: models.Product{Name: "B"}},  // +1
+			{ProductID: 3, ExpectedStock: 4, CostPrice: 500, CountedQuantity: counted(4), Product: models.Product{Name: "C"}},   // 0
+			{ProductID: 4, ExpectedStock: 7, CostPrice: 300, Product: models.Product{Name: "D"}},                                // not counted
+		},
+	}, nil).Once()
+
+	report, err := service.GetVariances(ctx, 1, true)
+
+	require.NoError(t, err)
+	assert.Equal(t, 4, report.TotalItems)
+	assert.Equal(t, 3, report.CountedItems)
+	assert.Equal(t, 2000.0, report.ShortageValue)
+	assert.Equal(t, 2000.0, report.SurplusValue)
+	assert.Equal(t, 0.0, report.NetValue)
+	require.Len(t, report.Items, 2)
+	assert.Equal(t, -2.0, report.Items[0].Variance)
+	assert.Equal(t, -2000.0, report.Items[0].VarianceValue)
+}
+
+func TestStockOpnameService_Approve_NothingCounted(t *testing.T) {
+	mockRepo, _, service := setupStockOpnameTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("GetByID", ctx, uint(1)).Return(&models.StockOpname{
+		ID:     1,
+		Status: models.StockOpnameOpen,
+		Items:  []models.StockOpnameItem{{ProductID: 1, ExpectedStock: 10}},
+	}, nil).Once()
+
+	_, err := service.Approve(ctx, 1, 2)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "no products have been counted")
+}
+
+func TestStockOpnameService_Approve_Success(t *testing.T) {
+	mockRepo, _, service := setupStockOpnameTest(t)
+	ctx := context.Background()
+
+	open := &models.StockOpname{ID: 1, Status: models.StockOpnameOpen, Items: []models.StockOpnameItem{{ProductID: 1, ExpectedStock: 10, CountedQuantity: counted(9)}}}
+	approved := &models.StockOpname{ID: 1, Status: models.StockOpnameApproved}
+	mockRepo.On("GetByID", ctx, uint(1)).Return(open, nil).Once()
+	mockRepo.On("Approve", ctx, uint(1), uint(2)).Return([]models.InventoryLog{{ProductID: 1, Quantity: -1}}, nil).Once()
+	mockRepo.On("GetByID", ctx, uint(1)).Return(approved, nil).Once()
+
+	opname, err := service.Approve(ctx, 1, 2)
+
+	require.NoError(t, err)
+	assert.Equal(t, models.StockOpnameApproved, opname.Status)
+}
+
+func TestStockOpnameItem_Variance(t *testing.T) {
+	assert.Equal(t, 0.0, models.StockOpnameItem{ExpectedStock: 3}.Variance())
+	assert.Equal(t, -0.25, models.StockOpnameItem{ExpectedStock: 1.5, CountedQuantity: counted(1.25)}.Variance())
+}
@@ -0,0 +1,133 @@
+package services_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"pos-api/internal/models"
+	customErrors "pos-api/internal/pkg/errors"
+	"pos-api/internal/services"
+	"pos-api/tests/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type stockReservationMocks struct {
+	reservation *mocks.StockReservationRepository
+	location    *mocks.LocationRepository
+	product     *mocks.ProductRepository
+}
+
+func setupStockReservationTest(t *testing.T) (stockReservationMocks, services.StockReservationService) {
+	m := stockReservationMocks{
+		reservation: mocks.NewStockReservationRepository(t),
+		location:    mocks.NewLocationRepository(t),
+		product:     mocks.NewProductRepository(t),
+	}
+	return m, services.NewStockReservationService(m.reservation, m.location, m.product)
+}
+
+func TestStockReservationService_Create_DefaultLocation(t *testing.T) {
+	m, service := setupStockReservationTest(t)
+	ctx := context.Background()
+
+	m.location.On("GetDefault", ctx).Return(&models.Location{ID: 1, Code: "MAIN", IsDefault: true, IsActive: true}, nil).Once()
+	m.product.On("GetProductByID", ctx, uint(5)).Return(&models.Product{ID: 5, Name: "Gula"}, nil).Once()
+
+	var created *models.StockReservation
+	m.reservation.On("Create", ctx, mock.AnythingOfType("*models.StockReservation")).Run(func(args mock.Arguments) {
+		created = args.Get(1).(*models.StockReservation)
+		created.ID = 3
+	}).Return(nil).Once()
+	m.reservation.On("GetByID", ctx, uint(3)).Return(func(context.Context, uint) *models.StockReservation { return created }, nil).Once()
+
+	before := time.Now()
+	reservation, err := service.Create(ctx, services.StockReservationRequest{
+		Channel:   "online",
+		Reference: "ORD-1001",
+		Items:     []services.StockReservationItemRequest{{ProductID: 5, Quantity: 2}},
+	}, 9)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), reservation.LocationID)
+	assert.Equal(t, models.ReservationActive, reservation.Status)
+	assert.Equal(t, uint(9), reservation.UserID)
+	assert.Len(t, reservation.Items, 1)
+	assert.Equal(t, float64(2), reservation.Items[0].Quantity)
+	assert.WithinDuration(t, before.Add(30*time.Minute), reservation.ExpiresAt, 5*time.Second)
+}
+
+func TestStockReservationService_Create_DuplicateProduct(t *testing.T) {
+	m, service := setupStockReservationTest(t)
+	ctx := context.Background()
+
+	m.location.On("GetDefault", ctx).Return(&models.Location{ID: 1, IsDefault: true, IsActive: true}, nil).Once()
+	m.product.On("GetProductByID", ctx, uint(5)).Return(&models.Product{ID: 5, Name: "Gula"}, nil).Once()
+
+	_, err := service.Create(ctx, services.StockReservationRequest{
+		Items: []services.StockReservationItemRequest{{ProductID: 5, Quantity: 1}, {ProductID: 5, Quantity: 2}},
+	}, 9)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "more than once")
+	m.reservation.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestStockReservationService_Create_InsufficientStock(t *testing.T) {
+	m, service := setupStockReservationTest(t)
+	ctx := context.Background()
+
+	m.location.On("GetByID", ctx, uint(2)).Return(&models.Location{ID: 2, IsActive: true}, nil).Once()
+	m.product.On("GetProductByID", ctx, uint(5)).Return(&models.Product{ID: 5, Name: "Gula"}, nil).Once()
+	m.reservation.On("Create", ctx, mock.AnythingOfType("*models.StockReservation")).
+		Return(fmt.Errorf("%w: product 5 has 1 units free at location 2, need 4", customErrors.ErrInsufficientStock)).Once()
+
+	_, err := service.Create(ctx, services.StockReservationRequest{
+		LocationID: 2,
+		Items:      []services.StockReservationItemRequest{{ProductID: 5, Quantity: 4}},
+	}, 9)
+
+	assert.ErrorIs(t, err, customErrors.ErrInsufficientStock)
+}
+
+func TestStockReservationService_Create_FractionalNotSoldByWeight(t *testing.T) {
+	m, service := setupStockReservationTest(t)
+	ctx := context.Background()
+
+	m.location.On("GetDefault", ctx).Return(&models.Location{ID: 1, IsDefault: true, IsActive: true}, nil).Once()
+	m.product.On("GetProductByID", ctx, uint(5)).Return(&models.Product{ID: 5, Name: "Gula"}, nil).Once()
+
+	_, err := service.Create(ctx, services.StockReservationRequest{
+		Items: []services.StockReservationItemRequest{{ProductID: 5, Quantity: 1.5}},
+	}, 9)
+
+	assert.Error(t, err)
+	m.reservation.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestStockReservationService_Release_NotActive(t *testing.T) {
+	m, service := setupStockReservationTest(t)
+	ctx := context.Background()
+
+	m.reservation.On("Release", ctx, uint(3)).
+		Return(fmt.Errorf("%w: reservation RSV-1 is fulfilled", customErrors.ErrConflict)).Once()
+
+	_, err := service.Release(ctx, 3)
+
+	assert.ErrorIs(t, err, customErrors.ErrConflict)
+}
+
+func TestStockReservationService_Release_NotFound(t *testing.T) {
+	m, service := setupStockReservationTest(t)
+	ctx := context.Background()
+
+	m.reservation.On("Release", ctx, uint(3)).Return(gorm.ErrRecordNotFound).Once()
+
+	_, err := service.Release(ctx, 3)
+
+	assert.ErrorIs(t, err, customErrors.ErrNotFound)
+}
@@ -0,0 +1,165 @@
+package services_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"pos-api/internal/models"
+	customErrors "pos-api/internal/pkg/errors"
+	"pos-api/internal/services"
+	"pos-api/tests/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type stockTransferMocks struct {
+	transfer *mocks.StockTransferRepository
+	location *mocks.LocationRepository
+	product  *mocks.ProductRepository
+}
+
+func setupStockTransferTest(t *testing.T) (stockTransferMocks, services.StockTransferService) {
+	m := stockTransferMocks{
+		transfer: mocks.NewStockTransferRepository(t),
+		location: mocks.NewLocationRepository(t),
+		product:  mocks.NewProductRepository(t),
+	}
+	return m, services.NewStockTransferService(m.transfer, m.location, m.product)
+}
+
+// expectActiveLocations makes the storeroom (1) and shop floor (2) available.
+func expectActiveLocations(m stockTransferMocks, ctx context.Context) {
+	m.location.On("GetByID", ctx, uint(1)).Return(&models.Location{ID: 1, Code: "GUDANG", IsActive: true}, nil).Once()
+	m.location.On("GetByID", ctx, uint(2)).Return(&models.Location{ID: 2, Code: "TOKO-1", IsActive: true}, nil).Once()
+}
+
+func TestStockTransferService_Create_Success(t *testing.T) {
+	m, service := setupStockTransferTest(t)
+	ctx := context.Background()
+
+	expectActiveLocations(m, ctx)
+	m.product.On("GetProductByID", ctx, uint(1)).Return(&models.Product{ID: 1, Name: "Beras"}, nil).Once()
+	m.product.On("GetProductByID", ctx, uint(2)).Return(&models.Product{ID: 2, Name: "Daging", SoldByWeight: true}, nil).Once()
+
+	var created *models.StockTransfer
+	m.transfer.On("Create", ctx, mock.AnythingOfType("*models.StockTransfer")).Run(func(args mock.Arguments) {
+		created = args.Get(1).(*models.StockTransfer)
+		created.ID = 7
+	}).Return(nil).Once()
+	m.transfer.On("GetByID", ctx, uint(7)).Return(func(context.Context, uint) *models.StockTransfer { return created }, nil).Once()
+
+	transfer, err := service.Create(ctx, services.StockTransferRequest{
+		FromLocationID: 1,
+		ToLocationID:   2,
+		Items: []services.StockTransferItemRequest{
+			{ProductID: 1, Quantity: 10},
+			{ProductID: 2, Quantity: 2.5},
+		},
+	}, 3)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), transfer.FromLocationID)
+	assert.Equal(t, uint(2), transfer.ToLocationID)
+	assert.Equal(t, uint(3), transfer.UserID)
+	assert.Len(t, transfer.Items, 2)
+	assert.Equal(t, 2.5, transfer.Items[1].Quantity)
+}
+
+func TestStockTransferService_Create_SameLocation(t *testing.T) {
+	_, service := setupStockTransferTest(t)
+
+	_, err := service.Create(context.Background(), services.StockTransferRequest{
+		FromLocationID: 1,
+		ToLocationID:   1,
+		Items:          []services.StockTransferItemRequest{{ProductID: 1, Quantity: 1}},
+	}, 1)
+
+	assert.ErrorContains(t, err, "validation failed")
+}
+
+func TestStockTransferService_Create_InactiveLocation(t *testing.T) {
+	m, service := setupStockTransferTest(t)
+	ctx := context.Background()
+
+	m.location.On("GetByID", ctx, uint(1)).Return(&models.Location{ID: 1, Code: "GUDANG", IsActive: true}, nil).Once()
+	m.location.On("GetByID", ctx, uint(2)).Return(&models.Location{ID: 2, Code: "TOKO-1", IsActive: false}, nil).Once()
+
+	_, err := service.Create(ctx, services.StockTransferRequest{
+		FromLocationID: 1,
+		ToLocationID:   2,
+		Items:          []services.StockTransferItemRequest{{ProductID: 1, Quantity: 1}},
+	}, 1)
+
+	assert.ErrorContains(t, err, "TOKO-1 is inactive")
+}
+
+func TestStockTransferService_Create_DuplicateProduct(t *testing.T) {
+	m, service := setupStockTransferTest(t)
+	ctx := context.Background()
+
+	expectActiveLocations(m, ctx)
+	m.product.On("GetProductByID", ctx, uint(1)).Return(&models.Product{ID: 1, Name: "Beras"}, nil).Once()
+
+	_, err := service.Create(ctx, services.StockTransferRequest{
+		FromLocationID: 1,
+		ToLocationID:   2,
+		Items: []services.StockTransferItemRequest{
+			{ProductID: 1, Quantity: 1},
+			{ProductID: 1, Quantity: 2},
+		},
+	}, 1)
+
+	assert.ErrorContains(t, err, "more than once")
+}
+
+func TestStockTransferService_Create_FractionalNotSoldByWeight(t *testing.T) {
+	m, service := setupStockTransferTest(t)
+	ctx := context.Background()
+
+	expectActiveLocations(m, ctx)
+	m.product.On("GetProductByID", ctx, uint(1)).Return(&models.Product{ID: 1, Name: "Beras"}, nil).Once()
+
+	_, err := service.Create(ctx, services.StockTransferRequest{
+		FromLocationID: 1,
+		ToLocationID:   2,
+		Items:          []services.StockTransferItemRequest{{ProductID: 1, Quantity: 1.5}},
+	}, 1)
+
+	assert.ErrorContains(t, err, "whole number")
+}
+
+func TestStockTransferService_Create_SerializedNeedsSerials(t *testing.T) {
+	m, service := setupStockTransferTest(t)
+	ctx := context.Background()
+
+	expectActiveLocations(m, ctx)
+	m.product.On("GetProductByID", ctx, uint(3)).Return(&models.Product{ID: 3, Name: "Powerbank", Serialized: true}, nil).Once()
+
+	_, err := service.Create(ctx, services.StockTransferRequest{
+		FromLocationID: 1,
+		ToLocationID:   2,
+		Items:          []services.StockTransferItemRequest{{ProductID: 3, Quantity: 2}},
+	}, 1)
+
+	assert.ErrorContains(t, err, "one serial number per unit")
+}
+
+func TestStockTransferService_Create_InsufficientStock(t *testing.T) {
+	m, service := setupStockTransferTest(t)
+	ctx := context.Background()
+
+	expectActiveLocations(m, ctx)
+	m.product.On("GetProductByID", ctx, uint(1)).Return(&models.Product{ID: 1, Name: "Beras"}, nil).Once()
+	m.transfer.On("Create", ctx, mock.AnythingOfType("*models.StockTransfer")).
+		Return(fmt.Errorf("Beras: %w at location 1: have 3, need 10", customErrors.ErrInsufficientStock)).Once()
+
+	_, err := service.Create(ctx, services.StockTransferRequest{
+		FromLocationID: 1,
+		ToLocationID:   2,
+		Items:          []services.StockTransferItemRequest{{ProductID: 1, Quantity: 10}},
+	}, 1)
+
+	assert.ErrorIs(t, err, customErrors.ErrInsufficientStock)
+}
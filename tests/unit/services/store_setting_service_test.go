@@ -15,10 +15,16 @@ import (
 
 func setupStoreSettingTest(t *testing.T) (*mocks.StoreSettingRepository, services.StoreSettingService) {
 	mockRepo := mocks.NewStoreSettingRepository(t)
-	service := services.NewStoreSettingService(mockRepo)
+	service := services.NewStoreSettingService(mockRepo, mocks.NewCurrencyRepository(t))
 	return mockRepo, service
 }
 
+func setupStoreSettingCurrencyTest(t *testing.T) (*mocks.StoreSettingRepository, *mocks.CurrencyRepository, services.StoreSettingService) {
+	mockRepo := mocks.NewStoreSettingRepository(t)
+	mockCurrencyRepo := mocks.NewCurrencyRepository(t)
+	return mockRepo, mockCurrencyRepo, services.NewStoreSettingService(mockRepo, mockCurrencyRepo)
+}
+
 // --- GetSettings ---
 
 func TestStoreSettingService_Get_Success(t *testing.T) {
@@ -94,3 +100,110 @@ func TestStoreSettingService_Update_Error(t *testing.T) {
 	assert.Error(t, err)
 	assert.Nil(t, settings)
 }
+
+func TestStoreSettingService_Update_CostingMethodFIFO(t *testing.T) {
+	mockRepo, service := setupStoreSettingTest(t)
+	ctx := context.Background()
+
+	input := &models.StoreSetting{StoreName: "Test", CostingMethod: models.CostingFIFO}
+	mockRepo.On("UpsertSettings", ctx, mock.MatchedBy(func(s *models.StoreSetting) bool {
+		return s.CostingMethod == models.CostingFIFO
+	})).Return(input, nil).Once()
+
+	settings, err := service.UpdateSettings(ctx, input)
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.CostingFIFO, settings.CostingMethod)
+}
+
+func TestStoreSettingService_Update_InvalidCostingMethod(t *testing.T) {
+	mockRepo, service := setupStoreSettingTest(t)
+	ctx := context.Background()
+
+	settings, err := service.UpdateSettings(ctx, &models.StoreSetting{StoreName: "Test", CostingMethod: "lifo"})
+
+	assert.ErrorIs(t, err, services.ErrInvalidCostingMethod)
+	assert.Nil(t, settings)
+	mockRepo.AssertNotCalled(t, "UpsertSettings", mock.Anything, mock.Anything)
+}
+
+func TestStoreSettingService_Update_NegativeStockPolicyWarn(t *testing.T) {
+	mockRepo, service := setupStoreSettingTest(t)
+	ctx := context.Background()
+
+	input := &models.StoreSetting{StoreName: "Test", NegativeStockPolicy: models.NegativeStockWarn}
+	mockRepo.On("UpsertSettings", ctx, mock.MatchedBy(func(s *models.StoreSetting) bool {
+		return s.NegativeStockPolicy == models.NegativeStockWarn
+	})).Return(input, nil).Once()
+
+	settings, err := service.UpdateSettings(ctx, input)
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.NegativeStockWarn, settings.NegativeStockPolicy)
+}
+
+func TestStoreSettingService_Update_InvalidNegativeStockPolicy(t *testing.T) {
+	mockRepo, service := setupStoreSettingTest(t)
+	ctx := context.Background()
+
+	settings, err := service.UpdateSettings(ctx, &models.StoreSetting{StoreName: "Test", NegativeStockPolicy: "ignore"})
+
+	assert.ErrorIs(t, err, services.ErrInvalidNegativeStockPolicy)
+	assert.Nil(t, settings)
+	mockRepo.AssertNotCalled(t, "UpsertSettings", mock.Anything, mock.Anything)
+}
+
+func TestStoreSettingService_Update_BaseCurrency(t *testing.T) {
+	mockRepo, mockCurrencyRepo, service := setupStoreSettingCurrencyTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("GetSettings", ctx).Return(&models.StoreSetting{BaseCurrency: "IDR"}, nil).Once()
+	mockCurrencyRepo.On("Count", ctx).Return(int64(0), nil).Once()
+	mockRepo.On("HasActivity", ctx).Return(false, nil).Once()
+	mockRepo.On("UpsertSettings", ctx, mock.MatchedBy(func(s *models.StoreSetting) bool {
+		return s.BaseCurrency == "SGD"
+	})).Return(&models.StoreSetting{BaseCurrency: "SGD"}, nil).Once()
+
+	settings, err := service.UpdateSettings(ctx, &models.StoreSetting{StoreName: "Test", BaseCurrency: " sgd "})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "SGD", settings.BaseCurrency)
+}
+
+func TestStoreSettingService_Update_BaseCurrencyInUse(t *testing.T) {
+	mockRepo, mockCurrencyRepo, service := setupStoreSettingCurrencyTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("GetSettings", ctx).Return(&models.StoreSetting{BaseCurrency: "IDR"}, nil).Once()
+	mockCurrencyRepo.On("Count", ctx).Return(int64(2), nil).Once()
+
+	settings, err := service.UpdateSettings(ctx, &models.StoreSetting{StoreName: "Test", BaseCurrency: "SGD"})
+
+	assert.ErrorIs(t, err, services.ErrBaseCurrencyInUse)
+	assert.Nil(t, settings)
+	mockRepo.AssertNotCalled(t, "UpsertSettings", mock.Anything, mock.Anything)
+}
+
+func TestStoreSettingService_Update_BaseCurrencyAfterSales(t *testing.T) {
+	mockRepo, mockCurrencyRepo, service := setupStoreSettingCurrencyTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("GetSettings", ctx).Return(&models.StoreSetting{BaseCurrency: "IDR"}, nil).Once()
+	mockCurrencyRepo.On("Count", ctx).Return(int64(0), nil).Once()
+	mockRepo.On("HasActivity", ctx).Return(true, nil).Once()
+
+	settings, err := service.UpdateSettings(ctx, &models.StoreSetting{StoreName: "Test", BaseCurrency: "SGD"})
+
+	assert.ErrorIs(t, err, services.ErrBaseCurrencyInUse)
+	assert.Nil(t, settings)
+	mockRepo.AssertNotCalled(t, "UpsertSettings", mock.Anything, mock.Anything)
+}
+
+func TestStoreSettingService_Update_InvalidBaseCurrency(t *testing.T) {
+	_, service := setupStoreSettingTest(t)
+
+	settings, err := service.UpdateSettings(context.Background(), &models.StoreSetting{StoreName: "Test", BaseCurrency: "Rp"})
+
+	assert.ErrorIs(t, err, services.ErrInvalidBaseCurrency)
+	assert.Nil(t, settings)
+}
@@ -0,0 +1,123 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"pos-api/internal/models"
+	"pos-api/internal/services"
+	"pos-api/tests/mocks"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupPayableTest(t *testing.T) (*mocks.SupplierPayableRepository, services.SupplierPayableService) {
+	mockRepo := mocks.NewSupplierPayableRepository(t)
+	return mockRepo, services.NewSupplierPayableService(mockRepo)
+}
+
+func openPayable() *models.SupplierPayable {
+	poID := uint(3)
+	return &models.SupplierPayable{
+		ID:              1,
+		SupplierID:      2,
+		Supplier:        models.Supplier{ID: 2, Name: "PT Sumber"},
+		PurchaseOrderID: &poID,
+		PurchaseOrder:   &models.PurchaseOrder{ID: 3, PONumber: "PO-3"},
+		Amount:          100000,
+		PaidAmount:      40000,
+		Status:          models.PayablePartial,
+	}
+}
+
+func TestSupplierPayableService_RecordPayment_Success(t *testing.T) {
+	mockRepo, service := setupPayableTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("GetByID", ctx, uint(1)).Return(openPayable(), nil).Twice()
+
+	var cashFlow *models.CashFlow
+	var payment *models.SupplierPayment
+	mockRepo.On("RecordPayment", ctx, mock.AnythingOfType("*models.SupplierPayment"), mock.AnythingOfType("*models.CashFlow")).
+		Run(func(args mock.Arguments) {
+			payment = args.Get(1).(*models.SupplierPayment)
+			cashFlow = args.Get(2).(*models.CashFlow)
+		}).Return(nil).Once()
+
+	_, err := service.RecordPayment(ctx, 1, services.SupplierPaymentRequest{Amount: 60000, Date: "2026-03-01", Reference: "TRF-01"}, 7)
+
+	require.NoError(t, err)
+	assert.Equal(t, 60000.0, payment.Amount)
+	assert.Equal(t, uint(2), payment.SupplierID)
+	assert.Equal(t, "expense", cashFlow.Type)
+	assert.Equal(t, "penambahan_stok", cashFlow.Source)
+	assert.Equal(t, 60000.0, cashFlow.Amount)
+	assert.Equal(t, "2026-03-01", cashFlow.Date.Format("2006-01-02"))
+	assert.Equal(t, uint(3), *cashFlow.PurchaseOrderID)
+	assert.Contains(t, cashFlow.Notes, "PO-3")
+	assert.Equal(t, uint(7), cashFlow.UserID)
+}
+
+func TestSupplierPayableService_RecordPayment_Overpayment(t *testing.T) {
+	mockRepo, service := setupPayableTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("GetByID", ctx, uint(1)).Return(openPayable(), nil).Once()
+
+	_, err := service.RecordPayment(ctx, 1, services.SupplierPaymentRequest{Amount: 60000.01}, 7)
+
+	assert.ErrorIs(t, err, customErrors.ErrOverpayment)
+}
+
+func TestSupplierPayableService_RecordPayment_AlreadyPaid(t *testing.T) {
+	mockRepo, service := setupPayableTest(t)
+	ctx := context.Background()
+
+	payable := openPayable()
+	payable.PaidAmount = payable.Amount
+	payable.Status = models.PayablePaid
+	mockRepo.On("GetByID", ctx, uint(1)).Return(payable, nil).Once()
+
+	_, err := service.RecordPayment(ctx, 1, services.SupplierPaymentRequest{Amount: 1}, 7)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "already paid")
+}
+
+func TestSupplierPayableService_GetAging(t *testing.T) {
+	mockRepo, service := setupPayableTest(t)
+	ctx := context.Background()
+	asOf := time.Date(2026, 6, 30, 15, 0, 0, 0, time.UTC)
+
+	a := models.Supplier{ID: 1, Name: "PT A"}
+	b := models.Supplier{ID: 2, Name: "PT B"}
+	mockRepo.On("GetOutstanding", ctx, uint(0)).Return([]models.SupplierPayable{
+		{SupplierID: 1, Supplier: a, Amount: 1000, DueDate: asOf.AddDate(0, 0, 5)},                     // current
+		{SupplierID: 1, Supplier: a, Amount: 2000, PaidAmount: 500, DueDate: asOf},                     // due today: current
+		{SupplierID: 2, Supplier: b, Amount: 3000, DueDate: asOf.AddDate(0, 0, -10)},                   // 1-30
+		{SupplierID: 2, Supplier: b, Amount: 4000, DueDate: asOf.AddDate(0, 0, -45)},                   // 31-60
+		{SupplierID: 2, Supplier: b, Amount: 5000, DueDate: asOf.AddDate(0, 0, -90)},                   // 61-90
+		{SupplierID: 1, Supplier: a, Amount: 6000, PaidAmount: 1000, DueDate: asOf.AddDate(0, 0, -91)}, // over 90
+	}, nil).Once()
+
+	report, err := service.GetAging(ctx, asOf, 0)
+
+	require.NoError(t, err)
+	require.Len(t, report.Suppliers, 2)
+
+	assert.Equal(t, "PT A", report.Suppliers[0].SupplierName)
+	assert.Equal(t, 2500.0, report.Suppliers[0].Current)
+	assert.Equal(t, 5000.0, report.Suppliers[0].Over90)
+	assert.Equal(t, 7500.0, report.Suppliers[0].Total)
+
+	assert.Equal(t, 3000.0, report.Suppliers[1].Days1To30)
+	assert.Equal(t, 4000.0, report.Suppliers[1].Days31To60)
+	assert.Equal(t, 5000.0, report.Suppliers[1].Days61To90)
+
+	assert.Equal(t, 19500.0, report.Total.Total)
+}
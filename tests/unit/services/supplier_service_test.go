@@ -0,0 +1,82 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"pos-api/internal/models"
+	"pos-api/internal/services"
+	"pos-api/tests/mocks"
+
+	customErrors "pos-api/internal/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func setupSupplierTest(t *testing.T) (*mocks.SupplierRepository, services.SupplierService) {
+	mockRepo := mocks.NewSupplierRepository(t)
+	return mockRepo, services.NewSupplierService(mockRepo)
+}
+
+func TestSupplierService_Create_Success(t *testing.T) {
+	mockRepo, service := setupSupplierTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("Create", ctx, mock.AnythingOfType("*models.Supplier")).Return(nil).Once()
+
+	supplier, err := service.Create(ctx, services.SupplierRequest{Name: "  PT Sumber Makmur ", Phone: "08123"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "PT Sumber Makmur", supplier.Name)
+	assert.True(t, supplier.IsActive)
+}
+
+func TestSupplierService_Create_ValidationError(t *testing.T) {
+	_, service := setupSupplierTest(t)
+
+	_, err := service.Create(context.Background(), services.SupplierRequest{})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "validation failed")
+}
+
+func TestSupplierService_Create_Duplicate(t *testing.T) {
+	mockRepo, service := setupSupplierTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("Create", ctx, mock.AnythingOfType("*models.Supplier")).
+		Return(errors.New(`ERROR: duplicate key value violates unique constraint "uni_suppliers_name"`)).Once()
+
+	_, err := service.Create(ctx, services.SupplierRequest{Name: "PT Sumber Makmur"})
+
+	assert.ErrorIs(t, err, customErrors.ErrConflict)
+}
+
+func TestSupplierService_Update_Deactivate(t *testing.T) {
+	mockRepo, service := setupSupplierTest(t)
+	ctx := context.Background()
+	inactive := false
+
+	mockRepo.On("GetByID", ctx, uint(1)).Return(&models.Supplier{ID: 1, Name: "Old", IsActive: true}, nil).Once()
+	mockRepo.On("Update", ctx, mock.AnythingOfType("*models.Supplier")).Return(nil).Once()
+
+	supplier, err := service.Update(ctx, 1, services.SupplierRequest{Name: "New", IsActive: &inactive})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "New", supplier.Name)
+	assert.False(t, supplier.IsActive)
+}
+
+func TestSupplierService_GetByID_NotFound(t *testing.T) {
+	mockRepo, service := setupSupplierTest(t)
+	ctx := context.Background()
+
+	mockRepo.On("GetByID", ctx, uint(99)).Return(nil, gorm.ErrRecordNotFound).Once()
+
+	_, err := service.GetByID(ctx, 99)
+
+	assert.ErrorIs(t, err, customErrors.ErrNotFound)
+}
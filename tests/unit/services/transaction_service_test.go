@@ -7,18 +7,46 @@ import (
 	"time"
 
 	"pos-api/internal/models"
+	"pos-api/internal/pkg/scale"
 	"pos-api/internal/services"
 	"pos-api/tests/mocks"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
 )
 
 func setupTransactionTest(t *testing.T) (*mocks.TransactionRepository, *mocks.ProductRepository, services.TransactionService) {
+	mockRepo, mockProductRepo, mockLocationRepo, service := setupTransactionLocationTest(t)
+	// Sales without a register deduct from the default location
+	mockLocationRepo.On("GetDefault", mock.Anything).Return(&models.Location{ID: 1, Code: "MAIN", IsDefault: true, IsActive: true}, nil).Maybe()
+	return mockRepo, mockProductRepo, service
+}
+
+func setupTransactionLocationTest(t *testing.T) (*mocks.TransactionRepository, *mocks.ProductRepository, *mocks.LocationRepository, services.TransactionService) {
 	mockRepo := mocks.NewTransactionRepository(t)
 	mockProductRepo := mocks.NewProductRepository(t)
-	service := services.NewTransactionService(mockRepo, mockProductRepo)
-	return mockRepo, mockProductRepo, service
+	mockLocationRepo := mocks.NewLocationRepository(t)
+	patterns, err := scale.ParsePatterns(scale.DefaultPatterns)
+	assert.NoError(t, err)
+	currencies := services.NewCurrencyService(mocks.NewCurrencyRepository(t), mocks.NewStoreSettingRepository(t))
+	service := services.NewTransactionService(mockRepo, mockProductRepo, mockLocationRepo, services.NewBarcodeScanner(mockProductRepo, scale.NewParser(patterns)), currencies)
+	return mockRepo, mockProductRepo, mockLocationRepo, service
+}
+
+func setupTransactionCurrencyTest(t *testing.T) (*mocks.TransactionRepository, *mocks.ProductRepository, *mocks.CurrencyRepository, services.TransactionService) {
+	mockRepo := mocks.NewTransactionRepository(t)
+	mockProductRepo := mocks.NewProductRepository(t)
+	mockLocationRepo := mocks.NewLocationRepository(t)
+	mockCurrencyRepo := mocks.NewCurrencyRepository(t)
+	mockSettingRepo := mocks.NewStoreSettingRepository(t)
+	mockLocationRepo.On("GetDefault", mock.Anything).Return(&models.Location{ID: 1, Code: "MAIN", IsDefault: true, IsActive: true}, nil).Maybe()
+	mockSettingRepo.On("GetSettings", mock.Anything).Return(&models.StoreSetting{BaseCurrency: "IDR"}, nil).Maybe()
+	patterns, err := scale.ParsePatterns(scale.DefaultPatterns)
+	assert.NoError(t, err)
+	currencies := services.NewCurrencyService(mockCurrencyRepo, mockSettingRepo)
+	service := services.NewTransactionService(mockRepo, mockProductRepo, mockLocationRepo, services.NewBarcodeScanner(mockProductRepo, scale.NewParser(patterns)), currencies)
+	return mockRepo, mockProductRepo, mockCurrencyRepo, service
 }
 
 // --- ProcessTransaction ---
@@ -80,6 +108,42 @@ func TestTransactionService_Process_InsufficientPayment(t *testing.T) {
 	assert.Contains(t, err.Error(), "uang tunai kurang")
 }
 
+func TestTransactionService_Process_ForeignCurrencyPayments(t *testing.T) {
+	mockRepo, mockProductRepo, mockCurrencyRepo, service := setupTransactionCurrencyTest(t)
+	ctx := context.Background()
+
+	mockProductRepo.On("GetProductByID", ctx, uint(1)).Return(&models.Product{ID: 1, Price: 200000}, nil)
+	mockCurrencyRepo.On("GetByCode", ctx, "USD").Return(&models.Currency{Code: "USD", Rate: 15850.5, IsActive: true}, nil).Once()
+	// 10 USD + Rp 50.000 = Rp 208.505, kembalian Rp 8.505 dalam rupiah
+	mockRepo.On("ProcessFullTransaction", ctx, mock.MatchedBy(func(trx *models.Transaction) bool {
+		return trx.Cash == 208505 && trx.Change == 8505 && len(trx.Payments) == 2 &&
+			trx.Payments[0].Currency == "USD" && trx.Payments[0].Rate == 15850.5 && trx.Payments[0].BaseAmount == 158505 &&
+			trx.Payments[1].Currency == "IDR" && trx.Payments[1].Rate == 1
+	})).Return(nil).Once()
+	mockRepo.On("GetTransactionByID", ctx, mock.AnythingOfType("uint")).Return(&models.Transaction{ID: 1}, nil).Once()
+
+	_, err := service.ProcessTransaction(ctx, services.TransactionRequest{
+		PaymentMethod: "Cash",
+		Payments:      []services.PaymentRequest{{Currency: "usd", Amount: 10}, {Amount: 50000}},
+		Items:         []services.ItemRequest{{ProductID: 1, Quantity: 1}},
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestTransactionService_Process_CashAndPayments(t *testing.T) {
+	_, _, service := setupTransactionTest(t)
+
+	_, err := service.ProcessTransaction(context.Background(), services.TransactionRequest{
+		PaymentMethod: "Cash",
+		Cash:          50000,
+		Payments:      []services.PaymentRequest{{Currency: "USD", Amount: 10}},
+		Items:         []services.ItemRequest{{ProductID: 1, Quantity: 1}},
+	})
+
+	assert.ErrorContains(t, err, "tidak keduanya")
+}
+
 func TestTransactionService_Process_ProductNotFound(t *testing.T) {
 	_, mockProductRepo, service := setupTransactionTest(t)
 	ctx := context.Background()
@@ -146,6 +210,97 @@ func TestTransactionService_Process_MultipleItems(t *testing.T) {
 	assert.NotNil(t, trx)
 }
 
+func TestTransactionService_Process_DeductsFromRegisterLocation(t *testing.T) {
+	mockRepo, mockProductRepo, mockLocationRepo, service := setupTransactionLocationTest(t)
+	ctx := context.Background()
+
+	registerID := uint(3)
+	mockLocationRepo.On("GetRegisterByID", ctx, registerID).Return(&models.Register{
+		ID: registerID, Code: "KASIR-2", LocationID: 2, IsActive: true,
+	}, nil).Once()
+	mockProductRepo.On("GetProductByID", ctx, uint(1)).Return(&models.Product{
+		ID: 1, Name: "A", Price: 5000, Cost: 3000, Stock: 10,
+	}, nil)
+
+	mockRepo.On("ProcessFullTransaction", ctx, mock.MatchedBy(func(trx *models.Transaction) bool {
+		return trx.LocationID == 2 && trx.RegisterID != nil && *trx.RegisterID == registerID
+	})).Return(nil).Once()
+	mockRepo.On("GetTransactionByID", ctx, mock.AnythingOfType("uint")).Return(&models.Transaction{ID: 1, LocationID: 2}, nil)
+
+	trx, err := service.ProcessTransaction(ctx, services.TransactionRequest{
+		PaymentMethod: "Cash",
+		Cash:          10000,
+		RegisterID:    &registerID,
+		Items:         []services.ItemRequest{{ProductID: 1, Quantity: 1}},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(2), trx.LocationID)
+}
+
+func TestTransactionService_Process_InactiveRegister(t *testing.T) {
+	_, _, mockLocationRepo, service := setupTransactionLocationTest(t)
+	ctx := context.Background()
+
+	registerID := uint(3)
+	mockLocationRepo.On("GetRegisterByID", ctx, registerID).Return(&models.Register{
+		ID: registerID, Code: "KASIR-2", LocationID: 2, IsActive: false,
+	}, nil).Once()
+
+	trx, err := service.ProcessTransaction(ctx, services.TransactionRequest{
+		PaymentMethod: "Cash",
+		Cash:          10000,
+		RegisterID:    &registerID,
+		Items:         []services.ItemRequest{{ProductID: 1, Quantity: 1}},
+	})
+
+	assert.Nil(t, trx)
+	assert.ErrorContains(t, err, "tidak aktif")
+}
+
+func TestTransactionService_Process_SerializedItemNeedsSerials(t *testing.T) {
+	_, mockProductRepo, service := setupTransactionTest(t)
+	ctx := context.Background()
+
+	mockProductRepo.On("GetProductByID", ctx, uint(3)).Return(&models.Product{
+		ID: 3, Name: "Powerbank", Price: 200000, Cost: 150000, Stock: 5, Serialized: true,
+	}, nil)
+
+	_, err := service.ProcessTransaction(ctx, services.TransactionRequest{
+		PaymentMethod: "Cash",
+		Cash:          500000,
+		Items:         []services.ItemRequest{{ProductID: 3, Quantity: 2, SerialNumbers: []string{"PB-001"}}},
+	})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "one serial number per unit")
+}
+
+func TestTransactionService_Process_StoresSerialsAndCustomer(t *testing.T) {
+	mockRepo, mockProductRepo, service := setupTransactionTest(t)
+	ctx := context.Background()
+
+	mockProductRepo.On("GetProductByID", ctx, uint(3)).Return(&models.Product{
+		ID: 3, Name: "Powerbank", Price: 200000, Cost: 150000, Stock: 5, Serialized: true,
+	}, nil)
+	mockRepo.On("ProcessFullTransaction", ctx, mock.MatchedBy(func(trx *models.Transaction) bool {
+		d := trx.TransactionDetails[0]
+		return trx.CustomerName == "Budi" && trx.CustomerPhone == "0812" &&
+			len(d.SerialNumbers) == 2 && d.SerialNumbers[0] == "PB-001" && d.SerialNumbers[1] == "PB-002"
+	})).Return(nil).Once()
+	mockRepo.On("GetTransactionByID", ctx, mock.AnythingOfType("uint")).Return(&models.Transaction{ID: 1}, nil).Once()
+
+	_, err := service.ProcessTransaction(ctx, services.TransactionRequest{
+		PaymentMethod: "Cash",
+		Cash:          400000,
+		CustomerName:  " Budi ",
+		CustomerPhone: "0812",
+		Items:         []services.ItemRequest{{ProductID: 3, Quantity: 2, SerialNumbers: []string{" PB-001", "PB-002 "}}},
+	})
+
+	assert.NoError(t, err)
+}
+
 func TestTransactionService_Process_WithDiscount(t *testing.T) {
 	mockRepo, mockProductRepo, service := setupTransactionTest(t)
 	ctx := context.Background()
@@ -195,6 +350,85 @@ func TestTransactionService_Process_RepositoryError(t *testing.T) {
 	assert.Contains(t, err.Error(), "gagal memproses transaksi")
 }
 
+func TestTransactionService_Process_ScaleLabel(t *testing.T) {
+	mockRepo, mockProductRepo, service := setupTransactionTest(t)
+	ctx := context.Background()
+
+	mockProductRepo.On("GetProductByBarcode", ctx, "2800101012500").Return(nil, gorm.ErrRecordNotFound).Once()
+	mockProductRepo.On("GetProductByPLU", ctx, "101").Return(&models.Product{
+		ID: 7, Name: "Daging Sapi", Price: 150000, Cost: 120000, Stock: 10, SoldByWeight: true,
+	}, nil).Once()
+
+	mockRepo.On("ProcessFullTransaction", ctx, mock.MatchedBy(func(trx *models.Transaction) bool {
+		d := trx.TransactionDetails[0]
+		// 1.25 kg * 150000 = 187500, quantity from the label ignores the client value
+		return trx.TotalAmount == 187500 && d.ProductID == 7 && d.Quantity == 1.25
+	})).Return(nil).Once()
+
+	mockRepo.On("GetTransactionByID", ctx, mock.AnythingOfType("uint")).Return(&models.Transaction{
+		ID:          1,
+		TotalAmount: 187500,
+	}, nil)
+
+	trx, err := service.ProcessTransaction(ctx, services.TransactionRequest{
+		PaymentMethod: "Cash",
+		Cash:          200000,
+		Items:         []services.ItemRequest{{Barcode: "2800101012500", Quantity: 5}},
+	})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, trx)
+}
+
+func TestTransactionService_Process_PriceLabel(t *testing.T) {
+	mockRepo, mockProductRepo, service := setupTransactionTest(t)
+	ctx := context.Background()
+
+	mockProductRepo.On("GetProductByBarcode", ctx, "2901020100009").Return(nil, gorm.ErrRecordNotFound).Once()
+	mockProductRepo.On("GetProductByPLU", ctx, "102").Return(&models.Product{
+		ID: 8, Name: "Apel Fuji", Price: 45000, Cost: 30000, Stock: 10, SoldByWeight: true,
+	}, nil).Once()
+
+	mockRepo.On("ProcessFullTransaction", ctx, mock.MatchedBy(func(trx *models.Transaction) bool {
+		d := trx.TransactionDetails[0]
+		// The label price is the subtotal; the price recorded is what the weight sold for
+		return trx.TotalAmount == 10000 && d.SubTotal == 10000 && d.Quantity == 0.222 && d.PriceAtSale == 45045.05
+	})).Return(nil).Once()
+
+	mockRepo.On("GetTransactionByID", ctx, mock.AnythingOfType("uint")).Return(&models.Transaction{
+		ID:          1,
+		TotalAmount: 10000,
+	}, nil)
+
+	trx, err := service.ProcessTransaction(ctx, services.TransactionRequest{
+		PaymentMethod: "Cash",
+		Cash:          10000,
+		Items:         []services.ItemRequest{{Barcode: "2901020100009"}},
+	})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, trx)
+}
+
+func TestTransactionService_Process_FractionalQuantityNotSoldByWeight(t *testing.T) {
+	_, mockProductRepo, service := setupTransactionTest(t)
+	ctx := context.Background()
+
+	mockProductRepo.On("GetProductByID", ctx, uint(1)).Return(&models.Product{
+		ID: 1, Name: "Beras 5kg", Price: 70000, Stock: 10,
+	}, nil)
+
+	trx, err := service.ProcessTransaction(ctx, services.TransactionRequest{
+		PaymentMethod: "Cash",
+		Cash:          200000,
+		Items:         []services.ItemRequest{{ProductID: 1, Quantity: 1.5}},
+	})
+
+	assert.Error(t, err)
+	assert.Nil(t, trx)
+	assert.Contains(t, err.Error(), "bilangan bulat")
+}
+
 // --- GetTransaction ---
 
 func TestTransactionService_Get_Success(t *testing.T) {
@@ -232,9 +466,9 @@ func TestTransactionService_List_Success(t *testing.T) {
 		{ID: 1, TotalAmount: 10000},
 		{ID: 2, TotalAmount: 20000},
 	}
-	mockRepo.On("ListTransactions", ctx, 1, 10).Return(transactions, int64(2), nil).Once()
+	mockRepo.On("ListTransactions", ctx, 1, 10, "", "", "").Return(transactions, int64(2), nil).Once()
 
-	result, err := service.ListTransactions(ctx, 1, 10)
+	result, err := service.ListTransactions(ctx, 1, 10, "", "", "")
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
@@ -245,10 +479,10 @@ func TestTransactionService_List_DefaultsPagination(t *testing.T) {
 	mockRepo, _, service := setupTransactionTest(t)
 	ctx := context.Background()
 
-	mockRepo.On("ListTransactions", ctx, 1, 10).Return([]models.Transaction{}, int64(0), nil).Once()
+	mockRepo.On("ListTransactions", ctx, 1, 10, "", "", "").Return([]models.Transaction{}, int64(0), nil).Once()
 
 	// page=0 and limit=0 should default to 1 and 10
-	result, err := service.ListTransactions(ctx, 0, 0)
+	result, err := service.ListTransactions(ctx, 0, 0, "", "", "")
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
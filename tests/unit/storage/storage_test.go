@@ -0,0 +1,169 @@
+package storage_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"pos-api/internal/pkg/storage"
+)
+
+// roundTrip stores, reads back and deletes a file through s.
+func roundTrip(t *testing.T, s storage.Storage, key string) {
+	t.Helper()
+	ctx := context.Background()
+
+	if err := s.Put(ctx, key, strings.NewReader("receipt"), 7, "image/png"); err != nil {
+		t.Fatalf("put: %v", err)
+	}
+	r, err := s.Open(ctx, key)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	content, _ := io.ReadAll(r)
+	r.Close()
+	if string(content) != "receipt" {
+		t.Fatalf("expected stored content back, got %q", content)
+	}
+
+	if err := s.Delete(ctx, key); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if _, err := s.Open(ctx, key); !errors.Is(err, storage.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound after delete, got %v", err)
+	}
+	if err := s.Delete(ctx, key); err != nil {
+		t.Fatalf("expected deleting a missing file to succeed, got %v", err)
+	}
+}
+
+func TestLocalStorage_RoundTrip(t *testing.T) {
+	s, err := storage.NewLocalStorage(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	roundTrip(t, s, "cash_flow/1/abc.png")
+}
+
+func TestLocalStorage_KeysStayInsideDirectory(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "files")
+	s, err := storage.NewLocalStorage(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Put(context.Background(), "../escaped.txt", strings.NewReader("x"), 1, ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "escaped.txt")); !os.IsNotExist(err) {
+		t.Fatal("expected the key not to climb out of the storage directory")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "escaped.txt")); err != nil {
+		t.Fatalf("expected the file inside the storage directory: %v", err)
+	}
+}
+
+func TestLocalStorage_ShortContent(t *testing.T) {
+	s, err := storage.NewLocalStorage(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Put(context.Background(), "a.png", strings.NewReader("abc"), 10, ""); err == nil {
+		t.Fatal("expected an error when the content is shorter than its size")
+	}
+	if _, err := s.Open(context.Background(), "a.png"); !errors.Is(err, storage.ErrNotFound) {
+		t.Fatalf("expected no file to be left behind, got %v", err)
+	}
+}
+
+// fakeS3 is an in-memory bucket checking that requests are signed.
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string]string
+	t       *testing.T
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKID/") ||
+		!strings.Contains(auth, "/ap-southeast-1/s3/aws4_request") ||
+		!strings.Contains(auth, "SignedHeaders=host;x-amz-content-sha256;x-amz-date") ||
+		r.Header.Get("X-Amz-Date") == "" {
+		f.t.Errorf("unsigned request: %q", auth)
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		f.objects[r.URL.Path] = string(body)
+	case http.MethodGet:
+		body, ok := f.objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		io.WriteString(w, body)
+	case http.MethodDelete:
+		delete(f.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func TestS3Storage_RoundTrip(t *testing.T) {
+	bucket := &fakeS3{objects: map[string]string{}, t: t}
+	server := httptest.NewServer(bucket)
+	defer server.Close()
+
+	s, err := storage.NewS3Storage(storage.S3Config{
+		Endpoint:  server.URL,
+		Region:    "ap-southeast-1",
+		Bucket:    "receipts",
+		AccessKey: "AKID",
+		SecretKey: "secret",
+		PathStyle: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Put(context.Background(), "cash_flow/1/abc.png", strings.NewReader("receipt"), 7, "image/png"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := bucket.objects["/receipts/cash_flow/1/abc.png"]; !ok {
+		t.Fatalf("expected a path-style object, got %v", bucket.objects)
+	}
+	roundTrip(t, s, "cash_flow/1/abc.png")
+}
+
+func TestS3Storage_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		io.WriteString(w, "<Error><Code>AccessDenied</Code></Error>")
+	}))
+	defer server.Close()
+
+	s, err := storage.NewS3Storage(storage.S3Config{Endpoint: server.URL, Bucket: "receipts", PathStyle: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.Put(context.Background(), "a.png", strings.NewReader("x"), 1, "")
+	if err == nil || !strings.Contains(err.Error(), "AccessDenied") {
+		t.Fatalf("expected the S3 error to be reported, got %v", err)
+	}
+
+	if _, err := storage.NewS3Storage(storage.S3Config{Endpoint: "localhost:9000", Bucket: "receipts"}); err == nil {
+		t.Fatal("expected an endpoint without scheme to be rejected")
+	}
+}